@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/bench"
+)
+
+func runBench(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	rt := addRuntimeFlags(fs)
+	iterations := fs.Int("iterations", 100, "measured operations per benchmark case")
+	warmup := fs.Int("warmup", 10, "unmeasured warmup runs (negative disables)")
+	threads := fs.Int("threads", 0, "intra-op threads per session (0 uses the ORT default)")
+	interThreads := fs.Int("inter-threads", 0, "inter-op threads per session (0 uses the ORT default)")
+	providers := fs.String("providers", "", "comma-separated execution providers in preference order, e.g. CUDAExecutionProvider")
+	poolSize := fs.Int("pool-size", 4, "number of sessions in the pooled case")
+	concurrency := fs.Int("concurrency", 8, "number of goroutines in the concurrent cases")
+	dims := fs.String("dims", "", "symbolic input dimensions, e.g. batch_size=8,sequence_length=128")
+	asJSON := fs.Bool("json", false, "print the full report as JSON")
+	path, err := parseModelArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	symbolicDims, err := parseDims(*dims)
+	if err != nil {
+		return err
+	}
+
+	options := &ort.SessionOptions{
+		IntraOpNumThreads: *threads,
+		InterOpNumThreads: *interThreads,
+		GraphOptimization: ort.GraphOptimizationAll,
+	}
+	for _, name := range strings.Split(*providers, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			options.ExecutionProviders = append(options.ExecutionProviders, ort.ExecutionProvider{Name: name})
+		}
+	}
+
+	runtime, env, err := rt.open("onnxer")
+	if err != nil {
+		return err
+	}
+	defer runtime.Close()
+	defer env.Close()
+
+	report, err := bench.Run(ctx, runtime, env, []string{path}, bench.Config{
+		Iterations:     *iterations,
+		Warmup:         *warmup,
+		PoolSize:       *poolSize,
+		Concurrency:    *concurrency,
+		Dims:           symbolicDims,
+		SessionOptions: options,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return report.WriteJSON(os.Stdout)
+	}
+
+	fmt.Printf("ONNX Runtime %s, %s/%s, %d CPUs\n\n", report.ORTVersion, report.GOOS, report.GOARCH, report.NumCPU)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "case\tconcurrency\tp50\tp95\tp99\tops/s\tallocs/op\t\n")
+	for _, r := range report.Models[0].Results {
+		fmt.Fprintf(w, "%s\t%d\t%v\t%v\t%v\t%.1f\t%d\t\n", r.Name, r.Concurrency,
+			time.Duration(r.P50Ns), time.Duration(r.P95Ns), time.Duration(r.P99Ns), r.OpsPerSec, r.AllocsPerOp)
+	}
+	return w.Flush()
+}
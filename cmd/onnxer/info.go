@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/modelinfo"
+)
+
+func runInfo(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("info", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	path, err := parseModelArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	info, err := modelinfo.InspectModel(path)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+	printInfo(path, info)
+	return nil
+}
+
+func printInfo(path string, info *modelinfo.Info) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "Model:\t%s\n", path)
+	fmt.Fprintf(w, "IR version:\t%d\n", info.IRVersion)
+	if info.ProducerName != "" {
+		fmt.Fprintf(w, "Producer:\t%s %s\n", info.ProducerName, info.ProducerVersion)
+	}
+	if info.ModelVersion != 0 {
+		fmt.Fprintf(w, "Model version:\t%d\n", info.ModelVersion)
+	}
+
+	opsets := make([]string, len(info.Opsets))
+	for i, op := range info.Opsets {
+		domain := op.Domain
+		if domain == "" {
+			domain = "ai.onnx"
+		}
+		opsets[i] = fmt.Sprintf("%s %d", domain, op.Version)
+	}
+	fmt.Fprintf(w, "Opsets:\t%s\n", strings.Join(opsets, ", "))
+
+	if len(info.Metadata) > 0 {
+		keys := make([]string, 0, len(info.Metadata))
+		for k := range info.Metadata {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		fmt.Fprintf(w, "Metadata:\t\n")
+		for _, k := range keys {
+			fmt.Fprintf(w, "  %s\t%s\n", k, info.Metadata[k])
+		}
+	}
+
+	printValues(w, "Inputs", info.Inputs)
+	printValues(w, "Outputs", info.Outputs)
+	if len(info.OverridableInitializers) > 0 {
+		printValues(w, "Overridable initializers", info.OverridableInitializers)
+	}
+
+	fmt.Fprintf(w, "Initializers:\t%d (%s", info.Initializers, formatBytes(info.InitializerBytes))
+	if info.ExternalInitializers > 0 {
+		fmt.Fprintf(w, ", %d external", info.ExternalInitializers)
+	}
+	fmt.Fprintf(w, ")\n")
+
+	fmt.Fprintf(w, "Operators:\t%d nodes, %d distinct\n", info.Nodes, len(info.Operators))
+	for _, op := range info.Operators {
+		fmt.Fprintf(w, "  %s\t%d\n", op, op.Count)
+	}
+}
+
+func printValues(w *tabwriter.Writer, title string, values []modelinfo.Value) {
+	fmt.Fprintf(w, "%s:\t\n", title)
+	for _, v := range values {
+		fmt.Fprintf(w, "  %s\t%s\n", v.Name, v.TypeString())
+	}
+}
@@ -0,0 +1,161 @@
+// Command onnxer inspects, benchmarks, and runs ONNX models from the command
+// line.
+//
+// Usage:
+//
+//	onnxer info [-json] <model.onnx>
+//	onnxer bench [flags] <model.onnx>
+//	onnxer run [flags] <model.onnx>
+//
+// The info subcommand reads the model in pure Go and does not need the
+// ONNX Runtime library. The bench and run subcommands load the library from
+// -lib, defaulting to $ONNXRUNTIME_LIB_PATH and then the system search path.
+//
+// Examples:
+//
+//	onnxer info resnet50.onnx
+//	onnxer bench -threads 4 -providers CUDAExecutionProvider -iterations 500 resnet50.onnx
+//	onnxer run -input test_data_set_0/input_0.pb -o out resnet50.onnx
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{"info", "print model metadata, inputs, outputs, and operators", runInfo},
+	{"bench", "measure latency and throughput", runBench},
+	{"run", "run the model once and print or save its outputs", runRun},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: onnxer <command> [flags] <model.onnx>\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-6s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun 'onnxer <command> -h' for command flags.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+		if err := c.run(ctx, os.Args[2:]); err != nil {
+			if err != flag.ErrHelp {
+				fmt.Fprintf(os.Stderr, "onnxer %s: %v\n", c.name, err)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+
+	if os.Args[1] != "-h" && os.Args[1] != "help" {
+		fmt.Fprintf(os.Stderr, "onnxer: unknown command %q\n\n", os.Args[1])
+	}
+	usage()
+	os.Exit(2)
+}
+
+// parseModelArgs parses fs from args, allowing flags both before and after
+// the model path, and returns the single model path.
+func parseModelArgs(fs *flag.FlagSet, args []string) (string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return "", err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return "", fmt.Errorf("expected one model path, got %d arguments", len(positional))
+	}
+	return positional[0], nil
+}
+
+// runtimeFlags are the flags shared by subcommands that load the library.
+type runtimeFlags struct {
+	lib        *string
+	apiVersion *uint
+}
+
+func addRuntimeFlags(fs *flag.FlagSet) runtimeFlags {
+	return runtimeFlags{
+		lib:        fs.String("lib", os.Getenv("ONNXRUNTIME_LIB_PATH"), "path to the ONNX Runtime shared library"),
+		apiVersion: fs.Uint("api-version", 23, "ONNX Runtime C API version (falls back to the highest the library provides)"),
+	}
+}
+
+func (f runtimeFlags) open(logID string) (*ort.Runtime, *ort.Env, error) {
+	runtime, err := ort.NewRuntime(*f.lib, uint32(*f.apiVersion))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create runtime: %w", err)
+	}
+	env, err := runtime.NewEnv(logID, ort.LoggingLevelWarning)
+	if err != nil {
+		runtime.Close()
+		return nil, nil, fmt.Errorf("failed to create environment: %w", err)
+	}
+	return runtime, env, nil
+}
+
+// parseDims parses "name=value,name=value" into a map.
+func parseDims(s string) (map[string]int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	result := make(map[string]int64)
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid dimension %q, expected name=value", pair)
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid size for dimension %q: %q", name, value)
+		}
+		result[strings.TrimSpace(name)] = n
+	}
+	return result, nil
+}
+
+// formatBytes formats n as a human-readable size.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/bench"
+	"github.com/benedoc-inc/onnxer/onnxruntime/modelinfo"
+)
+
+// stringList is a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func runRun(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	rt := addRuntimeFlags(fs)
+	var inputFiles stringList
+	fs.Var(&inputFiles, "input", "input TensorProto file as [name=]file.pb; repeatable. Unnamed files map to the name stored in the file, "+
+		"else to model inputs in order. Missing inputs are synthesized")
+	dims := fs.String("dims", "", "symbolic dimensions for synthesized inputs, e.g. batch_size=8")
+	outDir := fs.String("o", "", "write each output to <dir>/output_<i>.pb")
+	limit := fs.Int("n", 10, "maximum number of values to print per output (0 prints none)")
+	threads := fs.Int("threads", 0, "intra-op threads (0 uses the ORT default)")
+	providers := fs.String("providers", "", "comma-separated execution providers in preference order")
+	path, err := parseModelArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	symbolicDims, err := parseDims(*dims)
+	if err != nil {
+		return err
+	}
+
+	runtime, env, err := rt.open("onnxer")
+	if err != nil {
+		return err
+	}
+	defer runtime.Close()
+	defer env.Close()
+
+	options := &ort.SessionOptions{IntraOpNumThreads: *threads, GraphOptimization: ort.GraphOptimizationAll}
+	for _, name := range strings.Split(*providers, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			options.ExecutionProviders = append(options.ExecutionProviders, ort.ExecutionProvider{Name: name})
+		}
+	}
+	session, err := runtime.NewSession(env, path, options)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	inputs, err := bench.SynthesizeInputs(runtime, session, symbolicDims)
+	if err != nil {
+		return err
+	}
+	defer closeValues(inputs)
+	if err := loadInputs(runtime, session.InputNames(), inputFiles, inputs); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	outputs, err := session.Run(ctx, inputs)
+	if err != nil {
+		return err
+	}
+	defer closeValues(outputs)
+	fmt.Printf("Ran in %v\n", time.Since(start).Round(time.Microsecond))
+
+	if *outDir != "" {
+		if err := os.MkdirAll(*outDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	for i, name := range session.OutputNames() {
+		v := outputs[name]
+		fmt.Printf("\n%s: %s\n", name, describeTensor(v, *limit))
+		if *outDir == "" {
+			continue
+		}
+		data, err := ort.MarshalTensorProto(v, name)
+		if err != nil {
+			return fmt.Errorf("failed to serialize output %q: %w", name, err)
+		}
+		file := filepath.Join(*outDir, fmt.Sprintf("output_%d.pb", i))
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write output %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// loadInputs reads each [name=]file.pb spec and replaces the synthesized
+// input of that name.
+func loadInputs(runtime *ort.Runtime, inputNames []string, specs []string, inputs map[string]*ort.Value) error {
+	for i, spec := range specs {
+		name, file, ok := strings.Cut(spec, "=")
+		if !ok {
+			name, file = "", spec
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		v, protoName, err := ort.NewTensorValueFromProto(runtime, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		if name == "" {
+			name = protoName
+		}
+		if name == "" && i < len(inputNames) {
+			name = inputNames[i]
+		}
+		old, ok := inputs[name]
+		if !ok {
+			v.Close()
+			return fmt.Errorf("%s: model has no input %q (inputs: %s)", file, name, strings.Join(inputNames, ", "))
+		}
+		old.Close()
+		inputs[name] = v
+	}
+	return nil
+}
+
+// describeTensor formats a tensor's type, shape, and up to limit values.
+func describeTensor(v *ort.Value, limit int) string {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return "(not a tensor)"
+	}
+	shape, _ := v.GetTensorShape()
+	desc := modelinfo.Value{
+		Type:       ort.ONNXTypeTensor,
+		TensorInfo: &ort.TensorTypeInfo{ElementType: elemType, Shape: shape},
+	}
+	s := desc.TypeString()
+	if limit <= 0 {
+		return s
+	}
+
+	values, err := formatValues(v, elemType)
+	if err != nil {
+		return s + " (" + err.Error() + ")"
+	}
+	if len(values) > limit {
+		values = append(values[:limit], fmt.Sprintf("... (%d more)", len(values)-limit))
+	}
+	return s + "\n  [" + strings.Join(values, " ") + "]"
+}
+
+func formatValues(v *ort.Value, elemType ort.ONNXTensorElementDataType) ([]string, error) {
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeFloat, ort.ONNXTensorElementDataTypeFloat16, ort.ONNXTensorElementDataTypeBFloat16:
+		data, _, err := ort.GetTensorDataAsFloat32(v)
+		return formatSlice(data), err
+	case ort.ONNXTensorElementDataTypeDouble:
+		return formatTensor[float64](v)
+	case ort.ONNXTensorElementDataTypeInt64:
+		return formatTensor[int64](v)
+	case ort.ONNXTensorElementDataTypeInt32:
+		return formatTensor[int32](v)
+	case ort.ONNXTensorElementDataTypeInt16:
+		return formatTensor[int16](v)
+	case ort.ONNXTensorElementDataTypeInt8:
+		return formatTensor[int8](v)
+	case ort.ONNXTensorElementDataTypeUint8:
+		return formatTensor[uint8](v)
+	case ort.ONNXTensorElementDataTypeBool:
+		return formatTensor[bool](v)
+	case ort.ONNXTensorElementDataTypeString:
+		data, _, err := ort.GetStringTensorData(v)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]string, len(data))
+		for i, s := range data {
+			out[i] = strconv.Quote(s)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("values of this type are not printed")
+	}
+}
+
+func formatTensor[T ort.TensorData](v *ort.Value) ([]string, error) {
+	data, _, err := ort.GetTensorData[T](v)
+	if err != nil {
+		return nil, err
+	}
+	return formatSlice(data), nil
+}
+
+func formatSlice[T any](data []T) []string {
+	out := make([]string, len(data))
+	for i, x := range data {
+		out[i] = fmt.Sprint(x)
+	}
+	return out
+}
+
+func closeValues(values map[string]*ort.Value) {
+	for _, v := range values {
+		v.Close()
+	}
+}
@@ -10,13 +10,12 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 	"log"
-	"math"
 	"os"
-	"sort"
 	"strings"
 
-	"github.com/nfnt/resize"
 	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/imagetensor"
+	"github.com/benedoc-inc/onnxer/onnxruntime/postprocess"
 )
 
 var (
@@ -26,11 +25,6 @@ var (
 //go:embed imagenet_classes.txt
 var imagenetClassesData string
 
-var (
-	imagenetMean = []float32{0.485, 0.456, 0.406}
-	imagenetStd  = []float32{0.229, 0.224, 0.225}
-)
-
 // imagenetClasses contains the 1000 ImageNet class labels, loaded from embedded file
 var imagenetClasses []string
 
@@ -52,95 +46,27 @@ func getImageNetClassName(classID int) string {
 	return imagenetClasses[classID]
 }
 
-// preprocessImage loads and preprocesses an image for ResNet
-func preprocessImage(imagePath string) ([]float32, []int64, error) {
-	// Open image file
+// loadImage decodes the image at imagePath
+func loadImage(imagePath string) (image.Image, error) {
 	file, err := os.Open(imagePath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to open image: %w", err)
+		return nil, fmt.Errorf("failed to open image: %w", err)
 	}
 	defer file.Close()
 
-	// Decode image
 	img, _, err := image.Decode(file)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to decode image: %w", err)
-	}
-
-	// Resize to 224x224
-	resized := resize.Resize(224, 224, img, resize.Bilinear)
-
-	// Convert to float32 tensor with shape [1, 3, 224, 224]
-	// and normalize using ImageNet mean and std
-	data := make([]float32, 1*3*224*224)
-
-	for y := range 224 {
-		for x := range 224 {
-			r, g, b, _ := resized.At(x, y).RGBA()
-
-			// RGBA() returns 16-bit color values (0-65535)
-			// Convert to 8-bit (0-255) then to 0-1 range
-			rVal := float32(r>>8) / 255.0
-			gVal := float32(g>>8) / 255.0
-			bVal := float32(b>>8) / 255.0
-
-			// Apply ImageNet normalization: (value - mean) / std
-			rNorm := (rVal - imagenetMean[0]) / imagenetStd[0]
-			gNorm := (gVal - imagenetMean[1]) / imagenetStd[1]
-			bNorm := (bVal - imagenetMean[2]) / imagenetStd[2]
-
-			// Store in CHW format (channels, height, width)
-			idx := y*224 + x
-			data[0*224*224+idx] = rNorm // R channel
-			data[1*224*224+idx] = gNorm // G channel
-			data[2*224*224+idx] = bNorm // B channel
-		}
-	}
-
-	return data, []int64{1, 3, 224, 224}, nil
-}
-
-// softmax applies softmax function to convert logits to probabilities
-func softmax(logits []float32) []float32 {
-	probs := make([]float32, len(logits))
-
-	// Find max for numerical stability
-	maxLogit := logits[0]
-	for _, v := range logits {
-		if v > maxLogit {
-			maxLogit = v
-		}
-	}
-
-	// Compute exp(logit - max) and sum
-	sum := float32(0.0)
-	for i, v := range logits {
-		x := float64(v - maxLogit)
-		exp := float32(math.Exp(x))
-		probs[i] = exp
-		sum += exp
-	}
-
-	// Normalize
-	for i := range probs {
-		probs[i] /= sum
+		return nil, fmt.Errorf("failed to decode image: %w", err)
 	}
-
-	return probs
-}
-
-// ClassScore represents a class prediction with its score
-type ClassScore struct {
-	ClassID int
-	Score   float32
+	return img, nil
 }
 
 func run(ctx context.Context, modelPath, imagePath string) error {
-	// Load and preprocess image
+	// Load image
 	fmt.Printf("Loading image: %s\n", imagePath)
-	inputData, inputShape, err := preprocessImage(imagePath)
+	img, err := loadImage(imagePath)
 	if err != nil {
-		return fmt.Errorf("failed to preprocess image: %w", err)
+		return err
 	}
 
 	libraryPath := os.Getenv("ONNXRUNTIME_LIB_PATH")
@@ -185,8 +111,14 @@ func run(ctx context.Context, modelPath, imagePath string) error {
 	fmt.Printf("Input names: %v\n", inputNames)
 	fmt.Printf("Output names: %v\n", outputNames)
 
-	// Create input tensor
-	inputTensor, err := ort.NewTensorValue(runtime, inputData, inputShape)
+	// Resize to 224x224 and normalize with ImageNet mean and std into a
+	// [1, 3, 224, 224] tensor
+	inputTensor, err := imagetensor.FromImage(runtime, img, &imagetensor.InputOptions{
+		Width:  224,
+		Height: 224,
+		Mean:   imagetensor.ImageNetMean,
+		Std:    imagetensor.ImageNetStd,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create input tensor: %w", err)
 	}
@@ -204,37 +136,27 @@ func run(ctx context.Context, modelPath, imagePath string) error {
 	output := outputs[outputNames[0]]
 	defer output.Close()
 
-	logits, _, err := ort.GetTensorData[float32](output)
+	// Apply softmax to get probabilities
+	probs, _, err := postprocess.Softmax(output, -1)
 	if err != nil {
-		return fmt.Errorf("failed to get output tensor data: %w", err)
+		return fmt.Errorf("failed to compute probabilities: %w", err)
 	}
 
-	// Apply softmax to get probabilities
-	probs := softmax(logits)
-
 	// Get top 10 predictions
-	scores := make([]ClassScore, len(probs))
-	for i, prob := range probs {
-		scores[i] = ClassScore{
-			ClassID: i,
-			Score:   prob,
-		}
+	top, _, _, err := postprocess.TopK(output, 10, -1)
+	if err != nil {
+		return fmt.Errorf("failed to get top predictions: %w", err)
 	}
 
-	// Sort by score descending
-	sort.Slice(scores, func(i, j int) bool {
-		return scores[i].Score > scores[j].Score
-	})
-
 	// Display top 10
 	fmt.Println("\nImage Classification")
-	for i := 0; i < 10 && i < len(scores); i++ {
-		className := getImageNetClassName(scores[i].ClassID)
+	for i, classID := range top {
+		className := getImageNetClassName(int(classID))
 		fmt.Printf("%2d. %-30s (ID: %4d) - %.2f%%\n",
 			i+1,
 			className,
-			scores[i].ClassID,
-			scores[i].Score*100)
+			classID,
+			probs[classID]*100)
 	}
 
 	return nil
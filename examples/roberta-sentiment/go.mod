@@ -1,6 +1,6 @@
 module github.com/benedoc-inc/onnxer/examples/roberta-sentiment
 
-go 1.25
+go 1.25.0
 
 require (
 	github.com/benedoc-inc/onnxer v0.0.0
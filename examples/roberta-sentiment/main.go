@@ -6,10 +6,11 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"math"
 	"os"
 
 	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/pipelines"
+	"github.com/sugarme/tokenizer"
 	"github.com/sugarme/tokenizer/pretrained"
 )
 
@@ -22,30 +23,34 @@ var (
 // Sentiment labels for twitter-xlm-roberta-base-sentiment
 var sentimentLabels = []string{"negative", "neutral", "positive"}
 
-// softmax converts logits to probabilities
-func softmax(logits []float32) []float32 {
-	maxLogit := float32(-math.MaxFloat32)
-	for _, v := range logits {
-		if v > maxLogit {
-			maxLogit = v
-		}
-	}
+// robertaPadID is the <pad> token ID in the RoBERTa vocabulary.
+const robertaPadID = 1
 
-	var sum float32
-	probs := make([]float32, len(logits))
-	for i, v := range logits {
-		probs[i] = float32(math.Exp(float64(v - maxLogit)))
-		sum += probs[i]
-	}
+// hfTokenizer adapts a sugarme/tokenizer Tokenizer to pipelines.Tokenizer.
+type hfTokenizer struct {
+	tk *tokenizer.Tokenizer
+}
 
-	for i := range probs {
-		probs[i] /= sum
+func (t hfTokenizer) Encode(text string) (pipelines.Encoding, error) {
+	encoding, err := t.tk.EncodeSingle(text)
+	if err != nil {
+		return pipelines.Encoding{}, err
 	}
+	return pipelines.Encoding{
+		IDs:           toInt64(encoding.GetIds()),
+		AttentionMask: toInt64(encoding.GetAttentionMask()),
+	}, nil
+}
 
-	return probs
+func toInt64(values []int) []int64 {
+	out := make([]int64, len(values))
+	for i, v := range values {
+		out[i] = int64(v)
+	}
+	return out
 }
 
-func run(ctx context.Context, modelPath, tokenizerPath, text string, maxLength int) error {
+func run(ctx context.Context, modelPath, tokenizerPath string, texts []string, maxLength int) error {
 	// Load tokenizer
 	fmt.Printf("Loading tokenizer: %s\n", tokenizerPath)
 	tk, err := pretrained.FromFile(tokenizerPath)
@@ -53,157 +58,44 @@ func run(ctx context.Context, modelPath, tokenizerPath, text string, maxLength i
 		return fmt.Errorf("failed to load tokenizer: %w", err)
 	}
 
-	// Tokenize input text
-	fmt.Printf("Processing text: \"%s\"\n", text)
-	encoding, err := tk.EncodeSingle(text)
-	if err != nil {
-		return fmt.Errorf("failed to tokenize text: %w", err)
-	}
-
-	// Get token IDs
-	inputIds := encoding.GetIds()
-	attentionMask := encoding.GetAttentionMask()
-
-	// Pad or truncate to max_length
-	if len(inputIds) > maxLength {
-		inputIds = inputIds[:maxLength]
-		attentionMask = attentionMask[:maxLength]
-	}
-
-	// Pad if needed
-	for len(inputIds) < maxLength {
-		inputIds = append(inputIds, 1) // PAD token ID for RoBERTa
-		attentionMask = append(attentionMask, 0)
-	}
-
-	// Convert to int64
-	inputIds64 := make([]int64, len(inputIds))
-	attentionMask64 := make([]int64, len(attentionMask))
-
-	for i := range inputIds {
-		inputIds64[i] = int64(inputIds[i])
-		attentionMask64[i] = int64(attentionMask[i])
-	}
-
-	fmt.Printf("Input IDs (first 10): %v...\n", inputIds64[:min(10, len(inputIds64))])
-
-	// Initialize ONNX Runtime
 	libraryPath := os.Getenv("ONNXRUNTIME_LIB_PATH")
 	if libraryPath == "" {
 		return errors.New("ONNXRUNTIME_LIB_PATH environment variable not set")
 	}
 
-	// Create runtime
-	runtime, err := ort.NewRuntime(libraryPath, 23)
-	if err != nil {
-		return fmt.Errorf("failed to create runtime: %w", err)
-	}
-	defer runtime.Close()
-
-	// Create environment
-	env, err := runtime.NewEnv("roberta-sentiment-example", ort.LoggingLevelWarning)
-	if err != nil {
-		return fmt.Errorf("failed to create environment: %w", err)
-	}
-	defer env.Close()
-
-	// Open model file
+	// Load model (runtime, environment and session in one step)
 	fmt.Printf("Loading model: %s\n", modelPath)
-	modelFile, err := os.Open(modelPath)
-	if err != nil {
-		return fmt.Errorf("failed to open model file: %w", err)
-	}
-	defer modelFile.Close()
-
-	// Create session
-	session, err := runtime.NewSessionFromReader(env, modelFile, &ort.SessionOptions{
-		IntraOpNumThreads: 1,
+	model, err := ort.LoadModelFromFile(modelPath, &ort.ModelConfig{
+		LibraryPath:    libraryPath,
+		SessionOptions: &ort.SessionOptions{IntraOpNumThreads: 1},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create session: %w", err)
-	}
-	defer session.Close()
-
-	inputNames := session.InputNames()
-	outputNames := session.OutputNames()
-
-	fmt.Printf("Input names: %v\n", inputNames)
-	fmt.Printf("Output names: %v\n", outputNames)
-
-	// Create input tensors
-	batchSize := int64(1)
-	seqLength := int64(maxLength)
-
-	inputIdsTensor, err := ort.NewTensorValue(runtime, inputIds64, []int64{batchSize, seqLength})
-	if err != nil {
-		return fmt.Errorf("failed to create input_ids tensor: %w", err)
+		return fmt.Errorf("failed to load model: %w", err)
 	}
-	defer inputIdsTensor.Close()
+	defer model.Close()
 
-	attentionMaskTensor, err := ort.NewTensorValue(runtime, attentionMask64, []int64{batchSize, seqLength})
+	// The pipeline handles truncation, padding, batching and softmax
+	classifier, err := pipelines.NewTextClassifier(model, hfTokenizer{tk}, sentimentLabels,
+		pipelines.WithMaxLength(maxLength),
+		pipelines.WithPadID(robertaPadID),
+	)
 	if err != nil {
-		return fmt.Errorf("failed to create attention_mask tensor: %w", err)
-	}
-	defer attentionMaskTensor.Close()
-
-	// Prepare inputs - map to model's expected input names
-	inputs := make(map[string]*ort.Value)
-	for _, name := range inputNames {
-		switch name {
-		case "input_ids":
-			inputs[name] = inputIdsTensor
-		case "attention_mask":
-			inputs[name] = attentionMaskTensor
-		}
+		return fmt.Errorf("failed to create classifier: %w", err)
 	}
 
-	// Run inference
-	outputs, err := session.Run(ctx, inputs)
+	predictions, err := classifier.Classify(ctx, texts)
 	if err != nil {
-		return fmt.Errorf("failed to run inference: %w", err)
+		return fmt.Errorf("failed to classify: %w", err)
 	}
 
-	// Process outputs
-	output := outputs["logits"]
-	defer output.Close()
-
-	// Get the output logits (float32)
-	logits, shape, err := ort.GetTensorData[float32](output)
-	if err != nil {
-		return fmt.Errorf("failed to get output tensor data: %w", err)
-	}
-
-	// For sentiment classification, the output is typically [batch_size, num_labels]
-	if len(shape) == 2 && shape[0] == 1 {
-		numLabels := int(shape[1])
-		if numLabels != len(sentimentLabels) {
-			fmt.Printf("  Warning: Expected %d labels but got %d\n", len(sentimentLabels), numLabels)
-		}
-
-		// Get logits for the first (and only) batch item
-		batchLogits := logits[:numLabels]
-
-		// Apply softmax to get probabilities
-		probs := softmax(batchLogits)
-
-		// Find the prediction with highest probability
-		maxProb := float32(0)
-		maxIdx := 0
-		for i, prob := range probs {
-			if prob > maxProb {
-				maxProb = prob
-				maxIdx = i
-			}
-		}
-
-		fmt.Println("\nSentiment Analysis")
-		fmt.Printf("Text: \"%s\"\n", text)
-		fmt.Printf("Predicted Sentiment: %s (%.2f%% confidence)\n",
-			sentimentLabels[maxIdx], maxProb*100)
+	fmt.Println("\nSentiment Analysis")
+	for i, p := range predictions {
+		fmt.Printf("\nText: \"%s\"\n", texts[i])
+		fmt.Printf("Predicted Sentiment: %s (%.2f%% confidence)\n", p.Label, p.Score*100)
 
 		fmt.Println("All Scores:")
-		for i, label := range sentimentLabels {
-			fmt.Printf("  %s: %.2f%%\n", label, probs[i]*100)
+		for j, label := range sentimentLabels {
+			fmt.Printf("  %s: %.2f%%\n", label, p.Scores[j]*100)
 		}
 	}
 
@@ -220,17 +112,15 @@ func main() {
 	}
 
 	if flag.NArg() < 1 {
-		fmt.Fprintf(os.Stderr, "Usage: %s -f <model_path> -tokenizer <tokenizer.json> \"<text>\"\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s -f <model_path> -tokenizer <tokenizer.json> \"<text>\" [\"<text>\" ...]\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Example: %s -f model.onnx -tokenizer tokenizer.json \"I love this movie!\"\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
 
-	text := flag.Arg(0)
-
 	ctx := context.Background()
-	if err := run(ctx, *modelPath, *tokenizerPath, text, *maxLength); err != nil {
+	if err := run(ctx, *modelPath, *tokenizerPath, flag.Args(), *maxLength); err != nil {
 		log.Fatalf("Error: %v", err)
 	}
 }
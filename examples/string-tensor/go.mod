@@ -1,9 +1,13 @@
 module github.com/benedoc-inc/onnxer/examples/string-tensor
 
-go 1.25
+go 1.25.0
 
 replace github.com/benedoc-inc/onnxer => ../..
 
 require github.com/benedoc-inc/onnxer v0.0.0-00010101000000-000000000000
 
-require github.com/ebitengine/purego v0.9.0 // indirect
+require (
+	github.com/ebitengine/purego v0.9.0 // indirect
+	github.com/fsnotify/fsnotify v1.9.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
@@ -6,10 +6,10 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"math"
 	"os"
 
 	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/postprocess"
 )
 
 var (
@@ -99,12 +99,18 @@ func run(ctx context.Context, modelPath string, texts []string) error {
 		fmt.Printf("\nOutput shape: %v\n", outShape)
 		fmt.Println("\nResults:")
 		if len(outShape) == 2 {
+			probs, _, err := postprocess.Softmax(output, -1)
+			if err != nil {
+				return fmt.Errorf("failed to compute probabilities: %w", err)
+			}
+			classes, _, err := postprocess.Argmax(output, -1)
+			if err != nil {
+				return fmt.Errorf("failed to get predicted classes: %w", err)
+			}
 			numClasses := int(outShape[1])
 			for i, text := range texts {
-				scores := data[i*numClasses : (i+1)*numClasses]
-				probs := softmax(scores)
-				maxIdx := argmax(probs)
-				fmt.Printf("  %q -> class %d (%.2f%%)\n", text, maxIdx, probs[maxIdx]*100)
+				class := int(classes[i])
+				fmt.Printf("  %q -> class %d (%.2f%%)\n", text, class, probs[i*numClasses+class]*100)
 			}
 		} else {
 			fmt.Printf("  Raw output: %v\n", data)
@@ -116,35 +122,6 @@ func run(ctx context.Context, modelPath string, texts []string) error {
 	return nil
 }
 
-func softmax(logits []float32) []float32 {
-	maxVal := logits[0]
-	for _, v := range logits[1:] {
-		if v > maxVal {
-			maxVal = v
-		}
-	}
-	var sum float32
-	probs := make([]float32, len(logits))
-	for i, v := range logits {
-		probs[i] = float32(math.Exp(float64(v - maxVal)))
-		sum += probs[i]
-	}
-	for i := range probs {
-		probs[i] /= sum
-	}
-	return probs
-}
-
-func argmax(vals []float32) int {
-	maxIdx := 0
-	for i, v := range vals {
-		if v > vals[maxIdx] {
-			maxIdx = i
-		}
-	}
-	return maxIdx
-}
-
 func main() {
 	flag.Parse()
 
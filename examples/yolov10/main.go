@@ -17,6 +17,7 @@ import (
 	"github.com/fogleman/gg"
 	"github.com/nfnt/resize"
 	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/postprocess"
 )
 
 var (
@@ -103,68 +104,42 @@ func preprocessImage(imagePath string) ([]float32, image.Image, error) {
 // YOLOv10 output shape: [1, 300, 6]
 // where each detection is [xmin, ymin, xmax, ymax, score, class_id]
 // YOLOv10 has NMS built-in, so no need for post-processing NMS.
-func parseYOLOv10Output(output []float32, shape []int64, confThreshold float64, originalImg image.Image) []Detection {
-	if len(shape) != 3 {
-		fmt.Printf("Unexpected output shape: %v\n", shape)
-		return nil
+func parseYOLOv10Output(output *ort.Value, confThreshold float64, originalImg image.Image) ([]Detection, error) {
+	decoded, err := postprocess.Decode(output, &postprocess.DecodeOptions{
+		Format:          postprocess.BoxXYXY,
+		ScoreAndClassID: true,
+		ScoreThreshold:  float32(confThreshold),
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	numDetections := int(shape[1])
-	featuresPerDetection := int(shape[2])
-
-	if featuresPerDetection != 6 {
-		fmt.Printf("Expected 6 features per detection, got %d\n", featuresPerDetection)
-		return nil
-	}
+	// Scale coordinates back to original image size
+	scaleX := float32(originalImg.Bounds().Dx()) / inputWidth
+	scaleY := float32(originalImg.Bounds().Dy()) / inputHeight
 
 	var detections []Detection
-
-	origWidth := float32(originalImg.Bounds().Dx())
-	origHeight := float32(originalImg.Bounds().Dy())
-	scaleX := origWidth / inputWidth
-	scaleY := origHeight / inputHeight
-
-	for i := range numDetections {
-		// YOLOv10 format: [xmin, ymin, xmax, ymax, score, class_id]
-		offset := i * featuresPerDetection
-
-		xmin := output[offset+0]
-		ymin := output[offset+1]
-		xmax := output[offset+2]
-		ymax := output[offset+3]
-		score := output[offset+4]
-		classID := int(output[offset+5])
-
-		// Filter by confidence threshold
-		if float64(score) < confThreshold {
-			continue
-		}
-
-		// Scale coordinates back to original image size
-		x1 := xmin * scaleX
-		y1 := ymin * scaleY
-		x2 := xmax * scaleX
-		y2 := ymax * scaleY
-
+	for _, d := range decoded[0] {
 		className := "unknown"
-		if classID >= 0 && classID < len(cocoClasses) {
-			className = cocoClasses[classID]
+		if d.ClassID >= 0 && d.ClassID < len(cocoClasses) {
+			className = cocoClasses[d.ClassID]
 		}
 
+		box := d.Box.Scale(scaleX, scaleY)
 		detections = append(detections, Detection{
-			ClassID:    classID,
+			ClassID:    d.ClassID,
 			ClassName:  className,
-			Confidence: score,
+			Confidence: d.Score,
 			Box: BoundingBox{
-				X1: x1,
-				Y1: y1,
-				X2: x2,
-				Y2: y2,
+				X1: box.X1,
+				Y1: box.Y1,
+				X2: box.X2,
+				Y2: box.Y2,
 			},
 		})
 	}
 
-	return detections
+	return detections, nil
 }
 
 // drawDetections draws bounding boxes on the image
@@ -329,15 +304,18 @@ func run(ctx context.Context, modelPath, imagePath, outputFile string, confThres
 	output := outputs[outputNames[0]]
 	defer output.Close()
 
-	outputData, shape, err := ort.GetTensorData[float32](output)
+	shape, err := output.GetTensorShape()
 	if err != nil {
-		return fmt.Errorf("failed to get output data: %w", err)
+		return fmt.Errorf("failed to get output shape: %w", err)
 	}
 
 	fmt.Printf("Output shape: %v\n", shape)
 
 	// Parse detections
-	detections := parseYOLOv10Output(outputData, shape, confThreshold, originalImg)
+	detections, err := parseYOLOv10Output(output, confThreshold, originalImg)
+	if err != nil {
+		return fmt.Errorf("failed to parse detections: %w", err)
+	}
 	fmt.Printf("Found %d detections\n", len(detections))
 
 	// Display results
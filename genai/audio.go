@@ -2,6 +2,7 @@ package genai
 
 import (
 	"fmt"
+	"runtime"
 
 	"github.com/benedoc-inc/onnxer/genai/internal/api"
 )
@@ -18,7 +19,7 @@ func (r *Runtime) LoadAudio(audioPath string) (*Audios, error) {
 
 	var audiosPtr api.OgaAudios
 	result := r.funcs.LoadAudio(&pathBytes[0], &audiosPtr)
-	if err := resultError(r.funcs, result); err != nil {
+	if err := resultError(r.funcs, result, "LoadAudio"); err != nil {
 		return nil, fmt.Errorf("failed to load audio: %w", err)
 	}
 
@@ -42,7 +43,33 @@ func (r *Runtime) LoadAudios(audioPaths []string) (*Audios, error) {
 
 	var audiosPtr api.OgaAudios
 	result := r.funcs.LoadAudios(pathsArray.ptr, &audiosPtr)
-	if err := resultError(r.funcs, result); err != nil {
+	if err := resultError(r.funcs, result, "LoadAudios"); err != nil {
+		return nil, fmt.Errorf("failed to load audios: %w", err)
+	}
+
+	return &Audios{
+		ptr:     audiosPtr,
+		runtime: r,
+	}, nil
+}
+
+// LoadAudiosFromBytes loads audio from encoded file contents, such as WAV
+// or MP3 uploads, without writing them to disk. The data is copied, so the
+// buffers may be reused once it returns.
+func (r *Runtime) LoadAudiosFromBytes(data [][]byte) (*Audios, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no audio data provided")
+	}
+
+	buffers, sizes, err := bufferPointers(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audio data: %w", err)
+	}
+
+	var audiosPtr api.OgaAudios
+	result := r.funcs.LoadAudiosFromBuffers(&buffers[0], &sizes[0], uintptr(len(data)), &audiosPtr)
+	runtime.KeepAlive(data)
+	if err := resultError(r.funcs, result, "LoadAudiosFromBuffers"); err != nil {
 		return nil, fmt.Errorf("failed to load audios: %w", err)
 	}
 
@@ -0,0 +1,16 @@
+package genai
+
+import (
+	"testing"
+)
+
+func TestLoadAudiosFromBytesInvalid(t *testing.T) {
+	rt := newTestRuntime(t)
+
+	if _, err := rt.LoadAudiosFromBytes(nil); err == nil {
+		t.Error("LoadAudiosFromBytes(nil) succeeded, want error")
+	}
+	if _, err := rt.LoadAudiosFromBytes([][]byte{{}}); err == nil {
+		t.Error("LoadAudiosFromBytes() with an empty buffer succeeded, want error")
+	}
+}
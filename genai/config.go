@@ -0,0 +1,129 @@
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/benedoc-inc/onnxer/genai/internal/api"
+)
+
+// Config holds a model's genai_config.json so it can be adjusted before the
+// model is created with NewModelFromConfig.
+//
+// Example:
+//
+//	config, _ := rt.NewConfig(modelPath)
+//	defer config.Close()
+//	config.SetProviders("cuda")
+//	config.SetSearchOptions(genai.GeneratorParams{"max_length": 2048, "do_sample": true})
+//	model, _ := rt.NewModelFromConfig(config)
+type Config struct {
+	ptr     api.OgaConfig
+	runtime *Runtime
+}
+
+// NewConfig loads the configuration of the model in the specified directory.
+func (r *Runtime) NewConfig(modelPath string) (*Config, error) {
+	pathBytes := stringToBytes(modelPath)
+
+	var configPtr api.OgaConfig
+	result := r.funcs.CreateConfig(&pathBytes[0], &configPtr)
+	if err := resultError(r.funcs, result, "CreateConfig"); err != nil {
+		return nil, fmt.Errorf("failed to create config: %w", err)
+	}
+
+	return &Config{
+		ptr:     configPtr,
+		runtime: r,
+	}, nil
+}
+
+// Close releases resources associated with the config.
+func (c *Config) Close() {
+	if c.ptr != 0 {
+		c.runtime.funcs.DestroyConfig(c.ptr)
+		c.ptr = 0
+	}
+}
+
+// SetProviders replaces the execution providers of the config with
+// providers, in order of preference. With no providers the model runs on CPU.
+func (c *Config) SetProviders(providers ...string) error {
+	result := c.runtime.funcs.ConfigClearProviders(c.ptr)
+	if err := resultError(c.runtime.funcs, result, "ConfigClearProviders"); err != nil {
+		return fmt.Errorf("failed to clear providers: %w", err)
+	}
+
+	for _, provider := range providers {
+		providerBytes := stringToBytes(provider)
+		result = c.runtime.funcs.ConfigAppendProvider(c.ptr, &providerBytes[0])
+		if err := resultError(c.runtime.funcs, result, "ConfigAppendProvider"); err != nil {
+			return fmt.Errorf("failed to append provider %q: %w", provider, err)
+		}
+	}
+	return nil
+}
+
+// SetProviderOptions sets options of an execution provider, such as
+// "device_id" for "cuda".
+func (c *Config) SetProviderOptions(provider string, options ProviderOptions) error {
+	providerBytes := stringToBytes(provider)
+	for key, value := range options {
+		keyBytes := stringToBytes(key)
+		valueBytes := stringToBytes(value)
+		result := c.runtime.funcs.ConfigSetProviderOption(c.ptr, &providerBytes[0], &keyBytes[0], &valueBytes[0])
+		if err := resultError(c.runtime.funcs, result, "ConfigSetProviderOption"); err != nil {
+			return fmt.Errorf("failed to set provider option %q=%q for %q: %w", key, value, provider, err)
+		}
+	}
+	return nil
+}
+
+// SetSearchOptions overrides entries of the "search" section of the config,
+// which are the defaults of every generator created from the model. Keys
+// are the GeneratorParams names, such as "max_length" and "do_sample".
+func (c *Config) SetSearchOptions(options GeneratorParams) error {
+	if len(options) == 0 {
+		return nil
+	}
+	overlay, err := json.Marshal(map[string]any{"search": options})
+	if err != nil {
+		return fmt.Errorf("failed to encode search options: %w", err)
+	}
+	return c.Overlay(string(overlay))
+}
+
+// SetMaxLength overrides the maximum number of tokens, prompt included, of
+// the sequences generated by the model.
+func (c *Config) SetMaxLength(maxLength int) error {
+	return c.SetSearchOptions(GeneratorParams{"max_length": maxLength})
+}
+
+// Overlay merges a JSON document into the config, overriding the entries it
+// sets. It is the escape hatch for settings without a dedicated method, for
+// example:
+//
+//	config.Overlay(`{"model": {"context_length": 4096}}`)
+func (c *Config) Overlay(overlay string) error {
+	overlayBytes := stringToBytes(overlay)
+	result := c.runtime.funcs.ConfigOverlay(c.ptr, &overlayBytes[0])
+	if err := resultError(c.runtime.funcs, result, "ConfigOverlay"); err != nil {
+		return fmt.Errorf("failed to overlay config: %w", err)
+	}
+	return nil
+}
+
+// NewModelFromConfig creates a model from config. The config may be closed
+// or reused once the model is created.
+func (r *Runtime) NewModelFromConfig(config *Config) (*Model, error) {
+	var modelPtr api.OgaModel
+	result := r.funcs.CreateModelFromConfig(config.ptr, &modelPtr)
+	if err := resultError(r.funcs, result, "CreateModelFromConfig"); err != nil {
+		return nil, fmt.Errorf("failed to create model from config: %w", err)
+	}
+
+	return &Model{
+		ptr:     modelPtr,
+		runtime: r,
+	}, nil
+}
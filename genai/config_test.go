@@ -0,0 +1,86 @@
+package genai
+
+import (
+	"testing"
+)
+
+func TestNewModelFromConfig(t *testing.T) {
+	if !isModelAvailable() {
+		t.Skip("Test model not available. Set ONNXRUNTIME_GENAI_MODEL_PATH environment variable.")
+	}
+
+	rt := newTestRuntime(t)
+
+	config, err := rt.NewConfig(testModelPath)
+	if err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	defer config.Close()
+
+	if err := config.SetProviders(); err != nil {
+		t.Fatalf("Failed to set providers: %v", err)
+	}
+	if err := config.SetMaxLength(16); err != nil {
+		t.Fatalf("Failed to set max length: %v", err)
+	}
+	if err := config.SetSearchOptions(GeneratorParams{"do_sample": false}); err != nil {
+		t.Fatalf("Failed to set search options: %v", err)
+	}
+
+	model, err := rt.NewModelFromConfig(config)
+	if err != nil {
+		t.Fatalf("Failed to create model from config: %v", err)
+	}
+	defer model.Close()
+
+	// The config is no longer needed once the model exists
+	config.Close()
+	config.Close()
+
+	tokenizer := newTestTokenizer(t, model)
+	tokens, err := tokenizer.Encode("Hello")
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+
+	generator, err := model.NewGenerator(nil)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	defer generator.Close()
+
+	if err := generator.AppendTokens(tokens); err != nil {
+		t.Fatalf("Failed to append tokens: %v", err)
+	}
+	for !generator.IsDone() {
+		if err := generator.GenerateNextToken(); err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+	}
+
+	sequence, err := generator.GetSequence(0)
+	if err != nil {
+		t.Fatalf("Failed to get sequence: %v", err)
+	}
+	if len(sequence) > 16 {
+		t.Errorf("Generated %d tokens, want at most the overridden max length 16", len(sequence))
+	}
+}
+
+func TestConfigOverlayInvalid(t *testing.T) {
+	if !isModelAvailable() {
+		t.Skip("Test model not available. Set ONNXRUNTIME_GENAI_MODEL_PATH environment variable.")
+	}
+
+	rt := newTestRuntime(t)
+
+	config, err := rt.NewConfig(testModelPath)
+	if err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	defer config.Close()
+
+	if err := config.Overlay("{not json"); err == nil {
+		t.Error("Overlay() with invalid JSON succeeded, want error")
+	}
+}
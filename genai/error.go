@@ -5,23 +5,55 @@ import (
 
 	"github.com/benedoc-inc/onnxer/genai/internal/api"
 	"github.com/benedoc-inc/onnxer/internal/cstrings"
+	"github.com/benedoc-inc/onnxer/nativeerror"
 )
 
-// resultError checks if an OgaResult indicates an error and returns a formatted error.
-func resultError(funcs *api.Funcs, result api.OgaResult) error {
-	if result == 0 {
-		return nil
+// Error represents an error returned from the ONNX Runtime GenAI C API.
+// It implements nativeerror.Error; GenAI reports no error codes, so
+// ErrorCode returns nativeerror.CodeUnknown.
+type Error struct {
+	// Op is the C API function that failed, such as "CreateModel".
+	Op string
+
+	Message string
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Op == "":
+		return fmt.Sprintf("onnxruntime genai: %s", e.Message)
+	case e.Message == "":
+		return fmt.Sprintf("onnxruntime genai error in %s", e.Op)
+	default:
+		return fmt.Sprintf("onnxruntime genai error in %s: %s", e.Op, e.Message)
 	}
+}
+
+// Library returns nativeerror.LibraryGenAI.
+func (e *Error) Library() string { return nativeerror.LibraryGenAI }
 
-	msgPtr := funcs.ResultGetError(result)
-	if msgPtr == nil {
-		funcs.DestroyResult(result)
-		return fmt.Errorf("onnxruntime genai error")
+// ErrorCode returns nativeerror.CodeUnknown.
+func (e *Error) ErrorCode() int { return nativeerror.CodeUnknown }
+
+// ErrorOp returns e.Op.
+func (e *Error) ErrorOp() string { return e.Op }
+
+// ErrorMessage returns e.Message.
+func (e *Error) ErrorMessage() string { return e.Message }
+
+// resultError converts an OgaResult to a Go error. op names the C API
+// function that returned the result.
+func resultError(funcs *api.Funcs, result api.OgaResult, op string) error {
+	if result == 0 {
+		return nil
 	}
 
 	// Convert C string to Go string
-	msg := cstrings.CStringToString(msgPtr)
+	message := cstrings.CStringToString(funcs.ResultGetError(result))
 	funcs.DestroyResult(result)
 
-	return fmt.Errorf("onnxruntime genai: %s", msg)
+	return &Error{
+		Op:      op,
+		Message: message,
+	}
 }
@@ -0,0 +1,26 @@
+package genai
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/benedoc-inc/onnxer/nativeerror"
+)
+
+func TestError(t *testing.T) {
+	err := fmt.Errorf("failed to create model: %w", &Error{Op: "CreateModel", Message: "config.json not found"})
+
+	if got, want := err.Error(), "failed to create model: onnxruntime genai error in CreateModel: config.json not found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	var nerr nativeerror.Error
+	if !errors.As(err, &nerr) {
+		t.Fatal("errors.As(nativeerror.Error) = false")
+	}
+	if nerr.Library() != nativeerror.LibraryGenAI || nerr.ErrorCode() != nativeerror.CodeUnknown ||
+		nerr.ErrorOp() != "CreateModel" || nerr.ErrorMessage() != "config.json not found" {
+		t.Errorf("nativeerror.Error = %q %d %q %q", nerr.Library(), nerr.ErrorCode(), nerr.ErrorOp(), nerr.ErrorMessage())
+	}
+}
@@ -35,7 +35,7 @@ func (g *Generator) AppendTokens(tokens []int32) error {
 	}
 
 	result := g.runtime.funcs.GeneratorAppendTokens(g.ptr, &tokens[0], uintptr(len(tokens)))
-	if err := resultError(g.runtime.funcs, result); err != nil {
+	if err := resultError(g.runtime.funcs, result, "GeneratorAppendTokens"); err != nil {
 		return fmt.Errorf("failed to append tokens: %w", err)
 	}
 	return nil
@@ -44,7 +44,7 @@ func (g *Generator) AppendTokens(tokens []int32) error {
 // SetInputs sets the named tensors as inputs for the generator.
 func (g *Generator) SetInputs(inputs *NamedTensors) error {
 	result := g.runtime.funcs.GeneratorSetInputs(g.ptr, inputs.ptr)
-	if err := resultError(g.runtime.funcs, result); err != nil {
+	if err := resultError(g.runtime.funcs, result, "GeneratorSetInputs"); err != nil {
 		return fmt.Errorf("failed to set inputs: %w", err)
 	}
 	return nil
@@ -53,7 +53,7 @@ func (g *Generator) SetInputs(inputs *NamedTensors) error {
 // GenerateNextToken generates the next token in the sequence.
 func (g *Generator) GenerateNextToken() error {
 	result := g.runtime.funcs.GeneratorGenerateNextToken(g.ptr)
-	if err := resultError(g.runtime.funcs, result); err != nil {
+	if err := resultError(g.runtime.funcs, result, "GeneratorGenerateNextToken"); err != nil {
 		return fmt.Errorf("failed to generate next token: %w", err)
 	}
 	return nil
@@ -88,7 +88,7 @@ func (g *Generator) GetNextTokens() ([]int32, error) {
 	var count uintptr
 
 	result := g.runtime.funcs.GeneratorGetNextTokens(g.ptr, &tokensPtr, &count)
-	if err := resultError(g.runtime.funcs, result); err != nil {
+	if err := resultError(g.runtime.funcs, result, "GeneratorGetNextTokens"); err != nil {
 		return nil, fmt.Errorf("failed to get next tokens: %w", err)
 	}
 
@@ -2,6 +2,8 @@ package genai
 
 import (
 	"fmt"
+	"runtime"
+	"unsafe"
 
 	"github.com/benedoc-inc/onnxer/genai/internal/api"
 )
@@ -18,7 +20,7 @@ func (r *Runtime) LoadImage(imagePath string) (*Images, error) {
 
 	var imagesPtr api.OgaImages
 	result := r.funcs.LoadImage(&pathBytes[0], &imagesPtr)
-	if err := resultError(r.funcs, result); err != nil {
+	if err := resultError(r.funcs, result, "LoadImage"); err != nil {
 		return nil, fmt.Errorf("failed to load image: %w", err)
 	}
 
@@ -42,7 +44,7 @@ func (r *Runtime) LoadImages(imagePaths []string) (*Images, error) {
 
 	var imagesPtr api.OgaImages
 	result := r.funcs.LoadImages(pathsArray.ptr, &imagesPtr)
-	if err := resultError(r.funcs, result); err != nil {
+	if err := resultError(r.funcs, result, "LoadImages"); err != nil {
 		return nil, fmt.Errorf("failed to load images: %w", err)
 	}
 
@@ -52,6 +54,48 @@ func (r *Runtime) LoadImages(imagePaths []string) (*Images, error) {
 	}, nil
 }
 
+// LoadImagesFromBytes loads images from encoded file contents, such as PNG
+// or JPEG uploads, without writing them to disk. The data is copied, so the
+// buffers may be reused once it returns.
+func (r *Runtime) LoadImagesFromBytes(data [][]byte) (*Images, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no image data provided")
+	}
+
+	buffers, sizes, err := bufferPointers(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image data: %w", err)
+	}
+
+	var imagesPtr api.OgaImages
+	result := r.funcs.LoadImagesFromBuffers(&buffers[0], &sizes[0], uintptr(len(data)), &imagesPtr)
+	runtime.KeepAlive(data)
+	if err := resultError(r.funcs, result, "LoadImagesFromBuffers"); err != nil {
+		return nil, fmt.Errorf("failed to load images: %w", err)
+	}
+
+	return &Images{
+		ptr:     imagesPtr,
+		runtime: r,
+	}, nil
+}
+
+// bufferPointers returns the addresses and sizes of data, as the
+// OgaLoad*FromBuffers functions take them. The caller must keep data alive
+// until the call returns.
+func bufferPointers(data [][]byte) (buffers, sizes []uintptr, err error) {
+	buffers = make([]uintptr, len(data))
+	sizes = make([]uintptr, len(data))
+	for i, b := range data {
+		if len(b) == 0 {
+			return nil, nil, fmt.Errorf("buffer %d is empty", i)
+		}
+		buffers[i] = uintptr(unsafe.Pointer(&b[0]))
+		sizes[i] = uintptr(len(b))
+	}
+	return buffers, sizes, nil
+}
+
 // Close releases resources associated with the images.
 func (i *Images) Close() {
 	if i.ptr != 0 {
@@ -0,0 +1,19 @@
+package genai
+
+import (
+	"testing"
+)
+
+func TestLoadImagesFromBytesInvalid(t *testing.T) {
+	rt := newTestRuntime(t)
+
+	if _, err := rt.LoadImagesFromBytes(nil); err == nil {
+		t.Error("LoadImagesFromBytes(nil) succeeded, want error")
+	}
+	if _, err := rt.LoadImagesFromBytes([][]byte{{0x89, 'P', 'N', 'G'}, {}}); err == nil {
+		t.Error("LoadImagesFromBytes() with an empty buffer succeeded, want error")
+	}
+	if _, err := rt.LoadImagesFromBytes([][]byte{[]byte("not an image")}); err == nil {
+		t.Error("LoadImagesFromBytes() with undecodable data succeeded, want error")
+	}
+}
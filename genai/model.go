@@ -24,7 +24,7 @@ func (m *Model) Close() {
 func (m *Model) NewTokenizer() (*Tokenizer, error) {
 	var tokenizerPtr api.OgaTokenizer
 	result := m.runtime.funcs.CreateTokenizer(m.ptr, &tokenizerPtr)
-	if err := resultError(m.runtime.funcs, result); err != nil {
+	if err := resultError(m.runtime.funcs, result, "CreateTokenizer"); err != nil {
 		return nil, fmt.Errorf("failed to create tokenizer: %w", err)
 	}
 
@@ -66,7 +66,7 @@ func (m *Model) NewGenerator(params GeneratorParams) (*Generator, error) {
 	// Create C generator params
 	var cParamsPtr api.OgaGeneratorParams
 	result := m.runtime.funcs.CreateGeneratorParams(m.ptr, &cParamsPtr)
-	if err := resultError(m.runtime.funcs, result); err != nil {
+	if err := resultError(m.runtime.funcs, result, "CreateGeneratorParams"); err != nil {
 		return nil, fmt.Errorf("failed to create generator params: %w", err)
 	}
 	defer m.runtime.funcs.DestroyGeneratorParams(cParamsPtr)
@@ -81,7 +81,7 @@ func (m *Model) NewGenerator(params GeneratorParams) (*Generator, error) {
 	// Create generator
 	var generatorPtr api.OgaGenerator
 	result = m.runtime.funcs.CreateGenerator(m.ptr, cParamsPtr, &generatorPtr)
-	if err := resultError(m.runtime.funcs, result); err != nil {
+	if err := resultError(m.runtime.funcs, result, "CreateGenerator"); err != nil {
 		return nil, fmt.Errorf("failed to create generator: %w", err)
 	}
 
@@ -98,32 +98,32 @@ func (m *Model) applyGeneratorParams(cParams api.OgaGeneratorParams, params Gene
 		switch v := value.(type) {
 		case bool:
 			result := m.runtime.funcs.GeneratorParamsSetSearchBool(cParams, &nameBytes[0], v)
-			if err := resultError(m.runtime.funcs, result); err != nil {
+			if err := resultError(m.runtime.funcs, result, "GeneratorParamsSetSearchBool"); err != nil {
 				return fmt.Errorf("failed to set %q: %w", name, err)
 			}
 		case int:
 			result := m.runtime.funcs.GeneratorParamsSetSearchNumber(cParams, &nameBytes[0], float64(v))
-			if err := resultError(m.runtime.funcs, result); err != nil {
+			if err := resultError(m.runtime.funcs, result, "GeneratorParamsSetSearchNumber"); err != nil {
 				return fmt.Errorf("failed to set %q: %w", name, err)
 			}
 		case int32:
 			result := m.runtime.funcs.GeneratorParamsSetSearchNumber(cParams, &nameBytes[0], float64(v))
-			if err := resultError(m.runtime.funcs, result); err != nil {
+			if err := resultError(m.runtime.funcs, result, "GeneratorParamsSetSearchNumber"); err != nil {
 				return fmt.Errorf("failed to set %q: %w", name, err)
 			}
 		case int64:
 			result := m.runtime.funcs.GeneratorParamsSetSearchNumber(cParams, &nameBytes[0], float64(v))
-			if err := resultError(m.runtime.funcs, result); err != nil {
+			if err := resultError(m.runtime.funcs, result, "GeneratorParamsSetSearchNumber"); err != nil {
 				return fmt.Errorf("failed to set %q: %w", name, err)
 			}
 		case float32:
 			result := m.runtime.funcs.GeneratorParamsSetSearchNumber(cParams, &nameBytes[0], float64(v))
-			if err := resultError(m.runtime.funcs, result); err != nil {
+			if err := resultError(m.runtime.funcs, result, "GeneratorParamsSetSearchNumber"); err != nil {
 				return fmt.Errorf("failed to set %q: %w", name, err)
 			}
 		case float64:
 			result := m.runtime.funcs.GeneratorParamsSetSearchNumber(cParams, &nameBytes[0], v)
-			if err := resultError(m.runtime.funcs, result); err != nil {
+			if err := resultError(m.runtime.funcs, result, "GeneratorParamsSetSearchNumber"); err != nil {
 				return fmt.Errorf("failed to set %q: %w", name, err)
 			}
 		default:
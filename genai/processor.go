@@ -17,7 +17,7 @@ type MultiModalProcessor struct {
 func (m *Model) NewMultiModalProcessor() (*MultiModalProcessor, error) {
 	var processorPtr api.OgaMultiModalProcessor
 	result := m.runtime.funcs.CreateMultiModalProcessor(m.ptr, &processorPtr)
-	if err := resultError(m.runtime.funcs, result); err != nil {
+	if err := resultError(m.runtime.funcs, result, "CreateMultiModalProcessor"); err != nil {
 		return nil, fmt.Errorf("failed to create multi-modal processor: %w", err)
 	}
 
@@ -55,7 +55,7 @@ func (p *MultiModalProcessor) ProcessAudios(prompt string, audios *Audios) (*Nam
 
 	var tensorsPtr api.OgaNamedTensors
 	result := p.runtime.funcs.ProcessorProcessAudios(p.ptr, &promptBytes[0], audios.ptr, &tensorsPtr)
-	if err := resultError(p.runtime.funcs, result); err != nil {
+	if err := resultError(p.runtime.funcs, result, "ProcessorProcessAudios"); err != nil {
 		return nil, fmt.Errorf("failed to process audios: %w", err)
 	}
 
@@ -71,7 +71,7 @@ func (p *MultiModalProcessor) ProcessImages(prompt string, images *Images) (*Nam
 
 	var tensorsPtr api.OgaNamedTensors
 	result := p.runtime.funcs.ProcessorProcessImages(p.ptr, &promptBytes[0], images.ptr, &tensorsPtr)
-	if err := resultError(p.runtime.funcs, result); err != nil {
+	if err := resultError(p.runtime.funcs, result, "ProcessorProcessImages"); err != nil {
 		return nil, fmt.Errorf("failed to process images: %w", err)
 	}
 
@@ -87,7 +87,7 @@ func (p *MultiModalProcessor) ProcessImagesAndAudios(prompt string, images *Imag
 
 	var tensorsPtr api.OgaNamedTensors
 	result := p.runtime.funcs.ProcessorProcessImagesAndAudios(p.ptr, &promptBytes[0], images.ptr, audios.ptr, &tensorsPtr)
-	if err := resultError(p.runtime.funcs, result); err != nil {
+	if err := resultError(p.runtime.funcs, result, "ProcessorProcessImagesAndAudios"); err != nil {
 		return nil, fmt.Errorf("failed to process images and audios: %w", err)
 	}
 
@@ -110,7 +110,7 @@ func (p *MultiModalProcessor) Decode(tokens []int32) (string, error) {
 		uintptr(len(tokens)),
 		&outStringPtr,
 	)
-	if err := resultError(p.runtime.funcs, result); err != nil {
+	if err := resultError(p.runtime.funcs, result, "ProcessorDecode"); err != nil {
 		return "", fmt.Errorf("failed to decode tokens: %w", err)
 	}
 
@@ -124,3 +124,18 @@ func (p *MultiModalProcessor) Decode(tokens []int32) (string, error) {
 
 	return text, nil
 }
+
+// NewStream creates a TokenizerStream that decodes generated tokens with the
+// processor's tokenizer.
+func (p *MultiModalProcessor) NewStream() (*TokenizerStream, error) {
+	var streamPtr api.OgaTokenizerStream
+	result := p.runtime.funcs.CreateTokenizerStreamFromProcessor(p.ptr, &streamPtr)
+	if err := resultError(p.runtime.funcs, result, "CreateTokenizerStreamFromProcessor"); err != nil {
+		return nil, fmt.Errorf("failed to create tokenizer stream: %w", err)
+	}
+
+	return &TokenizerStream{
+		ptr:     streamPtr,
+		runtime: p.runtime,
+	}, nil
+}
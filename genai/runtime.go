@@ -109,7 +109,7 @@ func (r *Runtime) NewModel(modelPath string, options *ModelOptions) (*Model, err
 	if options == nil || len(options.Providers) == 0 {
 		var modelPtr api.OgaModel
 		result := r.funcs.CreateModel(&pathBytes[0], &modelPtr)
-		if err := resultError(r.funcs, result); err != nil {
+		if err := resultError(r.funcs, result, "CreateModel"); err != nil {
 			return nil, fmt.Errorf("failed to create model: %w", err)
 		}
 
@@ -119,53 +119,22 @@ func (r *Runtime) NewModel(modelPath string, options *ModelOptions) (*Model, err
 		}, nil
 	}
 
-	// Create config for provider configuration
-	var configPtr api.OgaConfig
-	result := r.funcs.CreateConfig(&pathBytes[0], &configPtr)
-	if err := resultError(r.funcs, result); err != nil {
-		return nil, fmt.Errorf("failed to create config: %w", err)
+	config, err := r.NewConfig(modelPath)
+	if err != nil {
+		return nil, err
 	}
-	defer r.funcs.DestroyConfig(configPtr)
+	defer config.Close()
 
-	// Clear existing providers
-	result = r.funcs.ConfigClearProviders(configPtr)
-	if err := resultError(r.funcs, result); err != nil {
-		return nil, fmt.Errorf("failed to clear providers: %w", err)
+	if err := config.SetProviders(options.Providers...); err != nil {
+		return nil, err
 	}
-
-	// Append providers
 	for _, provider := range options.Providers {
-		providerBytes := stringToBytes(provider)
-		result = r.funcs.ConfigAppendProvider(configPtr, &providerBytes[0])
-		if err := resultError(r.funcs, result); err != nil {
-			return nil, fmt.Errorf("failed to append provider %q: %w", provider, err)
-		}
-
-		// Set provider options if specified
-		if options.ProviderOptions != nil {
-			if providerOpts, ok := options.ProviderOptions[provider]; ok {
-				for key, value := range providerOpts {
-					keyBytes := stringToBytes(key)
-					valueBytes := stringToBytes(value)
-					result = r.funcs.ConfigSetProviderOption(configPtr, &providerBytes[0], &keyBytes[0], &valueBytes[0])
-					if err := resultError(r.funcs, result); err != nil {
-						return nil, fmt.Errorf("failed to set provider option %q=%q for %q: %w", key, value, provider, err)
-					}
-				}
-			}
+		if err := config.SetProviderOptions(provider, options.ProviderOptions[provider]); err != nil {
+			return nil, err
 		}
 	}
 
-	var modelPtr api.OgaModel
-	result = r.funcs.CreateModelFromConfig(configPtr, &modelPtr)
-	if err := resultError(r.funcs, result); err != nil {
-		return nil, fmt.Errorf("failed to create model from config: %w", err)
-	}
-
-	return &Model{
-		ptr:     modelPtr,
-		runtime: r,
-	}, nil
+	return r.NewModelFromConfig(config)
 }
 
 // stringToBytes converts a Go string to a null-terminated byte slice.
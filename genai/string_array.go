@@ -35,7 +35,7 @@ func (r *Runtime) newStringArray(strings []string) (*stringArray, error) {
 		uintptr(len(strings)),
 		&arrayPtr,
 	)
-	if err := resultError(r.funcs, result); err != nil {
+	if err := resultError(r.funcs, result, "CreateStringArrayFromStrings"); err != nil {
 		return nil, fmt.Errorf("failed to create string array: %w", err)
 	}
 
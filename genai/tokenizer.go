@@ -1,6 +1,7 @@
 package genai
 
 import (
+	"encoding/json"
 	"fmt"
 	"unsafe"
 
@@ -24,38 +25,52 @@ func (t *Tokenizer) Close() {
 
 // Encode converts text to token IDs.
 func (t *Tokenizer) Encode(text string) ([]int32, error) {
+	batch, err := t.EncodeBatch([]string{text})
+	if err != nil {
+		return nil, err
+	}
+	return batch[0], nil
+}
+
+// EncodeBatch converts each text to token IDs. The sequences are not padded,
+// so they may differ in length.
+func (t *Tokenizer) EncodeBatch(texts []string) ([][]int32, error) {
 	var seqPtr api.OgaSequences
 	result := t.runtime.funcs.CreateSequences(&seqPtr)
-	if err := resultError(t.runtime.funcs, result); err != nil {
+	if err := resultError(t.runtime.funcs, result, "CreateSequences"); err != nil {
 		return nil, fmt.Errorf("failed to create sequences: %w", err)
 	}
 	defer t.runtime.funcs.DestroySequences(seqPtr)
 
-	// Encode the text
-	textBytes := stringToBytes(text)
-	result = t.runtime.funcs.TokenizerEncode(t.ptr, &textBytes[0], seqPtr)
-	if err := resultError(t.runtime.funcs, result); err != nil {
-		return nil, fmt.Errorf("failed to encode text: %w", err)
+	// Each call appends one sequence
+	for i, text := range texts {
+		textBytes := stringToBytes(text)
+		result = t.runtime.funcs.TokenizerEncode(t.ptr, &textBytes[0], seqPtr)
+		if err := resultError(t.runtime.funcs, result, "TokenizerEncode"); err != nil {
+			return nil, fmt.Errorf("failed to encode text %d: %w", i, err)
+		}
 	}
 
-	// Get the token count for the first sequence (index 0)
-	count := t.runtime.funcs.SequencesGetSequenceCount(seqPtr, 0)
-	if count == 0 {
-		return []int32{}, nil
+	batch := make([][]int32, len(texts))
+	for i := range batch {
+		count := t.runtime.funcs.SequencesGetSequenceCount(seqPtr, uintptr(i))
+		if count == 0 {
+			batch[i] = []int32{}
+			continue
+		}
+
+		dataPtr := t.runtime.funcs.SequencesGetSequenceData(seqPtr, uintptr(i))
+		if dataPtr == nil {
+			return nil, fmt.Errorf("failed to get sequence data")
+		}
+
+		// Copy tokens to Go slice
+		tokens := make([]int32, count)
+		copy(tokens, unsafe.Slice(dataPtr, count))
+		batch[i] = tokens
 	}
 
-	// Get the token data
-	dataPtr := t.runtime.funcs.SequencesGetSequenceData(seqPtr, 0)
-	if dataPtr == nil {
-		return nil, fmt.Errorf("failed to get sequence data")
-	}
-
-	// Copy tokens to Go slice
-	tokens := make([]int32, count)
-	srcTokens := unsafe.Slice(dataPtr, count)
-	copy(tokens, srcTokens)
-
-	return tokens, nil
+	return batch, nil
 }
 
 // Decode converts token IDs to text.
@@ -71,7 +86,7 @@ func (t *Tokenizer) Decode(tokens []int32) (string, error) {
 		uintptr(len(tokens)),
 		&outStringPtr,
 	)
-	if err := resultError(t.runtime.funcs, result); err != nil {
+	if err := resultError(t.runtime.funcs, result, "TokenizerDecode"); err != nil {
 		return "", fmt.Errorf("failed to decode tokens: %w", err)
 	}
 
@@ -85,3 +100,148 @@ func (t *Tokenizer) Decode(tokens []int32) (string, error) {
 
 	return text, nil
 }
+
+// ChatMessage is one turn of a conversation formatted by ApplyChatTemplate.
+type ChatMessage struct {
+	// Role is the speaker, usually "system", "user", "assistant", or "tool".
+	Role string `json:"role"`
+
+	// Content is the text of the message.
+	Content string `json:"content"`
+}
+
+// ChatTemplateOptions configures ApplyChatTemplate.
+type ChatTemplateOptions struct {
+	// Template is a Jinja chat template to use instead of the one in the
+	// model's tokenizer_config.json.
+	Template string
+
+	// Tools is a JSON array of tool definitions, for templates that
+	// describe the available tools to the model.
+	Tools string
+
+	// AddGenerationPrompt ends the prompt with the header of an assistant
+	// turn, so that generation continues as the assistant's reply.
+	AddGenerationPrompt bool
+}
+
+// ApplyChatTemplate formats messages into a prompt with the model's chat
+// template, so that roles and special tokens are laid out the way the model
+// was trained on. If options is nil, the model's template is used and the
+// generation prompt is added.
+//
+// Example:
+//
+//	prompt, _ := tokenizer.ApplyChatTemplate([]genai.ChatMessage{
+//	    {Role: "system", Content: "You are a helpful assistant."},
+//	    {Role: "user", Content: "What is the capital of France?"},
+//	}, nil)
+//	tokens, _ := tokenizer.Encode(prompt)
+func (t *Tokenizer) ApplyChatTemplate(messages []ChatMessage, options *ChatTemplateOptions) (string, error) {
+	if options == nil {
+		options = &ChatTemplateOptions{AddGenerationPrompt: true}
+	}
+
+	messagesJSON, err := json.Marshal(messages)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode messages: %w", err)
+	}
+	messagesBytes := stringToBytes(string(messagesJSON))
+
+	// Empty strings are passed as NULL to use the model's defaults
+	var templatePtr, toolsPtr *byte
+	if options.Template != "" {
+		templateBytes := stringToBytes(options.Template)
+		templatePtr = &templateBytes[0]
+	}
+	if options.Tools != "" {
+		toolsBytes := stringToBytes(options.Tools)
+		toolsPtr = &toolsBytes[0]
+	}
+
+	var outStringPtr *byte
+	result := t.runtime.funcs.TokenizerApplyChatTemplate(
+		t.ptr,
+		templatePtr,
+		&messagesBytes[0],
+		toolsPtr,
+		options.AddGenerationPrompt,
+		&outStringPtr,
+	)
+	if err := resultError(t.runtime.funcs, result, "TokenizerApplyChatTemplate"); err != nil {
+		return "", fmt.Errorf("failed to apply chat template: %w", err)
+	}
+
+	if outStringPtr == nil {
+		return "", nil
+	}
+
+	prompt := cstrings.CStringToString(outStringPtr)
+
+	t.runtime.funcs.DestroyString(outStringPtr)
+
+	return prompt, nil
+}
+
+// NewStream creates a TokenizerStream for decoding generated tokens one at a
+// time.
+func (t *Tokenizer) NewStream() (*TokenizerStream, error) {
+	var streamPtr api.OgaTokenizerStream
+	result := t.runtime.funcs.CreateTokenizerStream(t.ptr, &streamPtr)
+	if err := resultError(t.runtime.funcs, result, "CreateTokenizerStream"); err != nil {
+		return nil, fmt.Errorf("failed to create tokenizer stream: %w", err)
+	}
+
+	return &TokenizerStream{
+		ptr:     streamPtr,
+		runtime: t.runtime,
+	}, nil
+}
+
+// TokenizerStream decodes tokens incrementally during generation. Decoding
+// tokens separately with Tokenizer.Decode breaks multi-byte characters and
+// drops the spaces some tokenizers encode as token prefixes; a stream keeps
+// enough state to emit text only once it is complete.
+//
+// A TokenizerStream is NOT safe for concurrent use. Use one per generated
+// sequence.
+//
+// Example:
+//
+//	stream, _ := tokenizer.NewStream()
+//	defer stream.Close()
+//	for !generator.IsDone() {
+//	    generator.GenerateNextToken()
+//	    next, _ := generator.GetNextTokens()
+//	    text, _ := stream.Decode(next[0])
+//	    fmt.Print(text)
+//	}
+type TokenizerStream struct {
+	ptr     api.OgaTokenizerStream
+	runtime *Runtime
+}
+
+// Decode adds a token to the stream and returns the text it completes,
+// which is empty while a character spans several tokens.
+func (s *TokenizerStream) Decode(token int32) (string, error) {
+	var outStringPtr *byte
+	result := s.runtime.funcs.TokenizerStreamDecode(s.ptr, token, &outStringPtr)
+	if err := resultError(s.runtime.funcs, result, "TokenizerStreamDecode"); err != nil {
+		return "", fmt.Errorf("failed to decode token %d: %w", token, err)
+	}
+
+	if outStringPtr == nil {
+		return "", nil
+	}
+
+	// The string is owned by the stream and valid until the next call
+	return cstrings.CStringToString(outStringPtr), nil
+}
+
+// Close releases resources associated with the tokenizer stream.
+func (s *TokenizerStream) Close() {
+	if s.ptr != 0 {
+		s.runtime.funcs.DestroyTokenizerStream(s.ptr)
+		s.ptr = 0
+	}
+}
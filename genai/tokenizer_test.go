@@ -1,6 +1,8 @@
 package genai
 
 import (
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -89,3 +91,101 @@ func TestTokenizerClose(t *testing.T) {
 	// Second close should also succeed (idempotent)
 	tokenizer.Close()
 }
+
+func TestTokenizerEncodeBatch(t *testing.T) {
+	rt := newTestRuntime(t)
+	model := newTestModel(t, rt)
+	tokenizer := newTestTokenizer(t, model)
+
+	texts := []string{"Hello, world!", "", "She sells sea shells by the sea shore."}
+	batch, err := tokenizer.EncodeBatch(texts)
+	if err != nil {
+		t.Fatalf("Failed to encode batch: %v", err)
+	}
+	if len(batch) != len(texts) {
+		t.Fatalf("Expected %d sequences, got %d", len(texts), len(batch))
+	}
+
+	for i, text := range texts {
+		tokens, err := tokenizer.Encode(text)
+		if err != nil {
+			t.Fatalf("Failed to encode %q: %v", text, err)
+		}
+		if !slices.Equal(batch[i], tokens) {
+			t.Errorf("Sequence %d: expected %v, got %v", i, tokens, batch[i])
+		}
+	}
+}
+
+func TestTokenizerStream(t *testing.T) {
+	rt := newTestRuntime(t)
+	model := newTestModel(t, rt)
+	tokenizer := newTestTokenizer(t, model)
+
+	text := "She sells sea shells by the sea shore."
+	tokens, err := tokenizer.Encode(text)
+	if err != nil {
+		t.Fatalf("Failed to encode text: %v", err)
+	}
+	decoded, err := tokenizer.Decode(tokens)
+	if err != nil {
+		t.Fatalf("Failed to decode tokens: %v", err)
+	}
+
+	stream, err := tokenizer.NewStream()
+	if err != nil {
+		t.Fatalf("Failed to create stream: %v", err)
+	}
+	defer stream.Close()
+
+	var streamed strings.Builder
+	for _, token := range tokens {
+		piece, err := stream.Decode(token)
+		if err != nil {
+			t.Fatalf("Failed to decode token %d: %v", token, err)
+		}
+		streamed.WriteString(piece)
+	}
+
+	// Special tokens such as BOS are skipped by both decoders
+	if strings.TrimSpace(streamed.String()) != strings.TrimSpace(decoded) {
+		t.Errorf("Expected streamed text %q, got %q", decoded, streamed.String())
+	}
+
+	// Second close should also succeed (idempotent)
+	stream.Close()
+	stream.Close()
+}
+
+func TestTokenizerApplyChatTemplate(t *testing.T) {
+	rt := newTestRuntime(t)
+	model := newTestModel(t, rt)
+	tokenizer := newTestTokenizer(t, model)
+
+	messages := []ChatMessage{
+		{Role: "system", Content: "You are terse."},
+		{Role: "user", Content: "Say \"hi\"."},
+	}
+	options := &ChatTemplateOptions{
+		Template:            "{% for m in messages %}<{{ m.role }}>{{ m.content }}\n{% endfor %}{% if add_generation_prompt %}<assistant>{% endif %}",
+		AddGenerationPrompt: true,
+	}
+
+	prompt, err := tokenizer.ApplyChatTemplate(messages, options)
+	if err != nil {
+		t.Fatalf("Failed to apply chat template: %v", err)
+	}
+	want := "<system>You are terse.\n<user>Say \"hi\".\n<assistant>"
+	if prompt != want {
+		t.Errorf("ApplyChatTemplate() = %q, want %q", prompt, want)
+	}
+
+	options.AddGenerationPrompt = false
+	prompt, err = tokenizer.ApplyChatTemplate(messages, options)
+	if err != nil {
+		t.Fatalf("Failed to apply chat template: %v", err)
+	}
+	if strings.HasSuffix(prompt, "<assistant>") {
+		t.Errorf("ApplyChatTemplate() = %q, want no generation prompt", prompt)
+	}
+}
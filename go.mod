@@ -1,5 +1,9 @@
 module github.com/benedoc-inc/onnxer
 
-go 1.25
+go 1.25.0
 
-require github.com/ebitengine/purego v0.9.0
+require (
+	github.com/ebitengine/purego v0.9.0
+	github.com/fsnotify/fsnotify v1.9.0
+	golang.org/x/sys v0.47.0
+)
@@ -0,0 +1,41 @@
+// Package nativeerror defines the interface shared by the errors that the
+// onnxruntime and genai packages return when their native library fails,
+// so that programs using both can handle them in one place:
+//
+//	var nerr nativeerror.Error
+//	if errors.As(err, &nerr) {
+//	    log.Printf("%s: %s failed with code %d: %s",
+//	        nerr.Library(), nerr.ErrorOp(), nerr.ErrorCode(), nerr.ErrorMessage())
+//	}
+//
+// The concrete types, *onnxruntime.RuntimeError and *genai.Error, carry the
+// same information as fields.
+package nativeerror
+
+// Names of the libraries reported by Error.Library.
+const (
+	LibraryONNXRuntime = "onnxruntime"
+	LibraryGenAI       = "onnxruntime-genai"
+)
+
+// CodeUnknown is the code of errors from libraries that do not report one,
+// such as ONNX Runtime GenAI.
+const CodeUnknown = -1
+
+// Error is an error reported by a native library.
+type Error interface {
+	error
+
+	// Library names the library that reported the error.
+	Library() string
+
+	// ErrorCode is the library's error code, such as an OrtErrorCode, or
+	// CodeUnknown.
+	ErrorCode() int
+
+	// ErrorOp is the C API function that failed, or "" if unknown.
+	ErrorOp() string
+
+	// ErrorMessage is the message reported by the library.
+	ErrorMessage() string
+}
@@ -1,8 +1,13 @@
 package onnxruntime
 
 import (
+	"fmt"
+	"runtime"
+	"strconv"
 	"unsafe"
 
+	"github.com/benedoc-inc/onnxer/internal/cstrings"
+
 	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
 )
 
@@ -34,3 +39,125 @@ func (mi *memoryInfo) release() {
 		mi.ptr = 0
 	}
 }
+
+// Allocator is an ONNX Runtime allocator created for a session and device.
+// Its statistics expose native memory usage, such as arena size and bytes in
+// use, that is otherwise only visible through process RSS.
+//
+// An Allocator is only valid while its session is open. Close it when done.
+type Allocator struct {
+	ptr     api.OrtAllocator
+	runtime *Runtime
+	session *Session
+
+	// ID of the allocator in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the allocator is not closed
+	cleanup runtime.Cleanup
+}
+
+// AllocatorStats holds allocator statistics. Only arena-based allocators
+// track usage; other allocators report zeros.
+type AllocatorStats struct {
+	Limit              int64 // arena size limit in bytes; 0 if unlimited
+	InUse              int64 // bytes currently allocated
+	TotalAllocated     int64 // bytes reserved by the arena
+	MaxInUse           int64 // peak bytes allocated
+	NumAllocs          int64 // number of allocations
+	NumReserves        int64 // number of reserve calls
+	NumArenaExtensions int64 // number of times the arena grew
+	NumArenaShrinkages int64 // number of times the arena shrank
+	MaxAllocSize       int64 // largest single allocation in bytes
+
+	// Raw holds every statistic reported by ONNX Runtime, including any
+	// not mapped to a field above.
+	Raw map[string]string
+}
+
+// NewAllocatorForSession creates an allocator for the device described by
+// memInfo, sharing the session's arena for that device.
+func (r *Runtime) NewAllocatorForSession(session *Session, memInfo *MemoryInfo) (*Allocator, error) {
+	if session.ptr == 0 {
+		return nil, ErrSessionClosed
+	}
+
+	var allocPtr api.OrtAllocator
+	status := r.apiFuncs.CreateAllocator(session.ptr, memInfo.ptr, &allocPtr)
+	if err := r.statusError(status, "CreateAllocator"); err != nil {
+		return nil, fmt.Errorf("failed to create allocator: %w", err)
+	}
+
+	a := &Allocator{
+		ptr:     allocPtr,
+		runtime: r,
+		session: session,
+		trackID: r.objects.add(kindAllocator),
+	}
+	// The session is kept until the allocator is released
+	a.cleanup = guard(a, leak{runtime: r, kind: kindAllocator, id: a.trackID, ptr: uintptr(allocPtr), release: releaseLeakedAllocator, keep: session})
+	return a, nil
+}
+
+func releaseLeakedAllocator(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseAllocator(api.OrtAllocator(ptr))
+}
+
+// Stats returns the allocator's current statistics. It requires API version
+// 23 or later and returns an error wrapping ErrNotImplemented otherwise.
+func (a *Allocator) Stats() (AllocatorStats, error) {
+	if a.ptr == 0 {
+		return AllocatorStats{}, fmt.Errorf("allocator is closed")
+	}
+
+	var kvps api.OrtKeyValuePairs
+	status := a.runtime.apiFuncs.AllocatorGetStats(a.ptr, &kvps)
+	if err := a.runtime.statusError(status, "AllocatorGetStats"); err != nil {
+		return AllocatorStats{}, fmt.Errorf("failed to get allocator stats: %w", err)
+	}
+	defer a.runtime.apiFuncs.ReleaseKeyValuePairs(kvps)
+
+	var keysPtr, valuesPtr **byte
+	var count uintptr
+	a.runtime.apiFuncs.GetKeyValuePairs(kvps, &keysPtr, &valuesPtr, &count)
+
+	stats := AllocatorStats{Raw: make(map[string]string, count)}
+	if count == 0 {
+		return stats, nil
+	}
+
+	fields := map[string]*int64{
+		"Limit":              &stats.Limit,
+		"InUse":              &stats.InUse,
+		"TotalAllocated":     &stats.TotalAllocated,
+		"MaxInUse":           &stats.MaxInUse,
+		"NumAllocs":          &stats.NumAllocs,
+		"NumReserves":        &stats.NumReserves,
+		"NumArenaExtensions": &stats.NumArenaExtensions,
+		"NumArenaShrinkages": &stats.NumArenaShrinkages,
+		"MaxAllocSize":       &stats.MaxAllocSize,
+	}
+	keys := unsafe.Slice(keysPtr, count)
+	values := unsafe.Slice(valuesPtr, count)
+	for i := range keys {
+		key := cstrings.CStringToString(keys[i])
+		value := cstrings.CStringToString(values[i])
+		stats.Raw[key] = value
+		if field, ok := fields[key]; ok {
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				*field = n
+			}
+		}
+	}
+	return stats, nil
+}
+
+// Close releases the allocator. It is safe to call Close multiple times.
+func (a *Allocator) Close() {
+	if a.ptr != 0 && a.runtime != nil && a.runtime.apiFuncs != nil {
+		a.cleanup.Stop()
+		a.runtime.apiFuncs.ReleaseAllocator(a.ptr)
+		a.ptr = 0
+		a.runtime.objects.remove(kindAllocator, a.trackID)
+	}
+}
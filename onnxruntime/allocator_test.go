@@ -0,0 +1,58 @@
+package onnxruntime
+
+import (
+	"testing"
+)
+
+func TestNewAllocatorForSession(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	memInfo, err := runtime.NewCPUMemoryInfo()
+	if err != nil {
+		t.Fatalf("Failed to create CPU memory info: %v", err)
+	}
+	defer memInfo.Close()
+
+	alloc, err := runtime.NewAllocatorForSession(session, memInfo)
+	if err != nil {
+		t.Fatalf("NewAllocatorForSession failed: %v", err)
+	}
+	defer alloc.Close()
+
+	runInference(t, runtime, session)
+
+	stats, err := alloc.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Raw == nil {
+		t.Error("Expected Raw stats map to be initialized")
+	}
+	if stats.InUse < 0 || stats.MaxInUse < stats.InUse {
+		t.Errorf("Inconsistent stats: InUse=%d MaxInUse=%d", stats.InUse, stats.MaxInUse)
+	}
+
+	alloc.Close()
+	if _, err := alloc.Stats(); err == nil {
+		t.Error("Expected error from Stats after Close")
+	}
+	// Double close should not panic
+	alloc.Close()
+}
+
+func TestNewAllocatorForClosedSession(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	memInfo, err := runtime.NewCPUMemoryInfo()
+	if err != nil {
+		t.Fatalf("Failed to create CPU memory info: %v", err)
+	}
+	defer memInfo.Close()
+
+	session.Close()
+	if _, err := runtime.NewAllocatorForSession(session, memInfo); err != ErrSessionClosed {
+		t.Errorf("Expected ErrSessionClosed, got %v", err)
+	}
+}
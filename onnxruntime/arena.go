@@ -0,0 +1,174 @@
+package onnxruntime
+
+import (
+	"fmt"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// ConfigKeyUseEnvAllocators makes a session use allocators registered on its
+// Env with CreateAndRegisterAllocator instead of creating its own ("1" to
+// enable). SessionOptions.UseEnvAllocators sets it.
+const ConfigKeyUseEnvAllocators = "session.use_env_allocators"
+
+// ArenaExtendStrategy controls how an arena grows when it runs out of memory.
+type ArenaExtendStrategy int
+
+const (
+	// ArenaExtendDefault uses the ONNX Runtime default (next power of two).
+	ArenaExtendDefault ArenaExtendStrategy = iota
+	// ArenaExtendNextPowerOfTwo grows the arena in increasingly large chunks,
+	// which means fewer allocations but can overshoot memory limits.
+	ArenaExtendNextPowerOfTwo
+	// ArenaExtendSameAsRequested grows the arena by exactly the requested
+	// size, which keeps memory usage tight.
+	ArenaExtendSameAsRequested
+)
+
+// String returns the strategy name.
+func (s ArenaExtendStrategy) String() string {
+	switch s {
+	case ArenaExtendDefault:
+		return "Default"
+	case ArenaExtendNextPowerOfTwo:
+		return "NextPowerOfTwo"
+	case ArenaExtendSameAsRequested:
+		return "SameAsRequested"
+	default:
+		return fmt.Sprintf("ArenaExtendStrategy(%d)", int(s))
+	}
+}
+
+// value returns the C API value, where -1 selects the default.
+func (s ArenaExtendStrategy) value() int32 {
+	return int32(s) - 1
+}
+
+// ArenaConfig configures an arena allocator. Zero fields use the ONNX
+// Runtime defaults. Pass it to Env.CreateAndRegisterAllocator for the CPU
+// arena, or set ExecutionProvider.Arena for the CUDA device arena, to bound
+// memory growth in containers with hard limits.
+type ArenaConfig struct {
+	// MaxMem caps the memory the arena may hold, in bytes.
+	MaxMem uint64
+
+	// ArenaExtendStrategy controls how the arena grows.
+	ArenaExtendStrategy ArenaExtendStrategy
+
+	// InitialChunkSizeBytes is the size of the first allocation.
+	InitialChunkSizeBytes int
+
+	// MaxDeadBytesPerChunk limits unused bytes in a chunk before it is split.
+	MaxDeadBytesPerChunk int
+}
+
+// arenaCfg is a native arena configuration.
+type arenaCfg struct {
+	ptr     api.OrtArenaCfg
+	runtime *Runtime
+}
+
+// keyValues returns the CreateArenaCfgV2 keys and values for the fields that
+// differ from the ONNX Runtime defaults.
+func (c *ArenaConfig) keyValues() ([]string, []uintptr) {
+	var keys []string
+	var values []uintptr
+	add := func(key string, value uintptr) {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if c.MaxMem > 0 {
+		add("max_mem", uintptr(c.MaxMem))
+	}
+	if c.ArenaExtendStrategy != ArenaExtendDefault {
+		add("arena_extend_strategy", uintptr(c.ArenaExtendStrategy.value()))
+	}
+	if c.InitialChunkSizeBytes > 0 {
+		add("initial_chunk_size_bytes", uintptr(c.InitialChunkSizeBytes))
+	}
+	if c.MaxDeadBytesPerChunk > 0 {
+		add("max_dead_bytes_per_chunk", uintptr(c.MaxDeadBytesPerChunk))
+	}
+	return keys, values
+}
+
+// newArenaCfg creates the native form of config. A nil config yields a zero
+// pointer, which ONNX Runtime treats as all defaults.
+func (r *Runtime) newArenaCfg(config *ArenaConfig) (*arenaCfg, error) {
+	if config == nil {
+		return &arenaCfg{runtime: r}, nil
+	}
+
+	keys, values := config.keyValues()
+	var keyPtrs **byte
+	var valuePtr *uintptr
+	if len(keys) > 0 {
+		cKeys := make([]*byte, len(keys))
+		for i, k := range keys {
+			kBytes := append([]byte(k), 0)
+			cKeys[i] = &kBytes[0]
+		}
+		keyPtrs = &cKeys[0]
+		valuePtr = &values[0]
+	}
+
+	var ptr api.OrtArenaCfg
+	status := r.apiFuncs.CreateArenaCfgV2(keyPtrs, valuePtr, uintptr(len(keys)), &ptr)
+	if err := r.statusError(status, "CreateArenaCfgV2"); err != nil {
+		return nil, fmt.Errorf("failed to create arena config: %w", err)
+	}
+	return &arenaCfg{ptr: ptr, runtime: r}, nil
+}
+
+func (c *arenaCfg) release() {
+	if c.ptr != 0 && c.runtime != nil && c.runtime.apiFuncs != nil {
+		c.runtime.apiFuncs.ReleaseArenaCfg(c.ptr)
+		c.ptr = 0
+	}
+}
+
+// CreateAndRegisterAllocator creates an allocator for memInfo, an arena if
+// memInfo has AllocatorTypeArena, and registers it on the environment.
+// Sessions created from the Env with SessionOptions.UseEnvAllocators then
+// share it instead of each growing a private arena, which cuts memory
+// substantially when many sessions, such as a SessionPool, serve the same
+// model. arena configures the arena and may be nil for defaults.
+//
+// Example:
+//
+//	memInfo, _ := runtime.NewMemoryInfo(onnxruntime.MemoryInfoNameCPU,
+//	    onnxruntime.AllocatorTypeArena, onnxruntime.MemTypeDefault, 0)
+//	defer memInfo.Close()
+//	err := env.CreateAndRegisterAllocator(memInfo, &onnxruntime.ArenaConfig{MaxMem: 1 << 30})
+//	pool, err := onnxruntime.NewSessionPool(runtime, env, modelData, 8, &onnxruntime.PoolConfig{
+//	    SessionOptions: &onnxruntime.SessionOptions{UseEnvAllocators: true},
+//	})
+func (e *Env) CreateAndRegisterAllocator(memInfo *MemoryInfo, arena *ArenaConfig) error {
+	if memInfo == nil || memInfo.ptr == 0 {
+		return fmt.Errorf("memory info is nil or closed")
+	}
+	cfg, err := e.runtime.newArenaCfg(arena)
+	if err != nil {
+		return err
+	}
+	defer cfg.release()
+
+	status := e.runtime.apiFuncs.CreateAndRegisterAllocator(e.ptr, memInfo.ptr, cfg.ptr)
+	if err := e.runtime.statusError(status, "CreateAndRegisterAllocator"); err != nil {
+		return fmt.Errorf("failed to create and register allocator: %w", err)
+	}
+	return nil
+}
+
+// UnregisterAllocator removes the allocator registered for memInfo. Sessions
+// already using it keep it alive until they are closed.
+func (e *Env) UnregisterAllocator(memInfo *MemoryInfo) error {
+	if memInfo == nil || memInfo.ptr == 0 {
+		return fmt.Errorf("memory info is nil or closed")
+	}
+	status := e.runtime.apiFuncs.UnregisterAllocator(e.ptr, memInfo.ptr)
+	if err := e.runtime.statusError(status, "UnregisterAllocator"); err != nil {
+		return fmt.Errorf("failed to unregister allocator: %w", err)
+	}
+	return nil
+}
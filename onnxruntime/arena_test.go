@@ -0,0 +1,115 @@
+package onnxruntime
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestArenaExtendStrategyValue(t *testing.T) {
+	tests := []struct {
+		strategy ArenaExtendStrategy
+		want     int32
+	}{
+		{ArenaExtendDefault, -1},
+		{ArenaExtendNextPowerOfTwo, 0},
+		{ArenaExtendSameAsRequested, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.strategy.value(); got != tt.want {
+			t.Errorf("%v.value() = %d, want %d", tt.strategy, got, tt.want)
+		}
+	}
+}
+
+func TestArenaConfigKeyValues(t *testing.T) {
+	keys, values := (&ArenaConfig{}).keyValues()
+	if len(keys) != 0 || len(values) != 0 {
+		t.Errorf("Expected no keys for the zero config, got %v", keys)
+	}
+
+	keys, values = (&ArenaConfig{
+		MaxMem:                1 << 30,
+		ArenaExtendStrategy:   ArenaExtendSameAsRequested,
+		InitialChunkSizeBytes: 1 << 20,
+		MaxDeadBytesPerChunk:  128,
+	}).keyValues()
+	wantKeys := []string{"max_mem", "arena_extend_strategy", "initial_chunk_size_bytes", "max_dead_bytes_per_chunk"}
+	wantValues := []uintptr{1 << 30, 1, 1 << 20, 128}
+	if !slices.Equal(keys, wantKeys) || !slices.Equal(values, wantValues) {
+		t.Errorf("keyValues() = %v, %v; want %v, %v", keys, values, wantKeys, wantValues)
+	}
+}
+
+func TestExecutionProviderArenaRequiresCUDA(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	_, err = runtime.NewSession(env, testModelPath(), &SessionOptions{
+		ExecutionProviders: []ExecutionProvider{{Name: cpuExecutionProvider, Arena: &ArenaConfig{MaxMem: 1 << 20}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "arena config") {
+		t.Errorf("Expected arena config error for %s, got %v", cpuExecutionProvider, err)
+	}
+}
+
+func TestImpliedConfigEntries(t *testing.T) {
+	entries := (&SessionOptions{UseEnvAllocators: true, ModelFormat: ModelFormatORT}).impliedConfigEntries()
+	if entries[ConfigKeyUseEnvAllocators] != "1" {
+		t.Errorf("Expected %s=1, got %v", ConfigKeyUseEnvAllocators, entries)
+	}
+	if entries[ConfigKeyLoadModelFormat] != "ORT" {
+		t.Errorf("Expected model format entries to be kept, got %v", entries)
+	}
+
+	if entries := (&SessionOptions{}).impliedConfigEntries(); len(entries) != 0 {
+		t.Errorf("Expected no entries for default options, got %v", entries)
+	}
+}
+
+func TestEnvCreateAndRegisterAllocator(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	memInfo, err := runtime.NewMemoryInfo(MemoryInfoNameCPU, AllocatorTypeArena, MemTypeDefault, 0)
+	if err != nil {
+		t.Fatalf("Failed to create memory info: %v", err)
+	}
+	defer memInfo.Close()
+
+	arena := &ArenaConfig{MaxMem: 64 << 20, ArenaExtendStrategy: ArenaExtendSameAsRequested}
+	if err := env.CreateAndRegisterAllocator(memInfo, arena); err != nil {
+		t.Fatalf("CreateAndRegisterAllocator failed: %v", err)
+	}
+
+	opts := &SessionOptions{UseEnvAllocators: true}
+	sessions := make([]*Session, 2)
+	for i := range sessions {
+		session, err := runtime.NewSession(env, testModelPath(), opts)
+		if err != nil {
+			t.Fatalf("Failed to create session %d: %v", i, err)
+		}
+		defer session.Close()
+		sessions[i] = session
+	}
+	for _, session := range sessions {
+		runInference(t, runtime, session)
+	}
+
+	if err := env.UnregisterAllocator(memInfo); err != nil {
+		t.Errorf("UnregisterAllocator failed: %v", err)
+	}
+	if err := env.CreateAndRegisterAllocator(nil, nil); err == nil {
+		t.Error("Expected error for nil memory info")
+	}
+}
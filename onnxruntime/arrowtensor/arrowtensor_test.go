@@ -0,0 +1,235 @@
+package arrowtensor
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func TestRowWidth(t *testing.T) {
+	tests := []struct {
+		shape   []int64
+		want    int64
+		wantErr bool
+	}{
+		{shape: []int64{}, want: 1},
+		{shape: []int64{5}, want: 1},
+		{shape: []int64{5, 1}, want: 1},
+		{shape: []int64{5, 3}, want: 3},
+		{shape: []int64{0, 3}, want: 3},
+		{shape: []int64{5, 0}, wantErr: true},
+		{shape: []int64{2, 3, 4}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := rowWidth(tt.shape)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("rowWidth(%v) error = %v, wantErr %v", tt.shape, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("rowWidth(%v) = %d, want %d", tt.shape, got, tt.want)
+		}
+	}
+}
+
+func TestNewArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	arr, err := newArray(mem, []string{"a", "b"}, []int64{2, 1})
+	if err != nil {
+		t.Fatalf("newArray() error = %v", err)
+	}
+	strs, ok := arr.(*array.String)
+	if !ok || strs.Len() != 2 || strs.Value(1) != "b" {
+		t.Errorf("newArray() = %v, want [a b]", arr)
+	}
+	arr.Release()
+
+	arr, err = newArray(mem, []float32{1, 2, 3, 4, 5, 6}, []int64{2, 3})
+	if err != nil {
+		t.Fatalf("newArray() error = %v", err)
+	}
+	defer arr.Release()
+	list, ok := arr.(*array.FixedSizeList)
+	if !ok {
+		t.Fatalf("newArray() = %T, want *array.FixedSizeList", arr)
+	}
+	if list.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", list.Len())
+	}
+	if !arrow.TypeEqual(list.DataType(), arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Float32)) {
+		t.Errorf("DataType() = %s, want fixed_size_list<float32>[3]", list.DataType())
+	}
+	values := list.ListValues().(*array.Float32).Float32Values()
+	if !slices.Equal(values, []float32{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("ListValues() = %v", values)
+	}
+}
+
+func newRecord(t *testing.T, mem memory.Allocator) arrow.RecordBatch {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "age", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "income", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "city", Type: arrow.BinaryTypes.String},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{31, 45, 27}, nil)
+	b.Field(1).(*array.Float64Builder).AppendValues([]float64{52000, 0, 38500}, []bool{true, false, true})
+	b.Field(2).(*array.StringBuilder).AppendValues([]string{"Oslo", "Lima", "Pune"}, nil)
+	rec := b.NewRecordBatch()
+	t.Cleanup(rec.Release)
+	return rec
+}
+
+func TestFromRecord(t *testing.T) {
+	runtime := newTestRuntime(t)
+	rec := newRecord(t, memory.DefaultAllocator)
+
+	if _, err := FromRecord(runtime, rec, nil); err == nil {
+		t.Error("FromRecord() with nulls succeeded, want error")
+	}
+
+	values, err := FromRecord(runtime, rec, &Options{FillNulls: true})
+	if err != nil {
+		t.Fatalf("FromRecord() error = %v", err)
+	}
+	defer closeValues(values)
+
+	ages, shape, err := ort.GetTensorData[int64](values["age"])
+	if err != nil {
+		t.Fatalf("GetTensorData() error = %v", err)
+	}
+	if !slices.Equal(shape, []int64{3, 1}) || !slices.Equal(ages, []int64{31, 45, 27}) {
+		t.Errorf("age = %v %v, want [31 45 27] [3 1]", ages, shape)
+	}
+
+	incomes, _, err := ort.GetTensorData[float64](values["income"])
+	if err != nil {
+		t.Fatalf("GetTensorData() error = %v", err)
+	}
+	if incomes[0] != 52000 || !math.IsNaN(incomes[1]) || incomes[2] != 38500 {
+		t.Errorf("income = %v, want [52000 NaN 38500]", incomes)
+	}
+
+	cities, _, err := ort.GetStringTensorData(values["city"])
+	if err != nil {
+		t.Fatalf("GetStringTensorData() error = %v", err)
+	}
+	if !slices.Equal(cities, []string{"Oslo", "Lima", "Pune"}) {
+		t.Errorf("city = %v", cities)
+	}
+}
+
+func TestFromArraySlice(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	b := array.NewFloat32Builder(memory.DefaultAllocator)
+	defer b.Release()
+	b.AppendValues([]float32{1, 2, 3, 4, 5}, nil)
+	arr := b.NewArray()
+	defer arr.Release()
+	sliced := array.NewSlice(arr, 1, 4)
+	defer sliced.Release()
+
+	v, err := FromArray(runtime, sliced, nil)
+	if err != nil {
+		t.Fatalf("FromArray() error = %v", err)
+	}
+	defer v.Close()
+
+	data, shape, err := ort.GetTensorData[float32](v)
+	if err != nil {
+		t.Fatalf("GetTensorData() error = %v", err)
+	}
+	if !slices.Equal(shape, []int64{3, 1}) || !slices.Equal(data, []float32{2, 3, 4}) {
+		t.Errorf("FromArray() = %v %v, want [2 3 4] [3 1]", data, shape)
+	}
+}
+
+func TestFromRecordMatrix(t *testing.T) {
+	runtime := newTestRuntime(t)
+	rec := newRecord(t, memory.DefaultAllocator)
+
+	if _, err := FromRecordMatrix(runtime, rec, []string{"age", "city"}, nil); err == nil {
+		t.Error("FromRecordMatrix() with a string column succeeded, want error")
+	}
+	if _, err := FromRecordMatrix(runtime, rec, []string{"height"}, nil); err == nil {
+		t.Error("FromRecordMatrix() with a missing column succeeded, want error")
+	}
+
+	v, err := FromRecordMatrix(runtime, rec, []string{"income", "age"}, &Options{FillNulls: true})
+	if err != nil {
+		t.Fatalf("FromRecordMatrix() error = %v", err)
+	}
+	defer v.Close()
+
+	data, shape, err := ort.GetTensorData[float32](v)
+	if err != nil {
+		t.Fatalf("GetTensorData() error = %v", err)
+	}
+	if !slices.Equal(shape, []int64{3, 2}) {
+		t.Errorf("shape = %v, want [3 2]", shape)
+	}
+	if data[0] != 52000 || data[1] != 31 || !math.IsNaN(float64(data[2])) || data[3] != 45 || data[4] != 38500 || data[5] != 27 {
+		t.Errorf("data = %v, want [52000 31 NaN 45 38500 27]", data)
+	}
+}
+
+func TestToRecord(t *testing.T) {
+	runtime := newTestRuntime(t)
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	label, err := ort.NewTensorValue(runtime, []int64{1, 0}, []int64{2})
+	if err != nil {
+		t.Fatalf("NewTensorValue() error = %v", err)
+	}
+	defer label.Close()
+	probs, err := ort.NewTensorValue(runtime, []float32{0.2, 0.8, 0.9, 0.1}, []int64{2, 2})
+	if err != nil {
+		t.Fatalf("NewTensorValue() error = %v", err)
+	}
+	defer probs.Close()
+	other, err := ort.NewTensorValue(runtime, []float32{1, 2, 3}, []int64{3})
+	if err != nil {
+		t.Fatalf("NewTensorValue() error = %v", err)
+	}
+	defer other.Close()
+
+	outputs := map[string]*ort.Value{"label": label, "probabilities": probs, "other": other}
+	if _, err := ToRecord(mem, outputs, nil); err == nil {
+		t.Error("ToRecord() with mismatched rows succeeded, want error")
+	}
+	if _, err := ToRecord(mem, outputs, []string{"missing"}); err == nil {
+		t.Error("ToRecord() with a missing output succeeded, want error")
+	}
+
+	rec, err := ToRecord(mem, outputs, []string{"label", "probabilities"})
+	if err != nil {
+		t.Fatalf("ToRecord() error = %v", err)
+	}
+	defer rec.Release()
+
+	if rec.NumRows() != 2 || rec.NumCols() != 2 {
+		t.Fatalf("ToRecord() = %d rows x %d cols, want 2 x 2", rec.NumRows(), rec.NumCols())
+	}
+	labels := rec.Column(0).(*array.Int64).Int64Values()
+	if !slices.Equal(labels, []int64{1, 0}) {
+		t.Errorf("label = %v, want [1 0]", labels)
+	}
+	list := rec.Column(1).(*array.FixedSizeList)
+	values := list.ListValues().(*array.Float32).Float32Values()
+	if !slices.Equal(values, []float32{0.2, 0.8, 0.9, 0.1}) {
+		t.Errorf("probabilities = %v", values)
+	}
+}
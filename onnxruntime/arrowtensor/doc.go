@@ -0,0 +1,28 @@
+// Package arrowtensor converts between Apache Arrow columns and ONNX Runtime
+// tensors, for tabular models fed from Parquet files, Arrow Flight, or other
+// Arrow sources.
+//
+// FromRecord turns each column of a record batch into a [rows, 1] input
+// tensor named after its field, the layout scikit-learn and other tabular
+// converters give their inputs, and FromRecordMatrix stacks numeric columns
+// into one [rows, columns] float32 feature tensor. Float32, float64, int32,
+// and int64 columns without nulls are used in place rather than copied, so
+// the record must not be released until the tensors are closed. Boolean and
+// string columns are copied.
+//
+// In the other direction, ToArray and ToRecord turn output tensors back into
+// Arrow arrays, with [rows, n] outputs such as class probabilities as
+// fixed-size lists.
+//
+// Example:
+//
+//	rec, err := reader.Read() // from a Parquet or IPC reader
+//	...
+//	inputs, err := arrowtensor.FromRecord(runtime, rec, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	outputs, err := session.Run(ctx, inputs)
+//	...
+//	result, err := arrowtensor.ToRecord(memory.DefaultAllocator, outputs, []string{"label", "probabilities"})
+package arrowtensor
@@ -0,0 +1,151 @@
+package arrowtensor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Options configures conversions from Arrow.
+type Options struct {
+	// FillNulls replaces nulls with NaN in float columns, zero in integer
+	// columns, false in boolean columns, and "" in string columns. Without
+	// it, columns with nulls are rejected.
+	FillNulls bool
+}
+
+// FromArray creates a [len, 1] tensor from an Arrow column. Float32,
+// float64, int32, int64, and bool columns become tensors of the same
+// element type, and string and large string columns become string tensors.
+// Numeric columns without nulls share the column's memory, which must stay
+// valid until the tensor is closed. opts may be nil.
+func FromArray(r *ort.Runtime, arr arrow.Array, opts *Options) (*ort.Value, error) {
+	shape := []int64{int64(arr.Len()), 1}
+	if arr.NullN() > 0 && (opts == nil || !opts.FillNulls) {
+		return nil, fmt.Errorf("column of %s has %d nulls", arr.DataType(), arr.NullN())
+	}
+
+	switch a := arr.(type) {
+	case *array.Float32:
+		return numericValue(r, a, a.Float32Values(), arrow.Float32Traits.CastToBytes, float32(math.NaN()), ort.ONNXTensorElementDataTypeFloat, shape)
+	case *array.Float64:
+		return numericValue(r, a, a.Float64Values(), arrow.Float64Traits.CastToBytes, math.NaN(), ort.ONNXTensorElementDataTypeDouble, shape)
+	case *array.Int32:
+		return numericValue(r, a, a.Int32Values(), arrow.Int32Traits.CastToBytes, 0, ort.ONNXTensorElementDataTypeInt32, shape)
+	case *array.Int64:
+		return numericValue(r, a, a.Int64Values(), arrow.Int64Traits.CastToBytes, 0, ort.ONNXTensorElementDataTypeInt64, shape)
+	case *array.Boolean:
+		data := make([]byte, a.Len())
+		for i := range data {
+			if a.IsValid(i) && a.Value(i) {
+				data[i] = 1
+			}
+		}
+		return ort.NewTensorValueFromBytes(r, data, shape, ort.ONNXTensorElementDataTypeBool)
+	case *array.String:
+		return stringValue(r, a, a.Value, shape)
+	case *array.LargeString:
+		return stringValue(r, a, a.Value, shape)
+	default:
+		return nil, fmt.Errorf("unsupported column type %s", arr.DataType())
+	}
+}
+
+// numericValue creates a tensor from the values of a numeric column, in
+// place when it has no nulls.
+func numericValue[T float32 | float64 | int32 | int64](r *ort.Runtime, arr arrow.Array, values []T, toBytes func([]T) []byte, fill T, elemType ort.ONNXTensorElementDataType, shape []int64) (*ort.Value, error) {
+	if arr.NullN() > 0 {
+		filled := make([]T, len(values))
+		for i, v := range values {
+			if arr.IsNull(i) {
+				v = fill
+			}
+			filled[i] = v
+		}
+		values = filled
+	}
+	return ort.NewTensorValueFromBytes(r, toBytes(values), shape, elemType)
+}
+
+func stringValue(r *ort.Runtime, arr arrow.Array, value func(int) string, shape []int64) (*ort.Value, error) {
+	strs := make([]string, arr.Len())
+	for i := range strs {
+		if arr.IsValid(i) {
+			strs[i] = value(i)
+		}
+	}
+	return r.NewStringTensorValue(strs, shape)
+}
+
+// FromRecord creates a [rows, 1] tensor from each column of rec, keyed by
+// field name, as FromArray does. opts may be nil.
+func FromRecord(r *ort.Runtime, rec arrow.RecordBatch, opts *Options) (map[string]*ort.Value, error) {
+	values := make(map[string]*ort.Value, rec.NumCols())
+	for i, field := range rec.Schema().Fields() {
+		if _, ok := values[field.Name]; ok {
+			closeValues(values)
+			return nil, fmt.Errorf("duplicate column %q", field.Name)
+		}
+		v, err := FromArray(r, rec.Column(i), opts)
+		if err != nil {
+			closeValues(values)
+			return nil, fmt.Errorf("column %q: %w", field.Name, err)
+		}
+		values[field.Name] = v
+	}
+	return values, nil
+}
+
+// FromRecordMatrix creates a [rows, len(columns)] float32 tensor from the
+// named numeric columns of rec, converting float64, int32, and int64 values
+// to float32. opts may be nil.
+func FromRecordMatrix(r *ort.Runtime, rec arrow.RecordBatch, columns []string, opts *Options) (*ort.Value, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	rows, cols := int(rec.NumRows()), len(columns)
+	data := make([]float32, rows*cols)
+	for j, name := range columns {
+		indices := rec.Schema().FieldIndices(name)
+		if len(indices) != 1 {
+			return nil, fmt.Errorf("column %q not found or ambiguous", name)
+		}
+		arr := rec.Column(indices[0])
+		if arr.NullN() > 0 && (opts == nil || !opts.FillNulls) {
+			return nil, fmt.Errorf("column %q has %d nulls", name, arr.NullN())
+		}
+
+		var at func(int) float32
+		switch a := arr.(type) {
+		case *array.Float32:
+			at = a.Value
+		case *array.Float64:
+			at = func(i int) float32 { return float32(a.Value(i)) }
+		case *array.Int32:
+			at = func(i int) float32 { return float32(a.Value(i)) }
+		case *array.Int64:
+			at = func(i int) float32 { return float32(a.Value(i)) }
+		default:
+			return nil, fmt.Errorf("column %q of %s is not numeric", name, arr.DataType())
+		}
+		nan := float32(math.NaN())
+		for i := range rows {
+			v := nan
+			if arr.IsValid(i) {
+				v = at(i)
+			}
+			data[i*cols+j] = v
+		}
+	}
+	return ort.NewTensorValueFromBytes(r, arrow.Float32Traits.CastToBytes(data), []int64{int64(rows), int64(cols)}, ort.ONNXTensorElementDataTypeFloat)
+}
+
+func closeValues(values map[string]*ort.Value) {
+	for _, v := range values {
+		v.Close()
+	}
+}
@@ -0,0 +1,170 @@
+package arrowtensor
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// ToArray converts a tensor to an Arrow array of rows. Scalars, vectors, and
+// [rows, 1] tensors become flat arrays, and [rows, n] tensors become
+// fixed-size lists of n elements. Float16 and bfloat16 tensors are widened to
+// float32. The array is built with mem, or the default allocator if nil, and
+// the caller must release it.
+func ToArray(mem memory.Allocator, v *ort.Value) (arrow.Array, error) {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, err
+	}
+
+	var values any
+	var shape []int64
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeFloat, ort.ONNXTensorElementDataTypeFloat16, ort.ONNXTensorElementDataTypeBFloat16:
+		values, shape, err = ort.GetTensorDataAsFloat32(v)
+	case ort.ONNXTensorElementDataTypeDouble:
+		values, shape, err = ort.GetTensorData[float64](v)
+	case ort.ONNXTensorElementDataTypeInt32:
+		values, shape, err = ort.GetTensorData[int32](v)
+	case ort.ONNXTensorElementDataTypeInt64:
+		values, shape, err = ort.GetTensorData[int64](v)
+	case ort.ONNXTensorElementDataTypeBool:
+		values, shape, err = ort.GetTensorData[bool](v)
+	case ort.ONNXTensorElementDataTypeString:
+		values, shape, err = ort.GetStringTensorData(v)
+	default:
+		return nil, fmt.Errorf("unsupported tensor element type %d", elemType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newArray(mem, values, shape)
+}
+
+// newArray builds an array of rows from tensor elements in row-major order.
+func newArray(mem memory.Allocator, values any, shape []int64) (arrow.Array, error) {
+	width, err := rowWidth(shape)
+	if err != nil {
+		return nil, err
+	}
+	if width == 1 {
+		return flatArray(mem, values), nil
+	}
+
+	flat := flatArray(mem, values)
+	defer flat.Release()
+	rows := flat.Len() / int(width)
+	listType := arrow.FixedSizeListOf(int32(width), flat.DataType())
+	data := array.NewData(listType, rows, []*memory.Buffer{nil}, []arrow.ArrayData{flat.Data()}, 0, 0)
+	defer data.Release()
+	return array.NewFixedSizeListData(data), nil
+}
+
+// rowWidth returns the number of elements per row of a tensor: 1 for
+// scalars, vectors, and [rows, 1] tensors, and n for [rows, n] tensors.
+func rowWidth(shape []int64) (int64, error) {
+	switch {
+	case len(shape) <= 1:
+		return 1, nil
+	case len(shape) == 2:
+		if shape[1] < 1 {
+			return 0, fmt.Errorf("unsupported shape %v", shape)
+		}
+		return shape[1], nil
+	default:
+		return 0, fmt.Errorf("unsupported shape %v: only rank 0 to 2 tensors convert to Arrow", shape)
+	}
+}
+
+// flatArray builds a flat array from one of the slice types ToArray reads.
+func flatArray(mem memory.Allocator, values any) arrow.Array {
+	switch d := values.(type) {
+	case []float32:
+		b := array.NewFloat32Builder(mem)
+		defer b.Release()
+		b.AppendValues(d, nil)
+		return b.NewArray()
+	case []float64:
+		b := array.NewFloat64Builder(mem)
+		defer b.Release()
+		b.AppendValues(d, nil)
+		return b.NewArray()
+	case []int32:
+		b := array.NewInt32Builder(mem)
+		defer b.Release()
+		b.AppendValues(d, nil)
+		return b.NewArray()
+	case []int64:
+		b := array.NewInt64Builder(mem)
+		defer b.Release()
+		b.AppendValues(d, nil)
+		return b.NewArray()
+	case []bool:
+		b := array.NewBooleanBuilder(mem)
+		defer b.Release()
+		b.AppendValues(d, nil)
+		return b.NewArray()
+	case []string:
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(d, nil)
+		return b.NewArray()
+	default:
+		panic(fmt.Sprintf("arrowtensor: unexpected slice type %T", values))
+	}
+}
+
+// ToRecord converts outputs to a record batch with one column per tensor,
+// as ToArray does, in the order given by names. If names is nil, all
+// outputs are included in sorted order. Every column must have the same
+// number of rows. The caller must release the record.
+func ToRecord(mem memory.Allocator, outputs map[string]*ort.Value, names []string) (arrow.RecordBatch, error) {
+	if names == nil {
+		names = make([]string, 0, len(outputs))
+		for name := range outputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	fields := make([]arrow.Field, 0, len(names))
+	cols := make([]arrow.Array, 0, len(names))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	for _, name := range names {
+		v, ok := outputs[name]
+		if !ok {
+			return nil, fmt.Errorf("output %q not found", name)
+		}
+		if slices.ContainsFunc(fields, func(f arrow.Field) bool { return f.Name == name }) {
+			return nil, fmt.Errorf("duplicate output %q", name)
+		}
+		col, err := ToArray(mem, v)
+		if err != nil {
+			return nil, fmt.Errorf("output %q: %w", name, err)
+		}
+		cols = append(cols, col)
+		if col.Len() != cols[0].Len() {
+			return nil, fmt.Errorf("output %q has %d rows, %q has %d", name, col.Len(), names[0], cols[0].Len())
+		}
+		fields = append(fields, arrow.Field{Name: name, Type: col.DataType()})
+	}
+
+	var rows int64
+	if len(cols) > 0 {
+		rows = int64(cols[0].Len())
+	}
+	return array.NewRecordBatch(arrow.NewSchema(fields, nil), cols, rows), nil
+}
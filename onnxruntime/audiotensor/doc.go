@@ -0,0 +1,12 @@
+// Package audiotensor converts between PCM audio and ONNX Runtime tensors.
+//
+// Speech and audio models (TTS vocoders, source separation, enhancement)
+// return waveforms as float tensors. The helpers in this package turn those
+// output Values into interleaved float32 or 16-bit PCM buffers and can write
+// them as WAV files.
+//
+// In the other direction, ReadWAV and DecodePCM16 decode audio, Resample
+// converts it to the rate a model expects, and FromPCM and FromLogMel create
+// waveform and log-mel spectrogram input tensors, the latter for
+// Whisper-style speech recognition encoders.
+package audiotensor
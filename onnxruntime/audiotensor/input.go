@@ -0,0 +1,239 @@
+package audiotensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// WAV format codes.
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// ReadWAV decodes a WAV stream into PCM. It supports integer PCM with 8, 16,
+// 24, or 32 bits per sample and IEEE float with 32 or 64 bits, including
+// WAVE_FORMAT_EXTENSIBLE files. Chunks other than fmt and data are skipped.
+func ReadWAV(r io.Reader) (*PCM, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WAV: %w", err)
+	}
+	if len(buf) < 12 || string(buf[0:4]) != "RIFF" || string(buf[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a RIFF WAVE stream")
+	}
+
+	var format, channels, bits int
+	var sampleRate int
+	var haveFmt bool
+	for rest := buf[12:]; len(rest) >= 8; {
+		id := string(rest[0:4])
+		size := int(binary.LittleEndian.Uint32(rest[4:8]))
+		rest = rest[8:]
+		// Streamed files may leave the data size unset; clamp to what was read.
+		if size < 0 || size > len(rest) {
+			size = len(rest)
+		}
+		chunk := rest[:size]
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("fmt chunk too short")
+			}
+			format = int(binary.LittleEndian.Uint16(chunk[0:]))
+			channels = int(binary.LittleEndian.Uint16(chunk[2:]))
+			sampleRate = int(binary.LittleEndian.Uint32(chunk[4:]))
+			bits = int(binary.LittleEndian.Uint16(chunk[14:]))
+			if format == wavFormatExtensible && size >= 26 {
+				// The sub-format GUID starts with the actual format code.
+				format = int(binary.LittleEndian.Uint16(chunk[24:]))
+			}
+			haveFmt = true
+		case "data":
+			if !haveFmt {
+				return nil, fmt.Errorf("data chunk before fmt chunk")
+			}
+			return decodeWAVData(chunk, format, bits, channels, sampleRate)
+		}
+
+		// Chunks are padded to an even size.
+		rest = rest[min(size+size%2, len(rest)):]
+	}
+	return nil, fmt.Errorf("WAV stream has no data chunk")
+}
+
+// decodeWAVData converts the body of a data chunk to normalized samples.
+func decodeWAVData(data []byte, format, bits, channels, sampleRate int) (*PCM, error) {
+	if channels <= 0 {
+		return nil, fmt.Errorf("invalid channel count %d", channels)
+	}
+
+	var decode func([]byte) float32
+	switch {
+	case format == wavFormatPCM && bits == 8:
+		decode = func(b []byte) float32 { return (float32(b[0]) - 128) / 128 }
+	case format == wavFormatPCM && bits == 16:
+		decode = func(b []byte) float32 { return float32(int16(binary.LittleEndian.Uint16(b))) / 32768 }
+	case format == wavFormatPCM && bits == 24:
+		decode = func(b []byte) float32 {
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			return float32(v) / 8388608
+		}
+	case format == wavFormatPCM && bits == 32:
+		decode = func(b []byte) float32 { return float32(int32(binary.LittleEndian.Uint32(b))) / 2147483648 }
+	case format == wavFormatFloat && bits == 32:
+		decode = func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) }
+	case format == wavFormatFloat && bits == 64:
+		decode = func(b []byte) float32 { return float32(math.Float64frombits(binary.LittleEndian.Uint64(b))) }
+	default:
+		return nil, fmt.Errorf("unsupported WAV format %d with %d bits per sample", format, bits)
+	}
+
+	width := bits / 8
+	frames := len(data) / (width * channels)
+	samples := make([]float32, frames*channels)
+	for i := range samples {
+		samples[i] = decode(data[i*width:])
+	}
+	return &PCM{Samples: samples, Channels: channels, SampleRate: sampleRate}, nil
+}
+
+// DecodePCM16 converts headerless little-endian signed 16-bit PCM, the
+// s16le format of most audio capture APIs, into PCM.
+func DecodePCM16(data []byte, channels, sampleRate int) (*PCM, error) {
+	if channels <= 0 {
+		return nil, fmt.Errorf("invalid channel count %d", channels)
+	}
+	if len(data)%(2*channels) != 0 {
+		return nil, fmt.Errorf("data length %d is not a whole number of %d-channel frames", len(data), channels)
+	}
+	raw := make([]int16, len(data)/2)
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, raw); err != nil {
+		return nil, err
+	}
+	samples := make([]float32, len(raw))
+	for i, s := range raw {
+		samples[i] = float32(s) / 32768
+	}
+	return &PCM{Samples: samples, Channels: channels, SampleRate: sampleRate}, nil
+}
+
+// Mono returns p mixed down to one channel by averaging, or p itself if it
+// is already mono.
+func (p *PCM) Mono() *PCM {
+	if p.Channels == 1 {
+		return p
+	}
+	frames := p.Frames()
+	samples := make([]float32, frames)
+	for i := range samples {
+		var sum float32
+		for _, s := range p.Samples[i*p.Channels : (i+1)*p.Channels] {
+			sum += s
+		}
+		samples[i] = sum / float32(p.Channels)
+	}
+	return &PCM{Samples: samples, Channels: 1, SampleRate: p.SampleRate}
+}
+
+// Resampling kernel: a Hann-windowed sinc spanning resampleZeros zero
+// crossings on each side, tabulated at resampleResolution points per unit.
+const (
+	resampleZeros      = 16
+	resampleResolution = 512
+)
+
+var resampleKernel = func() []float64 {
+	k := make([]float64, resampleZeros*resampleResolution+2)
+	for i := range k {
+		x := float64(i) / resampleResolution
+		if x >= resampleZeros {
+			continue
+		}
+		w := 0.5 + 0.5*math.Cos(math.Pi*x/resampleZeros)
+		if x == 0 {
+			k[i] = 1
+		} else {
+			k[i] = w * math.Sin(math.Pi*x) / (math.Pi * x)
+		}
+	}
+	return k
+}()
+
+// kernelAt returns the interpolated resampling kernel at x.
+func kernelAt(x float64) float64 {
+	x = math.Abs(x) * resampleResolution
+	i := int(x)
+	if i >= len(resampleKernel)-1 {
+		return 0
+	}
+	f := x - float64(i)
+	return resampleKernel[i]*(1-f) + resampleKernel[i+1]*f
+}
+
+// Resample converts p to sampleRate with band-limited (windowed sinc)
+// interpolation, low-pass filtering when downsampling to avoid aliasing.
+// It returns p itself if the rate already matches.
+func (p *PCM) Resample(sampleRate int) (*PCM, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("invalid target sample rate %d", sampleRate)
+	}
+	if p.SampleRate <= 0 {
+		return nil, fmt.Errorf("invalid source sample rate %d", p.SampleRate)
+	}
+	if p.Channels <= 0 {
+		return nil, fmt.Errorf("invalid channel count %d", p.Channels)
+	}
+	if sampleRate == p.SampleRate {
+		return p, nil
+	}
+
+	ratio := float64(sampleRate) / float64(p.SampleRate)
+	cutoff := min(1, ratio) // relative to the source Nyquist frequency
+	half := resampleZeros / cutoff
+	frames := p.Frames()
+	outFrames := int(math.Round(float64(frames) * ratio))
+
+	out := make([]float32, outFrames*p.Channels)
+	acc := make([]float64, p.Channels)
+	for i := range outFrames {
+		t := float64(i) / ratio
+		lo := max(int(math.Ceil(t-half)), 0)
+		hi := min(int(math.Floor(t+half)), frames-1)
+		clear(acc)
+		for j := lo; j <= hi; j++ {
+			w := cutoff * kernelAt(cutoff*(t-float64(j)))
+			for c := range acc {
+				acc[c] += w * float64(p.Samples[j*p.Channels+c])
+			}
+		}
+		for c, v := range acc {
+			out[i*p.Channels+c] = float32(v)
+		}
+	}
+	return &PCM{Samples: out, Channels: p.Channels, SampleRate: sampleRate}, nil
+}
+
+// FromPCM converts p into a float32 waveform tensor of shape [Channels,
+// Frames], the layout ToPCM reads, for models that take raw audio. The
+// caller must close the returned Value.
+func FromPCM(r *ort.Runtime, p *PCM) (*ort.Value, error) {
+	if p.Channels <= 0 {
+		return nil, fmt.Errorf("invalid channel count %d", p.Channels)
+	}
+	frames := p.Frames()
+	data := make([]float32, p.Channels*frames)
+	for i := range frames {
+		for c := range p.Channels {
+			data[c*frames+i] = p.Samples[i*p.Channels+c]
+		}
+	}
+	return ort.NewTensorValue(r, data, []int64{int64(p.Channels), int64(frames)})
+}
@@ -0,0 +1,188 @@
+package audiotensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// sine returns frames samples of a mono sine wave at freq Hz.
+func sine(freq float64, sampleRate, frames int) *PCM {
+	samples := make([]float32, frames)
+	for i := range samples {
+		samples[i] = float32(0.5 * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+	}
+	return &PCM{Samples: samples, Channels: 1, SampleRate: sampleRate}
+}
+
+// wavFile builds a WAV stream with the given fmt chunk fields and data,
+// preceded by an unrelated LIST chunk of odd size.
+func wavFile(format, channels, sampleRate, bits int, data []byte) []byte {
+	var b bytes.Buffer
+	le := binary.LittleEndian
+	b.WriteString("RIFF")
+	binary.Write(&b, le, uint32(0)) // size is not checked
+	b.WriteString("WAVE")
+	b.WriteString("LIST")
+	binary.Write(&b, le, uint32(3))
+	b.Write([]byte{1, 2, 3, 0}) // padded to an even size
+	b.WriteString("fmt ")
+	binary.Write(&b, le, uint32(16))
+	binary.Write(&b, le, uint16(format))
+	binary.Write(&b, le, uint16(channels))
+	binary.Write(&b, le, uint32(sampleRate))
+	binary.Write(&b, le, uint32(sampleRate*channels*bits/8))
+	binary.Write(&b, le, uint16(channels*bits/8))
+	binary.Write(&b, le, uint16(bits))
+	b.WriteString("data")
+	binary.Write(&b, le, uint32(len(data)))
+	b.Write(data)
+	return b.Bytes()
+}
+
+func TestReadWAVRoundTrip(t *testing.T) {
+	pcm := &PCM{Samples: []float32{0, 0.5, -0.5, 0.25}, Channels: 2, SampleRate: 22050}
+	var buf bytes.Buffer
+	if err := pcm.WriteWAV(&buf); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+
+	got, err := ReadWAV(&buf)
+	if err != nil {
+		t.Fatalf("ReadWAV failed: %v", err)
+	}
+	if got.Channels != 2 || got.SampleRate != 22050 {
+		t.Errorf("Expected 2 channels at 22050 Hz, got %d at %d", got.Channels, got.SampleRate)
+	}
+	if !slices.EqualFunc(got.Samples, pcm.Samples, func(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-4 }) {
+		t.Errorf("Expected samples %v, got %v", pcm.Samples, got.Samples)
+	}
+}
+
+func TestReadWAVFormats(t *testing.T) {
+	float32Data := make([]byte, 8)
+	binary.LittleEndian.PutUint32(float32Data, math.Float32bits(0.5))
+	binary.LittleEndian.PutUint32(float32Data[4:], math.Float32bits(-1))
+
+	tests := []struct {
+		name   string
+		format int
+		bits   int
+		data   []byte
+		want   []float32
+	}{
+		{"uint8", wavFormatPCM, 8, []byte{128, 0, 192}, []float32{0, -1, 0.5}},
+		{"int24", wavFormatPCM, 24, []byte{0, 0, 0x40, 0, 0, 0xC0}, []float32{0.5, -0.5}},
+		{"int32", wavFormatPCM, 32, []byte{0, 0, 0, 0x40}, []float32{0.5}},
+		{"float32", wavFormatFloat, 32, float32Data, []float32{0.5, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcm, err := ReadWAV(bytes.NewReader(wavFile(tt.format, 1, 8000, tt.bits, tt.data)))
+			if err != nil {
+				t.Fatalf("ReadWAV failed: %v", err)
+			}
+			if !slices.Equal(pcm.Samples, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, pcm.Samples)
+			}
+		})
+	}
+
+	if _, err := ReadWAV(bytes.NewReader(wavFile(2, 1, 8000, 4, []byte{0}))); err == nil {
+		t.Error("Expected an error for ADPCM")
+	}
+	if _, err := ReadWAV(bytes.NewReader([]byte("not a wav file"))); err == nil {
+		t.Error("Expected an error for a non-WAV stream")
+	}
+}
+
+func TestDecodePCM16(t *testing.T) {
+	pcm, err := DecodePCM16([]byte{0, 0x40, 0, 0xC0}, 2, 16000)
+	if err != nil {
+		t.Fatalf("DecodePCM16 failed: %v", err)
+	}
+	if !slices.Equal(pcm.Samples, []float32{0.5, -0.5}) || pcm.Frames() != 1 {
+		t.Errorf("Unexpected PCM %+v", pcm)
+	}
+	if _, err := DecodePCM16([]byte{0, 0, 0}, 1, 16000); err == nil {
+		t.Error("Expected an error for a partial frame")
+	}
+}
+
+func TestMono(t *testing.T) {
+	pcm := &PCM{Samples: []float32{1, 0, 0.5, 0.5}, Channels: 2, SampleRate: 8000}
+	mono := pcm.Mono()
+	if mono.Channels != 1 || !slices.Equal(mono.Samples, []float32{0.5, 0.5}) {
+		t.Errorf("Unexpected mono mix %+v", mono)
+	}
+	if mono.Mono() != mono {
+		t.Error("Expected Mono of mono audio to return it unchanged")
+	}
+}
+
+// rms returns the root mean square of samples[from:to].
+func rms(samples []float32, from, to int) float64 {
+	var sum float64
+	for _, s := range samples[from:to] {
+		sum += float64(s) * float64(s)
+	}
+	return math.Sqrt(sum / float64(to-from))
+}
+
+func TestResample(t *testing.T) {
+	// A 440 Hz tone survives 44.1 kHz -> 16 kHz with its shape intact.
+	src := sine(440, 44100, 44100)
+	got, err := src.Resample(16000)
+	if err != nil {
+		t.Fatalf("Resample failed: %v", err)
+	}
+	if got.SampleRate != 16000 || got.Frames() != 16000 {
+		t.Fatalf("Expected 16000 frames at 16000 Hz, got %d at %d", got.Frames(), got.SampleRate)
+	}
+	want := sine(440, 16000, 16000)
+	for i := 1000; i < 15000; i++ {
+		if d := math.Abs(float64(got.Samples[i] - want.Samples[i])); d > 1e-2 {
+			t.Fatalf("sample %d: expected %v, got %v", i, want.Samples[i], got.Samples[i])
+		}
+	}
+
+	// A 12 kHz tone is above the new Nyquist frequency and is filtered out
+	// instead of aliasing to 4 kHz.
+	got, err = sine(12000, 44100, 44100).Resample(16000)
+	if err != nil {
+		t.Fatalf("Resample failed: %v", err)
+	}
+	if level := rms(got.Samples, 1000, 15000); level > 0.01 {
+		t.Errorf("Expected the tone above Nyquist to be removed, got RMS %v", level)
+	}
+
+	if same, _ := src.Resample(44100); same != src {
+		t.Error("Expected resampling to the same rate to return the input")
+	}
+	if _, err := (&PCM{Channels: 1}).Resample(16000); err == nil {
+		t.Error("Expected an error without a source sample rate")
+	}
+}
+
+func TestFromPCM(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	pcm := &PCM{Samples: []float32{1, 4, 2, 5, 3, 6}, Channels: 2, SampleRate: 16000}
+	v, err := FromPCM(runtime, pcm)
+	if err != nil {
+		t.Fatalf("FromPCM failed: %v", err)
+	}
+	defer v.Close()
+
+	data, shape, err := ort.GetTensorData[float32](v)
+	if err != nil {
+		t.Fatalf("Failed to read tensor: %v", err)
+	}
+	if !slices.Equal(shape, []int64{2, 3}) || !slices.Equal(data, []float32{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Expected planar [2 3] data, got %v of shape %v", data, shape)
+	}
+}
@@ -0,0 +1,321 @@
+package audiotensor
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// MelOptions configures log-mel spectrogram features. Zero fields take the
+// defaults of Whisper-style speech models.
+type MelOptions struct {
+	// SampleRate is the rate the model expects; audio at another rate is
+	// resampled. Zero means 16000.
+	SampleRate int
+
+	// NFFT is the STFT window and FFT length. Zero means 400 (25 ms at
+	// 16 kHz).
+	NFFT int
+
+	// HopLength is the number of samples between frames. Zero means 160
+	// (10 ms at 16 kHz).
+	HopLength int
+
+	// NMels is the number of mel bands. Zero means 80.
+	NMels int
+
+	// FMin and FMax bound the mel filter bank in Hz. Zero FMax means
+	// SampleRate/2.
+	FMin, FMax float64
+
+	// Frames, when positive, pads the audio with silence or truncates it so
+	// the spectrogram has exactly this many frames, as Whisper does for its
+	// 30-second windows of 3000 frames. When zero, every frame is kept.
+	Frames int
+
+	// WhisperScaling clamps the log values to at most 8 below their maximum
+	// and maps them with (x + 4) / 4, as Whisper's feature extractor does.
+	// Without it the features are log10 of the mel power, floored at 1e-10.
+	WhisperScaling bool
+}
+
+// WhisperMelOptions matches the feature extractor of Whisper models. For
+// models with 128 mel bands (large-v3), copy it and set NMels.
+var WhisperMelOptions = MelOptions{
+	SampleRate:     16000,
+	NFFT:           400,
+	HopLength:      160,
+	NMels:          80,
+	Frames:         3000,
+	WhisperScaling: true,
+}
+
+// withDefaults returns a copy of o with zero fields set to their defaults.
+func (o MelOptions) withDefaults() MelOptions {
+	if o.SampleRate == 0 {
+		o.SampleRate = 16000
+	}
+	if o.NFFT == 0 {
+		o.NFFT = 400
+	}
+	if o.HopLength == 0 {
+		o.HopLength = 160
+	}
+	if o.NMels == 0 {
+		o.NMels = 80
+	}
+	if o.FMax == 0 {
+		o.FMax = float64(o.SampleRate) / 2
+	}
+	return o
+}
+
+func (o *MelOptions) validate() error {
+	if o.SampleRate < 0 || o.NFFT < 2 || o.HopLength <= 0 || o.NMels < 0 || o.Frames < 0 {
+		return fmt.Errorf("invalid mel options %+v", *o)
+	}
+	if o.FMin < 0 || o.FMax <= o.FMin || o.FMax > float64(o.SampleRate)/2 {
+		return fmt.Errorf("mel frequency range [%g, %g] is invalid for sample rate %d", o.FMin, o.FMax, o.SampleRate)
+	}
+	return nil
+}
+
+// LogMelSpectrogram computes log-mel features of p, mixing it down to mono
+// and resampling it to opts.SampleRate first. It returns the features in
+// [NMels, frames] row-major order and the number of frames.
+//
+// The STFT uses a periodic Hann window over frames centered on multiples of
+// HopLength, with reflection padding at the edges, and the filter bank uses
+// Slaney-style mel scaling and area normalization, matching librosa's and
+// Whisper's defaults.
+func LogMelSpectrogram(p *PCM, opts *MelOptions) ([]float32, int, error) {
+	if opts == nil {
+		return nil, 0, fmt.Errorf("mel options are required")
+	}
+	o := opts.withDefaults()
+	if err := o.validate(); err != nil {
+		return nil, 0, err
+	}
+	if p.Channels <= 0 {
+		return nil, 0, fmt.Errorf("invalid channel count %d", p.Channels)
+	}
+
+	audio, err := p.Mono().Resample(o.SampleRate)
+	if err != nil {
+		return nil, 0, err
+	}
+	x := audio.Samples
+	frames := 1 + len(x)/o.HopLength
+	if o.Frames > 0 {
+		n := o.Frames * o.HopLength
+		if len(x) > n {
+			x = x[:n]
+		} else {
+			x = append(x[:len(x):len(x)], make([]float32, n-len(x))...)
+		}
+		// The frame centered on the final sample is dropped, as in Whisper.
+		frames = o.Frames
+	}
+
+	window := make([]float64, o.NFFT)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(o.NFFT))
+	}
+	filters := melFilters(o)
+	plan := newFFT(o.NFFT)
+	buf := make([]complex128, o.NFFT)
+	power := make([]float64, o.NFFT/2+1)
+	pad := o.NFFT / 2
+
+	out := make([]float32, o.NMels*frames)
+	for t := range frames {
+		for i := range buf {
+			buf[i] = complex(reflectAt(x, t*o.HopLength+i-pad)*window[i], 0)
+		}
+		plan.transform(buf)
+		for k := range power {
+			a := cmplx.Abs(buf[k])
+			power[k] = a * a
+		}
+		for m, f := range filters {
+			var sum float64
+			for k, w := range f.weights {
+				sum += w * power[f.start+k]
+			}
+			out[m*frames+t] = float32(math.Log10(max(sum, 1e-10)))
+		}
+	}
+
+	if o.WhisperScaling && len(out) > 0 {
+		top := out[0]
+		for _, v := range out {
+			top = max(top, v)
+		}
+		for i, v := range out {
+			out[i] = (max(v, top-8) + 4) / 4
+		}
+	}
+	return out, frames, nil
+}
+
+// FromLogMel computes the log-mel features of p and returns them as a
+// float32 tensor of shape [1, NMels, frames], the input of Whisper-style
+// encoders. The caller must close the returned Value.
+//
+// Example:
+//
+//	pcm, err := audiotensor.ReadWAV(file)
+//	features, err := audiotensor.FromLogMel(runtime, pcm, &audiotensor.WhisperMelOptions)
+func FromLogMel(r *ort.Runtime, p *PCM, opts *MelOptions) (*ort.Value, error) {
+	data, frames, err := LogMelSpectrogram(p, opts)
+	if err != nil {
+		return nil, err
+	}
+	nMels := len(data) / max(frames, 1)
+	return ort.NewTensorValue(r, data, []int64{1, int64(nMels), int64(frames)})
+}
+
+// reflectAt returns x[i], mirroring indices outside x about its ends and
+// treating indices still out of range (for very short audio) as silence.
+func reflectAt(x []float32, i int) float64 {
+	n := len(x)
+	if i < 0 {
+		i = -i
+	}
+	if i >= n {
+		i = 2*(n-1) - i
+	}
+	if i < 0 || i >= n {
+		return 0
+	}
+	return float64(x[i])
+}
+
+// melFilter is one triangular filter over the FFT bins from start on.
+type melFilter struct {
+	start   int
+	weights []float64
+}
+
+// melFilters builds the filter bank for o, like librosa.filters.mel with
+// its default Slaney scaling and normalization.
+func melFilters(o MelOptions) []melFilter {
+	bins := o.NFFT/2 + 1
+	lo, hi := hzToMel(o.FMin), hzToMel(o.FMax)
+	points := make([]float64, o.NMels+2)
+	for i := range points {
+		points[i] = melToHz(lo + (hi-lo)*float64(i)/float64(o.NMels+1))
+	}
+
+	filters := make([]melFilter, o.NMels)
+	for m := range filters {
+		left, center, right := points[m], points[m+1], points[m+2]
+		norm := 2 / (right - left)
+		f := &filters[m]
+		for k := range bins {
+			freq := float64(k) * float64(o.SampleRate) / float64(o.NFFT)
+			w := max(0, min((freq-left)/(center-left), (right-freq)/(right-center))) * norm
+			if w == 0 {
+				if len(f.weights) > 0 {
+					break
+				}
+				continue
+			}
+			if len(f.weights) == 0 {
+				f.start = k
+			}
+			f.weights = append(f.weights, w)
+		}
+	}
+	return filters
+}
+
+// Slaney mel scale: linear below 1 kHz and logarithmic above.
+const (
+	melLinearStep = 200.0 / 3
+	melLogStartHz = 1000.0
+	melLogStart   = melLogStartHz / melLinearStep
+)
+
+var melLogStep = math.Log(6.4) / 27
+
+func hzToMel(hz float64) float64 {
+	if hz < melLogStartHz {
+		return hz / melLinearStep
+	}
+	return melLogStart + math.Log(hz/melLogStartHz)/melLogStep
+}
+
+func melToHz(mel float64) float64 {
+	if mel < melLogStart {
+		return mel * melLinearStep
+	}
+	return melLogStartHz * math.Exp(melLogStep*(mel-melLogStart))
+}
+
+// fft computes discrete Fourier transforms of one length with a recursive
+// mixed-radix algorithm, which handles STFT sizes such as 400 that are not
+// powers of two. It is not safe for concurrent use.
+type fft struct {
+	n       int
+	twiddle []complex128
+	input   []complex128
+	scratch []complex128
+}
+
+func newFFT(n int) *fft {
+	f := &fft{
+		n:       n,
+		twiddle: make([]complex128, n),
+		input:   make([]complex128, n),
+		scratch: make([]complex128, n),
+	}
+	for k := range f.twiddle {
+		f.twiddle[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+	}
+	return f
+}
+
+// transform replaces x, of the plan's length, with its DFT.
+func (f *fft) transform(x []complex128) {
+	copy(f.input, x)
+	f.recurse(x, f.input, f.n, 1)
+}
+
+// recurse writes the DFT of the n elements src[0], src[stride], ... to dst.
+// It splits the input into p interleaved subsequences for the smallest
+// factor p of n and combines their transforms.
+func (f *fft) recurse(dst, src []complex128, n, stride int) {
+	if n == 1 {
+		dst[0] = src[0]
+		return
+	}
+	p := smallestFactor(n)
+	m := n / p
+	for r := range p {
+		f.recurse(dst[r*m:(r+1)*m], src[r*stride:], m, stride*p)
+	}
+
+	// The subsequence transforms are complete, so scratch is free to use.
+	tw := f.n / n
+	tmp := f.scratch[:n]
+	for k := range n {
+		var sum complex128
+		for r := range p {
+			sum += dst[r*m+k%m] * f.twiddle[(r*k*tw)%f.n]
+		}
+		tmp[k] = sum
+	}
+	copy(dst, tmp)
+}
+
+func smallestFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
@@ -0,0 +1,162 @@
+package audiotensor
+
+import (
+	"math"
+	"math/cmplx"
+	"slices"
+	"testing"
+)
+
+func TestFFTMatchesDFT(t *testing.T) {
+	for _, n := range []int{1, 2, 8, 12, 97, 400} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(math.Sin(float64(i)*0.7)+float64(i%3), math.Cos(float64(i)))
+		}
+		want := make([]complex128, n)
+		for k := range want {
+			for j, v := range x {
+				want[k] += v * cmplx.Rect(1, -2*math.Pi*float64(j*k)/float64(n))
+			}
+		}
+
+		got := slices.Clone(x)
+		newFFT(n).transform(got)
+		for k := range want {
+			if cmplx.Abs(got[k]-want[k]) > 1e-6*float64(n) {
+				t.Fatalf("n=%d: bin %d = %v, want %v", n, k, got[k], want[k])
+			}
+		}
+	}
+}
+
+func TestMelScale(t *testing.T) {
+	for _, hz := range []float64{0, 500, 1000, 4000, 8000} {
+		if got := melToHz(hzToMel(hz)); math.Abs(got-hz) > 1e-9 {
+			t.Errorf("melToHz(hzToMel(%v)) = %v", hz, got)
+		}
+	}
+	if mel := hzToMel(1000); math.Abs(mel-15) > 1e-12 {
+		t.Errorf("Expected 1 kHz at mel 15, got %v", mel)
+	}
+}
+
+func TestMelFilters(t *testing.T) {
+	filters := melFilters(WhisperMelOptions.withDefaults())
+	if len(filters) != 80 {
+		t.Fatalf("Expected 80 filters, got %d", len(filters))
+	}
+	prev := -1
+	for m, f := range filters {
+		if len(f.weights) == 0 {
+			t.Fatalf("filter %d is empty", m)
+		}
+		if f.start < prev {
+			t.Errorf("filter %d starts at bin %d, before filter %d", m, f.start, m-1)
+		}
+		prev = f.start
+		for _, w := range f.weights {
+			if w <= 0 {
+				t.Fatalf("filter %d has non-positive weight %v", m, w)
+			}
+		}
+	}
+}
+
+// peakBand returns the mel band with the most energy in frame t.
+func peakBand(data []float32, frames, t int) int {
+	best := 0
+	for m := range len(data) / frames {
+		if data[m*frames+t] > data[best*frames+t] {
+			best = m
+		}
+	}
+	return best
+}
+
+func TestLogMelSpectrogram(t *testing.T) {
+	opts := &MelOptions{}
+	low, frames, err := LogMelSpectrogram(sine(300, 16000, 16000), opts)
+	if err != nil {
+		t.Fatalf("LogMelSpectrogram failed: %v", err)
+	}
+	if frames != 101 || len(low) != 80*frames {
+		t.Fatalf("Expected 80x101 features, got %d values over %d frames", len(low), frames)
+	}
+	high, _, err := LogMelSpectrogram(sine(3000, 16000, 16000), opts)
+	if err != nil {
+		t.Fatalf("LogMelSpectrogram failed: %v", err)
+	}
+
+	// Filter m is centered on mel (m+1) * hzToMel(8000) / 81.
+	want := int(math.Round(hzToMel(300)/(hzToMel(8000)/81))) - 1
+	if band := peakBand(low, frames, 50); band != want {
+		t.Errorf("Expected the 300 Hz tone in band %d, got %d", want, band)
+	}
+	if peakBand(high, frames, 50) <= peakBand(low, frames, 50)+20 {
+		t.Error("Expected the 3 kHz tone in a much higher band")
+	}
+
+	// Audio at another rate is resampled to the same features.
+	resampled, _, err := LogMelSpectrogram(sine(300, 44100, 44100), opts)
+	if err != nil {
+		t.Fatalf("LogMelSpectrogram failed: %v", err)
+	}
+	if band := peakBand(resampled, frames, 50); band != peakBand(low, frames, 50) {
+		t.Errorf("Expected the resampled tone in band %d, got %d", peakBand(low, frames, 50), band)
+	}
+}
+
+func TestLogMelWhisper(t *testing.T) {
+	data, frames, err := LogMelSpectrogram(sine(440, 16000, 32000), &WhisperMelOptions)
+	if err != nil {
+		t.Fatalf("LogMelSpectrogram failed: %v", err)
+	}
+	if frames != 3000 || len(data) != 80*3000 {
+		t.Fatalf("Expected 80x3000 features, got %d values over %d frames", len(data), frames)
+	}
+	top := slices.Max(data)
+	for _, v := range data {
+		if v < top-2 {
+			t.Fatalf("Expected values within 2 of the maximum %v, got %v", top, v)
+		}
+	}
+	// Padding beyond the 2 seconds of audio is silence at the floor.
+	if v := data[3*frames+2500]; v != top-2 {
+		t.Errorf("Expected padded frames at the floor %v, got %v", top-2, v)
+	}
+}
+
+func TestLogMelOptionsValidation(t *testing.T) {
+	pcm := sine(440, 16000, 1600)
+	bad := []*MelOptions{
+		nil,
+		{NFFT: 1},
+		{HopLength: -1},
+		{FMax: 9000},
+		{FMin: 4000, FMax: 2000},
+	}
+	for _, opts := range bad {
+		if _, _, err := LogMelSpectrogram(pcm, opts); err == nil {
+			t.Errorf("Expected error for options %+v", opts)
+		}
+	}
+}
+
+func TestFromLogMel(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	v, err := FromLogMel(runtime, sine(440, 16000, 16000), &WhisperMelOptions)
+	if err != nil {
+		t.Fatalf("FromLogMel failed: %v", err)
+	}
+	defer v.Close()
+
+	shape, err := v.GetTensorShape()
+	if err != nil {
+		t.Fatalf("GetTensorShape failed: %v", err)
+	}
+	if !slices.Equal(shape, []int64{1, 80, 3000}) {
+		t.Errorf("Expected shape [1 80 3000], got %v", shape)
+	}
+}
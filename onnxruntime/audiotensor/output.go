@@ -0,0 +1,160 @@
+package audiotensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// PCM holds interleaved audio samples normalized to [-1, 1].
+type PCM struct {
+	// Samples are interleaved by channel: frame i of channel c is at i*Channels+c.
+	Samples []float32
+
+	// Channels is the number of interleaved channels.
+	Channels int
+
+	// SampleRate is the sample rate in Hz. It is informational for tensors and
+	// required when writing WAV output.
+	SampleRate int
+}
+
+// Frames returns the number of sample frames (samples per channel).
+func (p *PCM) Frames() int {
+	if p.Channels == 0 {
+		return 0
+	}
+	return len(p.Samples) / p.Channels
+}
+
+// Int16 converts the samples to signed 16-bit PCM, clamping to [-1, 1].
+func (p *PCM) Int16() []int16 {
+	out := make([]int16, len(p.Samples))
+	for i, s := range p.Samples {
+		out[i] = floatToInt16(s)
+	}
+	return out
+}
+
+// ToPCM converts a waveform tensor to interleaved PCM samples.
+//
+// The last dimension holds samples; the dimension before it (if any) holds
+// channels, and any leading dimensions must be 1. Accepted shapes therefore
+// include [N], [1, N], [C, N], and [1, C, N]. Float32, float16, bfloat16,
+// float64 and int16 tensors are supported; int16 data is scaled to [-1, 1].
+func ToPCM(v *ort.Value, sampleRate int) (*PCM, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, err
+	}
+
+	var data []float32
+	var shape []int64
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeFloat, ort.ONNXTensorElementDataTypeFloat16,
+		ort.ONNXTensorElementDataTypeBFloat16, ort.ONNXTensorElementDataTypeDouble:
+		data, shape, err = ort.GetTensorDataAsFloat32(v)
+	case ort.ONNXTensorElementDataTypeInt16:
+		var raw []int16
+		raw, shape, err = ort.GetTensorDataUnsafe[int16](v)
+		data = make([]float32, len(raw))
+		for i, s := range raw {
+			data[i] = float32(s) / 32768
+		}
+	default:
+		return nil, fmt.Errorf("unsupported element type %d for audio conversion", elemType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pcmFromPlanar(data, shape, sampleRate)
+}
+
+// pcmFromPlanar interleaves channel-major tensor data.
+func pcmFromPlanar(data []float32, shape []int64, sampleRate int) (*PCM, error) {
+	if len(shape) == 0 {
+		return nil, fmt.Errorf("audio tensor must have at least one dimension")
+	}
+
+	frames := int(shape[len(shape)-1])
+	channels := 1
+	if len(shape) >= 2 {
+		channels = int(shape[len(shape)-2])
+		for _, d := range shape[:len(shape)-2] {
+			if d != 1 {
+				return nil, fmt.Errorf("unsupported audio tensor shape %v: leading dimensions must be 1", shape)
+			}
+		}
+	}
+	if channels <= 0 || frames < 0 || channels*frames != len(data) {
+		return nil, fmt.Errorf("shape %v does not match data length %d", shape, len(data))
+	}
+
+	samples := make([]float32, len(data))
+	if channels == 1 {
+		copy(samples, data)
+	} else {
+		for c := 0; c < channels; c++ {
+			for i := 0; i < frames; i++ {
+				samples[i*channels+c] = data[c*frames+i]
+			}
+		}
+	}
+
+	return &PCM{Samples: samples, Channels: channels, SampleRate: sampleRate}, nil
+}
+
+func floatToInt16(s float32) int16 {
+	if s != s { // NaN
+		return 0
+	}
+	s = min(max(s, -1), 1)
+	return int16(math.Round(float64(s) * 32767))
+}
+
+// WriteWAV writes p as a 16-bit PCM WAV stream.
+func (p *PCM) WriteWAV(w io.Writer) error {
+	if p.Channels <= 0 {
+		return fmt.Errorf("invalid channel count %d", p.Channels)
+	}
+	if p.SampleRate <= 0 {
+		return fmt.Errorf("invalid sample rate %d", p.SampleRate)
+	}
+
+	const bitsPerSample = 16
+	dataSize := uint32(len(p.Samples) * 2)
+	blockAlign := uint16(p.Channels * bitsPerSample / 8)
+	byteRate := uint32(p.SampleRate) * uint32(blockAlign)
+
+	header := make([]byte, 44)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], 36+dataSize)
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16) // fmt chunk size
+	binary.LittleEndian.PutUint16(header[20:], 1)  // PCM
+	binary.LittleEndian.PutUint16(header[22:], uint16(p.Channels))
+	binary.LittleEndian.PutUint32(header[24:], uint32(p.SampleRate))
+	binary.LittleEndian.PutUint32(header[28:], byteRate)
+	binary.LittleEndian.PutUint16(header[32:], blockAlign)
+	binary.LittleEndian.PutUint16(header[34:], bitsPerSample)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], dataSize)
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write WAV header: %w", err)
+	}
+
+	body := make([]byte, dataSize)
+	for i, s := range p.Samples {
+		binary.LittleEndian.PutUint16(body[i*2:], uint16(floatToInt16(s)))
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write WAV data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package audiotensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func TestPCMFromPlanarMono(t *testing.T) {
+	pcm, err := pcmFromPlanar([]float32{0.1, 0.2, 0.3}, []int64{1, 3}, 16000)
+	if err != nil {
+		t.Fatalf("pcmFromPlanar failed: %v", err)
+	}
+	if pcm.Channels != 1 || pcm.Frames() != 3 {
+		t.Errorf("Expected 1 channel x 3 frames, got %d x %d", pcm.Channels, pcm.Frames())
+	}
+}
+
+func TestPCMFromPlanarStereoInterleaves(t *testing.T) {
+	// [1, 2, 3]: left = 1,2,3 ; right = 4,5,6
+	data := []float32{1, 2, 3, 4, 5, 6}
+	pcm, err := pcmFromPlanar(data, []int64{1, 2, 3}, 22050)
+	if err != nil {
+		t.Fatalf("pcmFromPlanar failed: %v", err)
+	}
+
+	want := []float32{1, 4, 2, 5, 3, 6}
+	for i, w := range want {
+		if pcm.Samples[i] != w {
+			t.Fatalf("Samples = %v, want %v", pcm.Samples, want)
+		}
+	}
+}
+
+func TestPCMFromPlanarRejectsBatch(t *testing.T) {
+	if _, err := pcmFromPlanar(make([]float32, 8), []int64{2, 2, 2}, 16000); err == nil {
+		t.Error("Expected error for batch size > 1")
+	}
+	if _, err := pcmFromPlanar(make([]float32, 5), []int64{2, 3}, 16000); err == nil {
+		t.Error("Expected error for shape mismatch")
+	}
+}
+
+func TestInt16Clamping(t *testing.T) {
+	pcm := &PCM{Samples: []float32{0, 1, -1, 2, -2}, Channels: 1}
+	got := pcm.Int16()
+	want := []int16{0, 32767, -32767, 32767, -32767}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("sample %d = %d, want %d", i, got[i], w)
+		}
+	}
+}
+
+func TestWriteWAV(t *testing.T) {
+	pcm := &PCM{Samples: []float32{0, 0.5, -0.5, 1}, Channels: 2, SampleRate: 16000}
+
+	var buf bytes.Buffer
+	if err := pcm.WriteWAV(&buf); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+
+	b := buf.Bytes()
+	if len(b) != 44+8 {
+		t.Fatalf("Expected 52 bytes, got %d", len(b))
+	}
+	if string(b[0:4]) != "RIFF" || string(b[8:12]) != "WAVE" || string(b[36:40]) != "data" {
+		t.Error("Invalid WAV header markers")
+	}
+	if ch := binary.LittleEndian.Uint16(b[22:]); ch != 2 {
+		t.Errorf("Expected 2 channels, got %d", ch)
+	}
+	if rate := binary.LittleEndian.Uint32(b[24:]); rate != 16000 {
+		t.Errorf("Expected sample rate 16000, got %d", rate)
+	}
+	if s := int16(binary.LittleEndian.Uint16(b[44+6:])); s != 32767 {
+		t.Errorf("Expected last sample 32767, got %d", s)
+	}
+}
+
+func TestWriteWAVRequiresSampleRate(t *testing.T) {
+	pcm := &PCM{Samples: []float32{0}, Channels: 1}
+	if err := pcm.WriteWAV(&bytes.Buffer{}); err == nil {
+		t.Error("Expected error for missing sample rate")
+	}
+}
+
+func TestToPCMFromValue(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	value, err := ort.NewTensorValue(runtime, []float32{0, 0.25, 0.5, 0.75}, []int64{1, 4})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer value.Close()
+
+	pcm, err := ToPCM(value, 24000)
+	if err != nil {
+		t.Fatalf("ToPCM failed: %v", err)
+	}
+	if pcm.Frames() != 4 || pcm.SampleRate != 24000 {
+		t.Errorf("Unexpected PCM: %d frames at %d Hz", pcm.Frames(), pcm.SampleRate)
+	}
+}
@@ -0,0 +1,258 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Runner executes inference on a set of named inputs.
+// [Session], [SessionPool] and [Model] all implement Runner.
+type Runner interface {
+	Run(ctx context.Context, inputs map[string]*Value, opts ...RunOption) (map[string]*Value, error)
+}
+
+// Batcher stacks individual requests into a single batched run.
+//
+// Each request is a map of input tensors whose first dimension is the batch
+// dimension (usually 1). Run concatenates every input along axis 0, executes
+// one inference, and splits each output back along axis 0 so that every
+// request receives outputs with its own batch size.
+//
+// All requests must provide the same input names, and corresponding tensors
+// must have the same element type and the same trailing dimensions. String
+// tensors are not supported.
+//
+// A Batcher is not safe for concurrent use; callers collecting requests from
+// multiple goroutines must synchronize access.
+//
+// Example:
+//
+//	b := onnxruntime.NewBatcher(runtime, session)
+//	for _, req := range requests {
+//	    b.Add(map[string]*onnxruntime.Value{"input": req.Tensor})
+//	}
+//	results, err := b.Run(ctx)
+//	// results[i] holds the outputs for requests[i]
+type Batcher struct {
+	runtime  *Runtime
+	runner   Runner
+	requests []map[string]*Value
+}
+
+// NewBatcher creates a Batcher that runs batches with runner.
+// The runtime is used to create the batched input and split output tensors.
+func NewBatcher(runtime *Runtime, runner Runner) *Batcher {
+	return &Batcher{
+		runtime: runtime,
+		runner:  runner,
+	}
+}
+
+// Add queues a request and returns its index in the results of the next Run.
+// The Batcher does not take ownership of the input values; they must stay
+// alive until Run returns.
+func (b *Batcher) Add(inputs map[string]*Value) int {
+	b.requests = append(b.requests, inputs)
+	return len(b.requests) - 1
+}
+
+// Len returns the number of queued requests.
+func (b *Batcher) Len() int {
+	return len(b.requests)
+}
+
+// Reset discards all queued requests.
+func (b *Batcher) Reset() {
+	clear(b.requests)
+	b.requests = b.requests[:0]
+}
+
+// Run executes all queued requests as a single batch and returns the outputs
+// for each request, in the order they were added. The queue is cleared even
+// if the run fails. The caller owns the returned values and must close them.
+func (b *Batcher) Run(ctx context.Context, opts ...RunOption) ([]map[string]*Value, error) {
+	requests := b.requests
+	defer b.Reset()
+
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	batchSizes, err := requestBatchSizes(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	batched, err := b.runtime.stackInputs(requests)
+	if err != nil {
+		return nil, err
+	}
+	defer closeValues(batched)
+
+	outputs, err := b.runner.Run(ctx, batched, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeValues(outputs)
+
+	return b.runtime.splitOutputs(outputs, batchSizes)
+}
+
+// requestBatchSizes returns the leading dimension of each request, checking
+// that every input within a request agrees on it.
+func requestBatchSizes(requests []map[string]*Value) ([]int64, error) {
+	sizes := make([]int64, len(requests))
+	for i, inputs := range requests {
+		if len(inputs) == 0 {
+			return nil, fmt.Errorf("request %d has no inputs", i)
+		}
+		sizes[i] = -1
+		for name, v := range inputs {
+			if v == nil {
+				return nil, fmt.Errorf("request %d: input %q is nil", i, name)
+			}
+			shape, err := v.GetTensorShape()
+			if err != nil {
+				return nil, fmt.Errorf("request %d: failed to get shape of input %q: %w", i, name, err)
+			}
+			if len(shape) == 0 {
+				return nil, fmt.Errorf("request %d: input %q is a scalar and has no batch dimension", i, name)
+			}
+			if sizes[i] >= 0 && shape[0] != sizes[i] {
+				return nil, fmt.Errorf("request %d: input %q has batch size %d, other inputs have %d", i, name, shape[0], sizes[i])
+			}
+			sizes[i] = shape[0]
+		}
+	}
+	return sizes, nil
+}
+
+// stackInputs concatenates each named input across requests along axis 0.
+func (r *Runtime) stackInputs(requests []map[string]*Value) (map[string]*Value, error) {
+	batched := make(map[string]*Value, len(requests[0]))
+	fail := func(err error) (map[string]*Value, error) {
+		for _, v := range batched {
+			v.Close()
+		}
+		return nil, err
+	}
+
+	for i, inputs := range requests {
+		if len(inputs) != len(requests[0]) {
+			return nil, fmt.Errorf("request %d has %d inputs, expected %d", i, len(inputs), len(requests[0]))
+		}
+	}
+
+	for name := range requests[0] {
+		values := make([]*Value, len(requests))
+		for i, inputs := range requests {
+			v, ok := inputs[name]
+			if !ok {
+				return fail(fmt.Errorf("request %d is missing input %q", i, name))
+			}
+			values[i] = v
+		}
+		v, err := r.concatTensors(values)
+		if err != nil {
+			return fail(fmt.Errorf("failed to stack input %q: %w", name, err))
+		}
+		batched[name] = v
+	}
+	return batched, nil
+}
+
+// concatTensors concatenates tensors along axis 0 into a new tensor.
+func (r *Runtime) concatTensors(values []*Value) (*Value, error) {
+	var (
+		dataType ONNXTensorElementDataType
+		shape    []int64
+		total    int
+	)
+	parts := make([][]byte, len(values))
+	for i, v := range values {
+		data, elemType, err := v.tensorBytes()
+		if err != nil {
+			return nil, err
+		}
+		vShape, err := v.GetTensorShape()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tensor shape: %w", err)
+		}
+		if i == 0 {
+			dataType = elemType
+			shape = slices.Clone(vShape)
+		} else {
+			if elemType != dataType {
+				return nil, fmt.Errorf("request %d has element type %d, expected %d", i, elemType, dataType)
+			}
+			if !slices.Equal(vShape[1:], shape[1:]) {
+				return nil, fmt.Errorf("request %d has shape %v, incompatible with %v", i, vShape, shape)
+			}
+			shape[0] += vShape[0]
+		}
+		parts[i] = data
+		total += len(data)
+	}
+
+	buf := make([]byte, 0, total)
+	for _, data := range parts {
+		buf = append(buf, data...)
+	}
+	return r.newTensorValueFromGoBytes(buf, shape, dataType)
+}
+
+// splitOutputs slices every output along axis 0 into per-request tensors.
+func (r *Runtime) splitOutputs(outputs map[string]*Value, batchSizes []int64) ([]map[string]*Value, error) {
+	var total int64
+	for _, n := range batchSizes {
+		total += n
+	}
+
+	results := make([]map[string]*Value, len(batchSizes))
+	for i := range results {
+		results[i] = make(map[string]*Value, len(outputs))
+	}
+	fail := func(err error) ([]map[string]*Value, error) {
+		for _, result := range results {
+			for _, v := range result {
+				v.Close()
+			}
+		}
+		return nil, err
+	}
+
+	for name, out := range outputs {
+		data, dataType, err := out.tensorBytes()
+		if err != nil {
+			return fail(fmt.Errorf("failed to read output %q: %w", name, err))
+		}
+		shape, err := out.GetTensorShape()
+		if err != nil {
+			return fail(fmt.Errorf("failed to get shape of output %q: %w", name, err))
+		}
+		if len(shape) == 0 || shape[0] != total {
+			return fail(fmt.Errorf("output %q has shape %v, expected leading batch dimension %d", name, shape, total))
+		}
+
+		var rowBytes int
+		if total > 0 {
+			rowBytes = len(data) / int(total)
+		}
+		offset := 0
+		for i, n := range batchSizes {
+			size := int(n) * rowBytes
+			part := slices.Clone(data[offset : offset+size])
+			offset += size
+
+			partShape := slices.Clone(shape)
+			partShape[0] = n
+			v, err := r.newTensorValueFromGoBytes(part, partShape, dataType)
+			if err != nil {
+				return fail(fmt.Errorf("failed to split output %q: %w", name, err))
+			}
+			results[i][name] = v
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,179 @@
+package onnxruntime
+
+import (
+	"slices"
+	"testing"
+)
+
+var (
+	_ Runner = (*Session)(nil)
+	_ Runner = (*SessionPool)(nil)
+	_ Runner = (*Model)(nil)
+)
+
+func TestBatcherEmpty(t *testing.T) {
+	b := NewBatcher(nil, nil)
+	results, err := b.Run(t.Context())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results != nil {
+		t.Errorf("Expected nil results, got %v", results)
+	}
+}
+
+func TestBatcherMatchesIndividualRuns(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	inputs := [][]float32{
+		{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		{-1, 0, 1, 0, -1, 0, 1, 0, -1, 0},
+		{0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5},
+	}
+
+	b := NewBatcher(runtime, session)
+	var expected [][]float32
+	for i, data := range inputs {
+		tensor, err := NewTensorValue(runtime, data, []int64{1, 10})
+		if err != nil {
+			t.Fatalf("Failed to create tensor: %v", err)
+		}
+		defer tensor.Close()
+
+		outputs, err := session.Run(t.Context(), map[string]*Value{"input": tensor})
+		if err != nil {
+			t.Fatalf("Failed to run inference: %v", err)
+		}
+		logits, _, err := GetTensorData[float32](outputs["logits"])
+		if err != nil {
+			t.Fatalf("Failed to get output data: %v", err)
+		}
+		for _, v := range outputs {
+			v.Close()
+		}
+		expected = append(expected, logits)
+
+		if idx := b.Add(map[string]*Value{"input": tensor}); idx != i {
+			t.Errorf("Add returned index %d, want %d", idx, i)
+		}
+	}
+
+	if b.Len() != len(inputs) {
+		t.Fatalf("Len = %d, want %d", b.Len(), len(inputs))
+	}
+
+	results, err := b.Run(t.Context())
+	if err != nil {
+		t.Fatalf("Batched run failed: %v", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Expected queue to be cleared after Run, Len = %d", b.Len())
+	}
+	if len(results) != len(inputs) {
+		t.Fatalf("Got %d results, want %d", len(results), len(inputs))
+	}
+
+	for i, result := range results {
+		data, shape, err := GetTensorData[float32](result["logits"])
+		if err != nil {
+			t.Fatalf("Failed to get result %d: %v", i, err)
+		}
+		if !slices.Equal(shape, []int64{1, 3}) {
+			t.Errorf("Result %d shape = %v, want [1 3]", i, shape)
+		}
+		for j := range data {
+			if diff := data[j] - expected[i][j]; diff > 1e-5 || diff < -1e-5 {
+				t.Errorf("Result %d[%d] = %v, want %v", i, j, data[j], expected[i][j])
+			}
+		}
+		for _, v := range result {
+			v.Close()
+		}
+	}
+}
+
+func TestBatcherMixedBatchSizes(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	one, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer one.Close()
+
+	two, err := NewTensorValue(runtime, make([]float32, 20), []int64{2, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer two.Close()
+
+	b := NewBatcher(runtime, session)
+	b.Add(map[string]*Value{"input": one})
+	b.Add(map[string]*Value{"input": two})
+
+	results, err := b.Run(t.Context())
+	if err != nil {
+		t.Fatalf("Batched run failed: %v", err)
+	}
+	for i, want := range []int64{1, 2} {
+		shape, err := results[i]["logits"].GetTensorShape()
+		if err != nil {
+			t.Fatalf("Failed to get shape: %v", err)
+		}
+		if shape[0] != want {
+			t.Errorf("Result %d batch size = %d, want %d", i, shape[0], want)
+		}
+		for _, v := range results[i] {
+			v.Close()
+		}
+	}
+}
+
+func TestBatcherShapeMismatch(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	a, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer a.Close()
+
+	c, err := NewTensorValue(runtime, make([]float32, 5), []int64{1, 5})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer c.Close()
+
+	b := NewBatcher(runtime, session)
+	b.Add(map[string]*Value{"input": a})
+	b.Add(map[string]*Value{"input": c})
+
+	if _, err := b.Run(t.Context()); err == nil {
+		t.Fatal("Expected error for mismatched trailing dimensions")
+	}
+	if b.Len() != 0 {
+		t.Errorf("Expected queue to be cleared after failed Run, Len = %d", b.Len())
+	}
+}
+
+func TestBatcherMissingInput(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	a, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer a.Close()
+
+	b := NewBatcher(runtime, session)
+	b.Add(map[string]*Value{"input": a})
+	b.Add(map[string]*Value{"other": a})
+
+	if _, err := b.Run(t.Context()); err == nil {
+		t.Fatal("Expected error for request with different input names")
+	}
+}
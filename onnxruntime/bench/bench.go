@@ -0,0 +1,233 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Config controls how benchmarks are run. Zero fields use defaults.
+type Config struct {
+	// Iterations is the number of measured operations per case. Default 100.
+	Iterations int `json:"iterations"`
+
+	// Warmup is the number of unmeasured runs before measuring. Default 10;
+	// a negative value disables warmup.
+	Warmup int `json:"warmup"`
+
+	// PoolSize is the number of sessions in the pooled case. Default 4.
+	PoolSize int `json:"pool_size"`
+
+	// Concurrency is the number of goroutines in the concurrent cases.
+	// Default 8.
+	Concurrency int `json:"concurrency"`
+
+	// Dims sets symbolic input dimensions by name when synthesizing inputs.
+	// Unlisted symbolic dimensions are 1.
+	Dims map[string]int64 `json:"dims,omitempty"`
+
+	// SessionOptions are applied to every session. They are not serialized.
+	SessionOptions *ort.SessionOptions `json:"-"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.Iterations <= 0 {
+		c.Iterations = 100
+	}
+	if c.Warmup < 0 {
+		c.Warmup = 0
+	} else if c.Warmup == 0 {
+		c.Warmup = 10
+	}
+	if c.PoolSize <= 0 {
+		c.PoolSize = 4
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 8
+	}
+	return c
+}
+
+// Report is the full output of a benchmark run.
+type Report struct {
+	Timestamp  time.Time     `json:"timestamp"`
+	GoVersion  string        `json:"go_version"`
+	GOOS       string        `json:"goos"`
+	GOARCH     string        `json:"goarch"`
+	NumCPU     int           `json:"num_cpu"`
+	ORTVersion string        `json:"ort_version"`
+	Config     Config        `json:"config"`
+	Models     []ModelReport `json:"models"`
+}
+
+// ModelReport holds the results for one model.
+type ModelReport struct {
+	Model   string   `json:"model"`
+	Results []Result `json:"results"`
+}
+
+// Result returns the result with the given name, or nil.
+func (m *ModelReport) Result(name string) *Result {
+	for i := range m.Results {
+		if m.Results[i].Name == name {
+			return &m.Results[i]
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Run benchmarks every model in modelPaths and returns a combined report.
+func Run(ctx context.Context, r *ort.Runtime, env *ort.Env, modelPaths []string, cfg Config) (*Report, error) {
+	cfg = cfg.withDefaults()
+	report := &Report{
+		Timestamp:  time.Now().UTC(),
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		NumCPU:     runtime.NumCPU(),
+		ORTVersion: r.GetVersionString(),
+		Config:     cfg,
+	}
+
+	for _, path := range modelPaths {
+		model, err := RunModel(ctx, r, env, path, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		report.Models = append(report.Models, *model)
+	}
+	return report, nil
+}
+
+// RunModel benchmarks a single model. The following cases are measured:
+//
+//   - run/session: Session.Run, one goroutine
+//   - run/iobinding: IoBinding.Run with preallocated outputs, one goroutine
+//   - extract/copy: GetTensorData on every output
+//   - extract/unsafe: GetTensorDataUnsafe on every output
+//   - concurrent/single-session: one mutex-guarded Session, Concurrency goroutines
+//   - concurrent/pool: SessionPool of PoolSize, Concurrency goroutines
+func RunModel(ctx context.Context, r *ort.Runtime, env *ort.Env, modelPath string, cfg Config) (*ModelReport, error) {
+	cfg = cfg.withDefaults()
+
+	modelData, err := os.ReadFile(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model: %w", err)
+	}
+
+	session, err := r.NewSessionFromReader(env, bytes.NewReader(modelData), cfg.SessionOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	inputs, err := SynthesizeInputs(r, session, cfg.Dims)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAll(inputs)
+
+	runOnce := func(s *ort.Session) error {
+		outputs, err := s.Run(ctx, inputs)
+		closeAll(outputs)
+		return err
+	}
+
+	for range cfg.Warmup {
+		if err := runOnce(session); err != nil {
+			return nil, fmt.Errorf("warmup failed: %w", err)
+		}
+	}
+
+	report := &ModelReport{Model: filepath.Base(modelPath)}
+	measureCase := func(name string, concurrency int, fn func() error) error {
+		res, err := measure(ctx, name, cfg.Iterations, concurrency, fn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		report.Results = append(report.Results, res)
+		return nil
+	}
+
+	if err := measureCase("run/session", 1, func() error { return runOnce(session) }); err != nil {
+		return nil, err
+	}
+
+	binding, err := newPreparedBinding(ctx, r, session, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare IO binding: %w", err)
+	}
+	err = measureCase("run/iobinding", 1, func() error { return binding.Run(ctx) })
+	binding.close()
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := session.Run(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run model: %w", err)
+	}
+	defer closeAll(outputs)
+	for _, zeroCopy := range []bool{false, true} {
+		name := "extract/copy"
+		if zeroCopy {
+			name = "extract/unsafe"
+		}
+		err := measureCase(name, 1, func() error {
+			for _, v := range outputs {
+				if err := extract(v, zeroCopy); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var mu sync.Mutex
+	err = measureCase("concurrent/single-session", cfg.Concurrency, func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return runOnce(session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := ort.NewSessionPool(r, env, modelData, cfg.PoolSize, &ort.PoolConfig{SessionOptions: cfg.SessionOptions})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool: %w", err)
+	}
+	defer pool.Close()
+	if err := pool.Warmup(ctx, inputs); err != nil {
+		return nil, fmt.Errorf("pool warmup failed: %w", err)
+	}
+	err = measureCase("concurrent/pool", cfg.Concurrency, func() error {
+		outputs, err := pool.Run(ctx, inputs)
+		closeAll(outputs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,144 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func testModelPath() string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filepath.Dir(filename)), "internal", "tests", "testdata", "model.onnx")
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i + 1)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0.50, 50},
+		{0.95, 95},
+		{0.99, 99},
+		{1.00, 100},
+	}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("percentile of empty slice = %v, want 0", got)
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	var calls int
+	res, err := measure(t.Context(), "noop", 10, 1, func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("measure failed: %v", err)
+	}
+	if calls != 10 || res.Iterations != 10 {
+		t.Errorf("Expected 10 iterations, got calls=%d iterations=%d", calls, res.Iterations)
+	}
+	if res.Name != "noop" || res.Concurrency != 1 {
+		t.Errorf("Unexpected result: %+v", res)
+	}
+}
+
+func TestMeasureConcurrentSplitsIterations(t *testing.T) {
+	res, err := measure(t.Context(), "split", 10, 3, func() error { return nil })
+	if err != nil {
+		t.Fatalf("measure failed: %v", err)
+	}
+	if res.Iterations != 10 || res.Concurrency != 3 {
+		t.Errorf("Expected 10 iterations across 3 goroutines, got %+v", res)
+	}
+}
+
+func TestMeasureError(t *testing.T) {
+	want := errors.New("boom")
+	if _, err := measure(t.Context(), "fail", 5, 2, func() error { return want }); !errors.Is(err, want) {
+		t.Errorf("Expected error %v, got %v", want, err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := measure(ctx, "cancelled", 5, 1, func() error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	cfg := Config{}.withDefaults()
+	if cfg.Iterations != 100 || cfg.Warmup != 10 || cfg.PoolSize != 4 || cfg.Concurrency != 8 {
+		t.Errorf("Unexpected defaults: %+v", cfg)
+	}
+	if cfg := (Config{Warmup: -1}).withDefaults(); cfg.Warmup != 0 {
+		t.Errorf("Expected negative warmup to disable warmup, got %d", cfg.Warmup)
+	}
+}
+
+func TestRun(t *testing.T) {
+	r := newTestRuntime(t)
+
+	env, err := r.NewEnv("bench", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	report, err := Run(t.Context(), r, env, []string{testModelPath()}, Config{
+		Iterations:  20,
+		Warmup:      2,
+		PoolSize:    2,
+		Concurrency: 4,
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(report.Models) != 1 {
+		t.Fatalf("Expected 1 model report, got %d", len(report.Models))
+	}
+
+	model := report.Models[0]
+	for _, name := range []string{
+		"run/session", "run/iobinding", "extract/copy", "extract/unsafe",
+		"concurrent/single-session", "concurrent/pool",
+	} {
+		res := model.Result(name)
+		if res == nil {
+			t.Errorf("Missing result %q", name)
+			continue
+		}
+		if res.Iterations != 20 || res.OpsPerSec <= 0 {
+			t.Errorf("%s: unexpected result %+v", name, res)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+	if len(decoded.Models) != 1 || len(decoded.Models[0].Results) != len(model.Results) {
+		t.Errorf("Round-tripped report differs: %+v", decoded)
+	}
+}
@@ -0,0 +1,12 @@
+// Package bench runs reproducible micro-benchmarks of onnxruntime usage
+// patterns against user-provided models.
+//
+// For each model it compares standard Session.Run against IO binding, copying
+// output extraction (GetTensorData) against zero-copy extraction
+// (GetTensorDataUnsafe), and a SessionPool against a single mutex-guarded
+// session under concurrency. Results carry latency percentiles and Go
+// allocation counts and serialize to JSON, so they can be committed or
+// compared in CI for regression tracking.
+//
+// The onnxbench command in tools/onnxbench wraps this package.
+package bench
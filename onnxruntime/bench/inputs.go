@@ -0,0 +1,100 @@
+package bench
+
+import (
+	"fmt"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// SynthesizeInputs creates deterministic input tensors matching the session's
+// input metadata. Symbolic or unknown dimensions are set to 1 unless
+// overridden in dims by symbolic name. Floating-point inputs are filled with a
+// small repeating ramp and integer inputs with zeros, which are valid indices
+// for embedding and token inputs.
+func SynthesizeInputs(r *ort.Runtime, session *ort.Session, dims map[string]int64) (map[string]*ort.Value, error) {
+	infos, err := session.GetInputInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get input info: %w", err)
+	}
+
+	inputs := make(map[string]*ort.Value, len(infos))
+	for _, info := range infos {
+		if info.TensorInfo == nil {
+			closeAll(inputs)
+			return nil, fmt.Errorf("input %q is not a tensor", info.Name)
+		}
+
+		shape := make([]int64, len(info.TensorInfo.Shape))
+		count := 1
+		for i, d := range info.TensorInfo.Shape {
+			if d <= 0 {
+				d = 1
+				if i < len(info.TensorInfo.SymbolicDimNames) {
+					if v, ok := dims[info.TensorInfo.SymbolicDimNames[i]]; ok {
+						d = v
+					}
+				}
+			}
+			shape[i] = d
+			count *= int(d)
+		}
+
+		v, err := newSyntheticTensor(r, info.TensorInfo.ElementType, count, shape)
+		if err != nil {
+			closeAll(inputs)
+			return nil, fmt.Errorf("failed to create input %q: %w", info.Name, err)
+		}
+		inputs[info.Name] = v
+	}
+	return inputs, nil
+}
+
+func newSyntheticTensor(r *ort.Runtime, dataType ort.ONNXTensorElementDataType, count int, shape []int64) (*ort.Value, error) {
+	switch dataType {
+	case ort.ONNXTensorElementDataTypeFloat:
+		return ort.NewTensorValue(r, ramp[float32](count), shape)
+	case ort.ONNXTensorElementDataTypeDouble:
+		return ort.NewTensorValue(r, ramp[float64](count), shape)
+	case ort.ONNXTensorElementDataTypeFloat16:
+		return ort.NewFloat16TensorFromFloat32(r, ramp[float32](count), shape)
+	case ort.ONNXTensorElementDataTypeBFloat16:
+		return ort.NewBFloat16TensorFromFloat32(r, ramp[float32](count), shape)
+	case ort.ONNXTensorElementDataTypeInt8:
+		return ort.NewTensorValue(r, make([]int8, count), shape)
+	case ort.ONNXTensorElementDataTypeUint8:
+		return ort.NewTensorValue(r, make([]uint8, count), shape)
+	case ort.ONNXTensorElementDataTypeInt16:
+		return ort.NewTensorValue(r, make([]int16, count), shape)
+	case ort.ONNXTensorElementDataTypeUint16:
+		return ort.NewTensorValue(r, make([]uint16, count), shape)
+	case ort.ONNXTensorElementDataTypeInt32:
+		return ort.NewTensorValue(r, make([]int32, count), shape)
+	case ort.ONNXTensorElementDataTypeUint32:
+		return ort.NewTensorValue(r, make([]uint32, count), shape)
+	case ort.ONNXTensorElementDataTypeInt64:
+		return ort.NewTensorValue(r, make([]int64, count), shape)
+	case ort.ONNXTensorElementDataTypeUint64:
+		return ort.NewTensorValue(r, make([]uint64, count), shape)
+	case ort.ONNXTensorElementDataTypeBool:
+		return ort.NewTensorValue(r, make([]bool, count), shape)
+	case ort.ONNXTensorElementDataTypeString:
+		return r.NewStringTensorValue(make([]string, count), shape)
+	default:
+		return nil, fmt.Errorf("unsupported element type %d", dataType)
+	}
+}
+
+// ramp returns count values cycling through 0, 0.1, ..., 0.9.
+func ramp[T float32 | float64](count int) []T {
+	data := make([]T, count)
+	for i := range data {
+		data[i] = T(i%10) / 10
+	}
+	return data
+}
+
+func closeAll(values map[string]*ort.Value) {
+	for _, v := range values {
+		v.Close()
+	}
+}
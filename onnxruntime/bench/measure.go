@@ -0,0 +1,124 @@
+package bench
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Result holds measurements for one benchmark case.
+type Result struct {
+	// Name identifies the case, e.g. "run/session" or "extract/unsafe".
+	Name string `json:"name"`
+
+	Iterations  int `json:"iterations"`
+	Concurrency int `json:"concurrency"`
+
+	// TotalNs is the wall-clock time of all iterations.
+	TotalNs int64 `json:"total_ns"`
+
+	// NsPerOp is the mean latency of a single operation.
+	NsPerOp int64 `json:"ns_per_op"`
+
+	// OpsPerSec is the throughput across all goroutines.
+	OpsPerSec float64 `json:"ops_per_sec"`
+
+	// Latency percentiles of individual operations.
+	P50Ns int64 `json:"p50_ns"`
+	P95Ns int64 `json:"p95_ns"`
+	P99Ns int64 `json:"p99_ns"`
+
+	// Go heap allocations per operation. Native allocations made by
+	// ONNX Runtime are not included.
+	AllocsPerOp uint64 `json:"allocs_per_op"`
+	BytesPerOp  uint64 `json:"bytes_per_op"`
+}
+
+// measure runs fn iterations times split across concurrency goroutines and
+// summarizes latency, throughput and Go allocations.
+func measure(ctx context.Context, name string, iterations, concurrency int, fn func() error) (Result, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	latencies := make([][]time.Duration, concurrency)
+	errs := make([]error, concurrency)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for g := range concurrency {
+		n := iterations / concurrency
+		if g < iterations%concurrency {
+			n++
+		}
+		latencies[g] = make([]time.Duration, 0, n)
+		wg.Go(func() {
+			for range n {
+				if err := ctx.Err(); err != nil {
+					errs[g] = err
+					return
+				}
+				opStart := time.Now()
+				if err := fn(); err != nil {
+					errs[g] = err
+					return
+				}
+				latencies[g] = append(latencies[g], time.Since(opStart))
+			}
+		})
+	}
+	wg.Wait()
+
+	total := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	for _, err := range errs {
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	all := slices.Concat(latencies...)
+	slices.Sort(all)
+
+	var sum time.Duration
+	for _, d := range all {
+		sum += d
+	}
+
+	ops := uint64(len(all))
+	return Result{
+		Name:        name,
+		Iterations:  len(all),
+		Concurrency: concurrency,
+		TotalNs:     total.Nanoseconds(),
+		NsPerOp:     (sum / time.Duration(len(all))).Nanoseconds(),
+		OpsPerSec:   float64(len(all)) / total.Seconds(),
+		P50Ns:       percentile(all, 0.50).Nanoseconds(),
+		P95Ns:       percentile(all, 0.95).Nanoseconds(),
+		P99Ns:       percentile(all, 0.99).Nanoseconds(),
+		AllocsPerOp: (after.Mallocs - before.Mallocs) / ops,
+		BytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / ops,
+	}, nil
+}
+
+// percentile returns the p-th percentile (0 < p <= 1) of sorted durations
+// using the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(float64(len(sorted))*p)) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"os"
 	"testing"
+	"unsafe"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
 )
 
 func BenchmarkSessionRun(b *testing.B) {
@@ -44,17 +47,70 @@ func BenchmarkSessionRun(b *testing.B) {
 		"input": inputTensor,
 	}
 
-	for b.Loop() {
-		outputs, err := session.Run(b.Context(), inputs)
-		if err != nil {
-			b.Fatalf("Failed to run inference: %v", err)
-		}
-		for _, output := range outputs {
-			output.Close()
-		}
+	benchmarkRun(b, session, inputs)
+}
+
+// benchmarkRun runs session with a context that cannot be cancelled, which
+// takes the fast path without run options, and with a cancellable context,
+// which creates run options and a cancellation watcher per run.
+func benchmarkRun(b *testing.B, session *Session, inputs map[string]*Value) {
+	for _, bc := range []struct {
+		name string
+		ctx  func(b *testing.B) context.Context
+	}{
+		{"Background", func(*testing.B) context.Context { return context.Background() }},
+		{"Cancellable", func(b *testing.B) context.Context { return b.Context() }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := bc.ctx(b)
+			b.ReportAllocs()
+			for b.Loop() {
+				outputs, err := session.Run(ctx, inputs)
+				if err != nil {
+					b.Fatalf("Failed to run inference: %v", err)
+				}
+				for _, output := range outputs {
+					output.Close()
+				}
+			}
+		})
 	}
 }
 
+// stubRunAPI answers Run with fake output values without calling into ONNX
+// Runtime, so that BenchmarkSessionRunOverhead measures the Go side of
+// Session.Run alone.
+type stubRunAPI struct {
+	api.APIFuncs
+}
+
+func (stubRunAPI) Run(_ api.OrtSession, _ api.OrtRunOptions, _ **byte, _ *api.OrtValue, _ uintptr, _ **byte, outputCount uintptr, outputs *api.OrtValue) api.OrtStatus {
+	values := unsafe.Slice(outputs, outputCount)
+	for i := range values {
+		values[i] = api.OrtValue(i + 1)
+	}
+	return 0
+}
+
+func (stubRunAPI) CreateRunOptions(opts *api.OrtRunOptions) api.OrtStatus {
+	*opts = 1
+	return 0
+}
+
+func (stubRunAPI) ReleaseRunOptions(api.OrtRunOptions) {}
+func (stubRunAPI) ReleaseValue(api.OrtValue)           {}
+
+func BenchmarkSessionRunOverhead(b *testing.B) {
+	runtime := &Runtime{apiFuncs: stubRunAPI{}}
+	session := newScratchTestSession([]string{"input"}, []string{"logits"})
+	session.ptr = 1
+	session.runtime = runtime
+	session.defaultRun = runConfig{outputNames: session.outputNames}
+
+	input := &Value{ptr: 1, runtime: runtime}
+	benchmarkRun(b, session, map[string]*Value{"input": input})
+}
+
 func BenchmarkTensorCreation(b *testing.B) {
 	runtime, err := NewRuntime(libraryPath, 23)
 	if err != nil {
@@ -77,6 +133,27 @@ func BenchmarkTensorCreation(b *testing.B) {
 	}
 }
 
+func BenchmarkTensorPoolGetPut(b *testing.B) {
+	runtime, err := NewRuntime(libraryPath, 23)
+	if err != nil {
+		b.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	defer runtime.Close()
+
+	pool := runtime.NewTensorPool(0)
+	defer pool.Close()
+	shape := []int64{10, 100}
+
+	for b.Loop() {
+		tensor, data, err := GetPooledTensor[float32](pool, shape)
+		if err != nil {
+			b.Fatalf("Failed to get tensor: %v", err)
+		}
+		data[0] = 1
+		pool.Put(tensor)
+	}
+}
+
 func BenchmarkTensorCreationLarge(b *testing.B) {
 	runtime, err := NewRuntime(libraryPath, 23)
 	if err != nil {
@@ -263,6 +340,33 @@ func BenchmarkGetTensorDataUnsafe(b *testing.B) {
 	}
 }
 
+func BenchmarkGetTensorDataInto(b *testing.B) {
+	runtime, err := NewRuntime(libraryPath, 23)
+	if err != nil {
+		b.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	defer runtime.Close()
+
+	data := make([]float32, 1000)
+	for i := range data {
+		data[i] = float32(i)
+	}
+
+	tensor, err := NewTensorValue(runtime, data, []int64{10, 100})
+	if err != nil {
+		b.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	buf := make([]float32, len(data))
+	for b.Loop() {
+		_, _, err := GetTensorDataInto(tensor, buf)
+		if err != nil {
+			b.Fatalf("Failed to get tensor data: %v", err)
+		}
+	}
+}
+
 func BenchmarkModelLoad(b *testing.B) {
 	runtime, err := NewRuntime(libraryPath, 23)
 	if err != nil {
@@ -350,3 +454,17 @@ func BenchmarkBFloat16Conversion(b *testing.B) {
 		_ = bf16.Float32()
 	}
 }
+
+func BenchmarkFloat16ToFloat32(b *testing.B) {
+	src := make([]Float16, 1<<16)
+	for i := range src {
+		src[i] = Float16(i)
+	}
+	dst := make([]float32, len(src))
+
+	b.SetBytes(int64(len(src) * 2))
+	b.ResetTimer()
+	for b.Loop() {
+		Float16ToFloat32(dst, src)
+	}
+}
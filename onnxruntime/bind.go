@@ -0,0 +1,377 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Bind builds a Run inputs map from the fields of a struct tagged with
+// `onnx:"name"`. src is a struct or a pointer to one; untagged fields are
+// ignored.
+//
+// Supported field types are slices of a TensorData type, two-dimensional
+// slices of them, []string, and *Value. Slice data is copied into new
+// tensors. A 1-D slice defaults to shape [len] and a 2-D slice to
+// [rows, cols]; a shape option overrides this, with at most one dimension
+// given as -1 to be inferred from the length:
+//
+//	type Inputs struct {
+//	    InputIDs []int64     `onnx:"input_ids,shape=1x128"`
+//	    Mask     []int64     `onnx:"attention_mask,shape=1x-1"`
+//	    Pixels   [][]float32 `onnx:"pixel_values"`
+//	}
+//
+//	inputs, err := onnxruntime.Bind(runtime, &Inputs{...})
+//	defer func() { for _, v := range inputs { v.Close() } }()
+//
+// The caller must close every value in the returned map. *Value fields are
+// placed in the map as-is, so closing the map closes them too.
+func Bind(r *Runtime, src any) (map[string]*Value, error) {
+	rv := reflect.ValueOf(src)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bind source must be a struct or pointer to struct, got %T", src)
+	}
+
+	fields, err := taggedFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[string]*Value, len(fields))
+	created := make(map[string]*Value, len(fields))
+	for _, f := range fields {
+		v, err := bindField(r, rv.Field(f.index), f)
+		if err != nil {
+			closeValues(created)
+			return nil, fmt.Errorf("input %q (field %s): %w", f.name, f.field, err)
+		}
+		inputs[f.name] = v
+		if rv.Field(f.index).Type() != valuePtrType {
+			created[f.name] = v
+		}
+	}
+	return inputs, nil
+}
+
+// Unbind copies outputs into the fields of the struct pointed to by dst,
+// matched by `onnx:"name"` tags as in [Bind].
+//
+// 1-D slice fields receive the tensor data flattened in row-major order,
+// 2-D slice fields require a rank-2 tensor, and []string fields receive
+// string tensor data. *Value fields receive the output Value itself, which
+// must then be closed through either the field or the outputs map, not both.
+// Element types must match exactly. A tagged output missing from outputs is
+// an error unless the tag has the optional option.
+func Unbind(outputs map[string]*Value, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unbind destination must be a non-nil pointer to struct, got %T", dst)
+	}
+	rv = rv.Elem()
+
+	fields, err := taggedFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		v, ok := outputs[f.name]
+		if !ok || v == nil {
+			if f.optional {
+				continue
+			}
+			return fmt.Errorf("output %q (field %s) not found", f.name, f.field)
+		}
+		if err := unbindField(rv.Field(f.index), v); err != nil {
+			return fmt.Errorf("output %q (field %s): %w", f.name, f.field, err)
+		}
+	}
+	return nil
+}
+
+// bindTag is a parsed `onnx` struct tag.
+type bindTag struct {
+	index    int
+	field    string
+	name     string
+	shape    []int64 // nil unless a shape option was given
+	optional bool
+}
+
+func taggedFields(t reflect.Type) ([]bindTag, error) {
+	var fields []bindTag
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("onnx")
+		if !ok || tag == "-" {
+			continue
+		}
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("field %s has an onnx tag but is unexported", sf.Name)
+		}
+
+		parts := strings.Split(tag, ",")
+		f := bindTag{index: i, field: sf.Name, name: parts[0]}
+		if f.name == "" {
+			return nil, fmt.Errorf("field %s: onnx tag has no name", sf.Name)
+		}
+		for _, opt := range parts[1:] {
+			switch {
+			case opt == "optional":
+				f.optional = true
+			case strings.HasPrefix(opt, "shape="):
+				shape, err := parseTagShape(strings.TrimPrefix(opt, "shape="))
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+				}
+				f.shape = shape
+			default:
+				return nil, fmt.Errorf("field %s: unknown onnx tag option %q", sf.Name, opt)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// parseTagShape parses a shape such as "1x128" or "1x-1".
+func parseTagShape(s string) ([]int64, error) {
+	if s == "" {
+		return []int64{}, nil
+	}
+	parts := strings.Split(s, "x")
+	shape := make([]int64, len(parts))
+	inferred := false
+	for i, p := range parts {
+		d, err := strconv.ParseInt(p, 10, 64)
+		if err != nil || d < -1 {
+			return nil, fmt.Errorf("invalid shape %q", s)
+		}
+		if d == -1 {
+			if inferred {
+				return nil, fmt.Errorf("shape %q has more than one inferred dimension", s)
+			}
+			inferred = true
+		}
+		shape[i] = d
+	}
+	return shape, nil
+}
+
+// resolveShape checks shape against count elements, filling in a -1 dimension.
+func resolveShape(shape []int64, count int) ([]int64, error) {
+	shape = append([]int64(nil), shape...)
+	known, inferred := int64(1), -1
+	for i, d := range shape {
+		if d == -1 {
+			inferred = i
+			continue
+		}
+		known *= d
+	}
+	if inferred >= 0 {
+		if known == 0 || int64(count)%known != 0 {
+			return nil, fmt.Errorf("cannot infer shape %v from %d elements", shape, count)
+		}
+		shape[inferred] = int64(count) / known
+		known *= shape[inferred]
+	}
+	if known != int64(count) {
+		return nil, fmt.Errorf("shape %v requires %d elements, got %d", shape, known, count)
+	}
+	return shape, nil
+}
+
+var (
+	valuePtrType = reflect.TypeFor[*Value]()
+	stringsType  = reflect.TypeFor[[]string]()
+)
+
+// bindElementType returns the tensor element type for a Go element type.
+func bindElementType(t reflect.Type) (ONNXTensorElementDataType, bool) {
+	switch t {
+	case reflect.TypeFor[Float16]():
+		return ONNXTensorElementDataTypeFloat16, true
+	case reflect.TypeFor[BFloat16]():
+		return ONNXTensorElementDataTypeBFloat16, true
+	}
+	switch t.Kind() {
+	case reflect.Float32:
+		return ONNXTensorElementDataTypeFloat, true
+	case reflect.Float64:
+		return ONNXTensorElementDataTypeDouble, true
+	case reflect.Int8:
+		return ONNXTensorElementDataTypeInt8, true
+	case reflect.Int16:
+		return ONNXTensorElementDataTypeInt16, true
+	case reflect.Int32:
+		return ONNXTensorElementDataTypeInt32, true
+	case reflect.Int64:
+		return ONNXTensorElementDataTypeInt64, true
+	case reflect.Uint8:
+		return ONNXTensorElementDataTypeUint8, true
+	case reflect.Uint16:
+		return ONNXTensorElementDataTypeUint16, true
+	case reflect.Uint32:
+		return ONNXTensorElementDataTypeUint32, true
+	case reflect.Uint64:
+		return ONNXTensorElementDataTypeUint64, true
+	case reflect.Bool:
+		return ONNXTensorElementDataTypeBool, true
+	default:
+		return 0, false
+	}
+}
+
+// sliceBytes returns the backing bytes of a slice of fixed-size elements.
+func sliceBytes(s reflect.Value) []byte {
+	n := s.Len() * int(s.Type().Elem().Size())
+	if n == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(s.UnsafePointer()), n)
+}
+
+func bindField(r *Runtime, fv reflect.Value, f bindTag) (*Value, error) {
+	t := fv.Type()
+	switch {
+	case t == valuePtrType:
+		if fv.IsNil() {
+			return nil, fmt.Errorf("value is nil")
+		}
+		return fv.Interface().(*Value), nil
+
+	case t == stringsType:
+		data := fv.Interface().([]string)
+		shape, err := resolveShape(defaultShape(f.shape, len(data)), len(data))
+		if err != nil {
+			return nil, err
+		}
+		return r.NewStringTensorValue(data, shape)
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Slice:
+		dataType, ok := bindElementType(t.Elem().Elem())
+		if !ok {
+			return nil, fmt.Errorf("unsupported field type %s", t)
+		}
+		rows, cols := fv.Len(), 0
+		if rows > 0 {
+			cols = fv.Index(0).Len()
+		}
+		buf := make([]byte, 0, rows*cols*int(t.Elem().Elem().Size()))
+		for i := range rows {
+			row := fv.Index(i)
+			if row.Len() != cols {
+				return nil, fmt.Errorf("row %d has %d elements, expected %d", i, row.Len(), cols)
+			}
+			buf = append(buf, sliceBytes(row)...)
+		}
+		shape := f.shape
+		if shape == nil {
+			shape = []int64{int64(rows), int64(cols)}
+		}
+		shape, err := resolveShape(shape, rows*cols)
+		if err != nil {
+			return nil, err
+		}
+		return r.newTensorValueFromGoBytes(buf, shape, dataType)
+
+	case t.Kind() == reflect.Slice:
+		dataType, ok := bindElementType(t.Elem())
+		if !ok {
+			return nil, fmt.Errorf("unsupported field type %s", t)
+		}
+		shape, err := resolveShape(defaultShape(f.shape, fv.Len()), fv.Len())
+		if err != nil {
+			return nil, err
+		}
+		buf := append([]byte(nil), sliceBytes(fv)...)
+		return r.newTensorValueFromGoBytes(buf, shape, dataType)
+
+	default:
+		return nil, fmt.Errorf("unsupported field type %s", t)
+	}
+}
+
+func defaultShape(shape []int64, n int) []int64 {
+	if shape == nil {
+		return []int64{int64(n)}
+	}
+	return shape
+}
+
+func unbindField(fv reflect.Value, v *Value) error {
+	t := fv.Type()
+	switch {
+	case t == valuePtrType:
+		fv.Set(reflect.ValueOf(v))
+		return nil
+
+	case t == stringsType:
+		data, _, err := GetStringTensorData(v)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(data))
+		return nil
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Slice:
+		raw, shape, err := unbindTensorBytes(v, t.Elem().Elem())
+		if err != nil {
+			return err
+		}
+		if len(shape) != 2 {
+			return fmt.Errorf("field type %s requires a rank-2 tensor, got shape %v", t, shape)
+		}
+		rows, cols := int(shape[0]), int(shape[1])
+		rowBytes := cols * int(t.Elem().Elem().Size())
+		out := reflect.MakeSlice(t, rows, rows)
+		for i := range rows {
+			row := reflect.MakeSlice(t.Elem(), cols, cols)
+			copy(sliceBytes(row), raw[i*rowBytes:(i+1)*rowBytes])
+			out.Index(i).Set(row)
+		}
+		fv.Set(out)
+		return nil
+
+	case t.Kind() == reflect.Slice:
+		raw, _, err := unbindTensorBytes(v, t.Elem())
+		if err != nil {
+			return err
+		}
+		n := len(raw) / int(t.Elem().Size())
+		out := reflect.MakeSlice(t, n, n)
+		copy(sliceBytes(out), raw)
+		fv.Set(out)
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported field type %s", t)
+	}
+}
+
+// unbindTensorBytes returns v's raw data after checking it holds elem values.
+func unbindTensorBytes(v *Value, elem reflect.Type) ([]byte, []int64, error) {
+	want, ok := bindElementType(elem)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported element type %s", elem)
+	}
+	raw, got, err := v.tensorBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	if got != want {
+		return nil, nil, fmt.Errorf("expected %s tensor, got %s", elementTypeName(want), elementTypeName(got))
+	}
+	shape, err := v.GetTensorShape()
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, shape, nil
+}
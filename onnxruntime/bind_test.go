@@ -0,0 +1,148 @@
+package onnxruntime
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+type bindTestInputs struct {
+	Input []float32 `onnx:"input,shape=-1x10"`
+	Note  string
+}
+
+type bindTestOutputs struct {
+	Logits [][]float32 `onnx:"logits"`
+	Flat   []float32   `onnx:"logits"`
+	Raw    *Value      `onnx:"logits"`
+	Extra  []int64     `onnx:"extra,optional"`
+}
+
+func TestBindRunUnbind(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	src := bindTestInputs{Input: make([]float32, 20)}
+	for i := range src.Input {
+		src.Input[i] = float32(i)
+	}
+	inputs, err := Bind(runtime, &src)
+	if err != nil {
+		t.Fatalf("Failed to bind inputs: %v", err)
+	}
+	defer closeValues(inputs)
+
+	if shape, _ := inputs["input"].GetTensorShape(); !slices.Equal(shape, []int64{2, 10}) {
+		t.Errorf("Expected inferred shape [2 10], got %v", shape)
+	}
+
+	outputs, err := session.Run(context.Background(), inputs)
+	if err != nil {
+		t.Fatalf("Failed to run inference: %v", err)
+	}
+	defer closeValues(outputs)
+
+	var dst bindTestOutputs
+	if err := Unbind(outputs, &dst); err != nil {
+		t.Fatalf("Failed to unbind outputs: %v", err)
+	}
+	if len(dst.Logits) != 2 || len(dst.Logits[0]) != 3 {
+		t.Errorf("Expected 2x3 logits, got %v", dst.Logits)
+	}
+	if len(dst.Flat) != 6 || dst.Flat[3] != dst.Logits[1][0] {
+		t.Errorf("Expected flattened logits matching rows, got %v", dst.Flat)
+	}
+	if dst.Raw != outputs["logits"] {
+		t.Error("Expected *Value field to receive the output value")
+	}
+	if dst.Extra != nil {
+		t.Errorf("Expected optional missing output to be left unset, got %v", dst.Extra)
+	}
+}
+
+func TestBindTwoDimensional(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	inputs, err := Bind(runtime, struct {
+		X [][]int64 `onnx:"x"`
+		S []string  `onnx:"s,shape=1x2"`
+	}{
+		X: [][]int64{{1, 2, 3}, {4, 5, 6}},
+		S: []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to bind inputs: %v", err)
+	}
+	defer closeValues(inputs)
+
+	data, shape, err := GetTensorData[int64](inputs["x"])
+	if err != nil {
+		t.Fatalf("Failed to read tensor: %v", err)
+	}
+	if !slices.Equal(shape, []int64{2, 3}) || !slices.Equal(data, []int64{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Unexpected tensor %v %v", shape, data)
+	}
+	strs, shape, err := GetStringTensorData(inputs["s"])
+	if err != nil {
+		t.Fatalf("Failed to read string tensor: %v", err)
+	}
+	if !slices.Equal(shape, []int64{1, 2}) || !slices.Equal(strs, []string{"a", "b"}) {
+		t.Errorf("Unexpected string tensor %v %v", shape, strs)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	if _, err := Bind(nil, 42); err == nil {
+		t.Error("Expected error for non-struct source")
+	}
+	if err := Unbind(nil, bindTestOutputs{}); err == nil {
+		t.Error("Expected error for non-pointer destination")
+	}
+	if err := Unbind(map[string]*Value{}, &bindTestOutputs{}); err == nil {
+		t.Error("Expected error for missing required output")
+	}
+
+	badTags := []any{
+		&struct {
+			X []float32 `onnx:""`
+		}{},
+		&struct {
+			X []float32 `onnx:"x,shape=1xA"`
+		}{},
+		&struct {
+			X []float32 `onnx:"x,shape=-1x-1"`
+		}{},
+		&struct {
+			X []float32 `onnx:"x,bogus"`
+		}{},
+		&struct {
+			x []float32 `onnx:"x"`
+		}{},
+	}
+	for _, v := range badTags {
+		if _, err := Bind(nil, v); err == nil {
+			t.Errorf("Expected tag error for %T", v)
+		}
+	}
+}
+
+func TestResolveShape(t *testing.T) {
+	tests := []struct {
+		shape []int64
+		count int
+		want  []int64
+		ok    bool
+	}{
+		{[]int64{1, 128}, 128, []int64{1, 128}, true},
+		{[]int64{1, -1}, 64, []int64{1, 64}, true},
+		{[]int64{-1, 3}, 7, nil, false},
+		{[]int64{2, 2}, 5, nil, false},
+		{[]int64{0, -1}, 0, nil, false},
+	}
+	for _, tt := range tests {
+		got, err := resolveShape(tt.shape, tt.count)
+		if (err == nil) != tt.ok || !slices.Equal(got, tt.want) {
+			t.Errorf("resolveShape(%v, %d) = %v, %v", tt.shape, tt.count, got, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"unsafe"
+
+	"github.com/benedoc-inc/onnxer/internal/cstrings"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/bridge"
+)
+
+// Wire up the bridge used by subpackages that call the C API directly.
+func init() {
+	bridge.RuntimeOf = func(r any) (*bridge.Runtime, error) {
+		rt := r.(*Runtime)
+		if rt == nil || rt.apiFuncs == nil || rt.allocator == nil {
+			return nil, fmt.Errorf("runtime is closed")
+		}
+		return &bridge.Runtime{
+			Funcs:     rt.apiFuncs,
+			Allocator: rt.allocator.ptr,
+			FreeString: func(ptr *byte) string {
+				s := cstrings.CStringToString(ptr)
+				rt.allocator.free(unsafe.Pointer(ptr))
+				return s
+			},
+			StatusError: rt.statusError,
+			Track: func(kind string) func(bool) {
+				return bridgeTrack(rt, kind)
+			},
+		}, nil
+	}
+	bridge.Env = func(e any) api.OrtEnv {
+		if env := e.(*Env); env != nil {
+			return env.ptr
+		}
+		return 0
+	}
+	bridge.SessionOptions = func(r, opts any) (api.OrtSessionOptions, func(), error) {
+		options := opts.(*SessionOptions)
+		if options == nil {
+			options = &SessionOptions{}
+		}
+		return r.(*Runtime).createAndConfigureSessionOptions(options)
+	}
+	bridge.NewRunOptions = func(r any, ctx context.Context) (*bridge.RunOptions, error) {
+		h, err := r.(*Runtime).createRunOptions(ctx, &runConfig{})
+		if err != nil {
+			return nil, err
+		}
+		return &bridge.RunOptions{Ptr: h.ptr, WrapError: h.wrapError, Close: h.close}, nil
+	}
+	bridge.Value = func(v any) api.OrtValue {
+		if value := v.(*Value); value != nil {
+			return value.ptr
+		}
+		return 0
+	}
+	bridge.NewValue = func(r any, ptr api.OrtValue) any {
+		return r.(*Runtime).newValueFromPtr(ptr)
+	}
+}
+
+// bridgeTrack tracks an object of a subpackage, named by its kind's name, in
+// r.objects.
+func bridgeTrack(r *Runtime, name string) (untrack func(leaked bool)) {
+	kind := objectKind(slices.Index(objectKindNames[:], name))
+	id := r.objects.add(kind)
+	return func(leaked bool) {
+		if leaked {
+			r.objects.reclaim(kind, id)
+		} else {
+			r.objects.remove(kind, id)
+		}
+	}
+}
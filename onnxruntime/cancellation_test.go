@@ -2,6 +2,7 @@ package onnxruntime
 
 import (
 	"context"
+	"errors"
 	"testing"
 )
 
@@ -51,8 +52,11 @@ func TestSessionRunWithPreCancelledContext(t *testing.T) {
 		"input": tensor,
 	})
 	if err != nil {
-		// Expected: ORT may return an error due to termination
-		t.Logf("Pre-cancelled run returned error (expected): %v", err)
+		// Expected: ORT may return an error due to termination, which must
+		// be distinguishable from an inference failure.
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected error matching context.Canceled, got %v", err)
+		}
 		return
 	}
 
@@ -84,3 +88,30 @@ func TestSessionRunWithBackgroundContext(t *testing.T) {
 		v.Close()
 	}
 }
+
+func TestRunHandleWrapError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+
+	runErr := &RuntimeError{Code: ErrorCodeFail, Message: "Exiting due to terminate flag being set to true."}
+	h := &runHandle{ctx: ctx}
+
+	if err := h.wrapError(runErr); err != runErr {
+		t.Errorf("Expected error unchanged when the run was not terminated, got %v", err)
+	}
+
+	h.terminated.Store(true)
+	err := h.wrapError(runErr)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error matching context.DeadlineExceeded, got %v", err)
+	}
+	var rtErr *RuntimeError
+	if !errors.As(err, &rtErr) || rtErr != runErr {
+		t.Errorf("Expected the runtime error to remain accessible, got %v", err)
+	}
+
+	if h.wrapError(nil) != nil {
+		t.Error("Expected nil for a successful run")
+	}
+}
@@ -0,0 +1,47 @@
+package onnxruntime
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompileModel(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	output := filepath.Join(t.TempDir(), "model_ctx.onnx")
+	err = runtime.CompileModel(env, testModelPath(), output, &CompileOptions{EmbedMode: true})
+	if errors.Is(err, ErrNotImplemented) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("CompileModel failed: %v", err)
+	}
+
+	session, err := runtime.NewSession(env, output, nil)
+	if err != nil {
+		t.Fatalf("Failed to load compiled model: %v", err)
+	}
+	defer session.Close()
+	runInference(t, runtime, session)
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+	fromBytes := filepath.Join(t.TempDir(), "model_ctx.onnx")
+	if err := runtime.CompileModelFromBytes(env, modelData, fromBytes, nil); err != nil {
+		t.Errorf("CompileModelFromBytes failed: %v", err)
+	}
+
+	if err := runtime.CompileModel(env, testModelPath(), "", nil); err == nil {
+		t.Error("Expected error for empty output path")
+	}
+}
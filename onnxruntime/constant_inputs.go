@@ -0,0 +1,61 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"maps"
+)
+
+// SetConstantInput makes value the input named name of every run that does
+// not provide that input itself, for inputs that rarely change such as a
+// "use_cache" flag or a sampling temperature. A value passed to Run takes
+// precedence. A nil value removes the constant.
+//
+// The session does not take ownership of value, which must stay open until
+// it is replaced or removed. Run, RunIndexed and RunAsync use constant
+// inputs; IoBinding runs do not.
+//
+// Example:
+//
+//	useCache, _ := onnxruntime.NewTensorValue(runtime, []bool{true}, []int64{1})
+//	defer useCache.Close()
+//	session.SetConstantInput("use_cache", useCache)
+//	outputs, err := session.Run(ctx, map[string]*onnxruntime.Value{"input_ids": ids})
+func (s *Session) SetConstantInput(name string, value *Value) error {
+	if _, ok := s.inputIndex[name]; !ok {
+		return fmt.Errorf("model has no input %q", name)
+	}
+
+	s.constantsMu.Lock()
+	defer s.constantsMu.Unlock()
+
+	// Runs read the map without locking, so it is replaced, never modified
+	constants := maps.Clone(s.constantInputs())
+	if constants == nil {
+		constants = make(map[string]*Value, 1)
+	}
+	if value == nil {
+		delete(constants, name)
+	} else {
+		constants[name] = value
+	}
+	if len(constants) == 0 {
+		s.constants.Store(nil)
+	} else {
+		s.constants.Store(&constants)
+	}
+	return nil
+}
+
+// ConstantInputs returns a copy of the inputs set with SetConstantInput.
+func (s *Session) ConstantInputs() map[string]*Value {
+	return maps.Clone(s.constantInputs())
+}
+
+// constantInputs returns the inputs set with SetConstantInput, or nil if
+// there are none. The map must not be modified.
+func (s *Session) constantInputs() map[string]*Value {
+	if constants := s.constants.Load(); constants != nil {
+		return *constants
+	}
+	return nil
+}
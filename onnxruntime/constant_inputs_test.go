@@ -0,0 +1,76 @@
+package onnxruntime
+
+import (
+	"testing"
+)
+
+func TestSessionSetConstantInput(t *testing.T) {
+	s := newScratchTestSession([]string{"a", "b"}, []string{"x"})
+	constant := &Value{ptr: 9}
+
+	if err := s.SetConstantInput("missing", constant); err == nil {
+		t.Error("Expected error for an unknown input")
+	}
+	if err := s.SetConstantInput("b", constant); err != nil {
+		t.Fatalf("SetConstantInput() error = %v", err)
+	}
+	if got := s.ConstantInputs(); len(got) != 1 || got["b"] != constant {
+		t.Errorf("ConstantInputs() = %v, want b", got)
+	}
+
+	// The constant fills in the input the run leaves out
+	var sc runScratch
+	inNames, inValues, _, _ := sc.prepare(s, map[string]*Value{"a": {ptr: 1}}, s.outputNames)
+	if &inNames[0] != &s.inputNamePtrs[0] {
+		t.Error("Expected the session's own names when constants complete the inputs")
+	}
+	if inValues[0] != 1 || inValues[1] != 9 {
+		t.Errorf("input values = %v, want [1 9]", inValues)
+	}
+
+	// A value passed to the run takes precedence
+	_, inValues, _, _ = sc.prepare(s, map[string]*Value{"a": {ptr: 1}, "b": {ptr: 2}}, s.outputNames)
+	if inValues[1] != 2 {
+		t.Errorf("input b = %v, want the run's value 2", inValues[1])
+	}
+
+	names, values := s.orderedInputs(map[string]*Value{"a": {ptr: 1}})
+	if names[1] != "b" || values[1] != constant {
+		t.Errorf("orderedInputs() = %v, %v, want constant b", names, values)
+	}
+
+	if err := s.SetConstantInput("b", nil); err != nil {
+		t.Fatalf("SetConstantInput(nil) error = %v", err)
+	}
+	if got := s.ConstantInputs(); len(got) != 0 {
+		t.Errorf("ConstantInputs() = %v after removal, want none", got)
+	}
+}
+
+func TestRunScratchIndexedConstantInput(t *testing.T) {
+	s := newScratchTestSession([]string{"a", "b", "c"}, []string{"x"})
+	if err := s.SetConstantInput("b", &Value{ptr: 9}); err != nil {
+		t.Fatalf("SetConstantInput() error = %v", err)
+	}
+	if err := s.SetConstantInput("c", &Value{ptr: 8}); err != nil {
+		t.Fatalf("SetConstantInput() error = %v", err)
+	}
+
+	var sc runScratch
+	inNames, inValues, _, _, err := sc.prepareIndexed(s, []int{2, 0}, []*Value{{ptr: 3}, {ptr: 1}}, nil)
+	if err != nil {
+		t.Fatalf("prepareIndexed failed: %v", err)
+	}
+	want := []*byte{s.inputNamePtrs[2], s.inputNamePtrs[0], s.inputNamePtrs[1]}
+	if len(inNames) != len(want) {
+		t.Fatalf("got %d inputs, want %d", len(inNames), len(want))
+	}
+	for i := range want {
+		if inNames[i] != want[i] {
+			t.Errorf("input %d has the wrong name", i)
+		}
+	}
+	if inValues[0] != 3 || inValues[1] != 1 || inValues[2] != 9 {
+		t.Errorf("input values = %v, want [3 1 9]", inValues)
+	}
+}
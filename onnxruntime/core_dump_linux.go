@@ -0,0 +1,8 @@
+package onnxruntime
+
+import "golang.org/x/sys/unix"
+
+// excludeFromCoreDump asks the kernel to leave b out of core dumps.
+func excludeFromCoreDump(b []byte) {
+	_ = unix.Madvise(b, unix.MADV_DONTDUMP)
+}
@@ -0,0 +1,7 @@
+//go:build unix && !linux
+
+package onnxruntime
+
+// excludeFromCoreDump is a no-op; only Linux supports excluding memory from
+// core dumps.
+func excludeFromCoreDump(b []byte) {}
@@ -0,0 +1,110 @@
+package onnxruntime
+
+// CoreMLComputeUnits selects which Apple hardware CoreML may schedule work on.
+type CoreMLComputeUnits int
+
+const (
+	// CoreMLComputeUnitsAll lets CoreML use the CPU, GPU and Neural Engine (ORT default).
+	CoreMLComputeUnitsAll CoreMLComputeUnits = iota
+	// CoreMLComputeUnitsCPUOnly restricts CoreML to the CPU.
+	CoreMLComputeUnitsCPUOnly
+	// CoreMLComputeUnitsCPUAndGPU allows the CPU and GPU.
+	CoreMLComputeUnitsCPUAndGPU
+	// CoreMLComputeUnitsCPUAndNeuralEngine allows the CPU and Apple Neural Engine.
+	CoreMLComputeUnitsCPUAndNeuralEngine
+)
+
+// String returns the option value ORT expects for the compute units.
+func (c CoreMLComputeUnits) String() string {
+	switch c {
+	case CoreMLComputeUnitsAll:
+		return "ALL"
+	case CoreMLComputeUnitsCPUOnly:
+		return "CPUOnly"
+	case CoreMLComputeUnitsCPUAndGPU:
+		return "CPUAndGPU"
+	case CoreMLComputeUnitsCPUAndNeuralEngine:
+		return "CPUAndNeuralEngine"
+	default:
+		return "Unknown"
+	}
+}
+
+// CoreMLModelFormat selects the CoreML model representation the EP compiles to.
+type CoreMLModelFormat int
+
+const (
+	// CoreMLModelFormatNeuralNetwork uses the legacy NeuralNetwork format (ORT default).
+	CoreMLModelFormatNeuralNetwork CoreMLModelFormat = iota
+	// CoreMLModelFormatMLProgram uses the MLProgram format (macOS 12+, iOS 15+),
+	// which supports more operators and float16 compute.
+	CoreMLModelFormatMLProgram
+)
+
+// String returns the option value ORT expects for the model format.
+func (f CoreMLModelFormat) String() string {
+	switch f {
+	case CoreMLModelFormatNeuralNetwork:
+		return "NeuralNetwork"
+	case CoreMLModelFormatMLProgram:
+		return "MLProgram"
+	default:
+		return "Unknown"
+	}
+}
+
+// CoreMLProviderOptions configures the CoreML execution provider on macOS and iOS.
+// The zero value enables CoreML with ORT's defaults.
+//
+// Example:
+//
+//	opts := &SessionOptions{
+//	    ExecutionProviders: []ExecutionProvider{
+//	        (&CoreMLProviderOptions{
+//	            ComputeUnits: CoreMLComputeUnitsCPUAndNeuralEngine,
+//	            ModelFormat:  CoreMLModelFormatMLProgram,
+//	        }).ExecutionProvider(),
+//	    },
+//	}
+type CoreMLProviderOptions struct {
+	// ComputeUnits restricts the hardware CoreML may use.
+	ComputeUnits CoreMLComputeUnits
+
+	// ModelFormat selects the CoreML model format.
+	ModelFormat CoreMLModelFormat
+
+	// RequireStaticInputShapes only assigns nodes with static input shapes to CoreML.
+	// Dynamic shapes can cause CoreML to fall back to slower paths.
+	RequireStaticInputShapes bool
+
+	// EnableOnSubgraphs allows CoreML to run nodes inside control flow subgraphs.
+	EnableOnSubgraphs bool
+
+	// ModelCacheDirectory caches compiled CoreML models on disk so later
+	// sessions skip compilation. Empty disables caching.
+	ModelCacheDirectory string
+}
+
+// ExecutionProvider returns the ExecutionProvider entry for these options,
+// suitable for SessionOptions.ExecutionProviders.
+func (o *CoreMLProviderOptions) ExecutionProvider() ExecutionProvider {
+	if o == nil {
+		o = &CoreMLProviderOptions{}
+	}
+
+	options := map[string]string{
+		"MLComputeUnits": o.ComputeUnits.String(),
+		"ModelFormat":    o.ModelFormat.String(),
+	}
+	if o.RequireStaticInputShapes {
+		options["RequireStaticInputShapes"] = "1"
+	}
+	if o.EnableOnSubgraphs {
+		options["EnableOnSubgraphs"] = "1"
+	}
+	if o.ModelCacheDirectory != "" {
+		options["ModelCacheDirectory"] = o.ModelCacheDirectory
+	}
+
+	return ExecutionProvider{Name: "CoreMLExecutionProvider", Options: options}
+}
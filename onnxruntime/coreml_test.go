@@ -0,0 +1,52 @@
+package onnxruntime
+
+import "testing"
+
+func TestCoreMLProviderOptions(t *testing.T) {
+	ep := (&CoreMLProviderOptions{
+		ComputeUnits:             CoreMLComputeUnitsCPUAndNeuralEngine,
+		ModelFormat:              CoreMLModelFormatMLProgram,
+		RequireStaticInputShapes: true,
+		ModelCacheDirectory:      "/tmp/coreml",
+	}).ExecutionProvider()
+
+	if ep.Name != "CoreMLExecutionProvider" {
+		t.Errorf("Expected CoreMLExecutionProvider, got %s", ep.Name)
+	}
+
+	expected := map[string]string{
+		"MLComputeUnits":           "CPUAndNeuralEngine",
+		"ModelFormat":              "MLProgram",
+		"RequireStaticInputShapes": "1",
+		"ModelCacheDirectory":      "/tmp/coreml",
+	}
+	if len(ep.Options) != len(expected) {
+		t.Errorf("Expected %d options, got %v", len(expected), ep.Options)
+	}
+	for k, v := range expected {
+		if ep.Options[k] != v {
+			t.Errorf("Option %s = %q, want %q", k, ep.Options[k], v)
+		}
+	}
+}
+
+func TestCoreMLProviderOptionsDefaults(t *testing.T) {
+	var opts *CoreMLProviderOptions
+	ep := opts.ExecutionProvider()
+
+	if ep.Options["MLComputeUnits"] != "ALL" || ep.Options["ModelFormat"] != "NeuralNetwork" {
+		t.Errorf("Unexpected default options: %v", ep.Options)
+	}
+	if _, ok := ep.Options["RequireStaticInputShapes"]; ok {
+		t.Error("RequireStaticInputShapes should be unset by default")
+	}
+}
+
+func TestAppendProviderName(t *testing.T) {
+	if got := appendProviderName("CoreMLExecutionProvider"); got != "CoreML" {
+		t.Errorf("Expected CoreML, got %s", got)
+	}
+	if got := appendProviderName("CustomProvider"); got != "CustomProvider" {
+		t.Errorf("Expected passthrough, got %s", got)
+	}
+}
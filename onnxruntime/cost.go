@@ -0,0 +1,109 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/onnxproto"
+)
+
+// RunCost is an estimate of the work performed by a single inference run.
+// Estimates come from the model graph with shapes resolved for the request's
+// actual input shapes; they are meant for usage accounting and capacity
+// forecasting, not as exact hardware counters.
+type RunCost struct {
+	// FLOPs is the estimated number of floating-point operations.
+	// A multiply-accumulate counts as two operations.
+	FLOPs int64
+
+	// MemoryBytes is the estimated memory traffic: bytes read by every operator
+	// (activations and weights) plus bytes written to its outputs.
+	MemoryBytes int64
+
+	// InputBytes and OutputBytes are the sizes of the graph inputs and outputs.
+	InputBytes  int64
+	OutputBytes int64
+
+	// UnresolvedNodes counts operators whose output shapes could not be inferred
+	// (unsupported or data-dependent ops). Their cost, and the cost of nodes that
+	// depend on them, is not included, so a non-zero value means the estimate
+	// is a lower bound.
+	UnresolvedNodes int
+}
+
+// CostModel estimates per-run cost from a model graph. It parses the ONNX file
+// once and resolves shapes for each request, so it is cheap enough to call on
+// every Run. A CostModel is safe for concurrent use.
+//
+// Example:
+//
+//	costModel, err := onnxruntime.NewCostModel(modelData)
+//	pool, err := onnxruntime.NewSessionPool(runtime, env, modelData, 4, &onnxruntime.PoolConfig{
+//	    CostModel: costModel,
+//	    Hooks: []onnxruntime.Hook{onnxruntime.AfterRunHook(func(info *onnxruntime.RunInfo) {
+//	        billing.Charge(tenant, info.Cost.FLOPs)
+//	    })},
+//	})
+type CostModel struct {
+	graph        *onnxproto.Graph
+	initializers map[string]*onnxproto.Tensor
+	opset        int64
+}
+
+// NewCostModel creates a cost model from serialized ONNX model data.
+// The native library is not required.
+func NewCostModel(modelData []byte) (*CostModel, error) {
+	if DetectModelFormat(modelData) == ModelFormatORT {
+		return nil, fmt.Errorf("%w: cost estimation requires an ONNX format model", ErrUnsupportedModelFormat)
+	}
+
+	model, err := onnxproto.DecodeModel(modelData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse model: %w", err)
+	}
+
+	m := &CostModel{
+		graph:        model.Graph,
+		initializers: make(map[string]*onnxproto.Tensor, len(model.Graph.Initializers)),
+	}
+	for _, t := range model.Graph.Initializers {
+		m.initializers[t.Name] = t
+	}
+	for _, op := range model.OpsetImports {
+		if op.Domain == "" || op.Domain == "ai.onnx" {
+			m.opset = op.Version
+		}
+	}
+	return m, nil
+}
+
+// NewCostModelFromFile creates a cost model from an ONNX model file.
+func NewCostModelFromFile(path string) (*CostModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model file: %w", err)
+	}
+	return NewCostModel(data)
+}
+
+// Estimate returns the estimated cost of a run with the given input shapes.
+// Inputs missing from inputShapes use the model's declared shape when it is
+// fully static.
+func (m *CostModel) Estimate(inputShapes map[string][]int64) RunCost {
+	return m.newShapeInference(inputShapes).run()
+}
+
+// EstimateValues returns the estimated cost of a run with the given input tensors.
+// Non-tensor inputs are treated as having unknown shape.
+func (m *CostModel) EstimateValues(inputs map[string]*Value) RunCost {
+	shapes := make(map[string][]int64, len(inputs))
+	for name, v := range inputs {
+		if v == nil {
+			continue
+		}
+		if shape, err := v.GetTensorShape(); err == nil {
+			shapes[name] = shape
+		}
+	}
+	return m.Estimate(shapes)
+}
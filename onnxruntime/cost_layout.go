@@ -0,0 +1,533 @@
+package onnxruntime
+
+import (
+	"math"
+	"slices"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/onnxproto"
+)
+
+// inferShapeOp handles operators that reshape, reorder, or select data without
+// meaningful arithmetic. They are free in FLOPs but still move memory.
+func inferShapeOp(n *onnxproto.Node, in []*tensorState, opset int64) ([]*tensorState, bool) {
+	x := argAt(in, 0)
+
+	switch n.OpType {
+	case "Shape":
+		if x == nil {
+			return nil, false
+		}
+		rank := int64(len(x.shape))
+		start, end := attrInt(n, "start", 0), attrInt(n, "end", rank)
+		start, end = clampRange(start, rank), clampRange(end, rank)
+		dims := []int64{}
+		if start < end {
+			dims = slices.Clone(x.shape[start:end])
+		}
+		return []*tensorState{{shape: []int64{int64(len(dims))}, dtype: onnxproto.DataTypeInt64, values: dims}}, true
+
+	case "Size":
+		if x == nil {
+			return nil, false
+		}
+		return []*tensorState{{shape: []int64{}, dtype: onnxproto.DataTypeInt64, values: []int64{x.numElements()}}}, true
+
+	case "Flatten":
+		if x == nil {
+			return nil, false
+		}
+		axis := attrInt(n, "axis", 1)
+		if axis < 0 {
+			axis += int64(len(x.shape))
+		}
+		if axis < 0 || axis > int64(len(x.shape)) {
+			return nil, false
+		}
+		shape := []int64{shapeElements(x.shape[:axis]), shapeElements(x.shape[axis:])}
+		return []*tensorState{{shape: shape, dtype: x.dtype}}, true
+
+	case "Reshape":
+		target := argAt(in, 1)
+		if x == nil || target == nil || target.values == nil {
+			return nil, false
+		}
+		shape, ok := reshapeTarget(x.shape, target.values, attrInt(n, "allowzero", 0) != 0)
+		if !ok {
+			return nil, false
+		}
+		return []*tensorState{{shape: shape, dtype: x.dtype, values: x.values}}, true
+
+	case "Transpose":
+		if x == nil {
+			return nil, false
+		}
+		perm := attrInts(n, "perm")
+		if len(perm) == 0 {
+			for i := len(x.shape) - 1; i >= 0; i-- {
+				perm = append(perm, int64(i))
+			}
+		}
+		if len(perm) != len(x.shape) {
+			return nil, false
+		}
+		shape := make([]int64, len(perm))
+		for i, p := range perm {
+			if p < 0 || int(p) >= len(x.shape) {
+				return nil, false
+			}
+			shape[i] = x.shape[p]
+		}
+		return []*tensorState{{shape: shape, dtype: x.dtype}}, true
+
+	case "Squeeze":
+		if x == nil {
+			return nil, false
+		}
+		axes, ok := axesArg(n, in, 1, opset >= 13)
+		if !ok {
+			return nil, false
+		}
+		squeeze := make([]bool, len(x.shape))
+		for _, a := range axes {
+			i, ok := normalizeAxis(a, len(x.shape))
+			if !ok {
+				return nil, false
+			}
+			squeeze[i] = true
+		}
+		shape := []int64{}
+		for i, d := range x.shape {
+			if (len(axes) == 0 && d == 1) || squeeze[i] {
+				continue
+			}
+			shape = append(shape, d)
+		}
+		return []*tensorState{{shape: shape, dtype: x.dtype, values: x.values}}, true
+
+	case "Unsqueeze":
+		if x == nil {
+			return nil, false
+		}
+		axes, ok := axesArg(n, in, 1, opset >= 13)
+		if !ok || len(axes) == 0 {
+			return nil, false
+		}
+		rank := len(x.shape) + len(axes)
+		insert := make([]bool, rank)
+		for _, a := range axes {
+			i, ok := normalizeAxis(a, rank)
+			if !ok {
+				return nil, false
+			}
+			insert[i] = true
+		}
+		shape := make([]int64, 0, rank)
+		next := 0
+		for i := 0; i < rank; i++ {
+			if insert[i] {
+				shape = append(shape, 1)
+			} else if next < len(x.shape) {
+				shape = append(shape, x.shape[next])
+				next++
+			}
+		}
+		return []*tensorState{{shape: shape, dtype: x.dtype, values: x.values}}, true
+
+	case "Concat":
+		return inferConcat(n, in)
+
+	case "Gather":
+		return inferGather(n, in)
+
+	case "Slice":
+		return inferSlice(n, in, opset)
+
+	case "Split":
+		return inferSplit(n, in, opset)
+
+	case "ConstantOfShape":
+		if x == nil || x.values == nil {
+			return nil, false
+		}
+		out := &tensorState{shape: x.values, dtype: onnxproto.DataTypeFloat}
+		if a := n.Attribute("value"); a != nil && a.T != nil {
+			out.dtype = a.T.DataType
+		}
+		return []*tensorState{out}, true
+
+	case "Expand":
+		target := argAt(in, 1)
+		if x == nil || target == nil || target.values == nil {
+			return nil, false
+		}
+		shape, ok := broadcastShapes(x.shape, target.values)
+		if !ok {
+			return nil, false
+		}
+		return []*tensorState{{shape: shape, dtype: x.dtype}}, true
+
+	case "Tile":
+		repeats := argAt(in, 1)
+		if x == nil || repeats == nil || len(repeats.values) != len(x.shape) {
+			return nil, false
+		}
+		shape := make([]int64, len(x.shape))
+		for i, d := range x.shape {
+			shape[i] = d * repeats.values[i]
+		}
+		return []*tensorState{{shape: shape, dtype: x.dtype}}, true
+
+	case "Pad":
+		if x == nil {
+			return nil, false
+		}
+		pads := attrInts(n, "pads")
+		if opset >= 11 {
+			t := argAt(in, 1)
+			if t == nil || t.values == nil {
+				return nil, false
+			}
+			pads = t.values
+		}
+		if hasInput(n, 3) { // axes (opset 18+) not tracked
+			return nil, false
+		}
+		if len(pads) != 2*len(x.shape) {
+			return nil, false
+		}
+		shape := make([]int64, len(x.shape))
+		for i, d := range x.shape {
+			shape[i] = d + pads[i] + pads[len(x.shape)+i]
+		}
+		return []*tensorState{{shape: shape, dtype: x.dtype}}, true
+
+	case "Resize", "Upsample":
+		return inferResize(n, in)
+
+	case "Range":
+		start, limit, delta := argAt(in, 0), argAt(in, 1), argAt(in, 2)
+		if start == nil || limit == nil || delta == nil ||
+			len(start.values) != 1 || len(limit.values) != 1 || len(delta.values) != 1 || delta.values[0] == 0 {
+			return nil, false
+		}
+		s, l, d := start.values[0], limit.values[0], delta.values[0]
+		count := max(0, (l-s+d-sign(d))/d)
+		out := &tensorState{shape: []int64{count}, dtype: start.dtype}
+		if count <= maxTrackedValues {
+			out.values = make([]int64, count)
+			for i := range out.values {
+				out.values[i] = s + int64(i)*d
+			}
+		}
+		return []*tensorState{out}, true
+
+	case "TopK":
+		k := argAt(in, 1)
+		if x == nil || k == nil || len(k.values) != 1 {
+			return nil, false
+		}
+		axis, ok := normalizeAxis(attrInt(n, "axis", -1), len(x.shape))
+		if !ok {
+			return nil, false
+		}
+		shape := slices.Clone(x.shape)
+		shape[axis] = k.values[0]
+		return []*tensorState{
+			{shape: shape, dtype: x.dtype},
+			{shape: shape, dtype: onnxproto.DataTypeInt64},
+		}, true
+	}
+
+	return nil, false
+}
+
+func sign(v int64) int64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
+
+func clampRange(v, rank int64) int64 {
+	if v < 0 {
+		v += rank
+	}
+	return min(max(v, 0), rank)
+}
+
+// axesArg reads an axes list from the attribute (older opsets) or the given input.
+func axesArg(n *onnxproto.Node, in []*tensorState, index int, fromInput bool) ([]int64, bool) {
+	if !fromInput {
+		return attrInts(n, "axes"), true
+	}
+	if !hasInput(n, index) {
+		return nil, true
+	}
+	t := argAt(in, index)
+	if t == nil || t.values == nil {
+		return nil, false
+	}
+	return t.values, true
+}
+
+// reshapeTarget resolves 0 and -1 entries in a Reshape target shape.
+func reshapeTarget(in, target []int64, allowZero bool) ([]int64, bool) {
+	shape := slices.Clone(target)
+	inferred := -1
+	known := int64(1)
+	for i, d := range shape {
+		switch {
+		case d == 0 && !allowZero:
+			if i >= len(in) {
+				return nil, false
+			}
+			shape[i] = in[i]
+			known *= in[i]
+		case d == -1:
+			if inferred >= 0 {
+				return nil, false
+			}
+			inferred = i
+		case d < 0:
+			return nil, false
+		default:
+			known *= d
+		}
+	}
+	if inferred >= 0 {
+		if known == 0 {
+			return nil, false
+		}
+		shape[inferred] = shapeElements(in) / known
+	}
+	if shapeElements(shape) != shapeElements(in) {
+		return nil, false
+	}
+	return shape, true
+}
+
+func inferConcat(n *onnxproto.Node, in []*tensorState) ([]*tensorState, bool) {
+	if len(in) == 0 || in[0] == nil {
+		return nil, false
+	}
+	rank := len(in[0].shape)
+	axis, ok := normalizeAxis(attrInt(n, "axis", 0), rank)
+	if !ok {
+		return nil, false
+	}
+
+	shape := slices.Clone(in[0].shape)
+	shape[axis] = 0
+	trackValues := rank == 1
+	var values []int64
+	for _, t := range in {
+		if t == nil || len(t.shape) != rank {
+			return nil, false
+		}
+		shape[axis] += t.shape[axis]
+		if t.values == nil {
+			trackValues = false
+		}
+		values = append(values, t.values...)
+	}
+
+	out := &tensorState{shape: shape, dtype: in[0].dtype}
+	if trackValues && len(values) <= maxTrackedValues {
+		out.values = values
+	}
+	return []*tensorState{out}, true
+}
+
+func inferGather(n *onnxproto.Node, in []*tensorState) ([]*tensorState, bool) {
+	data, indices := argAt(in, 0), argAt(in, 1)
+	if data == nil || indices == nil {
+		return nil, false
+	}
+	axis, ok := normalizeAxis(attrInt(n, "axis", 0), len(data.shape))
+	if !ok {
+		return nil, false
+	}
+
+	shape := slices.Clone(data.shape[:axis])
+	shape = append(shape, indices.shape...)
+	shape = append(shape, data.shape[axis+1:]...)
+	out := &tensorState{shape: shape, dtype: data.dtype}
+
+	// Gathering from a tracked 1-D tensor (typically a Shape output).
+	if len(data.shape) == 1 && data.values != nil && indices.values != nil {
+		values := make([]int64, len(indices.values))
+		for i, idx := range indices.values {
+			if idx < 0 {
+				idx += int64(len(data.values))
+			}
+			if idx < 0 || idx >= int64(len(data.values)) {
+				return nil, false
+			}
+			values[i] = data.values[idx]
+		}
+		out.values = values
+	}
+	return []*tensorState{out}, true
+}
+
+func inferSlice(n *onnxproto.Node, in []*tensorState, opset int64) ([]*tensorState, bool) {
+	x := argAt(in, 0)
+	if x == nil {
+		return nil, false
+	}
+
+	var starts, ends, axes, steps []int64
+	if opset < 10 {
+		starts, ends, axes = attrInts(n, "starts"), attrInts(n, "ends"), attrInts(n, "axes")
+	} else {
+		read := func(i int) ([]int64, bool) {
+			if !hasInput(n, i) {
+				return nil, true
+			}
+			t := argAt(in, i)
+			if t == nil || t.values == nil {
+				return nil, false
+			}
+			return t.values, true
+		}
+		var ok1, ok2, ok3, ok4 bool
+		starts, ok1 = read(1)
+		ends, ok2 = read(2)
+		axes, ok3 = read(3)
+		steps, ok4 = read(4)
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			return nil, false
+		}
+	}
+	if len(starts) != len(ends) {
+		return nil, false
+	}
+	if axes == nil {
+		for i := range starts {
+			axes = append(axes, int64(i))
+		}
+	}
+	if len(axes) != len(starts) || (steps != nil && len(steps) != len(starts)) {
+		return nil, false
+	}
+
+	shape := slices.Clone(x.shape)
+	var valueRange [3]int64 // start, end, step for 1-D value tracking
+	for i, a := range axes {
+		axis, ok := normalizeAxis(a, len(shape))
+		if !ok {
+			return nil, false
+		}
+		step := int64(1)
+		if steps != nil {
+			step = steps[i]
+		}
+		if step == 0 {
+			return nil, false
+		}
+		dim := x.shape[axis]
+		start, end := starts[i], ends[i]
+		if start < 0 {
+			start += dim
+		}
+		if end < 0 {
+			end += dim
+		}
+		if step > 0 {
+			start, end = min(max(start, 0), dim), min(max(end, 0), dim)
+			shape[axis] = max(0, (end-start+step-1)/step)
+		} else {
+			start, end = min(max(start, -1), dim-1), min(max(end, -1), dim-1)
+			shape[axis] = max(0, (start-end-step-1)/(-step))
+		}
+		valueRange = [3]int64{start, end, step}
+	}
+
+	out := &tensorState{shape: shape, dtype: x.dtype}
+	if len(x.shape) == 1 && x.values != nil {
+		start, step := valueRange[0], valueRange[2]
+		values := make([]int64, shape[0])
+		for i := range values {
+			values[i] = x.values[start+int64(i)*step]
+		}
+		out.values = values
+	}
+	return []*tensorState{out}, true
+}
+
+func inferSplit(n *onnxproto.Node, in []*tensorState, opset int64) ([]*tensorState, bool) {
+	x := argAt(in, 0)
+	if x == nil || len(n.Outputs) == 0 {
+		return nil, false
+	}
+	axis, ok := normalizeAxis(attrInt(n, "axis", 0), len(x.shape))
+	if !ok {
+		return nil, false
+	}
+
+	split := attrInts(n, "split")
+	if opset >= 13 && hasInput(n, 1) {
+		t := argAt(in, 1)
+		if t == nil || t.values == nil {
+			return nil, false
+		}
+		split = t.values
+	}
+	if len(split) == 0 {
+		parts := int64(len(n.Outputs))
+		dim := x.shape[axis]
+		chunk := (dim + parts - 1) / parts
+		for i := int64(0); i < parts; i++ {
+			split = append(split, min(chunk, max(0, dim-i*chunk)))
+		}
+	}
+	if len(split) != len(n.Outputs) {
+		return nil, false
+	}
+
+	outputs := make([]*tensorState, len(split))
+	for i, size := range split {
+		shape := slices.Clone(x.shape)
+		shape[axis] = size
+		outputs[i] = &tensorState{shape: shape, dtype: x.dtype}
+	}
+	return outputs, true
+}
+
+func inferResize(n *onnxproto.Node, in []*tensorState) ([]*tensorState, bool) {
+	x := argAt(in, 0)
+	if x == nil {
+		return nil, false
+	}
+
+	// Resize (opset 11+): X, roi, scales, sizes. Upsample and Resize-10: X, scales.
+	scalesIndex, sizesIndex := 2, 3
+	if n.OpType == "Upsample" || len(n.Inputs) == 2 {
+		scalesIndex, sizesIndex = 1, -1
+	}
+
+	if sizesIndex > 0 && hasInput(n, sizesIndex) {
+		sizes := argAt(in, sizesIndex)
+		if sizes == nil || len(sizes.values) != len(x.shape) {
+			return nil, false
+		}
+		return []*tensorState{{shape: slices.Clone(sizes.values), dtype: x.dtype}}, true
+	}
+
+	scales := attrFloats(n, "scales")
+	if scalesIndex < len(n.Inputs) && hasInput(n, scalesIndex) {
+		t := argAt(in, scalesIndex)
+		if t == nil {
+			return nil, false
+		}
+		scales = t.floats
+	}
+	if len(scales) != len(x.shape) {
+		return nil, false
+	}
+	shape := make([]int64, len(x.shape))
+	for i, d := range x.shape {
+		shape[i] = int64(math.Floor(float64(d) * float64(scales[i])))
+	}
+	return []*tensorState{{shape: shape, dtype: x.dtype}}, true
+}
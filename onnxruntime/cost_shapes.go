@@ -0,0 +1,707 @@
+package onnxruntime
+
+import (
+	"slices"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/onnxproto"
+)
+
+// maxTrackedValues bounds the size of integer tensors whose contents are tracked
+// during shape inference (shape arithmetic like Shape -> Gather -> Concat -> Reshape).
+const maxTrackedValues = 64
+
+// tensorState is what shape inference knows about one graph value.
+type tensorState struct {
+	shape  []int64
+	dtype  int32
+	values []int64   // contents of small integer tensors, when known
+	floats []float32 // contents of small float constants (e.g. Resize scales), when known
+}
+
+func (t *tensorState) numElements() int64 {
+	return shapeElements(t.shape)
+}
+
+func (t *tensorState) bytes() int64 {
+	size := onnxproto.ElementSize(t.dtype)
+	if size == 0 {
+		size = 4
+	}
+	return t.numElements() * int64(size)
+}
+
+func shapeElements(shape []int64) int64 {
+	n := int64(1)
+	for _, d := range shape {
+		n *= d
+	}
+	return n
+}
+
+// shapeInference propagates concrete shapes through a graph for one request.
+type shapeInference struct {
+	model   *CostModel
+	tensors map[string]*tensorState
+	cost    RunCost
+}
+
+func (m *CostModel) newShapeInference(inputShapes map[string][]int64) *shapeInference {
+	s := &shapeInference{
+		model:   m,
+		tensors: make(map[string]*tensorState, len(m.graph.Nodes)+len(m.initializers)),
+	}
+
+	for name, t := range m.initializers {
+		s.tensors[name] = tensorFromProto(t)
+	}
+
+	for _, in := range m.graph.Inputs {
+		if _, isInitializer := m.initializers[in.Name]; isInitializer {
+			continue
+		}
+		var dtype int32
+		if in.Type != nil {
+			dtype = in.Type.ElemType
+		}
+
+		shape, ok := inputShapes[in.Name]
+		if !ok {
+			shape, ok = staticShape(in.Type)
+		}
+		if !ok {
+			continue
+		}
+		state := &tensorState{shape: slices.Clone(shape), dtype: dtype}
+		s.tensors[in.Name] = state
+		s.cost.InputBytes += state.bytes()
+	}
+	return s
+}
+
+func tensorFromProto(t *onnxproto.Tensor) *tensorState {
+	state := &tensorState{shape: t.Dims, dtype: t.DataType}
+	if state.shape == nil {
+		state.shape = []int64{}
+	}
+	if !t.IsExternal() && t.NumElements() <= maxTrackedValues {
+		if vals, err := t.Int64s(); err == nil && int64(len(vals)) == t.NumElements() {
+			state.values = vals
+		}
+		if vals, err := t.Float32s(); err == nil && int64(len(vals)) == t.NumElements() {
+			state.floats = vals
+		}
+	}
+	return state
+}
+
+func staticShape(t *onnxproto.TypeInfo) ([]int64, bool) {
+	if t == nil || t.Kind != onnxproto.TypeTensor || !t.HasShape {
+		return nil, false
+	}
+	shape := make([]int64, len(t.Shape))
+	for i, d := range t.Shape {
+		if !d.HasValue {
+			return nil, false
+		}
+		shape[i] = d.Value
+	}
+	return shape, true
+}
+
+func (s *shapeInference) run() RunCost {
+	for _, node := range s.model.graph.Nodes {
+		s.visit(node)
+	}
+	for _, out := range s.model.graph.Outputs {
+		if t, ok := s.tensors[out.Name]; ok {
+			s.cost.OutputBytes += t.bytes()
+		}
+	}
+	return s.cost
+}
+
+// hasInput reports whether the node's i-th optional input is present in the graph.
+func hasInput(n *onnxproto.Node, i int) bool {
+	return i < len(n.Inputs) && n.Inputs[i] != ""
+}
+
+func (s *shapeInference) visit(n *onnxproto.Node) {
+	// Every present input must be resolved before the node can be.
+	inputs := make([]*tensorState, len(n.Inputs))
+	for i, name := range n.Inputs {
+		if name == "" {
+			continue
+		}
+		t, ok := s.tensors[name]
+		if !ok {
+			s.cost.UnresolvedNodes++
+			return
+		}
+		inputs[i] = t
+	}
+
+	outputs, flops, ok := inferNode(n, inputs, s.model.opset)
+	if !ok {
+		s.cost.UnresolvedNodes++
+		return
+	}
+
+	s.cost.FLOPs += flops
+	for _, in := range inputs {
+		if in != nil {
+			s.cost.MemoryBytes += in.bytes()
+		}
+	}
+	for i, out := range outputs {
+		if i >= len(n.Outputs) || n.Outputs[i] == "" || out == nil {
+			continue
+		}
+		s.tensors[n.Outputs[i]] = out
+		s.cost.MemoryBytes += out.bytes()
+	}
+}
+
+// Per-element FLOP weights for common non-linear and normalization operators.
+var elementwiseFLOPs = map[string]int64{
+	"Relu": 1, "LeakyRelu": 2, "PRelu": 2, "Elu": 3, "Selu": 3, "Celu": 3,
+	"Sigmoid": 4, "HardSigmoid": 3, "HardSwish": 4, "Tanh": 4, "Softplus": 4, "Softsign": 3,
+	"Exp": 1, "Log": 1, "Sqrt": 1, "Reciprocal": 1, "Neg": 1, "Abs": 1, "Sign": 1,
+	"Floor": 1, "Ceil": 1, "Round": 1, "Erf": 4, "Sin": 1, "Cos": 1, "Tan": 1,
+	"Clip": 2, "Gelu": 8, "FastGelu": 8, "BiasGelu": 9, "QuickGelu": 5, "Mish": 6,
+	"Softmax": 5, "LogSoftmax": 5, "Hardmax": 1,
+	"BatchNormalization": 2, "InstanceNormalization": 8, "LayerNormalization": 8,
+	"SimplifiedLayerNormalization": 6, "SkipLayerNormalization": 9,
+	"SkipSimplifiedLayerNormalization": 7, "LpNormalization": 3,
+	"Not": 1, "IsNaN": 1, "IsInf": 1,
+}
+
+// Operators that only move or relabel data and preserve the first input's shape.
+var passthroughOps = map[string]bool{
+	"Identity": true, "Dropout": true, "Cast": true, "CastLike": true,
+	"QuantizeLinear": true, "DequantizeLinear": true, "DynamicQuantizeLinear": true,
+}
+
+var broadcastOps = map[string]bool{
+	"Add": true, "Sub": true, "Mul": true, "Div": true, "Pow": true, "Mod": true,
+	"Max": true, "Min": true, "Sum": true, "Mean": true,
+	"Equal": true, "Less": true, "Greater": true, "LessOrEqual": true, "GreaterOrEqual": true,
+	"And": true, "Or": true, "Xor": true, "BitShift": true, "Where": true,
+}
+
+var comparisonOps = map[string]bool{
+	"Equal": true, "Less": true, "Greater": true, "LessOrEqual": true, "GreaterOrEqual": true,
+	"And": true, "Or": true, "Xor": true, "Not": true, "IsNaN": true, "IsInf": true,
+}
+
+var reduceOps = map[string]bool{
+	"ReduceMean": true, "ReduceSum": true, "ReduceMax": true, "ReduceMin": true, "ReduceProd": true,
+	"ReduceL1": true, "ReduceL2": true, "ReduceSumSquare": true, "ReduceLogSum": true, "ReduceLogSumExp": true,
+}
+
+// inferNode computes output states and FLOPs for one node. ok is false when the
+// op is unsupported or its output shape depends on unknown data.
+func inferNode(n *onnxproto.Node, in []*tensorState, opset int64) (out []*tensorState, flops int64, ok bool) {
+	first := func() *tensorState {
+		if len(in) == 0 {
+			return nil
+		}
+		return in[0]
+	}()
+
+	switch op := n.OpType; {
+	case op == "Constant":
+		return inferConstant(n)
+
+	case elementwiseFLOPs[op] > 0 || passthroughOps[op]:
+		if first == nil {
+			return nil, 0, false
+		}
+		result := &tensorState{shape: first.shape, dtype: first.dtype}
+		switch op {
+		case "Identity":
+			result.values = first.values
+		case "Cast":
+			result.dtype = int32(attrInt(n, "to", int64(first.dtype)))
+			if isIntegerType(result.dtype) {
+				result.values = first.values
+			}
+		case "CastLike":
+			if t := argAt(in, 1); t != nil {
+				result.dtype = t.dtype
+			}
+		case "DequantizeLinear":
+			result.dtype = onnxproto.DataTypeFloat
+		case "QuantizeLinear":
+			result.dtype = onnxproto.DataTypeUint8
+			if zp := argAt(in, 2); zp != nil {
+				result.dtype = zp.dtype
+			}
+		}
+		if comparisonOps[op] {
+			result.dtype = onnxproto.DataTypeBool
+		}
+		return []*tensorState{result}, first.numElements() * elementwiseFLOPs[op], true
+
+	case broadcastOps[op]:
+		return inferBroadcast(n, in)
+
+	case op == "MatMul" || op == "MatMulInteger" || op == "FusedMatMul":
+		return inferMatMul(in)
+
+	case op == "Gemm":
+		return inferGemm(n, in)
+
+	case op == "Conv" || op == "ConvInteger" || op == "FusedConv":
+		return inferConv(n, in)
+
+	case op == "ConvTranspose":
+		return inferConvTranspose(n, in)
+
+	case op == "MaxPool" || op == "AveragePool" || op == "LpPool":
+		return inferPool(n, in)
+
+	case op == "GlobalAveragePool" || op == "GlobalMaxPool" || op == "GlobalLpPool":
+		if first == nil || len(first.shape) < 2 {
+			return nil, 0, false
+		}
+		shape := slices.Clone(first.shape)
+		for i := 2; i < len(shape); i++ {
+			shape[i] = 1
+		}
+		return []*tensorState{{shape: shape, dtype: first.dtype}}, first.numElements(), true
+
+	case reduceOps[op] || op == "ArgMax" || op == "ArgMin":
+		return inferReduce(n, in, opset)
+
+	default:
+		out, ok := inferShapeOp(n, in, opset)
+		return out, 0, ok
+	}
+}
+
+func argAt(in []*tensorState, i int) *tensorState {
+	if i < len(in) {
+		return in[i]
+	}
+	return nil
+}
+
+func attrInt(n *onnxproto.Node, name string, def int64) int64 {
+	if a := n.Attribute(name); a != nil {
+		return a.I
+	}
+	return def
+}
+
+func attrInts(n *onnxproto.Node, name string) []int64 {
+	if a := n.Attribute(name); a != nil {
+		return a.Ints
+	}
+	return nil
+}
+
+func attrFloats(n *onnxproto.Node, name string) []float32 {
+	if a := n.Attribute(name); a != nil {
+		return a.Floats
+	}
+	return nil
+}
+
+func attrString(n *onnxproto.Node, name string) string {
+	if a := n.Attribute(name); a != nil {
+		return string(a.S)
+	}
+	return ""
+}
+
+func isIntegerType(dtype int32) bool {
+	switch dtype {
+	case onnxproto.DataTypeInt8, onnxproto.DataTypeInt16, onnxproto.DataTypeInt32, onnxproto.DataTypeInt64,
+		onnxproto.DataTypeUint8, onnxproto.DataTypeUint16, onnxproto.DataTypeUint32, onnxproto.DataTypeUint64:
+		return true
+	}
+	return false
+}
+
+// normalizeAxis maps a possibly negative axis into [0, rank).
+func normalizeAxis(axis int64, rank int) (int, bool) {
+	if axis < 0 {
+		axis += int64(rank)
+	}
+	if axis < 0 || axis >= int64(rank) {
+		return 0, false
+	}
+	return int(axis), true
+}
+
+func inferConstant(n *onnxproto.Node) ([]*tensorState, int64, bool) {
+	for _, a := range n.Attributes {
+		switch a.Name {
+		case "value":
+			if a.T != nil {
+				return []*tensorState{tensorFromProto(a.T)}, 0, true
+			}
+		case "value_int":
+			return []*tensorState{{shape: []int64{}, dtype: onnxproto.DataTypeInt64, values: []int64{a.I}}}, 0, true
+		case "value_ints":
+			return []*tensorState{{shape: []int64{int64(len(a.Ints))}, dtype: onnxproto.DataTypeInt64, values: a.Ints}}, 0, true
+		case "value_float":
+			return []*tensorState{{shape: []int64{}, dtype: onnxproto.DataTypeFloat, floats: []float32{a.F}}}, 0, true
+		case "value_floats":
+			return []*tensorState{{shape: []int64{int64(len(a.Floats))}, dtype: onnxproto.DataTypeFloat, floats: a.Floats}}, 0, true
+		}
+	}
+	return nil, 0, false
+}
+
+// broadcastShapes applies numpy-style multidirectional broadcasting.
+func broadcastShapes(shapes ...[]int64) ([]int64, bool) {
+	rank := 0
+	for _, s := range shapes {
+		rank = max(rank, len(s))
+	}
+	out := make([]int64, rank)
+	for i := range out {
+		out[i] = 1
+	}
+	for _, s := range shapes {
+		offset := rank - len(s)
+		for i, d := range s {
+			switch {
+			case d == out[offset+i] || d == 1:
+			case out[offset+i] == 1:
+				out[offset+i] = d
+			default:
+				return nil, false
+			}
+		}
+	}
+	return out, true
+}
+
+func inferBroadcast(n *onnxproto.Node, in []*tensorState) ([]*tensorState, int64, bool) {
+	shapes := make([][]int64, 0, len(in))
+	for _, t := range in {
+		if t == nil {
+			return nil, 0, false
+		}
+		shapes = append(shapes, t.shape)
+	}
+	if len(shapes) == 0 {
+		return nil, 0, false
+	}
+	shape, ok := broadcastShapes(shapes...)
+	if !ok {
+		return nil, 0, false
+	}
+
+	result := &tensorState{shape: shape, dtype: in[0].dtype}
+	switch {
+	case comparisonOps[n.OpType]:
+		result.dtype = onnxproto.DataTypeBool
+	case n.OpType == "Where":
+		result.dtype = in[1].dtype
+	}
+
+	// Track integer arithmetic used to compute shapes.
+	if len(in) == 2 && in[0].values != nil && in[1].values != nil && isIntegerType(result.dtype) {
+		result.values = broadcastValues(n.OpType, in[0], in[1], shape)
+	}
+
+	elements := shapeElements(shape)
+	return []*tensorState{result}, elements * int64(max(1, len(in)-1)), true
+}
+
+// broadcastValues evaluates simple integer arithmetic on tracked values.
+func broadcastValues(op string, a, b *tensorState, shape []int64) []int64 {
+	count := shapeElements(shape)
+	if count > maxTrackedValues || len(shape) > 1 {
+		return nil
+	}
+	at := func(t *tensorState, i int64) int64 {
+		if len(t.values) == 1 {
+			return t.values[0]
+		}
+		return t.values[i]
+	}
+	out := make([]int64, count)
+	for i := range out {
+		x, y := at(a, int64(i)), at(b, int64(i))
+		switch op {
+		case "Add":
+			out[i] = x + y
+		case "Sub":
+			out[i] = x - y
+		case "Mul":
+			out[i] = x * y
+		case "Div":
+			if y == 0 {
+				return nil
+			}
+			out[i] = x / y
+		case "Max":
+			out[i] = max(x, y)
+		case "Min":
+			out[i] = min(x, y)
+		default:
+			return nil
+		}
+	}
+	return out
+}
+
+func inferMatMul(in []*tensorState) ([]*tensorState, int64, bool) {
+	a, b := argAt(in, 0), argAt(in, 1)
+	if a == nil || b == nil || len(a.shape) == 0 || len(b.shape) == 0 {
+		return nil, 0, false
+	}
+
+	as, bs := a.shape, b.shape
+	if len(as) == 1 {
+		as = []int64{1, as[0]}
+	}
+	if len(bs) == 1 {
+		bs = []int64{bs[0], 1}
+	}
+	m, k := as[len(as)-2], as[len(as)-1]
+	k2, nn := bs[len(bs)-2], bs[len(bs)-1]
+	if k != k2 {
+		return nil, 0, false
+	}
+
+	batch, ok := broadcastShapes(as[:len(as)-2], bs[:len(bs)-2])
+	if !ok {
+		return nil, 0, false
+	}
+
+	shape := slices.Clone(batch)
+	if len(a.shape) > 1 {
+		shape = append(shape, m)
+	}
+	if len(b.shape) > 1 {
+		shape = append(shape, nn)
+	}
+
+	dtype := a.dtype
+	if dtype == onnxproto.DataTypeInt8 || dtype == onnxproto.DataTypeUint8 {
+		dtype = onnxproto.DataTypeInt32 // MatMulInteger
+	}
+	flops := 2 * shapeElements(batch) * m * nn * k
+	return []*tensorState{{shape: shape, dtype: dtype}}, flops, true
+}
+
+func inferGemm(n *onnxproto.Node, in []*tensorState) ([]*tensorState, int64, bool) {
+	a, b := argAt(in, 0), argAt(in, 1)
+	if a == nil || b == nil || len(a.shape) != 2 || len(b.shape) != 2 {
+		return nil, 0, false
+	}
+
+	m, k := a.shape[0], a.shape[1]
+	if attrInt(n, "transA", 0) != 0 {
+		m, k = k, m
+	}
+	k2, nn := b.shape[0], b.shape[1]
+	if attrInt(n, "transB", 0) != 0 {
+		k2, nn = nn, k2
+	}
+	if k != k2 {
+		return nil, 0, false
+	}
+
+	flops := 2 * m * nn * k
+	if argAt(in, 2) != nil {
+		flops += m * nn
+	}
+	return []*tensorState{{shape: []int64{m, nn}, dtype: a.dtype}}, flops, true
+}
+
+// windowOutput computes the output size of one spatial dimension of a
+// convolution or pooling window.
+func windowOutput(in, kernel, stride, dilation, padBegin, padEnd int64, autoPad string, ceil bool) int64 {
+	effective := (kernel-1)*dilation + 1
+	switch autoPad {
+	case "SAME_UPPER", "SAME_LOWER":
+		return (in + stride - 1) / stride
+	case "VALID":
+		return (in - effective + stride) / stride
+	}
+	span := in + padBegin + padEnd - effective
+	if ceil {
+		return (span+stride-1)/stride + 1
+	}
+	return span/stride + 1
+}
+
+// windowParams reads strides, dilations and pads for the given number of spatial dims.
+func windowParams(n *onnxproto.Node, spatial int) (strides, dilations, pads []int64) {
+	strides = attrInts(n, "strides")
+	dilations = attrInts(n, "dilations")
+	pads = attrInts(n, "pads")
+	fill := func(v []int64, size int, def int64) []int64 {
+		if len(v) == size {
+			return v
+		}
+		out := make([]int64, size)
+		for i := range out {
+			out[i] = def
+		}
+		return out
+	}
+	return fill(strides, spatial, 1), fill(dilations, spatial, 1), fill(pads, 2*spatial, 0)
+}
+
+func inferConv(n *onnxproto.Node, in []*tensorState) ([]*tensorState, int64, bool) {
+	x, w := argAt(in, 0), argAt(in, 1)
+	if x == nil || w == nil || len(x.shape) < 3 || len(w.shape) != len(x.shape) {
+		return nil, 0, false
+	}
+
+	spatial := len(x.shape) - 2
+	kernel := attrInts(n, "kernel_shape")
+	if len(kernel) != spatial {
+		kernel = w.shape[2:]
+	}
+	strides, dilations, pads := windowParams(n, spatial)
+	autoPad := attrString(n, "auto_pad")
+
+	shape := []int64{x.shape[0], w.shape[0]}
+	for i := 0; i < spatial; i++ {
+		d := windowOutput(x.shape[2+i], kernel[i], strides[i], dilations[i], pads[i], pads[spatial+i], autoPad, false)
+		if d <= 0 {
+			return nil, 0, false
+		}
+		shape = append(shape, d)
+	}
+
+	out := &tensorState{shape: shape, dtype: x.dtype}
+	if n.OpType == "ConvInteger" {
+		out.dtype = onnxproto.DataTypeInt32
+	}
+	macsPerOutput := shapeElements(w.shape[1:]) // (C/group) * prod(kernel)
+	flops := 2 * shapeElements(shape) * macsPerOutput
+	if argAt(in, 2) != nil {
+		flops += shapeElements(shape)
+	}
+	return []*tensorState{out}, flops, true
+}
+
+func inferConvTranspose(n *onnxproto.Node, in []*tensorState) ([]*tensorState, int64, bool) {
+	x, w := argAt(in, 0), argAt(in, 1)
+	if x == nil || w == nil || len(x.shape) < 3 || len(w.shape) != len(x.shape) {
+		return nil, 0, false
+	}
+
+	spatial := len(x.shape) - 2
+	kernel := attrInts(n, "kernel_shape")
+	if len(kernel) != spatial {
+		kernel = w.shape[2:]
+	}
+	strides, dilations, pads := windowParams(n, spatial)
+	outputPadding := attrInts(n, "output_padding")
+	outputShape := attrInts(n, "output_shape")
+	group := attrInt(n, "group", 1)
+
+	shape := []int64{x.shape[0], w.shape[1] * group}
+	for i := 0; i < spatial; i++ {
+		var d int64
+		if len(outputShape) == spatial {
+			d = outputShape[i]
+		} else {
+			d = strides[i]*(x.shape[2+i]-1) + (kernel[i]-1)*dilations[i] + 1 - pads[i] - pads[spatial+i]
+			if len(outputPadding) == spatial {
+				d += outputPadding[i]
+			}
+		}
+		if d <= 0 {
+			return nil, 0, false
+		}
+		shape = append(shape, d)
+	}
+
+	// Each input element is scattered into (M/group) * prod(kernel) outputs.
+	flops := 2 * x.numElements() * shapeElements(w.shape[1:])
+	return []*tensorState{{shape: shape, dtype: x.dtype}}, flops, true
+}
+
+func inferPool(n *onnxproto.Node, in []*tensorState) ([]*tensorState, int64, bool) {
+	x := argAt(in, 0)
+	kernel := attrInts(n, "kernel_shape")
+	if x == nil || len(x.shape) < 3 || len(kernel) != len(x.shape)-2 {
+		return nil, 0, false
+	}
+
+	spatial := len(kernel)
+	strides, dilations, pads := windowParams(n, spatial)
+	autoPad := attrString(n, "auto_pad")
+	ceil := attrInt(n, "ceil_mode", 0) != 0
+
+	shape := []int64{x.shape[0], x.shape[1]}
+	for i := 0; i < spatial; i++ {
+		d := windowOutput(x.shape[2+i], kernel[i], strides[i], dilations[i], pads[i], pads[spatial+i], autoPad, ceil)
+		if d <= 0 {
+			return nil, 0, false
+		}
+		shape = append(shape, d)
+	}
+
+	out := &tensorState{shape: shape, dtype: x.dtype}
+	outputs := []*tensorState{out}
+	if len(n.Outputs) > 1 { // MaxPool indices
+		outputs = append(outputs, &tensorState{shape: shape, dtype: onnxproto.DataTypeInt64})
+	}
+	return outputs, shapeElements(shape) * shapeElements(kernel), true
+}
+
+func inferReduce(n *onnxproto.Node, in []*tensorState, opset int64) ([]*tensorState, int64, bool) {
+	x := argAt(in, 0)
+	if x == nil {
+		return nil, 0, false
+	}
+	rank := len(x.shape)
+	keepDims := attrInt(n, "keepdims", 1) != 0
+
+	var axes []int64
+	dtype := x.dtype
+	if n.OpType == "ArgMax" || n.OpType == "ArgMin" {
+		axes = []int64{attrInt(n, "axis", 0)}
+		dtype = onnxproto.DataTypeInt64
+	} else {
+		axes = attrInts(n, "axes")
+		axesFromInput := n.OpType == "ReduceSum" && opset >= 13 || opset >= 18
+		if axesFromInput && hasInput(n, 1) {
+			t := argAt(in, 1)
+			if t == nil || t.values == nil {
+				return nil, 0, false
+			}
+			axes = t.values
+		}
+		if len(axes) == 0 && attrInt(n, "noop_with_empty_axes", 0) != 0 {
+			return []*tensorState{{shape: x.shape, dtype: dtype}}, 0, true
+		}
+	}
+
+	reduced := make([]bool, rank)
+	if len(axes) == 0 {
+		for i := range reduced {
+			reduced[i] = true
+		}
+	}
+	for _, a := range axes {
+		i, ok := normalizeAxis(a, rank)
+		if !ok {
+			return nil, 0, false
+		}
+		reduced[i] = true
+	}
+
+	shape := make([]int64, 0, rank)
+	for i, d := range x.shape {
+		switch {
+		case !reduced[i]:
+			shape = append(shape, d)
+		case keepDims:
+			shape = append(shape, 1)
+		}
+	}
+	return []*tensorState{{shape: shape, dtype: dtype}}, x.numElements(), true
+}
@@ -0,0 +1,183 @@
+package onnxruntime
+
+import (
+	"os"
+	"testing"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/onnxproto"
+)
+
+func TestCostModelTestModel(t *testing.T) {
+	data, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	model, err := NewCostModel(data)
+	if err != nil {
+		t.Fatalf("NewCostModel failed: %v", err)
+	}
+
+	// input [4,10] -> Gemm(16) -> Relu -> Gemm(3)
+	cost := model.Estimate(map[string][]int64{"input": {4, 10}})
+
+	wantFLOPs := int64(2*4*16*10 + 4*16 + 4*16 + 2*4*3*16 + 4*3)
+	if cost.FLOPs != wantFLOPs {
+		t.Errorf("FLOPs = %d, want %d", cost.FLOPs, wantFLOPs)
+	}
+	if cost.UnresolvedNodes != 0 {
+		t.Errorf("Expected all nodes resolved, got %d unresolved", cost.UnresolvedNodes)
+	}
+	if cost.InputBytes != 4*10*4 || cost.OutputBytes != 4*3*4 {
+		t.Errorf("InputBytes = %d, OutputBytes = %d", cost.InputBytes, cost.OutputBytes)
+	}
+	if cost.MemoryBytes <= cost.InputBytes+cost.OutputBytes {
+		t.Errorf("MemoryBytes %d should include weights and activations", cost.MemoryBytes)
+	}
+
+	// Cost scales with batch size.
+	double := model.Estimate(map[string][]int64{"input": {8, 10}})
+	if double.FLOPs != 2*cost.FLOPs {
+		t.Errorf("Expected FLOPs to double with batch size, got %d vs %d", double.FLOPs, cost.FLOPs)
+	}
+
+	// Unknown batch dimension cannot be resolved.
+	unknown := model.Estimate(nil)
+	if unknown.UnresolvedNodes != 3 || unknown.FLOPs != 0 {
+		t.Errorf("Expected 3 unresolved nodes without input shapes, got %+v", unknown)
+	}
+}
+
+func int64Initializer(name string, values ...int64) *onnxproto.Tensor {
+	return &onnxproto.Tensor{Name: name, Dims: []int64{int64(len(values))}, DataType: onnxproto.DataTypeInt64, Int64Data: values}
+}
+
+func TestCostModelConvAndShapeArithmetic(t *testing.T) {
+	// x[N,3,32,32] -> Conv(8, 3x3, pad 1) -> MaxPool(2x2, stride 2)
+	//   -> Reshape(Concat(Gather(Shape, 0), [-1])) -> MatMul(w[2048,10])
+	graph := &onnxproto.Graph{
+		Inputs: []*onnxproto.ValueInfo{{Name: "x", Type: &onnxproto.TypeInfo{Kind: onnxproto.TypeTensor, ElemType: onnxproto.DataTypeFloat}}},
+		Initializers: []*onnxproto.Tensor{
+			{Name: "w", Dims: []int64{8, 3, 3, 3}, DataType: onnxproto.DataTypeFloat},
+			{Name: "fc", Dims: []int64{2048, 10}, DataType: onnxproto.DataTypeFloat},
+			int64Initializer("zero", 0),
+			int64Initializer("minus_one", -1),
+		},
+		Nodes: []*onnxproto.Node{
+			{OpType: "Conv", Inputs: []string{"x", "w"}, Outputs: []string{"conv"}, Attributes: []*onnxproto.Attribute{
+				{Name: "pads", Ints: []int64{1, 1, 1, 1}},
+			}},
+			{OpType: "MaxPool", Inputs: []string{"conv"}, Outputs: []string{"pool"}, Attributes: []*onnxproto.Attribute{
+				{Name: "kernel_shape", Ints: []int64{2, 2}},
+				{Name: "strides", Ints: []int64{2, 2}},
+			}},
+			{OpType: "Shape", Inputs: []string{"pool"}, Outputs: []string{"shape"}},
+			{OpType: "Gather", Inputs: []string{"shape", "zero"}, Outputs: []string{"batch"}},
+			{OpType: "Concat", Inputs: []string{"batch", "minus_one"}, Outputs: []string{"target"}, Attributes: []*onnxproto.Attribute{
+				{Name: "axis", I: 0},
+			}},
+			{OpType: "Reshape", Inputs: []string{"pool", "target"}, Outputs: []string{"flat"}},
+			{OpType: "MatMul", Inputs: []string{"flat", "fc"}, Outputs: []string{"y"}},
+		},
+		Outputs: []*onnxproto.ValueInfo{{Name: "y"}},
+	}
+	model := &CostModel{graph: graph, initializers: map[string]*onnxproto.Tensor{}, opset: 17}
+	for _, init := range graph.Initializers {
+		model.initializers[init.Name] = init
+	}
+
+	cost := model.Estimate(map[string][]int64{"x": {2, 3, 32, 32}})
+	if cost.UnresolvedNodes != 0 {
+		t.Fatalf("Expected all nodes resolved, got %d unresolved", cost.UnresolvedNodes)
+	}
+
+	conv := int64(2 * (2 * 8 * 32 * 32) * (3 * 3 * 3))
+	pool := int64(2 * 8 * 16 * 16 * 4)
+	matmul := int64(2 * 2 * 10 * 2048)
+	if want := conv + pool + matmul; cost.FLOPs != want {
+		t.Errorf("FLOPs = %d, want %d", cost.FLOPs, want)
+	}
+	if cost.OutputBytes != 2*10*4 {
+		t.Errorf("OutputBytes = %d, want %d", cost.OutputBytes, 2*10*4)
+	}
+}
+
+func TestCostModelUnsupportedOp(t *testing.T) {
+	graph := &onnxproto.Graph{
+		Inputs: []*onnxproto.ValueInfo{{Name: "x"}},
+		Nodes: []*onnxproto.Node{
+			{OpType: "NonZero", Inputs: []string{"x"}, Outputs: []string{"idx"}},
+			{OpType: "Relu", Inputs: []string{"idx"}, Outputs: []string{"y"}},
+		},
+	}
+	model := &CostModel{graph: graph, initializers: map[string]*onnxproto.Tensor{}}
+
+	cost := model.Estimate(map[string][]int64{"x": {4}})
+	if cost.UnresolvedNodes != 2 {
+		t.Errorf("Expected the data-dependent op and its consumer to be unresolved, got %d", cost.UnresolvedNodes)
+	}
+}
+
+func TestReshapeTarget(t *testing.T) {
+	tests := []struct {
+		in, target, want []int64
+	}{
+		{[]int64{2, 3, 4}, []int64{0, -1}, []int64{2, 12}},
+		{[]int64{2, 3, 4}, []int64{-1}, []int64{24}},
+		{[]int64{2, 3, 4}, []int64{6, 4}, []int64{6, 4}},
+	}
+	for _, tc := range tests {
+		got, ok := reshapeTarget(tc.in, tc.target, false)
+		if !ok || len(got) != len(tc.want) {
+			t.Errorf("reshapeTarget(%v, %v) = %v, %v", tc.in, tc.target, got, ok)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("reshapeTarget(%v, %v) = %v, want %v", tc.in, tc.target, got, tc.want)
+			}
+		}
+	}
+
+	if _, ok := reshapeTarget([]int64{2, 3}, []int64{4, 2}, false); ok {
+		t.Error("Expected element count mismatch to fail")
+	}
+}
+
+func TestPoolCostModel(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	data, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+	model, err := NewCostModel(data)
+	if err != nil {
+		t.Fatalf("NewCostModel failed: %v", err)
+	}
+
+	var got *RunCost
+	pool, err := NewSessionPool(runtime, env, data, 1, &PoolConfig{
+		CostModel: model,
+		Hooks:     []Hook{AfterRunHook(func(info *RunInfo) { got = info.Cost })},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	outputs := runPoolInference(t, pool)
+	for _, v := range outputs {
+		v.Close()
+	}
+
+	if got == nil || got.FLOPs == 0 {
+		t.Errorf("Expected RunInfo.Cost to be populated, got %+v", got)
+	}
+}
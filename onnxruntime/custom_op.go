@@ -0,0 +1,339 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	"github.com/ebitengine/purego"
+)
+
+// CustomOp is an operator implemented in Go. Register it with
+// NewCustomOpDomain and add the domain to SessionOptions.CustomOpDomains so
+// models can use it for pre- and post-processing steps inside the graph.
+//
+// Compute may be called concurrently from ONNX Runtime threads and must not
+// call back into the session being run.
+type CustomOp interface {
+	// Name is the operator type used by nodes in the model.
+	Name() string
+
+	// InputTypes returns the element type of each input. Use
+	// ONNXTensorElementDataTypeUndefined to accept any type.
+	InputTypes() []ONNXTensorElementDataType
+
+	// OutputTypes returns the element type of each output.
+	OutputTypes() []ONNXTensorElementDataType
+
+	// Compute reads the inputs from ctx and writes the outputs it creates.
+	Compute(ctx *KernelContext) error
+}
+
+// KernelContext gives a CustomOp access to the tensors of one invocation.
+// It and the Values it returns are only valid during Compute.
+type KernelContext struct {
+	ptr     api.OrtKernelContext
+	runtime *Runtime
+	values  []*Value
+}
+
+// InputCount returns the number of inputs.
+func (k *KernelContext) InputCount() (int, error) {
+	var n uintptr
+	status := k.runtime.apiFuncs.KernelContextGetInputCount(k.ptr, &n)
+	if err := k.runtime.statusError(status, "KernelContext_GetInputCount"); err != nil {
+		return 0, fmt.Errorf("failed to get input count: %w", err)
+	}
+	return int(n), nil
+}
+
+// OutputCount returns the number of outputs.
+func (k *KernelContext) OutputCount() (int, error) {
+	var n uintptr
+	status := k.runtime.apiFuncs.KernelContextGetOutputCount(k.ptr, &n)
+	if err := k.runtime.statusError(status, "KernelContext_GetOutputCount"); err != nil {
+		return 0, fmt.Errorf("failed to get output count: %w", err)
+	}
+	return int(n), nil
+}
+
+// Input returns the i-th input. It is nil for an omitted optional input.
+// Read it with GetTensorDataUnsafe or GetTensorData; ONNX Runtime owns it.
+func (k *KernelContext) Input(i int) (*Value, error) {
+	var ptr api.OrtValue
+	status := k.runtime.apiFuncs.KernelContextGetInput(k.ptr, uintptr(i), &ptr)
+	if err := k.runtime.statusError(status, "KernelContext_GetInput"); err != nil {
+		return nil, fmt.Errorf("failed to get input %d: %w", i, err)
+	}
+	if ptr == 0 {
+		return nil, nil
+	}
+	return k.borrow(ptr), nil
+}
+
+// Output allocates the i-th output with the given shape and returns it.
+// Fill it through GetTensorDataUnsafe; ONNX Runtime owns it.
+func (k *KernelContext) Output(i int, shape []int64) (*Value, error) {
+	var dims *int64
+	if len(shape) > 0 {
+		dims = &shape[0]
+	}
+	var ptr api.OrtValue
+	status := k.runtime.apiFuncs.KernelContextGetOutput(k.ptr, uintptr(i), dims, uintptr(len(shape)), &ptr)
+	if err := k.runtime.statusError(status, "KernelContext_GetOutput"); err != nil {
+		return nil, fmt.Errorf("failed to get output %d: %w", i, err)
+	}
+	return k.borrow(ptr), nil
+}
+
+// borrow wraps a value owned by ONNX Runtime. It is released when Compute
+// returns.
+func (k *KernelContext) borrow(ptr api.OrtValue) *Value {
+	v := &Value{ptr: ptr, runtime: k.runtime, borrowed: true}
+	k.values = append(k.values, v)
+	return v
+}
+
+func (k *KernelContext) release() {
+	for _, v := range k.values {
+		v.Close()
+	}
+	k.values = nil
+}
+
+// cCustomOp mirrors OrtCustomOp from onnxruntime_c_api.h. Fields after
+// KernelComputeV2 are only read for newer versions and stay zero.
+type cCustomOp struct {
+	version                      uint32
+	createKernel                 uintptr
+	getName                      uintptr
+	getExecutionProviderType     uintptr
+	getInputType                 uintptr
+	getInputTypeCount            uintptr
+	getOutputType                uintptr
+	getOutputTypeCount           uintptr
+	kernelCompute                uintptr
+	kernelDestroy                uintptr
+	getInputCharacteristic       uintptr
+	getOutputCharacteristic      uintptr
+	getInputMemoryType           uintptr
+	getVariadicInputMinArity     uintptr
+	getVariadicInputHomogeneity  uintptr
+	getVariadicOutputMinArity    uintptr
+	getVariadicOutputHomogeneity uintptr
+	createKernelV2               uintptr
+	kernelComputeV2              uintptr
+	inferOutputShapeFn           uintptr
+	getStartVersion              uintptr
+	getEndVersion                uintptr
+	getMayInplace                uintptr
+	releaseMayInplace            uintptr
+	getAliasMap                  uintptr
+	releaseAliasMap              uintptr
+}
+
+// customOpVersion is the OrtCustomOp version implemented by cCustomOp: the
+// first with KernelComputeV2, so Compute errors are reported as statuses.
+const customOpVersion = 16
+
+// customOp is the registered state for one CustomOp.
+type customOp struct {
+	c       cCustomOp
+	op      CustomOp
+	name    []byte
+	inputs  []ONNXTensorElementDataType
+	outputs []ONNXTensorElementDataType
+	runtime *Runtime
+}
+
+var (
+	// purego callbacks are never freed, so one set of trampolines is shared
+	// by all custom ops and dispatches on the OrtCustomOp address.
+	customOpCallbacksOnce sync.Once
+	customOpCallbacks     cCustomOp
+
+	// Entries are never removed: ONNX Runtime keeps pointers to the
+	// OrtCustomOp for as long as any session using it lives.
+	customOps sync.Map // uintptr -> *customOp
+)
+
+func initCustomOpCallbacks() {
+	customOpCallbacksOnce.Do(func() {
+		customOpCallbacks = cCustomOp{
+			createKernel:                 purego.NewCallback(customOpCreateKernel),
+			getName:                      purego.NewCallback(customOpGetName),
+			getExecutionProviderType:     purego.NewCallback(customOpGetExecutionProviderType),
+			getInputType:                 purego.NewCallback(customOpGetInputType),
+			getInputTypeCount:            purego.NewCallback(customOpGetInputTypeCount),
+			getOutputType:                purego.NewCallback(customOpGetOutputType),
+			getOutputTypeCount:           purego.NewCallback(customOpGetOutputTypeCount),
+			kernelDestroy:                purego.NewCallback(customOpKernelDestroy),
+			getInputCharacteristic:       purego.NewCallback(customOpGetCharacteristic),
+			getOutputCharacteristic:      purego.NewCallback(customOpGetCharacteristic),
+			getInputMemoryType:           purego.NewCallback(customOpGetInputMemoryType),
+			getVariadicInputMinArity:     purego.NewCallback(customOpGetVariadicMinArity),
+			getVariadicInputHomogeneity:  purego.NewCallback(customOpGetVariadicHomogeneity),
+			getVariadicOutputMinArity:    purego.NewCallback(customOpGetVariadicMinArity),
+			getVariadicOutputHomogeneity: purego.NewCallback(customOpGetVariadicHomogeneity),
+			kernelComputeV2:              purego.NewCallback(customOpKernelComputeV2),
+		}
+		customOpCallbacks.version = customOpVersion
+	})
+}
+
+func lookupCustomOp(op uintptr) *customOp {
+	if v, ok := customOps.Load(op); ok {
+		return v.(*customOp)
+	}
+	return nil
+}
+
+// customOpCreateKernel returns the op itself as the kernel: Go ops carry no
+// per-node state.
+func customOpCreateKernel(op, _, _ uintptr) uintptr {
+	return op
+}
+
+func customOpGetName(op uintptr) uintptr {
+	return uintptr(unsafe.Pointer(&lookupCustomOp(op).name[0]))
+}
+
+// customOpGetExecutionProviderType returns NULL, which selects the CPU provider.
+func customOpGetExecutionProviderType(uintptr) uintptr {
+	return 0
+}
+
+func customOpGetInputType(op, index uintptr) uintptr {
+	return uintptr(lookupCustomOp(op).inputs[index])
+}
+
+func customOpGetInputTypeCount(op uintptr) uintptr {
+	return uintptr(len(lookupCustomOp(op).inputs))
+}
+
+func customOpGetOutputType(op, index uintptr) uintptr {
+	return uintptr(lookupCustomOp(op).outputs[index])
+}
+
+func customOpGetOutputTypeCount(op uintptr) uintptr {
+	return uintptr(len(lookupCustomOp(op).outputs))
+}
+
+func customOpKernelDestroy(uintptr) {}
+
+// customOpGetCharacteristic marks every input and output as required.
+func customOpGetCharacteristic(_, _ uintptr) uintptr {
+	return 0 // INPUT_OUTPUT_REQUIRED
+}
+
+func customOpGetInputMemoryType(_, _ uintptr) uintptr {
+	return uintptr(MemTypeDefault)
+}
+
+func customOpGetVariadicMinArity(uintptr) uintptr {
+	return 1
+}
+
+func customOpGetVariadicHomogeneity(uintptr) uintptr {
+	return 1
+}
+
+// customOpKernelComputeV2 runs Compute and converts an error or panic into
+// an ORT_FAIL status.
+func customOpKernelComputeV2(kernel, context uintptr) (status uintptr) {
+	op := lookupCustomOp(kernel)
+	ctx := &KernelContext{ptr: api.OrtKernelContext(context), runtime: op.runtime}
+	defer ctx.release()
+	defer func() {
+		if p := recover(); p != nil {
+			status = op.failure(fmt.Errorf("panic: %v", p))
+		}
+	}()
+	if err := op.op.Compute(ctx); err != nil {
+		return op.failure(err)
+	}
+	return 0
+}
+
+// failure creates an ORT_FAIL status for err. ONNX Runtime takes ownership.
+func (o *customOp) failure(err error) uintptr {
+	msg := append([]byte(fmt.Sprintf("custom op %s: %v", o.op.Name(), err)), 0)
+	return uintptr(o.runtime.apiFuncs.CreateStatus(ErrorCodeFail, &msg[0]))
+}
+
+// CustomOpDomain is a set of Go custom operators under one operator domain.
+type CustomOpDomain struct {
+	ptr     api.OrtCustomOpDomain
+	runtime *Runtime
+	ops     []*customOp
+
+	// ID of the domain in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the domain is not closed
+	cleanup runtime.Cleanup
+}
+
+// NewCustomOpDomain registers ops under domain, such as "com.example".
+// Models reference them by node domain and op type, and must import the
+// domain in their opset imports. The domain must not be closed before the
+// sessions using it.
+//
+// Example:
+//
+//	domain, err := runtime.NewCustomOpDomain("com.example", &Tokenize{})
+//	defer domain.Close()
+//	session, err := runtime.NewSession(env, "model.onnx", &onnxruntime.SessionOptions{
+//	    CustomOpDomains: []*onnxruntime.CustomOpDomain{domain},
+//	})
+func (r *Runtime) NewCustomOpDomain(domain string, ops ...CustomOp) (*CustomOpDomain, error) {
+	domainBytes := append([]byte(domain), 0)
+	var ptr api.OrtCustomOpDomain
+	status := r.apiFuncs.CreateCustomOpDomain(&domainBytes[0], &ptr)
+	if err := r.statusError(status, "CreateCustomOpDomain"); err != nil {
+		return nil, fmt.Errorf("failed to create custom op domain: %w", err)
+	}
+	d := &CustomOpDomain{ptr: ptr, runtime: r, trackID: r.objects.add(kindCustomOpDomain)}
+	d.cleanup = guard(d, leak{runtime: r, kind: kindCustomOpDomain, id: d.trackID, ptr: uintptr(ptr), release: releaseLeakedCustomOpDomain})
+
+	initCustomOpCallbacks()
+	for _, op := range ops {
+		if op == nil || op.Name() == "" {
+			d.Close()
+			return nil, fmt.Errorf("custom op is nil or has no name")
+		}
+		c := &customOp{
+			c:       customOpCallbacks,
+			op:      op,
+			name:    append([]byte(op.Name()), 0),
+			inputs:  op.InputTypes(),
+			outputs: op.OutputTypes(),
+			runtime: r,
+		}
+		customOps.Store(uintptr(unsafe.Pointer(&c.c)), c)
+
+		status := r.apiFuncs.CustomOpDomainAdd(ptr, unsafe.Pointer(&c.c))
+		if err := r.statusError(status, "CustomOpDomain_Add"); err != nil {
+			d.Close()
+			return nil, fmt.Errorf("failed to add custom op %q: %w", op.Name(), err)
+		}
+		d.ops = append(d.ops, c)
+	}
+	return d, nil
+}
+
+func releaseLeakedCustomOpDomain(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseCustomOpDomain(api.OrtCustomOpDomain(ptr))
+}
+
+// Close releases the domain. It is safe to call Close multiple times.
+func (d *CustomOpDomain) Close() {
+	if d.ptr != 0 && d.runtime != nil && d.runtime.apiFuncs != nil {
+		d.cleanup.Stop()
+		d.runtime.apiFuncs.ReleaseCustomOpDomain(d.ptr)
+		d.ptr = 0
+		d.runtime.objects.remove(kindCustomOpDomain, d.trackID)
+	}
+}
@@ -0,0 +1,181 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// doubleOp multiplies a float tensor by two, or fails with err if set.
+type doubleOp struct {
+	err error
+}
+
+func (doubleOp) Name() string { return "GoDouble" }
+
+func (doubleOp) InputTypes() []ONNXTensorElementDataType {
+	return []ONNXTensorElementDataType{ONNXTensorElementDataTypeFloat}
+}
+
+func (doubleOp) OutputTypes() []ONNXTensorElementDataType {
+	return []ONNXTensorElementDataType{ONNXTensorElementDataTypeFloat}
+}
+
+func (o doubleOp) Compute(ctx *KernelContext) error {
+	if o.err != nil {
+		return o.err
+	}
+	input, err := ctx.Input(0)
+	if err != nil {
+		return err
+	}
+	in, shape, err := GetTensorDataUnsafe[float32](input)
+	if err != nil {
+		return err
+	}
+	output, err := ctx.Output(0, shape)
+	if err != nil {
+		return err
+	}
+	out, _, err := GetTensorDataUnsafe[float32](output)
+	if err != nil {
+		return err
+	}
+	for i, x := range in {
+		out[i] = 2 * x
+	}
+	return nil
+}
+
+// protoField appends a protobuf field with a varint or length-delimited value.
+func protoField(buf []byte, field int, value any) []byte {
+	varint := func(b []byte, v uint64) []byte {
+		for v >= 0x80 {
+			b = append(b, byte(v)|0x80)
+			v >>= 7
+		}
+		return append(b, byte(v))
+	}
+	switch v := value.(type) {
+	case int:
+		buf = varint(buf, uint64(field)<<3)
+		return varint(buf, uint64(v))
+	case string:
+		value = []byte(v)
+	}
+	data := value.([]byte)
+	buf = varint(buf, uint64(field)<<3|2)
+	buf = varint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+// customOpModel returns an ONNX model with a single node Y = opType(X) in
+// domain, where X and Y are float tensors of shape [3].
+func customOpModel(domain, opType string) []byte {
+	valueInfo := func(name string) []byte {
+		dim := protoField(nil, 1, 3)                        // Dimension.dim_value
+		shape := protoField(nil, 1, dim)                    // TensorShapeProto.dim
+		tensor := protoField(nil, 1, 1)                     // elem_type FLOAT
+		tensor = protoField(tensor, 2, shape)               // shape
+		typ := protoField(nil, 1, tensor)                   // TypeProto.tensor_type
+		return protoField(protoField(nil, 1, name), 2, typ) // ValueInfoProto
+	}
+	node := protoField(nil, 1, "X")
+	node = protoField(node, 2, "Y")
+	node = protoField(node, 4, opType)
+	node = protoField(node, 7, domain)
+
+	graph := protoField(nil, 1, node)
+	graph = protoField(graph, 2, "custom_op")
+	graph = protoField(graph, 11, valueInfo("X"))
+	graph = protoField(graph, 12, valueInfo("Y"))
+
+	model := protoField(nil, 1, 8) // ir_version
+	model = protoField(model, 8, protoField(nil, 2, 17))
+	model = protoField(model, 8, protoField(protoField(nil, 1, domain), 2, 1))
+	return protoField(model, 7, graph)
+}
+
+func TestCustomOp(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	domain, err := runtime.NewCustomOpDomain("test.go", doubleOp{})
+	if err != nil {
+		t.Fatalf("NewCustomOpDomain failed: %v", err)
+	}
+	defer domain.Close()
+
+	session, err := runtime.NewSessionFromReader(env, bytes.NewReader(customOpModel("test.go", "GoDouble")), &SessionOptions{
+		CustomOpDomains: []*CustomOpDomain{domain},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	defer session.Close()
+
+	input, err := NewTensorValue(runtime, []float32{1, 2, 3}, []int64{3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	outputs, err := session.Run(t.Context(), map[string]*Value{"X": input})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	defer func() {
+		for _, v := range outputs {
+			v.Close()
+		}
+	}()
+	got, _, err := GetTensorData[float32](outputs["Y"])
+	if err != nil {
+		t.Fatalf("Failed to get output: %v", err)
+	}
+	if want := []float32{2, 4, 6}; !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestCustomOpError(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	domain, err := runtime.NewCustomOpDomain("test.go", doubleOp{err: errors.New("boom")})
+	if err != nil {
+		t.Fatalf("NewCustomOpDomain failed: %v", err)
+	}
+	defer domain.Close()
+
+	session, err := runtime.NewSessionFromReader(env, bytes.NewReader(customOpModel("test.go", "GoDouble")), &SessionOptions{
+		CustomOpDomains: []*CustomOpDomain{domain},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	defer session.Close()
+
+	input, err := NewTensorValue(runtime, []float32{1, 2, 3}, []int64{3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	_, err = session.Run(t.Context(), map[string]*Value{"X": input})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected the Compute error to surface from Run, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"sync"
+)
+
+// defaultRuntime is the process-wide runtime managed by Init.
+var defaultRuntime struct {
+	mu          sync.Mutex
+	runtime     *Runtime
+	libraryPath string
+	refs        int
+}
+
+// Init returns the process-wide default runtime, loading it with NewRuntime
+// on the first call, so that libraries built on this package can share one
+// runtime instead of each threading a *Runtime through their constructors
+// or loading the shared library again.
+//
+// Every successful call takes a reference that the caller drops by calling
+// Runtime.Close exactly once; the runtime is released when the last reference is
+// dropped, and the next Init loads it again. Calls while the runtime is
+// loaded return it as is: apiVersion is ignored, and a libraryPath other
+// than the one it was loaded from is an error. An empty libraryPath matches
+// any.
+//
+// Example:
+//
+//	runtime, err := onnxruntime.Init("", onnxruntime.APIVersionAuto)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer runtime.Close()
+func Init(libraryPath string, apiVersion uint32) (*Runtime, error) {
+	d := &defaultRuntime
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.runtime != nil {
+		if libraryPath != "" && d.libraryPath != "" && libraryPath != d.libraryPath {
+			return nil, fmt.Errorf("default runtime already loaded from %q, not %q", d.libraryPath, libraryPath)
+		}
+		d.refs++
+		return d.runtime, nil
+	}
+
+	runtime, err := NewRuntime(libraryPath, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	runtime.isDefault = true
+	d.runtime = runtime
+	d.libraryPath = libraryPath
+	d.refs = 1
+	return runtime, nil
+}
+
+// Default returns the default runtime loaded by Init, or nil if it is not
+// loaded. It does not take a reference, so the caller must not close it and
+// must only use it while an Init reference is held.
+func Default() *Runtime {
+	d := &defaultRuntime
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.runtime
+}
+
+// releaseDefault drops a reference to the default runtime r, releasing it
+// with the last one. Dropping a reference to a runtime that is no longer
+// the default does nothing.
+func releaseDefault(r *Runtime) error {
+	d := &defaultRuntime
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.runtime != r || d.refs == 0 {
+		return nil
+	}
+	if d.refs == 1 {
+		// Keep the reference if the runtime is still in use, so that
+		// Close can be retried
+		if err := r.close(); err != nil {
+			return err
+		}
+		d.runtime = nil
+		d.libraryPath = ""
+	}
+	d.refs--
+	return nil
+}
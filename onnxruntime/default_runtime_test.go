@@ -0,0 +1,63 @@
+package onnxruntime
+
+import (
+	"testing"
+)
+
+func TestInitDefaultRuntime(t *testing.T) {
+	first, err := Init(libraryPath, 23)
+	if err != nil {
+		t.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	second, err := Init("", APIVersionAuto)
+	if err != nil {
+		t.Fatalf("Second Init() error = %v", err)
+	}
+	if second != first {
+		t.Error("Expected Init to return the loaded runtime")
+	}
+	if Default() != first {
+		t.Error("Expected Default to return the runtime loaded by Init")
+	}
+	if _, err := Init("/other/libonnxruntime.so", 23); libraryPath != "" && err == nil {
+		t.Error("Expected error for a different library path")
+	}
+
+	// The runtime stays usable until the last reference is dropped
+	if err := second.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if first.apiFuncs == nil {
+		t.Fatal("Expected the runtime to stay open while referenced")
+	}
+	env, err := first.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("NewEnv() on the default runtime error = %v", err)
+	}
+	env.Close()
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if Default() != nil {
+		t.Error("Expected no default runtime after the last Close")
+	}
+	if first.apiFuncs != nil {
+		t.Error("Expected the runtime to be released after the last Close")
+	}
+
+	// Init loads the runtime again
+	again, err := Init(libraryPath, 23)
+	if err != nil {
+		t.Fatalf("Init() after release error = %v", err)
+	}
+	defer again.Close()
+	if again == first {
+		t.Error("Expected a new runtime after the previous one was released")
+	}
+}
+
+func TestReleaseDefaultOtherRuntime(t *testing.T) {
+	if err := releaseDefault(&Runtime{isDefault: true}); err != nil {
+		t.Errorf("releaseDefault() of a stale runtime error = %v", err)
+	}
+}
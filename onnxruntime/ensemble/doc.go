@@ -0,0 +1,40 @@
+// Package ensemble runs several models as one, wiring the outputs of some
+// models to the inputs of others in a directed acyclic graph.
+//
+// Each Stage names a model, maps its inputs to ensemble inputs or to outputs
+// of other stages, and optionally transforms the gathered values in Go before
+// the model runs. Stages are given as a Runner (a Session, SessionPool,
+// Model, or another Ensemble) or loaded from a model path with the
+// ensemble's shared Runtime and Env, as a single session or as a pool.
+//
+// Ensemble.Run executes stages in dependency order, running independent
+// stages concurrently. The ensemble owns every intermediate value and closes
+// it as soon as its last consumer has run, so only the requested outputs are
+// returned to the caller.
+//
+// Example of a detector feeding a classifier:
+//
+//	e, err := ensemble.New(ensemble.Config{
+//	    Runtime: runtime,
+//	    Env:     env,
+//	    Stages: []ensemble.Stage{
+//	        {Name: "detect", ModelPath: "detector.onnx", PoolSize: 2,
+//	            Inputs: map[string]ensemble.Source{"images": ensemble.Input("image")}},
+//	        {Name: "classify", ModelPath: "classifier.onnx",
+//	            Inputs: map[string]ensemble.Source{
+//	                "image": ensemble.Input("image"),
+//	                "boxes": ensemble.From("detect", "boxes"),
+//	            },
+//	            Transform: cropBoxes},
+//	    },
+//	    Outputs: map[string]ensemble.Source{
+//	        "boxes":  ensemble.From("detect", "boxes"),
+//	        "labels": ensemble.From("classify", "logits"),
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer e.Close()
+//	outputs, err := e.Run(ctx, map[string]*onnxruntime.Value{"image": image})
+package ensemble
@@ -0,0 +1,298 @@
+package ensemble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Source identifies where a value comes from: an input of the ensemble, or
+// an output of one of its stages.
+type Source struct {
+	// Stage is the name of the producing stage, or empty for an ensemble
+	// input.
+	Stage string
+
+	// Name is the ensemble input name or the stage's output name.
+	Name string
+}
+
+// Input returns the source for the ensemble input name.
+func Input(name string) Source {
+	return Source{Name: name}
+}
+
+// From returns the source for output of stage.
+func From(stage, output string) Source {
+	return Source{Stage: stage, Name: output}
+}
+
+func (s Source) String() string {
+	if s.Stage == "" {
+		return fmt.Sprintf("input %q", s.Name)
+	}
+	return fmt.Sprintf("output %q of stage %q", s.Name, s.Stage)
+}
+
+// TransformFunc converts the values gathered for a stage into the inputs of
+// its model, for example to crop, reshape, or cast between models. The
+// values passed in are owned by the ensemble and must not be closed. Values
+// returned that are not among them are closed by the ensemble after the
+// stage runs.
+type TransformFunc func(ctx context.Context, inputs map[string]*ort.Value) (map[string]*ort.Value, error)
+
+// Stage is one model of an ensemble.
+type Stage struct {
+	// Name identifies the stage in Sources. It must be unique.
+	Name string
+
+	// Runner runs the stage's model. If nil, the model is loaded from
+	// ModelPath.
+	Runner ort.Runner
+
+	// ModelPath is the model file loaded with Config.Runtime and Config.Env
+	// when Runner is nil. The ensemble closes the loaded model on Close.
+	ModelPath string
+
+	// PoolSize, if greater than zero, loads ModelPath into a SessionPool of
+	// that many sessions so concurrent ensemble runs share the stage.
+	// Otherwise a single Session is loaded.
+	PoolSize int
+
+	// SessionOptions configures the sessions loaded from ModelPath.
+	SessionOptions *ort.SessionOptions
+
+	// Inputs maps each value the stage needs to its source. Without a
+	// Transform the keys are the model's input names; with one they are
+	// the keys of the map passed to Transform.
+	Inputs map[string]Source
+
+	// Transform, if set, converts the gathered values before the model runs.
+	Transform TransformFunc
+}
+
+// Config configures an Ensemble.
+type Config struct {
+	// Stages are the models of the ensemble, in any order.
+	Stages []Stage
+
+	// Outputs maps each ensemble output name to the stage output returned
+	// under it. At least one output is required.
+	Outputs map[string]Source
+
+	// Runtime and Env are shared by all stages loaded from a ModelPath.
+	Runtime *ort.Runtime
+	Env     *ort.Env
+}
+
+// closer is implemented by the runners an ensemble loads.
+type closer interface {
+	Close()
+}
+
+type stage struct {
+	Stage
+	runner ort.Runner
+}
+
+// Ensemble runs a graph of models as a single Runner. It is safe for
+// concurrent use if its stages' runners are; a single Session is not, so use
+// PoolSize for ensembles run from several goroutines.
+type Ensemble struct {
+	// levels holds the stages in dependency order; the stages of one level
+	// only depend on earlier levels and run concurrently.
+	levels  [][]*stage
+	inputs  []string
+	outputs map[string]Source
+
+	// uses counts the consumers of each stage output, including the
+	// ensemble outputs.
+	uses map[Source]int
+
+	owned []closer
+}
+
+// New loads the stages given by ModelPath and validates the graph described
+// by config. It returns an error if a stage name is empty or repeated, a
+// source refers to a missing stage or output, or the stages form a cycle.
+func New(config Config) (_ *Ensemble, err error) {
+	if len(config.Stages) == 0 {
+		return nil, errors.New("ensemble has no stages")
+	}
+	if len(config.Outputs) == 0 {
+		return nil, errors.New("ensemble has no outputs")
+	}
+
+	e := &Ensemble{
+		outputs: config.Outputs,
+		uses:    make(map[Source]int),
+	}
+	byName := make(map[string]*stage, len(config.Stages))
+	for _, s := range config.Stages {
+		if s.Name == "" {
+			return nil, errors.New("stage name is empty")
+		}
+		if _, ok := byName[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate stage %q", s.Name)
+		}
+		if s.Runner == nil && s.ModelPath == "" {
+			return nil, fmt.Errorf("stage %q has neither a Runner nor a ModelPath", s.Name)
+		}
+		byName[s.Name] = &stage{Stage: s, runner: s.Runner}
+	}
+
+	defer func() {
+		if err != nil {
+			e.Close()
+		}
+	}()
+	for _, s := range config.Stages {
+		if st := byName[s.Name]; st.runner == nil {
+			if err := e.load(st, config.Runtime, config.Env); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// checkSource validates a stage output source.
+	checkSource := func(src Source) error {
+		producer, ok := byName[src.Stage]
+		if !ok {
+			return fmt.Errorf("unknown stage %q", src.Stage)
+		}
+		if named, ok := producer.runner.(interface{ OutputNames() []string }); ok && !slices.Contains(named.OutputNames(), src.Name) {
+			return fmt.Errorf("stage %q has no output %q", src.Stage, src.Name)
+		}
+		return nil
+	}
+
+	deps := make(map[*stage][]*stage)
+	for _, s := range config.Stages {
+		st := byName[s.Name]
+		for input, src := range s.Inputs {
+			if src.Stage == "" {
+				if !slices.Contains(e.inputs, src.Name) {
+					e.inputs = append(e.inputs, src.Name)
+				}
+				continue
+			}
+			if err := checkSource(src); err != nil {
+				return nil, fmt.Errorf("stage %q input %q: %w", s.Name, input, err)
+			}
+			if !slices.Contains(deps[st], byName[src.Stage]) {
+				deps[st] = append(deps[st], byName[src.Stage])
+			}
+			e.uses[src]++
+		}
+	}
+	seen := make(map[Source]string, len(config.Outputs))
+	for name, src := range config.Outputs {
+		if src.Stage == "" {
+			return nil, fmt.Errorf("ensemble output %q must come from a stage", name)
+		}
+		if err := checkSource(src); err != nil {
+			return nil, fmt.Errorf("ensemble output %q: %w", name, err)
+		}
+		if other, ok := seen[src]; ok {
+			return nil, fmt.Errorf("ensemble outputs %q and %q both return %s", other, name, src)
+		}
+		seen[src] = name
+		e.uses[src]++
+	}
+	slices.Sort(e.inputs)
+
+	// Group the stages into levels, keeping the configured order within a
+	// level.
+	level := make(map[*stage]int, len(byName))
+	for len(level) < len(byName) {
+		progressed := false
+		for _, s := range config.Stages {
+			st := byName[s.Name]
+			if _, done := level[st]; done {
+				continue
+			}
+			l, ready := 0, true
+			for _, dep := range deps[st] {
+				dl, ok := level[dep]
+				if !ok {
+					ready = false
+					break
+				}
+				l = max(l, dl+1)
+			}
+			if !ready {
+				continue
+			}
+			level[st] = l
+			if l == len(e.levels) {
+				e.levels = append(e.levels, nil)
+			}
+			progressed = true
+		}
+		if !progressed {
+			var cyclic []string
+			for _, s := range config.Stages {
+				if _, done := level[byName[s.Name]]; !done {
+					cyclic = append(cyclic, s.Name)
+				}
+			}
+			return nil, fmt.Errorf("stages %q form a cycle", cyclic)
+		}
+	}
+	for _, s := range config.Stages {
+		st := byName[s.Name]
+		e.levels[level[st]] = append(e.levels[level[st]], st)
+	}
+	return e, nil
+}
+
+// load creates the session or pool of a stage given by ModelPath.
+func (e *Ensemble) load(st *stage, runtime *ort.Runtime, env *ort.Env) error {
+	if runtime == nil || env == nil {
+		return fmt.Errorf("stage %q: loading %s requires Config.Runtime and Config.Env", st.Name, st.ModelPath)
+	}
+	if st.PoolSize > 0 {
+		pool, err := ort.NewSessionPoolFromFile(runtime, env, st.ModelPath, st.PoolSize, &ort.PoolConfig{SessionOptions: st.SessionOptions})
+		if err != nil {
+			return fmt.Errorf("failed to load stage %q: %w", st.Name, err)
+		}
+		st.runner = pool
+		e.owned = append(e.owned, pool)
+		return nil
+	}
+	session, err := runtime.NewSession(env, st.ModelPath, st.SessionOptions)
+	if err != nil {
+		return fmt.Errorf("failed to load stage %q: %w", st.Name, err)
+	}
+	st.runner = session
+	e.owned = append(e.owned, session)
+	return nil
+}
+
+// InputNames returns the names of the ensemble inputs the stages read,
+// sorted.
+func (e *Ensemble) InputNames() []string {
+	return slices.Clone(e.inputs)
+}
+
+// OutputNames returns the names of the ensemble outputs, sorted.
+func (e *Ensemble) OutputNames() []string {
+	names := make([]string, 0, len(e.outputs))
+	for name := range e.outputs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Close closes the sessions and pools the ensemble loaded from model paths.
+// Runners passed in a Stage are left open.
+func (e *Ensemble) Close() {
+	for _, c := range e.owned {
+		c.Close()
+	}
+	e.owned = nil
+}
@@ -0,0 +1,244 @@
+package ensemble
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	goruntime "runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func testModelPath() string {
+	_, file, _, _ := goruntime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "internal", "tests", "testdata", "model.onnx")
+}
+
+// fakeRunner returns no outputs, or err.
+type fakeRunner struct {
+	outputs []string
+	err     error
+}
+
+func (f *fakeRunner) Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error) {
+	return nil, f.err
+}
+
+func (f *fakeRunner) OutputNames() []string {
+	return f.outputs
+}
+
+func TestNewErrors(t *testing.T) {
+	runner := &fakeRunner{outputs: []string{"y"}}
+	out := map[string]Source{"y": From("a", "y")}
+
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{"no stages", Config{Outputs: out}, "no stages"},
+		{"no outputs", Config{Stages: []Stage{{Name: "a", Runner: runner}}}, "no outputs"},
+		{"empty name", Config{Stages: []Stage{{Runner: runner}}, Outputs: out}, "name is empty"},
+		{"duplicate stage", Config{Stages: []Stage{{Name: "a", Runner: runner}, {Name: "a", Runner: runner}}, Outputs: out}, "duplicate stage"},
+		{"no runner", Config{Stages: []Stage{{Name: "a"}}, Outputs: out}, "neither a Runner nor a ModelPath"},
+		{"no runtime", Config{Stages: []Stage{{Name: "a", ModelPath: "model.onnx"}}, Outputs: out}, "requires Config.Runtime"},
+		{"unknown stage", Config{
+			Stages:  []Stage{{Name: "a", Runner: runner, Inputs: map[string]Source{"x": From("b", "y")}}},
+			Outputs: out,
+		}, `unknown stage "b"`},
+		{"unknown output", Config{
+			Stages:  []Stage{{Name: "a", Runner: runner}},
+			Outputs: map[string]Source{"z": From("a", "z")},
+		}, `stage "a" has no output "z"`},
+		{"output from input", Config{
+			Stages:  []Stage{{Name: "a", Runner: runner}},
+			Outputs: map[string]Source{"x": Input("x")},
+		}, "must come from a stage"},
+		{"duplicate output", Config{
+			Stages:  []Stage{{Name: "a", Runner: runner}},
+			Outputs: map[string]Source{"y": From("a", "y"), "y2": From("a", "y")},
+		}, "both return"},
+		{"cycle", Config{
+			Stages: []Stage{
+				{Name: "a", Runner: runner, Inputs: map[string]Source{"x": From("b", "y")}},
+				{Name: "b", Runner: runner, Inputs: map[string]Source{"x": From("a", "y")}},
+				{Name: "c", Runner: runner, Inputs: map[string]Source{"x": Input("x")}},
+			},
+			Outputs: map[string]Source{"y": From("c", "y")},
+		}, `stages ["a" "b"] form a cycle`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevels(t *testing.T) {
+	runner := &fakeRunner{outputs: []string{"y"}}
+	e, err := New(Config{
+		Stages: []Stage{
+			{Name: "join", Runner: runner, Inputs: map[string]Source{"l": From("left", "y"), "r": From("right", "y")}},
+			{Name: "left", Runner: runner, Inputs: map[string]Source{"x": Input("x")}},
+			{Name: "right", Runner: runner, Inputs: map[string]Source{"x": From("root", "y")}},
+			{Name: "root", Runner: runner, Inputs: map[string]Source{"x": Input("x"), "m": Input("mask")}},
+		},
+		Outputs: map[string]Source{"y": From("join", "y")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var got [][]string
+	for _, level := range e.levels {
+		var names []string
+		for _, st := range level {
+			names = append(names, st.Name)
+		}
+		got = append(got, names)
+	}
+	want := [][]string{{"left", "root"}, {"right"}, {"join"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("levels = %v, want %v", got, want)
+	}
+	if names := e.InputNames(); !slices.Equal(names, []string{"mask", "x"}) {
+		t.Errorf("InputNames() = %v, want [mask x]", names)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	e, err := New(Config{
+		Stages: []Stage{
+			{Name: "a", Runner: &fakeRunner{outputs: []string{"y"}}, Inputs: map[string]Source{"x": Input("x")}},
+		},
+		Outputs: map[string]Source{"y": From("a", "y")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := e.Run(context.Background(), nil); err == nil || !strings.Contains(err.Error(), `missing ensemble input "x"`) {
+		t.Errorf("Run() without inputs error = %v, want missing input", err)
+	}
+	if _, err := e.Run(context.Background(), map[string]*ort.Value{"x": {}}); err == nil || !strings.Contains(err.Error(), "did not produce") {
+		t.Errorf("Run() error = %v, want missing output", err)
+	}
+
+	failure := errors.New("boom")
+	e, err = New(Config{
+		Stages: []Stage{
+			{Name: "a", Runner: &fakeRunner{outputs: []string{"y"}, err: failure}},
+			{Name: "b", Runner: &fakeRunner{}, Transform: func(ctx context.Context, inputs map[string]*ort.Value) (map[string]*ort.Value, error) {
+				return nil, failure
+			}},
+		},
+		Outputs: map[string]Source{"y": From("a", "y")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_, err = e.Run(context.Background(), nil)
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), `stage "a" failed`) || !strings.Contains(err.Error(), `stage "b" transform failed`) {
+		t.Errorf("Run() error = %v, want both stage errors", err)
+	}
+}
+
+func TestRunChain(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	shared, err := runtime.NewSession(env, testModelPath(), nil)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	defer shared.Close()
+
+	// padLogits turns the [1, 3] logits of one stage into the [1, 10] input
+	// of the next.
+	var transformed int
+	padLogits := func(ctx context.Context, inputs map[string]*ort.Value) (map[string]*ort.Value, error) {
+		transformed++
+		logits, _, err := ort.GetTensorData[float32](inputs["logits"])
+		if err != nil {
+			return nil, err
+		}
+		padded := make([]float32, 10)
+		copy(padded, logits)
+		v, err := ort.NewTensorValue(runtime, padded, []int64{1, 10})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]*ort.Value{"input": v}, nil
+	}
+
+	e, err := New(Config{
+		Runtime: runtime,
+		Env:     env,
+		Stages: []Stage{
+			{Name: "second", ModelPath: testModelPath(), PoolSize: 2,
+				Inputs:    map[string]Source{"logits": From("first", "logits")},
+				Transform: padLogits},
+			{Name: "first", Runner: shared, Inputs: map[string]Source{"input": Input("x")}},
+			{Name: "side", ModelPath: testModelPath(), Inputs: map[string]Source{"input": Input("x")}},
+		},
+		Outputs: map[string]Source{
+			"first":  From("first", "logits"),
+			"second": From("second", "logits"),
+			"side":   From("side", "logits"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer e.Close()
+
+	input, err := ort.NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	// The ensemble is itself a Runner.
+	var runner ort.Runner = e
+	outputs, err := runner.Run(context.Background(), map[string]*ort.Value{"x": input})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer func() {
+		for _, v := range outputs {
+			v.Close()
+		}
+	}()
+
+	if names := e.OutputNames(); !slices.Equal(names, []string{"first", "second", "side"}) {
+		t.Errorf("OutputNames() = %v", names)
+	}
+	if transformed != 1 {
+		t.Errorf("transform ran %d times, want 1", transformed)
+	}
+	first, _, err := ort.GetTensorData[float32](outputs["first"])
+	if err != nil {
+		t.Fatalf("GetTensorData(first) error = %v", err)
+	}
+	side, _, err := ort.GetTensorData[float32](outputs["side"])
+	if err != nil {
+		t.Fatalf("GetTensorData(side) error = %v", err)
+	}
+	if !slices.Equal(first, side) {
+		t.Errorf("first = %v, side = %v, want equal outputs for the same input", first, side)
+	}
+	if _, shape, err := ort.GetTensorData[float32](outputs["second"]); err != nil || !slices.Equal(shape, []int64{1, 3}) {
+		t.Errorf("second output shape = %v, %v, want [1 3]", shape, err)
+	}
+}
@@ -0,0 +1,141 @@
+package ensemble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Run runs every stage and returns the ensemble outputs, which the caller
+// must close. The inputs are borrowed and are not closed. Run options are
+// passed to every stage. If any stage fails, the values produced so far are
+// closed and the errors of the failing level are returned.
+func (e *Ensemble) Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error) {
+	for _, name := range e.inputs {
+		if inputs[name] == nil {
+			return nil, fmt.Errorf("missing ensemble input %q", name)
+		}
+	}
+
+	// values holds the stage outputs still needed by a later stage or as an
+	// ensemble output, and remaining counts their pending consumers.
+	values := make(map[Source]*ort.Value)
+	remaining := maps.Clone(e.uses)
+	closeAll := func() {
+		for _, v := range values {
+			v.Close()
+		}
+	}
+
+	for _, level := range e.levels {
+		if err := ctx.Err(); err != nil {
+			closeAll()
+			return nil, err
+		}
+
+		results := make([]map[string]*ort.Value, len(level))
+		errs := make([]error, len(level))
+		if len(level) == 1 {
+			results[0], errs[0] = e.runStage(ctx, level[0], inputs, values, opts)
+		} else {
+			var wg sync.WaitGroup
+			for i, st := range level {
+				wg.Go(func() {
+					results[i], errs[i] = e.runStage(ctx, st, inputs, values, opts)
+				})
+			}
+			wg.Wait()
+		}
+
+		for i, st := range level {
+			for name, v := range results[i] {
+				if src := From(st.Name, name); remaining[src] > 0 {
+					values[src] = v
+				} else {
+					v.Close()
+				}
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			closeAll()
+			return nil, err
+		}
+
+		// Release the values whose last consumer just ran.
+		for _, st := range level {
+			for _, src := range st.Inputs {
+				if src.Stage == "" {
+					continue
+				}
+				remaining[src]--
+				if remaining[src] == 0 {
+					values[src].Close()
+					delete(values, src)
+				}
+			}
+		}
+	}
+
+	outputs := make(map[string]*ort.Value, len(e.outputs))
+	for name, src := range e.outputs {
+		v, ok := values[src]
+		if !ok {
+			closeAll()
+			return nil, fmt.Errorf("ensemble output %q: stage %q did not produce %q", name, src.Stage, src.Name)
+		}
+		outputs[name] = v
+	}
+	return outputs, nil
+}
+
+// runStage gathers the inputs of st, applies its transform, and runs it.
+func (e *Ensemble) runStage(ctx context.Context, st *stage, inputs map[string]*ort.Value, values map[Source]*ort.Value, opts []ort.RunOption) (map[string]*ort.Value, error) {
+	gathered := make(map[string]*ort.Value, len(st.Inputs))
+	for input, src := range st.Inputs {
+		var v *ort.Value
+		if src.Stage == "" {
+			v = inputs[src.Name]
+		} else {
+			v = values[src]
+		}
+		if v == nil {
+			return nil, fmt.Errorf("stage %q input %q: %s was not produced", st.Name, input, src)
+		}
+		gathered[input] = v
+	}
+
+	modelInputs := gathered
+	if st.Transform != nil {
+		var err error
+		modelInputs, err = st.Transform(ctx, gathered)
+		if err != nil {
+			return nil, fmt.Errorf("stage %q transform failed: %w", st.Name, err)
+		}
+		defer func() {
+			for _, v := range modelInputs {
+				if v != nil && !containsValue(gathered, v) {
+					v.Close()
+				}
+			}
+		}()
+	}
+
+	outputs, err := st.runner.Run(ctx, modelInputs, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("stage %q failed: %w", st.Name, err)
+	}
+	return outputs, nil
+}
+
+func containsValue(m map[string]*ort.Value, v *ort.Value) bool {
+	for _, mv := range m {
+		if mv == v {
+			return true
+		}
+	}
+	return false
+}
@@ -10,8 +10,18 @@ import (
 // Env represents an ONNX Runtime environment that manages global state
 // and configuration for all inference sessions.
 type Env struct {
-	ptr     api.OrtEnv
-	runtime *Runtime
+	ptr      api.OrtEnv
+	runtime  *Runtime
+	loggerID uintptr // nonzero for environments created by NewEnvWithLogger
+
+	// globalThreadPools is set for environments created by NewEnvWithGlobalThreadPools
+	globalThreadPools bool
+
+	// ID of the environment in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the environment is not closed
+	cleanup runtime.Cleanup
 }
 
 // NewEnv creates a new ONNX Runtime environment with the specified logging level and identifier.
@@ -21,24 +31,38 @@ func (r *Runtime) NewEnv(logID string, logLevel LoggingLevel) (*Env, error) {
 	var envPtr api.OrtEnv
 
 	status := r.apiFuncs.CreateEnv(logLevel, &logIDBytes[0], &envPtr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateEnv"); err != nil {
 		return nil, fmt.Errorf("failed to create environment: %w", err)
 	}
 
 	env := &Env{
 		ptr:     envPtr,
 		runtime: r,
+		trackID: r.objects.add(kindEnv),
 	}
-	runtime.AddCleanup(env, func(_ struct{}) { env.Close() }, struct{}{})
+	env.setCleanup()
 	return env, nil
 }
 
+// setCleanup sets the safety net releasing the environment, and its logger,
+// if it is garbage collected without being closed.
+func (e *Env) setCleanup() {
+	e.cleanup = guard(e, leak{runtime: e.runtime, kind: kindEnv, id: e.trackID, ptr: uintptr(e.ptr), release: releaseLeakedEnv, keep: e.loggerID})
+}
+
+func releaseLeakedEnv(funcs api.APIFuncs, ptr uintptr, loggerID any) {
+	funcs.ReleaseEnv(api.OrtEnv(ptr))
+	if id := loggerID.(uintptr); id != 0 {
+		loggers.Delete(id)
+	}
+}
+
 // EnableTelemetry enables telemetry event collection for this environment.
 // Telemetry helps the ONNX Runtime team understand usage patterns.
 // It is enabled by default; call DisableTelemetry to opt out.
 func (e *Env) EnableTelemetry() error {
 	status := e.runtime.apiFuncs.EnableTelemetryEvents(e.ptr)
-	if err := e.runtime.statusError(status); err != nil {
+	if err := e.runtime.statusError(status, "EnableTelemetryEvents"); err != nil {
 		return fmt.Errorf("failed to enable telemetry: %w", err)
 	}
 	return nil
@@ -47,7 +71,7 @@ func (e *Env) EnableTelemetry() error {
 // DisableTelemetry disables telemetry event collection for this environment.
 func (e *Env) DisableTelemetry() error {
 	status := e.runtime.apiFuncs.DisableTelemetryEvents(e.ptr)
-	if err := e.runtime.statusError(status); err != nil {
+	if err := e.runtime.statusError(status, "DisableTelemetryEvents"); err != nil {
 		return fmt.Errorf("failed to disable telemetry: %w", err)
 	}
 	return nil
@@ -56,7 +80,13 @@ func (e *Env) DisableTelemetry() error {
 // Close releases the environment and frees associated resources.
 func (e *Env) Close() {
 	if e.ptr != 0 && e.runtime != nil && e.runtime.apiFuncs != nil {
+		e.cleanup.Stop()
 		e.runtime.apiFuncs.ReleaseEnv(e.ptr)
 		e.ptr = 0
+		e.runtime.objects.remove(kindEnv, e.trackID)
+	}
+	if e.loggerID != 0 {
+		loggers.Delete(e.loggerID)
+		e.loggerID = 0
 	}
 }
@@ -1,17 +1,111 @@
 package onnxruntime
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/benedoc-inc/onnxer/nativeerror"
+)
+
+// Sentinel errors for ONNX Runtime error codes. A *RuntimeError matches the
+// sentinel for its Code with errors.Is, so callers can test the kind of
+// failure without inspecting messages:
+//
+//	session, err := runtime.NewSession(env, path, nil)
+//	if errors.Is(err, onnxruntime.ErrNoSuchFile) {
+//	    ...
+//	}
+var (
+	ErrFail             = errors.New("onnxruntime: failure")
+	ErrInvalidArgument  = errors.New("onnxruntime: invalid argument")
+	ErrNoSuchFile       = errors.New("onnxruntime: no such file")
+	ErrNoModel          = errors.New("onnxruntime: no model")
+	ErrEngineError      = errors.New("onnxruntime: engine error")
+	ErrRuntimeException = errors.New("onnxruntime: runtime exception")
+	ErrInvalidProtobuf  = errors.New("onnxruntime: invalid protobuf")
+	ErrModelLoaded      = errors.New("onnxruntime: model already loaded")
+	ErrNotImplemented   = errors.New("onnxruntime: not implemented")
+	ErrInvalidGraph     = errors.New("onnxruntime: invalid graph")
+	ErrEPFail           = errors.New("onnxruntime: execution provider failure")
+
+	// ErrUnsupportedIRVersion matches errors for models whose IR version
+	// is newer than the loaded library supports. ONNX Runtime reports these
+	// with a generic code, so they are recognized by message.
+	ErrUnsupportedIRVersion = errors.New("onnxruntime: unsupported model IR version")
 )
 
+var errorCodeSentinels = map[ErrorCode]error{
+	ErrorCodeFail:             ErrFail,
+	ErrorCodeInvalidArgument:  ErrInvalidArgument,
+	ErrorCodeNoSuchFile:       ErrNoSuchFile,
+	ErrorCodeNoModel:          ErrNoModel,
+	ErrorCodeEngineError:      ErrEngineError,
+	ErrorCodeRuntimeException: ErrRuntimeException,
+	ErrorCodeInvalidProtobuf:  ErrInvalidProtobuf,
+	ErrorCodeModelLoaded:      ErrModelLoaded,
+	ErrorCodeNotImplemented:   ErrNotImplemented,
+	ErrorCodeInvalidGraph:     ErrInvalidGraph,
+	ErrorCodeEPFail:           ErrEPFail,
+}
+
 // RuntimeError represents an error returned from the ONNX Runtime C API.
+// It implements nativeerror.Error.
 type RuntimeError struct {
-	Code    ErrorCode
+	Code ErrorCode
+
+	// Op is the C API function that failed, such as "CreateSession" or "Run".
+	Op string
+
 	Message string
 }
 
 func (e *RuntimeError) Error() string {
-	return fmt.Sprintf("onnxruntime error (%s): %s", errorCodeName(e.Code), e.Message)
+	if e.Op == "" {
+		return fmt.Sprintf("onnxruntime error (%s): %s", errorCodeName(e.Code), e.Message)
+	}
+	return fmt.Sprintf("onnxruntime error (%s) in %s: %s", errorCodeName(e.Code), e.Op, e.Message)
+}
+
+// Is reports whether target is the sentinel error for e's code, or
+// ErrUnsupportedIRVersion for an IR version rejection.
+func (e *RuntimeError) Is(target error) bool {
+	if target == ErrUnsupportedIRVersion {
+		return strings.Contains(e.Message, "Unsupported model IR version")
+	}
+	sentinel, ok := errorCodeSentinels[e.Code]
+	return ok && target == sentinel
+}
+
+// Library returns nativeerror.LibraryONNXRuntime.
+func (e *RuntimeError) Library() string { return nativeerror.LibraryONNXRuntime }
+
+// ErrorCode returns e.Code.
+func (e *RuntimeError) ErrorCode() int { return int(e.Code) }
+
+// ErrorOp returns e.Op.
+func (e *RuntimeError) ErrorOp() string { return e.Op }
+
+// ErrorMessage returns e.Message.
+func (e *RuntimeError) ErrorMessage() string { return e.Message }
+
+// ProviderUnavailableError is returned when a requested execution provider is
+// not compiled into the loaded library. It matches ErrProviderUnavailable with errors.Is.
+type ProviderUnavailableError struct {
+	// Provider is the requested provider name.
+	Provider string
+
+	// Available lists the providers reported by the library.
+	Available []string
+}
+
+func (e *ProviderUnavailableError) Error() string {
+	return fmt.Sprintf("execution provider %q is not available (available: %s)", e.Provider, strings.Join(e.Available, ", "))
+}
+
+// Is reports whether target is ErrProviderUnavailable.
+func (e *ProviderUnavailableError) Is(target error) bool {
+	return target == ErrProviderUnavailable
 }
 
 // errorCodeName returns a human-readable name for an error code.
@@ -2,15 +2,18 @@ package onnxruntime
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/benedoc-inc/onnxer/nativeerror"
 )
 
 func TestStatusError(t *testing.T) {
 	runtime := newTestRuntime(t)
 
 	t.Run("nil status returns nil error", func(t *testing.T) {
-		err := runtime.statusError(0)
+		err := runtime.statusError(0, "Test")
 		if err != nil {
 			t.Errorf("runtime.statusError(0) should return nil, got %v", err)
 		}
@@ -35,7 +38,7 @@ func TestStatusError(t *testing.T) {
 					t.Fatal("createStatus should return non-zero status")
 				}
 
-				err := runtime.statusError(status)
+				err := runtime.statusError(status, "Test")
 				if err == nil {
 					t.Fatal("statusError should return an error")
 				}
@@ -48,6 +51,9 @@ func TestStatusError(t *testing.T) {
 				if ortErr.Code != tc.code {
 					t.Errorf("Expected error code %d, got %d", tc.code, ortErr.Code)
 				}
+				if ortErr.Op != "Test" || !strings.Contains(err.Error(), "in Test") {
+					t.Errorf("Expected error to name the failing function, got: %s", err.Error())
+				}
 
 				if !strings.Contains(err.Error(), "error for "+tc.name) {
 					t.Errorf("Error message should contain 'error for %s', got: %s", tc.name, err.Error())
@@ -87,3 +93,58 @@ func TestReleaseStatusWithNullStatus(t *testing.T) {
 	// This should not crash
 	runtime.apiFuncs.ReleaseStatus(0)
 }
+
+func TestProviderUnavailableError(t *testing.T) {
+	var err error = &ProviderUnavailableError{
+		Provider:  "CUDAExecutionProvider",
+		Available: []string{"CPUExecutionProvider"},
+	}
+
+	if !errors.Is(err, ErrProviderUnavailable) {
+		t.Error("ProviderUnavailableError should match ErrProviderUnavailable")
+	}
+	if !strings.Contains(err.Error(), "CPUExecutionProvider") {
+		t.Errorf("Error should list available providers, got %q", err.Error())
+	}
+}
+
+func TestRuntimeErrorIs(t *testing.T) {
+	err := fmt.Errorf("failed to create session: %w", &RuntimeError{
+		Code:    ErrorCodeNoSuchFile,
+		Op:      "CreateSession",
+		Message: "model.onnx not found",
+	})
+	if !errors.Is(err, ErrNoSuchFile) {
+		t.Error("Expected error to match ErrNoSuchFile")
+	}
+	if errors.Is(err, ErrInvalidArgument) || errors.Is(err, ErrUnsupportedIRVersion) {
+		t.Error("Expected error not to match other sentinels")
+	}
+	if want := "onnxruntime error (NoSuchFile) in CreateSession: model.onnx not found"; !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("Expected message ending %q, got %q", want, err.Error())
+	}
+
+	irErr := &RuntimeError{Code: ErrorCodeFail, Message: "Unsupported model IR version: 11, max supported IR version: 10"}
+	if !errors.Is(irErr, ErrUnsupportedIRVersion) || !errors.Is(irErr, ErrFail) {
+		t.Error("Expected IR version error to match ErrUnsupportedIRVersion and ErrFail")
+	}
+
+	for code, sentinel := range errorCodeSentinels {
+		if !errors.Is(&RuntimeError{Code: code}, sentinel) {
+			t.Errorf("Expected code %s to match %v", errorCodeName(code), sentinel)
+		}
+	}
+}
+
+func TestRuntimeErrorNativeError(t *testing.T) {
+	err := fmt.Errorf("failed to run: %w", &RuntimeError{Code: ErrorCodeInvalidArgument, Op: "Run", Message: "bad shape"})
+
+	var nerr nativeerror.Error
+	if !errors.As(err, &nerr) {
+		t.Fatal("Expected error to match nativeerror.Error")
+	}
+	if nerr.Library() != nativeerror.LibraryONNXRuntime || nerr.ErrorCode() != int(ErrorCodeInvalidArgument) ||
+		nerr.ErrorOp() != "Run" || nerr.ErrorMessage() != "bad shape" {
+		t.Errorf("Unexpected nativeerror.Error %q %d %q %q", nerr.Library(), nerr.ErrorCode(), nerr.ErrorOp(), nerr.ErrorMessage())
+	}
+}
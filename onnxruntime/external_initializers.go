@@ -0,0 +1,52 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// configureExternalInitializers adds SessionOptions.ExternalInitializers and
+// ExternalInitializerFiles to the session options.
+func (r *Runtime) configureExternalInitializers(optsPtr api.OrtSessionOptions, options *SessionOptions) error {
+	if n := len(options.ExternalInitializers); n > 0 {
+		names := make([]*byte, 0, n)
+		values := make([]api.OrtValue, 0, n)
+		for _, name := range slices.Sorted(maps.Keys(options.ExternalInitializers)) {
+			value := options.ExternalInitializers[name]
+			if value == nil || value.ptr == 0 {
+				return fmt.Errorf("external initializer %q is nil or closed", name)
+			}
+			nameBytes := append([]byte(name), 0)
+			names = append(names, &nameBytes[0])
+			values = append(values, value.ptr)
+		}
+		status := r.apiFuncs.AddExternalInitializers(optsPtr, &names[0], &values[0], uintptr(n))
+		if err := r.statusError(status, "AddExternalInitializers"); err != nil {
+			return fmt.Errorf("failed to add external initializers: %w", err)
+		}
+	}
+
+	if n := len(options.ExternalInitializerFiles); n > 0 {
+		fileNames := make([]*byte, 0, n)
+		buffers := make([]*byte, 0, n)
+		lengths := make([]uintptr, 0, n)
+		for _, name := range slices.Sorted(maps.Keys(options.ExternalInitializerFiles)) {
+			data := options.ExternalInitializerFiles[name]
+			if len(data) == 0 {
+				return fmt.Errorf("external initializer file %q is empty", name)
+			}
+			nameBytes := append([]byte(name), 0)
+			fileNames = append(fileNames, &nameBytes[0])
+			buffers = append(buffers, &data[0])
+			lengths = append(lengths, uintptr(len(data)))
+		}
+		status := r.apiFuncs.AddExternalInitializersFromFilesInMemory(optsPtr, &fileNames[0], &buffers[0], &lengths[0], uintptr(n))
+		if err := r.statusError(status, "AddExternalInitializersFromFilesInMemory"); err != nil {
+			return fmt.Errorf("failed to add external initializer files: %w", err)
+		}
+	}
+	return nil
+}
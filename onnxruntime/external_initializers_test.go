@@ -0,0 +1,75 @@
+package onnxruntime
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSessionOptionsExternalInitializers(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	// Zeroing the last layer makes every logit zero.
+	weight, err := NewTensorValue(runtime, make([]float32, 3*16), []int64{3, 16})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer weight.Close()
+	bias, err := NewTensorValue(runtime, make([]float32, 3), []int64{3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer bias.Close()
+
+	session := newSessionWithOptions(t, runtime, &SessionOptions{
+		ExternalInitializers: map[string]*Value{
+			"fc2.weight": weight,
+			"fc2.bias":   bias,
+		},
+	})
+
+	input, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	outputs, err := session.Run(t.Context(), map[string]*Value{"input": input})
+	if err != nil {
+		t.Fatalf("Failed to run inference: %v", err)
+	}
+	defer func() {
+		for _, v := range outputs {
+			v.Close()
+		}
+	}()
+	logits, _, err := GetTensorData[float32](outputs["logits"])
+	if err != nil {
+		t.Fatalf("Failed to get output data: %v", err)
+	}
+	if !slices.Equal(logits, []float32{0, 0, 0}) {
+		t.Errorf("Expected zero logits from the overridden initializers, got %v", logits)
+	}
+}
+
+func TestSessionOptionsExternalInitializersInvalid(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	tests := []struct {
+		name string
+		opts *SessionOptions
+	}{
+		{"nil value", &SessionOptions{ExternalInitializers: map[string]*Value{"fc2.bias": nil}}},
+		{"empty file", &SessionOptions{ExternalInitializerFiles: map[string][]byte{"weights.bin": nil}}},
+	}
+	for _, tt := range tests {
+		if _, err := runtime.NewSession(env, testModelPath(), tt.opts); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// FaultConfig configures a FaultInjector. Probabilities are in [0, 1].
+type FaultConfig struct {
+	// ErrorRate is the probability that a Run fails with ErrInjectedFault
+	// without executing the model.
+	ErrorRate float64
+
+	// PanicRate is the probability that a Run panics. Run recovers the panic
+	// into a *PanicError and marks the session unhealthy.
+	PanicRate float64
+
+	// PoisonRate is the probability that a Run poisons its session. A poisoned
+	// session fails every subsequent Run, simulating a lost or wedged device,
+	// until the injector is Reset.
+	PoisonRate float64
+
+	// Latency is added before every Run. LatencyJitter adds a further uniformly
+	// distributed delay in [0, LatencyJitter). Delays honour context cancellation.
+	Latency       time.Duration
+	LatencyJitter time.Duration
+
+	// Seed makes fault decisions reproducible. Zero uses a random seed.
+	Seed uint64
+}
+
+// FaultInjector injects artificial failures into session runs so that services
+// can exercise their retry, fallback and health-check logic without a real
+// failing accelerator. It is intended for tests and chaos experiments only.
+//
+// Attach an injector through SessionOptions.FaultInjector. Sharing one injector
+// across all sessions of a pool (via PoolConfig.SessionOptions) applies the
+// faults pool-wide while poisoning still affects individual sessions.
+//
+// Example:
+//
+//	faults := onnxruntime.NewFaultInjector(onnxruntime.FaultConfig{ErrorRate: 0.1, Seed: 1})
+//	pool, err := onnxruntime.NewSessionPool(runtime, env, modelData, 4, &onnxruntime.PoolConfig{
+//	    SessionOptions: &onnxruntime.SessionOptions{FaultInjector: faults},
+//	})
+//	// ~10% of pool.Run calls now return an error matching ErrInjectedFault.
+type FaultInjector struct {
+	mu       sync.Mutex
+	config   FaultConfig
+	rng      *rand.Rand
+	poisoned map[uint64]bool // session IDs
+	injected int64
+}
+
+// NewFaultInjector creates a FaultInjector with the given configuration.
+func NewFaultInjector(config FaultConfig) *FaultInjector {
+	seed := config.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &FaultInjector{
+		config:   config,
+		rng:      rand.New(rand.NewPCG(seed, seed)),
+		poisoned: make(map[uint64]bool),
+	}
+}
+
+// SetConfig replaces the injector's configuration. Poisoned sessions stay poisoned.
+func (f *FaultInjector) SetConfig(config FaultConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+}
+
+// Poison makes every subsequent Run on s fail until Reset is called.
+func (f *FaultInjector) Poison(s *Session) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.poisoned[s.id] = true
+}
+
+// Poisoned reports whether s is currently poisoned.
+func (f *FaultInjector) Poisoned(s *Session) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.poisoned[s.id]
+}
+
+// Reset heals all poisoned sessions and zeroes the injected fault count.
+func (f *FaultInjector) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.poisoned)
+	f.injected = 0
+}
+
+// Injected returns the number of faults injected so far, counting errors,
+// panics and runs rejected by a poisoned session. Latency is not counted.
+func (f *FaultInjector) Injected() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.injected
+}
+
+// faultDecision is the outcome of rolling the dice for a single run.
+type faultDecision struct {
+	delay    time.Duration
+	poisoned bool
+	fail     bool
+	panic    bool
+}
+
+func (f *FaultInjector) decide(s *Session) faultDecision {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var d faultDecision
+	d.delay = f.config.Latency
+	if f.config.LatencyJitter > 0 {
+		d.delay += time.Duration(f.rng.Int64N(int64(f.config.LatencyJitter)))
+	}
+
+	if !f.poisoned[s.id] && f.config.PoisonRate > 0 && f.rng.Float64() < f.config.PoisonRate {
+		f.poisoned[s.id] = true
+	}
+	switch {
+	case f.poisoned[s.id]:
+		d.poisoned = true
+	case f.config.PanicRate > 0 && f.rng.Float64() < f.config.PanicRate:
+		d.panic = true
+	case f.config.ErrorRate > 0 && f.rng.Float64() < f.config.ErrorRate:
+		d.fail = true
+	default:
+		return d
+	}
+	f.injected++
+	return d
+}
+
+// inject applies the configured faults before a run on s. It returns a non-nil
+// error if the run must not proceed.
+func (f *FaultInjector) inject(ctx context.Context, s *Session) error {
+	d := f.decide(s)
+
+	if d.delay > 0 {
+		timer := time.NewTimer(d.delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	switch {
+	case d.poisoned:
+		return fmt.Errorf("%w: session %d is poisoned", ErrInjectedFault, s.id)
+	case d.panic:
+		panic(fmt.Sprintf("onnxruntime: injected panic in session %d", s.id))
+	case d.fail:
+		return fmt.Errorf("%w: run failed", ErrInjectedFault)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package onnxruntime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFaultInjectorError(t *testing.T) {
+	f := NewFaultInjector(FaultConfig{ErrorRate: 1})
+	s := &Session{id: 1}
+
+	if err := f.inject(t.Context(), s); !errors.Is(err, ErrInjectedFault) {
+		t.Fatalf("Expected ErrInjectedFault, got %v", err)
+	}
+	if got := f.Injected(); got != 1 {
+		t.Errorf("Injected() = %d, want 1", got)
+	}
+
+	f.SetConfig(FaultConfig{})
+	if err := f.inject(t.Context(), s); err != nil {
+		t.Errorf("Expected no fault after disabling, got %v", err)
+	}
+}
+
+func TestFaultInjectorPanic(t *testing.T) {
+	f := NewFaultInjector(FaultConfig{PanicRate: 1})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected injected panic")
+		}
+	}()
+	_ = f.inject(t.Context(), &Session{id: 1})
+}
+
+func TestFaultInjectorPoison(t *testing.T) {
+	f := NewFaultInjector(FaultConfig{})
+	poisoned := &Session{id: 1}
+	healthy := &Session{id: 2}
+
+	f.Poison(poisoned)
+	if !f.Poisoned(poisoned) {
+		t.Fatal("Expected session to be poisoned")
+	}
+	for range 3 {
+		if err := f.inject(t.Context(), poisoned); !errors.Is(err, ErrInjectedFault) {
+			t.Fatalf("Expected poisoned session to fail, got %v", err)
+		}
+	}
+	if err := f.inject(t.Context(), healthy); err != nil {
+		t.Errorf("Expected healthy session to succeed, got %v", err)
+	}
+
+	f.Reset()
+	if err := f.inject(t.Context(), poisoned); err != nil {
+		t.Errorf("Expected session to be healed after Reset, got %v", err)
+	}
+	if got := f.Injected(); got != 0 {
+		t.Errorf("Injected() = %d after Reset, want 0", got)
+	}
+}
+
+func TestFaultInjectorPoisonRate(t *testing.T) {
+	f := NewFaultInjector(FaultConfig{PoisonRate: 1})
+	s := &Session{id: 1}
+
+	if err := f.inject(t.Context(), s); !errors.Is(err, ErrInjectedFault) {
+		t.Fatalf("Expected ErrInjectedFault, got %v", err)
+	}
+
+	// The session stays poisoned even after new poisoning stops.
+	f.SetConfig(FaultConfig{})
+	if err := f.inject(t.Context(), s); !errors.Is(err, ErrInjectedFault) {
+		t.Errorf("Expected session to remain poisoned, got %v", err)
+	}
+	if err := f.inject(t.Context(), &Session{id: 2}); err != nil {
+		t.Errorf("Expected other session to succeed, got %v", err)
+	}
+}
+
+func TestFaultInjectorLatency(t *testing.T) {
+	f := NewFaultInjector(FaultConfig{Latency: 20 * time.Millisecond})
+	s := &Session{id: 1}
+
+	start := time.Now()
+	if err := f.inject(t.Context(), s); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected at least 20ms latency, got %v", elapsed)
+	}
+
+	f.SetConfig(FaultConfig{Latency: time.Hour})
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if err := f.inject(ctx, s); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestFaultInjectorSeed(t *testing.T) {
+	sequence := func() []bool {
+		f := NewFaultInjector(FaultConfig{ErrorRate: 0.5, Seed: 42})
+		s := &Session{id: 1}
+		out := make([]bool, 64)
+		for i := range out {
+			out[i] = f.inject(t.Context(), s) != nil
+		}
+		return out
+	}
+
+	a, b := sequence(), sequence()
+	failures := 0
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Sequences with the same seed diverged at %d", i)
+		}
+		if a[i] {
+			failures++
+		}
+	}
+	if failures == 0 || failures == len(a) {
+		t.Errorf("Expected a mix of failures and successes at ErrorRate 0.5, got %d/%d", failures, len(a))
+	}
+}
+
+func TestSessionPoolFaultInjection(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	faults := NewFaultInjector(FaultConfig{ErrorRate: 1})
+	pool, err := NewSessionPool(runtime, env, modelData, 2, &PoolConfig{
+		SessionOptions: &SessionOptions{FaultInjector: faults},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	tensor, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	if _, err := pool.Run(t.Context(), map[string]*Value{"input": tensor}); !errors.Is(err, ErrInjectedFault) {
+		t.Fatalf("Expected ErrInjectedFault, got %v", err)
+	}
+	if stats := pool.Stats(); stats.TotalErrors != 1 {
+		t.Errorf("TotalErrors = %d, want 1", stats.TotalErrors)
+	}
+
+	faults.SetConfig(FaultConfig{})
+	outputs, err := pool.Run(t.Context(), map[string]*Value{"input": tensor})
+	if err != nil {
+		t.Fatalf("Expected run to succeed after disabling faults: %v", err)
+	}
+	closeValues(outputs)
+}
@@ -1,6 +1,11 @@
 package onnxruntime
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"sync"
+	"unsafe"
+)
 
 // Float16 represents an IEEE 754 half-precision (16-bit) floating-point number.
 type Float16 uint16
@@ -79,3 +84,139 @@ func NewBFloat16(f float32) BFloat16 {
 func (f BFloat16) Float32() float32 {
 	return math.Float32frombits(uint32(f) << 16)
 }
+
+// float16Table maps every Float16 bit pattern to its float32 value.
+// Lookup is faster than bit manipulation for bulk conversion.
+var float16Table = sync.OnceValue(func() *[1 << 16]float32 {
+	var table [1 << 16]float32
+	for i := range table {
+		table[i] = Float16(i).Float32()
+	}
+	return &table
+})
+
+// Float16ToFloat32 converts src into dst, which must be at least as long as src.
+// It returns the number of elements converted.
+func Float16ToFloat32(dst []float32, src []Float16) int {
+	if len(src) == 0 {
+		return 0
+	}
+	table := float16Table()
+	dst = dst[:len(src)]
+	for i, h := range src {
+		dst[i] = table[h]
+	}
+	return len(src)
+}
+
+// Float32ToFloat16 converts src into dst, which must be at least as long as src.
+// It returns the number of elements converted.
+func Float32ToFloat16(dst []Float16, src []float32) int {
+	dst = dst[:len(src)]
+	for i, f := range src {
+		dst[i] = NewFloat16(f)
+	}
+	return len(src)
+}
+
+// BFloat16ToFloat32 converts src into dst, which must be at least as long as src.
+// It returns the number of elements converted.
+func BFloat16ToFloat32(dst []float32, src []BFloat16) int {
+	dst = dst[:len(src)]
+	for i, b := range src {
+		dst[i] = math.Float32frombits(uint32(b) << 16)
+	}
+	return len(src)
+}
+
+// Float32ToBFloat16 converts src into dst, which must be at least as long as src.
+// Like NewBFloat16, it truncates the lower 16 bits. It returns the number of
+// elements converted.
+func Float32ToBFloat16(dst []BFloat16, src []float32) int {
+	dst = dst[:len(src)]
+	for i, f := range src {
+		dst[i] = BFloat16(math.Float32bits(f) >> 16)
+	}
+	return len(src)
+}
+
+// NewFloat16TensorFromFloat32 creates a float16 tensor from float32 data.
+// The data is converted into a buffer owned by the returned Value, so the
+// input slice may be reused immediately.
+func NewFloat16TensorFromFloat32(r *Runtime, data []float32, shape []int64) (*Value, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data cannot be empty")
+	}
+
+	buf := make([]Float16, len(data))
+	Float32ToFloat16(buf, data)
+
+	v, err := r.newTensorValue(unsafe.Pointer(&buf[0]), uintptr(len(buf))*2, shape, ONNXTensorElementDataTypeFloat16)
+	if err != nil {
+		return nil, err
+	}
+	v.goData = buf
+	return v, nil
+}
+
+// NewBFloat16TensorFromFloat32 creates a bfloat16 tensor from float32 data.
+// The data is converted into a buffer owned by the returned Value, so the
+// input slice may be reused immediately.
+func NewBFloat16TensorFromFloat32(r *Runtime, data []float32, shape []int64) (*Value, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data cannot be empty")
+	}
+
+	buf := make([]BFloat16, len(data))
+	Float32ToBFloat16(buf, data)
+
+	v, err := r.newTensorValue(unsafe.Pointer(&buf[0]), uintptr(len(buf))*2, shape, ONNXTensorElementDataTypeBFloat16)
+	if err != nil {
+		return nil, err
+	}
+	v.goData = buf
+	return v, nil
+}
+
+// GetTensorDataAsFloat32 returns a copy of a floating-point tensor's data as float32,
+// converting float16, bfloat16 and float64 elements. This lets callers serve
+// reduced-precision models without handling each element type.
+func GetTensorDataAsFloat32(v *Value) ([]float32, []int64, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get element type: %w", err)
+	}
+
+	switch elemType {
+	case ONNXTensorElementDataTypeFloat:
+		return GetTensorData[float32](v)
+	case ONNXTensorElementDataTypeFloat16:
+		src, shape, err := GetTensorDataUnsafe[Float16](v)
+		if err != nil {
+			return nil, nil, err
+		}
+		out := make([]float32, len(src))
+		Float16ToFloat32(out, src)
+		return out, shape, nil
+	case ONNXTensorElementDataTypeBFloat16:
+		src, shape, err := GetTensorDataUnsafe[BFloat16](v)
+		if err != nil {
+			return nil, nil, err
+		}
+		out := make([]float32, len(src))
+		BFloat16ToFloat32(out, src)
+		return out, shape, nil
+	case ONNXTensorElementDataTypeDouble:
+		src, shape, err := GetTensorDataUnsafe[float64](v)
+		if err != nil {
+			return nil, nil, err
+		}
+		out := make([]float32, len(src))
+		for i, f := range src {
+			out[i] = float32(f)
+		}
+		return out, shape, nil
+	default:
+		return nil, nil, fmt.Errorf("element type %d is not a floating-point type", elemType)
+	}
+}
@@ -169,3 +169,88 @@ func TestBFloat16ExponentRange(t *testing.T) {
 		t.Errorf("BFloat16 small value: expected ~%v, got %v", small, gots)
 	}
 }
+
+func TestBulkFloat16Conversion(t *testing.T) {
+	src := []float32{0, 1, -2.5, 0.333, 65504, float32(math.Inf(1))}
+
+	half := make([]Float16, len(src))
+	if n := Float32ToFloat16(half, src); n != len(src) {
+		t.Fatalf("Float32ToFloat16 converted %d elements, want %d", n, len(src))
+	}
+	for i, f := range src {
+		if half[i] != NewFloat16(f) {
+			t.Errorf("element %d: bulk %#x != scalar %#x", i, half[i], NewFloat16(f))
+		}
+	}
+
+	back := make([]float32, len(half))
+	Float16ToFloat32(back, half)
+	for i, h := range half {
+		if back[i] != h.Float32() {
+			t.Errorf("element %d: bulk %v != scalar %v", i, back[i], h.Float32())
+		}
+	}
+}
+
+func TestBulkBFloat16Conversion(t *testing.T) {
+	src := []float32{0, 1, -2.5, 3.14159, 1e30}
+
+	bf := make([]BFloat16, len(src))
+	Float32ToBFloat16(bf, src)
+	back := make([]float32, len(bf))
+	BFloat16ToFloat32(back, bf)
+
+	for i, f := range src {
+		if bf[i] != NewBFloat16(f) {
+			t.Errorf("element %d: bulk %#x != scalar %#x", i, bf[i], NewBFloat16(f))
+		}
+		if back[i] != bf[i].Float32() {
+			t.Errorf("element %d: bulk %v != scalar %v", i, back[i], bf[i].Float32())
+		}
+	}
+}
+
+func TestFloat16TensorFromFloat32(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	data := []float32{0.5, -1, 2, 1024}
+	v, err := NewFloat16TensorFromFloat32(runtime, data, []int64{2, 2})
+	if err != nil {
+		t.Fatalf("NewFloat16TensorFromFloat32 failed: %v", err)
+	}
+	defer v.Close()
+
+	elemType, err := v.GetTensorElementType()
+	if err != nil || elemType != ONNXTensorElementDataTypeFloat16 {
+		t.Fatalf("Expected float16 tensor, got %d (%v)", elemType, err)
+	}
+
+	got, shape, err := GetTensorDataAsFloat32(v)
+	if err != nil {
+		t.Fatalf("GetTensorDataAsFloat32 failed: %v", err)
+	}
+	if len(shape) != 2 || shape[0] != 2 {
+		t.Errorf("Unexpected shape %v", shape)
+	}
+	for i := range data {
+		if got[i] != data[i] {
+			t.Errorf("element %d = %v, want %v", i, got[i], data[i])
+		}
+	}
+
+	bf, err := NewBFloat16TensorFromFloat32(runtime, data, []int64{4})
+	if err != nil {
+		t.Fatalf("NewBFloat16TensorFromFloat32 failed: %v", err)
+	}
+	defer bf.Close()
+
+	got, _, err = GetTensorDataAsFloat32(bf)
+	if err != nil {
+		t.Fatalf("GetTensorDataAsFloat32 failed: %v", err)
+	}
+	for i := range data {
+		if got[i] != data[i] {
+			t.Errorf("bfloat16 element %d = %v, want %v", i, got[i], data[i])
+		}
+	}
+}
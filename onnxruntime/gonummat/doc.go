@@ -0,0 +1,7 @@
+// Package gonummat converts between tensor Values and gonum matrices, so
+// model outputs can flow into gonum-based post-processing such as PCA or a
+// linear head, and matrices computed with gonum can be fed back to a model.
+//
+// It is a separate module, so that the core onnxer module does not depend
+// on gonum.
+package gonummat
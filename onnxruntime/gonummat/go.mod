@@ -0,0 +1,16 @@
+module github.com/benedoc-inc/onnxer/onnxruntime/gonummat
+
+go 1.25.0
+
+replace github.com/benedoc-inc/onnxer => ../..
+
+require (
+	github.com/benedoc-inc/onnxer v0.0.0-00010101000000-000000000000
+	gonum.org/v1/gonum v0.17.0
+)
+
+require (
+	github.com/ebitengine/purego v0.9.0 // indirect
+	github.com/fsnotify/fsnotify v1.9.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
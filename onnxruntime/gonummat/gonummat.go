@@ -0,0 +1,93 @@
+package gonummat
+
+import (
+	"fmt"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ToMatrix copies a float32 or float64 tensor into a new matrix. The tensor
+// must be two-dimensional after dropping leading dimensions of size 1, so a
+// [1, n, d] batch of one becomes an n×d matrix; a one-dimensional tensor of
+// length d becomes a 1×d row vector.
+func ToMatrix(v *ort.Value) (*mat.Dense, error) {
+	if v == nil {
+		return nil, fmt.Errorf("value is nil")
+	}
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get element type: %w", err)
+	}
+
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeDouble:
+		data, shape, err := ort.GetTensorData[float64](v)
+		if err != nil {
+			return nil, err
+		}
+		rows, cols, err := matrixDims(shape)
+		if err != nil {
+			return nil, err
+		}
+		return mat.NewDense(rows, cols, data), nil
+	case ort.ONNXTensorElementDataTypeFloat:
+		data, shape, err := ort.GetTensorDataUnsafe[float32](v)
+		if err != nil {
+			return nil, err
+		}
+		rows, cols, err := matrixDims(shape)
+		if err != nil {
+			return nil, err
+		}
+		converted := make([]float64, len(data))
+		for i, x := range data {
+			converted[i] = float64(x)
+		}
+		return mat.NewDense(rows, cols, converted), nil
+	default:
+		return nil, fmt.Errorf("unsupported element type %d: expected float or double", elemType)
+	}
+}
+
+// matrixDims returns the matrix dimensions of a tensor shape.
+func matrixDims(shape []int64) (int, int, error) {
+	dims := shape
+	for len(dims) > 2 && dims[0] == 1 {
+		dims = dims[1:]
+	}
+	switch {
+	case len(dims) == 1 && dims[0] > 0:
+		return 1, int(dims[0]), nil
+	case len(dims) == 2 && dims[0] > 0 && dims[1] > 0:
+		return int(dims[0]), int(dims[1]), nil
+	default:
+		return 0, 0, fmt.Errorf("tensor of shape %v is not a non-empty matrix", shape)
+	}
+}
+
+// FromMatrix copies m into a new float32 tensor of shape [rows, cols], the
+// element type most models take. The caller must close the returned Value.
+func FromMatrix(r *ort.Runtime, m mat.Matrix) (*ort.Value, error) {
+	rows, cols := m.Dims()
+	data := make([]float32, rows*cols)
+	row := make([]float64, cols)
+	for i := range rows {
+		mat.Row(row, i, m)
+		for j, x := range row {
+			data[i*cols+j] = float32(x)
+		}
+	}
+	return ort.NewTensorValue(r, data, []int64{int64(rows), int64(cols)})
+}
+
+// FromMatrixFloat64 is like FromMatrix but creates a float64 tensor,
+// keeping full precision.
+func FromMatrixFloat64(r *ort.Runtime, m mat.Matrix) (*ort.Value, error) {
+	rows, cols := m.Dims()
+	data := make([]float64, rows*cols)
+	for i := range rows {
+		mat.Row(data[i*cols:(i+1)*cols], i, m)
+	}
+	return ort.NewTensorValue(r, data, []int64{int64(rows), int64(cols)})
+}
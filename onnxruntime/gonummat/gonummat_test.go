@@ -0,0 +1,84 @@
+package gonummat
+
+import (
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMatrixDims(t *testing.T) {
+	tests := []struct {
+		shape      []int64
+		rows, cols int
+		ok         bool
+	}{
+		{[]int64{2, 3}, 2, 3, true},
+		{[]int64{1, 2, 3}, 2, 3, true},
+		{[]int64{1, 1, 4}, 1, 4, true},
+		{[]int64{5}, 1, 5, true},
+		{[]int64{2, 2, 3}, 0, 0, false},
+		{[]int64{0, 3}, 0, 0, false},
+		{[]int64{}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		rows, cols, err := matrixDims(tt.shape)
+		if (err == nil) != tt.ok || rows != tt.rows || cols != tt.cols {
+			t.Errorf("matrixDims(%v) = %d, %d, %v; want %d, %d, ok=%v", tt.shape, rows, cols, err, tt.rows, tt.cols, tt.ok)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	m := mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	// A transposed view exercises matrices that are not row-major Dense.
+	for name, src := range map[string]mat.Matrix{"Dense": m, "Transpose": m.T()} {
+		t.Run(name, func(t *testing.T) {
+			for _, from := range []func(*ort.Runtime, mat.Matrix) (*ort.Value, error){FromMatrix, FromMatrixFloat64} {
+				v, err := from(runtime, src)
+				if err != nil {
+					t.Fatalf("Failed to create tensor: %v", err)
+				}
+				defer v.Close()
+
+				shape, err := v.GetTensorShape()
+				if err != nil {
+					t.Fatalf("GetTensorShape failed: %v", err)
+				}
+				rows, cols := src.Dims()
+				if !slices.Equal(shape, []int64{int64(rows), int64(cols)}) {
+					t.Errorf("Expected shape [%d %d], got %v", rows, cols, shape)
+				}
+
+				back, err := ToMatrix(v)
+				if err != nil {
+					t.Fatalf("ToMatrix failed: %v", err)
+				}
+				if !mat.Equal(back, src) {
+					t.Errorf("Round trip changed the matrix:\n%v\nwant\n%v", mat.Formatted(back), mat.Formatted(src))
+				}
+			}
+		})
+	}
+}
+
+func TestToMatrixUnsupported(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	v, err := ort.NewTensorValue(runtime, []int64{1, 2, 3, 4}, []int64{2, 2})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer v.Close()
+
+	if _, err := ToMatrix(v); err == nil {
+		t.Error("Expected an error for an int64 tensor")
+	}
+	if _, err := ToMatrix(nil); err == nil {
+		t.Error("Expected an error for a nil value")
+	}
+}
@@ -0,0 +1,20 @@
+package gonummat
+
+import (
+	"os"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func newTestRuntime(t *testing.T) *ort.Runtime {
+	t.Helper()
+
+	runtime, err := ort.NewRuntime(os.Getenv("ONNXRUNTIME_LIB_PATH"), 23)
+	if err != nil {
+		t.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	t.Cleanup(func() { runtime.Close() })
+
+	return runtime
+}
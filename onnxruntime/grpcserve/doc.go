@@ -0,0 +1,27 @@
+// Package grpcserve serves ONNX Runtime inference over gRPC.
+//
+// The Inference service, defined in inferencepb/inference.proto, has two
+// methods. Predict runs a model once with typed tensors as inputs and
+// outputs; the call's deadline and cancellation reach the run through its
+// context. Generate streams the text of a token generator, such as a genai
+// model, as each token is produced.
+//
+// A Server implements the service over named SessionPools and
+// TokenGenerators.
+//
+// Example:
+//
+//	pool, err := onnxruntime.NewSessionPool(runtime, env, modelData, 4, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	server := grpc.NewServer()
+//	inferencepb.RegisterInferenceServer(server, grpcserve.NewServer(&grpcserve.ServerOptions{
+//	    Models: map[string]grpcserve.Pool{"classifier": pool},
+//	}))
+//	lis, err := net.Listen("tcp", ":9000")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Fatal(server.Serve(lis))
+package grpcserve
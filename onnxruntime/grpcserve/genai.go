@@ -0,0 +1,73 @@
+package grpcserve
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/benedoc-inc/onnxer/genai"
+	pb "github.com/benedoc-inc/onnxer/onnxruntime/grpcserve/inferencepb"
+)
+
+// GenAIGenerator is a TokenGenerator backed by an ONNX Runtime GenAI model.
+// Each call creates its own genai.Generator, so calls run concurrently.
+type GenAIGenerator struct {
+	Model     *genai.Model
+	Tokenizer *genai.Tokenizer
+
+	// Params are the default search options. A request's options and flags
+	// override them.
+	Params genai.GeneratorParams
+}
+
+// Generate encodes the prompt and yields the text of each generated token
+// until the generator is done or ctx ends.
+func (g *GenAIGenerator) Generate(ctx context.Context, req *pb.GenerateRequest, yield func(text string, tokens []int32) error) error {
+	tokens, err := g.Tokenizer.Encode(req.GetPrompt())
+	if err != nil {
+		return err
+	}
+
+	params := maps.Clone(g.Params)
+	if params == nil {
+		params = make(genai.GeneratorParams)
+	}
+	for name, v := range req.GetOptions() {
+		params[name] = v
+	}
+	for name, v := range req.GetFlags() {
+		params[name] = v
+	}
+	generator, err := g.Model.NewGenerator(params)
+	if err != nil {
+		return err
+	}
+	defer generator.Close()
+	if err := generator.AppendTokens(tokens); err != nil {
+		return err
+	}
+
+	for !generator.IsDone() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := generator.GenerateNextToken(); err != nil {
+			return err
+		}
+		next, err := generator.GetNextTokens()
+		if err != nil {
+			return err
+		}
+		if len(next) == 0 {
+			return fmt.Errorf("generator produced no token")
+		}
+		text, err := g.Tokenizer.Decode(next[:1])
+		if err != nil {
+			return err
+		}
+		if err := yield(text, next[:1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+module github.com/benedoc-inc/onnxer/onnxruntime/grpcserve
+
+go 1.25.0
+
+replace github.com/benedoc-inc/onnxer => ../..
+
+require (
+	github.com/benedoc-inc/onnxer v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	github.com/ebitengine/purego v0.9.0 // indirect
+	github.com/fsnotify/fsnotify v1.9.0 // indirect
+	golang.org/x/net v0.58.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.41.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
@@ -0,0 +1,5 @@
+// Package inferencepb holds the protobuf messages and gRPC stubs of the
+// Inference service, generated from inference.proto.
+package inferencepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative inference.proto
@@ -0,0 +1,620 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.1
+// source: inference.proto
+
+package inferencepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DataType is a tensor element type. Values match ONNX TensorProto.DataType.
+type DataType int32
+
+const (
+	DataType_DATA_TYPE_UNSPECIFIED DataType = 0
+	DataType_DATA_TYPE_FLOAT       DataType = 1
+	DataType_DATA_TYPE_UINT8       DataType = 2
+	DataType_DATA_TYPE_INT8        DataType = 3
+	DataType_DATA_TYPE_UINT16      DataType = 4
+	DataType_DATA_TYPE_INT16       DataType = 5
+	DataType_DATA_TYPE_INT32       DataType = 6
+	DataType_DATA_TYPE_INT64       DataType = 7
+	DataType_DATA_TYPE_STRING      DataType = 8
+	DataType_DATA_TYPE_BOOL        DataType = 9
+	DataType_DATA_TYPE_FLOAT16     DataType = 10
+	DataType_DATA_TYPE_DOUBLE      DataType = 11
+	DataType_DATA_TYPE_UINT32      DataType = 12
+	DataType_DATA_TYPE_UINT64      DataType = 13
+	DataType_DATA_TYPE_BFLOAT16    DataType = 16
+)
+
+// Enum value maps for DataType.
+var (
+	DataType_name = map[int32]string{
+		0:  "DATA_TYPE_UNSPECIFIED",
+		1:  "DATA_TYPE_FLOAT",
+		2:  "DATA_TYPE_UINT8",
+		3:  "DATA_TYPE_INT8",
+		4:  "DATA_TYPE_UINT16",
+		5:  "DATA_TYPE_INT16",
+		6:  "DATA_TYPE_INT32",
+		7:  "DATA_TYPE_INT64",
+		8:  "DATA_TYPE_STRING",
+		9:  "DATA_TYPE_BOOL",
+		10: "DATA_TYPE_FLOAT16",
+		11: "DATA_TYPE_DOUBLE",
+		12: "DATA_TYPE_UINT32",
+		13: "DATA_TYPE_UINT64",
+		16: "DATA_TYPE_BFLOAT16",
+	}
+	DataType_value = map[string]int32{
+		"DATA_TYPE_UNSPECIFIED": 0,
+		"DATA_TYPE_FLOAT":       1,
+		"DATA_TYPE_UINT8":       2,
+		"DATA_TYPE_INT8":        3,
+		"DATA_TYPE_UINT16":      4,
+		"DATA_TYPE_INT16":       5,
+		"DATA_TYPE_INT32":       6,
+		"DATA_TYPE_INT64":       7,
+		"DATA_TYPE_STRING":      8,
+		"DATA_TYPE_BOOL":        9,
+		"DATA_TYPE_FLOAT16":     10,
+		"DATA_TYPE_DOUBLE":      11,
+		"DATA_TYPE_UINT32":      12,
+		"DATA_TYPE_UINT64":      13,
+		"DATA_TYPE_BFLOAT16":    16,
+	}
+)
+
+func (x DataType) Enum() *DataType {
+	p := new(DataType)
+	*p = x
+	return p
+}
+
+func (x DataType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataType) Descriptor() protoreflect.EnumDescriptor {
+	return file_inference_proto_enumTypes[0].Descriptor()
+}
+
+func (DataType) Type() protoreflect.EnumType {
+	return &file_inference_proto_enumTypes[0]
+}
+
+func (x DataType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataType.Descriptor instead.
+func (DataType) EnumDescriptor() ([]byte, []int) {
+	return file_inference_proto_rawDescGZIP(), []int{0}
+}
+
+// Tensor is a dense tensor in row-major order. Its elements are either in
+// raw_data, little-endian, or in the typed field for its data type:
+//
+//	float_data   FLOAT
+//	double_data  DOUBLE
+//	int32_data   INT8, UINT8, INT16, UINT16, INT32
+//	int64_data   INT64
+//	uint64_data  UINT32, UINT64
+//	bool_data    BOOL
+//	string_data  STRING
+//
+// FLOAT16 and BFLOAT16 tensors use raw_data.
+type Tensor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataType      DataType               `protobuf:"varint,1,opt,name=data_type,json=dataType,proto3,enum=onnxer.inference.v1.DataType" json:"data_type,omitempty"`
+	Shape         []int64                `protobuf:"varint,2,rep,packed,name=shape,proto3" json:"shape,omitempty"`
+	FloatData     []float32              `protobuf:"fixed32,3,rep,packed,name=float_data,json=floatData,proto3" json:"float_data,omitempty"`
+	DoubleData    []float64              `protobuf:"fixed64,4,rep,packed,name=double_data,json=doubleData,proto3" json:"double_data,omitempty"`
+	Int32Data     []int32                `protobuf:"varint,5,rep,packed,name=int32_data,json=int32Data,proto3" json:"int32_data,omitempty"`
+	Int64Data     []int64                `protobuf:"varint,6,rep,packed,name=int64_data,json=int64Data,proto3" json:"int64_data,omitempty"`
+	Uint64Data    []uint64               `protobuf:"varint,7,rep,packed,name=uint64_data,json=uint64Data,proto3" json:"uint64_data,omitempty"`
+	BoolData      []bool                 `protobuf:"varint,8,rep,packed,name=bool_data,json=boolData,proto3" json:"bool_data,omitempty"`
+	StringData    [][]byte               `protobuf:"bytes,9,rep,name=string_data,json=stringData,proto3" json:"string_data,omitempty"`
+	RawData       []byte                 `protobuf:"bytes,10,opt,name=raw_data,json=rawData,proto3" json:"raw_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tensor) Reset() {
+	*x = Tensor{}
+	mi := &file_inference_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tensor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tensor) ProtoMessage() {}
+
+func (x *Tensor) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tensor.ProtoReflect.Descriptor instead.
+func (*Tensor) Descriptor() ([]byte, []int) {
+	return file_inference_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tensor) GetDataType() DataType {
+	if x != nil {
+		return x.DataType
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *Tensor) GetShape() []int64 {
+	if x != nil {
+		return x.Shape
+	}
+	return nil
+}
+
+func (x *Tensor) GetFloatData() []float32 {
+	if x != nil {
+		return x.FloatData
+	}
+	return nil
+}
+
+func (x *Tensor) GetDoubleData() []float64 {
+	if x != nil {
+		return x.DoubleData
+	}
+	return nil
+}
+
+func (x *Tensor) GetInt32Data() []int32 {
+	if x != nil {
+		return x.Int32Data
+	}
+	return nil
+}
+
+func (x *Tensor) GetInt64Data() []int64 {
+	if x != nil {
+		return x.Int64Data
+	}
+	return nil
+}
+
+func (x *Tensor) GetUint64Data() []uint64 {
+	if x != nil {
+		return x.Uint64Data
+	}
+	return nil
+}
+
+func (x *Tensor) GetBoolData() []bool {
+	if x != nil {
+		return x.BoolData
+	}
+	return nil
+}
+
+func (x *Tensor) GetStringData() [][]byte {
+	if x != nil {
+		return x.StringData
+	}
+	return nil
+}
+
+func (x *Tensor) GetRawData() []byte {
+	if x != nil {
+		return x.RawData
+	}
+	return nil
+}
+
+type PredictRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// model_name selects the model. It may be empty when the server has one.
+	ModelName string             `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	Inputs    map[string]*Tensor `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// output_names limits the outputs computed. Empty means all of them.
+	OutputNames   []string `protobuf:"bytes,3,rep,name=output_names,json=outputNames,proto3" json:"output_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictRequest) Reset() {
+	*x = PredictRequest{}
+	mi := &file_inference_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictRequest) ProtoMessage() {}
+
+func (x *PredictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictRequest.ProtoReflect.Descriptor instead.
+func (*PredictRequest) Descriptor() ([]byte, []int) {
+	return file_inference_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PredictRequest) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *PredictRequest) GetInputs() map[string]*Tensor {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *PredictRequest) GetOutputNames() []string {
+	if x != nil {
+		return x.OutputNames
+	}
+	return nil
+}
+
+type PredictResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelName     string                 `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	Outputs       map[string]*Tensor     `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictResponse) Reset() {
+	*x = PredictResponse{}
+	mi := &file_inference_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictResponse) ProtoMessage() {}
+
+func (x *PredictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictResponse.ProtoReflect.Descriptor instead.
+func (*PredictResponse) Descriptor() ([]byte, []int) {
+	return file_inference_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PredictResponse) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *PredictResponse) GetOutputs() map[string]*Tensor {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type GenerateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// model_name selects the generator. It may be empty when the server has one.
+	ModelName string `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	Prompt    string `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	// options are numeric search options such as max_length or temperature.
+	Options map[string]float64 `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// flags are boolean search options such as do_sample.
+	Flags         map[string]bool `protobuf:"bytes,4,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_inference_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_inference_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GenerateRequest) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetOptions() map[string]float64 {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *GenerateRequest) GetFlags() map[string]bool {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type GenerateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// text is the text produced since the previous response.
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// token_ids are the tokens that produced text.
+	TokenIds      []int32 `protobuf:"varint,2,rep,packed,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_inference_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_inference_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GenerateResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *GenerateResponse) GetTokenIds() []int32 {
+	if x != nil {
+		return x.TokenIds
+	}
+	return nil
+}
+
+var File_inference_proto protoreflect.FileDescriptor
+
+const file_inference_proto_rawDesc = "" +
+	"\n" +
+	"\x0finference.proto\x12\x13onnxer.inference.v1\"\xd2\x02\n" +
+	"\x06Tensor\x12:\n" +
+	"\tdata_type\x18\x01 \x01(\x0e2\x1d.onnxer.inference.v1.DataTypeR\bdataType\x12\x14\n" +
+	"\x05shape\x18\x02 \x03(\x03R\x05shape\x12\x1d\n" +
+	"\n" +
+	"float_data\x18\x03 \x03(\x02R\tfloatData\x12\x1f\n" +
+	"\vdouble_data\x18\x04 \x03(\x01R\n" +
+	"doubleData\x12\x1d\n" +
+	"\n" +
+	"int32_data\x18\x05 \x03(\x05R\tint32Data\x12\x1d\n" +
+	"\n" +
+	"int64_data\x18\x06 \x03(\x03R\tint64Data\x12\x1f\n" +
+	"\vuint64_data\x18\a \x03(\x04R\n" +
+	"uint64Data\x12\x1b\n" +
+	"\tbool_data\x18\b \x03(\bR\bboolData\x12\x1f\n" +
+	"\vstring_data\x18\t \x03(\fR\n" +
+	"stringData\x12\x19\n" +
+	"\braw_data\x18\n" +
+	" \x01(\fR\arawData\"\xf3\x01\n" +
+	"\x0ePredictRequest\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12G\n" +
+	"\x06inputs\x18\x02 \x03(\v2/.onnxer.inference.v1.PredictRequest.InputsEntryR\x06inputs\x12!\n" +
+	"\foutput_names\x18\x03 \x03(\tR\voutputNames\x1aV\n" +
+	"\vInputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x121\n" +
+	"\x05value\x18\x02 \x01(\v2\x1b.onnxer.inference.v1.TensorR\x05value:\x028\x01\"\xd6\x01\n" +
+	"\x0fPredictResponse\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12K\n" +
+	"\aoutputs\x18\x02 \x03(\v21.onnxer.inference.v1.PredictResponse.OutputsEntryR\aoutputs\x1aW\n" +
+	"\fOutputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x121\n" +
+	"\x05value\x18\x02 \x01(\v2\x1b.onnxer.inference.v1.TensorR\x05value:\x028\x01\"\xd2\x02\n" +
+	"\x0fGenerateRequest\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12\x16\n" +
+	"\x06prompt\x18\x02 \x01(\tR\x06prompt\x12K\n" +
+	"\aoptions\x18\x03 \x03(\v21.onnxer.inference.v1.GenerateRequest.OptionsEntryR\aoptions\x12E\n" +
+	"\x05flags\x18\x04 \x03(\v2/.onnxer.inference.v1.GenerateRequest.FlagsEntryR\x05flags\x1a:\n" +
+	"\fOptionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"C\n" +
+	"\x10GenerateResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1b\n" +
+	"\ttoken_ids\x18\x02 \x03(\x05R\btokenIds*\xd3\x02\n" +
+	"\bDataType\x12\x19\n" +
+	"\x15DATA_TYPE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fDATA_TYPE_FLOAT\x10\x01\x12\x13\n" +
+	"\x0fDATA_TYPE_UINT8\x10\x02\x12\x12\n" +
+	"\x0eDATA_TYPE_INT8\x10\x03\x12\x14\n" +
+	"\x10DATA_TYPE_UINT16\x10\x04\x12\x13\n" +
+	"\x0fDATA_TYPE_INT16\x10\x05\x12\x13\n" +
+	"\x0fDATA_TYPE_INT32\x10\x06\x12\x13\n" +
+	"\x0fDATA_TYPE_INT64\x10\a\x12\x14\n" +
+	"\x10DATA_TYPE_STRING\x10\b\x12\x12\n" +
+	"\x0eDATA_TYPE_BOOL\x10\t\x12\x15\n" +
+	"\x11DATA_TYPE_FLOAT16\x10\n" +
+	"\x12\x14\n" +
+	"\x10DATA_TYPE_DOUBLE\x10\v\x12\x14\n" +
+	"\x10DATA_TYPE_UINT32\x10\f\x12\x14\n" +
+	"\x10DATA_TYPE_UINT64\x10\r\x12\x16\n" +
+	"\x12DATA_TYPE_BFLOAT16\x10\x102\xbc\x01\n" +
+	"\tInference\x12T\n" +
+	"\aPredict\x12#.onnxer.inference.v1.PredictRequest\x1a$.onnxer.inference.v1.PredictResponse\x12Y\n" +
+	"\bGenerate\x12$.onnxer.inference.v1.GenerateRequest\x1a%.onnxer.inference.v1.GenerateResponse0\x01BAZ?github.com/benedoc-inc/onnxer/onnxruntime/grpcserve/inferencepbb\x06proto3"
+
+var (
+	file_inference_proto_rawDescOnce sync.Once
+	file_inference_proto_rawDescData []byte
+)
+
+func file_inference_proto_rawDescGZIP() []byte {
+	file_inference_proto_rawDescOnce.Do(func() {
+		file_inference_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inference_proto_rawDesc), len(file_inference_proto_rawDesc)))
+	})
+	return file_inference_proto_rawDescData
+}
+
+var file_inference_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_inference_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_inference_proto_goTypes = []any{
+	(DataType)(0),            // 0: onnxer.inference.v1.DataType
+	(*Tensor)(nil),           // 1: onnxer.inference.v1.Tensor
+	(*PredictRequest)(nil),   // 2: onnxer.inference.v1.PredictRequest
+	(*PredictResponse)(nil),  // 3: onnxer.inference.v1.PredictResponse
+	(*GenerateRequest)(nil),  // 4: onnxer.inference.v1.GenerateRequest
+	(*GenerateResponse)(nil), // 5: onnxer.inference.v1.GenerateResponse
+	nil,                      // 6: onnxer.inference.v1.PredictRequest.InputsEntry
+	nil,                      // 7: onnxer.inference.v1.PredictResponse.OutputsEntry
+	nil,                      // 8: onnxer.inference.v1.GenerateRequest.OptionsEntry
+	nil,                      // 9: onnxer.inference.v1.GenerateRequest.FlagsEntry
+}
+var file_inference_proto_depIdxs = []int32{
+	0, // 0: onnxer.inference.v1.Tensor.data_type:type_name -> onnxer.inference.v1.DataType
+	6, // 1: onnxer.inference.v1.PredictRequest.inputs:type_name -> onnxer.inference.v1.PredictRequest.InputsEntry
+	7, // 2: onnxer.inference.v1.PredictResponse.outputs:type_name -> onnxer.inference.v1.PredictResponse.OutputsEntry
+	8, // 3: onnxer.inference.v1.GenerateRequest.options:type_name -> onnxer.inference.v1.GenerateRequest.OptionsEntry
+	9, // 4: onnxer.inference.v1.GenerateRequest.flags:type_name -> onnxer.inference.v1.GenerateRequest.FlagsEntry
+	1, // 5: onnxer.inference.v1.PredictRequest.InputsEntry.value:type_name -> onnxer.inference.v1.Tensor
+	1, // 6: onnxer.inference.v1.PredictResponse.OutputsEntry.value:type_name -> onnxer.inference.v1.Tensor
+	2, // 7: onnxer.inference.v1.Inference.Predict:input_type -> onnxer.inference.v1.PredictRequest
+	4, // 8: onnxer.inference.v1.Inference.Generate:input_type -> onnxer.inference.v1.GenerateRequest
+	3, // 9: onnxer.inference.v1.Inference.Predict:output_type -> onnxer.inference.v1.PredictResponse
+	5, // 10: onnxer.inference.v1.Inference.Generate:output_type -> onnxer.inference.v1.GenerateResponse
+	9, // [9:11] is the sub-list for method output_type
+	7, // [7:9] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_inference_proto_init() }
+func file_inference_proto_init() {
+	if File_inference_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inference_proto_rawDesc), len(file_inference_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_inference_proto_goTypes,
+		DependencyIndexes: file_inference_proto_depIdxs,
+		EnumInfos:         file_inference_proto_enumTypes,
+		MessageInfos:      file_inference_proto_msgTypes,
+	}.Build()
+	File_inference_proto = out.File
+	file_inference_proto_goTypes = nil
+	file_inference_proto_depIdxs = nil
+}
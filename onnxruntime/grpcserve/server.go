@@ -0,0 +1,167 @@
+package grpcserve
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	pb "github.com/benedoc-inc/onnxer/onnxruntime/grpcserve/inferencepb"
+)
+
+// Pool runs inference for a Server. *onnxruntime.SessionPool and
+// *onnxruntime.SwappablePool implement it.
+type Pool interface {
+	Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error)
+	Runtime() *ort.Runtime
+}
+
+// TokenGenerator produces text for Generate calls. It calls yield with each
+// piece of text as it is generated, along with the tokens that produced it,
+// and stops when yield returns an error or ctx is done.
+type TokenGenerator interface {
+	Generate(ctx context.Context, req *pb.GenerateRequest, yield func(text string, tokens []int32) error) error
+}
+
+// ServerOptions configures a Server.
+type ServerOptions struct {
+	// Models are the pools Predict runs, by model name.
+	Models map[string]Pool
+
+	// Generators are the token generators Generate streams from, by model
+	// name.
+	Generators map[string]TokenGenerator
+
+	// RunOptions are passed to every run.
+	RunOptions []ort.RunOption
+}
+
+// Server implements the Inference gRPC service. Register it with
+// inferencepb.RegisterInferenceServer.
+//
+// Each call's context, which carries the client's deadline, is passed to
+// the run or generator, so work stops when the deadline passes or the client
+// cancels. Errors are returned with a gRPC status: NotFound for unknown
+// models, InvalidArgument for undecodable tensors and invalid inputs,
+// Unavailable when a pool is closed or draining, DeadlineExceeded and
+// Canceled when the call's context ends, and Internal otherwise.
+type Server struct {
+	pb.UnimplementedInferenceServer
+
+	opts ServerOptions
+}
+
+// NewServer returns a Server for the models and generators in opts.
+func NewServer(opts *ServerOptions) *Server {
+	s := &Server{}
+	if opts != nil {
+		s.opts = *opts
+	}
+	return s
+}
+
+// Predict runs the requested model once.
+func (s *Server) Predict(ctx context.Context, req *pb.PredictRequest) (*pb.PredictResponse, error) {
+	name, pool, err := lookup(s.opts.Models, req.GetModelName())
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[string]*ort.Value, len(req.GetInputs()))
+	defer closeValues(inputs)
+	for inputName, t := range req.GetInputs() {
+		v, err := TensorValue(pool.Runtime(), t)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "input %q: %v", inputName, err)
+		}
+		inputs[inputName] = v
+	}
+
+	runOpts := s.opts.RunOptions
+	if names := req.GetOutputNames(); len(names) > 0 {
+		runOpts = append(slices.Clip(runOpts), ort.WithOutputNames(names...))
+	}
+	outputs, err := pool.Run(ctx, inputs, runOpts...)
+	if err != nil {
+		return nil, runError(ctx, err)
+	}
+	defer closeValues(outputs)
+
+	resp := &pb.PredictResponse{ModelName: name, Outputs: make(map[string]*pb.Tensor, len(outputs))}
+	for outputName, v := range outputs {
+		t, err := NewTensor(v)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "output %q: %v", outputName, err)
+		}
+		resp.Outputs[outputName] = t
+	}
+	return resp, nil
+}
+
+// Generate streams the requested generator's text as it is produced.
+func (s *Server) Generate(req *pb.GenerateRequest, stream pb.Inference_GenerateServer) error {
+	_, gen, err := lookup(s.opts.Generators, req.GetModelName())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	err = gen.Generate(ctx, req, func(text string, tokens []int32) error {
+		return stream.Send(&pb.GenerateResponse{Text: text, TokenIds: tokens})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return runError(ctx, err)
+	}
+	return nil
+}
+
+// lookup returns the named entry of models. An empty name selects the only
+// entry when there is exactly one.
+func lookup[T any](models map[string]T, name string) (string, T, error) {
+	if name == "" && len(models) == 1 {
+		for name, m := range models {
+			return name, m, nil
+		}
+	}
+	m, ok := models[name]
+	if !ok {
+		var zero T
+		if name == "" {
+			names := slices.Sorted(maps.Keys(models))
+			return "", zero, status.Errorf(codes.InvalidArgument, "model name is required, one of %q", names)
+		}
+		return "", zero, status.Errorf(codes.NotFound, "model %q not found", name)
+	}
+	return name, m, nil
+}
+
+// runError converts a run or generation error to a gRPC status error.
+func runError(ctx context.Context, err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, ort.ErrPoolClosed), errors.Is(err, ort.ErrPoolDrained):
+		code = codes.Unavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case ctx.Err() != nil:
+		code = status.FromContextError(ctx.Err()).Code()
+	case errors.Is(err, ort.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	}
+	return status.Error(code, err.Error())
+}
+
+func closeValues(values map[string]*ort.Value) {
+	for _, v := range values {
+		v.Close()
+	}
+}
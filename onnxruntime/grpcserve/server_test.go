@@ -0,0 +1,203 @@
+package grpcserve
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	pb "github.com/benedoc-inc/onnxer/onnxruntime/grpcserve/inferencepb"
+)
+
+// stubPool records the context of its last run and fails with err.
+type stubPool struct {
+	err error
+	ctx context.Context
+}
+
+func (p *stubPool) Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error) {
+	p.ctx = ctx
+	return map[string]*ort.Value{}, p.err
+}
+
+func (p *stubPool) Runtime() *ort.Runtime { return nil }
+
+// stubGenerator yields each word of its prompt.
+type stubGenerator struct {
+	words []string
+}
+
+func (g *stubGenerator) Generate(ctx context.Context, req *pb.GenerateRequest, yield func(string, []int32) error) error {
+	for i, w := range g.words {
+		if err := yield(w, []int32{int32(i)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newTestClient serves s over an in-memory connection.
+func newTestClient(t *testing.T, s *Server) pb.InferenceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterInferenceServer(server, s)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewInferenceClient(conn)
+}
+
+func TestPredictStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		model string
+		err   error
+		want  codes.Code
+	}{
+		{"ok", "a", nil, codes.OK},
+		{"not found", "missing", nil, codes.NotFound},
+		{"ambiguous", "", nil, codes.InvalidArgument},
+		{"closed", "a", ort.ErrPoolClosed, codes.Unavailable},
+		{"drained", "a", ort.ErrPoolDrained, codes.Unavailable},
+		{"invalid", "a", ort.ErrInvalidArgument, codes.InvalidArgument},
+		{"deadline", "a", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"other", "a", errors.New("boom"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, NewServer(&ServerOptions{
+				Models: map[string]Pool{"a": &stubPool{err: tt.err}, "b": &stubPool{}},
+			}))
+			_, err := client.Predict(context.Background(), &pb.PredictRequest{ModelName: tt.model})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("Expected %v, got %v (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestPredictDeadline(t *testing.T) {
+	pool := &stubPool{}
+	client := newTestClient(t, NewServer(&ServerOptions{Models: map[string]Pool{"a": pool}}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	resp, err := client.Predict(ctx, &pb.PredictRequest{})
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	if resp.GetModelName() != "a" {
+		t.Errorf("Expected the only model to be used, got %q", resp.GetModelName())
+	}
+	deadline, ok := pool.ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("Expected the call's deadline in the run context, got %v, %v", deadline, ok)
+	}
+}
+
+func TestPredictInvalidTensor(t *testing.T) {
+	client := newTestClient(t, NewServer(&ServerOptions{Models: map[string]Pool{"a": &stubPool{}}}))
+
+	_, err := client.Predict(context.Background(), &pb.PredictRequest{Inputs: map[string]*pb.Tensor{
+		"input": {DataType: pb.DataType_DATA_TYPE_FLOAT, Shape: []int64{2}, FloatData: []float32{1}},
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	client := newTestClient(t, NewServer(&ServerOptions{
+		Generators: map[string]TokenGenerator{"llm": &stubGenerator{words: []string{"Hello", ",", " world"}}},
+	}))
+
+	stream, err := client.Generate(context.Background(), &pb.GenerateRequest{Prompt: "Hi"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	var text string
+	var tokens []int32
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		text += resp.GetText()
+		tokens = append(tokens, resp.GetTokenIds()...)
+	}
+	if text != "Hello, world" || len(tokens) != 3 {
+		t.Errorf("Expected 3 tokens of %q, got %d of %q", "Hello, world", len(tokens), text)
+	}
+
+	stream, err = client.Generate(context.Background(), &pb.GenerateRequest{ModelName: "missing"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func testModelPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "internal", "tests", "testdata", "model.onnx")
+}
+
+func TestPredict(t *testing.T) {
+	rt := newTestRuntime(t)
+
+	env, err := rt.NewEnv("test", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	t.Cleanup(env.Close)
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := ort.NewSessionPool(rt, env, modelData, 1, nil)
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	client := newTestClient(t, NewServer(&ServerOptions{Models: map[string]Pool{"simple": pool}}))
+	resp, err := client.Predict(context.Background(), &pb.PredictRequest{Inputs: map[string]*pb.Tensor{
+		"input": {DataType: pb.DataType_DATA_TYPE_FLOAT, Shape: []int64{1, 10}, FloatData: make([]float32, 10)},
+	}})
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	if len(resp.GetOutputs()) == 0 {
+		t.Fatal("Expected outputs")
+	}
+	for name, out := range resp.GetOutputs() {
+		if out.GetDataType() == pb.DataType_DATA_TYPE_UNSPECIFIED || len(out.GetShape()) == 0 {
+			t.Errorf("Output %q is not a typed tensor: %v", name, out)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package grpcserve
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	pb "github.com/benedoc-inc/onnxer/onnxruntime/grpcserve/inferencepb"
+)
+
+// TensorValue creates an ONNX Runtime tensor from t. The caller must close
+// it.
+func TensorValue(r *ort.Runtime, t *pb.Tensor) (*ort.Value, error) {
+	data, err := tensorData(t)
+	if err != nil {
+		return nil, err
+	}
+	elemType := ort.ONNXTensorElementDataType(t.GetDataType())
+	switch d := data.(type) {
+	case rawData:
+		return ort.NewTensorValueFromBytes(r, d.aligned(elemType), t.GetShape(), elemType)
+	case []float32:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []float64:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []int8:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []int16:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []int32:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []int64:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []uint8:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []uint16:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []uint32:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []uint64:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []bool:
+		return ort.NewTensorValue(r, d, t.GetShape())
+	case []string:
+		return r.NewStringTensorValue(d, t.GetShape())
+	default:
+		return nil, fmt.Errorf("unsupported data type %v", t.GetDataType())
+	}
+}
+
+// tensorData returns t's elements as a slice of the Go type for its data
+// type, or as []byte when they are in raw_data, and checks the count
+// against its shape.
+func tensorData(t *pb.Tensor) (any, error) {
+	count := int64(1)
+	for _, d := range t.GetShape() {
+		if d < 0 || (d > 0 && count > math.MaxInt64/d) {
+			return nil, fmt.Errorf("invalid shape %v", t.GetShape())
+		}
+		count *= d
+	}
+
+	dataType := t.GetDataType()
+	raw := t.GetRawData()
+	if len(raw) > 0 || dataType == pb.DataType_DATA_TYPE_FLOAT16 || dataType == pb.DataType_DATA_TYPE_BFLOAT16 {
+		size := elementSize(dataType)
+		if size == 0 {
+			return nil, fmt.Errorf("data type %v cannot use raw_data", dataType)
+		}
+		if count > math.MaxInt64/size || int64(len(raw)) != count*size {
+			return nil, fmt.Errorf("raw_data has %d bytes, shape %v needs %d of %d bytes each", len(raw), t.GetShape(), count, size)
+		}
+		if hasTypedData(t) {
+			return nil, fmt.Errorf("tensor has both raw_data and typed data")
+		}
+		return rawData(raw), nil
+	}
+
+	var data any
+	var n int
+	switch dataType {
+	case pb.DataType_DATA_TYPE_FLOAT:
+		data, n = t.GetFloatData(), len(t.GetFloatData())
+	case pb.DataType_DATA_TYPE_DOUBLE:
+		data, n = t.GetDoubleData(), len(t.GetDoubleData())
+	case pb.DataType_DATA_TYPE_INT8:
+		return narrow[int8](t.GetInt32Data(), count)
+	case pb.DataType_DATA_TYPE_UINT8:
+		return narrow[uint8](t.GetInt32Data(), count)
+	case pb.DataType_DATA_TYPE_INT16:
+		return narrow[int16](t.GetInt32Data(), count)
+	case pb.DataType_DATA_TYPE_UINT16:
+		return narrow[uint16](t.GetInt32Data(), count)
+	case pb.DataType_DATA_TYPE_INT32:
+		data, n = t.GetInt32Data(), len(t.GetInt32Data())
+	case pb.DataType_DATA_TYPE_INT64:
+		data, n = t.GetInt64Data(), len(t.GetInt64Data())
+	case pb.DataType_DATA_TYPE_UINT32:
+		return narrow[uint32](t.GetUint64Data(), count)
+	case pb.DataType_DATA_TYPE_UINT64:
+		data, n = t.GetUint64Data(), len(t.GetUint64Data())
+	case pb.DataType_DATA_TYPE_BOOL:
+		data, n = t.GetBoolData(), len(t.GetBoolData())
+	case pb.DataType_DATA_TYPE_STRING:
+		strs := make([]string, len(t.GetStringData()))
+		for i, s := range t.GetStringData() {
+			strs[i] = string(s)
+		}
+		data, n = strs, len(strs)
+	default:
+		return nil, fmt.Errorf("data type %v requires raw_data", dataType)
+	}
+	if int64(n) != count {
+		return nil, fmt.Errorf("tensor has %d elements, shape %v needs %d", n, t.GetShape(), count)
+	}
+	return data, nil
+}
+
+// rawData is the little-endian raw_data of a tensor.
+type rawData []byte
+
+// aligned returns d, or a copy of it if it is not aligned for elemType.
+func (d rawData) aligned(elemType ort.ONNXTensorElementDataType) []byte {
+	size := uintptr(elementSize(pb.DataType(elemType)))
+	if len(d) == 0 || uintptr(unsafe.Pointer(unsafe.SliceData(d)))%size == 0 {
+		return d
+	}
+	words := make([]uint64, (len(d)+7)/8)
+	out := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(words))), len(d))
+	copy(out, d)
+	return out
+}
+
+// narrow converts elements packed into a wider field to T, checking that
+// each fits.
+func narrow[T int8 | uint8 | int16 | uint16 | uint32, W int32 | uint64](wide []W, count int64) ([]T, error) {
+	if int64(len(wide)) != count {
+		return nil, fmt.Errorf("tensor has %d elements, shape needs %d", len(wide), count)
+	}
+	out := make([]T, len(wide))
+	for i, w := range wide {
+		out[i] = T(w)
+		if W(out[i]) != w {
+			return nil, fmt.Errorf("element %d (%d) is out of range", i, w)
+		}
+	}
+	return out, nil
+}
+
+func hasTypedData(t *pb.Tensor) bool {
+	return len(t.GetFloatData()) > 0 || len(t.GetDoubleData()) > 0 || len(t.GetInt32Data()) > 0 ||
+		len(t.GetInt64Data()) > 0 || len(t.GetUint64Data()) > 0 || len(t.GetBoolData()) > 0 ||
+		len(t.GetStringData()) > 0
+}
+
+// elementSize returns the size in bytes of an element of a fixed-size data
+// type, or 0 for strings and unknown types.
+func elementSize(dataType pb.DataType) int64 {
+	switch dataType {
+	case pb.DataType_DATA_TYPE_UINT8, pb.DataType_DATA_TYPE_INT8, pb.DataType_DATA_TYPE_BOOL:
+		return 1
+	case pb.DataType_DATA_TYPE_UINT16, pb.DataType_DATA_TYPE_INT16, pb.DataType_DATA_TYPE_FLOAT16, pb.DataType_DATA_TYPE_BFLOAT16:
+		return 2
+	case pb.DataType_DATA_TYPE_FLOAT, pb.DataType_DATA_TYPE_INT32, pb.DataType_DATA_TYPE_UINT32:
+		return 4
+	case pb.DataType_DATA_TYPE_DOUBLE, pb.DataType_DATA_TYPE_INT64, pb.DataType_DATA_TYPE_UINT64:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// NewTensor encodes an ONNX Runtime tensor, using the typed field for its
+// element type, or raw_data for float16 and bfloat16.
+func NewTensor(v *ort.Value) (*pb.Tensor, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := pb.DataType_name[int32(elemType)]; !ok || elemType == ort.ONNXTensorElementDataTypeUndefined {
+		return nil, fmt.Errorf("unsupported tensor element type %d", elemType)
+	}
+	t := &pb.Tensor{DataType: pb.DataType(elemType)}
+
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeFloat:
+		t.FloatData, t.Shape, err = ort.GetTensorData[float32](v)
+	case ort.ONNXTensorElementDataTypeDouble:
+		t.DoubleData, t.Shape, err = ort.GetTensorData[float64](v)
+	case ort.ONNXTensorElementDataTypeInt8:
+		t.Int32Data, t.Shape, err = widen[int8, int32](v)
+	case ort.ONNXTensorElementDataTypeUint8:
+		t.Int32Data, t.Shape, err = widen[uint8, int32](v)
+	case ort.ONNXTensorElementDataTypeInt16:
+		t.Int32Data, t.Shape, err = widen[int16, int32](v)
+	case ort.ONNXTensorElementDataTypeUint16:
+		t.Int32Data, t.Shape, err = widen[uint16, int32](v)
+	case ort.ONNXTensorElementDataTypeInt32:
+		t.Int32Data, t.Shape, err = ort.GetTensorData[int32](v)
+	case ort.ONNXTensorElementDataTypeInt64:
+		t.Int64Data, t.Shape, err = ort.GetTensorData[int64](v)
+	case ort.ONNXTensorElementDataTypeUint32:
+		t.Uint64Data, t.Shape, err = widen[uint32, uint64](v)
+	case ort.ONNXTensorElementDataTypeUint64:
+		t.Uint64Data, t.Shape, err = ort.GetTensorData[uint64](v)
+	case ort.ONNXTensorElementDataTypeBool:
+		t.BoolData, t.Shape, err = ort.GetTensorData[bool](v)
+	case ort.ONNXTensorElementDataTypeString:
+		var strs []string
+		strs, t.Shape, err = ort.GetStringTensorData(v)
+		t.StringData = make([][]byte, len(strs))
+		for i, s := range strs {
+			t.StringData[i] = []byte(s)
+		}
+	default:
+		t.RawData, t.Shape, _, err = ort.GetTensorBytes(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func widen[T int8 | uint8 | int16 | uint16 | uint32, W int32 | uint64](v *ort.Value) ([]W, []int64, error) {
+	data, shape, err := ort.GetTensorData[T](v)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make([]W, len(data))
+	for i, d := range data {
+		out[i] = W(d)
+	}
+	return out, shape, nil
+}
@@ -0,0 +1,58 @@
+package grpcserve
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/benedoc-inc/onnxer/onnxruntime/grpcserve/inferencepb"
+)
+
+func TestTensorData(t *testing.T) {
+	tests := []struct {
+		name   string
+		tensor *pb.Tensor
+		want   any
+	}{
+		{"float", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_FLOAT, Shape: []int64{2}, FloatData: []float32{1, 2}}, []float32{1, 2}},
+		{"int8", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_INT8, Shape: []int64{2}, Int32Data: []int32{-128, 127}}, []int8{-128, 127}},
+		{"uint16", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_UINT16, Shape: []int64{1}, Int32Data: []int32{65535}}, []uint16{65535}},
+		{"uint32", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_UINT32, Shape: []int64{1}, Uint64Data: []uint64{1 << 31}}, []uint32{1 << 31}},
+		{"string", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_STRING, Shape: []int64{2}, StringData: [][]byte{[]byte("a"), nil}}, []string{"a", ""}},
+		{"scalar", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_INT64, Int64Data: []int64{7}}, []int64{7}},
+		{"empty", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_BOOL, Shape: []int64{0, 3}}, []bool(nil)},
+		{"raw", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_INT32, Shape: []int64{1}, RawData: []byte{1, 0, 0, 0}}, rawData{1, 0, 0, 0}},
+		{"float16", &pb.Tensor{DataType: pb.DataType_DATA_TYPE_FLOAT16, Shape: []int64{1}, RawData: []byte{0, 0x3c}}, rawData{0, 0x3c}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tensorData(tt.tensor)
+			if err != nil {
+				t.Fatalf("tensorData failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTensorDataErrors(t *testing.T) {
+	tests := map[string]*pb.Tensor{
+		"count mismatch":   {DataType: pb.DataType_DATA_TYPE_FLOAT, Shape: []int64{3}, FloatData: []float32{1, 2}},
+		"negative shape":   {DataType: pb.DataType_DATA_TYPE_FLOAT, Shape: []int64{-1}},
+		"shape overflow":   {DataType: pb.DataType_DATA_TYPE_FLOAT, Shape: []int64{1 << 62, 4}},
+		"raw overflow":     {DataType: pb.DataType_DATA_TYPE_FLOAT16, Shape: []int64{1 << 62}},
+		"out of range":     {DataType: pb.DataType_DATA_TYPE_UINT8, Shape: []int64{1}, Int32Data: []int32{256}},
+		"wrong field":      {DataType: pb.DataType_DATA_TYPE_DOUBLE, Shape: []int64{1}, FloatData: []float32{1}},
+		"raw size":         {DataType: pb.DataType_DATA_TYPE_INT64, Shape: []int64{1}, RawData: []byte{1, 2, 3, 4}},
+		"raw and typed":    {DataType: pb.DataType_DATA_TYPE_INT32, Shape: []int64{1}, RawData: []byte{1, 0, 0, 0}, Int32Data: []int32{1}},
+		"raw string":       {DataType: pb.DataType_DATA_TYPE_STRING, Shape: []int64{1}, RawData: []byte{1}},
+		"float16 typed":    {DataType: pb.DataType_DATA_TYPE_FLOAT16, Shape: []int64{1}, FloatData: []float32{1}},
+		"unspecified type": {Shape: []int64{1}, FloatData: []float32{1}},
+	}
+	for name, tensor := range tests {
+		if _, err := tensorData(tensor); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package onnxruntime
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Hook provides callbacks around inference execution for observability.
 // Implement this interface to add metrics, logging, or tracing.
@@ -28,13 +31,83 @@ type Hook interface {
 }
 
 // RunInfo contains information about an inference execution.
-// Fields are progressively populated: InputNames is set before Run,
-// Duration/Error/OutputNames are set after.
+// Fields are progressively populated: Context, ModelName, Inputs, InputNames,
+// Cost, RunTag, the input tensors and the pool borrow metrics are set before
+// Run, Duration/Error/OutputNames and the output tensors are set after.
 type RunInfo struct {
+	// Context is the context passed to Run, for hooks that propagate
+	// tracing or request-scoped values.
+	Context context.Context
+
+	// ModelName is PoolConfig.ModelName, or empty if unset.
+	ModelName string
+
+	// Inputs are the values passed to Run. They are only valid for the
+	// duration of the hook call and must not be retained or closed.
+	Inputs map[string]*Value
+
 	InputNames  []string
 	OutputNames []string
 	Duration    time.Duration
 	Error       error
+
+	// Cost is the estimated compute and memory cost of the run for the request's
+	// input shapes. It is nil unless the pool was configured with a CostModel.
+	Cost *RunCost
+
+	// BatchSize is the number of Run calls merged into this inference by the
+	// pool's micro-batcher. It is 1 for runs that were not batched.
+	BatchSize int
+
+	// QueueTime is how long the call waited to borrow a session from the pool.
+	QueueTime time.Duration
+
+	// SessionIndex identifies the pool session serving the run, matching its
+	// position in PoolStats.Sessions.
+	SessionIndex int
+
+	// ActiveBorrowers is the number of sessions borrowed from the pool,
+	// including this one, when the session was borrowed.
+	ActiveBorrowers int
+
+	// RunTag is the tag set with WithRunTag, or empty.
+	RunTag string
+
+	// InputTensors and OutputTensors describe the element type and shape of
+	// each tensor input and output, and InputBytes and OutputBytes total
+	// their data sizes, excluding string tensors. They are only set with
+	// PoolConfig.DetailedRunInfo; the outputs are set after the run.
+	InputTensors  map[string]TensorTypeInfo
+	OutputTensors map[string]TensorTypeInfo
+	InputBytes    int64
+	OutputBytes   int64
+}
+
+// describeValues returns the element type and shape of each tensor in
+// values and their total data size in bytes. Values that are not tensors
+// are left out.
+func describeValues(values map[string]*Value) (map[string]TensorTypeInfo, int64) {
+	if len(values) == 0 {
+		return nil, 0
+	}
+	infos := make(map[string]TensorTypeInfo, len(values))
+	var total int64
+	for name, value := range values {
+		if value == nil {
+			continue
+		}
+		elemType, err := value.GetTensorElementType()
+		if err != nil {
+			continue
+		}
+		shape, err := value.GetTensorShape()
+		if err != nil {
+			continue
+		}
+		infos[name] = TensorTypeInfo{ElementType: elemType, Shape: shape}
+		total += int64(shapeElementCount(shape)) * int64(tensorElementSize(elemType))
+	}
+	return infos, total
 }
 
 // HookFunc adapts a simple function into a Hook.
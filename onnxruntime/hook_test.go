@@ -0,0 +1,83 @@
+package onnxruntime
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestSessionPoolDetailedRunInfo(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	infos := make(chan *RunInfo, 1)
+	pool, err := NewSessionPool(runtime, env, modelData, 1, &PoolConfig{
+		Hooks:           []Hook{AfterRunHook(func(info *RunInfo) { infos <- info })},
+		DetailedRunInfo: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	input, err := NewTensorValue(runtime, make([]float32, 20), []int64{2, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	outputs, err := pool.Run(t.Context(), map[string]*Value{"input": input}, WithRunTag("request-1"))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	closeValues(outputs)
+
+	info := <-infos
+	if info.RunTag != "request-1" {
+		t.Errorf("RunTag = %q, want request-1", info.RunTag)
+	}
+	in := info.InputTensors["input"]
+	if in.ElementType != ONNXTensorElementDataTypeFloat || !slices.Equal(in.Shape, []int64{2, 10}) {
+		t.Errorf("InputTensors[input] = %+v, want float [2 10]", in)
+	}
+	if info.InputBytes != 80 {
+		t.Errorf("InputBytes = %d, want 80", info.InputBytes)
+	}
+	out := info.OutputTensors["logits"]
+	if !slices.Equal(out.Shape, []int64{2, 3}) {
+		t.Errorf("OutputTensors[logits] = %+v, want shape [2 3]", out)
+	}
+	if info.OutputBytes != 24 {
+		t.Errorf("OutputBytes = %d, want 24", info.OutputBytes)
+	}
+}
+
+func TestSessionPoolRunInfoWithoutDetails(t *testing.T) {
+	infos := make(chan *RunInfo, 1)
+	pool := newTestPool(t, 1, AfterRunHook(func(info *RunInfo) { infos <- info }))
+
+	closeValues(runPoolInference(t, pool))
+
+	info := <-infos
+	if info.InputTensors != nil || info.OutputTensors != nil || info.InputBytes != 0 {
+		t.Errorf("Expected no tensor details without DetailedRunInfo, got %+v", info)
+	}
+}
+
+func TestRunTag(t *testing.T) {
+	if got := runTag(nil); got != "" {
+		t.Errorf("runTag(nil) = %q, want empty", got)
+	}
+	if got := runTag([]RunOption{WithOutputNames("logits"), WithRunTag("a"), WithRunTag("b")}); got != "b" {
+		t.Errorf("runTag() = %q, want the last tag b", got)
+	}
+}
@@ -0,0 +1,81 @@
+package httpserve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Codec converts between HTTP bodies and tensors.
+type Codec interface {
+	// ContentType is the media type of encoded responses.
+	ContentType() string
+
+	// DecodeInputs reads the input tensors of a request body. If it returns
+	// an error, it must close any Values it created.
+	DecodeInputs(r *ort.Runtime, body io.Reader) (map[string]*ort.Value, error)
+
+	// EncodeOutputs writes output tensors as a response body.
+	EncodeOutputs(w io.Writer, outputs map[string]*ort.Value) error
+}
+
+// Request is the body JSONCodec decodes.
+type Request struct {
+	Inputs map[string]Tensor `json:"inputs"`
+}
+
+// Response is the body JSONCodec encodes.
+type Response struct {
+	Outputs map[string]Tensor `json:"outputs"`
+}
+
+// JSONCodec encodes tensors as JSON, using the Tensor schema.
+type JSONCodec struct{}
+
+// ContentType returns "application/json".
+func (JSONCodec) ContentType() string {
+	return "application/json"
+}
+
+// DecodeInputs decodes a Request.
+func (JSONCodec) DecodeInputs(r *ort.Runtime, body io.Reader) (map[string]*ort.Value, error) {
+	var req Request
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if len(req.Inputs) == 0 {
+		return nil, fmt.Errorf("request has no inputs")
+	}
+
+	inputs := make(map[string]*ort.Value, len(req.Inputs))
+	for name, t := range req.Inputs {
+		v, err := t.Value(r)
+		if err != nil {
+			closeValues(inputs)
+			return nil, fmt.Errorf("input %q: %w", name, err)
+		}
+		inputs[name] = v
+	}
+	return inputs, nil
+}
+
+// EncodeOutputs encodes a Response.
+func (JSONCodec) EncodeOutputs(w io.Writer, outputs map[string]*ort.Value) error {
+	resp := Response{Outputs: make(map[string]Tensor, len(outputs))}
+	for name, v := range outputs {
+		t, err := NewTensor(v)
+		if err != nil {
+			return fmt.Errorf("output %q: %w", name, err)
+		}
+		resp.Outputs[name] = t
+	}
+	return json.NewEncoder(w).Encode(resp)
+}
+
+func closeValues(values map[string]*ort.Value) {
+	for _, v := range values {
+		v.Close()
+	}
+}
@@ -0,0 +1,29 @@
+// Package httpserve serves ONNX Runtime inference over HTTP.
+//
+// NewHandler turns a SessionPool into an http.Handler that decodes input
+// tensors from the request body, runs them with the request's context, and
+// encodes the outputs in the response. The default JSONCodec uses this
+// schema for both directions:
+//
+//	{
+//	  "inputs": {
+//	    "input": {"dtype": "float32", "shape": [1, 10], "data": [0.1, 0.2, ...]}
+//	  }
+//	}
+//
+// with "outputs" in place of "inputs" in responses. Other wire formats plug
+// in through the Codec interface.
+//
+// NewKServeHandler serves the KServe Open Inference Protocol (v2), the REST
+// API also spoken by Triton, for every model in a ModelRegistry, including
+// the binary tensor data extension.
+//
+// Example:
+//
+//	pool, err := onnxruntime.NewSessionPool(runtime, env, modelData, 4, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	http.Handle("/predict", httpserve.NewHandler(pool, nil, nil))
+//	log.Fatal(http.ListenAndServe(":8080", nil))
+package httpserve
@@ -0,0 +1,125 @@
+package httpserve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Pool runs inference for a Handler. *onnxruntime.SessionPool and
+// *onnxruntime.SwappablePool implement it.
+type Pool interface {
+	Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error)
+	Runtime() *ort.Runtime
+}
+
+// HandlerOptions configures a Handler.
+type HandlerOptions struct {
+	// MaxRequestBytes limits the size of request bodies; larger requests
+	// are rejected with 413 Request Entity Too Large. Zero means 32 MiB and
+	// a negative value means no limit.
+	MaxRequestBytes int64
+
+	// RunOptions are passed to every run.
+	RunOptions []ort.RunOption
+}
+
+const defaultMaxRequestBytes = 32 << 20
+
+// Handler serves inference requests over HTTP. Each POST body is decoded
+// into input tensors, run on the pool with the request's context, so runs
+// stop when clients disconnect, and the outputs are encoded in the
+// response.
+//
+// Errors are returned as JSON objects with an "error" field and a status
+// code: 400 for undecodable requests and invalid inputs, 413 for bodies over
+// the size limit, 503 when the pool is closed or draining, 504 when the
+// request's deadline passes, and 500 otherwise.
+type Handler struct {
+	pool  Pool
+	codec Codec
+	opts  HandlerOptions
+}
+
+// NewHandler returns a Handler running requests on pool. A nil codec means
+// JSONCodec, and opts may be nil.
+func NewHandler(pool Pool, codec Codec, opts *HandlerOptions) *Handler {
+	h := &Handler{pool: pool, codec: codec}
+	if h.codec == nil {
+		h.codec = JSONCodec{}
+	}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.MaxRequestBytes == 0 {
+		h.opts.MaxRequestBytes = defaultMaxRequestBytes
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	body := r.Body
+	if h.opts.MaxRequestBytes > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.opts.MaxRequestBytes)
+	}
+	inputs, err := h.codec.DecodeInputs(h.pool.Runtime(), body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+		} else {
+			writeError(w, http.StatusBadRequest, err)
+		}
+		return
+	}
+	defer closeValues(inputs)
+
+	outputs, err := h.pool.Run(r.Context(), inputs, h.opts.RunOptions...)
+	if err != nil {
+		writeError(w, runErrorStatus(err), err)
+		return
+	}
+	defer closeValues(outputs)
+
+	// Encode fully before writing so encoding errors can still set the status.
+	var buf bytes.Buffer
+	if err := h.codec.EncodeOutputs(&buf, outputs); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", h.codec.ContentType())
+	w.Write(buf.Bytes())
+}
+
+// runErrorStatus returns the HTTP status for a failed run.
+func runErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ort.ErrPoolClosed), errors.Is(err, ort.ErrPoolDrained):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, ort.ErrInvalidArgument):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
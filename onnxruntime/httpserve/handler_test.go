@@ -0,0 +1,148 @@
+package httpserve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// stubPool returns err from every run.
+type stubPool struct {
+	err error
+	ctx context.Context
+}
+
+func (p *stubPool) Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error) {
+	p.ctx = ctx
+	return map[string]*ort.Value{}, p.err
+}
+
+func (p *stubPool) Runtime() *ort.Runtime { return nil }
+
+// stubCodec reads the whole body and fails on "bad".
+type stubCodec struct{}
+
+func (stubCodec) ContentType() string { return "text/plain" }
+
+func (stubCodec) DecodeInputs(r *ort.Runtime, body io.Reader) (map[string]*ort.Value, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "bad" {
+		return nil, errors.New("bad request")
+	}
+	return map[string]*ort.Value{}, nil
+}
+
+func (stubCodec) EncodeOutputs(w io.Writer, outputs map[string]*ort.Value) error {
+	_, err := io.WriteString(w, "ok")
+	return err
+}
+
+func TestHandlerStatus(t *testing.T) {
+	tests := []struct {
+		method, body string
+		runErr       error
+		want         int
+	}{
+		{http.MethodPost, "good", nil, http.StatusOK},
+		{http.MethodGet, "", nil, http.StatusMethodNotAllowed},
+		{http.MethodPost, "bad", nil, http.StatusBadRequest},
+		{http.MethodPost, strings.Repeat("x", 100), nil, http.StatusRequestEntityTooLarge},
+		{http.MethodPost, "good", ort.ErrPoolClosed, http.StatusServiceUnavailable},
+		{http.MethodPost, "good", fmt.Errorf("run: %w", ort.ErrPoolDrained), http.StatusServiceUnavailable},
+		{http.MethodPost, "good", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{http.MethodPost, "good", ort.ErrInvalidArgument, http.StatusBadRequest},
+		{http.MethodPost, "good", errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		pool := &stubPool{err: tt.runErr}
+		h := NewHandler(pool, stubCodec{}, &HandlerOptions{MaxRequestBytes: 64})
+
+		type ctxKey struct{}
+		req := httptest.NewRequest(tt.method, "/predict", strings.NewReader(tt.body))
+		req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, "request"))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("%s %q with run error %v: status %d, want %d", tt.method, tt.body, tt.runErr, rec.Code, tt.want)
+		}
+		if tt.want == http.StatusOK {
+			if rec.Body.String() != "ok" || rec.Header().Get("Content-Type") != "text/plain" {
+				t.Errorf("Unexpected response %q with type %q", rec.Body, rec.Header().Get("Content-Type"))
+			}
+			if pool.ctx.Value(ctxKey{}) != "request" {
+				t.Error("Expected the run to use the request's context")
+			}
+			continue
+		}
+		var body struct{ Error string }
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error == "" {
+			t.Errorf("Expected a JSON error body, got %q", rec.Body)
+		}
+	}
+}
+
+func testModelPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "internal", "tests", "testdata", "model.onnx")
+}
+
+func TestHandlerJSON(t *testing.T) {
+	rt := newTestRuntime(t)
+
+	env, err := rt.NewEnv("test", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := ort.NewSessionPool(rt, env, modelData, 1, nil)
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	server := httptest.NewServer(NewHandler(pool, nil, nil))
+	defer server.Close()
+
+	body := `{"inputs":{"input":{"dtype":"float32","shape":[1,10],"data":[1,2,3,4,5,6,7,8,9,10]}}}`
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Expected status 200, got %d: %s", resp.StatusCode, data)
+	}
+
+	var out Response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(out.Outputs) == 0 {
+		t.Fatal("Expected outputs")
+	}
+	for name, tensor := range out.Outputs {
+		if _, err := tensor.decode(); err != nil {
+			t.Errorf("Output %q does not decode: %v", name, err)
+		}
+	}
+}
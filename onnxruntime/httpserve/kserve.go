@@ -0,0 +1,575 @@
+package httpserve
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"unsafe"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// headerLengthHeader carries the size of the JSON header of a request or
+// response using the binary tensor data extension.
+const headerLengthHeader = "Inference-Header-Content-Length"
+
+// ModelPool is a Pool that describes its model's inputs and outputs.
+// *onnxruntime.SessionPool and *onnxruntime.SwappablePool implement it.
+type ModelPool interface {
+	Pool
+	InputInfo() []ort.InputInfo
+	OutputInfo() []ort.OutputInfo
+}
+
+// ModelRegistry finds the models a KServeHandler serves.
+type ModelRegistry interface {
+	// Model returns the pool serving the given version of the named model,
+	// or its current version if version is empty, along with the version it
+	// serves. It returns an error wrapping onnxruntime.ErrModelNotFound if
+	// there is none.
+	Model(name, version string) (ModelPool, string, error)
+}
+
+// StaticModels is a ModelRegistry of unversioned models by name.
+type StaticModels map[string]ModelPool
+
+// Model returns the named pool. Requests for a specific version fail.
+func (m StaticModels) Model(name, version string) (ModelPool, string, error) {
+	pool, ok := m[name]
+	if !ok || version != "" {
+		return nil, "", fmt.Errorf("%w: %q version %q", ort.ErrModelNotFound, name, version)
+	}
+	return pool, "", nil
+}
+
+// ManagedModels returns a ModelRegistry serving the models of m.
+func ManagedModels(m *ort.ModelManager) ModelRegistry {
+	return managedModels{m}
+}
+
+type managedModels struct {
+	manager *ort.ModelManager
+}
+
+func (m managedModels) Model(name, version string) (ModelPool, string, error) {
+	pool, err := m.manager.Get(name, version)
+	if err != nil {
+		return nil, "", err
+	}
+	return pool, pool.Version(), nil
+}
+
+// KServeOptions configures a KServeHandler.
+type KServeOptions struct {
+	HandlerOptions
+
+	// ServerName and ServerVersion are reported by the server metadata
+	// endpoint. They default to "onnxer" and the onnxer module version.
+	ServerName, ServerVersion string
+}
+
+// KServeHandler serves the KServe Open Inference Protocol (v2) REST API,
+// also spoken by Triton Inference Server:
+//
+//	GET  /v2                                           server metadata
+//	GET  /v2/health/live, /v2/health/ready             server health
+//	GET  /v2/models/{name}[/versions/{version}]        model metadata
+//	GET  /v2/models/{name}[/versions/{version}]/ready  model readiness
+//	POST /v2/models/{name}[/versions/{version}]/infer  inference
+//
+// Inference supports the binary tensor data extension: inputs may carry a
+// binary_data_size parameter and be appended as raw little-endian bytes
+// after a JSON header whose length is given by the
+// Inference-Header-Content-Length header, and outputs are returned the same
+// way when requested with the binary_data parameter. BYTES elements are
+// encoded as a 4-byte little-endian length followed by the bytes.
+//
+// Example:
+//
+//	handler := httpserve.NewKServeHandler(httpserve.StaticModels{"resnet": pool}, nil)
+//	log.Fatal(http.ListenAndServe(":8000", handler))
+type KServeHandler struct {
+	models ModelRegistry
+	opts   KServeOptions
+	mux    *http.ServeMux
+}
+
+// NewKServeHandler returns a KServeHandler serving the models of registry.
+// opts may be nil.
+func NewKServeHandler(registry ModelRegistry, opts *KServeOptions) *KServeHandler {
+	h := &KServeHandler{models: registry, mux: http.NewServeMux()}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.MaxRequestBytes == 0 {
+		h.opts.MaxRequestBytes = defaultMaxRequestBytes
+	}
+	if h.opts.ServerName == "" {
+		h.opts.ServerName = "onnxer"
+	}
+	if h.opts.ServerVersion == "" {
+		h.opts.ServerVersion = moduleVersion()
+	}
+
+	h.mux.HandleFunc("GET /v2", h.serverMetadata)
+	h.mux.HandleFunc("GET /v2/health/live", func(w http.ResponseWriter, r *http.Request) {})
+	h.mux.HandleFunc("GET /v2/health/ready", func(w http.ResponseWriter, r *http.Request) {})
+	for _, prefix := range []string{"/v2/models/{name}", "/v2/models/{name}/versions/{version}"} {
+		h.mux.HandleFunc("GET "+prefix, h.modelMetadata)
+		h.mux.HandleFunc("GET "+prefix+"/ready", h.modelReady)
+		h.mux.HandleFunc("POST "+prefix+"/infer", h.infer)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *KServeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// moduleVersion returns the version of the onnxer module in the running
+// binary, or "devel".
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	for _, dep := range append([]*debug.Module{&info.Main}, info.Deps...) {
+		if dep.Path == "github.com/benedoc-inc/onnxer" && dep.Version != "" && dep.Version != "(devel)" {
+			return dep.Version
+		}
+	}
+	return "devel"
+}
+
+func (h *KServeHandler) serverMetadata(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]any{
+		"name":       h.opts.ServerName,
+		"version":    h.opts.ServerVersion,
+		"extensions": []string{"binary_tensor_data"},
+	})
+}
+
+// model looks up the model addressed by r's path, writing a 404 response if
+// there is none.
+func (h *KServeHandler) model(w http.ResponseWriter, r *http.Request) (ModelPool, string, bool) {
+	pool, version, err := h.models.Model(r.PathValue("name"), r.PathValue("version"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return nil, "", false
+	}
+	return pool, version, true
+}
+
+// kserveTensorMetadata describes a model input or output.
+type kserveTensorMetadata struct {
+	Name     string  `json:"name"`
+	Datatype string  `json:"datatype"`
+	Shape    []int64 `json:"shape"`
+}
+
+func tensorMetadata(name string, info *ort.TensorTypeInfo) (kserveTensorMetadata, bool) {
+	if info == nil {
+		return kserveTensorMetadata{}, false
+	}
+	datatype, ok := kserveDatatypeName(info.ElementType)
+	return kserveTensorMetadata{Name: name, Datatype: datatype, Shape: info.Shape}, ok
+}
+
+func (h *KServeHandler) modelMetadata(w http.ResponseWriter, r *http.Request) {
+	pool, version, ok := h.model(w, r)
+	if !ok {
+		return
+	}
+	inputs := []kserveTensorMetadata{}
+	for _, in := range pool.InputInfo() {
+		if m, ok := tensorMetadata(in.Name, in.TensorInfo); ok {
+			inputs = append(inputs, m)
+		}
+	}
+	outputs := []kserveTensorMetadata{}
+	for _, out := range pool.OutputInfo() {
+		if m, ok := tensorMetadata(out.Name, out.TensorInfo); ok {
+			outputs = append(outputs, m)
+		}
+	}
+	versions := []string{}
+	if version != "" {
+		versions = append(versions, version)
+	}
+	writeJSON(w, map[string]any{
+		"name":     r.PathValue("name"),
+		"versions": versions,
+		"platform": "onnxruntime_onnx",
+		"inputs":   inputs,
+		"outputs":  outputs,
+	})
+}
+
+func (h *KServeHandler) modelReady(w http.ResponseWriter, r *http.Request) {
+	pool, _, ok := h.model(w, r)
+	if !ok {
+		return
+	}
+	if p, ok := pool.(interface{ State() ort.PoolState }); ok && p.State() != ort.PoolActive {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("model is %v", p.State()))
+	}
+}
+
+// kserveParameters are the request and tensor parameters the handler uses;
+// others are ignored.
+type kserveParameters struct {
+	BinaryDataSize   *int64 `json:"binary_data_size,omitempty"`
+	BinaryData       bool   `json:"binary_data,omitempty"`
+	BinaryDataOutput bool   `json:"binary_data_output,omitempty"`
+}
+
+type kserveTensor struct {
+	Name       string            `json:"name"`
+	Shape      []int64           `json:"shape"`
+	Datatype   string            `json:"datatype"`
+	Parameters *kserveParameters `json:"parameters,omitempty"`
+	Data       json.RawMessage   `json:"data,omitempty"`
+}
+
+type kserveRequest struct {
+	ID         string           `json:"id,omitempty"`
+	Parameters kserveParameters `json:"parameters"`
+	Inputs     []kserveTensor   `json:"inputs"`
+	Outputs    []struct {
+		Name       string           `json:"name"`
+		Parameters kserveParameters `json:"parameters"`
+	} `json:"outputs"`
+}
+
+type kserveResponse struct {
+	ModelName    string         `json:"model_name"`
+	ModelVersion string         `json:"model_version,omitempty"`
+	ID           string         `json:"id,omitempty"`
+	Outputs      []kserveTensor `json:"outputs"`
+}
+
+func (h *KServeHandler) infer(w http.ResponseWriter, r *http.Request) {
+	pool, version, ok := h.model(w, r)
+	if !ok {
+		return
+	}
+
+	body := r.Body
+	if h.opts.MaxRequestBytes > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.opts.MaxRequestBytes)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+		} else {
+			writeError(w, http.StatusBadRequest, err)
+		}
+		return
+	}
+	header, binaryData, err := splitBinaryBody(data, r.Header.Get(headerLengthHeader))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req kserveRequest
+	if err := json.Unmarshal(header, &req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	inputs, err := decodeKServeInputs(pool.Runtime(), req.Inputs, binaryData)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	defer closeValues(inputs)
+
+	// Outputs are returned in the requested order, or the model's.
+	var names []string
+	binaryOutputs := make(map[string]bool)
+	for _, out := range req.Outputs {
+		names = append(names, out.Name)
+		binaryOutputs[out.Name] = out.Parameters.BinaryData || req.Parameters.BinaryDataOutput
+	}
+	runOpts := h.opts.RunOptions
+	if names != nil {
+		runOpts = append(slices.Clip(runOpts), ort.WithOutputNames(names...))
+	} else {
+		for _, out := range pool.OutputInfo() {
+			names = append(names, out.Name)
+			binaryOutputs[out.Name] = req.Parameters.BinaryDataOutput
+		}
+	}
+
+	outputs, err := pool.Run(r.Context(), inputs, runOpts...)
+	if err != nil {
+		writeError(w, runErrorStatus(err), err)
+		return
+	}
+	defer closeValues(outputs)
+
+	resp := kserveResponse{ModelName: r.PathValue("name"), ModelVersion: version, ID: req.ID}
+	var binaryOut []byte
+	anyBinary := false
+	for _, name := range names {
+		v, ok := outputs[name]
+		if !ok {
+			continue
+		}
+		t, raw, err := encodeKServeOutput(name, v, binaryOutputs[name])
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		resp.Outputs = append(resp.Outputs, t)
+		binaryOut = append(binaryOut, raw...)
+		anyBinary = anyBinary || binaryOutputs[name]
+	}
+
+	headerJSON, err := json.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if !anyBinary {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(headerJSON)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set(headerLengthHeader, strconv.Itoa(len(headerJSON)))
+	w.Write(headerJSON)
+	w.Write(binaryOut)
+}
+
+// splitBinaryBody splits a request body into its JSON header and binary
+// tensor data, given the Inference-Header-Content-Length header value.
+func splitBinaryBody(body []byte, headerLength string) ([]byte, []byte, error) {
+	if headerLength == "" {
+		return body, nil, nil
+	}
+	n, err := strconv.Atoi(headerLength)
+	if err != nil || n < 0 || n > len(body) {
+		return nil, nil, fmt.Errorf("invalid %s %q for a %d-byte body", headerLengthHeader, headerLength, len(body))
+	}
+	return body[:n], body[n:], nil
+}
+
+// decodeKServeInputs creates the input tensors of a request, taking binary
+// inputs from binaryData in order.
+func decodeKServeInputs(r *ort.Runtime, tensors []kserveTensor, binaryData []byte) (map[string]*ort.Value, error) {
+	if len(tensors) == 0 {
+		return nil, fmt.Errorf("request has no inputs")
+	}
+	seen := make(map[string]bool, len(tensors))
+	for _, t := range tensors {
+		if seen[t.Name] {
+			return nil, fmt.Errorf("input %q appears more than once", t.Name)
+		}
+		seen[t.Name] = true
+	}
+
+	inputs := make(map[string]*ort.Value, len(tensors))
+	for _, t := range tensors {
+		var v *ort.Value
+		var err error
+		if t.Parameters != nil && t.Parameters.BinaryDataSize != nil {
+			size := *t.Parameters.BinaryDataSize
+			if size < 0 || size > int64(len(binaryData)) {
+				err = fmt.Errorf("binary_data_size %d exceeds the %d bytes of binary data left", size, len(binaryData))
+			} else {
+				v, err = binaryTensorValue(r, t, binaryData[:size])
+				binaryData = binaryData[size:]
+			}
+		} else {
+			v, err = jsonTensorValue(r, t)
+		}
+		if err != nil {
+			closeValues(inputs)
+			return nil, fmt.Errorf("input %q: %w", t.Name, err)
+		}
+		inputs[t.Name] = v
+	}
+	if len(binaryData) > 0 {
+		closeValues(inputs)
+		return nil, fmt.Errorf("%d bytes of binary data were not consumed by any input", len(binaryData))
+	}
+	return inputs, nil
+}
+
+// kserveDatatypes maps KServe datatypes to Tensor.DType names.
+var kserveDatatypes = map[string]string{
+	"BOOL":   "bool",
+	"UINT8":  "uint8",
+	"UINT16": "uint16",
+	"UINT32": "uint32",
+	"UINT64": "uint64",
+	"INT8":   "int8",
+	"INT16":  "int16",
+	"INT32":  "int32",
+	"INT64":  "int64",
+	"FP16":   "float16",
+	"BF16":   "bfloat16",
+	"FP32":   "float32",
+	"FP64":   "float64",
+	"BYTES":  "string",
+}
+
+// kserveDatatypeName returns the KServe datatype of an element type.
+func kserveDatatypeName(t ort.ONNXTensorElementDataType) (string, bool) {
+	dtype, ok := dtypeName(t)
+	if !ok {
+		return "", false
+	}
+	for name, d := range kserveDatatypes {
+		if d == dtype {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func jsonTensorValue(r *ort.Runtime, t kserveTensor) (*ort.Value, error) {
+	dtype, ok := kserveDatatypes[t.Datatype]
+	if !ok {
+		return nil, fmt.Errorf("unsupported datatype %q", t.Datatype)
+	}
+	data, err := flattenJSON(t.Data)
+	if err != nil {
+		return nil, err
+	}
+	tensor := Tensor{DType: dtype, Shape: t.Shape, Data: data}
+	return tensor.Value(r)
+}
+
+// flattenJSON flattens nested JSON arrays of scalars into one array, as the
+// protocol allows tensor data in either form.
+func flattenJSON(raw json.RawMessage) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	out := []byte{'['}
+	first := true
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid data: %w", err)
+		}
+		if d, ok := tok.(json.Delim); ok {
+			if d == '{' || d == '}' {
+				return nil, fmt.Errorf("invalid data: objects are not tensor elements")
+			}
+			continue
+		}
+		elem, err := json.Marshal(tok)
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			out = append(out, ',')
+		}
+		out = append(out, elem...)
+		first = false
+	}
+	return append(out, ']'), nil
+}
+
+func binaryTensorValue(r *ort.Runtime, t kserveTensor, raw []byte) (*ort.Value, error) {
+	dtype, ok := kserveDatatypes[t.Datatype]
+	if !ok {
+		return nil, fmt.Errorf("unsupported datatype %q", t.Datatype)
+	}
+	if dtype == "string" {
+		strs, err := decodeBinaryStrings(raw)
+		if err != nil {
+			return nil, err
+		}
+		return r.NewStringTensorValue(strs, t.Shape)
+	}
+	// Binary data need not be aligned within the body.
+	return ort.NewTensorValueFromBytes(r, alignedCopy(raw), t.Shape, dtypes[dtype])
+}
+
+// alignedCopy copies b into memory aligned for any element type.
+func alignedCopy(b []byte) []byte {
+	words := make([]uint64, (len(b)+7)/8)
+	out := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(words))), len(b))
+	copy(out, b)
+	return out
+}
+
+// decodeBinaryStrings parses BYTES elements, each a 4-byte little-endian
+// length followed by that many bytes.
+func decodeBinaryStrings(raw []byte) ([]string, error) {
+	var strs []string
+	for len(raw) > 0 {
+		if len(raw) < 4 {
+			return nil, fmt.Errorf("truncated BYTES element length")
+		}
+		n := binary.LittleEndian.Uint32(raw)
+		raw = raw[4:]
+		if uint64(n) > uint64(len(raw)) {
+			return nil, fmt.Errorf("BYTES element of %d bytes exceeds the data", n)
+		}
+		strs = append(strs, string(raw[:n]))
+		raw = raw[n:]
+	}
+	return strs, nil
+}
+
+// encodeKServeOutput encodes an output tensor, returning its binary data
+// separately when binaryData is set.
+func encodeKServeOutput(name string, v *ort.Value, binaryData bool) (kserveTensor, []byte, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return kserveTensor{}, nil, fmt.Errorf("output %q: %w", name, err)
+	}
+	datatype, ok := kserveDatatypeName(elemType)
+	if !ok {
+		return kserveTensor{}, nil, fmt.Errorf("output %q has unsupported element type %d", name, elemType)
+	}
+
+	if !binaryData {
+		t, err := NewTensor(v)
+		if err != nil {
+			return kserveTensor{}, nil, fmt.Errorf("output %q: %w", name, err)
+		}
+		return kserveTensor{Name: name, Shape: t.Shape, Datatype: datatype, Data: t.Data}, nil, nil
+	}
+
+	var raw []byte
+	var shape []int64
+	if elemType == ort.ONNXTensorElementDataTypeString {
+		var strs []string
+		strs, shape, err = ort.GetStringTensorData(v)
+		for _, s := range strs {
+			raw = binary.LittleEndian.AppendUint32(raw, uint32(len(s)))
+			raw = append(raw, s...)
+		}
+	} else {
+		raw, shape, _, err = ort.GetTensorBytes(v)
+	}
+	if err != nil {
+		return kserveTensor{}, nil, fmt.Errorf("output %q: %w", name, err)
+	}
+	size := int64(len(raw))
+	t := kserveTensor{Name: name, Shape: shape, Datatype: datatype, Parameters: &kserveParameters{BinaryDataSize: &size}}
+	return t, raw, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,260 @@
+package httpserve
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// stubModel describes a model with one float32 input and output.
+type stubModel struct {
+	stubPool
+}
+
+func (m *stubModel) InputInfo() []ort.InputInfo {
+	return []ort.InputInfo{{Name: "input", Type: ort.ONNXTypeTensor, TensorInfo: &ort.TensorTypeInfo{
+		ElementType: ort.ONNXTensorElementDataTypeFloat, Shape: []int64{-1, 10},
+	}}}
+}
+
+func (m *stubModel) OutputInfo() []ort.OutputInfo {
+	return []ort.OutputInfo{{Name: "output", Type: ort.ONNXTypeTensor, TensorInfo: &ort.TensorTypeInfo{
+		ElementType: ort.ONNXTensorElementDataTypeInt64, Shape: []int64{-1},
+	}}}
+}
+
+func serveKServe(t *testing.T, h http.Handler, method, path string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, body))
+	return rec
+}
+
+func TestKServeMetadata(t *testing.T) {
+	h := NewKServeHandler(StaticModels{"simple": &stubModel{}}, &KServeOptions{ServerVersion: "1.2.3"})
+
+	rec := serveKServe(t, h, http.MethodGet, "/v2", nil)
+	var server struct {
+		Name, Version string
+		Extensions    []string
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &server); err != nil || server.Name != "onnxer" || server.Version != "1.2.3" {
+		t.Errorf("Unexpected server metadata %s", rec.Body)
+	}
+
+	for _, path := range []string{"/v2/health/live", "/v2/health/ready", "/v2/models/simple/ready"} {
+		if rec := serveKServe(t, h, http.MethodGet, path, nil); rec.Code != http.StatusOK {
+			t.Errorf("GET %s: status %d, want 200", path, rec.Code)
+		}
+	}
+
+	rec = serveKServe(t, h, http.MethodGet, "/v2/models/simple", nil)
+	var model struct {
+		Name     string
+		Platform string
+		Inputs   []kserveTensorMetadata
+		Outputs  []kserveTensorMetadata
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &model); err != nil {
+		t.Fatalf("Invalid model metadata %s: %v", rec.Body, err)
+	}
+	wantIn := kserveTensorMetadata{Name: "input", Datatype: "FP32", Shape: []int64{-1, 10}}
+	if model.Name != "simple" || len(model.Inputs) != 1 || model.Inputs[0].Name != wantIn.Name ||
+		model.Inputs[0].Datatype != wantIn.Datatype || !slices.Equal(model.Inputs[0].Shape, wantIn.Shape) {
+		t.Errorf("Unexpected model metadata %s", rec.Body)
+	}
+	if len(model.Outputs) != 1 || model.Outputs[0].Datatype != "INT64" {
+		t.Errorf("Unexpected outputs %+v", model.Outputs)
+	}
+
+	for _, path := range []string{"/v2/models/missing", "/v2/models/simple/versions/3", "/v2/models/missing/ready"} {
+		if rec := serveKServe(t, h, http.MethodGet, path, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s: status %d, want 404", path, rec.Code)
+		}
+	}
+	rec = serveKServe(t, h, http.MethodPost, "/v2/models/missing/infer", strings.NewReader("{}"))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Infer on a missing model: status %d, want 404", rec.Code)
+	}
+}
+
+func TestFlattenJSON(t *testing.T) {
+	tests := map[string]string{
+		`[[1,2],[3,4]]`:     `[1,2,3,4]`,
+		`[1.5e3, -2]`:       `[1.5e3,-2]`,
+		`[["a"],["b","c"]]`: `["a","b","c"]`,
+		`[[true],[false]]`:  `[true,false]`,
+		`[]`:                `[]`,
+	}
+	for in, want := range tests {
+		got, err := flattenJSON(json.RawMessage(in))
+		if err != nil || string(got) != want {
+			t.Errorf("flattenJSON(%s) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+	if _, err := flattenJSON(json.RawMessage(`[{"a":1}]`)); err == nil {
+		t.Error("Expected an error for objects")
+	}
+}
+
+func TestSplitBinaryBody(t *testing.T) {
+	body := []byte(`{"a":1}` + "\x01\x02")
+	header, data, err := splitBinaryBody(body, "7")
+	if err != nil || string(header) != `{"a":1}` || !bytes.Equal(data, []byte{1, 2}) {
+		t.Errorf("splitBinaryBody = %q, %v, %v", header, data, err)
+	}
+	if header, data, _ := splitBinaryBody(body, ""); !bytes.Equal(header, body) || data != nil {
+		t.Error("Expected the whole body as the header without a length")
+	}
+	for _, bad := range []string{"10", "-1", "x"} {
+		if _, _, err := splitBinaryBody(body, bad); err == nil {
+			t.Errorf("Expected an error for header length %q", bad)
+		}
+	}
+}
+
+func TestDecodeKServeInputsDuplicate(t *testing.T) {
+	// The duplicate is rejected before any tensor is created, so no runtime
+	// is needed.
+	tensors := []kserveTensor{
+		{Name: "x", Shape: []int64{1}, Datatype: "FP32", Data: json.RawMessage("[1]")},
+		{Name: "x", Shape: []int64{1}, Datatype: "FP32", Data: json.RawMessage("[2]")},
+	}
+	if _, err := decodeKServeInputs(nil, tensors, nil); err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("Expected a duplicate input error, got %v", err)
+	}
+}
+
+func TestDecodeBinaryStrings(t *testing.T) {
+	var raw []byte
+	for _, s := range []string{"hello", "", "wörld"} {
+		raw = binary.LittleEndian.AppendUint32(raw, uint32(len(s)))
+		raw = append(raw, s...)
+	}
+	got, err := decodeBinaryStrings(raw)
+	if err != nil || !slices.Equal(got, []string{"hello", "", "wörld"}) {
+		t.Errorf("decodeBinaryStrings = %q, %v", got, err)
+	}
+	for _, bad := range [][]byte{{1, 0}, {5, 0, 0, 0, 'a'}} {
+		if _, err := decodeBinaryStrings(bad); err == nil {
+			t.Errorf("Expected an error for %v", bad)
+		}
+	}
+}
+
+func TestAlignedCopy(t *testing.T) {
+	src := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	got := alignedCopy(src[1:])
+	if !bytes.Equal(got, src[1:]) {
+		t.Errorf("Expected %v, got %v", src[1:], got)
+	}
+	if uintptr(unsafe.Pointer(unsafe.SliceData(got)))%8 != 0 {
+		t.Error("Expected 8-byte alignment")
+	}
+}
+
+func newTestKServeHandler(t *testing.T) *KServeHandler {
+	t.Helper()
+	rt := newTestRuntime(t)
+
+	env, err := rt.NewEnv("test", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	t.Cleanup(env.Close)
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := ort.NewSessionPool(rt, env, modelData, 1, nil)
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	return NewKServeHandler(StaticModels{"simple": pool}, nil)
+}
+
+func TestKServeInferJSON(t *testing.T) {
+	h := newTestKServeHandler(t)
+
+	body := `{"id":"42","inputs":[{"name":"input","shape":[1,10],"datatype":"FP32","data":[[1,2,3,4,5,6,7,8,9,10]]}]}`
+	rec := serveKServe(t, h, http.MethodPost, "/v2/models/simple/infer", strings.NewReader(body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var resp kserveResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if resp.ModelName != "simple" || resp.ID != "42" || len(resp.Outputs) == 0 {
+		t.Errorf("Unexpected response %s", rec.Body)
+	}
+}
+
+func TestKServeInferBinary(t *testing.T) {
+	h := newTestKServeHandler(t)
+
+	input := make([]byte, 0, 40)
+	for i := range 10 {
+		input = binary.LittleEndian.AppendUint32(input, math.Float32bits(float32(i)))
+	}
+	header := `{"inputs":[{"name":"input","shape":[1,10],"datatype":"FP32","parameters":{"binary_data_size":40}}],` +
+		`"parameters":{"binary_data_output":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/v2/models/simple/infer", strings.NewReader(header+string(input)))
+	req.Header.Set(headerLengthHeader, strconv.Itoa(len(header)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body)
+	}
+
+	n, err := strconv.Atoi(rec.Header().Get(headerLengthHeader))
+	if err != nil {
+		t.Fatalf("Missing %s header", headerLengthHeader)
+	}
+	var resp kserveResponse
+	if err := json.Unmarshal(rec.Body.Bytes()[:n], &resp); err != nil {
+		t.Fatalf("Invalid response header: %v", err)
+	}
+	var total int64
+	for _, out := range resp.Outputs {
+		if out.Parameters == nil || out.Parameters.BinaryDataSize == nil {
+			t.Fatalf("Expected output %q as binary data", out.Name)
+		}
+		total += *out.Parameters.BinaryDataSize
+	}
+	if int64(rec.Body.Len()-n) != total {
+		t.Errorf("Expected %d bytes of binary data, got %d", total, rec.Body.Len()-n)
+	}
+
+	// Binary data not claimed by an input is rejected.
+	req = httptest.NewRequest(http.MethodPost, "/v2/models/simple/infer", strings.NewReader(header+string(input)+"x"))
+	req.Header.Set(headerLengthHeader, strconv.Itoa(len(header)))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for extra binary data, got %d", rec.Code)
+	}
+}
+
+func TestManagedModels(t *testing.T) {
+	m := ort.NewModelManager(nil, nil, ort.ModelFetcherFunc(nil), nil)
+	defer m.Close()
+	if _, _, err := ManagedModels(m).Model("missing", ""); !errors.Is(err, ort.ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound, got %v", err)
+	}
+}
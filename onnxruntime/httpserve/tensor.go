@@ -0,0 +1,307 @@
+package httpserve
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Tensor is the JSON encoding of a tensor: its element type, shape, and
+// elements in row-major order.
+//
+// DType is one of float32, float64, float16, bfloat16, int8, int16, int32,
+// int64, uint8, uint16, uint32, uint64, bool, or string. Float16 and
+// bfloat16 elements are written as numbers. Non-finite floats are written
+// as the strings "NaN", "Infinity", and "-Infinity", as in the protobuf JSON
+// mapping, since JSON numbers cannot represent them.
+type Tensor struct {
+	DType string          `json:"dtype"`
+	Shape []int64         `json:"shape"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// dtypes maps Tensor.DType names to element types.
+var dtypes = map[string]ort.ONNXTensorElementDataType{
+	"float32":  ort.ONNXTensorElementDataTypeFloat,
+	"float64":  ort.ONNXTensorElementDataTypeDouble,
+	"float16":  ort.ONNXTensorElementDataTypeFloat16,
+	"bfloat16": ort.ONNXTensorElementDataTypeBFloat16,
+	"int8":     ort.ONNXTensorElementDataTypeInt8,
+	"int16":    ort.ONNXTensorElementDataTypeInt16,
+	"int32":    ort.ONNXTensorElementDataTypeInt32,
+	"int64":    ort.ONNXTensorElementDataTypeInt64,
+	"uint8":    ort.ONNXTensorElementDataTypeUint8,
+	"uint16":   ort.ONNXTensorElementDataTypeUint16,
+	"uint32":   ort.ONNXTensorElementDataTypeUint32,
+	"uint64":   ort.ONNXTensorElementDataTypeUint64,
+	"bool":     ort.ONNXTensorElementDataTypeBool,
+	"string":   ort.ONNXTensorElementDataTypeString,
+}
+
+// dtypeName returns the Tensor.DType name of an element type.
+func dtypeName(t ort.ONNXTensorElementDataType) (string, bool) {
+	for name, dt := range dtypes {
+		if dt == t {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Value creates an ONNX Runtime tensor from t. The caller must close it.
+func (t *Tensor) Value(r *ort.Runtime) (*ort.Value, error) {
+	data, err := t.decode()
+	if err != nil {
+		return nil, err
+	}
+	switch d := data.(type) {
+	case []float32:
+		switch t.DType {
+		case "float16":
+			return ort.NewFloat16TensorFromFloat32(r, d, t.Shape)
+		case "bfloat16":
+			return ort.NewBFloat16TensorFromFloat32(r, d, t.Shape)
+		}
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []float64:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []int8:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []int16:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []int32:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []int64:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []uint8:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []uint16:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []uint32:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []uint64:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []bool:
+		return ort.NewTensorValue(r, d, t.Shape)
+	case []string:
+		return r.NewStringTensorValue(d, t.Shape)
+	default:
+		return nil, fmt.Errorf("unsupported dtype %q", t.DType)
+	}
+}
+
+// decode parses t's elements into a slice of the Go type for its dtype,
+// []float32 for float16 and bfloat16, and checks the count against its
+// shape.
+func (t *Tensor) decode() (any, error) {
+	count := int64(1)
+	for _, d := range t.Shape {
+		if d < 0 || (d > 0 && count > math.MaxInt64/d) {
+			return nil, fmt.Errorf("invalid shape %v", t.Shape)
+		}
+		count *= d
+	}
+
+	var data any
+	var n int
+	var err error
+	switch t.DType {
+	case "float32", "float16", "bfloat16":
+		data, n, err = decodeFloats[float32](t.Data)
+	case "float64":
+		data, n, err = decodeFloats[float64](t.Data)
+	case "int8":
+		data, n, err = decodeSlice[int8](t.Data)
+	case "int16":
+		data, n, err = decodeSlice[int16](t.Data)
+	case "int32":
+		data, n, err = decodeSlice[int32](t.Data)
+	case "int64":
+		data, n, err = decodeSlice[int64](t.Data)
+	case "uint8":
+		data, n, err = decodeBytes(t.Data)
+	case "uint16":
+		data, n, err = decodeSlice[uint16](t.Data)
+	case "uint32":
+		data, n, err = decodeSlice[uint32](t.Data)
+	case "uint64":
+		data, n, err = decodeSlice[uint64](t.Data)
+	case "bool":
+		data, n, err = decodeSlice[bool](t.Data)
+	case "string":
+		data, n, err = decodeSlice[string](t.Data)
+	default:
+		return nil, fmt.Errorf("unsupported dtype %q", t.DType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s data: %w", t.DType, err)
+	}
+	if int64(n) != count {
+		return nil, fmt.Errorf("shape %v needs %d elements, got %d", t.Shape, count, n)
+	}
+	return data, nil
+}
+
+func decodeSlice[T any](raw json.RawMessage) ([]T, int, error) {
+	var s []T
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, 0, err
+	}
+	return s, len(s), nil
+}
+
+// decodeBytes parses a JSON array of numbers into bytes; json.Unmarshal
+// expects base64 for []byte.
+func decodeBytes(raw json.RawMessage) ([]uint8, int, error) {
+	wide, n, err := decodeSlice[uint16](raw)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]uint8, n)
+	for i, v := range wide {
+		if v > math.MaxUint8 {
+			return nil, 0, fmt.Errorf("value %d overflows uint8", v)
+		}
+		out[i] = uint8(v)
+	}
+	return out, n, nil
+}
+
+// jsonFloat is a float that also accepts the protobuf JSON names of
+// non-finite values.
+type jsonFloat float64
+
+func (f *jsonFloat) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case `"NaN"`:
+		*f = jsonFloat(math.NaN())
+	case `"Infinity"`:
+		*f = jsonFloat(math.Inf(1))
+	case `"-Infinity"`:
+		*f = jsonFloat(math.Inf(-1))
+	default:
+		v, err := strconv.ParseFloat(string(b), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %s", b)
+		}
+		*f = jsonFloat(v)
+	}
+	return nil
+}
+
+func decodeFloats[T float32 | float64](raw json.RawMessage) ([]T, int, error) {
+	fs, n, err := decodeSlice[jsonFloat](raw)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]T, n)
+	for i, f := range fs {
+		out[i] = T(f)
+	}
+	return out, n, nil
+}
+
+// NewTensor encodes an ONNX Runtime tensor.
+func NewTensor(v *ort.Value) (Tensor, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return Tensor{}, err
+	}
+	name, ok := dtypeName(elemType)
+	if !ok {
+		return Tensor{}, fmt.Errorf("unsupported tensor element type %d", elemType)
+	}
+
+	var shape []int64
+	var data json.RawMessage
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeFloat, ort.ONNXTensorElementDataTypeFloat16, ort.ONNXTensorElementDataTypeBFloat16:
+		var d []float32
+		d, shape, err = ort.GetTensorDataAsFloat32(v)
+		data = appendFloats(nil, d, 32)
+	case ort.ONNXTensorElementDataTypeDouble:
+		var d []float64
+		d, shape, err = ort.GetTensorData[float64](v)
+		data = appendFloats(nil, d, 64)
+	case ort.ONNXTensorElementDataTypeString:
+		var d []string
+		d, shape, err = ort.GetStringTensorData(v)
+		if err == nil {
+			data, err = json.Marshal(d)
+		}
+	case ort.ONNXTensorElementDataTypeInt8:
+		data, shape, err = marshalTensor[int8](v)
+	case ort.ONNXTensorElementDataTypeInt16:
+		data, shape, err = marshalTensor[int16](v)
+	case ort.ONNXTensorElementDataTypeInt32:
+		data, shape, err = marshalTensor[int32](v)
+	case ort.ONNXTensorElementDataTypeInt64:
+		data, shape, err = marshalTensor[int64](v)
+	case ort.ONNXTensorElementDataTypeUint8:
+		// Marshalled as numbers rather than as base64 like []byte.
+		var d []uint8
+		d, shape, err = ort.GetTensorData[uint8](v)
+		data = appendInts(nil, d)
+	case ort.ONNXTensorElementDataTypeUint16:
+		data, shape, err = marshalTensor[uint16](v)
+	case ort.ONNXTensorElementDataTypeUint32:
+		data, shape, err = marshalTensor[uint32](v)
+	case ort.ONNXTensorElementDataTypeUint64:
+		data, shape, err = marshalTensor[uint64](v)
+	case ort.ONNXTensorElementDataTypeBool:
+		data, shape, err = marshalTensor[bool](v)
+	}
+	if err != nil {
+		return Tensor{}, err
+	}
+	if shape == nil {
+		shape = []int64{}
+	}
+	return Tensor{DType: name, Shape: shape, Data: data}, nil
+}
+
+func marshalTensor[T ort.TensorData](v *ort.Value) (json.RawMessage, []int64, error) {
+	d, shape, err := ort.GetTensorData[T](v)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(d)
+	return data, shape, err
+}
+
+// appendFloats appends data as a JSON array, writing non-finite values as
+// strings.
+func appendFloats[T float32 | float64](b []byte, data []T, bitSize int) []byte {
+	b = append(b, '[')
+	for i, f := range data {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		v := float64(f)
+		switch {
+		case math.IsNaN(v):
+			b = append(b, `"NaN"`...)
+		case math.IsInf(v, 1):
+			b = append(b, `"Infinity"`...)
+		case math.IsInf(v, -1):
+			b = append(b, `"-Infinity"`...)
+		default:
+			b = strconv.AppendFloat(b, v, 'g', -1, bitSize)
+		}
+	}
+	return append(b, ']')
+}
+
+func appendInts(b []byte, data []uint8) []byte {
+	b = append(b, '[')
+	for i, v := range data {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendUint(b, uint64(v), 10)
+	}
+	return append(b, ']')
+}
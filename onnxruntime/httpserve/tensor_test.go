@@ -0,0 +1,80 @@
+package httpserve
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTensorDecode(t *testing.T) {
+	tests := []struct {
+		json string
+		want any
+	}{
+		{`{"dtype":"float32","shape":[2],"data":[1.5,"-Infinity"]}`, []float32{1.5, float32(math.Inf(-1))}},
+		{`{"dtype":"float16","shape":[1],"data":[0.5]}`, []float32{0.5}},
+		{`{"dtype":"float64","shape":[],"data":[2]}`, []float64{2}},
+		{`{"dtype":"int64","shape":[1,2],"data":[9007199254740993,-1]}`, []int64{9007199254740993, -1}},
+		{`{"dtype":"uint8","shape":[3],"data":[0,128,255]}`, []uint8{0, 128, 255}},
+		{`{"dtype":"bool","shape":[2],"data":[true,false]}`, []bool{true, false}},
+		{`{"dtype":"string","shape":[1],"data":["hi"]}`, []string{"hi"}},
+	}
+	for _, tt := range tests {
+		var tensor Tensor
+		if err := json.Unmarshal([]byte(tt.json), &tensor); err != nil {
+			t.Fatal(err)
+		}
+		got, err := tensor.decode()
+		if err != nil {
+			t.Errorf("%s: decode failed: %v", tt.json, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.json, got, tt.want)
+		}
+	}
+}
+
+func TestTensorDecodeErrors(t *testing.T) {
+	bad := []string{
+		`{"dtype":"float32","shape":[3],"data":[1,2]}`,
+		`{"dtype":"float32","shape":[-1],"data":[]}`,
+		`{"dtype":"float32","shape":[4611686018427387904,4],"data":[]}`,
+		`{"dtype":"complex64","shape":[1],"data":[1]}`,
+		`{"dtype":"int8","shape":[1],"data":[200]}`,
+		`{"dtype":"uint8","shape":[1],"data":[256]}`,
+		`{"dtype":"float32","shape":[1],"data":["one"]}`,
+		`{"dtype":"int32","shape":[1],"data":[1.5]}`,
+	}
+	for _, s := range bad {
+		var tensor Tensor
+		if err := json.Unmarshal([]byte(s), &tensor); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tensor.decode(); err == nil {
+			t.Errorf("Expected an error decoding %s", s)
+		}
+	}
+}
+
+func TestAppendFloats(t *testing.T) {
+	got := appendFloats(nil, []float32{0.1, float32(math.NaN()), float32(math.Inf(1)), -2}, 32)
+	if want := `[0.1,"NaN","Infinity",-2]`; string(got) != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if got := appendInts(nil, []uint8{0, 255}); string(got) != "[0,255]" {
+		t.Errorf("Expected [0,255], got %s", got)
+	}
+
+	// Encoded floats decode to the same values.
+	data := []float32{1e-8, 3.4028235e38, float32(math.Inf(-1))}
+	decoded, _, err := decodeFloats[float32](appendFloats(nil, data, 32))
+	if err != nil {
+		t.Fatalf("decodeFloats failed: %v", err)
+	}
+	if !slices.Equal(decoded, data) {
+		t.Errorf("Expected %v after a round trip, got %v", data, decoded)
+	}
+}
@@ -0,0 +1,11 @@
+// Package imagetensor converts between Go images and ONNX Runtime tensors.
+//
+// Vision models that produce images (super-resolution, style transfer,
+// segmentation masks) return tensors in NCHW or NHWC layout. The helpers in
+// this package turn those output Values into standard image.Gray and
+// image.RGBA values, applying inverse normalization, scaling, and clamping
+// to the 0-255 pixel range.
+//
+// In the other direction, FromImages and Preprocess resize, crop, and
+// normalize Go images into model input tensors.
+package imagetensor
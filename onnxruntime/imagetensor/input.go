@@ -0,0 +1,325 @@
+package imagetensor
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// ImageNet channel statistics used by most torchvision and timm models.
+var (
+	ImageNetMean = []float32{0.485, 0.456, 0.406}
+	ImageNetStd  = []float32{0.229, 0.224, 0.225}
+)
+
+// Interpolation selects how images are resampled to the tensor size.
+type Interpolation int
+
+const (
+	// InterpolationBilinear blends the four nearest source pixels.
+	InterpolationBilinear Interpolation = iota
+	// InterpolationNearest takes the nearest source pixel, keeping exact
+	// pixel values such as the colors of a label map.
+	InterpolationNearest
+)
+
+// String returns a human-readable name for the interpolation.
+func (i Interpolation) String() string {
+	switch i {
+	case InterpolationBilinear:
+		return "Bilinear"
+	case InterpolationNearest:
+		return "Nearest"
+	default:
+		return fmt.Sprintf("Interpolation(%d)", int(i))
+	}
+}
+
+// InputOptions configures conversion of images into a model input tensor.
+//
+// Each pixel intensity p in 0-255 becomes an element as:
+//
+//	v = (p * Scale - Mean[c]) / Std[c]
+type InputOptions struct {
+	// Layout is the dimension order of the produced tensor.
+	Layout Layout
+
+	// Width and Height are the spatial size of the tensor. Required.
+	Width, Height int
+
+	// ResizeShorter, when positive, resizes the image so its shorter side
+	// has this length, preserving aspect ratio, and then takes a centered
+	// Width x Height crop. ImageNet models are usually evaluated with 256
+	// for a 224 crop. When zero, the image is resized to Width x Height
+	// directly, which distorts the aspect ratio of non-matching images.
+	ResizeShorter int
+
+	// Grayscale produces one channel instead of RGB.
+	Grayscale bool
+
+	// BGR orders color channels blue, green, red, as OpenCV-trained models
+	// expect.
+	BGR bool
+
+	// Scale multiplies 0-255 intensities before normalization. Zero means
+	// 1/255, mapping pixels into [0, 1], or 1 for uint8 tensors, keeping raw
+	// pixel values.
+	Scale float32
+
+	// Mean and Std normalize each channel after scaling. Both are optional;
+	// when set their length must match the channel count.
+	Mean []float32
+	Std  []float32
+
+	// ElementType is the element type of tensors created by FromImage and
+	// FromImages: ONNXTensorElementDataTypeFloat (the default),
+	// ONNXTensorElementDataTypeFloat16, or ONNXTensorElementDataTypeUint8.
+	// Uint8 elements are rounded and clamped to 0-255.
+	ElementType ort.ONNXTensorElementDataType
+
+	// Interpolation selects the filter used to resample the image.
+	Interpolation Interpolation
+
+	// Resize, if set, replaces the built-in resampling, for example with a
+	// higher-quality filter from another package. It is called with the size
+	// the image is scaled to before cropping and must return an image of
+	// exactly that size; Interpolation is then ignored.
+	Resize func(img image.Image, width, height int) image.Image
+}
+
+func (o *InputOptions) scale() float32 {
+	switch {
+	case o.Scale != 0:
+		return o.Scale
+	case o.ElementType == ort.ONNXTensorElementDataTypeUint8:
+		return 1
+	default:
+		return 1.0 / 255
+	}
+}
+
+func (o *InputOptions) channels() int {
+	if o.Grayscale {
+		return 1
+	}
+	return 3
+}
+
+func (o *InputOptions) validate() error {
+	if o.Width <= 0 || o.Height <= 0 {
+		return fmt.Errorf("width and height must be positive, got %dx%d", o.Width, o.Height)
+	}
+	if o.ResizeShorter < 0 {
+		return fmt.Errorf("resize length cannot be negative")
+	}
+	if o.ResizeShorter > 0 && o.ResizeShorter < min(o.Width, o.Height) {
+		return fmt.Errorf("resize length %d is smaller than the %dx%d crop", o.ResizeShorter, o.Width, o.Height)
+	}
+	c := o.channels()
+	if len(o.Mean) != 0 && len(o.Mean) != c {
+		return fmt.Errorf("mean has %d values, expected %d channels", len(o.Mean), c)
+	}
+	if len(o.Std) != 0 && len(o.Std) != c {
+		return fmt.Errorf("std has %d values, expected %d channels", len(o.Std), c)
+	}
+	for _, s := range o.Std {
+		if s == 0 {
+			return fmt.Errorf("std values must be non-zero")
+		}
+	}
+	switch o.ElementType {
+	case ort.ONNXTensorElementDataTypeUndefined, ort.ONNXTensorElementDataTypeFloat,
+		ort.ONNXTensorElementDataTypeFloat16, ort.ONNXTensorElementDataTypeUint8:
+	default:
+		return fmt.Errorf("unsupported element type %d: expected float, float16, or uint8", o.ElementType)
+	}
+	if o.Interpolation != InterpolationBilinear && o.Interpolation != InterpolationNearest {
+		return fmt.Errorf("unsupported interpolation %v", o.Interpolation)
+	}
+	return nil
+}
+
+// Shape returns the tensor shape for a batch of n images.
+func (o *InputOptions) Shape(n int) []int64 {
+	c, h, w := int64(o.channels()), int64(o.Height), int64(o.Width)
+	if o.Layout == LayoutNHWC {
+		return []int64{int64(n), h, w, c}
+	}
+	return []int64{int64(n), c, h, w}
+}
+
+// FromImage converts one image into a tensor with a batch dimension of 1.
+// The caller must close the returned Value.
+//
+// Example:
+//
+//	input, err := imagetensor.FromImage(runtime, img, &imagetensor.InputOptions{
+//	    Width: 224, Height: 224, ResizeShorter: 256,
+//	    Mean: imagetensor.ImageNetMean, Std: imagetensor.ImageNetStd,
+//	})
+func FromImage(r *ort.Runtime, img image.Image, opts *InputOptions) (*ort.Value, error) {
+	return FromImages(r, []image.Image{img}, opts)
+}
+
+// FromImages converts images into one tensor of opts.ElementType shaped by
+// [InputOptions.Shape]. The caller must close the returned Value.
+func FromImages(r *ort.Runtime, images []image.Image, opts *InputOptions) (*ort.Value, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no images")
+	}
+	if opts == nil {
+		return nil, fmt.Errorf("input options are required")
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	size := opts.channels() * opts.Width * opts.Height
+	data := make([]float32, len(images)*size)
+	for i, img := range images {
+		if err := preprocess(img, opts, data[i*size:(i+1)*size]); err != nil {
+			return nil, fmt.Errorf("image %d: %w", i, err)
+		}
+	}
+	return newTensor(r, data, opts.Shape(len(images)), opts.ElementType)
+}
+
+// newTensor creates a tensor of elemType from float32 element data.
+func newTensor(r *ort.Runtime, data []float32, shape []int64, elemType ort.ONNXTensorElementDataType) (*ort.Value, error) {
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeFloat16:
+		return ort.NewFloat16TensorFromFloat32(r, data, shape)
+	case ort.ONNXTensorElementDataTypeUint8:
+		pixels := make([]uint8, len(data))
+		for i, v := range data {
+			pixels[i] = uint8(min(max(math.Round(float64(v)), 0), 255))
+		}
+		return ort.NewTensorValue(r, pixels, shape)
+	default:
+		return ort.NewTensorValue(r, data, shape)
+	}
+}
+
+// Preprocess converts one image into the flat element data of a single
+// batch item, without creating a tensor. It returns float32 elements
+// regardless of opts.ElementType.
+func Preprocess(img image.Image, opts *InputOptions) ([]float32, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("input options are required")
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	data := make([]float32, opts.channels()*opts.Width*opts.Height)
+	if err := preprocess(img, opts, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// preprocess writes img into out, which holds channels*Width*Height elements.
+func preprocess(img image.Image, o *InputOptions, out []float32) error {
+	src := toNRGBA(img)
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+
+	// Map output pixels to source coordinates: scale, then crop offset.
+	fx, fy := float64(o.Width)/float64(sw), float64(o.Height)/float64(sh)
+	var ox, oy float64
+	if o.ResizeShorter > 0 {
+		f := float64(o.ResizeShorter) / float64(min(sw, sh))
+		fx, fy = f, f
+		ox = math.Round((float64(sw)*f - float64(o.Width)) / 2)
+		oy = math.Round((float64(sh)*f - float64(o.Height)) / 2)
+	}
+
+	sample := bilinear
+	if o.Interpolation == InterpolationNearest {
+		sample = nearest
+	}
+	if o.Resize != nil {
+		// The hook scales the image, leaving only the crop to sample.
+		rw, rh := int(math.Round(float64(sw)*fx)), int(math.Round(float64(sh)*fy))
+		resized := o.Resize(img, rw, rh)
+		if b := resized.Bounds(); b.Dx() != rw || b.Dy() != rh {
+			return fmt.Errorf("resize returned a %dx%d image, expected %dx%d", b.Dx(), b.Dy(), rw, rh)
+		}
+		src = toNRGBA(resized)
+		fx, fy = 1, 1
+		ox, oy = math.Round(float64(rw-o.Width)/2), math.Round(float64(rh-o.Height)/2)
+		sample = nearest
+	}
+
+	scale := o.scale()
+	g := imageGeometry{channels: o.channels(), height: o.Height, width: o.Width, layout: o.Layout}
+	for y := 0; y < o.Height; y++ {
+		sy := (float64(y)+oy+0.5)/fy - 0.5
+		for x := 0; x < o.Width; x++ {
+			sx := (float64(x)+ox+0.5)/fx - 0.5
+			rgb := sample(src, sx, sy)
+
+			if o.Grayscale {
+				// ITU-R BT.601 luma, as used by image/color.GrayModel
+				rgb[0] = 0.299*rgb[0] + 0.587*rgb[1] + 0.114*rgb[2]
+			} else if o.BGR {
+				rgb[0], rgb[2] = rgb[2], rgb[0]
+			}
+			for c := 0; c < g.channels; c++ {
+				v := rgb[c] * scale
+				if len(o.Mean) != 0 {
+					v -= o.Mean[c]
+				}
+				if len(o.Std) != 0 {
+					v /= o.Std[c]
+				}
+				out[g.index(c, x, y)] = v
+			}
+		}
+	}
+	return nil
+}
+
+// toNRGBA returns img as an *image.NRGBA, converting only when needed.
+func toNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok {
+		return n
+	}
+	b := img.Bounds()
+	n := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(n, n.Rect, img, b.Min, draw.Src)
+	return n
+}
+
+// bilinear samples RGB intensities (0-255) at a fractional source position,
+// clamping to the image edges.
+func bilinear(img *image.NRGBA, x, y float64) [3]float32 {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	x = min(max(x, 0), float64(w-1))
+	y = min(max(y, 0), float64(h-1))
+	x0, y0 := int(x), int(y)
+	x1, y1 := min(x0+1, w-1), min(y0+1, h-1)
+	dx, dy := float32(x-float64(x0)), float32(y-float64(y0))
+
+	var rgb [3]float32
+	p00 := img.PixOffset(img.Rect.Min.X+x0, img.Rect.Min.Y+y0)
+	p10 := img.PixOffset(img.Rect.Min.X+x1, img.Rect.Min.Y+y0)
+	p01 := img.PixOffset(img.Rect.Min.X+x0, img.Rect.Min.Y+y1)
+	p11 := img.PixOffset(img.Rect.Min.X+x1, img.Rect.Min.Y+y1)
+	for c := 0; c < 3; c++ {
+		top := float32(img.Pix[p00+c])*(1-dx) + float32(img.Pix[p10+c])*dx
+		bottom := float32(img.Pix[p01+c])*(1-dx) + float32(img.Pix[p11+c])*dx
+		rgb[c] = top*(1-dy) + bottom*dy
+	}
+	return rgb
+}
+
+// nearest returns the RGB intensities (0-255) of the source pixel containing
+// a fractional source position, clamping to the image edges.
+func nearest(img *image.NRGBA, x, y float64) [3]float32 {
+	xi := min(max(int(math.Floor(x+0.5)), 0), img.Rect.Dx()-1)
+	yi := min(max(int(math.Floor(y+0.5)), 0), img.Rect.Dy()-1)
+	p := img.PixOffset(img.Rect.Min.X+xi, img.Rect.Min.Y+yi)
+	return [3]float32{float32(img.Pix[p]), float32(img.Pix[p+1]), float32(img.Pix[p+2])}
+}
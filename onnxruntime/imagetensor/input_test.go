@@ -0,0 +1,243 @@
+package imagetensor
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// columns returns a width x height image whose column x has colors[x].
+func columns(height int, colors ...color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, len(colors), height))
+	for y := 0; y < height; y++ {
+		for x, c := range colors {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func approx(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-4
+}
+
+func approxSlice(a, b []float32) bool {
+	return slices.EqualFunc(a, b, approx)
+}
+
+func TestPreprocessLayouts(t *testing.T) {
+	red := color.NRGBA{255, 0, 0, 255}
+	blue := color.NRGBA{0, 0, 255, 255}
+	img := columns(1, red, blue)
+
+	nchw, err := Preprocess(img, &InputOptions{Width: 2, Height: 1})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	// [C, H, W]: R plane, G plane, B plane
+	if want := []float32{1, 0, 0, 0, 0, 1}; !slices.Equal(nchw, want) {
+		t.Errorf("NCHW: expected %v, got %v", want, nchw)
+	}
+
+	nhwc, err := Preprocess(img, &InputOptions{Width: 2, Height: 1, Layout: LayoutNHWC})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	if want := []float32{1, 0, 0, 0, 0, 1}; !slices.Equal(nhwc, want) {
+		t.Errorf("NHWC: expected %v, got %v", want, nhwc)
+	}
+
+	bgr, err := Preprocess(img, &InputOptions{Width: 2, Height: 1, Layout: LayoutNHWC, BGR: true})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	if want := []float32{0, 0, 1, 1, 0, 0}; !slices.Equal(bgr, want) {
+		t.Errorf("BGR: expected %v, got %v", want, bgr)
+	}
+}
+
+func TestPreprocessNormalization(t *testing.T) {
+	gray := color.NRGBA{128, 128, 128, 255}
+	data, err := Preprocess(columns(1, gray), &InputOptions{
+		Width: 1, Height: 1, Mean: ImageNetMean, Std: ImageNetStd,
+	})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	for c, v := range data {
+		want := (128.0/255 - ImageNetMean[c]) / ImageNetStd[c]
+		if !approx(v, want) {
+			t.Errorf("channel %d: expected %v, got %v", c, want, v)
+		}
+	}
+
+	luma, err := Preprocess(columns(1, color.NRGBA{0, 255, 0, 255}), &InputOptions{
+		Width: 1, Height: 1, Grayscale: true, Scale: 1,
+	})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	if len(luma) != 1 || !approx(luma[0], 0.587*255) {
+		t.Errorf("Expected green luma %v, got %v", 0.587*255, luma)
+	}
+}
+
+func TestPreprocessResizeAndCrop(t *testing.T) {
+	c := func(v uint8) color.NRGBA { return color.NRGBA{v, v, v, 255} }
+	img := columns(2, c(0), c(10), c(20), c(30), c(40), c(50))
+
+	// Shorter side is already 2, so this is a pure 2x2 center crop.
+	data, err := Preprocess(img, &InputOptions{Width: 2, Height: 2, ResizeShorter: 2, Grayscale: true, Scale: 1})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	if want := []float32{20, 30, 20, 30}; !approxSlice(data, want) {
+		t.Errorf("Expected center crop %v, got %v", want, data)
+	}
+
+	// Direct resize halves the width, blending neighbouring columns.
+	data, err = Preprocess(img, &InputOptions{Width: 3, Height: 2, Grayscale: true, Scale: 1})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	if want := []float32{5, 25, 45, 5, 25, 45}; !approxSlice(data, want) {
+		t.Errorf("Expected resized %v, got %v", want, data)
+	}
+}
+
+func TestPreprocessNearest(t *testing.T) {
+	c := func(v uint8) color.NRGBA { return color.NRGBA{v, v, v, 255} }
+	img := columns(1, c(0), c(10), c(20), c(30))
+
+	// Halving the width takes one column of each pair instead of blending.
+	data, err := Preprocess(img, &InputOptions{Width: 2, Height: 1, Grayscale: true, Scale: 1, Interpolation: InterpolationNearest})
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	if want := []float32{10, 30}; !approxSlice(data, want) {
+		t.Errorf("Expected nearest samples %v, got %v", want, data)
+	}
+}
+
+func TestPreprocessResizeHook(t *testing.T) {
+	c := func(v uint8) color.NRGBA { return color.NRGBA{v, v, v, 255} }
+	img := columns(2, c(0), c(10), c(20), c(30))
+
+	var gotW, gotH int
+	opts := &InputOptions{
+		Width: 2, Height: 2, ResizeShorter: 4, Grayscale: true, Scale: 1,
+		Resize: func(src image.Image, width, height int) image.Image {
+			gotW, gotH = width, height
+			// Fill with a constant so the crop is easy to check.
+			dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+			for i := range dst.Pix {
+				dst.Pix[i] = 7
+			}
+			return dst
+		},
+	}
+	data, err := Preprocess(img, opts)
+	if err != nil {
+		t.Fatalf("Preprocess failed: %v", err)
+	}
+	if gotW != 8 || gotH != 4 {
+		t.Errorf("Expected the hook to resize to 8x4, got %dx%d", gotW, gotH)
+	}
+	if want := []float32{7, 7, 7, 7}; !approxSlice(data, want) {
+		t.Errorf("Expected %v, got %v", want, data)
+	}
+
+	opts.Resize = func(src image.Image, width, height int) image.Image { return src }
+	if _, err := Preprocess(img, opts); err == nil {
+		t.Error("Expected an error when the hook returns the wrong size")
+	}
+}
+
+func TestInputOptionsValidation(t *testing.T) {
+	img := columns(1, color.NRGBA{A: 255})
+	bad := []*InputOptions{
+		nil,
+		{},
+		{Width: 224, Height: 224, ResizeShorter: 100},
+		{Width: 1, Height: 1, Mean: []float32{0.5}},
+		{Width: 1, Height: 1, Std: []float32{1, 0, 1}},
+		{Width: 1, Height: 1, ElementType: ort.ONNXTensorElementDataTypeInt64},
+		{Width: 1, Height: 1, Interpolation: Interpolation(9)},
+	}
+	for _, opts := range bad {
+		if _, err := Preprocess(img, opts); err == nil {
+			t.Errorf("Expected error for options %+v", opts)
+		}
+	}
+}
+
+func TestInputShape(t *testing.T) {
+	o := &InputOptions{Width: 224, Height: 200}
+	if got := o.Shape(2); !slices.Equal(got, []int64{2, 3, 200, 224}) {
+		t.Errorf("Unexpected NCHW shape %v", got)
+	}
+	o.Layout, o.Grayscale = LayoutNHWC, true
+	if got := o.Shape(1); !slices.Equal(got, []int64{1, 200, 224, 1}) {
+		t.Errorf("Unexpected NHWC shape %v", got)
+	}
+}
+
+func TestFromImages(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	img := columns(2, color.NRGBA{255, 0, 0, 255}, color.NRGBA{0, 0, 255, 255})
+	v, err := FromImages(runtime, []image.Image{img, img}, &InputOptions{Width: 2, Height: 2})
+	if err != nil {
+		t.Fatalf("FromImages failed: %v", err)
+	}
+	defer v.Close()
+
+	_, shape, err := ort.GetTensorData[float32](v)
+	if err != nil {
+		t.Fatalf("Failed to read tensor: %v", err)
+	}
+	if !slices.Equal(shape, []int64{2, 3, 2, 2}) {
+		t.Errorf("Unexpected shape %v", shape)
+	}
+}
+
+func TestFromImageElementTypes(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	img := columns(1, color.NRGBA{255, 128, 0, 255})
+
+	v, err := FromImage(runtime, img, &InputOptions{Width: 1, Height: 1, ElementType: ort.ONNXTensorElementDataTypeUint8})
+	if err != nil {
+		t.Fatalf("FromImage failed: %v", err)
+	}
+	defer v.Close()
+	pixels, shape, err := ort.GetTensorData[uint8](v)
+	if err != nil {
+		t.Fatalf("Failed to read uint8 tensor: %v", err)
+	}
+	if !slices.Equal(shape, []int64{1, 3, 1, 1}) || !slices.Equal(pixels, []uint8{255, 128, 0}) {
+		t.Errorf("Expected raw pixels [255 128 0] of shape [1 3 1 1], got %v of shape %v", pixels, shape)
+	}
+
+	v16, err := FromImage(runtime, img, &InputOptions{Width: 1, Height: 1, ElementType: ort.ONNXTensorElementDataTypeFloat16})
+	if err != nil {
+		t.Fatalf("FromImage failed: %v", err)
+	}
+	defer v16.Close()
+	if elemType, err := v16.GetTensorElementType(); err != nil || elemType != ort.ONNXTensorElementDataTypeFloat16 {
+		t.Fatalf("Expected a float16 tensor, got %v (%v)", elemType, err)
+	}
+	data, _, err := ort.GetTensorDataAsFloat32(v16)
+	if err != nil {
+		t.Fatalf("Failed to read float16 tensor: %v", err)
+	}
+	for c, want := range []float32{1, 128.0 / 255, 0} {
+		if math.Abs(float64(data[c]-want)) > 1e-3 {
+			t.Errorf("channel %d: expected %v, got %v", c, want, data[c])
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package imagetensor
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Layout describes the dimension order of an image tensor.
+type Layout int
+
+const (
+	// LayoutNCHW stores channels before spatial dimensions ([N, C, H, W]).
+	LayoutNCHW Layout = iota
+	// LayoutNHWC stores channels last ([N, H, W, C]).
+	LayoutNHWC
+)
+
+// String returns a human-readable name for the layout.
+func (l Layout) String() string {
+	switch l {
+	case LayoutNCHW:
+		return "NCHW"
+	case LayoutNHWC:
+		return "NHWC"
+	default:
+		return fmt.Sprintf("Layout(%d)", int(l))
+	}
+}
+
+// OutputOptions configures conversion of a tensor into an image.
+//
+// Each element is mapped to a pixel intensity as:
+//
+//	pixel = clamp(round(((v * Std[c]) + Mean[c]) * Scale + Offset), 0, 255)
+//
+// which inverts the usual (x/255 - mean) / std preprocessing when Mean and Std
+// match the values used on the input side.
+type OutputOptions struct {
+	// Layout is the dimension order of the tensor. Rank-2 tensors ([H, W])
+	// are always treated as single-channel regardless of Layout.
+	Layout Layout
+
+	// BatchIndex selects the image within a rank-4 batched tensor.
+	BatchIndex int
+
+	// Mean and Std undo per-channel normalization before scaling.
+	// Both are optional; when set their length must match the channel count.
+	Mean []float32
+	Std  []float32
+
+	// Scale multiplies values after denormalization. Zero means 255 for
+	// floating-point tensors (values in [0, 1]) and 1 for integer tensors.
+	Scale float32
+
+	// Offset is added after scaling.
+	Offset float32
+
+	// MinMax stretches the tensor's observed value range to 0-255, ignoring
+	// Scale and Offset. Useful for depth maps and unnormalized masks.
+	MinMax bool
+}
+
+// ToGray converts a single-channel image tensor to an *image.Gray.
+// Accepted shapes are [H, W] in either layout, [1, H, W] and [N, 1, H, W]
+// with the default LayoutNCHW, and [H, W, 1] and [N, H, W, 1] with Layout
+// set to LayoutNHWC.
+func ToGray(v *ort.Value, opts *OutputOptions) (*image.Gray, error) {
+	data, shape, scale, err := tensorFloats(v)
+	if err != nil {
+		return nil, err
+	}
+	return grayFromFloats(data, shape, resolveOptions(opts, scale))
+}
+
+// ToRGBA converts a 1-, 3-, or 4-channel image tensor to an *image.RGBA.
+// Single-channel tensors are replicated across RGB; three-channel tensors
+// produce fully opaque pixels.
+func ToRGBA(v *ort.Value, opts *OutputOptions) (*image.RGBA, error) {
+	data, shape, scale, err := tensorFloats(v)
+	if err != nil {
+		return nil, err
+	}
+	return rgbaFromFloats(data, shape, resolveOptions(opts, scale))
+}
+
+// resolveOptions fills defaults, using defaultScale when Scale is unset.
+func resolveOptions(opts *OutputOptions, defaultScale float32) OutputOptions {
+	var o OutputOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Scale == 0 {
+		o.Scale = defaultScale
+	}
+	return o
+}
+
+// tensorFloats reads a tensor as float32 along with the default scale for its element type.
+func tensorFloats(v *ort.Value) ([]float32, []int64, float32, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	switch elemType {
+	case ort.ONNXTensorElementDataTypeFloat, ort.ONNXTensorElementDataTypeFloat16,
+		ort.ONNXTensorElementDataTypeBFloat16, ort.ONNXTensorElementDataTypeDouble:
+		data, shape, err := ort.GetTensorDataAsFloat32(v)
+		return data, shape, 255, err
+	case ort.ONNXTensorElementDataTypeUint8:
+		data, shape, err := ort.GetTensorDataUnsafe[uint8](v)
+		return convert(data), shape, 1, err
+	case ort.ONNXTensorElementDataTypeInt64:
+		data, shape, err := ort.GetTensorDataUnsafe[int64](v)
+		return convert(data), shape, 1, err
+	case ort.ONNXTensorElementDataTypeInt32:
+		data, shape, err := ort.GetTensorDataUnsafe[int32](v)
+		return convert(data), shape, 1, err
+	default:
+		return nil, nil, 0, fmt.Errorf("unsupported element type %d for image conversion", elemType)
+	}
+}
+
+func convert[T uint8 | int32 | int64](data []T) []float32 {
+	out := make([]float32, len(data))
+	for i, x := range data {
+		out[i] = float32(x)
+	}
+	return out
+}
+
+// imageGeometry describes where pixels live in a flat tensor buffer.
+type imageGeometry struct {
+	channels, height, width int
+	base                    int // offset of the selected batch item
+	layout                  Layout
+}
+
+// index returns the flat offset of channel c at (x, y).
+func (g imageGeometry) index(c, x, y int) int {
+	if g.layout == LayoutNHWC {
+		return g.base + (y*g.width+x)*g.channels + c
+	}
+	return g.base + c*g.height*g.width + y*g.width + x
+}
+
+func resolveGeometry(shape []int64, dataLen int, o OutputOptions) (imageGeometry, error) {
+	g := imageGeometry{layout: o.Layout}
+	batch := 1
+
+	switch len(shape) {
+	case 2:
+		g.channels, g.height, g.width = 1, int(shape[0]), int(shape[1])
+		g.layout = LayoutNCHW
+	case 3:
+		if o.Layout == LayoutNHWC {
+			g.height, g.width, g.channels = int(shape[0]), int(shape[1]), int(shape[2])
+		} else {
+			g.channels, g.height, g.width = int(shape[0]), int(shape[1]), int(shape[2])
+		}
+	case 4:
+		batch = int(shape[0])
+		if o.Layout == LayoutNHWC {
+			g.height, g.width, g.channels = int(shape[1]), int(shape[2]), int(shape[3])
+		} else {
+			g.channels, g.height, g.width = int(shape[1]), int(shape[2]), int(shape[3])
+		}
+	default:
+		return g, fmt.Errorf("unsupported image tensor rank %d (shape %v)", len(shape), shape)
+	}
+
+	if g.channels <= 0 || g.height <= 0 || g.width <= 0 {
+		return g, fmt.Errorf("invalid image tensor shape %v", shape)
+	}
+	if o.BatchIndex < 0 || o.BatchIndex >= batch {
+		return g, fmt.Errorf("batch index %d out of range for batch size %d", o.BatchIndex, batch)
+	}
+
+	size := g.channels * g.height * g.width
+	if batch*size != dataLen {
+		return g, fmt.Errorf("shape %v does not match data length %d", shape, dataLen)
+	}
+	g.base = o.BatchIndex * size
+
+	if len(o.Mean) != 0 && len(o.Mean) != g.channels {
+		return g, fmt.Errorf("mean has %d values, tensor has %d channels", len(o.Mean), g.channels)
+	}
+	if len(o.Std) != 0 && len(o.Std) != g.channels {
+		return g, fmt.Errorf("std has %d values, tensor has %d channels", len(o.Std), g.channels)
+	}
+	return g, nil
+}
+
+// pixelMapper converts raw tensor values to clamped 8-bit intensities.
+type pixelMapper struct {
+	mean, std     []float32
+	scale, offset float32
+}
+
+func newPixelMapper(data []float32, g imageGeometry, o OutputOptions) pixelMapper {
+	m := pixelMapper{mean: o.Mean, std: o.Std, scale: o.Scale, offset: o.Offset}
+	if !o.MinMax {
+		return m
+	}
+
+	// Stretch the denormalized range of the selected image to 0-255.
+	m.scale, m.offset = 1, 0
+	lo, hi := float32(math.Inf(1)), float32(math.Inf(-1))
+	for c := 0; c < g.channels; c++ {
+		for y := 0; y < g.height; y++ {
+			for x := 0; x < g.width; x++ {
+				v := m.denormalize(c, data[g.index(c, x, y)])
+				lo = min(lo, v)
+				hi = max(hi, v)
+			}
+		}
+	}
+	if hi > lo {
+		m.scale = 255 / (hi - lo)
+		m.offset = -lo * m.scale
+	} else {
+		m.offset = -lo
+	}
+	return m
+}
+
+func (m pixelMapper) denormalize(c int, v float32) float32 {
+	if len(m.std) != 0 {
+		v *= m.std[c]
+	}
+	if len(m.mean) != 0 {
+		v += m.mean[c]
+	}
+	return v
+}
+
+func (m pixelMapper) pixel(c int, v float32) uint8 {
+	p := m.denormalize(c, v)*m.scale + m.offset
+	if p != p { // NaN
+		return 0
+	}
+	return uint8(math.Round(float64(min(max(p, 0), 255))))
+}
+
+func grayFromFloats(data []float32, shape []int64, o OutputOptions) (*image.Gray, error) {
+	g, err := resolveGeometry(shape, len(data), o)
+	if err != nil {
+		return nil, err
+	}
+	if g.channels != 1 {
+		return nil, fmt.Errorf("grayscale conversion requires 1 channel, got %d", g.channels)
+	}
+
+	m := newPixelMapper(data, g, o)
+	img := image.NewGray(image.Rect(0, 0, g.width, g.height))
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			img.Pix[y*img.Stride+x] = m.pixel(0, data[g.index(0, x, y)])
+		}
+	}
+	return img, nil
+}
+
+func rgbaFromFloats(data []float32, shape []int64, o OutputOptions) (*image.RGBA, error) {
+	g, err := resolveGeometry(shape, len(data), o)
+	if err != nil {
+		return nil, err
+	}
+	if g.channels != 1 && g.channels != 3 && g.channels != 4 {
+		return nil, fmt.Errorf("RGBA conversion requires 1, 3, or 4 channels, got %d", g.channels)
+	}
+
+	m := newPixelMapper(data, g, o)
+	img := image.NewRGBA(image.Rect(0, 0, g.width, g.height))
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			off := y*img.Stride + x*4
+			if g.channels == 1 {
+				p := m.pixel(0, data[g.index(0, x, y)])
+				img.Pix[off], img.Pix[off+1], img.Pix[off+2], img.Pix[off+3] = p, p, p, 255
+				continue
+			}
+			for c := 0; c < 3; c++ {
+				img.Pix[off+c] = m.pixel(c, data[g.index(c, x, y)])
+			}
+			img.Pix[off+3] = 255
+			if g.channels == 4 {
+				// image.RGBA stores alpha-premultiplied color.
+				a := m.pixel(3, data[g.index(3, x, y)])
+				for c := 0; c < 3; c++ {
+					img.Pix[off+c] = uint8(uint16(img.Pix[off+c]) * uint16(a) / 255)
+				}
+				img.Pix[off+3] = a
+			}
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,143 @@
+package imagetensor
+
+import (
+	"image/color"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func TestGrayFromFloats(t *testing.T) {
+	data := []float32{0, 0.5, 1, 2}
+	img, err := grayFromFloats(data, []int64{2, 2}, resolveOptions(nil, 255))
+	if err != nil {
+		t.Fatalf("grayFromFloats failed: %v", err)
+	}
+
+	want := []uint8{0, 128, 255, 255} // 2.0 is clamped
+	for i, w := range want {
+		if img.Pix[i] != w {
+			t.Errorf("pixel %d = %d, want %d", i, img.Pix[i], w)
+		}
+	}
+}
+
+func TestGrayRejectsMultiChannel(t *testing.T) {
+	data := make([]float32, 3*2*2)
+	if _, err := grayFromFloats(data, []int64{1, 3, 2, 2}, resolveOptions(nil, 255)); err == nil {
+		t.Error("Expected error for 3-channel tensor")
+	}
+}
+
+func TestRGBAFromNCHW(t *testing.T) {
+	// 1x3x1x2: R plane, G plane, B plane
+	data := []float32{
+		1, 0, // R
+		0, 1, // G
+		0, 0, // B
+	}
+	img, err := rgbaFromFloats(data, []int64{1, 3, 1, 2}, resolveOptions(nil, 255))
+	if err != nil {
+		t.Fatalf("rgbaFromFloats failed: %v", err)
+	}
+
+	if got := img.RGBAAt(0, 0); got != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("pixel (0,0) = %v, want red", got)
+	}
+	if got := img.RGBAAt(1, 0); got != (color.RGBA{0, 255, 0, 255}) {
+		t.Errorf("pixel (1,0) = %v, want green", got)
+	}
+}
+
+func TestRGBAFromNHWC(t *testing.T) {
+	data := []float32{
+		0, 0, 255, 10, 20, 30,
+	}
+	img, err := rgbaFromFloats(data, []int64{1, 1, 2, 3}, resolveOptions(&OutputOptions{Layout: LayoutNHWC}, 1))
+	if err != nil {
+		t.Fatalf("rgbaFromFloats failed: %v", err)
+	}
+
+	if got := img.RGBAAt(0, 0); got != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("pixel (0,0) = %v, want blue", got)
+	}
+	if got := img.RGBAAt(1, 0); got != (color.RGBA{10, 20, 30, 255}) {
+		t.Errorf("pixel (1,0) = %v, want {10 20 30 255}", got)
+	}
+}
+
+func TestInverseNormalization(t *testing.T) {
+	mean := []float32{0.485, 0.456, 0.406}
+	std := []float32{0.229, 0.224, 0.225}
+
+	// Normalize a known pixel the way preprocessing does, then invert it.
+	pixel := []float32{200, 100, 50}
+	data := make([]float32, 3)
+	for c := range 3 {
+		data[c] = (pixel[c]/255 - mean[c]) / std[c]
+	}
+
+	img, err := rgbaFromFloats(data, []int64{3, 1, 1}, resolveOptions(&OutputOptions{Mean: mean, Std: std}, 255))
+	if err != nil {
+		t.Fatalf("rgbaFromFloats failed: %v", err)
+	}
+	if got := img.RGBAAt(0, 0); got != (color.RGBA{200, 100, 50, 255}) {
+		t.Errorf("pixel = %v, want {200 100 50 255}", got)
+	}
+}
+
+func TestMinMaxStretch(t *testing.T) {
+	data := []float32{-3, -1, 1, 5}
+	img, err := grayFromFloats(data, []int64{1, 1, 2, 2}, resolveOptions(&OutputOptions{MinMax: true}, 255))
+	if err != nil {
+		t.Fatalf("grayFromFloats failed: %v", err)
+	}
+	if img.Pix[0] != 0 || img.Pix[3] != 255 {
+		t.Errorf("Expected range stretched to [0, 255], got %v", img.Pix)
+	}
+}
+
+func TestBatchIndex(t *testing.T) {
+	data := []float32{0, 0, 1, 1}
+	img, err := grayFromFloats(data, []int64{2, 1, 1, 2}, resolveOptions(&OutputOptions{BatchIndex: 1}, 255))
+	if err != nil {
+		t.Fatalf("grayFromFloats failed: %v", err)
+	}
+	if img.Pix[0] != 255 || img.Pix[1] != 255 {
+		t.Errorf("Expected second batch item, got %v", img.Pix)
+	}
+
+	if _, err := grayFromFloats(data, []int64{2, 1, 1, 2}, resolveOptions(&OutputOptions{BatchIndex: 2}, 255)); err == nil {
+		t.Error("Expected error for out-of-range batch index")
+	}
+}
+
+func TestShapeMismatch(t *testing.T) {
+	if _, err := grayFromFloats([]float32{1, 2, 3}, []int64{2, 2}, resolveOptions(nil, 255)); err == nil {
+		t.Error("Expected error for shape/data length mismatch")
+	}
+	if _, err := rgbaFromFloats(make([]float32, 6), []int64{3, 1, 2}, resolveOptions(&OutputOptions{Mean: []float32{0}}, 255)); err == nil {
+		t.Error("Expected error for mean/channel mismatch")
+	}
+}
+
+func TestToGrayFromValue(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	value, err := ort.NewTensorValue(runtime, []uint8{0, 64, 128, 255}, []int64{1, 1, 2, 2})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer value.Close()
+
+	img, err := ToGray(value, nil)
+	if err != nil {
+		t.Fatalf("ToGray failed: %v", err)
+	}
+	if img.Bounds().Dx() != 2 || img.Bounds().Dy() != 2 {
+		t.Fatalf("Unexpected bounds %v", img.Bounds())
+	}
+	if img.GrayAt(1, 1).Y != 255 || img.GrayAt(1, 0).Y != 64 {
+		t.Errorf("Unexpected pixels %v", img.Pix)
+	}
+}
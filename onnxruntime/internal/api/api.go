@@ -53,6 +53,31 @@ type OrtPrepackedWeightsContainer uintptr
 // OrtThreadingOptions is an opaque pointer to ONNX Runtime threading options.
 type OrtThreadingOptions uintptr
 
+// OrtArenaCfg is an opaque pointer to an ONNX Runtime arena allocator configuration.
+type OrtArenaCfg uintptr
+
+// OrtCUDAProviderOptionsV2 is an opaque pointer to CUDA execution provider options.
+type OrtCUDAProviderOptionsV2 uintptr
+
+// OrtModelCompilationOptions is an opaque pointer to model compilation options.
+type OrtModelCompilationOptions uintptr
+
+// OrtCustomOpDomain is an opaque pointer to a custom operator domain.
+type OrtCustomOpDomain uintptr
+
+// OrtKernelContext is an opaque pointer to the context of a custom operator
+// kernel invocation.
+type OrtKernelContext uintptr
+
+// OrtCheckpointState is an opaque pointer to a training checkpoint state.
+type OrtCheckpointState uintptr
+
+// OrtTrainingSession is an opaque pointer to a training session.
+type OrtTrainingSession uintptr
+
+// OrtKeyValuePairs is an opaque pointer to an ONNX Runtime key-value pair collection.
+type OrtKeyValuePairs uintptr
+
 // OrtErrorCode represents error codes returned by the ONNX Runtime C API.
 type OrtErrorCode int32
 
@@ -71,6 +96,15 @@ type OrtAllocatorType int32
 // OrtMemType represents memory types for allocations.
 type OrtMemType int32
 
+// OrtMemoryInfoDeviceType represents the kind of device memory lives on.
+type OrtMemoryInfoDeviceType int32
+
+// OrtSparseFormat represents the storage format of a sparse tensor.
+type OrtSparseFormat int32
+
+// OrtSparseIndicesFormat selects which indices of a sparse tensor to query.
+type OrtSparseIndicesFormat int32
+
 // APIFuncs is an interface for ONNX Runtime C API functions.
 type APIFuncs interface {
 	// Status and error handling
@@ -82,14 +116,32 @@ type APIFuncs interface {
 	// Environment
 	CreateEnv(OrtLoggingLevel, *byte, *OrtEnv) OrtStatus
 	CreateEnvWithGlobalThreadPools(OrtLoggingLevel, *byte, OrtThreadingOptions, *OrtEnv) OrtStatus
+	CreateEnvWithCustomLogger(uintptr, uintptr, OrtLoggingLevel, *byte, *OrtEnv) OrtStatus
 	ReleaseEnv(OrtEnv)
+	CreateAndRegisterAllocator(OrtEnv, OrtMemoryInfo, OrtArenaCfg) OrtStatus
+	UnregisterAllocator(OrtEnv, OrtMemoryInfo) OrtStatus
 
 	// Allocator
 	GetAllocatorWithDefaultOptions(*OrtAllocator) OrtStatus
 	AllocatorFree(OrtAllocator, unsafe.Pointer)
+	CreateAllocator(OrtSession, OrtMemoryInfo, *OrtAllocator) OrtStatus
+	ReleaseAllocator(OrtAllocator)
+	CreateArenaCfgV2(**byte, *uintptr, uintptr, *OrtArenaCfg) OrtStatus
+	ReleaseArenaCfg(OrtArenaCfg)
+	AllocatorGetStats(OrtAllocator, *OrtKeyValuePairs) OrtStatus
+
+	// Key-value pairs
+	GetKeyValuePairs(OrtKeyValuePairs, ***byte, ***byte, *uintptr)
+	ReleaseKeyValuePairs(OrtKeyValuePairs)
 
 	// Memory info
 	CreateCpuMemoryInfo(OrtAllocatorType, OrtMemType, *OrtMemoryInfo) OrtStatus
+	CreateMemoryInfo(*byte, OrtAllocatorType, int32, OrtMemType, *OrtMemoryInfo) OrtStatus
+	MemoryInfoGetName(OrtMemoryInfo, **byte) OrtStatus
+	MemoryInfoGetId(OrtMemoryInfo, *int32) OrtStatus
+	MemoryInfoGetMemType(OrtMemoryInfo, *OrtMemType) OrtStatus
+	MemoryInfoGetType(OrtMemoryInfo, *OrtAllocatorType) OrtStatus
+	MemoryInfoGetDeviceType(OrtMemoryInfo, *OrtMemoryInfoDeviceType)
 	ReleaseMemoryInfo(OrtMemoryInfo)
 
 	// Telemetry
@@ -109,12 +161,24 @@ type APIFuncs interface {
 	DisableMemPattern(OrtSessionOptions) OrtStatus
 	SetSessionLogSeverityLevel(OrtSessionOptions, int32) OrtStatus
 	AddSessionConfigEntry(OrtSessionOptions, *byte, *byte) OrtStatus
+	AddFreeDimensionOverride(OrtSessionOptions, *byte, int64) OrtStatus
 	AddFreeDimensionOverrideByName(OrtSessionOptions, *byte, int64) OrtStatus
+	AddExternalInitializers(OrtSessionOptions, **byte, *OrtValue, uintptr) OrtStatus
+	AddExternalInitializersFromFilesInMemory(OrtSessionOptions, **byte, **byte, *uintptr, uintptr) OrtStatus
+	CreateCustomOpDomain(*byte, *OrtCustomOpDomain) OrtStatus
+	CustomOpDomainAdd(OrtCustomOpDomain, unsafe.Pointer) OrtStatus
+	AddCustomOpDomain(OrtSessionOptions, OrtCustomOpDomain) OrtStatus
+	ReleaseCustomOpDomain(OrtCustomOpDomain)
 	SetDeterministicCompute(OrtSessionOptions, int32) OrtStatus
 	DisablePerSessionThreads(OrtSessionOptions) OrtStatus
 	EnableProfiling(OrtSessionOptions, *byte) OrtStatus
 	DisableProfiling(OrtSessionOptions) OrtStatus
 	SessionOptionsAppendExecutionProvider(OrtSessionOptions, *byte, **byte, **byte, uintptr) OrtStatus
+	SessionOptionsAppendExecutionProviderCUDAV2(OrtSessionOptions, OrtCUDAProviderOptionsV2) OrtStatus
+	CreateCUDAProviderOptions(*OrtCUDAProviderOptionsV2) OrtStatus
+	UpdateCUDAProviderOptions(OrtCUDAProviderOptionsV2, **byte, **byte, uintptr) OrtStatus
+	UpdateCUDAProviderOptionsWithValue(OrtCUDAProviderOptionsV2, *byte, uintptr) OrtStatus
+	ReleaseCUDAProviderOptions(OrtCUDAProviderOptionsV2)
 	ReleaseSessionOptions(OrtSessionOptions)
 
 	// Run options
@@ -134,7 +198,10 @@ type APIFuncs interface {
 	SessionGetInputName(OrtSession, uintptr, OrtAllocator, **byte) OrtStatus
 	SessionGetOutputName(OrtSession, uintptr, OrtAllocator, **byte) OrtStatus
 	Run(OrtSession, OrtRunOptions, **byte, *OrtValue, uintptr, **byte, uintptr, *OrtValue) OrtStatus
+	RunAsync(OrtSession, OrtRunOptions, **byte, *OrtValue, uintptr, **byte, uintptr, *OrtValue, uintptr, uintptr) OrtStatus
 	ReleaseSession(OrtSession)
+	SessionGetMemoryInfoForInputs(OrtSession, *OrtMemoryInfo, uintptr) OrtStatus
+	SessionGetMemoryInfoForOutputs(OrtSession, *OrtMemoryInfo, uintptr) OrtStatus
 
 	// Profiling
 	SessionEndProfiling(OrtSession, OrtAllocator, **byte) OrtStatus
@@ -180,6 +247,10 @@ type APIFuncs interface {
 	GetDimensions(OrtTensorTypeAndShapeInfo, *int64, uintptr) OrtStatus
 	GetTensorShapeElementCount(OrtTensorTypeAndShapeInfo, *uintptr) OrtStatus
 	ReleaseValue(OrtValue)
+	KernelContextGetInputCount(OrtKernelContext, *uintptr) OrtStatus
+	KernelContextGetOutputCount(OrtKernelContext, *uintptr) OrtStatus
+	KernelContextGetInput(OrtKernelContext, uintptr, *OrtValue) OrtStatus
+	KernelContextGetOutput(OrtKernelContext, uintptr, *int64, uintptr, *OrtValue) OrtStatus
 	ReleaseTensorTypeAndShapeInfo(OrtTensorTypeAndShapeInfo)
 
 	// String tensor operations
@@ -230,4 +301,54 @@ type APIFuncs interface {
 	SetGlobalIntraOpNumThreads(OrtThreadingOptions, int32) OrtStatus
 	SetGlobalInterOpNumThreads(OrtThreadingOptions, int32) OrtStatus
 	SetGlobalSpinControl(OrtThreadingOptions, int32) OrtStatus
+	SetGlobalDenormalAsZero(OrtThreadingOptions) OrtStatus
+	SetGlobalIntraOpThreadAffinity(OrtThreadingOptions, *byte) OrtStatus
+	SetGlobalCustomCreateThreadFn(OrtThreadingOptions, uintptr) OrtStatus
+	SetGlobalCustomThreadCreationOptions(OrtThreadingOptions, uintptr) OrtStatus
+	SetGlobalCustomJoinThreadFn(OrtThreadingOptions, uintptr) OrtStatus
+
+	// Sparse tensors
+	IsSparseTensor(OrtValue, *int32) OrtStatus
+	CreateSparseTensorWithValuesAsOrtValue(OrtMemoryInfo, unsafe.Pointer, *int64, uintptr, *int64, uintptr, ONNXTensorElementDataType, *OrtValue) OrtStatus
+	UseCooIndices(OrtValue, *int64, uintptr) OrtStatus
+	UseCsrIndices(OrtValue, *int64, uintptr, *int64, uintptr) OrtStatus
+	GetSparseTensorFormat(OrtValue, *OrtSparseFormat) OrtStatus
+	GetSparseTensorValuesTypeAndShape(OrtValue, *OrtTensorTypeAndShapeInfo) OrtStatus
+	GetSparseTensorValues(OrtValue, *unsafe.Pointer) OrtStatus
+	GetSparseTensorIndicesTypeShape(OrtValue, OrtSparseIndicesFormat, *OrtTensorTypeAndShapeInfo) OrtStatus
+	GetSparseTensorIndices(OrtValue, OrtSparseIndicesFormat, *uintptr, *unsafe.Pointer) OrtStatus
+
+	// Compile API (ORT 1.22+)
+	HasCompileAPI() bool
+	CreateModelCompilationOptionsFromSessionOptions(OrtEnv, OrtSessionOptions, *OrtModelCompilationOptions) OrtStatus
+	ReleaseModelCompilationOptions(OrtModelCompilationOptions)
+	ModelCompilationOptionsSetInputModelPath(OrtModelCompilationOptions, *byte) OrtStatus
+	ModelCompilationOptionsSetInputModelFromBuffer(OrtModelCompilationOptions, unsafe.Pointer, uintptr) OrtStatus
+	ModelCompilationOptionsSetOutputModelPath(OrtModelCompilationOptions, *byte) OrtStatus
+	ModelCompilationOptionsSetOutputModelExternalInitializersFile(OrtModelCompilationOptions, *byte, uintptr) OrtStatus
+	ModelCompilationOptionsSetEpContextEmbedMode(OrtModelCompilationOptions, bool) OrtStatus
+	CompileModel(OrtEnv, OrtModelCompilationOptions) OrtStatus
+
+	// Training API (training builds only)
+	HasTrainingAPI() bool
+	LoadCheckpoint(*byte, *OrtCheckpointState) OrtStatus
+	SaveCheckpoint(OrtCheckpointState, *byte, bool) OrtStatus
+	ReleaseCheckpointState(OrtCheckpointState)
+	CreateTrainingSession(OrtEnv, OrtSessionOptions, OrtCheckpointState, *byte, *byte, *byte, *OrtTrainingSession) OrtStatus
+	ReleaseTrainingSession(OrtTrainingSession)
+	TrainingSessionGetTrainingModelInputCount(OrtTrainingSession, *uintptr) OrtStatus
+	TrainingSessionGetTrainingModelInputName(OrtTrainingSession, uintptr, OrtAllocator, **byte) OrtStatus
+	TrainingSessionGetEvalModelInputCount(OrtTrainingSession, *uintptr) OrtStatus
+	TrainingSessionGetEvalModelInputName(OrtTrainingSession, uintptr, OrtAllocator, **byte) OrtStatus
+	TrainingSessionGetTrainingModelOutputCount(OrtTrainingSession, *uintptr) OrtStatus
+	TrainingSessionGetTrainingModelOutputName(OrtTrainingSession, uintptr, OrtAllocator, **byte) OrtStatus
+	TrainingSessionGetEvalModelOutputCount(OrtTrainingSession, *uintptr) OrtStatus
+	TrainingSessionGetEvalModelOutputName(OrtTrainingSession, uintptr, OrtAllocator, **byte) OrtStatus
+	LazyResetGrad(OrtTrainingSession) OrtStatus
+	TrainStep(OrtTrainingSession, OrtRunOptions, uintptr, *OrtValue, uintptr, *OrtValue) OrtStatus
+	EvalStep(OrtTrainingSession, OrtRunOptions, uintptr, *OrtValue, uintptr, *OrtValue) OrtStatus
+	SetLearningRate(OrtTrainingSession, float32) OrtStatus
+	GetLearningRate(OrtTrainingSession, *float32) OrtStatus
+	OptimizerStep(OrtTrainingSession, OrtRunOptions) OrtStatus
+	ExportModelForInferencing(OrtTrainingSession, *byte, uintptr, **byte) OrtStatus
 }
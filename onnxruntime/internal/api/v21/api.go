@@ -0,0 +1,304 @@
+// Code generated by tools/codegen. DO NOT EDIT.
+// Source: https://raw.githubusercontent.com/microsoft/onnxruntime/v1.21.0/include/onnxruntime/core/session/onnxruntime_c_api.h
+package v21
+
+// APIVersion is the ONNX Runtime C API version (21).
+const APIVersion = 21
+
+// APIBase is the entry point structure for accessing the ONNX Runtime C API.
+// It contains function pointers for obtaining the versioned API structure.
+type APIBase struct {
+	GetAPI           uintptr // func(version uint32) *API
+	GetVersionString uintptr // func() *byte
+}
+
+// API contains function pointers to the ONNX Runtime C API (version 21).
+// Field order MUST match the actual OrtApi structure from onnxruntime_c_api.h
+// https://raw.githubusercontent.com/microsoft/onnxruntime/v1.21.0/include/onnxruntime/core/session/onnxruntime_c_api.h
+type API struct {
+	CreateStatus                                        uintptr // 0
+	GetErrorCode                                        uintptr // 1
+	GetErrorMessage                                     uintptr // 2
+	CreateEnv                                           uintptr // 3
+	CreateEnvWithCustomLogger                           uintptr // 4
+	EnableTelemetryEvents                               uintptr // 5
+	DisableTelemetryEvents                              uintptr // 6
+	CreateSession                                       uintptr // 7
+	CreateSessionFromArray                              uintptr // 8
+	Run                                                 uintptr // 9
+	CreateSessionOptions                                uintptr // 10
+	SetOptimizedModelFilePath                           uintptr // 11
+	CloneSessionOptions                                 uintptr // 12
+	SetSessionExecutionMode                             uintptr // 13
+	EnableProfiling                                     uintptr // 14
+	DisableProfiling                                    uintptr // 15
+	EnableMemPattern                                    uintptr // 16
+	DisableMemPattern                                   uintptr // 17
+	EnableCpuMemArena                                   uintptr // 18
+	DisableCpuMemArena                                  uintptr // 19
+	SetSessionLogId                                     uintptr // 20
+	SetSessionLogVerbosityLevel                         uintptr // 21
+	SetSessionLogSeverityLevel                          uintptr // 22
+	SetSessionGraphOptimizationLevel                    uintptr // 23
+	SetIntraOpNumThreads                                uintptr // 24
+	SetInterOpNumThreads                                uintptr // 25
+	CreateCustomOpDomain                                uintptr // 26
+	CustomOpDomain_Add                                  uintptr // 27
+	AddCustomOpDomain                                   uintptr // 28
+	RegisterCustomOpsLibrary                            uintptr // 29
+	SessionGetInputCount                                uintptr // 30
+	SessionGetOutputCount                               uintptr // 31
+	SessionGetOverridableInitializerCount               uintptr // 32
+	SessionGetInputTypeInfo                             uintptr // 33
+	SessionGetOutputTypeInfo                            uintptr // 34
+	SessionGetOverridableInitializerTypeInfo            uintptr // 35
+	SessionGetInputName                                 uintptr // 36
+	SessionGetOutputName                                uintptr // 37
+	SessionGetOverridableInitializerName                uintptr // 38
+	CreateRunOptions                                    uintptr // 39
+	RunOptionsSetRunLogVerbosityLevel                   uintptr // 40
+	RunOptionsSetRunLogSeverityLevel                    uintptr // 41
+	RunOptionsSetRunTag                                 uintptr // 42
+	RunOptionsGetRunLogVerbosityLevel                   uintptr // 43
+	RunOptionsGetRunLogSeverityLevel                    uintptr // 44
+	RunOptionsGetRunTag                                 uintptr // 45
+	RunOptionsSetTerminate                              uintptr // 46
+	RunOptionsUnsetTerminate                            uintptr // 47
+	CreateTensorAsOrtValue                              uintptr // 48
+	CreateTensorWithDataAsOrtValue                      uintptr // 49
+	IsTensor                                            uintptr // 50
+	GetTensorMutableData                                uintptr // 51
+	FillStringTensor                                    uintptr // 52
+	GetStringTensorDataLength                           uintptr // 53
+	GetStringTensorContent                              uintptr // 54
+	CastTypeInfoToTensorInfo                            uintptr // 55
+	GetOnnxTypeFromTypeInfo                             uintptr // 56
+	CreateTensorTypeAndShapeInfo                        uintptr // 57
+	SetTensorElementType                                uintptr // 58
+	SetDimensions                                       uintptr // 59
+	GetTensorElementType                                uintptr // 60
+	GetDimensionsCount                                  uintptr // 61
+	GetDimensions                                       uintptr // 62
+	GetSymbolicDimensions                               uintptr // 63
+	GetTensorShapeElementCount                          uintptr // 64
+	GetTensorTypeAndShape                               uintptr // 65
+	GetTypeInfo                                         uintptr // 66
+	GetValueType                                        uintptr // 67
+	CreateMemoryInfo                                    uintptr // 68
+	CreateCpuMemoryInfo                                 uintptr // 69
+	CompareMemoryInfo                                   uintptr // 70
+	MemoryInfoGetName                                   uintptr // 71
+	MemoryInfoGetId                                     uintptr // 72
+	MemoryInfoGetMemType                                uintptr // 73
+	MemoryInfoGetType                                   uintptr // 74
+	AllocatorAlloc                                      uintptr // 75
+	AllocatorFree                                       uintptr // 76
+	AllocatorGetInfo                                    uintptr // 77
+	GetAllocatorWithDefaultOptions                      uintptr // 78
+	AddFreeDimensionOverride                            uintptr // 79
+	GetValue                                            uintptr // 80
+	GetValueCount                                       uintptr // 81
+	CreateValue                                         uintptr // 82
+	CreateOpaqueValue                                   uintptr // 83
+	GetOpaqueValue                                      uintptr // 84
+	KernelInfoGetAttribute_float                        uintptr // 85
+	KernelInfoGetAttribute_int64                        uintptr // 86
+	KernelInfoGetAttribute_string                       uintptr // 87
+	KernelContext_GetInputCount                         uintptr // 88
+	KernelContext_GetOutputCount                        uintptr // 89
+	KernelContext_GetInput                              uintptr // 90
+	KernelContext_GetOutput                             uintptr // 91
+	ReleaseEnv                                          uintptr // 92
+	ReleaseStatus                                       uintptr // 93
+	ReleaseMemoryInfo                                   uintptr // 94
+	ReleaseSession                                      uintptr // 95
+	ReleaseValue                                        uintptr // 96
+	ReleaseRunOptions                                   uintptr // 97
+	ReleaseTypeInfo                                     uintptr // 98
+	ReleaseTensorTypeAndShapeInfo                       uintptr // 99
+	ReleaseSessionOptions                               uintptr // 100
+	ReleaseCustomOpDomain                               uintptr // 101
+	GetDenotationFromTypeInfo                           uintptr // 102
+	CastTypeInfoToMapTypeInfo                           uintptr // 103
+	CastTypeInfoToSequenceTypeInfo                      uintptr // 104
+	GetMapKeyType                                       uintptr // 105
+	GetMapValueType                                     uintptr // 106
+	GetSequenceElementType                              uintptr // 107
+	ReleaseMapTypeInfo                                  uintptr // 108
+	ReleaseSequenceTypeInfo                             uintptr // 109
+	SessionEndProfiling                                 uintptr // 110
+	SessionGetModelMetadata                             uintptr // 111
+	ModelMetadataGetProducerName                        uintptr // 112
+	ModelMetadataGetGraphName                           uintptr // 113
+	ModelMetadataGetDomain                              uintptr // 114
+	ModelMetadataGetDescription                         uintptr // 115
+	ModelMetadataLookupCustomMetadataMap                uintptr // 116
+	ModelMetadataGetVersion                             uintptr // 117
+	ReleaseModelMetadata                                uintptr // 118
+	CreateEnvWithGlobalThreadPools                      uintptr // 119
+	DisablePerSessionThreads                            uintptr // 120
+	CreateThreadingOptions                              uintptr // 121
+	ReleaseThreadingOptions                             uintptr // 122
+	ModelMetadataGetCustomMetadataMapKeys               uintptr // 123
+	AddFreeDimensionOverrideByName                      uintptr // 124
+	GetAvailableProviders                               uintptr // 125
+	ReleaseAvailableProviders                           uintptr // 126
+	GetStringTensorElementLength                        uintptr // 127
+	GetStringTensorElement                              uintptr // 128
+	FillStringTensorElement                             uintptr // 129
+	AddSessionConfigEntry                               uintptr // 130
+	CreateAllocator                                     uintptr // 131
+	ReleaseAllocator                                    uintptr // 132
+	RunWithBinding                                      uintptr // 133
+	CreateIoBinding                                     uintptr // 134
+	ReleaseIoBinding                                    uintptr // 135
+	BindInput                                           uintptr // 136
+	BindOutput                                          uintptr // 137
+	BindOutputToDevice                                  uintptr // 138
+	GetBoundOutputNames                                 uintptr // 139
+	GetBoundOutputValues                                uintptr // 140
+	ClearBoundInputs                                    uintptr // 141
+	ClearBoundOutputs                                   uintptr // 142
+	TensorAt                                            uintptr // 143
+	CreateAndRegisterAllocator                          uintptr // 144
+	SetLanguageProjection                               uintptr // 145
+	SessionGetProfilingStartTimeNs                      uintptr // 146
+	SetGlobalIntraOpNumThreads                          uintptr // 147
+	SetGlobalInterOpNumThreads                          uintptr // 148
+	SetGlobalSpinControl                                uintptr // 149
+	AddInitializer                                      uintptr // 150
+	CreateEnvWithCustomLoggerAndGlobalThreadPools       uintptr // 151
+	SessionOptionsAppendExecutionProvider_CUDA          uintptr // 152
+	SessionOptionsAppendExecutionProvider_ROCM          uintptr // 153
+	SessionOptionsAppendExecutionProvider_OpenVINO      uintptr // 154
+	SetGlobalDenormalAsZero                             uintptr // 155
+	CreateArenaCfg                                      uintptr // 156
+	ReleaseArenaCfg                                     uintptr // 157
+	ModelMetadataGetGraphDescription                    uintptr // 158
+	SessionOptionsAppendExecutionProvider_TensorRT      uintptr // 159
+	SetCurrentGpuDeviceId                               uintptr // 160
+	GetCurrentGpuDeviceId                               uintptr // 161
+	KernelInfoGetAttributeArray_float                   uintptr // 162
+	KernelInfoGetAttributeArray_int64                   uintptr // 163
+	CreateArenaCfgV2                                    uintptr // 164
+	AddRunConfigEntry                                   uintptr // 165
+	CreatePrepackedWeightsContainer                     uintptr // 166
+	ReleasePrepackedWeightsContainer                    uintptr // 167
+	CreateSessionWithPrepackedWeightsContainer          uintptr // 168
+	CreateSessionFromArrayWithPrepackedWeightsContainer uintptr // 169
+	SessionOptionsAppendExecutionProvider_TensorRT_V2   uintptr // 170
+	CreateTensorRTProviderOptions                       uintptr // 171
+	UpdateTensorRTProviderOptions                       uintptr // 172
+	GetTensorRTProviderOptionsAsString                  uintptr // 173
+	ReleaseTensorRTProviderOptions                      uintptr // 174
+	EnableOrtCustomOps                                  uintptr // 175
+	RegisterAllocator                                   uintptr // 176
+	UnregisterAllocator                                 uintptr // 177
+	IsSparseTensor                                      uintptr // 178
+	CreateSparseTensorAsOrtValue                        uintptr // 179
+	FillSparseTensorCoo                                 uintptr // 180
+	FillSparseTensorCsr                                 uintptr // 181
+	FillSparseTensorBlockSparse                         uintptr // 182
+	CreateSparseTensorWithValuesAsOrtValue              uintptr // 183
+	UseCooIndices                                       uintptr // 184
+	UseCsrIndices                                       uintptr // 185
+	UseBlockSparseIndices                               uintptr // 186
+	GetSparseTensorFormat                               uintptr // 187
+	GetSparseTensorValuesTypeAndShape                   uintptr // 188
+	GetSparseTensorValues                               uintptr // 189
+	GetSparseTensorIndicesTypeShape                     uintptr // 190
+	GetSparseTensorIndices                              uintptr // 191
+	HasValue                                            uintptr // 192
+	KernelContext_GetGPUComputeStream                   uintptr // 193
+	GetTensorMemoryInfo                                 uintptr // 194
+	GetExecutionProviderApi                             uintptr // 195
+	SessionOptionsSetCustomCreateThreadFn               uintptr // 196
+	SessionOptionsSetCustomThreadCreationOptions        uintptr // 197
+	SessionOptionsSetCustomJoinThreadFn                 uintptr // 198
+	SetGlobalCustomCreateThreadFn                       uintptr // 199
+	SetGlobalCustomThreadCreationOptions                uintptr // 200
+	SetGlobalCustomJoinThreadFn                         uintptr // 201
+	SynchronizeBoundInputs                              uintptr // 202
+	SynchronizeBoundOutputs                             uintptr // 203
+	SessionOptionsAppendExecutionProvider_CUDA_V2       uintptr // 204
+	CreateCUDAProviderOptions                           uintptr // 205
+	UpdateCUDAProviderOptions                           uintptr // 206
+	GetCUDAProviderOptionsAsString                      uintptr // 207
+	ReleaseCUDAProviderOptions                          uintptr // 208
+	SessionOptionsAppendExecutionProvider_MIGraphX      uintptr // 209
+	AddExternalInitializers                             uintptr // 210
+	CreateOpAttr                                        uintptr // 211
+	ReleaseOpAttr                                       uintptr // 212
+	CreateOp                                            uintptr // 213
+	InvokeOp                                            uintptr // 214
+	ReleaseOp                                           uintptr // 215
+	SessionOptionsAppendExecutionProvider               uintptr // 216
+	CopyKernelInfo                                      uintptr // 217
+	ReleaseKernelInfo                                   uintptr // 218
+	GetTrainingApi                                      uintptr // 219
+	SessionOptionsAppendExecutionProvider_CANN          uintptr // 220
+	CreateCANNProviderOptions                           uintptr // 221
+	UpdateCANNProviderOptions                           uintptr // 222
+	GetCANNProviderOptionsAsString                      uintptr // 223
+	ReleaseCANNProviderOptions                          uintptr // 224
+	MemoryInfoGetDeviceType                             uintptr // 225
+	UpdateEnvWithCustomLogLevel                         uintptr // 226
+	SetGlobalIntraOpThreadAffinity                      uintptr // 227
+	RegisterCustomOpsLibrary_V2                         uintptr // 228
+	RegisterCustomOpsUsingFunction                      uintptr // 229
+	KernelInfo_GetInputCount                            uintptr // 230
+	KernelInfo_GetOutputCount                           uintptr // 231
+	KernelInfo_GetInputName                             uintptr // 232
+	KernelInfo_GetOutputName                            uintptr // 233
+	KernelInfo_GetInputTypeInfo                         uintptr // 234
+	KernelInfo_GetOutputTypeInfo                        uintptr // 235
+	KernelInfoGetAttribute_tensor                       uintptr // 236
+	HasSessionConfigEntry                               uintptr // 237
+	GetSessionConfigEntry                               uintptr // 238
+	SessionOptionsAppendExecutionProvider_Dnnl          uintptr // 239
+	CreateDnnlProviderOptions                           uintptr // 240
+	UpdateDnnlProviderOptions                           uintptr // 241
+	GetDnnlProviderOptionsAsString                      uintptr // 242
+	ReleaseDnnlProviderOptions                          uintptr // 243
+	KernelInfo_GetNodeName                              uintptr // 244
+	KernelInfo_GetLogger                                uintptr // 245
+	KernelContext_GetLogger                             uintptr // 246
+	Logger_LogMessage                                   uintptr // 247
+	Logger_GetLoggingSeverityLevel                      uintptr // 248
+	KernelInfoGetConstantInput_tensor                   uintptr // 249
+	CastTypeInfoToOptionalTypeInfo                      uintptr // 250
+	GetOptionalContainedTypeInfo                        uintptr // 251
+	GetResizedStringTensorElementBuffer                 uintptr // 252
+	KernelContext_GetAllocator                          uintptr // 253
+	GetBuildInfoString                                  uintptr // 254
+	CreateROCMProviderOptions                           uintptr // 255
+	UpdateROCMProviderOptions                           uintptr // 256
+	GetROCMProviderOptionsAsString                      uintptr // 257
+	ReleaseROCMProviderOptions                          uintptr // 258
+	CreateAndRegisterAllocatorV2                        uintptr // 259
+	RunAsync                                            uintptr // 260
+	UpdateTensorRTProviderOptionsWithValue              uintptr // 261
+	GetTensorRTProviderOptionsByName                    uintptr // 262
+	UpdateCUDAProviderOptionsWithValue                  uintptr // 263
+	GetCUDAProviderOptionsByName                        uintptr // 264
+	KernelContext_GetResource                           uintptr // 265
+	SetUserLoggingFunction                              uintptr // 266
+	ShapeInferContext_GetInputCount                     uintptr // 267
+	ShapeInferContext_GetInputTypeShape                 uintptr // 268
+	ShapeInferContext_GetAttribute                      uintptr // 269
+	ShapeInferContext_SetOutputTypeShape                uintptr // 270
+	SetSymbolicDimensions                               uintptr // 271
+	ReadOpAttr                                          uintptr // 272
+	SetDeterministicCompute                             uintptr // 273
+	KernelContext_ParallelFor                           uintptr // 274
+	SessionOptionsAppendExecutionProvider_OpenVINO_V2   uintptr // 275
+	SessionOptionsAppendExecutionProvider_VitisAI       uintptr // 276
+	KernelContext_GetScratchBuffer                      uintptr // 277
+	KernelInfoGetAllocator                              uintptr // 278
+	AddExternalInitializersFromFilesInMemory            uintptr // 279
+	CreateLoraAdapter                                   uintptr // 280
+	CreateLoraAdapterFromArray                          uintptr // 281
+	ReleaseLoraAdapter                                  uintptr // 282
+	RunOptionsAddActiveLoraAdapter                      uintptr // 283
+	SetEpDynamicOptions                                 uintptr // 284
+}
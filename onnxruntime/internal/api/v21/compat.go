@@ -0,0 +1,19 @@
+package v21
+
+import (
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// Methods whose version 21 fallback differs from the generated one.
+
+// GetKeyValuePairs was added in API version 22. No version 21 function
+// returns key-value pairs, so there is never anything to read.
+func (f *Funcs) GetKeyValuePairs(kvps api.OrtKeyValuePairs, keys ***byte, values ***byte, numEntries *uintptr) {
+	*numEntries = 0
+}
+
+// HasCompileAPI reports whether the library provides the compile API, which
+// API version 21 does not.
+func (f *Funcs) HasCompileAPI() bool {
+	return false
+}
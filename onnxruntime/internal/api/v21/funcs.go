@@ -0,0 +1,1316 @@
+// Code generated by tools/codegen/funcs from v24/funcs.go. DO NOT EDIT.
+
+package v21
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	"github.com/ebitengine/purego"
+)
+
+// Funcs contains cached function pointers to ONNX Runtime C API functions.
+type Funcs struct {
+	// Status and error handling
+	createStatus    func(api.OrtErrorCode, *byte) api.OrtStatus
+	getErrorCode    func(api.OrtStatus) api.OrtErrorCode
+	getErrorMessage func(api.OrtStatus) unsafe.Pointer
+	releaseStatus   func(api.OrtStatus)
+
+	// Environment
+	createEnv                      func(api.OrtLoggingLevel, *byte, *api.OrtEnv) api.OrtStatus
+	createEnvWithGlobalThreadPools func(api.OrtLoggingLevel, *byte, api.OrtThreadingOptions, *api.OrtEnv) api.OrtStatus
+	createEnvWithCustomLogger      func(uintptr, uintptr, api.OrtLoggingLevel, *byte, *api.OrtEnv) api.OrtStatus
+	releaseEnv                     func(api.OrtEnv)
+	createAndRegisterAllocator     func(api.OrtEnv, api.OrtMemoryInfo, api.OrtArenaCfg) api.OrtStatus
+	unregisterAllocator            func(api.OrtEnv, api.OrtMemoryInfo) api.OrtStatus
+
+	// Allocator
+	getAllocatorWithDefaultOptions func(*api.OrtAllocator) api.OrtStatus
+	allocatorFree                  func(api.OrtAllocator, unsafe.Pointer)
+	createAllocator                func(api.OrtSession, api.OrtMemoryInfo, *api.OrtAllocator) api.OrtStatus
+	releaseAllocator               func(api.OrtAllocator)
+	createArenaCfgV2               func(**byte, *uintptr, uintptr, *api.OrtArenaCfg) api.OrtStatus
+	releaseArenaCfg                func(api.OrtArenaCfg)
+
+	// Memory info
+	createCpuMemoryInfo     func(api.OrtAllocatorType, api.OrtMemType, *api.OrtMemoryInfo) api.OrtStatus
+	createMemoryInfo        func(*byte, api.OrtAllocatorType, int32, api.OrtMemType, *api.OrtMemoryInfo) api.OrtStatus
+	memoryInfoGetName       func(api.OrtMemoryInfo, **byte) api.OrtStatus
+	memoryInfoGetId         func(api.OrtMemoryInfo, *int32) api.OrtStatus
+	memoryInfoGetMemType    func(api.OrtMemoryInfo, *api.OrtMemType) api.OrtStatus
+	memoryInfoGetType       func(api.OrtMemoryInfo, *api.OrtAllocatorType) api.OrtStatus
+	memoryInfoGetDeviceType func(api.OrtMemoryInfo, *api.OrtMemoryInfoDeviceType)
+	releaseMemoryInfo       func(api.OrtMemoryInfo)
+
+	// Telemetry
+	enableTelemetryEvents  func(api.OrtEnv) api.OrtStatus
+	disableTelemetryEvents func(api.OrtEnv) api.OrtStatus
+
+	// Session options
+	createSessionOptions                        func(*api.OrtSessionOptions) api.OrtStatus
+	setOptimizedModelFilePath                   func(api.OrtSessionOptions, *byte) api.OrtStatus
+	setIntraOpNumThreads                        func(api.OrtSessionOptions, int32) api.OrtStatus
+	setInterOpNumThreads                        func(api.OrtSessionOptions, int32) api.OrtStatus
+	setSessionExecutionMode                     func(api.OrtSessionOptions, int32) api.OrtStatus
+	setSessionGraphOptimizationLevel            func(api.OrtSessionOptions, int32) api.OrtStatus
+	enableCpuMemArena                           func(api.OrtSessionOptions) api.OrtStatus
+	disableCpuMemArena                          func(api.OrtSessionOptions) api.OrtStatus
+	enableMemPattern                            func(api.OrtSessionOptions) api.OrtStatus
+	disableMemPattern                           func(api.OrtSessionOptions) api.OrtStatus
+	setSessionLogSeverityLevel                  func(api.OrtSessionOptions, int32) api.OrtStatus
+	addSessionConfigEntry                       func(api.OrtSessionOptions, *byte, *byte) api.OrtStatus
+	addFreeDimensionOverride                    func(api.OrtSessionOptions, *byte, int64) api.OrtStatus
+	addFreeDimensionOverrideByName              func(api.OrtSessionOptions, *byte, int64) api.OrtStatus
+	addExternalInitializers                     func(api.OrtSessionOptions, **byte, *api.OrtValue, uintptr) api.OrtStatus
+	addExternalInitializersFromFilesInMemory    func(api.OrtSessionOptions, **byte, **byte, *uintptr, uintptr) api.OrtStatus
+	createCustomOpDomain                        func(*byte, *api.OrtCustomOpDomain) api.OrtStatus
+	customOpDomainAdd                           func(api.OrtCustomOpDomain, unsafe.Pointer) api.OrtStatus
+	addCustomOpDomain                           func(api.OrtSessionOptions, api.OrtCustomOpDomain) api.OrtStatus
+	releaseCustomOpDomain                       func(api.OrtCustomOpDomain)
+	setDeterministicCompute                     func(api.OrtSessionOptions, int32) api.OrtStatus
+	disablePerSessionThreads                    func(api.OrtSessionOptions) api.OrtStatus
+	enableProfiling                             func(api.OrtSessionOptions, *byte) api.OrtStatus
+	disableProfiling                            func(api.OrtSessionOptions) api.OrtStatus
+	sessionOptionsAppendExecutionProvider       func(api.OrtSessionOptions, *byte, **byte, **byte, uintptr) api.OrtStatus
+	sessionOptionsAppendExecutionProviderCUDAV2 func(api.OrtSessionOptions, api.OrtCUDAProviderOptionsV2) api.OrtStatus
+	createCUDAProviderOptions                   func(*api.OrtCUDAProviderOptionsV2) api.OrtStatus
+	updateCUDAProviderOptions                   func(api.OrtCUDAProviderOptionsV2, **byte, **byte, uintptr) api.OrtStatus
+	updateCUDAProviderOptionsWithValue          func(api.OrtCUDAProviderOptionsV2, *byte, uintptr) api.OrtStatus
+	releaseCUDAProviderOptions                  func(api.OrtCUDAProviderOptionsV2)
+	releaseSessionOptions                       func(api.OrtSessionOptions)
+
+	// Run options
+	createRunOptions               func(*api.OrtRunOptions) api.OrtStatus
+	releaseRunOptions              func(api.OrtRunOptions)
+	runOptionsSetTerminate         func(api.OrtRunOptions) api.OrtStatus
+	runOptionsUnsetTerminate       func(api.OrtRunOptions) api.OrtStatus
+	runOptionsSetRunTag            func(api.OrtRunOptions, *byte) api.OrtStatus
+	addRunConfigEntry              func(api.OrtRunOptions, *byte, *byte) api.OrtStatus
+	runOptionsAddActiveLoraAdapter func(api.OrtRunOptions, api.OrtLoraAdapter) api.OrtStatus
+
+	// Session
+	createSession          func(api.OrtEnv, *byte, api.OrtSessionOptions, *api.OrtSession) api.OrtStatus
+	createSessionFromArray func(api.OrtEnv, unsafe.Pointer, uintptr, api.OrtSessionOptions, *api.OrtSession) api.OrtStatus
+	sessionGetInputCount   func(api.OrtSession, *uintptr) api.OrtStatus
+	sessionGetOutputCount  func(api.OrtSession, *uintptr) api.OrtStatus
+	sessionGetInputName    func(api.OrtSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	sessionGetOutputName   func(api.OrtSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	run                    func(api.OrtSession, api.OrtRunOptions, **byte, *api.OrtValue, uintptr, **byte, uintptr, *api.OrtValue) api.OrtStatus
+	runAsync               func(api.OrtSession, api.OrtRunOptions, **byte, *api.OrtValue, uintptr, **byte, uintptr, *api.OrtValue, uintptr, uintptr) api.OrtStatus
+	releaseSession         func(api.OrtSession)
+
+	// Profiling
+	sessionEndProfiling            func(api.OrtSession, api.OrtAllocator, **byte) api.OrtStatus
+	sessionGetProfilingStartTimeNs func(api.OrtSession, *uint64) api.OrtStatus
+
+	// LoRA adapters
+	createLoraAdapter          func(*byte, api.OrtAllocator, *api.OrtLoraAdapter) api.OrtStatus
+	createLoraAdapterFromArray func(unsafe.Pointer, uintptr, api.OrtAllocator, *api.OrtLoraAdapter) api.OrtStatus
+	releaseLoraAdapter         func(api.OrtLoraAdapter)
+
+	// Build info
+	getBuildInfoString func() unsafe.Pointer
+
+	// Model metadata
+	sessionGetModelMetadata               func(api.OrtSession, *api.OrtModelMetadata) api.OrtStatus
+	modelMetadataGetProducerName          func(api.OrtModelMetadata, api.OrtAllocator, **byte) api.OrtStatus
+	modelMetadataGetGraphName             func(api.OrtModelMetadata, api.OrtAllocator, **byte) api.OrtStatus
+	modelMetadataGetDomain                func(api.OrtModelMetadata, api.OrtAllocator, **byte) api.OrtStatus
+	modelMetadataGetDescription           func(api.OrtModelMetadata, api.OrtAllocator, **byte) api.OrtStatus
+	modelMetadataLookupCustomMetadataMap  func(api.OrtModelMetadata, api.OrtAllocator, *byte, **byte) api.OrtStatus
+	modelMetadataGetVersion               func(api.OrtModelMetadata, *int64) api.OrtStatus
+	releaseModelMetadata                  func(api.OrtModelMetadata)
+	modelMetadataGetCustomMetadataMapKeys func(api.OrtModelMetadata, api.OrtAllocator, ***byte, *int64) api.OrtStatus
+
+	// Type introspection
+	sessionGetInputTypeInfo  func(api.OrtSession, uintptr, *api.OrtTypeInfo) api.OrtStatus
+	sessionGetOutputTypeInfo func(api.OrtSession, uintptr, *api.OrtTypeInfo) api.OrtStatus
+	castTypeInfoToTensorInfo func(api.OrtTypeInfo, *api.OrtTensorTypeAndShapeInfo) api.OrtStatus
+	getOnnxTypeFromTypeInfo  func(api.OrtTypeInfo, *api.ONNXType) api.OrtStatus
+	getSymbolicDimensions    func(api.OrtTensorTypeAndShapeInfo, **byte, uintptr) api.OrtStatus
+	releaseTypeInfo          func(api.OrtTypeInfo)
+
+	// Tensor/Value operations
+	createTensorAsOrtValue         func(api.OrtAllocator, *int64, uintptr, api.ONNXTensorElementDataType, *api.OrtValue) api.OrtStatus
+	createTensorWithDataAsOrtValue func(api.OrtMemoryInfo, unsafe.Pointer, uintptr, *int64, uintptr, api.ONNXTensorElementDataType, *api.OrtValue) api.OrtStatus
+	isTensor                       func(api.OrtValue, *int32) api.OrtStatus
+	getValueType                   func(api.OrtValue, *api.ONNXType) api.OrtStatus
+	hasValue                       func(api.OrtValue, *int32) api.OrtStatus
+	getTensorMutableData           func(api.OrtValue, *unsafe.Pointer) api.OrtStatus
+	getTensorTypeAndShape          func(api.OrtValue, *api.OrtTensorTypeAndShapeInfo) api.OrtStatus
+	getTensorElementType           func(api.OrtTensorTypeAndShapeInfo, *api.ONNXTensorElementDataType) api.OrtStatus
+	getDimensionsCount             func(api.OrtTensorTypeAndShapeInfo, *uintptr) api.OrtStatus
+	getDimensions                  func(api.OrtTensorTypeAndShapeInfo, *int64, uintptr) api.OrtStatus
+	getTensorShapeElementCount     func(api.OrtTensorTypeAndShapeInfo, *uintptr) api.OrtStatus
+	releaseValue                   func(api.OrtValue)
+	kernelContextGetInputCount     func(api.OrtKernelContext, *uintptr) api.OrtStatus
+	kernelContextGetOutputCount    func(api.OrtKernelContext, *uintptr) api.OrtStatus
+	kernelContextGetInput          func(api.OrtKernelContext, uintptr, *api.OrtValue) api.OrtStatus
+	kernelContextGetOutput         func(api.OrtKernelContext, uintptr, *int64, uintptr, *api.OrtValue) api.OrtStatus
+	releaseTensorTypeAndShapeInfo  func(api.OrtTensorTypeAndShapeInfo)
+
+	// String tensor operations
+	fillStringTensor             func(api.OrtValue, **byte, uintptr) api.OrtStatus
+	getStringTensorDataLength    func(api.OrtValue, *uintptr) api.OrtStatus
+	getStringTensorContent       func(api.OrtValue, unsafe.Pointer, uintptr, *uintptr, uintptr) api.OrtStatus
+	getStringTensorElementLength func(api.OrtValue, uintptr, *uintptr) api.OrtStatus
+	getStringTensorElement       func(api.OrtValue, uintptr, uintptr, unsafe.Pointer) api.OrtStatus
+	fillStringTensorElement      func(api.OrtValue, *byte, uintptr) api.OrtStatus
+
+	// Sequence/Map operations
+	getValue                       func(api.OrtValue, int32, api.OrtAllocator, *api.OrtValue) api.OrtStatus
+	getValueCount                  func(api.OrtValue, *uintptr) api.OrtStatus
+	castTypeInfoToMapTypeInfo      func(api.OrtTypeInfo, *api.OrtMapTypeInfo) api.OrtStatus
+	castTypeInfoToSequenceTypeInfo func(api.OrtTypeInfo, *api.OrtSequenceTypeInfo) api.OrtStatus
+	getMapKeyType                  func(api.OrtMapTypeInfo, *api.ONNXTensorElementDataType) api.OrtStatus
+	getSequenceElementType         func(api.OrtSequenceTypeInfo, *api.OrtTypeInfo) api.OrtStatus
+	releaseMapTypeInfo             func(api.OrtMapTypeInfo)
+	releaseSequenceTypeInfo        func(api.OrtSequenceTypeInfo)
+
+	// IO Binding
+	createIoBinding         func(api.OrtSession, *api.OrtIoBinding) api.OrtStatus
+	releaseIoBinding        func(api.OrtIoBinding)
+	bindInput               func(api.OrtIoBinding, *byte, api.OrtValue) api.OrtStatus
+	bindOutput              func(api.OrtIoBinding, *byte, api.OrtValue) api.OrtStatus
+	bindOutputToDevice      func(api.OrtIoBinding, *byte, api.OrtMemoryInfo) api.OrtStatus
+	getBoundOutputNames     func(api.OrtIoBinding, api.OrtAllocator, **byte, *uintptr, *uintptr) api.OrtStatus
+	getBoundOutputValues    func(api.OrtIoBinding, api.OrtAllocator, **api.OrtValue, *uintptr) api.OrtStatus
+	clearBoundInputs        func(api.OrtIoBinding)
+	clearBoundOutputs       func(api.OrtIoBinding)
+	runWithBinding          func(api.OrtSession, api.OrtRunOptions, api.OrtIoBinding) api.OrtStatus
+	synchronizeBoundInputs  func(api.OrtIoBinding) api.OrtStatus
+	synchronizeBoundOutputs func(api.OrtIoBinding) api.OrtStatus
+
+	// Execution provider information
+	getAvailableProviders     func(***byte, *int32) api.OrtStatus
+	releaseAvailableProviders func(**byte, int32) api.OrtStatus
+
+	// Prepacked weights
+	createPrepackedWeightsContainer                     func(*api.OrtPrepackedWeightsContainer) api.OrtStatus
+	releasePrepackedWeightsContainer                    func(api.OrtPrepackedWeightsContainer)
+	createSessionWithPrepackedWeightsContainer          func(api.OrtEnv, *byte, api.OrtSessionOptions, api.OrtPrepackedWeightsContainer, *api.OrtSession) api.OrtStatus
+	createSessionFromArrayWithPrepackedWeightsContainer func(api.OrtEnv, unsafe.Pointer, uintptr, api.OrtSessionOptions, api.OrtPrepackedWeightsContainer, *api.OrtSession) api.OrtStatus
+
+	// Threading options
+	createThreadingOptions               func(*api.OrtThreadingOptions) api.OrtStatus
+	releaseThreadingOptions              func(api.OrtThreadingOptions)
+	setGlobalIntraOpNumThreads           func(api.OrtThreadingOptions, int32) api.OrtStatus
+	setGlobalInterOpNumThreads           func(api.OrtThreadingOptions, int32) api.OrtStatus
+	setGlobalSpinControl                 func(api.OrtThreadingOptions, int32) api.OrtStatus
+	setGlobalDenormalAsZero              func(api.OrtThreadingOptions) api.OrtStatus
+	setGlobalIntraOpThreadAffinity       func(api.OrtThreadingOptions, *byte) api.OrtStatus
+	setGlobalCustomCreateThreadFn        func(api.OrtThreadingOptions, uintptr) api.OrtStatus
+	setGlobalCustomThreadCreationOptions func(api.OrtThreadingOptions, uintptr) api.OrtStatus
+	setGlobalCustomJoinThreadFn          func(api.OrtThreadingOptions, uintptr) api.OrtStatus
+
+	// Sparse tensors
+	isSparseTensor                         func(api.OrtValue, *int32) api.OrtStatus
+	createSparseTensorWithValuesAsOrtValue func(api.OrtMemoryInfo, unsafe.Pointer, *int64, uintptr, *int64, uintptr, api.ONNXTensorElementDataType, *api.OrtValue) api.OrtStatus
+	useCooIndices                          func(api.OrtValue, *int64, uintptr) api.OrtStatus
+	useCsrIndices                          func(api.OrtValue, *int64, uintptr, *int64, uintptr) api.OrtStatus
+	getSparseTensorFormat                  func(api.OrtValue, *api.OrtSparseFormat) api.OrtStatus
+	getSparseTensorValuesTypeAndShape      func(api.OrtValue, *api.OrtTensorTypeAndShapeInfo) api.OrtStatus
+	getSparseTensorValues                  func(api.OrtValue, *unsafe.Pointer) api.OrtStatus
+	getSparseTensorIndicesTypeShape        func(api.OrtValue, api.OrtSparseIndicesFormat, *api.OrtTensorTypeAndShapeInfo) api.OrtStatus
+	getSparseTensorIndices                 func(api.OrtValue, api.OrtSparseIndicesFormat, *uintptr, *unsafe.Pointer) api.OrtStatus
+
+	// Training API, nil unless the library is a training build
+	loadCheckpoint                             func(*byte, *api.OrtCheckpointState) api.OrtStatus
+	saveCheckpoint                             func(api.OrtCheckpointState, *byte, bool) api.OrtStatus
+	releaseCheckpointState                     func(api.OrtCheckpointState)
+	createTrainingSession                      func(api.OrtEnv, api.OrtSessionOptions, api.OrtCheckpointState, *byte, *byte, *byte, *api.OrtTrainingSession) api.OrtStatus
+	releaseTrainingSession                     func(api.OrtTrainingSession)
+	trainingSessionGetTrainingModelInputCount  func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetTrainingModelInputName   func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	trainingSessionGetEvalModelInputCount      func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetEvalModelInputName       func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	trainingSessionGetTrainingModelOutputCount func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetTrainingModelOutputName  func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	trainingSessionGetEvalModelOutputCount     func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetEvalModelOutputName      func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	lazyResetGrad                              func(api.OrtTrainingSession) api.OrtStatus
+	trainStep                                  func(api.OrtTrainingSession, api.OrtRunOptions, uintptr, *api.OrtValue, uintptr, *api.OrtValue) api.OrtStatus
+	evalStep                                   func(api.OrtTrainingSession, api.OrtRunOptions, uintptr, *api.OrtValue, uintptr, *api.OrtValue) api.OrtStatus
+	setLearningRate                            func(api.OrtTrainingSession, float32) api.OrtStatus
+	getLearningRate                            func(api.OrtTrainingSession, *float32) api.OrtStatus
+	optimizerStep                              func(api.OrtTrainingSession, api.OrtRunOptions) api.OrtStatus
+	exportModelForInferencing                  func(api.OrtTrainingSession, *byte, uintptr, **byte) api.OrtStatus
+}
+
+// InitializeFuncs initializes the v21 API function pointers from the library handle.
+func InitializeFuncs(libraryHandle uintptr) (*Funcs, error) {
+	// Get the OrtApiBase from the library
+	var ortGetAPIBase func() *APIBase
+	purego.RegisterLibFunc(&ortGetAPIBase, libraryHandle, "OrtGetApiBase")
+
+	apiBase := ortGetAPIBase()
+	if apiBase == nil {
+		return nil, fmt.Errorf("OrtGetApiBase returned nil")
+	}
+
+	// Get the versioned API
+	var getAPIFunc func(uint32) unsafe.Pointer
+	purego.RegisterFunc(&getAPIFunc, apiBase.GetAPI)
+
+	apiPtr := getAPIFunc(APIVersion)
+	if apiPtr == nil {
+		return nil, fmt.Errorf("failed to get OrtAPI for version %d", APIVersion)
+	}
+
+	api := (*API)(apiPtr)
+
+	funcs := &Funcs{}
+
+	// Register all function pointers
+	purego.RegisterFunc(&funcs.createStatus, api.CreateStatus)
+	purego.RegisterFunc(&funcs.getErrorCode, api.GetErrorCode)
+	purego.RegisterFunc(&funcs.getErrorMessage, api.GetErrorMessage)
+	purego.RegisterFunc(&funcs.releaseStatus, api.ReleaseStatus)
+
+	purego.RegisterFunc(&funcs.createEnv, api.CreateEnv)
+	purego.RegisterFunc(&funcs.createEnvWithGlobalThreadPools, api.CreateEnvWithGlobalThreadPools)
+	purego.RegisterFunc(&funcs.createEnvWithCustomLogger, api.CreateEnvWithCustomLogger)
+	purego.RegisterFunc(&funcs.releaseEnv, api.ReleaseEnv)
+	purego.RegisterFunc(&funcs.createAndRegisterAllocator, api.CreateAndRegisterAllocator)
+	purego.RegisterFunc(&funcs.unregisterAllocator, api.UnregisterAllocator)
+
+	purego.RegisterFunc(&funcs.enableTelemetryEvents, api.EnableTelemetryEvents)
+	purego.RegisterFunc(&funcs.disableTelemetryEvents, api.DisableTelemetryEvents)
+
+	purego.RegisterFunc(&funcs.getAllocatorWithDefaultOptions, api.GetAllocatorWithDefaultOptions)
+	purego.RegisterFunc(&funcs.allocatorFree, api.AllocatorFree)
+	purego.RegisterFunc(&funcs.createAllocator, api.CreateAllocator)
+	purego.RegisterFunc(&funcs.releaseAllocator, api.ReleaseAllocator)
+	purego.RegisterFunc(&funcs.createArenaCfgV2, api.CreateArenaCfgV2)
+	purego.RegisterFunc(&funcs.releaseArenaCfg, api.ReleaseArenaCfg)
+
+	purego.RegisterFunc(&funcs.createCpuMemoryInfo, api.CreateCpuMemoryInfo)
+	purego.RegisterFunc(&funcs.createMemoryInfo, api.CreateMemoryInfo)
+	purego.RegisterFunc(&funcs.memoryInfoGetName, api.MemoryInfoGetName)
+	purego.RegisterFunc(&funcs.memoryInfoGetId, api.MemoryInfoGetId)
+	purego.RegisterFunc(&funcs.memoryInfoGetMemType, api.MemoryInfoGetMemType)
+	purego.RegisterFunc(&funcs.memoryInfoGetType, api.MemoryInfoGetType)
+	purego.RegisterFunc(&funcs.memoryInfoGetDeviceType, api.MemoryInfoGetDeviceType)
+	purego.RegisterFunc(&funcs.releaseMemoryInfo, api.ReleaseMemoryInfo)
+
+	purego.RegisterFunc(&funcs.createSessionOptions, api.CreateSessionOptions)
+	purego.RegisterFunc(&funcs.setOptimizedModelFilePath, api.SetOptimizedModelFilePath)
+	purego.RegisterFunc(&funcs.setIntraOpNumThreads, api.SetIntraOpNumThreads)
+	purego.RegisterFunc(&funcs.setInterOpNumThreads, api.SetInterOpNumThreads)
+	purego.RegisterFunc(&funcs.setSessionExecutionMode, api.SetSessionExecutionMode)
+	purego.RegisterFunc(&funcs.setSessionGraphOptimizationLevel, api.SetSessionGraphOptimizationLevel)
+	purego.RegisterFunc(&funcs.enableCpuMemArena, api.EnableCpuMemArena)
+	purego.RegisterFunc(&funcs.disableCpuMemArena, api.DisableCpuMemArena)
+	purego.RegisterFunc(&funcs.enableMemPattern, api.EnableMemPattern)
+	purego.RegisterFunc(&funcs.disableMemPattern, api.DisableMemPattern)
+	purego.RegisterFunc(&funcs.setSessionLogSeverityLevel, api.SetSessionLogSeverityLevel)
+	purego.RegisterFunc(&funcs.addSessionConfigEntry, api.AddSessionConfigEntry)
+	purego.RegisterFunc(&funcs.addFreeDimensionOverride, api.AddFreeDimensionOverride)
+	purego.RegisterFunc(&funcs.addFreeDimensionOverrideByName, api.AddFreeDimensionOverrideByName)
+	purego.RegisterFunc(&funcs.addExternalInitializers, api.AddExternalInitializers)
+	purego.RegisterFunc(&funcs.addExternalInitializersFromFilesInMemory, api.AddExternalInitializersFromFilesInMemory)
+	purego.RegisterFunc(&funcs.createCustomOpDomain, api.CreateCustomOpDomain)
+	purego.RegisterFunc(&funcs.customOpDomainAdd, api.CustomOpDomain_Add)
+	purego.RegisterFunc(&funcs.addCustomOpDomain, api.AddCustomOpDomain)
+	purego.RegisterFunc(&funcs.releaseCustomOpDomain, api.ReleaseCustomOpDomain)
+	purego.RegisterFunc(&funcs.setDeterministicCompute, api.SetDeterministicCompute)
+	purego.RegisterFunc(&funcs.disablePerSessionThreads, api.DisablePerSessionThreads)
+	purego.RegisterFunc(&funcs.enableProfiling, api.EnableProfiling)
+	purego.RegisterFunc(&funcs.disableProfiling, api.DisableProfiling)
+	purego.RegisterFunc(&funcs.sessionOptionsAppendExecutionProvider, api.SessionOptionsAppendExecutionProvider)
+	purego.RegisterFunc(&funcs.sessionOptionsAppendExecutionProviderCUDAV2, api.SessionOptionsAppendExecutionProvider_CUDA_V2)
+	purego.RegisterFunc(&funcs.createCUDAProviderOptions, api.CreateCUDAProviderOptions)
+	purego.RegisterFunc(&funcs.updateCUDAProviderOptions, api.UpdateCUDAProviderOptions)
+	purego.RegisterFunc(&funcs.updateCUDAProviderOptionsWithValue, api.UpdateCUDAProviderOptionsWithValue)
+	purego.RegisterFunc(&funcs.releaseCUDAProviderOptions, api.ReleaseCUDAProviderOptions)
+	purego.RegisterFunc(&funcs.releaseSessionOptions, api.ReleaseSessionOptions)
+
+	purego.RegisterFunc(&funcs.createRunOptions, api.CreateRunOptions)
+	purego.RegisterFunc(&funcs.releaseRunOptions, api.ReleaseRunOptions)
+	purego.RegisterFunc(&funcs.runOptionsSetTerminate, api.RunOptionsSetTerminate)
+	purego.RegisterFunc(&funcs.runOptionsUnsetTerminate, api.RunOptionsUnsetTerminate)
+	purego.RegisterFunc(&funcs.runOptionsSetRunTag, api.RunOptionsSetRunTag)
+	purego.RegisterFunc(&funcs.addRunConfigEntry, api.AddRunConfigEntry)
+	purego.RegisterFunc(&funcs.runOptionsAddActiveLoraAdapter, api.RunOptionsAddActiveLoraAdapter)
+
+	purego.RegisterFunc(&funcs.createSession, api.CreateSession)
+	purego.RegisterFunc(&funcs.createSessionFromArray, api.CreateSessionFromArray)
+	purego.RegisterFunc(&funcs.sessionGetInputCount, api.SessionGetInputCount)
+	purego.RegisterFunc(&funcs.sessionGetOutputCount, api.SessionGetOutputCount)
+	purego.RegisterFunc(&funcs.sessionGetInputName, api.SessionGetInputName)
+	purego.RegisterFunc(&funcs.sessionGetOutputName, api.SessionGetOutputName)
+	purego.RegisterFunc(&funcs.run, api.Run)
+	purego.RegisterFunc(&funcs.runAsync, api.RunAsync)
+	purego.RegisterFunc(&funcs.releaseSession, api.ReleaseSession)
+
+	purego.RegisterFunc(&funcs.sessionEndProfiling, api.SessionEndProfiling)
+	purego.RegisterFunc(&funcs.sessionGetProfilingStartTimeNs, api.SessionGetProfilingStartTimeNs)
+
+	purego.RegisterFunc(&funcs.createLoraAdapter, api.CreateLoraAdapter)
+	purego.RegisterFunc(&funcs.createLoraAdapterFromArray, api.CreateLoraAdapterFromArray)
+	purego.RegisterFunc(&funcs.releaseLoraAdapter, api.ReleaseLoraAdapter)
+
+	purego.RegisterFunc(&funcs.getBuildInfoString, api.GetBuildInfoString)
+
+	purego.RegisterFunc(&funcs.sessionGetModelMetadata, api.SessionGetModelMetadata)
+	purego.RegisterFunc(&funcs.modelMetadataGetProducerName, api.ModelMetadataGetProducerName)
+	purego.RegisterFunc(&funcs.modelMetadataGetGraphName, api.ModelMetadataGetGraphName)
+	purego.RegisterFunc(&funcs.modelMetadataGetDomain, api.ModelMetadataGetDomain)
+	purego.RegisterFunc(&funcs.modelMetadataGetDescription, api.ModelMetadataGetDescription)
+	purego.RegisterFunc(&funcs.modelMetadataLookupCustomMetadataMap, api.ModelMetadataLookupCustomMetadataMap)
+	purego.RegisterFunc(&funcs.modelMetadataGetVersion, api.ModelMetadataGetVersion)
+	purego.RegisterFunc(&funcs.releaseModelMetadata, api.ReleaseModelMetadata)
+	purego.RegisterFunc(&funcs.modelMetadataGetCustomMetadataMapKeys, api.ModelMetadataGetCustomMetadataMapKeys)
+
+	purego.RegisterFunc(&funcs.sessionGetInputTypeInfo, api.SessionGetInputTypeInfo)
+	purego.RegisterFunc(&funcs.sessionGetOutputTypeInfo, api.SessionGetOutputTypeInfo)
+	purego.RegisterFunc(&funcs.castTypeInfoToTensorInfo, api.CastTypeInfoToTensorInfo)
+	purego.RegisterFunc(&funcs.getOnnxTypeFromTypeInfo, api.GetOnnxTypeFromTypeInfo)
+	purego.RegisterFunc(&funcs.getSymbolicDimensions, api.GetSymbolicDimensions)
+	purego.RegisterFunc(&funcs.releaseTypeInfo, api.ReleaseTypeInfo)
+
+	purego.RegisterFunc(&funcs.createTensorAsOrtValue, api.CreateTensorAsOrtValue)
+	purego.RegisterFunc(&funcs.createTensorWithDataAsOrtValue, api.CreateTensorWithDataAsOrtValue)
+	purego.RegisterFunc(&funcs.isTensor, api.IsTensor)
+	purego.RegisterFunc(&funcs.getValueType, api.GetValueType)
+	purego.RegisterFunc(&funcs.hasValue, api.HasValue)
+	purego.RegisterFunc(&funcs.getTensorMutableData, api.GetTensorMutableData)
+	purego.RegisterFunc(&funcs.getTensorTypeAndShape, api.GetTensorTypeAndShape)
+	purego.RegisterFunc(&funcs.getTensorElementType, api.GetTensorElementType)
+	purego.RegisterFunc(&funcs.getDimensionsCount, api.GetDimensionsCount)
+	purego.RegisterFunc(&funcs.getDimensions, api.GetDimensions)
+	purego.RegisterFunc(&funcs.getTensorShapeElementCount, api.GetTensorShapeElementCount)
+	purego.RegisterFunc(&funcs.releaseValue, api.ReleaseValue)
+	purego.RegisterFunc(&funcs.kernelContextGetInputCount, api.KernelContext_GetInputCount)
+	purego.RegisterFunc(&funcs.kernelContextGetOutputCount, api.KernelContext_GetOutputCount)
+	purego.RegisterFunc(&funcs.kernelContextGetInput, api.KernelContext_GetInput)
+	purego.RegisterFunc(&funcs.kernelContextGetOutput, api.KernelContext_GetOutput)
+	purego.RegisterFunc(&funcs.releaseTensorTypeAndShapeInfo, api.ReleaseTensorTypeAndShapeInfo)
+
+	purego.RegisterFunc(&funcs.fillStringTensor, api.FillStringTensor)
+	purego.RegisterFunc(&funcs.getStringTensorDataLength, api.GetStringTensorDataLength)
+	purego.RegisterFunc(&funcs.getStringTensorContent, api.GetStringTensorContent)
+	purego.RegisterFunc(&funcs.getStringTensorElementLength, api.GetStringTensorElementLength)
+	purego.RegisterFunc(&funcs.getStringTensorElement, api.GetStringTensorElement)
+	purego.RegisterFunc(&funcs.fillStringTensorElement, api.FillStringTensorElement)
+
+	purego.RegisterFunc(&funcs.getValue, api.GetValue)
+	purego.RegisterFunc(&funcs.getValueCount, api.GetValueCount)
+	purego.RegisterFunc(&funcs.castTypeInfoToMapTypeInfo, api.CastTypeInfoToMapTypeInfo)
+	purego.RegisterFunc(&funcs.castTypeInfoToSequenceTypeInfo, api.CastTypeInfoToSequenceTypeInfo)
+	purego.RegisterFunc(&funcs.getMapKeyType, api.GetMapKeyType)
+	purego.RegisterFunc(&funcs.getSequenceElementType, api.GetSequenceElementType)
+	purego.RegisterFunc(&funcs.releaseMapTypeInfo, api.ReleaseMapTypeInfo)
+	purego.RegisterFunc(&funcs.releaseSequenceTypeInfo, api.ReleaseSequenceTypeInfo)
+
+	purego.RegisterFunc(&funcs.createIoBinding, api.CreateIoBinding)
+	purego.RegisterFunc(&funcs.releaseIoBinding, api.ReleaseIoBinding)
+	purego.RegisterFunc(&funcs.bindInput, api.BindInput)
+	purego.RegisterFunc(&funcs.bindOutput, api.BindOutput)
+	purego.RegisterFunc(&funcs.bindOutputToDevice, api.BindOutputToDevice)
+	purego.RegisterFunc(&funcs.getBoundOutputNames, api.GetBoundOutputNames)
+	purego.RegisterFunc(&funcs.getBoundOutputValues, api.GetBoundOutputValues)
+	purego.RegisterFunc(&funcs.clearBoundInputs, api.ClearBoundInputs)
+	purego.RegisterFunc(&funcs.clearBoundOutputs, api.ClearBoundOutputs)
+	purego.RegisterFunc(&funcs.runWithBinding, api.RunWithBinding)
+	purego.RegisterFunc(&funcs.synchronizeBoundInputs, api.SynchronizeBoundInputs)
+	purego.RegisterFunc(&funcs.synchronizeBoundOutputs, api.SynchronizeBoundOutputs)
+
+	purego.RegisterFunc(&funcs.getAvailableProviders, api.GetAvailableProviders)
+	purego.RegisterFunc(&funcs.releaseAvailableProviders, api.ReleaseAvailableProviders)
+
+	purego.RegisterFunc(&funcs.createPrepackedWeightsContainer, api.CreatePrepackedWeightsContainer)
+	purego.RegisterFunc(&funcs.releasePrepackedWeightsContainer, api.ReleasePrepackedWeightsContainer)
+	purego.RegisterFunc(&funcs.createSessionWithPrepackedWeightsContainer, api.CreateSessionWithPrepackedWeightsContainer)
+	purego.RegisterFunc(&funcs.createSessionFromArrayWithPrepackedWeightsContainer, api.CreateSessionFromArrayWithPrepackedWeightsContainer)
+
+	purego.RegisterFunc(&funcs.createThreadingOptions, api.CreateThreadingOptions)
+	purego.RegisterFunc(&funcs.releaseThreadingOptions, api.ReleaseThreadingOptions)
+	purego.RegisterFunc(&funcs.setGlobalIntraOpNumThreads, api.SetGlobalIntraOpNumThreads)
+	purego.RegisterFunc(&funcs.setGlobalInterOpNumThreads, api.SetGlobalInterOpNumThreads)
+	purego.RegisterFunc(&funcs.setGlobalSpinControl, api.SetGlobalSpinControl)
+	purego.RegisterFunc(&funcs.setGlobalDenormalAsZero, api.SetGlobalDenormalAsZero)
+	purego.RegisterFunc(&funcs.setGlobalIntraOpThreadAffinity, api.SetGlobalIntraOpThreadAffinity)
+	purego.RegisterFunc(&funcs.setGlobalCustomCreateThreadFn, api.SetGlobalCustomCreateThreadFn)
+	purego.RegisterFunc(&funcs.setGlobalCustomThreadCreationOptions, api.SetGlobalCustomThreadCreationOptions)
+	purego.RegisterFunc(&funcs.setGlobalCustomJoinThreadFn, api.SetGlobalCustomJoinThreadFn)
+
+	purego.RegisterFunc(&funcs.isSparseTensor, api.IsSparseTensor)
+	purego.RegisterFunc(&funcs.createSparseTensorWithValuesAsOrtValue, api.CreateSparseTensorWithValuesAsOrtValue)
+	purego.RegisterFunc(&funcs.useCooIndices, api.UseCooIndices)
+	purego.RegisterFunc(&funcs.useCsrIndices, api.UseCsrIndices)
+	purego.RegisterFunc(&funcs.getSparseTensorFormat, api.GetSparseTensorFormat)
+	purego.RegisterFunc(&funcs.getSparseTensorValuesTypeAndShape, api.GetSparseTensorValuesTypeAndShape)
+	purego.RegisterFunc(&funcs.getSparseTensorValues, api.GetSparseTensorValues)
+	purego.RegisterFunc(&funcs.getSparseTensorIndicesTypeShape, api.GetSparseTensorIndicesTypeShape)
+	purego.RegisterFunc(&funcs.getSparseTensorIndices, api.GetSparseTensorIndices)
+
+	// The training API is only present in training builds of the library.
+	var getTrainingAPI func(uint32) unsafe.Pointer
+	purego.RegisterFunc(&getTrainingAPI, api.GetTrainingApi)
+	if trainingAPI := (*TrainingAPI)(getTrainingAPI(APIVersion)); trainingAPI != nil {
+		purego.RegisterFunc(&funcs.loadCheckpoint, trainingAPI.LoadCheckpoint)
+		purego.RegisterFunc(&funcs.saveCheckpoint, trainingAPI.SaveCheckpoint)
+		purego.RegisterFunc(&funcs.releaseCheckpointState, trainingAPI.ReleaseCheckpointState)
+		purego.RegisterFunc(&funcs.createTrainingSession, trainingAPI.CreateTrainingSession)
+		purego.RegisterFunc(&funcs.releaseTrainingSession, trainingAPI.ReleaseTrainingSession)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelInputCount, trainingAPI.TrainingSessionGetTrainingModelInputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelInputName, trainingAPI.TrainingSessionGetTrainingModelInputName)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelInputCount, trainingAPI.TrainingSessionGetEvalModelInputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelInputName, trainingAPI.TrainingSessionGetEvalModelInputName)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelOutputCount, trainingAPI.TrainingSessionGetTrainingModelOutputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelOutputName, trainingAPI.TrainingSessionGetTrainingModelOutputName)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelOutputCount, trainingAPI.TrainingSessionGetEvalModelOutputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelOutputName, trainingAPI.TrainingSessionGetEvalModelOutputName)
+		purego.RegisterFunc(&funcs.lazyResetGrad, trainingAPI.LazyResetGrad)
+		purego.RegisterFunc(&funcs.trainStep, trainingAPI.TrainStep)
+		purego.RegisterFunc(&funcs.evalStep, trainingAPI.EvalStep)
+		purego.RegisterFunc(&funcs.setLearningRate, trainingAPI.SetLearningRate)
+		purego.RegisterFunc(&funcs.getLearningRate, trainingAPI.GetLearningRate)
+		purego.RegisterFunc(&funcs.optimizerStep, trainingAPI.OptimizerStep)
+		purego.RegisterFunc(&funcs.exportModelForInferencing, trainingAPI.ExportModelForInferencing)
+	}
+
+	return funcs, nil
+}
+
+// Status and error handling methods
+
+func (f *Funcs) CreateStatus(code api.OrtErrorCode, msg *byte) api.OrtStatus {
+	return f.createStatus(code, msg)
+}
+
+func (f *Funcs) GetErrorCode(status api.OrtStatus) api.OrtErrorCode {
+	return f.getErrorCode(status)
+}
+
+func (f *Funcs) GetErrorMessage(status api.OrtStatus) unsafe.Pointer {
+	return f.getErrorMessage(status)
+}
+
+func (f *Funcs) ReleaseStatus(status api.OrtStatus) {
+	f.releaseStatus(status)
+}
+
+// Environment methods
+
+func (f *Funcs) CreateEnv(logLevel api.OrtLoggingLevel, logID *byte, env *api.OrtEnv) api.OrtStatus {
+	return f.createEnv(logLevel, logID, env)
+}
+
+func (f *Funcs) CreateEnvWithGlobalThreadPools(logLevel api.OrtLoggingLevel, logID *byte, threadingOptions api.OrtThreadingOptions, env *api.OrtEnv) api.OrtStatus {
+	return f.createEnvWithGlobalThreadPools(logLevel, logID, threadingOptions, env)
+}
+
+func (f *Funcs) CreateEnvWithCustomLogger(loggingFunction uintptr, loggerParam uintptr, logLevel api.OrtLoggingLevel, logID *byte, env *api.OrtEnv) api.OrtStatus {
+	return f.createEnvWithCustomLogger(loggingFunction, loggerParam, logLevel, logID, env)
+}
+
+func (f *Funcs) ReleaseEnv(env api.OrtEnv) {
+	f.releaseEnv(env)
+}
+
+func (f *Funcs) CreateAndRegisterAllocator(env api.OrtEnv, memInfo api.OrtMemoryInfo, arenaCfg api.OrtArenaCfg) api.OrtStatus {
+	return f.createAndRegisterAllocator(env, memInfo, arenaCfg)
+}
+
+func (f *Funcs) UnregisterAllocator(env api.OrtEnv, memInfo api.OrtMemoryInfo) api.OrtStatus {
+	return f.unregisterAllocator(env, memInfo)
+}
+
+// Telemetry methods
+
+func (f *Funcs) EnableTelemetryEvents(env api.OrtEnv) api.OrtStatus {
+	return f.enableTelemetryEvents(env)
+}
+
+func (f *Funcs) DisableTelemetryEvents(env api.OrtEnv) api.OrtStatus {
+	return f.disableTelemetryEvents(env)
+}
+
+// Allocator methods
+
+func (f *Funcs) GetAllocatorWithDefaultOptions(allocator *api.OrtAllocator) api.OrtStatus {
+	return f.getAllocatorWithDefaultOptions(allocator)
+}
+
+func (f *Funcs) AllocatorFree(allocator api.OrtAllocator, ptr unsafe.Pointer) {
+	f.allocatorFree(allocator, ptr)
+}
+
+func (f *Funcs) CreateAllocator(session api.OrtSession, memInfo api.OrtMemoryInfo, allocator *api.OrtAllocator) api.OrtStatus {
+	return f.createAllocator(session, memInfo, allocator)
+}
+
+func (f *Funcs) ReleaseAllocator(allocator api.OrtAllocator) {
+	f.releaseAllocator(allocator)
+}
+
+func (f *Funcs) CreateArenaCfgV2(keys **byte, values *uintptr, numKeys uintptr, out *api.OrtArenaCfg) api.OrtStatus {
+	return f.createArenaCfgV2(keys, values, numKeys, out)
+}
+
+func (f *Funcs) ReleaseArenaCfg(arenaCfg api.OrtArenaCfg) {
+	f.releaseArenaCfg(arenaCfg)
+}
+
+// AllocatorGetStats was added in API version 23.
+func (f *Funcs) AllocatorGetStats(allocator api.OrtAllocator, stats *api.OrtKeyValuePairs) api.OrtStatus {
+	return f.notImplemented("AllocatorGetStats")
+}
+
+// ReleaseKeyValuePairs was added in API version 22.
+func (f *Funcs) ReleaseKeyValuePairs(kvps api.OrtKeyValuePairs) {}
+
+// Memory info methods
+
+func (f *Funcs) CreateCpuMemoryInfo(allocType api.OrtAllocatorType, memType api.OrtMemType, memInfo *api.OrtMemoryInfo) api.OrtStatus {
+	return f.createCpuMemoryInfo(allocType, memType, memInfo)
+}
+
+func (f *Funcs) CreateMemoryInfo(name *byte, allocType api.OrtAllocatorType, id int32, memType api.OrtMemType, memInfo *api.OrtMemoryInfo) api.OrtStatus {
+	return f.createMemoryInfo(name, allocType, id, memType, memInfo)
+}
+
+func (f *Funcs) MemoryInfoGetName(memInfo api.OrtMemoryInfo, name **byte) api.OrtStatus {
+	return f.memoryInfoGetName(memInfo, name)
+}
+
+func (f *Funcs) MemoryInfoGetId(memInfo api.OrtMemoryInfo, id *int32) api.OrtStatus {
+	return f.memoryInfoGetId(memInfo, id)
+}
+
+func (f *Funcs) MemoryInfoGetMemType(memInfo api.OrtMemoryInfo, memType *api.OrtMemType) api.OrtStatus {
+	return f.memoryInfoGetMemType(memInfo, memType)
+}
+
+func (f *Funcs) MemoryInfoGetType(memInfo api.OrtMemoryInfo, allocType *api.OrtAllocatorType) api.OrtStatus {
+	return f.memoryInfoGetType(memInfo, allocType)
+}
+
+func (f *Funcs) MemoryInfoGetDeviceType(memInfo api.OrtMemoryInfo, deviceType *api.OrtMemoryInfoDeviceType) {
+	f.memoryInfoGetDeviceType(memInfo, deviceType)
+}
+
+func (f *Funcs) ReleaseMemoryInfo(memInfo api.OrtMemoryInfo) {
+	f.releaseMemoryInfo(memInfo)
+}
+
+// Session options methods
+
+func (f *Funcs) CreateSessionOptions(options *api.OrtSessionOptions) api.OrtStatus {
+	return f.createSessionOptions(options)
+}
+
+func (f *Funcs) SetOptimizedModelFilePath(options api.OrtSessionOptions, path *byte) api.OrtStatus {
+	return f.setOptimizedModelFilePath(options, path)
+}
+
+func (f *Funcs) SetIntraOpNumThreads(options api.OrtSessionOptions, numThreads int32) api.OrtStatus {
+	return f.setIntraOpNumThreads(options, numThreads)
+}
+
+func (f *Funcs) SetInterOpNumThreads(options api.OrtSessionOptions, numThreads int32) api.OrtStatus {
+	return f.setInterOpNumThreads(options, numThreads)
+}
+
+func (f *Funcs) SetSessionExecutionMode(options api.OrtSessionOptions, mode int32) api.OrtStatus {
+	return f.setSessionExecutionMode(options, mode)
+}
+
+func (f *Funcs) SetSessionGraphOptimizationLevel(options api.OrtSessionOptions, level int32) api.OrtStatus {
+	return f.setSessionGraphOptimizationLevel(options, level)
+}
+
+func (f *Funcs) EnableCpuMemArena(options api.OrtSessionOptions) api.OrtStatus {
+	return f.enableCpuMemArena(options)
+}
+
+func (f *Funcs) DisableCpuMemArena(options api.OrtSessionOptions) api.OrtStatus {
+	return f.disableCpuMemArena(options)
+}
+
+func (f *Funcs) EnableMemPattern(options api.OrtSessionOptions) api.OrtStatus {
+	return f.enableMemPattern(options)
+}
+
+func (f *Funcs) DisableMemPattern(options api.OrtSessionOptions) api.OrtStatus {
+	return f.disableMemPattern(options)
+}
+
+func (f *Funcs) SetSessionLogSeverityLevel(options api.OrtSessionOptions, level int32) api.OrtStatus {
+	return f.setSessionLogSeverityLevel(options, level)
+}
+
+func (f *Funcs) AddSessionConfigEntry(options api.OrtSessionOptions, key *byte, value *byte) api.OrtStatus {
+	return f.addSessionConfigEntry(options, key, value)
+}
+
+func (f *Funcs) AddFreeDimensionOverride(options api.OrtSessionOptions, dimDenotation *byte, dimValue int64) api.OrtStatus {
+	return f.addFreeDimensionOverride(options, dimDenotation, dimValue)
+}
+
+func (f *Funcs) AddFreeDimensionOverrideByName(options api.OrtSessionOptions, dimName *byte, dimValue int64) api.OrtStatus {
+	return f.addFreeDimensionOverrideByName(options, dimName, dimValue)
+}
+
+func (f *Funcs) AddExternalInitializers(options api.OrtSessionOptions, names **byte, values *api.OrtValue, numInitializers uintptr) api.OrtStatus {
+	return f.addExternalInitializers(options, names, values, numInitializers)
+}
+
+func (f *Funcs) AddExternalInitializersFromFilesInMemory(options api.OrtSessionOptions, fileNames **byte, buffers **byte, lengths *uintptr, numFiles uintptr) api.OrtStatus {
+	return f.addExternalInitializersFromFilesInMemory(options, fileNames, buffers, lengths, numFiles)
+}
+
+func (f *Funcs) CreateCustomOpDomain(domain *byte, out *api.OrtCustomOpDomain) api.OrtStatus {
+	return f.createCustomOpDomain(domain, out)
+}
+
+func (f *Funcs) CustomOpDomainAdd(domain api.OrtCustomOpDomain, op unsafe.Pointer) api.OrtStatus {
+	return f.customOpDomainAdd(domain, op)
+}
+
+func (f *Funcs) AddCustomOpDomain(options api.OrtSessionOptions, domain api.OrtCustomOpDomain) api.OrtStatus {
+	return f.addCustomOpDomain(options, domain)
+}
+
+func (f *Funcs) ReleaseCustomOpDomain(domain api.OrtCustomOpDomain) {
+	f.releaseCustomOpDomain(domain)
+}
+
+func (f *Funcs) SetDeterministicCompute(options api.OrtSessionOptions, value int32) api.OrtStatus {
+	return f.setDeterministicCompute(options, value)
+}
+
+func (f *Funcs) DisablePerSessionThreads(options api.OrtSessionOptions) api.OrtStatus {
+	return f.disablePerSessionThreads(options)
+}
+
+func (f *Funcs) EnableProfiling(options api.OrtSessionOptions, path *byte) api.OrtStatus {
+	return f.enableProfiling(options, path)
+}
+
+func (f *Funcs) DisableProfiling(options api.OrtSessionOptions) api.OrtStatus {
+	return f.disableProfiling(options)
+}
+
+func (f *Funcs) SessionOptionsAppendExecutionProvider(options api.OrtSessionOptions, providerName *byte, keys **byte, values **byte, numKeys uintptr) api.OrtStatus {
+	return f.sessionOptionsAppendExecutionProvider(options, providerName, keys, values, numKeys)
+}
+
+func (f *Funcs) SessionOptionsAppendExecutionProviderCUDAV2(options api.OrtSessionOptions, cudaOptions api.OrtCUDAProviderOptionsV2) api.OrtStatus {
+	return f.sessionOptionsAppendExecutionProviderCUDAV2(options, cudaOptions)
+}
+
+func (f *Funcs) CreateCUDAProviderOptions(out *api.OrtCUDAProviderOptionsV2) api.OrtStatus {
+	return f.createCUDAProviderOptions(out)
+}
+
+func (f *Funcs) UpdateCUDAProviderOptions(cudaOptions api.OrtCUDAProviderOptionsV2, keys **byte, values **byte, numKeys uintptr) api.OrtStatus {
+	return f.updateCUDAProviderOptions(cudaOptions, keys, values, numKeys)
+}
+
+func (f *Funcs) UpdateCUDAProviderOptionsWithValue(cudaOptions api.OrtCUDAProviderOptionsV2, key *byte, value uintptr) api.OrtStatus {
+	return f.updateCUDAProviderOptionsWithValue(cudaOptions, key, value)
+}
+
+func (f *Funcs) ReleaseCUDAProviderOptions(cudaOptions api.OrtCUDAProviderOptionsV2) {
+	f.releaseCUDAProviderOptions(cudaOptions)
+}
+
+func (f *Funcs) ReleaseSessionOptions(options api.OrtSessionOptions) {
+	f.releaseSessionOptions(options)
+}
+
+// Run options methods
+
+func (f *Funcs) CreateRunOptions(options *api.OrtRunOptions) api.OrtStatus {
+	return f.createRunOptions(options)
+}
+
+func (f *Funcs) ReleaseRunOptions(options api.OrtRunOptions) {
+	f.releaseRunOptions(options)
+}
+
+func (f *Funcs) RunOptionsSetTerminate(options api.OrtRunOptions) api.OrtStatus {
+	return f.runOptionsSetTerminate(options)
+}
+
+func (f *Funcs) RunOptionsUnsetTerminate(options api.OrtRunOptions) api.OrtStatus {
+	return f.runOptionsUnsetTerminate(options)
+}
+
+func (f *Funcs) RunOptionsSetRunTag(options api.OrtRunOptions, tag *byte) api.OrtStatus {
+	return f.runOptionsSetRunTag(options, tag)
+}
+
+func (f *Funcs) AddRunConfigEntry(options api.OrtRunOptions, key *byte, value *byte) api.OrtStatus {
+	return f.addRunConfigEntry(options, key, value)
+}
+
+func (f *Funcs) RunOptionsAddActiveLoraAdapter(options api.OrtRunOptions, adapter api.OrtLoraAdapter) api.OrtStatus {
+	return f.runOptionsAddActiveLoraAdapter(options, adapter)
+}
+
+// Session methods
+
+func (f *Funcs) CreateSession(env api.OrtEnv, modelPath *byte, options api.OrtSessionOptions, session *api.OrtSession) api.OrtStatus {
+	return f.createSession(env, modelPath, options, session)
+}
+
+func (f *Funcs) CreateSessionFromArray(env api.OrtEnv, modelData unsafe.Pointer, modelDataLength uintptr, options api.OrtSessionOptions, session *api.OrtSession) api.OrtStatus {
+	return f.createSessionFromArray(env, modelData, modelDataLength, options, session)
+}
+
+func (f *Funcs) SessionGetInputCount(session api.OrtSession, count *uintptr) api.OrtStatus {
+	return f.sessionGetInputCount(session, count)
+}
+
+func (f *Funcs) SessionGetOutputCount(session api.OrtSession, count *uintptr) api.OrtStatus {
+	return f.sessionGetOutputCount(session, count)
+}
+
+func (f *Funcs) SessionGetInputName(session api.OrtSession, index uintptr, allocator api.OrtAllocator, name **byte) api.OrtStatus {
+	return f.sessionGetInputName(session, index, allocator, name)
+}
+
+func (f *Funcs) SessionGetOutputName(session api.OrtSession, index uintptr, allocator api.OrtAllocator, name **byte) api.OrtStatus {
+	return f.sessionGetOutputName(session, index, allocator, name)
+}
+
+func (f *Funcs) Run(session api.OrtSession, runOptions api.OrtRunOptions, inputNames **byte, inputs *api.OrtValue, inputCount uintptr, outputNames **byte, outputCount uintptr, outputs *api.OrtValue) api.OrtStatus {
+	return f.run(session, runOptions, inputNames, inputs, inputCount, outputNames, outputCount, outputs)
+}
+
+func (f *Funcs) RunAsync(session api.OrtSession, runOptions api.OrtRunOptions, inputNames **byte, inputs *api.OrtValue, inputCount uintptr, outputNames **byte, outputCount uintptr, outputs *api.OrtValue, callback uintptr, userData uintptr) api.OrtStatus {
+	return f.runAsync(session, runOptions, inputNames, inputs, inputCount, outputNames, outputCount, outputs, callback, userData)
+}
+
+func (f *Funcs) ReleaseSession(session api.OrtSession) {
+	f.releaseSession(session)
+}
+
+// Profiling methods
+
+func (f *Funcs) SessionEndProfiling(session api.OrtSession, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.sessionEndProfiling(session, allocator, out)
+}
+
+func (f *Funcs) SessionGetProfilingStartTimeNs(session api.OrtSession, out *uint64) api.OrtStatus {
+	return f.sessionGetProfilingStartTimeNs(session, out)
+}
+
+// SessionGetMemoryInfoForInputs was added in API version 23.
+func (f *Funcs) SessionGetMemoryInfoForInputs(session api.OrtSession, out *api.OrtMemoryInfo, numInputs uintptr) api.OrtStatus {
+	return f.notImplemented("SessionGetMemoryInfoForInputs")
+}
+
+// SessionGetMemoryInfoForOutputs was added in API version 23.
+func (f *Funcs) SessionGetMemoryInfoForOutputs(session api.OrtSession, out *api.OrtMemoryInfo, numOutputs uintptr) api.OrtStatus {
+	return f.notImplemented("SessionGetMemoryInfoForOutputs")
+}
+
+// LoRA adapter methods
+
+func (f *Funcs) CreateLoraAdapter(path *byte, allocator api.OrtAllocator, out *api.OrtLoraAdapter) api.OrtStatus {
+	return f.createLoraAdapter(path, allocator, out)
+}
+
+func (f *Funcs) CreateLoraAdapterFromArray(data unsafe.Pointer, dataLen uintptr, allocator api.OrtAllocator, out *api.OrtLoraAdapter) api.OrtStatus {
+	return f.createLoraAdapterFromArray(data, dataLen, allocator, out)
+}
+
+func (f *Funcs) ReleaseLoraAdapter(adapter api.OrtLoraAdapter) {
+	f.releaseLoraAdapter(adapter)
+}
+
+// Build info methods
+
+func (f *Funcs) GetBuildInfoString() unsafe.Pointer {
+	return f.getBuildInfoString()
+}
+
+// Model metadata methods
+
+func (f *Funcs) SessionGetModelMetadata(session api.OrtSession, metadata *api.OrtModelMetadata) api.OrtStatus {
+	return f.sessionGetModelMetadata(session, metadata)
+}
+
+func (f *Funcs) ModelMetadataGetProducerName(metadata api.OrtModelMetadata, allocator api.OrtAllocator, value **byte) api.OrtStatus {
+	return f.modelMetadataGetProducerName(metadata, allocator, value)
+}
+
+func (f *Funcs) ModelMetadataGetGraphName(metadata api.OrtModelMetadata, allocator api.OrtAllocator, value **byte) api.OrtStatus {
+	return f.modelMetadataGetGraphName(metadata, allocator, value)
+}
+
+func (f *Funcs) ModelMetadataGetDomain(metadata api.OrtModelMetadata, allocator api.OrtAllocator, value **byte) api.OrtStatus {
+	return f.modelMetadataGetDomain(metadata, allocator, value)
+}
+
+func (f *Funcs) ModelMetadataGetDescription(metadata api.OrtModelMetadata, allocator api.OrtAllocator, value **byte) api.OrtStatus {
+	return f.modelMetadataGetDescription(metadata, allocator, value)
+}
+
+func (f *Funcs) ModelMetadataLookupCustomMetadataMap(metadata api.OrtModelMetadata, allocator api.OrtAllocator, key *byte, value **byte) api.OrtStatus {
+	return f.modelMetadataLookupCustomMetadataMap(metadata, allocator, key, value)
+}
+
+func (f *Funcs) ModelMetadataGetVersion(metadata api.OrtModelMetadata, version *int64) api.OrtStatus {
+	return f.modelMetadataGetVersion(metadata, version)
+}
+
+func (f *Funcs) ReleaseModelMetadata(metadata api.OrtModelMetadata) {
+	f.releaseModelMetadata(metadata)
+}
+
+func (f *Funcs) ModelMetadataGetCustomMetadataMapKeys(metadata api.OrtModelMetadata, allocator api.OrtAllocator, keys ***byte, numKeys *int64) api.OrtStatus {
+	return f.modelMetadataGetCustomMetadataMapKeys(metadata, allocator, keys, numKeys)
+}
+
+// Type introspection methods
+
+func (f *Funcs) SessionGetInputTypeInfo(session api.OrtSession, index uintptr, typeInfo *api.OrtTypeInfo) api.OrtStatus {
+	return f.sessionGetInputTypeInfo(session, index, typeInfo)
+}
+
+func (f *Funcs) SessionGetOutputTypeInfo(session api.OrtSession, index uintptr, typeInfo *api.OrtTypeInfo) api.OrtStatus {
+	return f.sessionGetOutputTypeInfo(session, index, typeInfo)
+}
+
+func (f *Funcs) CastTypeInfoToTensorInfo(typeInfo api.OrtTypeInfo, tensorInfo *api.OrtTensorTypeAndShapeInfo) api.OrtStatus {
+	return f.castTypeInfoToTensorInfo(typeInfo, tensorInfo)
+}
+
+func (f *Funcs) GetOnnxTypeFromTypeInfo(typeInfo api.OrtTypeInfo, onnxType *api.ONNXType) api.OrtStatus {
+	return f.getOnnxTypeFromTypeInfo(typeInfo, onnxType)
+}
+
+func (f *Funcs) GetSymbolicDimensions(typeAndShape api.OrtTensorTypeAndShapeInfo, dimParams **byte, dimParamsLen uintptr) api.OrtStatus {
+	return f.getSymbolicDimensions(typeAndShape, dimParams, dimParamsLen)
+}
+
+func (f *Funcs) ReleaseTypeInfo(typeInfo api.OrtTypeInfo) {
+	f.releaseTypeInfo(typeInfo)
+}
+
+// Tensor/Value operations methods
+
+func (f *Funcs) CreateTensorAsOrtValue(allocator api.OrtAllocator, shape *int64, shapeLen uintptr, dataType api.ONNXTensorElementDataType, value *api.OrtValue) api.OrtStatus {
+	return f.createTensorAsOrtValue(allocator, shape, shapeLen, dataType, value)
+}
+
+func (f *Funcs) CreateTensorWithDataAsOrtValue(memInfo api.OrtMemoryInfo, data unsafe.Pointer, dataSize uintptr, shape *int64, shapeLen uintptr, dataType api.ONNXTensorElementDataType, value *api.OrtValue) api.OrtStatus {
+	return f.createTensorWithDataAsOrtValue(memInfo, data, dataSize, shape, shapeLen, dataType, value)
+}
+
+func (f *Funcs) IsTensor(value api.OrtValue, out *int32) api.OrtStatus {
+	return f.isTensor(value, out)
+}
+
+func (f *Funcs) GetValueType(value api.OrtValue, valueType *api.ONNXType) api.OrtStatus {
+	return f.getValueType(value, valueType)
+}
+
+func (f *Funcs) HasValue(value api.OrtValue, out *int32) api.OrtStatus {
+	return f.hasValue(value, out)
+}
+
+func (f *Funcs) GetTensorMutableData(value api.OrtValue, data *unsafe.Pointer) api.OrtStatus {
+	return f.getTensorMutableData(value, data)
+}
+
+func (f *Funcs) GetTensorTypeAndShape(value api.OrtValue, typeAndShape *api.OrtTensorTypeAndShapeInfo) api.OrtStatus {
+	return f.getTensorTypeAndShape(value, typeAndShape)
+}
+
+func (f *Funcs) GetTensorElementType(typeAndShape api.OrtTensorTypeAndShapeInfo, dataType *api.ONNXTensorElementDataType) api.OrtStatus {
+	return f.getTensorElementType(typeAndShape, dataType)
+}
+
+func (f *Funcs) GetDimensionsCount(typeAndShape api.OrtTensorTypeAndShapeInfo, count *uintptr) api.OrtStatus {
+	return f.getDimensionsCount(typeAndShape, count)
+}
+
+func (f *Funcs) GetDimensions(typeAndShape api.OrtTensorTypeAndShapeInfo, dims *int64, dimsLen uintptr) api.OrtStatus {
+	return f.getDimensions(typeAndShape, dims, dimsLen)
+}
+
+func (f *Funcs) GetTensorShapeElementCount(typeAndShape api.OrtTensorTypeAndShapeInfo, count *uintptr) api.OrtStatus {
+	return f.getTensorShapeElementCount(typeAndShape, count)
+}
+
+func (f *Funcs) ReleaseValue(value api.OrtValue) {
+	f.releaseValue(value)
+}
+
+func (f *Funcs) KernelContextGetInputCount(context api.OrtKernelContext, out *uintptr) api.OrtStatus {
+	return f.kernelContextGetInputCount(context, out)
+}
+
+func (f *Funcs) KernelContextGetOutputCount(context api.OrtKernelContext, out *uintptr) api.OrtStatus {
+	return f.kernelContextGetOutputCount(context, out)
+}
+
+func (f *Funcs) KernelContextGetInput(context api.OrtKernelContext, index uintptr, out *api.OrtValue) api.OrtStatus {
+	return f.kernelContextGetInput(context, index, out)
+}
+
+func (f *Funcs) KernelContextGetOutput(context api.OrtKernelContext, index uintptr, dims *int64, numDims uintptr, out *api.OrtValue) api.OrtStatus {
+	return f.kernelContextGetOutput(context, index, dims, numDims, out)
+}
+
+func (f *Funcs) ReleaseTensorTypeAndShapeInfo(typeAndShape api.OrtTensorTypeAndShapeInfo) {
+	f.releaseTensorTypeAndShapeInfo(typeAndShape)
+}
+
+// String tensor methods
+
+func (f *Funcs) FillStringTensor(value api.OrtValue, s **byte, sLen uintptr) api.OrtStatus {
+	return f.fillStringTensor(value, s, sLen)
+}
+
+func (f *Funcs) GetStringTensorDataLength(value api.OrtValue, length *uintptr) api.OrtStatus {
+	return f.getStringTensorDataLength(value, length)
+}
+
+func (f *Funcs) GetStringTensorContent(value api.OrtValue, s unsafe.Pointer, sLen uintptr, offsets *uintptr, offsetsLen uintptr) api.OrtStatus {
+	return f.getStringTensorContent(value, s, sLen, offsets, offsetsLen)
+}
+
+func (f *Funcs) GetStringTensorElementLength(value api.OrtValue, index uintptr, length *uintptr) api.OrtStatus {
+	return f.getStringTensorElementLength(value, index, length)
+}
+
+func (f *Funcs) GetStringTensorElement(value api.OrtValue, sLen uintptr, index uintptr, s unsafe.Pointer) api.OrtStatus {
+	return f.getStringTensorElement(value, sLen, index, s)
+}
+
+func (f *Funcs) FillStringTensorElement(value api.OrtValue, s *byte, index uintptr) api.OrtStatus {
+	return f.fillStringTensorElement(value, s, index)
+}
+
+// Sequence/Map methods
+
+func (f *Funcs) GetValue(value api.OrtValue, index int32, allocator api.OrtAllocator, out *api.OrtValue) api.OrtStatus {
+	return f.getValue(value, index, allocator, out)
+}
+
+func (f *Funcs) GetValueCount(value api.OrtValue, count *uintptr) api.OrtStatus {
+	return f.getValueCount(value, count)
+}
+
+func (f *Funcs) CastTypeInfoToMapTypeInfo(typeInfo api.OrtTypeInfo, mapTypeInfo *api.OrtMapTypeInfo) api.OrtStatus {
+	return f.castTypeInfoToMapTypeInfo(typeInfo, mapTypeInfo)
+}
+
+func (f *Funcs) CastTypeInfoToSequenceTypeInfo(typeInfo api.OrtTypeInfo, seqTypeInfo *api.OrtSequenceTypeInfo) api.OrtStatus {
+	return f.castTypeInfoToSequenceTypeInfo(typeInfo, seqTypeInfo)
+}
+
+func (f *Funcs) GetMapKeyType(mapTypeInfo api.OrtMapTypeInfo, keyType *api.ONNXTensorElementDataType) api.OrtStatus {
+	return f.getMapKeyType(mapTypeInfo, keyType)
+}
+
+func (f *Funcs) GetSequenceElementType(seqTypeInfo api.OrtSequenceTypeInfo, typeInfo *api.OrtTypeInfo) api.OrtStatus {
+	return f.getSequenceElementType(seqTypeInfo, typeInfo)
+}
+
+func (f *Funcs) ReleaseMapTypeInfo(mapTypeInfo api.OrtMapTypeInfo) {
+	f.releaseMapTypeInfo(mapTypeInfo)
+}
+
+func (f *Funcs) ReleaseSequenceTypeInfo(seqTypeInfo api.OrtSequenceTypeInfo) {
+	f.releaseSequenceTypeInfo(seqTypeInfo)
+}
+
+// IO Binding methods
+
+func (f *Funcs) CreateIoBinding(session api.OrtSession, binding *api.OrtIoBinding) api.OrtStatus {
+	return f.createIoBinding(session, binding)
+}
+
+func (f *Funcs) ReleaseIoBinding(binding api.OrtIoBinding) {
+	f.releaseIoBinding(binding)
+}
+
+func (f *Funcs) BindInput(binding api.OrtIoBinding, name *byte, value api.OrtValue) api.OrtStatus {
+	return f.bindInput(binding, name, value)
+}
+
+func (f *Funcs) BindOutput(binding api.OrtIoBinding, name *byte, value api.OrtValue) api.OrtStatus {
+	return f.bindOutput(binding, name, value)
+}
+
+func (f *Funcs) BindOutputToDevice(binding api.OrtIoBinding, name *byte, memInfo api.OrtMemoryInfo) api.OrtStatus {
+	return f.bindOutputToDevice(binding, name, memInfo)
+}
+
+func (f *Funcs) GetBoundOutputNames(binding api.OrtIoBinding, allocator api.OrtAllocator, buffer **byte, lengths *uintptr, count *uintptr) api.OrtStatus {
+	return f.getBoundOutputNames(binding, allocator, buffer, lengths, count)
+}
+
+func (f *Funcs) GetBoundOutputValues(binding api.OrtIoBinding, allocator api.OrtAllocator, output **api.OrtValue, count *uintptr) api.OrtStatus {
+	return f.getBoundOutputValues(binding, allocator, output, count)
+}
+
+func (f *Funcs) ClearBoundInputs(binding api.OrtIoBinding) {
+	f.clearBoundInputs(binding)
+}
+
+func (f *Funcs) ClearBoundOutputs(binding api.OrtIoBinding) {
+	f.clearBoundOutputs(binding)
+}
+
+func (f *Funcs) RunWithBinding(session api.OrtSession, runOptions api.OrtRunOptions, binding api.OrtIoBinding) api.OrtStatus {
+	return f.runWithBinding(session, runOptions, binding)
+}
+
+func (f *Funcs) SynchronizeBoundInputs(binding api.OrtIoBinding) api.OrtStatus {
+	return f.synchronizeBoundInputs(binding)
+}
+
+func (f *Funcs) SynchronizeBoundOutputs(binding api.OrtIoBinding) api.OrtStatus {
+	return f.synchronizeBoundOutputs(binding)
+}
+
+// Execution provider information methods
+
+func (f *Funcs) GetAvailableProviders(providers ***byte, length *int32) api.OrtStatus {
+	return f.getAvailableProviders(providers, length)
+}
+
+func (f *Funcs) ReleaseAvailableProviders(providers **byte, length int32) api.OrtStatus {
+	return f.releaseAvailableProviders(providers, length)
+}
+
+// Prepacked weights methods
+
+func (f *Funcs) CreatePrepackedWeightsContainer(container *api.OrtPrepackedWeightsContainer) api.OrtStatus {
+	return f.createPrepackedWeightsContainer(container)
+}
+
+func (f *Funcs) ReleasePrepackedWeightsContainer(container api.OrtPrepackedWeightsContainer) {
+	f.releasePrepackedWeightsContainer(container)
+}
+
+func (f *Funcs) CreateSessionWithPrepackedWeightsContainer(env api.OrtEnv, modelPath *byte, options api.OrtSessionOptions, prepackedWeightsContainer api.OrtPrepackedWeightsContainer, session *api.OrtSession) api.OrtStatus {
+	return f.createSessionWithPrepackedWeightsContainer(env, modelPath, options, prepackedWeightsContainer, session)
+}
+
+func (f *Funcs) CreateSessionFromArrayWithPrepackedWeightsContainer(env api.OrtEnv, modelData unsafe.Pointer, modelDataLength uintptr, options api.OrtSessionOptions, prepackedWeightsContainer api.OrtPrepackedWeightsContainer, session *api.OrtSession) api.OrtStatus {
+	return f.createSessionFromArrayWithPrepackedWeightsContainer(env, modelData, modelDataLength, options, prepackedWeightsContainer, session)
+}
+
+// Threading options methods
+
+func (f *Funcs) CreateThreadingOptions(options *api.OrtThreadingOptions) api.OrtStatus {
+	return f.createThreadingOptions(options)
+}
+
+func (f *Funcs) ReleaseThreadingOptions(options api.OrtThreadingOptions) {
+	f.releaseThreadingOptions(options)
+}
+
+func (f *Funcs) SetGlobalIntraOpNumThreads(options api.OrtThreadingOptions, numThreads int32) api.OrtStatus {
+	return f.setGlobalIntraOpNumThreads(options, numThreads)
+}
+
+func (f *Funcs) SetGlobalInterOpNumThreads(options api.OrtThreadingOptions, numThreads int32) api.OrtStatus {
+	return f.setGlobalInterOpNumThreads(options, numThreads)
+}
+
+func (f *Funcs) SetGlobalSpinControl(options api.OrtThreadingOptions, allowSpinning int32) api.OrtStatus {
+	return f.setGlobalSpinControl(options, allowSpinning)
+}
+
+func (f *Funcs) SetGlobalDenormalAsZero(options api.OrtThreadingOptions) api.OrtStatus {
+	return f.setGlobalDenormalAsZero(options)
+}
+
+func (f *Funcs) SetGlobalIntraOpThreadAffinity(options api.OrtThreadingOptions, affinity *byte) api.OrtStatus {
+	return f.setGlobalIntraOpThreadAffinity(options, affinity)
+}
+
+func (f *Funcs) SetGlobalCustomCreateThreadFn(options api.OrtThreadingOptions, fn uintptr) api.OrtStatus {
+	return f.setGlobalCustomCreateThreadFn(options, fn)
+}
+
+func (f *Funcs) SetGlobalCustomThreadCreationOptions(options api.OrtThreadingOptions, creationOptions uintptr) api.OrtStatus {
+	return f.setGlobalCustomThreadCreationOptions(options, creationOptions)
+}
+
+func (f *Funcs) SetGlobalCustomJoinThreadFn(options api.OrtThreadingOptions, fn uintptr) api.OrtStatus {
+	return f.setGlobalCustomJoinThreadFn(options, fn)
+}
+
+func (f *Funcs) IsSparseTensor(value api.OrtValue, out *int32) api.OrtStatus {
+	return f.isSparseTensor(value, out)
+}
+
+func (f *Funcs) CreateSparseTensorWithValuesAsOrtValue(info api.OrtMemoryInfo, values unsafe.Pointer, denseShape *int64, denseShapeLen uintptr, valuesShape *int64, valuesShapeLen uintptr, dataType api.ONNXTensorElementDataType, out *api.OrtValue) api.OrtStatus {
+	return f.createSparseTensorWithValuesAsOrtValue(info, values, denseShape, denseShapeLen, valuesShape, valuesShapeLen, dataType, out)
+}
+
+func (f *Funcs) UseCooIndices(value api.OrtValue, indices *int64, indicesNum uintptr) api.OrtStatus {
+	return f.useCooIndices(value, indices, indicesNum)
+}
+
+func (f *Funcs) UseCsrIndices(value api.OrtValue, inner *int64, innerNum uintptr, outer *int64, outerNum uintptr) api.OrtStatus {
+	return f.useCsrIndices(value, inner, innerNum, outer, outerNum)
+}
+
+func (f *Funcs) GetSparseTensorFormat(value api.OrtValue, out *api.OrtSparseFormat) api.OrtStatus {
+	return f.getSparseTensorFormat(value, out)
+}
+
+func (f *Funcs) GetSparseTensorValuesTypeAndShape(value api.OrtValue, out *api.OrtTensorTypeAndShapeInfo) api.OrtStatus {
+	return f.getSparseTensorValuesTypeAndShape(value, out)
+}
+
+func (f *Funcs) GetSparseTensorValues(value api.OrtValue, out *unsafe.Pointer) api.OrtStatus {
+	return f.getSparseTensorValues(value, out)
+}
+
+func (f *Funcs) GetSparseTensorIndicesTypeShape(value api.OrtValue, format api.OrtSparseIndicesFormat, out *api.OrtTensorTypeAndShapeInfo) api.OrtStatus {
+	return f.getSparseTensorIndicesTypeShape(value, format, out)
+}
+
+func (f *Funcs) GetSparseTensorIndices(value api.OrtValue, format api.OrtSparseIndicesFormat, num *uintptr, out *unsafe.Pointer) api.OrtStatus {
+	return f.getSparseTensorIndices(value, format, num, out)
+}
+
+// CreateModelCompilationOptionsFromSessionOptions was added in API version 22.
+func (f *Funcs) CreateModelCompilationOptionsFromSessionOptions(env api.OrtEnv, options api.OrtSessionOptions, out *api.OrtModelCompilationOptions) api.OrtStatus {
+	return f.notImplemented("CreateModelCompilationOptionsFromSessionOptions")
+}
+
+// ReleaseModelCompilationOptions was added in API version 22.
+func (f *Funcs) ReleaseModelCompilationOptions(options api.OrtModelCompilationOptions) {}
+
+// ModelCompilationOptionsSetInputModelPath was added in API version 22.
+func (f *Funcs) ModelCompilationOptionsSetInputModelPath(options api.OrtModelCompilationOptions, path *byte) api.OrtStatus {
+	return f.notImplemented("ModelCompilationOptions_SetInputModelPath")
+}
+
+// ModelCompilationOptionsSetInputModelFromBuffer was added in API version 22.
+func (f *Funcs) ModelCompilationOptionsSetInputModelFromBuffer(options api.OrtModelCompilationOptions, data unsafe.Pointer, size uintptr) api.OrtStatus {
+	return f.notImplemented("ModelCompilationOptions_SetInputModelFromBuffer")
+}
+
+// ModelCompilationOptionsSetOutputModelPath was added in API version 22.
+func (f *Funcs) ModelCompilationOptionsSetOutputModelPath(options api.OrtModelCompilationOptions, path *byte) api.OrtStatus {
+	return f.notImplemented("ModelCompilationOptions_SetOutputModelPath")
+}
+
+// ModelCompilationOptionsSetOutputModelExternalInitializersFile was added in API version 22.
+func (f *Funcs) ModelCompilationOptionsSetOutputModelExternalInitializersFile(options api.OrtModelCompilationOptions, path *byte, sizeThreshold uintptr) api.OrtStatus {
+	return f.notImplemented("ModelCompilationOptions_SetOutputModelExternalInitializersFile")
+}
+
+// ModelCompilationOptionsSetEpContextEmbedMode was added in API version 22.
+func (f *Funcs) ModelCompilationOptionsSetEpContextEmbedMode(options api.OrtModelCompilationOptions, embed bool) api.OrtStatus {
+	return f.notImplemented("ModelCompilationOptions_SetEpContextEmbedMode")
+}
+
+// CompileModel was added in API version 22.
+func (f *Funcs) CompileModel(env api.OrtEnv, options api.OrtModelCompilationOptions) api.OrtStatus {
+	return f.notImplemented("CompileModel")
+}
+
+// HasTrainingAPI reports whether the library provides the training API.
+func (f *Funcs) HasTrainingAPI() bool {
+	return f.trainStep != nil
+}
+
+func (f *Funcs) LoadCheckpoint(path *byte, out *api.OrtCheckpointState) api.OrtStatus {
+	return f.loadCheckpoint(path, out)
+}
+
+func (f *Funcs) SaveCheckpoint(state api.OrtCheckpointState, path *byte, includeOptimizerState bool) api.OrtStatus {
+	return f.saveCheckpoint(state, path, includeOptimizerState)
+}
+
+func (f *Funcs) ReleaseCheckpointState(state api.OrtCheckpointState) {
+	f.releaseCheckpointState(state)
+}
+
+func (f *Funcs) CreateTrainingSession(env api.OrtEnv, options api.OrtSessionOptions, state api.OrtCheckpointState, trainModelPath, evalModelPath, optimizerModelPath *byte, out *api.OrtTrainingSession) api.OrtStatus {
+	return f.createTrainingSession(env, options, state, trainModelPath, evalModelPath, optimizerModelPath, out)
+}
+
+func (f *Funcs) ReleaseTrainingSession(session api.OrtTrainingSession) {
+	f.releaseTrainingSession(session)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelInputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelInputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelInputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelInputName(session, index, allocator, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelInputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetEvalModelInputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelInputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetEvalModelInputName(session, index, allocator, out)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelOutputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelOutputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelOutputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelOutputName(session, index, allocator, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelOutputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetEvalModelOutputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelOutputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetEvalModelOutputName(session, index, allocator, out)
+}
+
+func (f *Funcs) LazyResetGrad(session api.OrtTrainingSession) api.OrtStatus {
+	return f.lazyResetGrad(session)
+}
+
+func (f *Funcs) TrainStep(session api.OrtTrainingSession, runOptions api.OrtRunOptions, inputsLen uintptr, inputs *api.OrtValue, outputsLen uintptr, outputs *api.OrtValue) api.OrtStatus {
+	return f.trainStep(session, runOptions, inputsLen, inputs, outputsLen, outputs)
+}
+
+func (f *Funcs) EvalStep(session api.OrtTrainingSession, runOptions api.OrtRunOptions, inputsLen uintptr, inputs *api.OrtValue, outputsLen uintptr, outputs *api.OrtValue) api.OrtStatus {
+	return f.evalStep(session, runOptions, inputsLen, inputs, outputsLen, outputs)
+}
+
+func (f *Funcs) SetLearningRate(session api.OrtTrainingSession, learningRate float32) api.OrtStatus {
+	return f.setLearningRate(session, learningRate)
+}
+
+func (f *Funcs) GetLearningRate(session api.OrtTrainingSession, out *float32) api.OrtStatus {
+	return f.getLearningRate(session, out)
+}
+
+func (f *Funcs) OptimizerStep(session api.OrtTrainingSession, runOptions api.OrtRunOptions) api.OrtStatus {
+	return f.optimizerStep(session, runOptions)
+}
+
+func (f *Funcs) ExportModelForInferencing(session api.OrtTrainingSession, inferenceModelPath *byte, outputNamesLen uintptr, outputNames **byte) api.OrtStatus {
+	return f.exportModelForInferencing(session, inferenceModelPath, outputNamesLen, outputNames)
+}
+
+// errorCodeNotImplemented is ORT_NOT_IMPLEMENTED from onnxruntime_c_api.h.
+const errorCodeNotImplemented api.OrtErrorCode = 9
+
+// notImplemented returns a status reporting that name is not part of this
+// API version. The library copies the message, so it need not outlive the call.
+func (f *Funcs) notImplemented(name string) api.OrtStatus {
+	msg := append([]byte(fmt.Sprintf("%s requires ONNX Runtime API version newer than %d", name, APIVersion)), 0)
+	return f.createStatus(errorCodeNotImplemented, &msg[0])
+}
@@ -0,0 +1,3 @@
+package v21
+
+//go:generate go run github.com/benedoc-inc/onnxer/tools/codegen/funcs -from ../v24 -out .
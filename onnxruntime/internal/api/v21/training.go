@@ -0,0 +1,33 @@
+package v21
+
+// TrainingAPI contains function pointers to the OrtTrainingApi returned by
+// GetTrainingApi. Field order MUST match onnxruntime_training_c_api.h.
+type TrainingAPI struct {
+	LoadCheckpoint                             uintptr // 0
+	SaveCheckpoint                             uintptr // 1
+	CreateTrainingSession                      uintptr // 2
+	CreateTrainingSessionFromBuffer            uintptr // 3
+	TrainingSessionGetTrainingModelOutputCount uintptr // 4
+	TrainingSessionGetEvalModelOutputCount     uintptr // 5
+	TrainingSessionGetTrainingModelOutputName  uintptr // 6
+	TrainingSessionGetEvalModelOutputName      uintptr // 7
+	LazyResetGrad                              uintptr // 8
+	TrainStep                                  uintptr // 9
+	EvalStep                                   uintptr // 10
+	SetLearningRate                            uintptr // 11
+	GetLearningRate                            uintptr // 12
+	OptimizerStep                              uintptr // 13
+	RegisterLinearLRScheduler                  uintptr // 14
+	SchedulerStep                              uintptr // 15
+	GetParametersSize                          uintptr // 16
+	CopyParametersToBuffer                     uintptr // 17
+	CopyBufferToParameters                     uintptr // 18
+	ReleaseTrainingSession                     uintptr // 19
+	ReleaseCheckpointState                     uintptr // 20
+	ExportModelForInferencing                  uintptr // 21
+	SetSeed                                    uintptr // 22
+	TrainingSessionGetTrainingModelInputCount  uintptr // 23
+	TrainingSessionGetEvalModelInputCount      uintptr // 24
+	TrainingSessionGetTrainingModelInputName   uintptr // 25
+	TrainingSessionGetEvalModelInputName       uintptr // 26
+}
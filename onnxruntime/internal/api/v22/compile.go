@@ -0,0 +1,16 @@
+package v22
+
+// CompileAPI contains function pointers to the OrtCompileApi returned by
+// GetCompileApi. Only the functions shared by every supported release are
+// listed; field order MUST match onnxruntime_c_api.h.
+type CompileAPI struct {
+	ReleaseModelCompilationOptions                                 uintptr // 0
+	CreateModelCompilationOptionsFromSessionOptions                uintptr // 1
+	ModelCompilationOptions_SetInputModelPath                      uintptr // 2
+	ModelCompilationOptions_SetInputModelFromBuffer                uintptr // 3
+	ModelCompilationOptions_SetOutputModelPath                     uintptr // 4
+	ModelCompilationOptions_SetOutputModelExternalInitializersFile uintptr // 5
+	ModelCompilationOptions_SetOutputModelBuffer                   uintptr // 6
+	ModelCompilationOptions_SetEpContextEmbedMode                  uintptr // 7
+	CompileModel                                                   uintptr // 8
+}
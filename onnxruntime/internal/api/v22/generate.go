@@ -0,0 +1,3 @@
+package v22
+
+//go:generate go run github.com/benedoc-inc/onnxer/tools/codegen/funcs -from ../v24 -out .
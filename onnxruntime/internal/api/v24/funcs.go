@@ -19,40 +19,70 @@ type Funcs struct {
 	// Environment
 	createEnv                      func(api.OrtLoggingLevel, *byte, *api.OrtEnv) api.OrtStatus
 	createEnvWithGlobalThreadPools func(api.OrtLoggingLevel, *byte, api.OrtThreadingOptions, *api.OrtEnv) api.OrtStatus
+	createEnvWithCustomLogger      func(uintptr, uintptr, api.OrtLoggingLevel, *byte, *api.OrtEnv) api.OrtStatus
 	releaseEnv                     func(api.OrtEnv)
+	createAndRegisterAllocator     func(api.OrtEnv, api.OrtMemoryInfo, api.OrtArenaCfg) api.OrtStatus
+	unregisterAllocator            func(api.OrtEnv, api.OrtMemoryInfo) api.OrtStatus
 
 	// Allocator
 	getAllocatorWithDefaultOptions func(*api.OrtAllocator) api.OrtStatus
 	allocatorFree                  func(api.OrtAllocator, unsafe.Pointer)
+	createAllocator                func(api.OrtSession, api.OrtMemoryInfo, *api.OrtAllocator) api.OrtStatus
+	releaseAllocator               func(api.OrtAllocator)
+	createArenaCfgV2               func(**byte, *uintptr, uintptr, *api.OrtArenaCfg) api.OrtStatus
+	releaseArenaCfg                func(api.OrtArenaCfg)
+	allocatorGetStats              func(api.OrtAllocator, *api.OrtKeyValuePairs) api.OrtStatus
+
+	// Key-value pairs
+	getKeyValuePairs     func(api.OrtKeyValuePairs, ***byte, ***byte, *uintptr)
+	releaseKeyValuePairs func(api.OrtKeyValuePairs)
 
 	// Memory info
-	createCpuMemoryInfo func(api.OrtAllocatorType, api.OrtMemType, *api.OrtMemoryInfo) api.OrtStatus
-	releaseMemoryInfo   func(api.OrtMemoryInfo)
+	createCpuMemoryInfo     func(api.OrtAllocatorType, api.OrtMemType, *api.OrtMemoryInfo) api.OrtStatus
+	createMemoryInfo        func(*byte, api.OrtAllocatorType, int32, api.OrtMemType, *api.OrtMemoryInfo) api.OrtStatus
+	memoryInfoGetName       func(api.OrtMemoryInfo, **byte) api.OrtStatus
+	memoryInfoGetId         func(api.OrtMemoryInfo, *int32) api.OrtStatus
+	memoryInfoGetMemType    func(api.OrtMemoryInfo, *api.OrtMemType) api.OrtStatus
+	memoryInfoGetType       func(api.OrtMemoryInfo, *api.OrtAllocatorType) api.OrtStatus
+	memoryInfoGetDeviceType func(api.OrtMemoryInfo, *api.OrtMemoryInfoDeviceType)
+	releaseMemoryInfo       func(api.OrtMemoryInfo)
 
 	// Telemetry
 	enableTelemetryEvents  func(api.OrtEnv) api.OrtStatus
 	disableTelemetryEvents func(api.OrtEnv) api.OrtStatus
 
 	// Session options
-	createSessionOptions                  func(*api.OrtSessionOptions) api.OrtStatus
-	setOptimizedModelFilePath             func(api.OrtSessionOptions, *byte) api.OrtStatus
-	setIntraOpNumThreads                  func(api.OrtSessionOptions, int32) api.OrtStatus
-	setInterOpNumThreads                  func(api.OrtSessionOptions, int32) api.OrtStatus
-	setSessionExecutionMode               func(api.OrtSessionOptions, int32) api.OrtStatus
-	setSessionGraphOptimizationLevel      func(api.OrtSessionOptions, int32) api.OrtStatus
-	enableCpuMemArena                     func(api.OrtSessionOptions) api.OrtStatus
-	disableCpuMemArena                    func(api.OrtSessionOptions) api.OrtStatus
-	enableMemPattern                      func(api.OrtSessionOptions) api.OrtStatus
-	disableMemPattern                     func(api.OrtSessionOptions) api.OrtStatus
-	setSessionLogSeverityLevel            func(api.OrtSessionOptions, int32) api.OrtStatus
-	addSessionConfigEntry                 func(api.OrtSessionOptions, *byte, *byte) api.OrtStatus
-	addFreeDimensionOverrideByName        func(api.OrtSessionOptions, *byte, int64) api.OrtStatus
-	setDeterministicCompute               func(api.OrtSessionOptions, int32) api.OrtStatus
-	disablePerSessionThreads              func(api.OrtSessionOptions) api.OrtStatus
-	enableProfiling                       func(api.OrtSessionOptions, *byte) api.OrtStatus
-	disableProfiling                      func(api.OrtSessionOptions) api.OrtStatus
-	sessionOptionsAppendExecutionProvider func(api.OrtSessionOptions, *byte, **byte, **byte, uintptr) api.OrtStatus
-	releaseSessionOptions                 func(api.OrtSessionOptions)
+	createSessionOptions                        func(*api.OrtSessionOptions) api.OrtStatus
+	setOptimizedModelFilePath                   func(api.OrtSessionOptions, *byte) api.OrtStatus
+	setIntraOpNumThreads                        func(api.OrtSessionOptions, int32) api.OrtStatus
+	setInterOpNumThreads                        func(api.OrtSessionOptions, int32) api.OrtStatus
+	setSessionExecutionMode                     func(api.OrtSessionOptions, int32) api.OrtStatus
+	setSessionGraphOptimizationLevel            func(api.OrtSessionOptions, int32) api.OrtStatus
+	enableCpuMemArena                           func(api.OrtSessionOptions) api.OrtStatus
+	disableCpuMemArena                          func(api.OrtSessionOptions) api.OrtStatus
+	enableMemPattern                            func(api.OrtSessionOptions) api.OrtStatus
+	disableMemPattern                           func(api.OrtSessionOptions) api.OrtStatus
+	setSessionLogSeverityLevel                  func(api.OrtSessionOptions, int32) api.OrtStatus
+	addSessionConfigEntry                       func(api.OrtSessionOptions, *byte, *byte) api.OrtStatus
+	addFreeDimensionOverride                    func(api.OrtSessionOptions, *byte, int64) api.OrtStatus
+	addFreeDimensionOverrideByName              func(api.OrtSessionOptions, *byte, int64) api.OrtStatus
+	addExternalInitializers                     func(api.OrtSessionOptions, **byte, *api.OrtValue, uintptr) api.OrtStatus
+	addExternalInitializersFromFilesInMemory    func(api.OrtSessionOptions, **byte, **byte, *uintptr, uintptr) api.OrtStatus
+	createCustomOpDomain                        func(*byte, *api.OrtCustomOpDomain) api.OrtStatus
+	customOpDomainAdd                           func(api.OrtCustomOpDomain, unsafe.Pointer) api.OrtStatus
+	addCustomOpDomain                           func(api.OrtSessionOptions, api.OrtCustomOpDomain) api.OrtStatus
+	releaseCustomOpDomain                       func(api.OrtCustomOpDomain)
+	setDeterministicCompute                     func(api.OrtSessionOptions, int32) api.OrtStatus
+	disablePerSessionThreads                    func(api.OrtSessionOptions) api.OrtStatus
+	enableProfiling                             func(api.OrtSessionOptions, *byte) api.OrtStatus
+	disableProfiling                            func(api.OrtSessionOptions) api.OrtStatus
+	sessionOptionsAppendExecutionProvider       func(api.OrtSessionOptions, *byte, **byte, **byte, uintptr) api.OrtStatus
+	sessionOptionsAppendExecutionProviderCUDAV2 func(api.OrtSessionOptions, api.OrtCUDAProviderOptionsV2) api.OrtStatus
+	createCUDAProviderOptions                   func(*api.OrtCUDAProviderOptionsV2) api.OrtStatus
+	updateCUDAProviderOptions                   func(api.OrtCUDAProviderOptionsV2, **byte, **byte, uintptr) api.OrtStatus
+	updateCUDAProviderOptionsWithValue          func(api.OrtCUDAProviderOptionsV2, *byte, uintptr) api.OrtStatus
+	releaseCUDAProviderOptions                  func(api.OrtCUDAProviderOptionsV2)
+	releaseSessionOptions                       func(api.OrtSessionOptions)
 
 	// Run options
 	createRunOptions               func(*api.OrtRunOptions) api.OrtStatus
@@ -71,11 +101,14 @@ type Funcs struct {
 	sessionGetInputName    func(api.OrtSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
 	sessionGetOutputName   func(api.OrtSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
 	run                    func(api.OrtSession, api.OrtRunOptions, **byte, *api.OrtValue, uintptr, **byte, uintptr, *api.OrtValue) api.OrtStatus
+	runAsync               func(api.OrtSession, api.OrtRunOptions, **byte, *api.OrtValue, uintptr, **byte, uintptr, *api.OrtValue, uintptr, uintptr) api.OrtStatus
 	releaseSession         func(api.OrtSession)
 
 	// Profiling
 	sessionEndProfiling            func(api.OrtSession, api.OrtAllocator, **byte) api.OrtStatus
 	sessionGetProfilingStartTimeNs func(api.OrtSession, *uint64) api.OrtStatus
+	sessionGetMemoryInfoForInputs  func(api.OrtSession, *api.OrtMemoryInfo, uintptr) api.OrtStatus
+	sessionGetMemoryInfoForOutputs func(api.OrtSession, *api.OrtMemoryInfo, uintptr) api.OrtStatus
 
 	// LoRA adapters
 	createLoraAdapter          func(*byte, api.OrtAllocator, *api.OrtLoraAdapter) api.OrtStatus
@@ -117,6 +150,10 @@ type Funcs struct {
 	getDimensions                  func(api.OrtTensorTypeAndShapeInfo, *int64, uintptr) api.OrtStatus
 	getTensorShapeElementCount     func(api.OrtTensorTypeAndShapeInfo, *uintptr) api.OrtStatus
 	releaseValue                   func(api.OrtValue)
+	kernelContextGetInputCount     func(api.OrtKernelContext, *uintptr) api.OrtStatus
+	kernelContextGetOutputCount    func(api.OrtKernelContext, *uintptr) api.OrtStatus
+	kernelContextGetInput          func(api.OrtKernelContext, uintptr, *api.OrtValue) api.OrtStatus
+	kernelContextGetOutput         func(api.OrtKernelContext, uintptr, *int64, uintptr, *api.OrtValue) api.OrtStatus
 	releaseTensorTypeAndShapeInfo  func(api.OrtTensorTypeAndShapeInfo)
 
 	// String tensor operations
@@ -162,11 +199,59 @@ type Funcs struct {
 	createSessionFromArrayWithPrepackedWeightsContainer func(api.OrtEnv, unsafe.Pointer, uintptr, api.OrtSessionOptions, api.OrtPrepackedWeightsContainer, *api.OrtSession) api.OrtStatus
 
 	// Threading options
-	createThreadingOptions     func(*api.OrtThreadingOptions) api.OrtStatus
-	releaseThreadingOptions    func(api.OrtThreadingOptions)
-	setGlobalIntraOpNumThreads func(api.OrtThreadingOptions, int32) api.OrtStatus
-	setGlobalInterOpNumThreads func(api.OrtThreadingOptions, int32) api.OrtStatus
-	setGlobalSpinControl       func(api.OrtThreadingOptions, int32) api.OrtStatus
+	createThreadingOptions               func(*api.OrtThreadingOptions) api.OrtStatus
+	releaseThreadingOptions              func(api.OrtThreadingOptions)
+	setGlobalIntraOpNumThreads           func(api.OrtThreadingOptions, int32) api.OrtStatus
+	setGlobalInterOpNumThreads           func(api.OrtThreadingOptions, int32) api.OrtStatus
+	setGlobalSpinControl                 func(api.OrtThreadingOptions, int32) api.OrtStatus
+	setGlobalDenormalAsZero              func(api.OrtThreadingOptions) api.OrtStatus
+	setGlobalIntraOpThreadAffinity       func(api.OrtThreadingOptions, *byte) api.OrtStatus
+	setGlobalCustomCreateThreadFn        func(api.OrtThreadingOptions, uintptr) api.OrtStatus
+	setGlobalCustomThreadCreationOptions func(api.OrtThreadingOptions, uintptr) api.OrtStatus
+	setGlobalCustomJoinThreadFn          func(api.OrtThreadingOptions, uintptr) api.OrtStatus
+
+	// Sparse tensors
+	isSparseTensor                         func(api.OrtValue, *int32) api.OrtStatus
+	createSparseTensorWithValuesAsOrtValue func(api.OrtMemoryInfo, unsafe.Pointer, *int64, uintptr, *int64, uintptr, api.ONNXTensorElementDataType, *api.OrtValue) api.OrtStatus
+	useCooIndices                          func(api.OrtValue, *int64, uintptr) api.OrtStatus
+	useCsrIndices                          func(api.OrtValue, *int64, uintptr, *int64, uintptr) api.OrtStatus
+	getSparseTensorFormat                  func(api.OrtValue, *api.OrtSparseFormat) api.OrtStatus
+	getSparseTensorValuesTypeAndShape      func(api.OrtValue, *api.OrtTensorTypeAndShapeInfo) api.OrtStatus
+	getSparseTensorValues                  func(api.OrtValue, *unsafe.Pointer) api.OrtStatus
+	getSparseTensorIndicesTypeShape        func(api.OrtValue, api.OrtSparseIndicesFormat, *api.OrtTensorTypeAndShapeInfo) api.OrtStatus
+	getSparseTensorIndices                 func(api.OrtValue, api.OrtSparseIndicesFormat, *uintptr, *unsafe.Pointer) api.OrtStatus
+
+	// Compile API, nil when the library does not provide one
+	createModelCompilationOptionsFromSessionOptions               func(api.OrtEnv, api.OrtSessionOptions, *api.OrtModelCompilationOptions) api.OrtStatus
+	releaseModelCompilationOptions                                func(api.OrtModelCompilationOptions)
+	modelCompilationOptionsSetInputModelPath                      func(api.OrtModelCompilationOptions, *byte) api.OrtStatus
+	modelCompilationOptionsSetInputModelFromBuffer                func(api.OrtModelCompilationOptions, unsafe.Pointer, uintptr) api.OrtStatus
+	modelCompilationOptionsSetOutputModelPath                     func(api.OrtModelCompilationOptions, *byte) api.OrtStatus
+	modelCompilationOptionsSetOutputModelExternalInitializersFile func(api.OrtModelCompilationOptions, *byte, uintptr) api.OrtStatus
+	modelCompilationOptionsSetEpContextEmbedMode                  func(api.OrtModelCompilationOptions, bool) api.OrtStatus
+	compileModel                                                  func(api.OrtEnv, api.OrtModelCompilationOptions) api.OrtStatus
+
+	// Training API, nil unless the library is a training build
+	loadCheckpoint                             func(*byte, *api.OrtCheckpointState) api.OrtStatus
+	saveCheckpoint                             func(api.OrtCheckpointState, *byte, bool) api.OrtStatus
+	releaseCheckpointState                     func(api.OrtCheckpointState)
+	createTrainingSession                      func(api.OrtEnv, api.OrtSessionOptions, api.OrtCheckpointState, *byte, *byte, *byte, *api.OrtTrainingSession) api.OrtStatus
+	releaseTrainingSession                     func(api.OrtTrainingSession)
+	trainingSessionGetTrainingModelInputCount  func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetTrainingModelInputName   func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	trainingSessionGetEvalModelInputCount      func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetEvalModelInputName       func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	trainingSessionGetTrainingModelOutputCount func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetTrainingModelOutputName  func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	trainingSessionGetEvalModelOutputCount     func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+	trainingSessionGetEvalModelOutputName      func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+	lazyResetGrad                              func(api.OrtTrainingSession) api.OrtStatus
+	trainStep                                  func(api.OrtTrainingSession, api.OrtRunOptions, uintptr, *api.OrtValue, uintptr, *api.OrtValue) api.OrtStatus
+	evalStep                                   func(api.OrtTrainingSession, api.OrtRunOptions, uintptr, *api.OrtValue, uintptr, *api.OrtValue) api.OrtStatus
+	setLearningRate                            func(api.OrtTrainingSession, float32) api.OrtStatus
+	getLearningRate                            func(api.OrtTrainingSession, *float32) api.OrtStatus
+	optimizerStep                              func(api.OrtTrainingSession, api.OrtRunOptions) api.OrtStatus
+	exportModelForInferencing                  func(api.OrtTrainingSession, *byte, uintptr, **byte) api.OrtStatus
 }
 
 // InitializeFuncs initializes the v24 API function pointers from the library handle.
@@ -201,15 +286,32 @@ func InitializeFuncs(libraryHandle uintptr) (*Funcs, error) {
 
 	purego.RegisterFunc(&funcs.createEnv, api.CreateEnv)
 	purego.RegisterFunc(&funcs.createEnvWithGlobalThreadPools, api.CreateEnvWithGlobalThreadPools)
+	purego.RegisterFunc(&funcs.createEnvWithCustomLogger, api.CreateEnvWithCustomLogger)
 	purego.RegisterFunc(&funcs.releaseEnv, api.ReleaseEnv)
+	purego.RegisterFunc(&funcs.createAndRegisterAllocator, api.CreateAndRegisterAllocator)
+	purego.RegisterFunc(&funcs.unregisterAllocator, api.UnregisterAllocator)
 
 	purego.RegisterFunc(&funcs.enableTelemetryEvents, api.EnableTelemetryEvents)
 	purego.RegisterFunc(&funcs.disableTelemetryEvents, api.DisableTelemetryEvents)
 
 	purego.RegisterFunc(&funcs.getAllocatorWithDefaultOptions, api.GetAllocatorWithDefaultOptions)
 	purego.RegisterFunc(&funcs.allocatorFree, api.AllocatorFree)
+	purego.RegisterFunc(&funcs.createAllocator, api.CreateAllocator)
+	purego.RegisterFunc(&funcs.releaseAllocator, api.ReleaseAllocator)
+	purego.RegisterFunc(&funcs.createArenaCfgV2, api.CreateArenaCfgV2)
+	purego.RegisterFunc(&funcs.releaseArenaCfg, api.ReleaseArenaCfg)
+	purego.RegisterFunc(&funcs.allocatorGetStats, api.AllocatorGetStats)
+
+	purego.RegisterFunc(&funcs.getKeyValuePairs, api.GetKeyValuePairs)
+	purego.RegisterFunc(&funcs.releaseKeyValuePairs, api.ReleaseKeyValuePairs)
 
 	purego.RegisterFunc(&funcs.createCpuMemoryInfo, api.CreateCpuMemoryInfo)
+	purego.RegisterFunc(&funcs.createMemoryInfo, api.CreateMemoryInfo)
+	purego.RegisterFunc(&funcs.memoryInfoGetName, api.MemoryInfoGetName)
+	purego.RegisterFunc(&funcs.memoryInfoGetId, api.MemoryInfoGetId)
+	purego.RegisterFunc(&funcs.memoryInfoGetMemType, api.MemoryInfoGetMemType)
+	purego.RegisterFunc(&funcs.memoryInfoGetType, api.MemoryInfoGetType)
+	purego.RegisterFunc(&funcs.memoryInfoGetDeviceType, api.MemoryInfoGetDeviceType)
 	purego.RegisterFunc(&funcs.releaseMemoryInfo, api.ReleaseMemoryInfo)
 
 	purego.RegisterFunc(&funcs.createSessionOptions, api.CreateSessionOptions)
@@ -224,12 +326,24 @@ func InitializeFuncs(libraryHandle uintptr) (*Funcs, error) {
 	purego.RegisterFunc(&funcs.disableMemPattern, api.DisableMemPattern)
 	purego.RegisterFunc(&funcs.setSessionLogSeverityLevel, api.SetSessionLogSeverityLevel)
 	purego.RegisterFunc(&funcs.addSessionConfigEntry, api.AddSessionConfigEntry)
+	purego.RegisterFunc(&funcs.addFreeDimensionOverride, api.AddFreeDimensionOverride)
 	purego.RegisterFunc(&funcs.addFreeDimensionOverrideByName, api.AddFreeDimensionOverrideByName)
+	purego.RegisterFunc(&funcs.addExternalInitializers, api.AddExternalInitializers)
+	purego.RegisterFunc(&funcs.addExternalInitializersFromFilesInMemory, api.AddExternalInitializersFromFilesInMemory)
+	purego.RegisterFunc(&funcs.createCustomOpDomain, api.CreateCustomOpDomain)
+	purego.RegisterFunc(&funcs.customOpDomainAdd, api.CustomOpDomain_Add)
+	purego.RegisterFunc(&funcs.addCustomOpDomain, api.AddCustomOpDomain)
+	purego.RegisterFunc(&funcs.releaseCustomOpDomain, api.ReleaseCustomOpDomain)
 	purego.RegisterFunc(&funcs.setDeterministicCompute, api.SetDeterministicCompute)
 	purego.RegisterFunc(&funcs.disablePerSessionThreads, api.DisablePerSessionThreads)
 	purego.RegisterFunc(&funcs.enableProfiling, api.EnableProfiling)
 	purego.RegisterFunc(&funcs.disableProfiling, api.DisableProfiling)
 	purego.RegisterFunc(&funcs.sessionOptionsAppendExecutionProvider, api.SessionOptionsAppendExecutionProvider)
+	purego.RegisterFunc(&funcs.sessionOptionsAppendExecutionProviderCUDAV2, api.SessionOptionsAppendExecutionProvider_CUDA_V2)
+	purego.RegisterFunc(&funcs.createCUDAProviderOptions, api.CreateCUDAProviderOptions)
+	purego.RegisterFunc(&funcs.updateCUDAProviderOptions, api.UpdateCUDAProviderOptions)
+	purego.RegisterFunc(&funcs.updateCUDAProviderOptionsWithValue, api.UpdateCUDAProviderOptionsWithValue)
+	purego.RegisterFunc(&funcs.releaseCUDAProviderOptions, api.ReleaseCUDAProviderOptions)
 	purego.RegisterFunc(&funcs.releaseSessionOptions, api.ReleaseSessionOptions)
 
 	purego.RegisterFunc(&funcs.createRunOptions, api.CreateRunOptions)
@@ -247,10 +361,13 @@ func InitializeFuncs(libraryHandle uintptr) (*Funcs, error) {
 	purego.RegisterFunc(&funcs.sessionGetInputName, api.SessionGetInputName)
 	purego.RegisterFunc(&funcs.sessionGetOutputName, api.SessionGetOutputName)
 	purego.RegisterFunc(&funcs.run, api.Run)
+	purego.RegisterFunc(&funcs.runAsync, api.RunAsync)
 	purego.RegisterFunc(&funcs.releaseSession, api.ReleaseSession)
 
 	purego.RegisterFunc(&funcs.sessionEndProfiling, api.SessionEndProfiling)
 	purego.RegisterFunc(&funcs.sessionGetProfilingStartTimeNs, api.SessionGetProfilingStartTimeNs)
+	purego.RegisterFunc(&funcs.sessionGetMemoryInfoForInputs, api.SessionGetMemoryInfoForInputs)
+	purego.RegisterFunc(&funcs.sessionGetMemoryInfoForOutputs, api.SessionGetMemoryInfoForOutputs)
 
 	purego.RegisterFunc(&funcs.createLoraAdapter, api.CreateLoraAdapter)
 	purego.RegisterFunc(&funcs.createLoraAdapterFromArray, api.CreateLoraAdapterFromArray)
@@ -287,6 +404,10 @@ func InitializeFuncs(libraryHandle uintptr) (*Funcs, error) {
 	purego.RegisterFunc(&funcs.getDimensions, api.GetDimensions)
 	purego.RegisterFunc(&funcs.getTensorShapeElementCount, api.GetTensorShapeElementCount)
 	purego.RegisterFunc(&funcs.releaseValue, api.ReleaseValue)
+	purego.RegisterFunc(&funcs.kernelContextGetInputCount, api.KernelContext_GetInputCount)
+	purego.RegisterFunc(&funcs.kernelContextGetOutputCount, api.KernelContext_GetOutputCount)
+	purego.RegisterFunc(&funcs.kernelContextGetInput, api.KernelContext_GetInput)
+	purego.RegisterFunc(&funcs.kernelContextGetOutput, api.KernelContext_GetOutput)
 	purego.RegisterFunc(&funcs.releaseTensorTypeAndShapeInfo, api.ReleaseTensorTypeAndShapeInfo)
 
 	purego.RegisterFunc(&funcs.fillStringTensor, api.FillStringTensor)
@@ -331,6 +452,61 @@ func InitializeFuncs(libraryHandle uintptr) (*Funcs, error) {
 	purego.RegisterFunc(&funcs.setGlobalIntraOpNumThreads, api.SetGlobalIntraOpNumThreads)
 	purego.RegisterFunc(&funcs.setGlobalInterOpNumThreads, api.SetGlobalInterOpNumThreads)
 	purego.RegisterFunc(&funcs.setGlobalSpinControl, api.SetGlobalSpinControl)
+	purego.RegisterFunc(&funcs.setGlobalDenormalAsZero, api.SetGlobalDenormalAsZero)
+	purego.RegisterFunc(&funcs.setGlobalIntraOpThreadAffinity, api.SetGlobalIntraOpThreadAffinity)
+	purego.RegisterFunc(&funcs.setGlobalCustomCreateThreadFn, api.SetGlobalCustomCreateThreadFn)
+	purego.RegisterFunc(&funcs.setGlobalCustomThreadCreationOptions, api.SetGlobalCustomThreadCreationOptions)
+	purego.RegisterFunc(&funcs.setGlobalCustomJoinThreadFn, api.SetGlobalCustomJoinThreadFn)
+
+	purego.RegisterFunc(&funcs.isSparseTensor, api.IsSparseTensor)
+	purego.RegisterFunc(&funcs.createSparseTensorWithValuesAsOrtValue, api.CreateSparseTensorWithValuesAsOrtValue)
+	purego.RegisterFunc(&funcs.useCooIndices, api.UseCooIndices)
+	purego.RegisterFunc(&funcs.useCsrIndices, api.UseCsrIndices)
+	purego.RegisterFunc(&funcs.getSparseTensorFormat, api.GetSparseTensorFormat)
+	purego.RegisterFunc(&funcs.getSparseTensorValuesTypeAndShape, api.GetSparseTensorValuesTypeAndShape)
+	purego.RegisterFunc(&funcs.getSparseTensorValues, api.GetSparseTensorValues)
+	purego.RegisterFunc(&funcs.getSparseTensorIndicesTypeShape, api.GetSparseTensorIndicesTypeShape)
+	purego.RegisterFunc(&funcs.getSparseTensorIndices, api.GetSparseTensorIndices)
+
+	// The compile API is a separate function table; minimal builds omit it.
+	var getCompileAPI func() unsafe.Pointer
+	purego.RegisterFunc(&getCompileAPI, api.GetCompileApi)
+	if compileAPI := (*CompileAPI)(getCompileAPI()); compileAPI != nil {
+		purego.RegisterFunc(&funcs.createModelCompilationOptionsFromSessionOptions, compileAPI.CreateModelCompilationOptionsFromSessionOptions)
+		purego.RegisterFunc(&funcs.releaseModelCompilationOptions, compileAPI.ReleaseModelCompilationOptions)
+		purego.RegisterFunc(&funcs.modelCompilationOptionsSetInputModelPath, compileAPI.ModelCompilationOptions_SetInputModelPath)
+		purego.RegisterFunc(&funcs.modelCompilationOptionsSetInputModelFromBuffer, compileAPI.ModelCompilationOptions_SetInputModelFromBuffer)
+		purego.RegisterFunc(&funcs.modelCompilationOptionsSetOutputModelPath, compileAPI.ModelCompilationOptions_SetOutputModelPath)
+		purego.RegisterFunc(&funcs.modelCompilationOptionsSetOutputModelExternalInitializersFile, compileAPI.ModelCompilationOptions_SetOutputModelExternalInitializersFile)
+		purego.RegisterFunc(&funcs.modelCompilationOptionsSetEpContextEmbedMode, compileAPI.ModelCompilationOptions_SetEpContextEmbedMode)
+		purego.RegisterFunc(&funcs.compileModel, compileAPI.CompileModel)
+	}
+
+	// The training API is only present in training builds of the library.
+	var getTrainingAPI func(uint32) unsafe.Pointer
+	purego.RegisterFunc(&getTrainingAPI, api.GetTrainingApi)
+	if trainingAPI := (*TrainingAPI)(getTrainingAPI(APIVersion)); trainingAPI != nil {
+		purego.RegisterFunc(&funcs.loadCheckpoint, trainingAPI.LoadCheckpoint)
+		purego.RegisterFunc(&funcs.saveCheckpoint, trainingAPI.SaveCheckpoint)
+		purego.RegisterFunc(&funcs.releaseCheckpointState, trainingAPI.ReleaseCheckpointState)
+		purego.RegisterFunc(&funcs.createTrainingSession, trainingAPI.CreateTrainingSession)
+		purego.RegisterFunc(&funcs.releaseTrainingSession, trainingAPI.ReleaseTrainingSession)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelInputCount, trainingAPI.TrainingSessionGetTrainingModelInputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelInputName, trainingAPI.TrainingSessionGetTrainingModelInputName)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelInputCount, trainingAPI.TrainingSessionGetEvalModelInputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelInputName, trainingAPI.TrainingSessionGetEvalModelInputName)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelOutputCount, trainingAPI.TrainingSessionGetTrainingModelOutputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetTrainingModelOutputName, trainingAPI.TrainingSessionGetTrainingModelOutputName)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelOutputCount, trainingAPI.TrainingSessionGetEvalModelOutputCount)
+		purego.RegisterFunc(&funcs.trainingSessionGetEvalModelOutputName, trainingAPI.TrainingSessionGetEvalModelOutputName)
+		purego.RegisterFunc(&funcs.lazyResetGrad, trainingAPI.LazyResetGrad)
+		purego.RegisterFunc(&funcs.trainStep, trainingAPI.TrainStep)
+		purego.RegisterFunc(&funcs.evalStep, trainingAPI.EvalStep)
+		purego.RegisterFunc(&funcs.setLearningRate, trainingAPI.SetLearningRate)
+		purego.RegisterFunc(&funcs.getLearningRate, trainingAPI.GetLearningRate)
+		purego.RegisterFunc(&funcs.optimizerStep, trainingAPI.OptimizerStep)
+		purego.RegisterFunc(&funcs.exportModelForInferencing, trainingAPI.ExportModelForInferencing)
+	}
 
 	return funcs, nil
 }
@@ -363,10 +539,22 @@ func (f *Funcs) CreateEnvWithGlobalThreadPools(logLevel api.OrtLoggingLevel, log
 	return f.createEnvWithGlobalThreadPools(logLevel, logID, threadingOptions, env)
 }
 
+func (f *Funcs) CreateEnvWithCustomLogger(loggingFunction uintptr, loggerParam uintptr, logLevel api.OrtLoggingLevel, logID *byte, env *api.OrtEnv) api.OrtStatus {
+	return f.createEnvWithCustomLogger(loggingFunction, loggerParam, logLevel, logID, env)
+}
+
 func (f *Funcs) ReleaseEnv(env api.OrtEnv) {
 	f.releaseEnv(env)
 }
 
+func (f *Funcs) CreateAndRegisterAllocator(env api.OrtEnv, memInfo api.OrtMemoryInfo, arenaCfg api.OrtArenaCfg) api.OrtStatus {
+	return f.createAndRegisterAllocator(env, memInfo, arenaCfg)
+}
+
+func (f *Funcs) UnregisterAllocator(env api.OrtEnv, memInfo api.OrtMemoryInfo) api.OrtStatus {
+	return f.unregisterAllocator(env, memInfo)
+}
+
 // Telemetry methods
 
 func (f *Funcs) EnableTelemetryEvents(env api.OrtEnv) api.OrtStatus {
@@ -387,12 +575,64 @@ func (f *Funcs) AllocatorFree(allocator api.OrtAllocator, ptr unsafe.Pointer) {
 	f.allocatorFree(allocator, ptr)
 }
 
+func (f *Funcs) CreateAllocator(session api.OrtSession, memInfo api.OrtMemoryInfo, allocator *api.OrtAllocator) api.OrtStatus {
+	return f.createAllocator(session, memInfo, allocator)
+}
+
+func (f *Funcs) ReleaseAllocator(allocator api.OrtAllocator) {
+	f.releaseAllocator(allocator)
+}
+
+func (f *Funcs) CreateArenaCfgV2(keys **byte, values *uintptr, numKeys uintptr, out *api.OrtArenaCfg) api.OrtStatus {
+	return f.createArenaCfgV2(keys, values, numKeys, out)
+}
+
+func (f *Funcs) ReleaseArenaCfg(arenaCfg api.OrtArenaCfg) {
+	f.releaseArenaCfg(arenaCfg)
+}
+
+func (f *Funcs) AllocatorGetStats(allocator api.OrtAllocator, stats *api.OrtKeyValuePairs) api.OrtStatus {
+	return f.allocatorGetStats(allocator, stats)
+}
+
+func (f *Funcs) GetKeyValuePairs(kvps api.OrtKeyValuePairs, keys ***byte, values ***byte, numEntries *uintptr) {
+	f.getKeyValuePairs(kvps, keys, values, numEntries)
+}
+
+func (f *Funcs) ReleaseKeyValuePairs(kvps api.OrtKeyValuePairs) {
+	f.releaseKeyValuePairs(kvps)
+}
+
 // Memory info methods
 
 func (f *Funcs) CreateCpuMemoryInfo(allocType api.OrtAllocatorType, memType api.OrtMemType, memInfo *api.OrtMemoryInfo) api.OrtStatus {
 	return f.createCpuMemoryInfo(allocType, memType, memInfo)
 }
 
+func (f *Funcs) CreateMemoryInfo(name *byte, allocType api.OrtAllocatorType, id int32, memType api.OrtMemType, memInfo *api.OrtMemoryInfo) api.OrtStatus {
+	return f.createMemoryInfo(name, allocType, id, memType, memInfo)
+}
+
+func (f *Funcs) MemoryInfoGetName(memInfo api.OrtMemoryInfo, name **byte) api.OrtStatus {
+	return f.memoryInfoGetName(memInfo, name)
+}
+
+func (f *Funcs) MemoryInfoGetId(memInfo api.OrtMemoryInfo, id *int32) api.OrtStatus {
+	return f.memoryInfoGetId(memInfo, id)
+}
+
+func (f *Funcs) MemoryInfoGetMemType(memInfo api.OrtMemoryInfo, memType *api.OrtMemType) api.OrtStatus {
+	return f.memoryInfoGetMemType(memInfo, memType)
+}
+
+func (f *Funcs) MemoryInfoGetType(memInfo api.OrtMemoryInfo, allocType *api.OrtAllocatorType) api.OrtStatus {
+	return f.memoryInfoGetType(memInfo, allocType)
+}
+
+func (f *Funcs) MemoryInfoGetDeviceType(memInfo api.OrtMemoryInfo, deviceType *api.OrtMemoryInfoDeviceType) {
+	f.memoryInfoGetDeviceType(memInfo, deviceType)
+}
+
 func (f *Funcs) ReleaseMemoryInfo(memInfo api.OrtMemoryInfo) {
 	f.releaseMemoryInfo(memInfo)
 }
@@ -447,10 +687,38 @@ func (f *Funcs) AddSessionConfigEntry(options api.OrtSessionOptions, key *byte,
 	return f.addSessionConfigEntry(options, key, value)
 }
 
+func (f *Funcs) AddFreeDimensionOverride(options api.OrtSessionOptions, dimDenotation *byte, dimValue int64) api.OrtStatus {
+	return f.addFreeDimensionOverride(options, dimDenotation, dimValue)
+}
+
 func (f *Funcs) AddFreeDimensionOverrideByName(options api.OrtSessionOptions, dimName *byte, dimValue int64) api.OrtStatus {
 	return f.addFreeDimensionOverrideByName(options, dimName, dimValue)
 }
 
+func (f *Funcs) AddExternalInitializers(options api.OrtSessionOptions, names **byte, values *api.OrtValue, numInitializers uintptr) api.OrtStatus {
+	return f.addExternalInitializers(options, names, values, numInitializers)
+}
+
+func (f *Funcs) AddExternalInitializersFromFilesInMemory(options api.OrtSessionOptions, fileNames **byte, buffers **byte, lengths *uintptr, numFiles uintptr) api.OrtStatus {
+	return f.addExternalInitializersFromFilesInMemory(options, fileNames, buffers, lengths, numFiles)
+}
+
+func (f *Funcs) CreateCustomOpDomain(domain *byte, out *api.OrtCustomOpDomain) api.OrtStatus {
+	return f.createCustomOpDomain(domain, out)
+}
+
+func (f *Funcs) CustomOpDomainAdd(domain api.OrtCustomOpDomain, op unsafe.Pointer) api.OrtStatus {
+	return f.customOpDomainAdd(domain, op)
+}
+
+func (f *Funcs) AddCustomOpDomain(options api.OrtSessionOptions, domain api.OrtCustomOpDomain) api.OrtStatus {
+	return f.addCustomOpDomain(options, domain)
+}
+
+func (f *Funcs) ReleaseCustomOpDomain(domain api.OrtCustomOpDomain) {
+	f.releaseCustomOpDomain(domain)
+}
+
 func (f *Funcs) SetDeterministicCompute(options api.OrtSessionOptions, value int32) api.OrtStatus {
 	return f.setDeterministicCompute(options, value)
 }
@@ -471,6 +739,26 @@ func (f *Funcs) SessionOptionsAppendExecutionProvider(options api.OrtSessionOpti
 	return f.sessionOptionsAppendExecutionProvider(options, providerName, keys, values, numKeys)
 }
 
+func (f *Funcs) SessionOptionsAppendExecutionProviderCUDAV2(options api.OrtSessionOptions, cudaOptions api.OrtCUDAProviderOptionsV2) api.OrtStatus {
+	return f.sessionOptionsAppendExecutionProviderCUDAV2(options, cudaOptions)
+}
+
+func (f *Funcs) CreateCUDAProviderOptions(out *api.OrtCUDAProviderOptionsV2) api.OrtStatus {
+	return f.createCUDAProviderOptions(out)
+}
+
+func (f *Funcs) UpdateCUDAProviderOptions(cudaOptions api.OrtCUDAProviderOptionsV2, keys **byte, values **byte, numKeys uintptr) api.OrtStatus {
+	return f.updateCUDAProviderOptions(cudaOptions, keys, values, numKeys)
+}
+
+func (f *Funcs) UpdateCUDAProviderOptionsWithValue(cudaOptions api.OrtCUDAProviderOptionsV2, key *byte, value uintptr) api.OrtStatus {
+	return f.updateCUDAProviderOptionsWithValue(cudaOptions, key, value)
+}
+
+func (f *Funcs) ReleaseCUDAProviderOptions(cudaOptions api.OrtCUDAProviderOptionsV2) {
+	f.releaseCUDAProviderOptions(cudaOptions)
+}
+
 func (f *Funcs) ReleaseSessionOptions(options api.OrtSessionOptions) {
 	f.releaseSessionOptions(options)
 }
@@ -535,6 +823,10 @@ func (f *Funcs) Run(session api.OrtSession, runOptions api.OrtRunOptions, inputN
 	return f.run(session, runOptions, inputNames, inputs, inputCount, outputNames, outputCount, outputs)
 }
 
+func (f *Funcs) RunAsync(session api.OrtSession, runOptions api.OrtRunOptions, inputNames **byte, inputs *api.OrtValue, inputCount uintptr, outputNames **byte, outputCount uintptr, outputs *api.OrtValue, callback uintptr, userData uintptr) api.OrtStatus {
+	return f.runAsync(session, runOptions, inputNames, inputs, inputCount, outputNames, outputCount, outputs, callback, userData)
+}
+
 func (f *Funcs) ReleaseSession(session api.OrtSession) {
 	f.releaseSession(session)
 }
@@ -549,6 +841,14 @@ func (f *Funcs) SessionGetProfilingStartTimeNs(session api.OrtSession, out *uint
 	return f.sessionGetProfilingStartTimeNs(session, out)
 }
 
+func (f *Funcs) SessionGetMemoryInfoForInputs(session api.OrtSession, out *api.OrtMemoryInfo, numInputs uintptr) api.OrtStatus {
+	return f.sessionGetMemoryInfoForInputs(session, out, numInputs)
+}
+
+func (f *Funcs) SessionGetMemoryInfoForOutputs(session api.OrtSession, out *api.OrtMemoryInfo, numOutputs uintptr) api.OrtStatus {
+	return f.sessionGetMemoryInfoForOutputs(session, out, numOutputs)
+}
+
 // LoRA adapter methods
 
 func (f *Funcs) CreateLoraAdapter(path *byte, allocator api.OrtAllocator, out *api.OrtLoraAdapter) api.OrtStatus {
@@ -683,6 +983,22 @@ func (f *Funcs) ReleaseValue(value api.OrtValue) {
 	f.releaseValue(value)
 }
 
+func (f *Funcs) KernelContextGetInputCount(context api.OrtKernelContext, out *uintptr) api.OrtStatus {
+	return f.kernelContextGetInputCount(context, out)
+}
+
+func (f *Funcs) KernelContextGetOutputCount(context api.OrtKernelContext, out *uintptr) api.OrtStatus {
+	return f.kernelContextGetOutputCount(context, out)
+}
+
+func (f *Funcs) KernelContextGetInput(context api.OrtKernelContext, index uintptr, out *api.OrtValue) api.OrtStatus {
+	return f.kernelContextGetInput(context, index, out)
+}
+
+func (f *Funcs) KernelContextGetOutput(context api.OrtKernelContext, index uintptr, dims *int64, numDims uintptr, out *api.OrtValue) api.OrtStatus {
+	return f.kernelContextGetOutput(context, index, dims, numDims, out)
+}
+
 func (f *Funcs) ReleaseTensorTypeAndShapeInfo(typeAndShape api.OrtTensorTypeAndShapeInfo) {
 	f.releaseTensorTypeAndShapeInfo(typeAndShape)
 }
@@ -846,3 +1162,181 @@ func (f *Funcs) SetGlobalInterOpNumThreads(options api.OrtThreadingOptions, numT
 func (f *Funcs) SetGlobalSpinControl(options api.OrtThreadingOptions, allowSpinning int32) api.OrtStatus {
 	return f.setGlobalSpinControl(options, allowSpinning)
 }
+
+func (f *Funcs) SetGlobalDenormalAsZero(options api.OrtThreadingOptions) api.OrtStatus {
+	return f.setGlobalDenormalAsZero(options)
+}
+
+func (f *Funcs) SetGlobalIntraOpThreadAffinity(options api.OrtThreadingOptions, affinity *byte) api.OrtStatus {
+	return f.setGlobalIntraOpThreadAffinity(options, affinity)
+}
+
+func (f *Funcs) SetGlobalCustomCreateThreadFn(options api.OrtThreadingOptions, fn uintptr) api.OrtStatus {
+	return f.setGlobalCustomCreateThreadFn(options, fn)
+}
+
+func (f *Funcs) SetGlobalCustomThreadCreationOptions(options api.OrtThreadingOptions, creationOptions uintptr) api.OrtStatus {
+	return f.setGlobalCustomThreadCreationOptions(options, creationOptions)
+}
+
+func (f *Funcs) SetGlobalCustomJoinThreadFn(options api.OrtThreadingOptions, fn uintptr) api.OrtStatus {
+	return f.setGlobalCustomJoinThreadFn(options, fn)
+}
+
+func (f *Funcs) IsSparseTensor(value api.OrtValue, out *int32) api.OrtStatus {
+	return f.isSparseTensor(value, out)
+}
+
+func (f *Funcs) CreateSparseTensorWithValuesAsOrtValue(info api.OrtMemoryInfo, values unsafe.Pointer, denseShape *int64, denseShapeLen uintptr, valuesShape *int64, valuesShapeLen uintptr, dataType api.ONNXTensorElementDataType, out *api.OrtValue) api.OrtStatus {
+	return f.createSparseTensorWithValuesAsOrtValue(info, values, denseShape, denseShapeLen, valuesShape, valuesShapeLen, dataType, out)
+}
+
+func (f *Funcs) UseCooIndices(value api.OrtValue, indices *int64, indicesNum uintptr) api.OrtStatus {
+	return f.useCooIndices(value, indices, indicesNum)
+}
+
+func (f *Funcs) UseCsrIndices(value api.OrtValue, inner *int64, innerNum uintptr, outer *int64, outerNum uintptr) api.OrtStatus {
+	return f.useCsrIndices(value, inner, innerNum, outer, outerNum)
+}
+
+func (f *Funcs) GetSparseTensorFormat(value api.OrtValue, out *api.OrtSparseFormat) api.OrtStatus {
+	return f.getSparseTensorFormat(value, out)
+}
+
+func (f *Funcs) GetSparseTensorValuesTypeAndShape(value api.OrtValue, out *api.OrtTensorTypeAndShapeInfo) api.OrtStatus {
+	return f.getSparseTensorValuesTypeAndShape(value, out)
+}
+
+func (f *Funcs) GetSparseTensorValues(value api.OrtValue, out *unsafe.Pointer) api.OrtStatus {
+	return f.getSparseTensorValues(value, out)
+}
+
+func (f *Funcs) GetSparseTensorIndicesTypeShape(value api.OrtValue, format api.OrtSparseIndicesFormat, out *api.OrtTensorTypeAndShapeInfo) api.OrtStatus {
+	return f.getSparseTensorIndicesTypeShape(value, format, out)
+}
+
+func (f *Funcs) GetSparseTensorIndices(value api.OrtValue, format api.OrtSparseIndicesFormat, num *uintptr, out *unsafe.Pointer) api.OrtStatus {
+	return f.getSparseTensorIndices(value, format, num, out)
+}
+
+// HasCompileAPI reports whether the library provides the compile API.
+func (f *Funcs) HasCompileAPI() bool {
+	return f.compileModel != nil
+}
+
+func (f *Funcs) CreateModelCompilationOptionsFromSessionOptions(env api.OrtEnv, options api.OrtSessionOptions, out *api.OrtModelCompilationOptions) api.OrtStatus {
+	return f.createModelCompilationOptionsFromSessionOptions(env, options, out)
+}
+
+func (f *Funcs) ReleaseModelCompilationOptions(options api.OrtModelCompilationOptions) {
+	f.releaseModelCompilationOptions(options)
+}
+
+func (f *Funcs) ModelCompilationOptionsSetInputModelPath(options api.OrtModelCompilationOptions, path *byte) api.OrtStatus {
+	return f.modelCompilationOptionsSetInputModelPath(options, path)
+}
+
+func (f *Funcs) ModelCompilationOptionsSetInputModelFromBuffer(options api.OrtModelCompilationOptions, data unsafe.Pointer, size uintptr) api.OrtStatus {
+	return f.modelCompilationOptionsSetInputModelFromBuffer(options, data, size)
+}
+
+func (f *Funcs) ModelCompilationOptionsSetOutputModelPath(options api.OrtModelCompilationOptions, path *byte) api.OrtStatus {
+	return f.modelCompilationOptionsSetOutputModelPath(options, path)
+}
+
+func (f *Funcs) ModelCompilationOptionsSetOutputModelExternalInitializersFile(options api.OrtModelCompilationOptions, path *byte, sizeThreshold uintptr) api.OrtStatus {
+	return f.modelCompilationOptionsSetOutputModelExternalInitializersFile(options, path, sizeThreshold)
+}
+
+func (f *Funcs) ModelCompilationOptionsSetEpContextEmbedMode(options api.OrtModelCompilationOptions, embed bool) api.OrtStatus {
+	return f.modelCompilationOptionsSetEpContextEmbedMode(options, embed)
+}
+
+func (f *Funcs) CompileModel(env api.OrtEnv, options api.OrtModelCompilationOptions) api.OrtStatus {
+	return f.compileModel(env, options)
+}
+
+// HasTrainingAPI reports whether the library provides the training API.
+func (f *Funcs) HasTrainingAPI() bool {
+	return f.trainStep != nil
+}
+
+func (f *Funcs) LoadCheckpoint(path *byte, out *api.OrtCheckpointState) api.OrtStatus {
+	return f.loadCheckpoint(path, out)
+}
+
+func (f *Funcs) SaveCheckpoint(state api.OrtCheckpointState, path *byte, includeOptimizerState bool) api.OrtStatus {
+	return f.saveCheckpoint(state, path, includeOptimizerState)
+}
+
+func (f *Funcs) ReleaseCheckpointState(state api.OrtCheckpointState) {
+	f.releaseCheckpointState(state)
+}
+
+func (f *Funcs) CreateTrainingSession(env api.OrtEnv, options api.OrtSessionOptions, state api.OrtCheckpointState, trainModelPath, evalModelPath, optimizerModelPath *byte, out *api.OrtTrainingSession) api.OrtStatus {
+	return f.createTrainingSession(env, options, state, trainModelPath, evalModelPath, optimizerModelPath, out)
+}
+
+func (f *Funcs) ReleaseTrainingSession(session api.OrtTrainingSession) {
+	f.releaseTrainingSession(session)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelInputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelInputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelInputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelInputName(session, index, allocator, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelInputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetEvalModelInputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelInputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetEvalModelInputName(session, index, allocator, out)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelOutputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelOutputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetTrainingModelOutputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetTrainingModelOutputName(session, index, allocator, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelOutputCount(session api.OrtTrainingSession, out *uintptr) api.OrtStatus {
+	return f.trainingSessionGetEvalModelOutputCount(session, out)
+}
+
+func (f *Funcs) TrainingSessionGetEvalModelOutputName(session api.OrtTrainingSession, index uintptr, allocator api.OrtAllocator, out **byte) api.OrtStatus {
+	return f.trainingSessionGetEvalModelOutputName(session, index, allocator, out)
+}
+
+func (f *Funcs) LazyResetGrad(session api.OrtTrainingSession) api.OrtStatus {
+	return f.lazyResetGrad(session)
+}
+
+func (f *Funcs) TrainStep(session api.OrtTrainingSession, runOptions api.OrtRunOptions, inputsLen uintptr, inputs *api.OrtValue, outputsLen uintptr, outputs *api.OrtValue) api.OrtStatus {
+	return f.trainStep(session, runOptions, inputsLen, inputs, outputsLen, outputs)
+}
+
+func (f *Funcs) EvalStep(session api.OrtTrainingSession, runOptions api.OrtRunOptions, inputsLen uintptr, inputs *api.OrtValue, outputsLen uintptr, outputs *api.OrtValue) api.OrtStatus {
+	return f.evalStep(session, runOptions, inputsLen, inputs, outputsLen, outputs)
+}
+
+func (f *Funcs) SetLearningRate(session api.OrtTrainingSession, learningRate float32) api.OrtStatus {
+	return f.setLearningRate(session, learningRate)
+}
+
+func (f *Funcs) GetLearningRate(session api.OrtTrainingSession, out *float32) api.OrtStatus {
+	return f.getLearningRate(session, out)
+}
+
+func (f *Funcs) OptimizerStep(session api.OrtTrainingSession, runOptions api.OrtRunOptions) api.OrtStatus {
+	return f.optimizerStep(session, runOptions)
+}
+
+func (f *Funcs) ExportModelForInferencing(session api.OrtTrainingSession, inferenceModelPath *byte, outputNamesLen uintptr, outputNames **byte) api.OrtStatus {
+	return f.exportModelForInferencing(session, inferenceModelPath, outputNamesLen, outputNames)
+}
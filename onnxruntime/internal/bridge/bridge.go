@@ -0,0 +1,67 @@
+// Package bridge gives subpackages of onnxruntime, such as orttraining, access
+// to the native handles behind its public types without exporting them. The
+// onnxruntime package sets the functions when it is initialized; the any
+// parameters hold the matching onnxruntime types.
+package bridge
+
+import (
+	"context"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// Runtime exposes the native side of an *onnxruntime.Runtime.
+type Runtime struct {
+	Funcs api.APIFuncs
+
+	// Allocator is the default allocator, used for strings returned by
+	// ONNX Runtime. FreeString copies such a string and frees it.
+	Allocator  api.OrtAllocator
+	FreeString func(*byte) string
+
+	// StatusError converts a status into an *onnxruntime.RuntimeError,
+	// releasing it, or returns nil for a nil status.
+	StatusError func(status api.OrtStatus, op string) error
+
+	// Track counts an object created from the runtime until untrack is
+	// called, so that Runtime.Close fails while it is open. kind names the
+	// object, such as "training session" or "checkpoint state". leaked is
+	// true when the object was garbage collected without being closed, which
+	// reports it to the leak handler.
+	Track func(kind string) (untrack func(leaked bool))
+}
+
+// RunOptions owns the OrtRunOptions of one run.
+type RunOptions struct {
+	Ptr api.OrtRunOptions // zero when no run options are needed
+
+	// WrapError makes an error from the run match the context's error with
+	// errors.Is when cancellation terminated the run.
+	WrapError func(error) error
+
+	// Close stops watching the context and releases the run options.
+	Close func()
+}
+
+var (
+	// RuntimeOf returns the native side of an *onnxruntime.Runtime, or an
+	// error if it is closed.
+	RuntimeOf func(r any) (*Runtime, error)
+
+	// Env returns the OrtEnv of an *onnxruntime.Env.
+	Env func(e any) api.OrtEnv
+
+	// SessionOptions creates OrtSessionOptions from an
+	// *onnxruntime.SessionOptions. The returned func releases them.
+	SessionOptions func(r, opts any) (api.OrtSessionOptions, func(), error)
+
+	// NewRunOptions creates run options that terminate the run when ctx
+	// is done.
+	NewRunOptions func(r any, ctx context.Context) (*RunOptions, error)
+
+	// Value returns the OrtValue of an *onnxruntime.Value, or zero for nil.
+	Value func(v any) api.OrtValue
+
+	// NewValue wraps an OrtValue owned by the caller in an *onnxruntime.Value.
+	NewValue func(r any, ptr api.OrtValue) any
+)
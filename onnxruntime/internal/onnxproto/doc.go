@@ -0,0 +1,8 @@
+// Package onnxproto decodes the subset of the ONNX protobuf schema needed to
+// inspect models and tensors without the native library: models, graphs,
+// nodes, attributes, value infos, and tensors.
+//
+// It is a hand-written wire-format reader with no protobuf dependency.
+// Byte fields such as raw_data alias the input buffer, which must not be
+// modified while decoded values are in use.
+package onnxproto
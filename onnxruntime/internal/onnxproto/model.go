@@ -0,0 +1,532 @@
+package onnxproto
+
+import "fmt"
+
+// Model is a decoded ModelProto.
+type Model struct {
+	IRVersion       int64
+	OpsetImports    []OperatorSetID
+	ProducerName    string
+	ProducerVersion string
+	Domain          string
+	ModelVersion    int64
+	DocString       string
+	Graph           *Graph
+	MetadataProps   map[string]string
+}
+
+// OperatorSetID is a decoded OperatorSetIdProto. An empty Domain is the default ONNX domain.
+type OperatorSetID struct {
+	Domain  string
+	Version int64
+}
+
+// Graph is a decoded GraphProto.
+type Graph struct {
+	Name         string
+	Nodes        []*Node
+	Initializers []*Tensor
+	Inputs       []*ValueInfo
+	Outputs      []*ValueInfo
+	ValueInfo    []*ValueInfo
+	DocString    string
+}
+
+// Node is a decoded NodeProto.
+type Node struct {
+	Name       string
+	OpType     string
+	Domain     string
+	Inputs     []string
+	Outputs    []string
+	Attributes []*Attribute
+}
+
+// Attribute returns the named attribute, or nil if the node does not have it.
+func (n *Node) Attribute(name string) *Attribute {
+	for _, a := range n.Attributes {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// AttributeType mirrors AttributeProto.AttributeType.
+type AttributeType int32
+
+// Attribute types.
+const (
+	AttributeUndefined AttributeType = 0
+	AttributeFloat     AttributeType = 1
+	AttributeInt       AttributeType = 2
+	AttributeString    AttributeType = 3
+	AttributeTensor    AttributeType = 4
+	AttributeGraph     AttributeType = 5
+	AttributeFloats    AttributeType = 6
+	AttributeInts      AttributeType = 7
+	AttributeStrings   AttributeType = 8
+	AttributeTensors   AttributeType = 9
+	AttributeGraphs    AttributeType = 10
+)
+
+// Attribute is a decoded AttributeProto.
+type Attribute struct {
+	Name    string
+	Type    AttributeType
+	F       float32
+	I       int64
+	S       []byte
+	T       *Tensor
+	G       *Graph
+	Floats  []float32
+	Ints    []int64
+	Strings [][]byte
+	Tensors []*Tensor
+	Graphs  []*Graph
+}
+
+// ValueInfo is a decoded ValueInfoProto.
+type ValueInfo struct {
+	Name      string
+	Type      *TypeInfo
+	DocString string
+}
+
+// TypeKind identifies which member of TypeProto's oneof is set.
+type TypeKind int
+
+// Type kinds.
+const (
+	TypeUnknown TypeKind = iota
+	TypeTensor
+	TypeSequence
+	TypeMap
+	TypeOptional
+	TypeSparseTensor
+)
+
+// TypeInfo is a decoded TypeProto.
+type TypeInfo struct {
+	Kind       TypeKind
+	Denotation string
+
+	// ElemType and Shape are set for tensor and sparse tensor types.
+	ElemType int32
+	Shape    []Dim // nil when the shape is unknown; empty for scalars
+	HasShape bool
+
+	// Elem is the element type for sequence and optional types, and the value type for maps.
+	Elem *TypeInfo
+
+	// KeyType is the key element type for map types.
+	KeyType int32
+}
+
+// Dim is a decoded TensorShapeProto.Dimension. Exactly one of Value or Param is
+// normally set; both are zero for unknown dimensions.
+type Dim struct {
+	Value      int64
+	Param      string
+	Denotation string
+	HasValue   bool
+}
+
+// DecodeModel decodes a serialized ModelProto.
+func DecodeModel(data []byte) (*Model, error) {
+	m := &Model{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode model: %w", err)
+		}
+		if !ok {
+			break
+		}
+		switch d.field {
+		case 1:
+			m.IRVersion = int64(d.varint)
+		case 2:
+			m.ProducerName = d.string()
+		case 3:
+			m.ProducerVersion = d.string()
+		case 4:
+			m.Domain = d.string()
+		case 5:
+			m.ModelVersion = int64(d.varint)
+		case 6:
+			m.DocString = d.string()
+		case 7:
+			g, err := decodeGraph(d.bytes)
+			if err != nil {
+				return nil, err
+			}
+			m.Graph = g
+		case 8:
+			op, err := decodeOperatorSetID(d.bytes)
+			if err != nil {
+				return nil, err
+			}
+			m.OpsetImports = append(m.OpsetImports, op)
+		case 14:
+			k, v, err := decodeStringStringEntry(d.bytes)
+			if err != nil {
+				return nil, err
+			}
+			if m.MetadataProps == nil {
+				m.MetadataProps = make(map[string]string)
+			}
+			m.MetadataProps[k] = v
+		}
+	}
+	if m.Graph == nil {
+		return nil, fmt.Errorf("failed to decode model: no graph")
+	}
+	return m, nil
+}
+
+func decodeOperatorSetID(data []byte) (OperatorSetID, error) {
+	var op OperatorSetID
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return op, fmt.Errorf("failed to decode opset import: %w", err)
+		}
+		if !ok {
+			return op, nil
+		}
+		switch d.field {
+		case 1:
+			op.Domain = d.string()
+		case 2:
+			op.Version = int64(d.varint)
+		}
+	}
+}
+
+func decodeStringStringEntry(data []byte) (string, string, error) {
+	var key, value string
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decode string entry: %w", err)
+		}
+		if !ok {
+			return key, value, nil
+		}
+		switch d.field {
+		case 1:
+			key = d.string()
+		case 2:
+			value = d.string()
+		}
+	}
+}
+
+func decodeGraph(data []byte) (*Graph, error) {
+	g := &Graph{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode graph: %w", err)
+		}
+		if !ok {
+			return g, nil
+		}
+		switch d.field {
+		case 1:
+			n, err := decodeNode(d.bytes)
+			if err != nil {
+				return nil, err
+			}
+			g.Nodes = append(g.Nodes, n)
+		case 2:
+			g.Name = d.string()
+		case 5:
+			t, err := DecodeTensor(d.bytes)
+			if err != nil {
+				return nil, err
+			}
+			g.Initializers = append(g.Initializers, t)
+		case 10:
+			g.DocString = d.string()
+		case 11, 12, 13:
+			vi, err := decodeValueInfo(d.bytes)
+			if err != nil {
+				return nil, err
+			}
+			switch d.field {
+			case 11:
+				g.Inputs = append(g.Inputs, vi)
+			case 12:
+				g.Outputs = append(g.Outputs, vi)
+			default:
+				g.ValueInfo = append(g.ValueInfo, vi)
+			}
+		}
+	}
+}
+
+func decodeNode(data []byte) (*Node, error) {
+	n := &Node{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode node: %w", err)
+		}
+		if !ok {
+			return n, nil
+		}
+		switch d.field {
+		case 1:
+			n.Inputs = append(n.Inputs, d.string())
+		case 2:
+			n.Outputs = append(n.Outputs, d.string())
+		case 3:
+			n.Name = d.string()
+		case 4:
+			n.OpType = d.string()
+		case 5:
+			a, err := decodeAttribute(d.bytes)
+			if err != nil {
+				return nil, fmt.Errorf("node %q: %w", n.Name, err)
+			}
+			n.Attributes = append(n.Attributes, a)
+		case 7:
+			n.Domain = d.string()
+		}
+	}
+}
+
+func decodeAttribute(data []byte) (*Attribute, error) {
+	a := &Attribute{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode attribute: %w", err)
+		}
+		if !ok {
+			return a, nil
+		}
+		switch d.field {
+		case 1:
+			a.Name = d.string()
+		case 2:
+			var f []float32
+			if f, err = d.float32s(nil); err == nil && len(f) > 0 {
+				a.F = f[0]
+			}
+		case 3:
+			a.I = int64(d.varint)
+		case 4:
+			a.S = d.bytes
+		case 5:
+			a.T, err = DecodeTensor(d.bytes)
+		case 6:
+			a.G, err = decodeGraph(d.bytes)
+		case 7:
+			a.Floats, err = d.float32s(a.Floats)
+		case 8:
+			a.Ints, err = d.int64s(a.Ints)
+		case 9:
+			a.Strings = append(a.Strings, d.bytes)
+		case 10:
+			var t *Tensor
+			if t, err = DecodeTensor(d.bytes); err == nil {
+				a.Tensors = append(a.Tensors, t)
+			}
+		case 11:
+			var g *Graph
+			if g, err = decodeGraph(d.bytes); err == nil {
+				a.Graphs = append(a.Graphs, g)
+			}
+		case 20:
+			a.Type = AttributeType(d.varint)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", a.Name, err)
+		}
+	}
+}
+
+func decodeValueInfo(data []byte) (*ValueInfo, error) {
+	vi := &ValueInfo{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode value info: %w", err)
+		}
+		if !ok {
+			return vi, nil
+		}
+		switch d.field {
+		case 1:
+			vi.Name = d.string()
+		case 2:
+			t, err := decodeTypeInfo(d.bytes)
+			if err != nil {
+				return nil, fmt.Errorf("value %q: %w", vi.Name, err)
+			}
+			vi.Type = t
+		case 3:
+			vi.DocString = d.string()
+		}
+	}
+}
+
+func decodeTypeInfo(data []byte) (*TypeInfo, error) {
+	t := &TypeInfo{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode type: %w", err)
+		}
+		if !ok {
+			return t, nil
+		}
+		switch d.field {
+		case 1, 8: // tensor_type, sparse_tensor_type
+			t.Kind = TypeTensor
+			if d.field == 8 {
+				t.Kind = TypeSparseTensor
+			}
+			if err := decodeTensorType(d.bytes, t); err != nil {
+				return nil, err
+			}
+		case 4, 9: // sequence_type, optional_type
+			t.Kind = TypeSequence
+			if d.field == 9 {
+				t.Kind = TypeOptional
+			}
+			elem, err := decodeElemType(d.bytes)
+			if err != nil {
+				return nil, err
+			}
+			t.Elem = elem
+		case 5: // map_type
+			t.Kind = TypeMap
+			if err := decodeMapType(d.bytes, t); err != nil {
+				return nil, err
+			}
+		case 6:
+			t.Denotation = d.string()
+		}
+	}
+}
+
+func decodeTensorType(data []byte, t *TypeInfo) error {
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return fmt.Errorf("failed to decode tensor type: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+		switch d.field {
+		case 1:
+			t.ElemType = int32(d.varint)
+		case 2:
+			shape, err := decodeShape(d.bytes)
+			if err != nil {
+				return err
+			}
+			t.Shape = shape
+			t.HasShape = true
+		}
+	}
+}
+
+// decodeElemType decodes a message whose field 1 is a TypeProto (Sequence and Optional).
+func decodeElemType(data []byte) (*TypeInfo, error) {
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode element type: %w", err)
+		}
+		if !ok {
+			return nil, nil
+		}
+		if d.field == 1 {
+			return decodeTypeInfo(d.bytes)
+		}
+	}
+}
+
+func decodeMapType(data []byte, t *TypeInfo) error {
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return fmt.Errorf("failed to decode map type: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+		switch d.field {
+		case 1:
+			t.KeyType = int32(d.varint)
+		case 2:
+			elem, err := decodeTypeInfo(d.bytes)
+			if err != nil {
+				return err
+			}
+			t.Elem = elem
+		}
+	}
+}
+
+func decodeShape(data []byte) ([]Dim, error) {
+	dims := []Dim{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode shape: %w", err)
+		}
+		if !ok {
+			return dims, nil
+		}
+		if d.field != 1 {
+			continue
+		}
+		dim, err := decodeDim(d.bytes)
+		if err != nil {
+			return nil, err
+		}
+		dims = append(dims, dim)
+	}
+}
+
+func decodeDim(data []byte) (Dim, error) {
+	var dim Dim
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return dim, fmt.Errorf("failed to decode dimension: %w", err)
+		}
+		if !ok {
+			return dim, nil
+		}
+		switch d.field {
+		case 1:
+			dim.Value = int64(d.varint)
+			dim.HasValue = true
+		case 2:
+			dim.Param = d.string()
+		case 3:
+			dim.Denotation = d.string()
+		}
+	}
+}
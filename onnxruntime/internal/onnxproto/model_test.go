@@ -0,0 +1,81 @@
+package onnxproto
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDecodeModel(t *testing.T) {
+	data, err := os.ReadFile("../tests/testdata/model.onnx")
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	m, err := DecodeModel(data)
+	if err != nil {
+		t.Fatalf("DecodeModel failed: %v", err)
+	}
+
+	if m.IRVersion == 0 || len(m.OpsetImports) == 0 {
+		t.Errorf("Expected IR version and opset imports, got %d / %v", m.IRVersion, m.OpsetImports)
+	}
+
+	g := m.Graph
+	if len(g.Nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(g.Nodes))
+	}
+	if g.Nodes[0].OpType != "Gemm" || g.Nodes[1].OpType != "Relu" {
+		t.Errorf("Unexpected op types: %s, %s", g.Nodes[0].OpType, g.Nodes[1].OpType)
+	}
+	if a := g.Nodes[0].Attribute("transB"); a == nil || a.I != 1 {
+		t.Errorf("Expected transB=1 on first Gemm, got %+v", a)
+	}
+
+	if len(g.Inputs) != 1 || g.Inputs[0].Name != "input" {
+		t.Fatalf("Unexpected inputs: %+v", g.Inputs)
+	}
+	shape := g.Inputs[0].Type.Shape
+	if len(shape) != 2 || shape[0].Param != "batch_size" || shape[1].Value != 10 {
+		t.Errorf("Unexpected input shape: %+v", shape)
+	}
+
+	if len(g.Initializers) != 4 {
+		t.Fatalf("Expected 4 initializers, got %d", len(g.Initializers))
+	}
+	w := g.Initializers[0]
+	if w.Name != "fc1.weight" || w.ByteSize() != 16*10*4 || int64(len(w.RawData)) != w.ByteSize() {
+		t.Errorf("Unexpected initializer %s dims=%v raw=%d", w.Name, w.Dims, len(w.RawData))
+	}
+}
+
+func TestDecodeTensorPackedAndUnpacked(t *testing.T) {
+	// dims: packed [2, 3]; data_type: INT64; int64_data: unpacked 5, 7
+	data := []byte{
+		0x0a, 0x02, 0x02, 0x03, // field 1, packed
+		0x10, 0x07, // field 2 = 7
+		0x38, 0x05, // field 7 = 5
+		0x38, 0x07, // field 7 = 7
+		0x42, 0x01, 'x', // field 8 = "x"
+	}
+
+	tensor, err := DecodeTensor(data)
+	if err != nil {
+		t.Fatalf("DecodeTensor failed: %v", err)
+	}
+	if tensor.Name != "x" || len(tensor.Dims) != 2 || tensor.Dims[1] != 3 {
+		t.Errorf("Unexpected tensor header: %+v", tensor)
+	}
+	vals, err := tensor.Int64s()
+	if err != nil || len(vals) != 2 || vals[0] != 5 || vals[1] != 7 {
+		t.Errorf("Int64s = %v, %v", vals, err)
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	if _, err := DecodeTensor([]byte{0x4a, 0x10, 0x00}); err == nil {
+		t.Error("Expected error for truncated raw_data")
+	}
+	if _, err := DecodeModel([]byte{0x08, 0x01}); err == nil {
+		t.Error("Expected error for model without graph")
+	}
+}
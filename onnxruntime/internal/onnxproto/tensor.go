@@ -0,0 +1,329 @@
+package onnxproto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// TensorProto.DataType values.
+const (
+	DataTypeUndefined  int32 = 0
+	DataTypeFloat      int32 = 1
+	DataTypeUint8      int32 = 2
+	DataTypeInt8       int32 = 3
+	DataTypeUint16     int32 = 4
+	DataTypeInt16      int32 = 5
+	DataTypeInt32      int32 = 6
+	DataTypeInt64      int32 = 7
+	DataTypeString     int32 = 8
+	DataTypeBool       int32 = 9
+	DataTypeFloat16    int32 = 10
+	DataTypeDouble     int32 = 11
+	DataTypeUint32     int32 = 12
+	DataTypeUint64     int32 = 13
+	DataTypeComplex64  int32 = 14
+	DataTypeComplex128 int32 = 15
+	DataTypeBFloat16   int32 = 16
+)
+
+// ElementSize returns the size in bytes of one element of the given data type,
+// or 0 for strings and unknown types.
+func ElementSize(dataType int32) int {
+	switch dataType {
+	case DataTypeUint8, DataTypeInt8, DataTypeBool:
+		return 1
+	case DataTypeUint16, DataTypeInt16, DataTypeFloat16, DataTypeBFloat16:
+		return 2
+	case DataTypeFloat, DataTypeInt32, DataTypeUint32:
+		return 4
+	case DataTypeInt64, DataTypeDouble, DataTypeUint64, DataTypeComplex64:
+		return 8
+	case DataTypeComplex128:
+		return 16
+	default:
+		return 0
+	}
+}
+
+// Tensor is a decoded TensorProto.
+type Tensor struct {
+	Name     string
+	Dims     []int64
+	DataType int32
+
+	RawData    []byte
+	FloatData  []float32
+	Int32Data  []int32 // also holds int8/int16/uint8/uint16/bool/float16/bfloat16 values
+	StringData [][]byte
+	Int64Data  []int64
+	DoubleData []float64
+	Uint64Data []uint64 // also holds uint32 values
+
+	// ExternalData holds the external_data key/value entries ("location",
+	// "offset", "length", ...) when the tensor data is stored outside the model.
+	ExternalData map[string]string
+}
+
+// IsExternal reports whether the tensor's data is stored in an external file.
+func (t *Tensor) IsExternal() bool {
+	return len(t.ExternalData) > 0
+}
+
+// NumElements returns the product of the tensor's dimensions (1 for scalars).
+func (t *Tensor) NumElements() int64 {
+	n := int64(1)
+	for _, d := range t.Dims {
+		n *= d
+	}
+	return n
+}
+
+// ByteSize returns the in-memory size of the tensor's data, or 0 for string tensors.
+func (t *Tensor) ByteSize() int64 {
+	return t.NumElements() * int64(ElementSize(t.DataType))
+}
+
+// Int64s returns the tensor's values as int64 for integer tensors stored inline.
+func (t *Tensor) Int64s() ([]int64, error) {
+	switch t.DataType {
+	case DataTypeInt64:
+		if len(t.Int64Data) > 0 || len(t.RawData) == 0 {
+			return t.Int64Data, nil
+		}
+		if len(t.RawData)%8 != 0 {
+			return nil, fmt.Errorf("invalid raw_data length %d for int64", len(t.RawData))
+		}
+		out := make([]int64, len(t.RawData)/8)
+		for i := range out {
+			out[i] = int64(binary.LittleEndian.Uint64(t.RawData[i*8:]))
+		}
+		return out, nil
+	case DataTypeInt32:
+		if len(t.Int32Data) > 0 || len(t.RawData) == 0 {
+			out := make([]int64, len(t.Int32Data))
+			for i, v := range t.Int32Data {
+				out[i] = int64(v)
+			}
+			return out, nil
+		}
+		if len(t.RawData)%4 != 0 {
+			return nil, fmt.Errorf("invalid raw_data length %d for int32", len(t.RawData))
+		}
+		out := make([]int64, len(t.RawData)/4)
+		for i := range out {
+			out[i] = int64(int32(binary.LittleEndian.Uint32(t.RawData[i*4:])))
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("tensor data type %d is not an integer type", t.DataType)
+	}
+}
+
+// Float32s returns the tensor's values for float tensors stored inline.
+func (t *Tensor) Float32s() ([]float32, error) {
+	if t.DataType != DataTypeFloat {
+		return nil, fmt.Errorf("tensor data type %d is not float", t.DataType)
+	}
+	if len(t.FloatData) > 0 || len(t.RawData) == 0 {
+		return t.FloatData, nil
+	}
+	if len(t.RawData)%4 != 0 {
+		return nil, fmt.Errorf("invalid raw_data length %d for float", len(t.RawData))
+	}
+	out := make([]float32, len(t.RawData)/4)
+	for i := range out {
+		out[i] = math.Float32frombits(binary.LittleEndian.Uint32(t.RawData[i*4:]))
+	}
+	return out, nil
+}
+
+// Bytes returns the tensor's values as packed little-endian elements, the
+// layout of raw_data, converting from the typed repeated fields when the
+// tensor does not use raw_data. String tensors and tensors with external
+// data are not supported.
+func (t *Tensor) Bytes() ([]byte, error) {
+	if t.IsExternal() {
+		return nil, fmt.Errorf("tensor %q stores its data externally", t.Name)
+	}
+	size := ElementSize(t.DataType)
+	if size == 0 {
+		return nil, fmt.Errorf("tensor data type %d has no fixed-size encoding", t.DataType)
+	}
+	want := t.NumElements() * int64(size)
+
+	if len(t.RawData) > 0 || want == 0 {
+		if int64(len(t.RawData)) != want {
+			return nil, fmt.Errorf("tensor %q has %d bytes of raw_data, dims %v require %d", t.Name, len(t.RawData), t.Dims, want)
+		}
+		return t.RawData, nil
+	}
+
+	var n int
+	switch t.DataType {
+	case DataTypeFloat, DataTypeComplex64:
+		n = len(t.FloatData) * 4
+	case DataTypeDouble, DataTypeComplex128:
+		n = len(t.DoubleData) * 8
+	case DataTypeInt64:
+		n = len(t.Int64Data) * 8
+	case DataTypeUint64:
+		n = len(t.Uint64Data) * 8
+	case DataTypeUint32:
+		n = len(t.Uint64Data) * 4
+	default:
+		n = len(t.Int32Data) * size
+	}
+	if int64(n) != want {
+		return nil, fmt.Errorf("tensor %q has %d bytes of data, dims %v require %d", t.Name, n, t.Dims, want)
+	}
+
+	out := make([]byte, 0, n)
+	switch t.DataType {
+	case DataTypeFloat, DataTypeComplex64:
+		for _, v := range t.FloatData {
+			out = binary.LittleEndian.AppendUint32(out, math.Float32bits(v))
+		}
+	case DataTypeDouble, DataTypeComplex128:
+		for _, v := range t.DoubleData {
+			out = binary.LittleEndian.AppendUint64(out, math.Float64bits(v))
+		}
+	case DataTypeInt64:
+		for _, v := range t.Int64Data {
+			out = binary.LittleEndian.AppendUint64(out, uint64(v))
+		}
+	case DataTypeUint64:
+		for _, v := range t.Uint64Data {
+			out = binary.LittleEndian.AppendUint64(out, v)
+		}
+	case DataTypeUint32:
+		for _, v := range t.Uint64Data {
+			out = binary.LittleEndian.AppendUint32(out, uint32(v))
+		}
+	default:
+		// int32_data holds every remaining type, one element per value,
+		// with 16-bit floats stored as their bit patterns.
+		for _, v := range t.Int32Data {
+			switch size {
+			case 1:
+				out = append(out, byte(v))
+			case 2:
+				out = binary.LittleEndian.AppendUint16(out, uint16(v))
+			default:
+				out = binary.LittleEndian.AppendUint32(out, uint32(v))
+			}
+		}
+	}
+	return out, nil
+}
+
+// EncodeTensor serializes t as a TensorProto with dims, data_type, name,
+// and its data: string_data for strings, the typed repeated fields that are
+// set, packed, or otherwise raw_data. External data entries are not written.
+func EncodeTensor(t *Tensor) []byte {
+	var buf []byte
+	if len(t.Dims) > 0 {
+		var dims []byte
+		for _, d := range t.Dims {
+			dims = binary.AppendUvarint(dims, uint64(d))
+		}
+		buf = appendBytesField(buf, 1, dims)
+	}
+	buf = appendTag(buf, 2, wireVarint)
+	buf = binary.AppendUvarint(buf, uint64(t.DataType))
+	if len(t.FloatData) > 0 {
+		var packed []byte
+		for _, v := range t.FloatData {
+			packed = binary.LittleEndian.AppendUint32(packed, math.Float32bits(v))
+		}
+		buf = appendBytesField(buf, 4, packed)
+	}
+	if len(t.Int32Data) > 0 {
+		var packed []byte
+		for _, v := range t.Int32Data {
+			packed = binary.AppendUvarint(packed, uint64(int64(v)))
+		}
+		buf = appendBytesField(buf, 5, packed)
+	}
+	for _, s := range t.StringData {
+		buf = appendBytesField(buf, 6, s)
+	}
+	if len(t.Int64Data) > 0 {
+		var packed []byte
+		for _, v := range t.Int64Data {
+			packed = binary.AppendUvarint(packed, uint64(v))
+		}
+		buf = appendBytesField(buf, 7, packed)
+	}
+	if t.Name != "" {
+		buf = appendBytesField(buf, 8, []byte(t.Name))
+	}
+	typed := len(t.FloatData) > 0 || len(t.Int32Data) > 0 || len(t.Int64Data) > 0 ||
+		len(t.DoubleData) > 0 || len(t.Uint64Data) > 0
+	if t.DataType != DataTypeString && !typed {
+		buf = appendBytesField(buf, 9, t.RawData)
+	}
+	if len(t.DoubleData) > 0 {
+		var packed []byte
+		for _, v := range t.DoubleData {
+			packed = binary.LittleEndian.AppendUint64(packed, math.Float64bits(v))
+		}
+		buf = appendBytesField(buf, 10, packed)
+	}
+	if len(t.Uint64Data) > 0 {
+		var packed []byte
+		for _, v := range t.Uint64Data {
+			packed = binary.AppendUvarint(packed, v)
+		}
+		buf = appendBytesField(buf, 11, packed)
+	}
+	return buf
+}
+
+// DecodeTensor decodes a serialized TensorProto.
+func DecodeTensor(data []byte) (*Tensor, error) {
+	t := &Tensor{}
+	d := newDecoder(data)
+	for {
+		ok, err := d.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tensor: %w", err)
+		}
+		if !ok {
+			return t, nil
+		}
+		switch d.field {
+		case 1:
+			t.Dims, err = d.int64s(t.Dims)
+		case 2:
+			t.DataType = int32(d.varint)
+		case 4:
+			t.FloatData, err = d.float32s(t.FloatData)
+		case 5:
+			t.Int32Data, err = d.int32s(t.Int32Data)
+		case 6:
+			t.StringData = append(t.StringData, d.bytes)
+		case 7:
+			t.Int64Data, err = d.int64s(t.Int64Data)
+		case 8:
+			t.Name = d.string()
+		case 9:
+			t.RawData = d.bytes
+		case 10:
+			t.DoubleData, err = d.float64s(t.DoubleData)
+		case 11:
+			t.Uint64Data, err = d.uint64s(t.Uint64Data)
+		case 13:
+			var k, v string
+			if k, v, err = decodeStringStringEntry(d.bytes); err == nil {
+				if t.ExternalData == nil {
+					t.ExternalData = make(map[string]string)
+				}
+				t.ExternalData[k] = v
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tensor %q: %w", t.Name, err)
+		}
+	}
+}
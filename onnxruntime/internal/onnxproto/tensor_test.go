@@ -0,0 +1,92 @@
+package onnxproto
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestTensorBytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		tensor Tensor
+		want   []byte
+	}{
+		{"raw", Tensor{Dims: []int64{2}, DataType: DataTypeInt16, RawData: []byte{1, 0, 2, 0}}, []byte{1, 0, 2, 0}},
+		{"float", Tensor{Dims: []int64{1}, DataType: DataTypeFloat, FloatData: []float32{1}}, []byte{0, 0, 0x80, 0x3f}},
+		{"int64", Tensor{Dims: []int64{1}, DataType: DataTypeInt64, Int64Data: []int64{-1}}, bytes.Repeat([]byte{0xff}, 8)},
+		{"uint32", Tensor{Dims: []int64{1}, DataType: DataTypeUint32, Uint64Data: []uint64{7}}, []byte{7, 0, 0, 0}},
+		{"bool", Tensor{Dims: []int64{3}, DataType: DataTypeBool, Int32Data: []int32{1, 0, 1}}, []byte{1, 0, 1}},
+		{"float16", Tensor{Dims: []int64{1}, DataType: DataTypeFloat16, Int32Data: []int32{0x3c00}}, []byte{0x00, 0x3c}},
+		{"empty", Tensor{Dims: []int64{0, 4}, DataType: DataTypeFloat}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tensor.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes failed: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTensorBytesErrors(t *testing.T) {
+	bad := []Tensor{
+		{Dims: []int64{3}, DataType: DataTypeFloat, FloatData: []float32{1}},
+		{Dims: []int64{1}, DataType: DataTypeFloat, RawData: []byte{1, 2}},
+		{Dims: []int64{1}, DataType: DataTypeString, StringData: [][]byte{[]byte("a")}},
+		{Dims: []int64{1}, DataType: DataTypeFloat, ExternalData: map[string]string{"location": "w.bin"}},
+	}
+	for _, tensor := range bad {
+		if _, err := tensor.Bytes(); err == nil {
+			t.Errorf("Expected error for %+v", tensor)
+		}
+	}
+}
+
+func TestEncodeTensorRoundTrip(t *testing.T) {
+	in := &Tensor{Name: "x", Dims: []int64{2, 1}, DataType: DataTypeFloat, RawData: []byte{0, 0, 0x80, 0x3f, 0, 0, 0, 0x40}}
+	out, err := DecodeTensor(EncodeTensor(in))
+	if err != nil {
+		t.Fatalf("DecodeTensor failed: %v", err)
+	}
+	if out.Name != "x" || !slices.Equal(out.Dims, in.Dims) || out.DataType != in.DataType || !bytes.Equal(out.RawData, in.RawData) {
+		t.Errorf("Round trip mismatch: %+v", out)
+	}
+
+	strs := &Tensor{Dims: []int64{2}, DataType: DataTypeString, StringData: [][]byte{[]byte("a"), []byte("bc")}}
+	out, err = DecodeTensor(EncodeTensor(strs))
+	if err != nil {
+		t.Fatalf("DecodeTensor failed: %v", err)
+	}
+	if len(out.StringData) != 2 || string(out.StringData[1]) != "bc" || out.RawData != nil {
+		t.Errorf("String round trip mismatch: %+v", out)
+	}
+}
+
+func TestEncodeTensorTypedFields(t *testing.T) {
+	in := &Tensor{
+		Dims:       []int64{2},
+		DataType:   DataTypeInt32,
+		FloatData:  []float32{1.5, -2},
+		Int32Data:  []int32{-1, 1 << 30},
+		Int64Data:  []int64{-1 << 40, 3},
+		DoubleData: []float64{0.25, -8},
+		Uint64Data: []uint64{1 << 63, 4},
+	}
+	out, err := DecodeTensor(EncodeTensor(in))
+	if err != nil {
+		t.Fatalf("DecodeTensor failed: %v", err)
+	}
+	if !slices.Equal(out.FloatData, in.FloatData) || !slices.Equal(out.Int32Data, in.Int32Data) ||
+		!slices.Equal(out.Int64Data, in.Int64Data) || !slices.Equal(out.DoubleData, in.DoubleData) ||
+		!slices.Equal(out.Uint64Data, in.Uint64Data) {
+		t.Errorf("Typed field round trip mismatch: %+v", out)
+	}
+	if out.RawData != nil {
+		t.Errorf("Expected no raw_data alongside typed fields, got %v", out.RawData)
+	}
+}
@@ -0,0 +1,170 @@
+package onnxproto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("truncated protobuf message")
+
+// decoder iterates over the fields of a protobuf message.
+type decoder struct {
+	buf []byte
+	off int
+
+	// current field
+	field    uint64
+	wireType uint64
+	varint   uint64 // value for varint, fixed32 and fixed64 fields
+	bytes    []byte // value for length-delimited fields
+}
+
+func newDecoder(buf []byte) *decoder {
+	return &decoder{buf: buf}
+}
+
+// next advances to the next field. It returns false at the end of the message
+// or on error; call err to distinguish the two.
+func (d *decoder) next() (bool, error) {
+	if d.off >= len(d.buf) {
+		return false, nil
+	}
+
+	tag, n := binary.Uvarint(d.buf[d.off:])
+	if n <= 0 {
+		return false, errTruncated
+	}
+	d.off += n
+	d.field = tag >> 3
+	d.wireType = tag & 0x7
+	d.bytes = nil
+
+	switch d.wireType {
+	case wireVarint:
+		v, n := binary.Uvarint(d.buf[d.off:])
+		if n <= 0 {
+			return false, errTruncated
+		}
+		d.off += n
+		d.varint = v
+	case wireFixed64:
+		if d.off+8 > len(d.buf) {
+			return false, errTruncated
+		}
+		d.varint = binary.LittleEndian.Uint64(d.buf[d.off:])
+		d.off += 8
+	case wireBytes:
+		length, n := binary.Uvarint(d.buf[d.off:])
+		if n <= 0 {
+			return false, errTruncated
+		}
+		d.off += n
+		if length > uint64(len(d.buf)-d.off) {
+			return false, errTruncated
+		}
+		d.bytes = d.buf[d.off : d.off+int(length)]
+		d.off += int(length)
+	case wireFixed32:
+		if d.off+4 > len(d.buf) {
+			return false, errTruncated
+		}
+		d.varint = uint64(binary.LittleEndian.Uint32(d.buf[d.off:]))
+		d.off += 4
+	default:
+		return false, fmt.Errorf("unsupported protobuf wire type %d", d.wireType)
+	}
+	return true, nil
+}
+
+// string returns the current length-delimited field as a string.
+func (d *decoder) string() string {
+	return string(d.bytes)
+}
+
+// int64s appends the current field to dst, handling both packed and unpacked encodings.
+func (d *decoder) int64s(dst []int64) ([]int64, error) {
+	if d.wireType == wireVarint {
+		return append(dst, int64(d.varint)), nil
+	}
+	if d.wireType != wireBytes {
+		return dst, fmt.Errorf("unexpected wire type %d for repeated int64", d.wireType)
+	}
+	buf := d.bytes
+	for len(buf) > 0 {
+		v, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return dst, errTruncated
+		}
+		dst = append(dst, int64(v))
+		buf = buf[n:]
+	}
+	return dst, nil
+}
+
+// uint64s appends the current field to dst, handling both packed and unpacked encodings.
+func (d *decoder) uint64s(dst []uint64) ([]uint64, error) {
+	vals, err := d.int64s(nil)
+	for _, v := range vals {
+		dst = append(dst, uint64(v))
+	}
+	return dst, err
+}
+
+// int32s appends the current field to dst, handling both packed and unpacked encodings.
+func (d *decoder) int32s(dst []int32) ([]int32, error) {
+	vals, err := d.int64s(nil)
+	for _, v := range vals {
+		dst = append(dst, int32(v))
+	}
+	return dst, err
+}
+
+// float32s appends the current field to dst, handling both packed and unpacked encodings.
+func (d *decoder) float32s(dst []float32) ([]float32, error) {
+	if d.wireType == wireFixed32 {
+		return append(dst, math.Float32frombits(uint32(d.varint))), nil
+	}
+	if d.wireType != wireBytes || len(d.bytes)%4 != 0 {
+		return dst, fmt.Errorf("invalid encoding for repeated float")
+	}
+	for i := 0; i < len(d.bytes); i += 4 {
+		dst = append(dst, math.Float32frombits(binary.LittleEndian.Uint32(d.bytes[i:])))
+	}
+	return dst, nil
+}
+
+// float64s appends the current field to dst, handling both packed and unpacked encodings.
+func (d *decoder) float64s(dst []float64) ([]float64, error) {
+	if d.wireType == wireFixed64 {
+		return append(dst, math.Float64frombits(d.varint)), nil
+	}
+	if d.wireType != wireBytes || len(d.bytes)%8 != 0 {
+		return dst, fmt.Errorf("invalid encoding for repeated double")
+	}
+	for i := 0; i < len(d.bytes); i += 8 {
+		dst = append(dst, math.Float64frombits(binary.LittleEndian.Uint64(d.bytes[i:])))
+	}
+	return dst, nil
+}
+
+// appendTag appends a field tag.
+func appendTag(buf []byte, field, wireType uint64) []byte {
+	return binary.AppendUvarint(buf, field<<3|wireType)
+}
+
+// appendBytesField appends a length-delimited field.
+func appendBytesField(buf []byte, field uint64, b []byte) []byte {
+	buf = appendTag(buf, field, wireBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -33,7 +34,7 @@ func TestE2E(t *testing.T) {
 			session, err := testRuntime.NewSessionFromReader(env, bytes.NewReader(modelData), nil)
 			if err != nil {
 				// Skip test cases with unsupported IR version
-				if strings.Contains(err.Error(), "Unsupported model IR version") {
+				if errors.Is(err, onnxruntime.ErrUnsupportedIRVersion) {
 					t.Skipf("Skipping due to unsupported IR version: %v", err)
 				}
 				t.Fatalf("Failed to create session: %v", err)
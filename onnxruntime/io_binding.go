@@ -4,32 +4,128 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"slices"
 	"unsafe"
 
+	"github.com/benedoc-inc/onnxer/internal/cstrings"
 	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
 )
 
 // MemoryInfo describes memory allocation properties.
-// Use Runtime.NewCPUMemoryInfo() to create one.
+// Use Runtime.NewCPUMemoryInfo() or Runtime.NewMemoryInfo() to create one.
 type MemoryInfo struct {
 	ptr     api.OrtMemoryInfo
 	runtime *Runtime
+
+	// owner is the session that owns ptr for memory infos returned by
+	// Session.GetMemoryInfoForInputs and GetMemoryInfoForOutputs; Close does
+	// not release them.
+	owner *Session
+
+	// ID of the memory info in Runtime.objects, zero unless created in debug
+	// mode or owned by a session
+	trackID uint64
+
+	// safety net releasing ptr if the memory info is not closed
+	cleanup runtime.Cleanup
 }
 
 // NewCPUMemoryInfo creates a MemoryInfo for CPU memory.
 func (r *Runtime) NewCPUMemoryInfo() (*MemoryInfo, error) {
-	mi, err := r.createCPUMemoryInfo(allocatorTypeDevice, memTypeCPU)
+	mi, err := r.createCPUMemoryInfo(AllocatorTypeDevice, MemTypeDefault)
 	if err != nil {
 		return nil, err
 	}
-	return &MemoryInfo{ptr: mi.ptr, runtime: r}, nil
+	return r.newMemoryInfo(mi.ptr), nil
+}
+
+// NewMemoryInfo creates a MemoryInfo for the named device, such as
+// [MemoryInfoNameCUDA] or [MemoryInfoNameDML]. Pass it to
+// [IoBinding.BindOutputToDevice] to keep outputs on the device so chained runs
+// avoid copying through host memory.
+//
+// The device must be provided by an execution provider registered on the
+// session that uses it; ONNX Runtime reports an error at bind or run time
+// otherwise.
+func (r *Runtime) NewMemoryInfo(name string, allocType AllocatorType, memType MemType, deviceID int) (*MemoryInfo, error) {
+	nameBytes := append([]byte(name), 0)
+	var memInfoPtr api.OrtMemoryInfo
+	status := r.apiFuncs.CreateMemoryInfo(&nameBytes[0], allocType, int32(deviceID), memType, &memInfoPtr)
+	if err := r.statusError(status, "CreateMemoryInfo"); err != nil {
+		return nil, fmt.Errorf("failed to create memory info for %q: %w", name, err)
+	}
+	return r.newMemoryInfo(memInfoPtr), nil
+}
+
+// newMemoryInfo wraps a memory info owned by the caller.
+func (r *Runtime) newMemoryInfo(ptr api.OrtMemoryInfo) *MemoryInfo {
+	mi := &MemoryInfo{ptr: ptr, runtime: r, trackID: r.objects.add(kindMemoryInfo)}
+	mi.cleanup = guard(mi, leak{runtime: r, kind: kindMemoryInfo, id: mi.trackID, ptr: uintptr(ptr), release: releaseLeakedMemoryInfo})
+	return mi
+}
+
+func releaseLeakedMemoryInfo(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseMemoryInfo(api.OrtMemoryInfo(ptr))
+}
+
+// Name returns the device name of the memory info, e.g. "Cpu" or "Cuda".
+func (mi *MemoryInfo) Name() (string, error) {
+	var namePtr *byte
+	status := mi.runtime.apiFuncs.MemoryInfoGetName(mi.ptr, &namePtr)
+	if err := mi.runtime.statusError(status, "MemoryInfoGetName"); err != nil {
+		return "", fmt.Errorf("failed to get memory info name: %w", err)
+	}
+	return cstrings.CStringToString(namePtr), nil
+}
+
+// DeviceID returns the device ordinal of the memory info.
+func (mi *MemoryInfo) DeviceID() (int, error) {
+	var id int32
+	status := mi.runtime.apiFuncs.MemoryInfoGetId(mi.ptr, &id)
+	if err := mi.runtime.statusError(status, "MemoryInfoGetId"); err != nil {
+		return 0, fmt.Errorf("failed to get memory info device id: %w", err)
+	}
+	return int(id), nil
+}
+
+// MemType returns the memory type of the memory info.
+func (mi *MemoryInfo) MemType() (MemType, error) {
+	var memType MemType
+	status := mi.runtime.apiFuncs.MemoryInfoGetMemType(mi.ptr, &memType)
+	if err := mi.runtime.statusError(status, "MemoryInfoGetMemType"); err != nil {
+		return MemTypeDefault, fmt.Errorf("failed to get memory info memory type: %w", err)
+	}
+	return memType, nil
+}
+
+// AllocatorType returns the allocator type of the memory info.
+func (mi *MemoryInfo) AllocatorType() (AllocatorType, error) {
+	var allocType AllocatorType
+	status := mi.runtime.apiFuncs.MemoryInfoGetType(mi.ptr, &allocType)
+	if err := mi.runtime.statusError(status, "MemoryInfoGetType"); err != nil {
+		return AllocatorTypeDevice, fmt.Errorf("failed to get memory info allocator type: %w", err)
+	}
+	return allocType, nil
+}
+
+// DeviceType returns the kind of device the memory lives on.
+func (mi *MemoryInfo) DeviceType() MemoryDeviceType {
+	var deviceType MemoryDeviceType
+	mi.runtime.apiFuncs.MemoryInfoGetDeviceType(mi.ptr, &deviceType)
+	return deviceType
 }
 
-// Close releases the memory info resources.
+// Close releases the memory info resources. It does nothing for memory infos
+// owned by a session.
 func (mi *MemoryInfo) Close() {
+	if mi.owner != nil {
+		return
+	}
 	if mi.ptr != 0 && mi.runtime != nil && mi.runtime.apiFuncs != nil {
+		mi.cleanup.Stop()
 		mi.runtime.apiFuncs.ReleaseMemoryInfo(mi.ptr)
 		mi.ptr = 0
+		mi.runtime.objects.remove(kindMemoryInfo, mi.trackID)
 	}
 }
 
@@ -40,6 +136,12 @@ func (mi *MemoryInfo) Close() {
 type IoBinding struct {
 	ptr     api.OrtIoBinding
 	session *Session
+
+	// ID of the binding in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the binding is not closed
+	cleanup runtime.Cleanup
 }
 
 // NewIoBinding creates a new IO binding for this session.
@@ -50,23 +152,66 @@ func (s *Session) NewIoBinding() (*IoBinding, error) {
 
 	var bindingPtr api.OrtIoBinding
 	status := s.runtime.apiFuncs.CreateIoBinding(s.ptr, &bindingPtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "CreateIoBinding"); err != nil {
 		return nil, fmt.Errorf("failed to create IO binding: %w", err)
 	}
 
 	b := &IoBinding{
 		ptr:     bindingPtr,
 		session: s,
+		trackID: s.runtime.objects.add(kindIoBinding),
 	}
-	runtime.AddCleanup(b, func(_ struct{}) { b.Close() }, struct{}{})
+	// The session is kept until the binding is released
+	b.cleanup = guard(b, leak{runtime: s.runtime, kind: kindIoBinding, id: b.trackID, ptr: uintptr(bindingPtr), release: releaseLeakedIoBinding, keep: s})
 	return b, nil
 }
 
+func releaseLeakedIoBinding(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseIoBinding(api.OrtIoBinding(ptr))
+}
+
+// NewOutputTensor allocates a tensor for the named output, owned by ONNX
+// Runtime's default CPU allocator, for use with [IoBinding.BindOutput]. The
+// element type is taken from the model and shape must match the declared
+// output shape, with concrete sizes for any symbolic dimensions.
+func (s *Session) NewOutputTensor(name string, shape []int64) (*Value, error) {
+	if s.ptr == 0 {
+		return nil, ErrSessionClosed
+	}
+
+	index := slices.Index(s.outputNames, name)
+	if index < 0 {
+		return nil, fmt.Errorf("unknown output %q", name)
+	}
+	info, err := s.getTypeInfo(false, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get type info for output %q: %w", name, err)
+	}
+	if info.tensorInfo == nil {
+		return nil, fmt.Errorf("output %q is not a tensor", name)
+	}
+
+	declared := info.tensorInfo.Shape
+	if len(shape) != len(declared) {
+		return nil, fmt.Errorf("output %q has rank %d, got shape %v", name, len(declared), shape)
+	}
+	for i, d := range shape {
+		if d < 0 {
+			return nil, fmt.Errorf("invalid dimension %d in shape %v", d, shape)
+		}
+		if declared[i] > 0 && d != declared[i] {
+			return nil, fmt.Errorf("output %q has shape %v, got %v", name, declared, shape)
+		}
+	}
+
+	return s.runtime.newAllocatedTensor(shape, info.tensorInfo.ElementType)
+}
+
 // BindInput binds an input tensor to the given name.
 func (b *IoBinding) BindInput(name string, value *Value) error {
 	nameBytes := append([]byte(name), 0)
 	status := b.session.runtime.apiFuncs.BindInput(b.ptr, &nameBytes[0], value.ptr)
-	if err := b.session.runtime.statusError(status); err != nil {
+	if err := b.session.runtime.statusError(status, "BindInput"); err != nil {
 		return fmt.Errorf("failed to bind input %q: %w", name, err)
 	}
 	return nil
@@ -76,7 +221,7 @@ func (b *IoBinding) BindInput(name string, value *Value) error {
 func (b *IoBinding) BindOutput(name string, value *Value) error {
 	nameBytes := append([]byte(name), 0)
 	status := b.session.runtime.apiFuncs.BindOutput(b.ptr, &nameBytes[0], value.ptr)
-	if err := b.session.runtime.statusError(status); err != nil {
+	if err := b.session.runtime.statusError(status, "BindOutput"); err != nil {
 		return fmt.Errorf("failed to bind output %q: %w", name, err)
 	}
 	return nil
@@ -87,26 +232,41 @@ func (b *IoBinding) BindOutput(name string, value *Value) error {
 func (b *IoBinding) BindOutputToDevice(name string, memInfo *MemoryInfo) error {
 	nameBytes := append([]byte(name), 0)
 	status := b.session.runtime.apiFuncs.BindOutputToDevice(b.ptr, &nameBytes[0], memInfo.ptr)
-	if err := b.session.runtime.statusError(status); err != nil {
+	if err := b.session.runtime.statusError(status, "BindOutputToDevice"); err != nil {
 		return fmt.Errorf("failed to bind output %q to device: %w", name, err)
 	}
 	return nil
 }
 
 // Run executes inference using the bound inputs and outputs.
-// Context cancellation is supported — if ctx is cancelled, the run will be terminated.
-func (b *IoBinding) Run(ctx context.Context) error {
+// Context cancellation is supported — if ctx is cancelled, the run will be terminated
+// and the returned error matches ctx.Err() with errors.Is.
+func (b *IoBinding) Run(ctx context.Context) (err error) {
 	r := b.session.runtime
+	if len(b.session.hooks) > 0 {
+		afterRun := b.session.beforeRun(ctx, nil, nil, "")
+		defer func() { afterRun(nil, err) }()
+	}
+	defer b.session.recoverRun(&err)
+
+	if b.session.faults != nil {
+		if err := b.session.faults.inject(ctx, b.session); err != nil {
+			return err
+		}
+	}
 
-	runOptions, cleanup, err := b.session.createRunOptions(ctx, &runConfig{})
+	runOptions, err := b.session.runtime.createRunOptions(ctx, &runConfig{})
 	if err != nil {
 		return err
 	}
-	defer cleanup()
+	defer runOptions.close()
 
-	status := r.apiFuncs.RunWithBinding(b.session.ptr, runOptions, b.ptr)
-	if err := r.statusError(status); err != nil {
-		return fmt.Errorf("failed to run with binding: %w", err)
+	var status api.OrtStatus
+	b.session.traceNative(ctx, regionRunWithBinding, "", func() {
+		status = r.apiFuncs.RunWithBinding(b.session.ptr, runOptions.ptr, b.ptr)
+	})
+	if err := r.statusError(status, "RunWithBinding"); err != nil {
+		return runOptions.wrapError(fmt.Errorf("failed to run with binding: %w", err))
 	}
 	return nil
 }
@@ -122,7 +282,7 @@ func (b *IoBinding) GetOutputValues() (map[string]*Value, error) {
 	var valuesPtr *api.OrtValue
 	var valueCount uintptr
 	status := r.apiFuncs.GetBoundOutputValues(b.ptr, r.allocator.ptr, &valuesPtr, &valueCount)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "GetBoundOutputValues"); err != nil {
 		return nil, fmt.Errorf("failed to get bound output values: %w", err)
 	}
 
@@ -144,6 +304,11 @@ func (b *IoBinding) GetOutputValues() (map[string]*Value, error) {
 	return result, nil
 }
 
+// Session returns the session the binding runs.
+func (b *IoBinding) Session() *Session {
+	return b.session
+}
+
 // ClearInputs clears all bound inputs.
 func (b *IoBinding) ClearInputs() {
 	b.session.runtime.apiFuncs.ClearBoundInputs(b.ptr)
@@ -159,7 +324,7 @@ func (b *IoBinding) ClearOutputs() {
 // to ensure host-to-device transfers are complete.
 func (b *IoBinding) SynchronizeInputs() error {
 	status := b.session.runtime.apiFuncs.SynchronizeBoundInputs(b.ptr)
-	if err := b.session.runtime.statusError(status); err != nil {
+	if err := b.session.runtime.statusError(status, "SynchronizeBoundInputs"); err != nil {
 		return fmt.Errorf("failed to synchronize bound inputs: %w", err)
 	}
 	return nil
@@ -170,7 +335,7 @@ func (b *IoBinding) SynchronizeInputs() error {
 // device-to-host transfers are complete before reading output data.
 func (b *IoBinding) SynchronizeOutputs() error {
 	status := b.session.runtime.apiFuncs.SynchronizeBoundOutputs(b.ptr)
-	if err := b.session.runtime.statusError(status); err != nil {
+	if err := b.session.runtime.statusError(status, "SynchronizeBoundOutputs"); err != nil {
 		return fmt.Errorf("failed to synchronize bound outputs: %w", err)
 	}
 	return nil
@@ -179,7 +344,9 @@ func (b *IoBinding) SynchronizeOutputs() error {
 // Close releases the IO binding resources.
 func (b *IoBinding) Close() {
 	if b.ptr != 0 && b.session != nil && b.session.runtime != nil && b.session.runtime.apiFuncs != nil {
+		b.cleanup.Stop()
 		b.session.runtime.apiFuncs.ReleaseIoBinding(b.ptr)
 		b.ptr = 0
+		b.session.runtime.objects.remove(kindIoBinding, b.trackID)
 	}
 }
@@ -165,6 +165,97 @@ func TestNewCPUMemoryInfo(t *testing.T) {
 	memInfo.Close()
 }
 
+func TestNewMemoryInfo(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	memInfo, err := runtime.NewMemoryInfo(MemoryInfoNameCPU, AllocatorTypeArena, MemTypeDefault, 0)
+	if err != nil {
+		t.Fatalf("Failed to create memory info: %v", err)
+	}
+	defer memInfo.Close()
+
+	name, err := memInfo.Name()
+	if err != nil {
+		t.Fatalf("Name failed: %v", err)
+	}
+	if name != MemoryInfoNameCPU {
+		t.Errorf("Expected name %q, got %q", MemoryInfoNameCPU, name)
+	}
+
+	id, err := memInfo.DeviceID()
+	if err != nil {
+		t.Fatalf("DeviceID failed: %v", err)
+	}
+	if id != 0 {
+		t.Errorf("Expected device id 0, got %d", id)
+	}
+
+	memType, err := memInfo.MemType()
+	if err != nil {
+		t.Fatalf("MemType failed: %v", err)
+	}
+	if memType != MemTypeDefault {
+		t.Errorf("Expected MemTypeDefault, got %d", memType)
+	}
+
+	allocType, err := memInfo.AllocatorType()
+	if err != nil {
+		t.Fatalf("AllocatorType failed: %v", err)
+	}
+	if allocType != AllocatorTypeArena {
+		t.Errorf("Expected AllocatorTypeArena, got %d", allocType)
+	}
+}
+
+func TestIoBindingRunWithNamedMemoryInfo(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	inputTensor, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create input tensor: %v", err)
+	}
+	defer inputTensor.Close()
+
+	memInfo, err := runtime.NewMemoryInfo(MemoryInfoNameCPU, AllocatorTypeDevice, MemTypeDefault, 0)
+	if err != nil {
+		t.Fatalf("Failed to create memory info: %v", err)
+	}
+	defer memInfo.Close()
+
+	binding, err := session.NewIoBinding()
+	if err != nil {
+		t.Fatalf("Failed to create IO binding: %v", err)
+	}
+	defer binding.Close()
+
+	if err := binding.BindInput("input", inputTensor); err != nil {
+		t.Fatalf("Failed to bind input: %v", err)
+	}
+	if err := binding.BindOutputToDevice("logits", memInfo); err != nil {
+		t.Fatalf("Failed to bind output to device: %v", err)
+	}
+	if err := binding.SynchronizeInputs(); err != nil {
+		t.Fatalf("SynchronizeInputs failed: %v", err)
+	}
+	if err := binding.Run(t.Context()); err != nil {
+		t.Fatalf("Failed to run with binding: %v", err)
+	}
+	if err := binding.SynchronizeOutputs(); err != nil {
+		t.Fatalf("SynchronizeOutputs failed: %v", err)
+	}
+
+	outputs, err := binding.GetOutputValues()
+	if err != nil {
+		t.Fatalf("Failed to get output values: %v", err)
+	}
+	defer closeValues(outputs)
+
+	if _, ok := outputs["logits"]; !ok {
+		t.Error("Expected logits output")
+	}
+}
+
 func TestIoBindingClosedSession(t *testing.T) {
 	runtime := newTestRuntime(t)
 	session := newTestSession(t, runtime)
@@ -175,3 +266,80 @@ func TestIoBindingClosedSession(t *testing.T) {
 		t.Error("Expected error for closed session")
 	}
 }
+
+func TestSessionNewOutputTensor(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	inputTensor, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{2, 10})
+	if err != nil {
+		t.Fatalf("Failed to create input tensor: %v", err)
+	}
+	defer inputTensor.Close()
+
+	outputTensor, err := session.NewOutputTensor("logits", []int64{2, 3})
+	if err != nil {
+		t.Fatalf("NewOutputTensor failed: %v", err)
+	}
+	defer outputTensor.Close()
+
+	elemType, err := outputTensor.GetTensorElementType()
+	if err != nil {
+		t.Fatalf("Failed to get element type: %v", err)
+	}
+	if elemType != ONNXTensorElementDataTypeFloat {
+		t.Errorf("Expected float output tensor, got %d", elemType)
+	}
+
+	binding, err := session.NewIoBinding()
+	if err != nil {
+		t.Fatalf("Failed to create IO binding: %v", err)
+	}
+	defer binding.Close()
+
+	if err := binding.BindInput("input", inputTensor); err != nil {
+		t.Fatalf("Failed to bind input: %v", err)
+	}
+	if err := binding.BindOutput("logits", outputTensor); err != nil {
+		t.Fatalf("Failed to bind output: %v", err)
+	}
+	if err := binding.Run(t.Context()); err != nil {
+		t.Fatalf("Failed to run with binding: %v", err)
+	}
+
+	data, shape, err := GetTensorData[float32](outputTensor)
+	if err != nil {
+		t.Fatalf("Failed to get output data: %v", err)
+	}
+	if len(data) != 6 || len(shape) != 2 || shape[0] != 2 || shape[1] != 3 {
+		t.Errorf("Expected 6 elements with shape [2 3], got %d with %v", len(data), shape)
+	}
+}
+
+func TestSessionNewOutputTensorErrors(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	tests := []struct {
+		name   string
+		output string
+		shape  []int64
+	}{
+		{"unknown output", "missing", []int64{1, 3}},
+		{"wrong rank", "logits", []int64{3}},
+		{"fixed dim mismatch", "logits", []int64{1, 4}},
+		{"negative dim", "logits", []int64{-1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := session.NewOutputTensor(tt.output, tt.shape); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+
+	session.Close()
+	if _, err := session.NewOutputTensor("logits", []int64{1, 3}); err != ErrSessionClosed {
+		t.Errorf("Expected ErrSessionClosed, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+// Package kvcache runs decoder-only language models exported to ONNX with
+// explicit key/value cache inputs and outputs, one token at a time.
+//
+// Such models, for example Hugging Face Optimum exports of GPT-2, Llama, or
+// Phi, take past_key_values.* inputs and produce present.* outputs of the
+// same shape with the new positions appended. A KVCache holds the cache
+// tensors between steps: it binds them as inputs of the next run through an
+// IoBinding and binds the present outputs to a device, so on GPU execution
+// providers the cache never leaves device memory.
+//
+// A StepRunner builds the remaining per-step inputs, input_ids,
+// attention_mask, and position_ids, from the tokens and the cache length,
+// and returns the logits:
+//
+//	steps, err := kvcache.NewStepRunner(runtime, session, 1, nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer steps.Close()
+//	tokens := [][]int64{prompt}
+//	for range maxTokens {
+//	    logits, err := steps.Step(ctx, tokens)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    next := sample(logits)
+//	    logits.Close()
+//	    tokens = [][]int64{{next}}
+//	}
+package kvcache
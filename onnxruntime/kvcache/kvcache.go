@@ -0,0 +1,226 @@
+package kvcache
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Default prefixes of cache input and output names, as in
+// past_key_values.0.key and present.0.key.
+const (
+	DefaultPastPrefix    = "past_key_values"
+	DefaultPresentPrefix = "present"
+)
+
+// Options configures a KVCache.
+type Options struct {
+	// PastPrefix and PresentPrefix start the names of the cache inputs and
+	// outputs. An input is fed by the output with the prefix replaced.
+	// Default DefaultPastPrefix and DefaultPresentPrefix.
+	PastPrefix    string
+	PresentPrefix string
+
+	// Device is where ONNX Runtime allocates the present outputs, such as a
+	// MemoryInfo created with MemoryInfoNameCUDA. Nil keeps the cache in CPU
+	// memory. The KVCache does not close it.
+	Device *ort.MemoryInfo
+
+	// SequenceAxis is the axis of the cache tensors that grows by one entry
+	// per token. Default 2, as in [batch, heads, sequence, head_dim].
+	SequenceAxis int
+}
+
+// entry is one cache tensor, read from the past input and refreshed from
+// the present output.
+type entry struct {
+	past, present string
+	info          ort.InputInfo
+	value         *ort.Value
+}
+
+// KVCache holds the key/value cache of a decoder-only model between runs.
+// It is not safe for concurrent use.
+type KVCache struct {
+	runtime *ort.Runtime
+	batch   int
+	device  *ort.MemoryInfo
+	cpu     *ort.MemoryInfo
+	axis    int
+	outputs []string
+	entries []*entry
+	length  int
+}
+
+// New creates an empty cache for batch sequences of session, which must
+// have at least one cache input with a matching present output.
+func New(runtime *ort.Runtime, session *ort.Session, batch int, opts *Options) (*KVCache, error) {
+	if runtime == nil || session == nil {
+		return nil, fmt.Errorf("runtime and session are required")
+	}
+	if batch <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", batch)
+	}
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.PastPrefix == "" {
+		o.PastPrefix = DefaultPastPrefix
+	}
+	if o.PresentPrefix == "" {
+		o.PresentPrefix = DefaultPresentPrefix
+	}
+	if o.SequenceAxis == 0 {
+		o.SequenceAxis = 2
+	}
+
+	infos, err := session.GetInputInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get input info: %w", err)
+	}
+	c := &KVCache{
+		runtime: runtime,
+		batch:   batch,
+		device:  o.Device,
+		axis:    o.SequenceAxis,
+		outputs: session.OutputNames(),
+	}
+	for _, info := range infos {
+		if !strings.HasPrefix(info.Name, o.PastPrefix) {
+			continue
+		}
+		present := presentName(info.Name, o.PastPrefix, o.PresentPrefix)
+		if !slices.Contains(c.outputs, present) {
+			return nil, fmt.Errorf("cache input %q has no %q output", info.Name, present)
+		}
+		if info.TensorInfo == nil || len(info.TensorInfo.Shape) <= c.axis {
+			return nil, fmt.Errorf("cache input %q must be a tensor with a sequence axis %d", info.Name, c.axis)
+		}
+		c.entries = append(c.entries, &entry{past: info.Name, present: present, info: info})
+	}
+	if len(c.entries) == 0 {
+		return nil, fmt.Errorf("session has no %s inputs", o.PastPrefix)
+	}
+	if c.cpu, err = runtime.NewCPUMemoryInfo(); err != nil {
+		return nil, fmt.Errorf("failed to create CPU memory info: %w", err)
+	}
+	return c, nil
+}
+
+// presentName returns the output that feeds cache input past.
+func presentName(past, pastPrefix, presentPrefix string) string {
+	return presentPrefix + strings.TrimPrefix(past, pastPrefix)
+}
+
+// Len returns the number of positions in the cache.
+func (c *KVCache) Len() int {
+	return c.length
+}
+
+// Bind binds the cache tensors as inputs of b, allocating empty ones before
+// the first run. It also binds every output of the session in model order,
+// as IoBinding.GetOutputValues expects: the present outputs to the cache
+// device and the others to CPU memory.
+func (c *KVCache) Bind(b *ort.IoBinding) error {
+	for _, e := range c.entries {
+		if e.value == nil {
+			v, err := c.empty(e.info)
+			if err != nil {
+				return err
+			}
+			e.value = v
+		}
+		if err := b.BindInput(e.past, e.value); err != nil {
+			return err
+		}
+	}
+	for _, name := range c.outputs {
+		device := c.cpu
+		if c.device != nil && c.isPresent(name) {
+			device = c.device
+		}
+		if err := b.BindOutputToDevice(name, device); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *KVCache) isPresent(output string) bool {
+	for _, e := range c.entries {
+		if e.present == output {
+			return true
+		}
+	}
+	return false
+}
+
+// Update replaces the cache with the present outputs of a run, removing
+// them from outputs, and sets Len to their sequence length. The previous
+// cache tensors are closed, so it must be called after the run that read
+// them.
+func (c *KVCache) Update(outputs map[string]*ort.Value) error {
+	for _, e := range c.entries {
+		if outputs[e.present] == nil {
+			return fmt.Errorf("missing cache output %q", e.present)
+		}
+	}
+	shape, err := outputs[c.entries[0].present].GetTensorShape()
+	if err != nil {
+		return fmt.Errorf("failed to get shape of %q: %w", c.entries[0].present, err)
+	}
+	if len(shape) <= c.axis {
+		return fmt.Errorf("cache output %q has shape %v, want a sequence axis %d", c.entries[0].present, shape, c.axis)
+	}
+	for _, e := range c.entries {
+		if e.value != nil {
+			e.value.Close()
+		}
+		e.value = outputs[e.present]
+		delete(outputs, e.present)
+	}
+	c.length = int(shape[c.axis])
+	return nil
+}
+
+// Reset empties the cache, for example to start a new conversation.
+func (c *KVCache) Reset() {
+	for _, e := range c.entries {
+		if e.value != nil {
+			e.value.Close()
+			e.value = nil
+		}
+	}
+	c.length = 0
+}
+
+// Close releases the cache tensors.
+func (c *KVCache) Close() {
+	c.Reset()
+	c.cpu.Close()
+}
+
+// empty creates a cache tensor with no positions. Other dynamic dimensions
+// besides the batch are zero too; exports usually declare the number of
+// heads and the head size as fixed.
+func (c *KVCache) empty(info ort.InputInfo) (*ort.Value, error) {
+	shape := make([]int64, len(info.TensorInfo.Shape))
+	for i, d := range info.TensorInfo.Shape {
+		switch {
+		case i == 0:
+			shape[i] = int64(c.batch)
+		case d < 0 || i == c.axis:
+			shape[i] = 0
+		default:
+			shape[i] = d
+		}
+	}
+	v, err := ort.NewTensorValueFromBytes(c.runtime, nil, shape, info.TensorInfo.ElementType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate empty %q: %w", info.Name, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,74 @@
+package kvcache
+
+import (
+	"path/filepath"
+	goruntime "runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func testModelPath() string {
+	_, file, _, _ := goruntime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "internal", "tests", "testdata", "model.onnx")
+}
+
+func TestStepInputs(t *testing.T) {
+	ids, mask, positions, err := stepInputs([][]int64{{7, 8}, {9, 10}}, 2, 3)
+	if err != nil {
+		t.Fatalf("stepInputs() error = %v", err)
+	}
+	if !slices.Equal(ids, []int64{7, 8, 9, 10}) {
+		t.Errorf("ids = %v", ids)
+	}
+	if len(mask) != 2*5 || slices.Contains(mask, 0) {
+		t.Errorf("mask = %v, want 10 ones", mask)
+	}
+	if !slices.Equal(positions, []int64{3, 4, 3, 4}) {
+		t.Errorf("positions = %v, want [3 4 3 4]", positions)
+	}
+
+	for _, tokens := range [][][]int64{{{1}}, {{}, {}}, {{1}, {1, 2}}} {
+		if _, _, _, err := stepInputs(tokens, 2, 0); err == nil {
+			t.Errorf("stepInputs(%v) succeeded, want error", tokens)
+		}
+	}
+}
+
+func TestPresentName(t *testing.T) {
+	if got := presentName("past_key_values.3.value", DefaultPastPrefix, DefaultPresentPrefix); got != "present.3.value" {
+		t.Errorf("presentName() = %q, want present.3.value", got)
+	}
+	if got := presentName("past_0", "past_", "present_"); got != "present_0" {
+		t.Errorf("presentName() = %q, want present_0", got)
+	}
+}
+
+func TestNewWithoutCache(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+	session, err := runtime.NewSession(env, testModelPath(), nil)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	defer session.Close()
+
+	if _, err := New(runtime, session, 1, nil); err == nil || !strings.Contains(err.Error(), "no past_key_values inputs") {
+		t.Errorf("New() error = %v, want no cache inputs", err)
+	}
+	// The test model's "input" matches a custom prefix but has no present
+	// output.
+	if _, err := NewStepRunner(runtime, session, 1, &Options{PastPrefix: "in", PresentPrefix: "out"}); err == nil || !strings.Contains(err.Error(), `"output"`) {
+		t.Errorf("NewStepRunner() error = %v, want missing present output", err)
+	}
+	if _, err := New(runtime, session, 0, nil); err == nil {
+		t.Error("New() with batch 0 succeeded, want error")
+	}
+}
@@ -0,0 +1,196 @@
+package kvcache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Input and output names of decoder-only exports.
+const (
+	InputIDs      = "input_ids"
+	AttentionMask = "attention_mask"
+	PositionIDs   = "position_ids"
+	Logits        = "logits"
+)
+
+// StepRunner runs a decoder-only model step by step, feeding the key/value
+// cache from one step to the next. The sequences of a batch advance
+// together, so their prompts must have the same length. It is not safe for
+// concurrent use.
+type StepRunner struct {
+	runtime *ort.Runtime
+	binding *ort.IoBinding
+	cache   *KVCache
+	batch   int
+	inputs  map[string]ort.InputInfo
+}
+
+// NewStepRunner creates a StepRunner for batch sequences of session, which
+// must take input_ids and cache inputs, may take attention_mask and
+// position_ids, and must produce logits and the present cache outputs.
+func NewStepRunner(runtime *ort.Runtime, session *ort.Session, batch int, opts *Options) (*StepRunner, error) {
+	cache, err := New(runtime, session, batch, opts)
+	if err != nil {
+		return nil, err
+	}
+	s := &StepRunner{runtime: runtime, cache: cache, batch: batch, inputs: make(map[string]ort.InputInfo)}
+
+	infos, err := session.GetInputInfo()
+	if err != nil {
+		cache.Close()
+		return nil, fmt.Errorf("failed to get input info: %w", err)
+	}
+	for _, info := range infos {
+		switch info.Name {
+		case InputIDs, AttentionMask, PositionIDs:
+			if info.TensorInfo == nil {
+				cache.Close()
+				return nil, fmt.Errorf("input %q is not a tensor", info.Name)
+			}
+			s.inputs[info.Name] = info
+		}
+	}
+	if _, ok := s.inputs[InputIDs]; !ok {
+		cache.Close()
+		return nil, fmt.Errorf("session has no %q input", InputIDs)
+	}
+	if !slices.Contains(session.OutputNames(), Logits) {
+		cache.Close()
+		return nil, fmt.Errorf("session has no %q output", Logits)
+	}
+
+	if s.binding, err = session.NewIoBinding(); err != nil {
+		cache.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Cache returns the runner's key/value cache.
+func (s *StepRunner) Cache() *KVCache {
+	return s.cache
+}
+
+// Step appends tokens, one row per sequence of the batch, to the sequences
+// and returns the logits of the new positions, shaped [batch, len(row),
+// vocab]. The first step usually passes the prompts and later steps one
+// sampled token per sequence. The caller must close the logits.
+func (s *StepRunner) Step(ctx context.Context, tokens [][]int64) (*ort.Value, error) {
+	ids, mask, positions, err := stepInputs(tokens, s.batch, s.cache.Len())
+	if err != nil {
+		return nil, err
+	}
+	n := int64(len(tokens[0]))
+	batch := int64(s.batch)
+
+	var created []*ort.Value
+	defer func() {
+		for _, v := range created {
+			v.Close()
+		}
+	}()
+	s.binding.ClearInputs()
+	s.binding.ClearOutputs()
+	bind := func(name string, data []int64, shape []int64) error {
+		info, ok := s.inputs[name]
+		if !ok {
+			return nil
+		}
+		v, err := newIDs(s.runtime, info, data, shape)
+		if err != nil {
+			return err
+		}
+		created = append(created, v)
+		return s.binding.BindInput(name, v)
+	}
+	if err := bind(InputIDs, ids, []int64{batch, n}); err != nil {
+		return nil, err
+	}
+	if err := bind(AttentionMask, mask, []int64{batch, int64(len(mask)) / batch}); err != nil {
+		return nil, err
+	}
+	if err := bind(PositionIDs, positions, []int64{batch, n}); err != nil {
+		return nil, err
+	}
+	if err := s.cache.Bind(s.binding); err != nil {
+		return nil, err
+	}
+
+	if err := s.binding.Run(ctx); err != nil {
+		return nil, err
+	}
+	outputs, err := s.binding.GetOutputValues()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, v := range outputs {
+			v.Close()
+		}
+	}()
+	if err := s.cache.Update(outputs); err != nil {
+		return nil, err
+	}
+	logits := outputs[Logits]
+	delete(outputs, Logits)
+	return logits, nil
+}
+
+// stepInputs builds the input_ids, attention_mask, and position_ids of a
+// step that appends tokens to sequences with past cached positions.
+func stepInputs(tokens [][]int64, batch, past int) (ids, mask, positions []int64, err error) {
+	if len(tokens) != batch {
+		return nil, nil, nil, fmt.Errorf("got %d token rows for a batch of %d", len(tokens), batch)
+	}
+	n := len(tokens[0])
+	if n == 0 {
+		return nil, nil, nil, fmt.Errorf("no tokens to append")
+	}
+	ids = make([]int64, 0, batch*n)
+	positions = make([]int64, 0, batch*n)
+	for i, row := range tokens {
+		if len(row) != n {
+			return nil, nil, nil, fmt.Errorf("token row %d has %d tokens, want %d", i, len(row), n)
+		}
+		ids = append(ids, row...)
+		for j := range n {
+			positions = append(positions, int64(past+j))
+		}
+	}
+	mask = make([]int64, batch*(past+n))
+	for i := range mask {
+		mask[i] = 1
+	}
+	return ids, mask, positions, nil
+}
+
+// newIDs creates an integer tensor with the element type of info, which
+// must be int64 or int32.
+func newIDs(r *ort.Runtime, info ort.InputInfo, data []int64, shape []int64) (*ort.Value, error) {
+	switch info.TensorInfo.ElementType {
+	case ort.ONNXTensorElementDataTypeInt64:
+		return ort.NewTensorValue(r, data, shape)
+	case ort.ONNXTensorElementDataTypeInt32:
+		data32 := make([]int32, len(data))
+		for i, v := range data {
+			data32[i] = int32(v)
+		}
+		return ort.NewTensorValue(r, data32, shape)
+	default:
+		return nil, fmt.Errorf("input %q must be int64 or int32", info.Name)
+	}
+}
+
+// Reset empties the cache to start new sequences.
+func (s *StepRunner) Reset() {
+	s.cache.Reset()
+}
+
+// Close releases the cache and the IoBinding. The session is left open.
+func (s *StepRunner) Close() {
+	s.cache.Close()
+	s.binding.Close()
+}
@@ -0,0 +1,170 @@
+package onnxruntime
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Latency histograms use log-linear buckets: values below 2*histSubBuckets
+// nanoseconds are exact, and every power-of-two range above that is split
+// into histSubBuckets equal buckets, bounding the relative error of a
+// reported percentile to 1/histSubBuckets (about 6%).
+const (
+	histSubBits    = 4
+	histSubBuckets = 1 << histSubBits
+
+	// histMaxValue caps recorded latencies at about 18 minutes.
+	histMaxValue = 1<<40 - 1
+	histBuckets  = (40-histSubBits)*histSubBuckets + histSubBuckets
+)
+
+// latencyHistogram is a fixed-size, lock-free latency histogram.
+type latencyHistogram struct {
+	counts [histBuckets]atomic.Int64
+	total  atomic.Int64
+	max    atomic.Int64
+}
+
+func histBucket(v uint64) int {
+	if v < histSubBuckets {
+		return int(v)
+	}
+	shift := bits.Len64(v) - histSubBits - 1
+	return (shift+1)*histSubBuckets + int(v>>shift) - histSubBuckets
+}
+
+// histBucketUpper returns the largest value that maps to bucket i.
+func histBucketUpper(i int) uint64 {
+	if i < 2*histSubBuckets {
+		return uint64(i)
+	}
+	shift := i/histSubBuckets - 1
+	mantissa := uint64(i%histSubBuckets + histSubBuckets)
+	return (mantissa+1)<<shift - 1
+}
+
+func (h *latencyHistogram) record(d time.Duration) {
+	v := uint64(max(d, 0))
+	v = min(v, histMaxValue)
+	h.counts[histBucket(v)].Add(1)
+	h.total.Add(1)
+	for {
+		cur := h.max.Load()
+		if int64(v) <= cur || h.max.CompareAndSwap(cur, int64(v)) {
+			break
+		}
+	}
+}
+
+func (h *latencyHistogram) reset() {
+	for i := range h.counts {
+		h.counts[i].Store(0)
+	}
+	h.total.Store(0)
+	h.max.Store(0)
+}
+
+// addTo accumulates h into a snapshot.
+func (h *latencyHistogram) addTo(s *latencySnapshot) {
+	for i := range h.counts {
+		s.counts[i] += h.counts[i].Load()
+	}
+	s.total += h.total.Load()
+	s.max = max(s.max, h.max.Load())
+}
+
+// latencySnapshot is a point-in-time copy of one or more histograms.
+type latencySnapshot struct {
+	counts [histBuckets]int64
+	total  int64
+	max    int64
+}
+
+// percentile returns the p-th percentile (0 < p <= 1) using the nearest-rank
+// method, reported as the upper bound of its bucket and never above the
+// maximum recorded value.
+func (s *latencySnapshot) percentile(p float64) time.Duration {
+	if s.total == 0 {
+		return 0
+	}
+	rank := max(int64(math.Ceil(float64(s.total)*p)), 1)
+	var seen int64
+	for i, c := range s.counts {
+		seen += c
+		if seen >= rank {
+			return time.Duration(min(int64(histBucketUpper(i)), s.max))
+		}
+	}
+	return time.Duration(s.max)
+}
+
+// latencyWindow tracks latencies over a sliding window made of
+// latencyWindowSlots fixed slots, so samples expire one slot at a time.
+type latencyWindow struct {
+	mu       sync.Mutex
+	slotSize time.Duration
+	slots    [latencyWindowSlots]windowSlot
+}
+
+const latencyWindowSlots = 6
+
+type windowSlot struct {
+	epoch  int64
+	hist   latencyHistogram
+	errors int64
+}
+
+func newLatencyWindow(window time.Duration) *latencyWindow {
+	return &latencyWindow{slotSize: max(window/latencyWindowSlots, 1)}
+}
+
+func (w *latencyWindow) duration() time.Duration {
+	return w.slotSize * latencyWindowSlots
+}
+
+func (w *latencyWindow) record(now time.Time, d time.Duration, failed bool) {
+	epoch := now.UnixNano() / int64(w.slotSize)
+	slot := &w.slots[epoch%latencyWindowSlots]
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if slot.epoch != epoch {
+		slot.hist.reset()
+		slot.errors = 0
+		slot.epoch = epoch
+	}
+	slot.hist.record(d)
+	if failed {
+		slot.errors++
+	}
+}
+
+func (w *latencyWindow) snapshot(now time.Time) (*latencySnapshot, int64) {
+	epoch := now.UnixNano() / int64(w.slotSize)
+	s := &latencySnapshot{}
+	var errors int64
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range w.slots {
+		slot := &w.slots[i]
+		if slot.epoch > epoch-latencyWindowSlots && slot.epoch <= epoch {
+			slot.hist.addTo(s)
+			errors += slot.errors
+		}
+	}
+	return s, errors
+}
+
+func (w *latencyWindow) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range w.slots {
+		w.slots[i].hist.reset()
+		w.slots[i].errors = 0
+		w.slots[i].epoch = 0
+	}
+}
@@ -0,0 +1,103 @@
+package onnxruntime
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHistBucketRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 15, 16, 31, 32, 33, 1000, 123456789, histMaxValue} {
+		i := histBucket(v)
+		if i < 0 || i >= histBuckets {
+			t.Fatalf("bucket %d for %d out of range", i, v)
+		}
+		if upper := histBucketUpper(i); upper < v {
+			t.Errorf("bucket %d upper bound %d is below value %d", i, upper, v)
+		}
+		if i > 0 && histBucketUpper(i-1) >= v {
+			t.Errorf("value %d should not fit in bucket %d", v, i-1)
+		}
+	}
+}
+
+func TestLatencyHistogramPercentiles(t *testing.T) {
+	var h latencyHistogram
+	rng := rand.New(rand.NewPCG(1, 2))
+	samples := make([]time.Duration, 10000)
+	for i := range samples {
+		samples[i] = time.Duration(rng.ExpFloat64() * float64(5*time.Millisecond))
+		h.record(samples[i])
+	}
+	slices.Sort(samples)
+
+	var s latencySnapshot
+	h.addTo(&s)
+	if s.total != int64(len(samples)) {
+		t.Fatalf("Expected %d samples, got %d", len(samples), s.total)
+	}
+	if time.Duration(s.max) != samples[len(samples)-1] {
+		t.Errorf("Expected max %v, got %v", samples[len(samples)-1], time.Duration(s.max))
+	}
+
+	for _, p := range []float64{0.5, 0.9, 0.99} {
+		exact := samples[int(float64(len(samples))*p)-1]
+		got := s.percentile(p)
+		if got < exact || float64(got-exact) > float64(exact)/histSubBuckets {
+			t.Errorf("p%v: expected about %v, got %v", p*100, exact, got)
+		}
+	}
+}
+
+func TestLatencyHistogramEmptyAndReset(t *testing.T) {
+	var h latencyHistogram
+	var s latencySnapshot
+	h.addTo(&s)
+	if s.percentile(0.99) != 0 {
+		t.Error("Expected zero percentile for empty histogram")
+	}
+
+	h.record(time.Second)
+	h.record(-time.Second) // clamped to zero
+	h.reset()
+	s = latencySnapshot{}
+	h.addTo(&s)
+	if s.total != 0 || s.max != 0 {
+		t.Errorf("Expected empty histogram after reset, got total=%d max=%d", s.total, s.max)
+	}
+}
+
+func TestLatencyWindowExpires(t *testing.T) {
+	w := newLatencyWindow(6 * time.Second)
+	base := time.Unix(1000, 0)
+
+	w.record(base, 10*time.Millisecond, false)
+	w.record(base.Add(2*time.Second), 20*time.Millisecond, true)
+
+	s, errors := w.snapshot(base.Add(3 * time.Second))
+	if s.total != 2 || errors != 1 {
+		t.Errorf("Expected 2 runs and 1 error in window, got %d and %d", s.total, errors)
+	}
+	if time.Duration(s.max) != 20*time.Millisecond {
+		t.Errorf("Expected max 20ms, got %v", time.Duration(s.max))
+	}
+
+	// The first sample falls out once its slot leaves the window.
+	s, errors = w.snapshot(base.Add(6 * time.Second))
+	if s.total != 1 || errors != 1 {
+		t.Errorf("Expected 1 run and 1 error after expiry, got %d and %d", s.total, errors)
+	}
+
+	// A slot reused for a new period starts empty.
+	w.record(base.Add(6*time.Second), time.Millisecond, false)
+	s, _ = w.snapshot(base.Add(6 * time.Second))
+	if s.total != 2 || time.Duration(s.max) != 20*time.Millisecond {
+		t.Errorf("Expected 2 runs with max 20ms, got %d and %v", s.total, time.Duration(s.max))
+	}
+
+	s, _ = w.snapshot(base.Add(time.Hour))
+	if s.total != 0 {
+		t.Errorf("Expected empty window after an hour, got %d", s.total)
+	}
+}
@@ -0,0 +1,22 @@
+// Package liblocator finds the ONNX Runtime shared library on the local
+// machine and, if asked to, downloads an official release.
+//
+// Find checks ONNXRUNTIME_LIB_PATH, then ORT_HOME, the dynamic loader path
+// (LD_LIBRARY_PATH, DYLD_LIBRARY_PATH or PATH), system and Homebrew library
+// directories, the NuGet package cache, Python onnxruntime installs from pip,
+// and finally libraries previously fetched by a Downloader. onnxruntime.NewRuntime
+// uses Find when it is given an empty library path.
+//
+// Downloader is opt-in: nothing is fetched unless it is called explicitly.
+// It downloads the release archive for the current OS and architecture from
+// GitHub, verifies its SHA-256 digest, and extracts the shared libraries into
+// a per-version cache directory.
+//
+// Example:
+//
+//	path, err := liblocator.Find()
+//	if errors.Is(err, liblocator.ErrNotFound) {
+//	    path, err = (&liblocator.Downloader{Version: "1.23.0"}).Download(ctx)
+//	}
+//	runtime, err := onnxruntime.NewRuntime(path, onnxruntime.APIVersionAuto)
+package liblocator
@@ -0,0 +1,339 @@
+package liblocator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultBaseURL is where official ONNX Runtime release archives are
+// published. The archive URL is BaseURL/v<version>/<archive name>.
+const DefaultBaseURL = "https://github.com/microsoft/onnxruntime/releases/download"
+
+// releaseAPIURL is the GitHub API endpoint describing a release, including
+// the SHA-256 digest of each asset. It is a variable so tests can replace it.
+var releaseAPIURL = "https://api.github.com/repos/microsoft/onnxruntime/releases/tags/v%s"
+
+// ErrChecksumMismatch is returned when a downloaded archive does not have
+// the expected SHA-256 digest.
+var ErrChecksumMismatch = errors.New("liblocator: checksum mismatch")
+
+// Downloader fetches an official ONNX Runtime release for the current OS and
+// architecture and caches its shared libraries. The zero value is not usable;
+// Version must be set.
+type Downloader struct {
+	// Version is the ONNX Runtime release, e.g. "1.23.0".
+	Version string
+
+	// CacheDir is the root of the download cache (default: DefaultCacheDir).
+	// Libraries are extracted to CacheDir/<version>/<os>-<arch>.
+	CacheDir string
+
+	// SHA256 is the expected hex digest of the release archive. If empty,
+	// the digest GitHub publishes for the release asset is used; the
+	// download fails if none is available.
+	SHA256 string
+
+	// BaseURL overrides DefaultBaseURL, e.g. for an internal mirror. Set
+	// SHA256 when using a mirror.
+	BaseURL string
+
+	// Client is the HTTP client to use (default: http.DefaultClient).
+	Client *http.Client
+}
+
+// DefaultCacheDir returns the default download cache root, a directory named
+// onnxer/onnxruntime in the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "onnxer", "onnxruntime"), nil
+}
+
+// ArchiveName returns the name of the official release archive of version
+// for goos and goarch, e.g. "onnxruntime-linux-x64-1.23.0.tgz".
+func ArchiveName(version, goos, goarch string) (string, error) {
+	var platform, ext string
+	switch goos + "/" + goarch {
+	case "linux/amd64":
+		platform, ext = "linux-x64", ".tgz"
+	case "linux/arm64":
+		platform, ext = "linux-aarch64", ".tgz"
+	case "darwin/amd64":
+		platform, ext = "osx-x86_64", ".tgz"
+	case "darwin/arm64":
+		platform, ext = "osx-arm64", ".tgz"
+	case "windows/amd64":
+		platform, ext = "win-x64", ".zip"
+	case "windows/arm64":
+		platform, ext = "win-arm64", ".zip"
+	default:
+		return "", fmt.Errorf("no ONNX Runtime release for %s/%s", goos, goarch)
+	}
+	return "onnxruntime-" + platform + "-" + version + ext, nil
+}
+
+// platformDir names the cache subdirectory for goos and goarch.
+func platformDir(goos, goarch string) string {
+	return goos + "-" + goarch
+}
+
+// Download returns the path of the cached library, downloading and
+// extracting the release first if it is not cached yet. The archive is
+// verified before anything is extracted.
+func (d *Downloader) Download(ctx context.Context) (string, error) {
+	if d.Version == "" {
+		return "", errors.New("liblocator: Downloader.Version is required")
+	}
+	archive, err := ArchiveName(d.Version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir := d.CacheDir
+	if cacheDir == "" {
+		if cacheDir, err = DefaultCacheDir(); err != nil {
+			return "", fmt.Errorf("failed to determine cache directory: %w", err)
+		}
+	}
+	dir := filepath.Join(cacheDir, d.Version, platformDir(runtime.GOOS, runtime.GOARCH))
+	if path, ok := libraryIn(dir); ok {
+		return path, nil
+	}
+
+	want := strings.ToLower(d.SHA256)
+	if want == "" {
+		if want, err = d.releaseDigest(ctx, archive); err != nil {
+			return "", fmt.Errorf("failed to get checksum of %s: %w", archive, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), ".download-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	archivePath := filepath.Join(tmp, archive)
+	if err := d.fetch(ctx, archive, archivePath, want); err != nil {
+		return "", err
+	}
+	libDir := filepath.Join(tmp, "lib")
+	if err := extractLibraries(archivePath, libDir); err != nil {
+		return "", fmt.Errorf("failed to extract %s: %w", archive, err)
+	}
+	if _, ok := libraryIn(libDir); !ok {
+		return "", fmt.Errorf("%s does not contain %s", archive, LibraryName())
+	}
+
+	// Publish atomically; a concurrent download may have won the race.
+	if err := os.Rename(libDir, dir); err != nil {
+		if path, ok := libraryIn(dir); ok {
+			return path, nil
+		}
+		return "", fmt.Errorf("failed to populate cache: %w", err)
+	}
+	path, _ := libraryIn(dir)
+	return path, nil
+}
+
+// fetch downloads archive to dst and checks its SHA-256 digest against want.
+func (d *Downloader) fetch(ctx context.Context, archive, dst, want string) error {
+	baseURL := d.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	url := strings.TrimSuffix(baseURL, "/") + "/v" + d.Version + "/" + archive
+
+	resp, err := d.get(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", archive, err)
+	}
+	defer resp.Body.Close()
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), resp.Body); err != nil {
+		return fmt.Errorf("failed to download %s: %w", archive, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("%w: %s has SHA-256 %s, want %s", ErrChecksumMismatch, archive, got, want)
+	}
+	return f.Close()
+}
+
+// releaseDigest looks up the SHA-256 digest GitHub records for archive.
+func (d *Downloader) releaseDigest(ctx context.Context, archive string) (string, error) {
+	resp, err := d.get(ctx, fmt.Sprintf(releaseAPIURL, d.Version))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var release struct {
+		Assets []struct {
+			Name   string `json:"name"`
+			Digest string `json:"digest"`
+		} `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to decode release metadata: %w", err)
+	}
+	for _, asset := range release.Assets {
+		if asset.Name != archive {
+			continue
+		}
+		digest, ok := strings.CutPrefix(asset.Digest, "sha256:")
+		if !ok {
+			return "", fmt.Errorf("release asset has no SHA-256 digest; set Downloader.SHA256")
+		}
+		return strings.ToLower(digest), nil
+	}
+	return "", fmt.Errorf("release v%s has no asset %s", d.Version, archive)
+}
+
+func (d *Downloader) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// extractLibraries copies the files directly inside the archive's lib
+// directory into dir. Other entries, including headers and nested
+// directories such as lib/cmake, are skipped.
+func extractLibraries(archivePath, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if strings.HasSuffix(archivePath, ".zip") {
+		return extractZip(archivePath, dir)
+	}
+	return extractTarGz(archivePath, dir)
+}
+
+// libraryEntry returns the file name of an archive entry that lies directly
+// in a top-level lib directory, as in onnxruntime-linux-x64-1.23.0/lib/.
+func libraryEntry(name string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path.Clean(name), "./"), "/")
+	if len(parts) != 3 || parts[1] != "lib" || parts[2] == ".." {
+		return "", false
+	}
+	return parts[2], true
+}
+
+func extractTarGz(archivePath, dir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name, ok := libraryEntry(hdr.Name)
+		if !ok {
+			continue
+		}
+		dst := filepath.Join(dir, name)
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+			if err := writeFile(dst, tr, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// Release archives link libonnxruntime.so to the versioned file
+			// next to it; refuse links that leave the directory.
+			if strings.ContainsAny(hdr.Linkname, `/\`) {
+				return fmt.Errorf("symlink %s points outside lib: %s", hdr.Name, hdr.Linkname)
+			}
+			if err := os.Symlink(hdr.Linkname, dst); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractZip(archivePath, dir string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		name, ok := libraryEntry(zf.Name)
+		if !ok || zf.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(filepath.Join(dir, name), rc, 0o755)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(dst string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,168 @@
+package liblocator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// releaseArchive builds a tgz laid out like an official release.
+func releaseArchive(t *testing.T, version string) []byte {
+	t.Helper()
+	root := "onnxruntime-test-" + version
+	versioned := LibraryName() + "." + version
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	files := []struct {
+		name, link, body string
+	}{
+		{name: root + "/include/onnxruntime_c_api.h", body: "header"},
+		{name: root + "/lib/" + versioned, body: "library"},
+		{name: root + "/lib/" + LibraryName(), link: versioned},
+		{name: root + "/lib/cmake/onnxruntime/config.cmake", body: "cmake"},
+	}
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.body)), Typeflag: tar.TypeReg}
+		if f.link != "" {
+			hdr = &tar.Header{Name: f.name, Linkname: f.link, Typeflag: tar.TypeSymlink}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(f.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestArchiveName(t *testing.T) {
+	tests := []struct {
+		goos, goarch, want string
+	}{
+		{"linux", "amd64", "onnxruntime-linux-x64-1.23.0.tgz"},
+		{"linux", "arm64", "onnxruntime-linux-aarch64-1.23.0.tgz"},
+		{"darwin", "arm64", "onnxruntime-osx-arm64-1.23.0.tgz"},
+		{"windows", "amd64", "onnxruntime-win-x64-1.23.0.zip"},
+	}
+	for _, tt := range tests {
+		got, err := ArchiveName("1.23.0", tt.goos, tt.goarch)
+		if err != nil || got != tt.want {
+			t.Errorf("ArchiveName(%s/%s) = %q, %v; want %q", tt.goos, tt.goarch, got, err, tt.want)
+		}
+	}
+	if _, err := ArchiveName("1.23.0", "plan9", "386"); err == nil {
+		t.Error("Expected error for an unsupported platform")
+	}
+}
+
+func TestDownload(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test archive is a tgz")
+	}
+	archive, err := ArchiveName("1.23.0", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Skip(err)
+	}
+	data := releaseArchive(t, "1.23.0")
+	sum := sha256.Sum256(data)
+
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.23.0/"+archive, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(data)
+	})
+	mux.HandleFunc("/release/1.23.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"assets":[{"name":%q,"digest":"sha256:%s"}]}`, archive, hex.EncodeToString(sum[:]))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	saved := releaseAPIURL
+	releaseAPIURL = server.URL + "/release/%s"
+	defer func() { releaseAPIURL = saved }()
+
+	cache := t.TempDir()
+	d := &Downloader{Version: "1.23.0", CacheDir: cache, BaseURL: server.URL}
+	path, err := d.Download(context.Background())
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if want := filepath.Join(cache, "1.23.0", platformDir(runtime.GOOS, runtime.GOARCH), LibraryName()); path != want {
+		t.Errorf("Expected %s, got %s", want, path)
+	}
+	if body, err := os.ReadFile(path); err != nil || string(body) != "library" {
+		t.Errorf("Expected library contents through the symlink, got %q, %v", body, err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "cmake")); !os.IsNotExist(err) {
+		t.Error("Expected nested lib directories to be skipped")
+	}
+
+	// A second call is served from the cache.
+	if again, err := d.Download(context.Background()); err != nil || again != path {
+		t.Errorf("Expected cached %s, got %s, %v", path, again, err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 archive request, got %d", requests)
+	}
+}
+
+func TestDownloadChecksumMismatch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test archive is a tgz")
+	}
+	data := releaseArchive(t, "1.23.0")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	cache := t.TempDir()
+	d := &Downloader{Version: "1.23.0", CacheDir: cache, BaseURL: server.URL, SHA256: hex.EncodeToString(make([]byte, 32))}
+	if _, err := d.Download(context.Background()); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Expected ErrChecksumMismatch, got %v", err)
+	}
+	if _, err := FindIn([]string{filepath.Join(cache, "1.23.0", platformDir(runtime.GOOS, runtime.GOARCH))}); err == nil {
+		t.Error("Expected nothing to be cached after a failed verification")
+	}
+}
+
+func TestLibraryEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"onnxruntime-linux-x64-1.23.0/lib/libonnxruntime.so", "libonnxruntime.so", true},
+		{"./onnxruntime-linux-x64-1.23.0/lib/libonnxruntime.so.1", "libonnxruntime.so.1", true},
+		{"onnxruntime-linux-x64-1.23.0/lib/cmake/x.cmake", "", false},
+		{"onnxruntime-linux-x64-1.23.0/include/onnxruntime_c_api.h", "", false},
+		{"onnxruntime-linux-x64-1.23.0/lib/../../evil.so", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := libraryEntry(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("libraryEntry(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
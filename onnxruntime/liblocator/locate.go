@@ -0,0 +1,290 @@
+package liblocator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Environment variables consulted by Find.
+const (
+	// EnvLibraryPath names the library file itself and takes precedence
+	// over every search location.
+	EnvLibraryPath = "ONNXRUNTIME_LIB_PATH"
+
+	// EnvHome names an extracted release directory; both it and its lib
+	// subdirectory are searched.
+	EnvHome = "ORT_HOME"
+)
+
+// ErrNotFound is returned when no ONNX Runtime library can be located.
+var ErrNotFound = errors.New("liblocator: ONNX Runtime library not found")
+
+// LibraryName returns the file name of the ONNX Runtime shared library on
+// the current platform.
+func LibraryName() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "libonnxruntime.dylib"
+	case "windows":
+		return "onnxruntime.dll"
+	default:
+		return "libonnxruntime.so"
+	}
+}
+
+// Find returns the path of the ONNX Runtime library. If EnvLibraryPath is set
+// it must name an existing file; otherwise the directories from SearchPaths
+// are searched in order.
+func Find() (string, error) {
+	if path := os.Getenv(EnvLibraryPath); path != "" {
+		if !isFile(path) {
+			return "", fmt.Errorf("%s=%s: %w", EnvLibraryPath, path, ErrNotFound)
+		}
+		return path, nil
+	}
+	return FindIn(SearchPaths())
+}
+
+// FindIn returns the first ONNX Runtime library found in dirs. Within a
+// directory the unversioned name (e.g., libonnxruntime.so) is preferred over
+// versioned ones (e.g., libonnxruntime.so.1.23.0), which pip packages ship.
+func FindIn(dirs []string) (string, error) {
+	for _, dir := range dirs {
+		if path, ok := libraryIn(dir); ok {
+			return path, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// SearchPaths returns the directories Find searches, in order. Directories
+// that do not exist are included; FindIn skips them.
+func SearchPaths() []string {
+	var dirs []string
+	if home := os.Getenv(EnvHome); home != "" {
+		dirs = append(dirs, filepath.Join(home, "lib"), home)
+	}
+	dirs = append(dirs, loaderPaths()...)
+	dirs = append(dirs, systemPaths()...)
+	dirs = append(dirs, nugetPaths()...)
+	dirs = append(dirs, pipPaths()...)
+	if cache, err := DefaultCacheDir(); err == nil {
+		dirs = append(dirs, globVersions(filepath.Join(cache, "*", platformDir(runtime.GOOS, runtime.GOARCH)))...)
+	}
+	return dirs
+}
+
+// loaderPaths returns the directories of the dynamic loader search variable.
+func loaderPaths() []string {
+	name := "LD_LIBRARY_PATH"
+	switch runtime.GOOS {
+	case "darwin":
+		name = "DYLD_LIBRARY_PATH"
+	case "windows":
+		name = "PATH"
+	}
+	return filepath.SplitList(os.Getenv(name))
+}
+
+// systemPaths returns the platform's standard library directories,
+// including Homebrew prefixes on macOS.
+func systemPaths() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			"/opt/homebrew/opt/onnxruntime/lib",
+			"/opt/homebrew/lib",
+			"/usr/local/opt/onnxruntime/lib",
+			"/usr/local/lib",
+			"/usr/lib",
+		}
+	case "windows":
+		return nil
+	default:
+		dirs := []string{"/usr/local/lib"}
+		if triplet := multiarchTriplet(); triplet != "" {
+			dirs = append(dirs, "/usr/lib/"+triplet, "/lib/"+triplet)
+		}
+		return append(dirs, "/usr/lib64", "/usr/lib", "/lib", "/home/linuxbrew/.linuxbrew/lib")
+	}
+}
+
+// multiarchTriplet returns the Debian multiarch directory name for the
+// current architecture.
+func multiarchTriplet() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x86_64-linux-gnu"
+	case "arm64":
+		return "aarch64-linux-gnu"
+	default:
+		return ""
+	}
+}
+
+// nugetPaths returns the native directories of Microsoft.ML.OnnxRuntime
+// packages in the NuGet cache, newest version first.
+func nugetPaths() []string {
+	root := os.Getenv("NUGET_PACKAGES")
+	if root == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		root = filepath.Join(home, ".nuget", "packages")
+	}
+	rid := nugetRID(runtime.GOOS, runtime.GOARCH)
+	if rid == "" {
+		return nil
+	}
+	return globVersions(filepath.Join(root, "microsoft.ml.onnxruntime", "*", "runtimes", rid, "native"))
+}
+
+// nugetRID returns the .NET runtime identifier NuGet packages use for goos
+// and goarch.
+func nugetRID(goos, goarch string) string {
+	system := map[string]string{"linux": "linux", "darwin": "osx", "windows": "win"}[goos]
+	arch := map[string]string{"amd64": "x64", "arm64": "arm64"}[goarch]
+	if system == "" || arch == "" {
+		return ""
+	}
+	return system + "-" + arch
+}
+
+// pipPaths returns the capi directories of onnxruntime Python packages in
+// the active virtual or conda environment, the user site, and the system
+// site directories, newest Python first.
+func pipPaths() []string {
+	var prefixes []string
+	for _, name := range []string{"VIRTUAL_ENV", "CONDA_PREFIX"} {
+		if prefix := os.Getenv(name); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	home, _ := os.UserHomeDir()
+
+	var patterns []string
+	switch runtime.GOOS {
+	case "windows":
+		for _, prefix := range prefixes {
+			patterns = append(patterns, filepath.Join(prefix, "Lib", "site-packages"))
+		}
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			patterns = append(patterns, filepath.Join(appData, "Python", "Python3*", "site-packages"))
+		}
+		if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+			patterns = append(patterns, filepath.Join(localAppData, "Programs", "Python", "Python3*", "Lib", "site-packages"))
+		}
+	default:
+		if home != "" {
+			prefixes = append(prefixes, filepath.Join(home, ".local"))
+		}
+		prefixes = append(prefixes, "/usr/local", "/usr")
+		if runtime.GOOS == "darwin" {
+			prefixes = append(prefixes, "/opt/homebrew")
+			if home != "" {
+				patterns = append(patterns, filepath.Join(home, "Library", "Python", "3.*", "lib", "python", "site-packages"))
+			}
+		}
+		for _, prefix := range prefixes {
+			patterns = append(patterns,
+				filepath.Join(prefix, "lib", "python3*", "site-packages"),
+				filepath.Join(prefix, "lib", "python3*", "dist-packages"))
+		}
+	}
+
+	var dirs []string
+	for _, pattern := range patterns {
+		dirs = append(dirs, globVersions(filepath.Join(pattern, "onnxruntime", "capi"))...)
+	}
+	return dirs
+}
+
+// libraryIn returns the ONNX Runtime library in dir, if any.
+func libraryIn(dir string) (string, bool) {
+	if dir == "" {
+		return "", false
+	}
+	name := LibraryName()
+	if path := filepath.Join(dir, name); isFile(path) {
+		return path, true
+	}
+
+	var pattern string
+	switch runtime.GOOS {
+	case "darwin":
+		pattern = "libonnxruntime.*.dylib"
+	case "windows":
+		return "", false
+	default:
+		pattern = name + ".*"
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+	sortVersionsDesc(matches)
+	for _, path := range matches {
+		if isFile(path) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// globVersions expands pattern and orders the matches newest version first.
+func globVersions(pattern string) []string {
+	matches, _ := filepath.Glob(pattern)
+	sortVersionsDesc(matches)
+	return matches
+}
+
+// sortVersionsDesc sorts paths so that embedded version numbers compare
+// numerically, highest first: python3.12 before python3.9, 1.23.0 before
+// 1.9.1.
+func sortVersionsDesc(paths []string) {
+	slices.SortFunc(paths, func(a, b string) int {
+		return compareVersions(b, a)
+	})
+}
+
+// compareVersions compares a and b piecewise, treating runs of digits as
+// numbers and everything else as text.
+func compareVersions(a, b string) int {
+	for a != "" && b != "" {
+		var pa, pb string
+		pa, a = nextPiece(a)
+		pb, b = nextPiece(b)
+		na, errA := strconv.Atoi(pa)
+		nb, errB := strconv.Atoi(pb)
+		var c int
+		if errA == nil && errB == nil {
+			c = na - nb
+		} else {
+			c = strings.Compare(pa, pb)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// nextPiece splits off the leading run of digits or non-digits of s.
+func nextPiece(s string) (string, string) {
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	digit := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digit {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
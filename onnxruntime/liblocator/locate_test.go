@@ -0,0 +1,126 @@
+package liblocator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindIn(t *testing.T) {
+	empty, dir := t.TempDir(), t.TempDir()
+	want := filepath.Join(dir, LibraryName())
+	touch(t, want)
+
+	got, err := FindIn([]string{"", filepath.Join(empty, "missing"), empty, dir})
+	if err != nil {
+		t.Fatalf("FindIn failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	if _, err := FindIn([]string{empty}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFindInVersionedName(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("versioned names are checked on Linux")
+	}
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "libonnxruntime.so.1.9.0"))
+	touch(t, filepath.Join(dir, "libonnxruntime.so.1.22.0"))
+	touch(t, filepath.Join(dir, "libonnxruntime_providers_shared.so"))
+
+	got, err := FindIn([]string{dir})
+	if err != nil {
+		t.Fatalf("FindIn failed: %v", err)
+	}
+	if filepath.Base(got) != "libonnxruntime.so.1.22.0" {
+		t.Errorf("Expected newest versioned library, got %s", got)
+	}
+}
+
+func TestFindEnvironment(t *testing.T) {
+	home := t.TempDir()
+	want := filepath.Join(home, "lib", LibraryName())
+	touch(t, want)
+
+	t.Setenv(EnvLibraryPath, "")
+	t.Setenv(EnvHome, home)
+	got, err := Find()
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if got != want {
+		t.Errorf("Expected %s from %s, got %s", want, EnvHome, got)
+	}
+
+	explicit := filepath.Join(t.TempDir(), "custom.so")
+	touch(t, explicit)
+	t.Setenv(EnvLibraryPath, explicit)
+	if got, err := Find(); err != nil || got != explicit {
+		t.Errorf("Expected %s from %s, got %s, %v", explicit, EnvLibraryPath, got, err)
+	}
+
+	t.Setenv(EnvLibraryPath, filepath.Join(home, "missing.so"))
+	if _, err := Find(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing %s, got %v", EnvLibraryPath, err)
+	}
+}
+
+func TestSearchPathsNuGet(t *testing.T) {
+	rid := nugetRID(runtime.GOOS, runtime.GOARCH)
+	if rid == "" {
+		t.Skipf("no NuGet runtime identifier for %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	root := t.TempDir()
+	for _, version := range []string{"1.9.0", "1.22.1"} {
+		if err := os.MkdirAll(filepath.Join(root, "microsoft.ml.onnxruntime", version, "runtimes", rid, "native"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("NUGET_PACKAGES", root)
+
+	got := nugetPaths()
+	want := []string{
+		filepath.Join(root, "microsoft.ml.onnxruntime", "1.22.1", "runtimes", rid, "native"),
+		filepath.Join(root, "microsoft.ml.onnxruntime", "1.9.0", "runtimes", rid, "native"),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"python3.12", "python3.9", 1},
+		{"1.9.1", "1.23.0", -1},
+		{"1.23.0", "1.23.0", 0},
+		{"1.23", "1.23.0", -1},
+		{"a", "b", -1},
+	}
+	for _, tt := range tests {
+		got := compareVersions(tt.a, tt.b)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, want sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !unix
+
+package onnxruntime
+
+// lockedBuffer holds plaintext model data. Memory cannot be locked on this
+// platform, so locked is always false.
+type lockedBuffer struct {
+	data   []byte
+	locked bool
+}
+
+// allocLocked returns an ordinary buffer of n bytes if allowUnlocked is set.
+func allocLocked(n int, allowUnlocked bool) (*lockedBuffer, error) {
+	if !allowUnlocked {
+		return nil, ErrMemoryLockUnsupported
+	}
+	return &lockedBuffer{data: make([]byte, n)}, nil
+}
+
+// free zeroes the buffer.
+func (b *lockedBuffer) free() {
+	clear(b.data)
+	b.data = nil
+}
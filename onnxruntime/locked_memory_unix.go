@@ -0,0 +1,49 @@
+//go:build unix
+
+package onnxruntime
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockedBuffer is memory outside the Go heap that is locked against
+// swapping when locked is true.
+type lockedBuffer struct {
+	data   []byte
+	locked bool
+}
+
+// allocLocked maps n bytes of anonymous memory and locks them. If locking
+// fails and allowUnlocked is set, the memory is returned unlocked.
+func allocLocked(n int, allowUnlocked bool) (*lockedBuffer, error) {
+	data, err := unix.Mmap(-1, 0, n, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANON)
+	if err != nil {
+		return nil, err
+	}
+	excludeFromCoreDump(data)
+	b := &lockedBuffer{data: data}
+	if err := unix.Mlock(data); err != nil {
+		if !allowUnlocked {
+			unix.Munmap(data)
+			return nil, fmt.Errorf("%w: mlock of %d bytes failed (check RLIMIT_MEMLOCK): %v", ErrMemoryLockUnsupported, n, err)
+		}
+	} else {
+		b.locked = true
+	}
+	return b, nil
+}
+
+// free zeroes the buffer, then unlocks and unmaps it.
+func (b *lockedBuffer) free() {
+	if b.data == nil {
+		return
+	}
+	clear(b.data)
+	if b.locked {
+		unix.Munlock(b.data)
+	}
+	unix.Munmap(b.data)
+	b.data = nil
+}
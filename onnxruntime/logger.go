@@ -0,0 +1,121 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/benedoc-inc/onnxer/internal/cstrings"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	"github.com/ebitengine/purego"
+)
+
+// LogFunc receives log messages from ONNX Runtime. category names the
+// subsystem, id is the logID of the environment or session that logged the
+// message, and location is the source location in ONNX Runtime.
+//
+// LogFunc is called from ONNX Runtime threads, possibly concurrently, and
+// must not call back into ONNX Runtime.
+type LogFunc func(level LoggingLevel, category, id, location, message string)
+
+var (
+	// purego callbacks are never freed, so a single trampoline is shared by
+	// all environments and dispatches on the logger parameter.
+	logCallbackOnce sync.Once
+	logCallbackPtr  uintptr
+
+	loggers      sync.Map // uintptr -> LogFunc
+	nextLoggerID atomic.Uintptr
+)
+
+func logCallback() uintptr {
+	logCallbackOnce.Do(func() {
+		logCallbackPtr = purego.NewCallback(logMessage)
+	})
+	return logCallbackPtr
+}
+
+func logMessage(param uintptr, level api.OrtLoggingLevel, category, logID, location, message *byte) {
+	fn, ok := loggers.Load(param)
+	if !ok {
+		return
+	}
+	fn.(LogFunc)(level,
+		cstrings.CStringToString(category),
+		cstrings.CStringToString(logID),
+		cstrings.CStringToString(location),
+		cstrings.CStringToString(message),
+	)
+}
+
+// NewEnvWithLogger creates an environment whose log messages at logLevel or
+// above are passed to fn instead of being written to stderr.
+//
+// ONNX Runtime keeps a single environment per process. If one already
+// exists, it is shared and keeps its original logger, so fn receives nothing;
+// create the logging environment before any other.
+//
+// Example routing ONNX Runtime logs into slog:
+//
+//	env, err := runtime.NewEnvWithLogger("app", onnxruntime.LoggingLevelWarning,
+//	    onnxruntime.SlogLogFunc(slog.Default()))
+func (r *Runtime) NewEnvWithLogger(logID string, logLevel LoggingLevel, fn LogFunc) (*Env, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("logging function cannot be nil")
+	}
+
+	id := nextLoggerID.Add(1)
+	loggers.Store(id, fn)
+
+	logIDBytes := append([]byte(logID), 0)
+	var envPtr api.OrtEnv
+
+	status := r.apiFuncs.CreateEnvWithCustomLogger(logCallback(), id, logLevel, &logIDBytes[0], &envPtr)
+	if err := r.statusError(status, "CreateEnvWithCustomLogger"); err != nil {
+		loggers.Delete(id)
+		return nil, fmt.Errorf("failed to create environment with custom logger: %w", err)
+	}
+
+	env := &Env{
+		ptr:      envPtr,
+		runtime:  r,
+		loggerID: id,
+		trackID:  r.objects.add(kindEnv),
+	}
+	env.setCleanup()
+	return env, nil
+}
+
+// SlogLogFunc returns a LogFunc that writes ONNX Runtime log messages to
+// logger with category, id and location as attributes. Verbose messages are
+// logged at Debug level and fatal messages above Error. If logger is nil,
+// slog.Default() is used.
+func SlogLogFunc(logger *slog.Logger) LogFunc {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(level LoggingLevel, category, id, location, message string) {
+		logger.LogAttrs(context.Background(), slogLevel(level), message,
+			slog.String("category", category),
+			slog.String("id", id),
+			slog.String("location", location),
+		)
+	}
+}
+
+func slogLevel(level LoggingLevel) slog.Level {
+	switch level {
+	case LoggingLevelVerbose:
+		return slog.LevelDebug
+	case LoggingLevelInfo:
+		return slog.LevelInfo
+	case LoggingLevelWarning:
+		return slog.LevelWarn
+	case LoggingLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelError + 4
+	}
+}
@@ -0,0 +1,80 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewEnvWithLogger(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnvWithLogger("test", LoggingLevelWarning, func(LoggingLevel, string, string, string, string) {})
+	if err != nil {
+		t.Fatalf("Failed to create environment with logger: %v", err)
+	}
+	if env.ptr == 0 || env.loggerID == 0 {
+		t.Fatal("Expected environment and logger to be set")
+	}
+
+	id := env.loggerID
+	env.Close()
+	if _, ok := loggers.Load(id); ok {
+		t.Error("Expected logger to be unregistered after Close")
+	}
+}
+
+func TestNewEnvWithLoggerNil(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	if _, err := runtime.NewEnvWithLogger("test", LoggingLevelWarning, nil); err == nil {
+		t.Error("Expected error for nil logging function")
+	}
+}
+
+func TestLogMessageDispatch(t *testing.T) {
+	if logCallback() == 0 {
+		t.Fatal("Expected logging callback to be registered")
+	}
+
+	var got []string
+	id := nextLoggerID.Add(1)
+	loggers.Store(id, LogFunc(func(level LoggingLevel, category, logID, location, message string) {
+		if level != LoggingLevelError {
+			t.Errorf("Expected error level, got %d", level)
+		}
+		got = []string{category, logID, location, message}
+	}))
+	defer loggers.Delete(id)
+
+	cstr := func(s string) *byte { return &append([]byte(s), 0)[0] }
+	logMessage(id, LoggingLevelError, cstr("onnxruntime"), cstr("test"), cstr("session.cc:42"), cstr("bad input"))
+
+	want := []string{"onnxruntime", "test", "session.cc:42", "bad input"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Messages for unknown loggers are dropped.
+	logMessage(id+1000, LoggingLevelError, cstr("c"), cstr("i"), cstr("l"), cstr("m"))
+}
+
+func TestSlogLogFunc(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	fn := SlogLogFunc(logger)
+
+	fn(LoggingLevelWarning, "onnxruntime", "test", "session.cc:42", "slow kernel")
+	fn(LoggingLevelVerbose, "onnxruntime", "test", "", "details")
+
+	out := buf.String()
+	for _, want := range []string{
+		"level=WARN", `msg="slow kernel"`, "category=onnxruntime", "id=test", "location=session.cc:42",
+		"level=DEBUG", "msg=details",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected log output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
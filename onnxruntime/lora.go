@@ -2,6 +2,7 @@ package onnxruntime
 
 import (
 	"fmt"
+	"runtime"
 	"unsafe"
 
 	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
@@ -26,6 +27,12 @@ import (
 type LoraAdapter struct {
 	ptr     api.OrtLoraAdapter
 	runtime *Runtime
+
+	// ID of the adapter in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the adapter is not closed
+	cleanup runtime.Cleanup
 }
 
 // LoadLoraAdapterFromFile loads a LoRA adapter from a file path.
@@ -34,14 +41,11 @@ func (r *Runtime) LoadLoraAdapterFromFile(path string) (*LoraAdapter, error) {
 	var adapterPtr api.OrtLoraAdapter
 
 	status := r.apiFuncs.CreateLoraAdapter(&pathBytes[0], r.allocator.ptr, &adapterPtr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateLoraAdapter"); err != nil {
 		return nil, fmt.Errorf("failed to load LoRA adapter from %q: %w", path, err)
 	}
 
-	return &LoraAdapter{
-		ptr:     adapterPtr,
-		runtime: r,
-	}, nil
+	return r.newLoraAdapter(adapterPtr), nil
 }
 
 // LoadLoraAdapterFromBytes loads a LoRA adapter from in-memory data.
@@ -57,22 +61,36 @@ func (r *Runtime) LoadLoraAdapterFromBytes(data []byte) (*LoraAdapter, error) {
 		r.allocator.ptr,
 		&adapterPtr,
 	)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateLoraAdapterFromArray"); err != nil {
 		return nil, fmt.Errorf("failed to load LoRA adapter from bytes: %w", err)
 	}
 
-	return &LoraAdapter{
-		ptr:     adapterPtr,
+	return r.newLoraAdapter(adapterPtr), nil
+}
+
+// newLoraAdapter wraps a LoRA adapter owned by the caller.
+func (r *Runtime) newLoraAdapter(ptr api.OrtLoraAdapter) *LoraAdapter {
+	a := &LoraAdapter{
+		ptr:     ptr,
 		runtime: r,
-	}, nil
+		trackID: r.objects.add(kindLoraAdapter),
+	}
+	a.cleanup = guard(a, leak{runtime: r, kind: kindLoraAdapter, id: a.trackID, ptr: uintptr(ptr), release: releaseLeakedLoraAdapter})
+	return a
+}
+
+func releaseLeakedLoraAdapter(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseLoraAdapter(api.OrtLoraAdapter(ptr))
 }
 
 // Close releases the LoRA adapter resources.
 // It is safe to call Close multiple times.
 func (a *LoraAdapter) Close() {
 	if a.ptr != 0 && a.runtime != nil && a.runtime.apiFuncs != nil {
+		a.cleanup.Stop()
 		a.runtime.apiFuncs.ReleaseLoraAdapter(a.ptr)
 		a.ptr = 0
+		a.runtime.objects.remove(kindLoraAdapter, a.trackID)
 	}
 }
 
@@ -0,0 +1,311 @@
+package onnxruntime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// LoraAdapterExt is the file extension of LoRA adapters loaded by an
+// AdapterRegistry.
+const LoraAdapterExt = ".onnx_adapter"
+
+// ErrLoraAdapterNotFound is returned when a run names a LoRA adapter that
+// the session's AdapterRegistry does not hold.
+var ErrLoraAdapterNotFound = errors.New("LoRA adapter not found")
+
+// AdapterRegistryConfig configures an AdapterRegistry.
+type AdapterRegistryConfig struct {
+	// OnLoad, if set, is called after an adapter is loaded or reloaded.
+	OnLoad func(name string)
+
+	// OnUnload, if set, is called after an adapter file is removed and the
+	// adapter is dropped from the registry.
+	OnUnload func(name string)
+
+	// OnError, if set, is called when an adapter file fails to load or the
+	// directory watch fails. The previously loaded version, if any, stays
+	// in use.
+	OnError func(name string, err error)
+}
+
+// AdapterRegistry holds the LoRA adapters in a directory and keeps them in
+// sync with it: adding or replacing a <name>.onnx_adapter file loads the
+// adapter under name, and removing the file unloads it. Runs select adapters
+// by name with WithLoraAdapterName on sessions created with
+// SessionOptions.AdapterRegistry.
+//
+// Adapters are reference counted, so an adapter replaced or removed while a
+// run uses it is released only when that run completes. To replace an
+// adapter without the registry seeing a partially written file, write it
+// elsewhere in the same filesystem and rename it into the directory.
+//
+// An AdapterRegistry is safe for concurrent use.
+//
+// Example:
+//
+//	registry, err := runtime.NewAdapterRegistry("/var/lib/adapters", nil)
+//	defer registry.Close()
+//
+//	session, err := runtime.NewSession(env, "model.onnx", &ort.SessionOptions{
+//	    AdapterRegistry: registry,
+//	})
+//	outputs, err := session.Run(ctx, inputs, ort.WithLoraAdapterName("customer-123"))
+type AdapterRegistry struct {
+	runtime *Runtime
+	dir     string
+	config  AdapterRegistryConfig
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	mu       sync.Mutex
+	adapters map[string]*registeredAdapter
+	closed   bool
+}
+
+// registeredAdapter is a loaded adapter with one reference held by the
+// registry while it is current and one per run using it.
+type registeredAdapter struct {
+	adapter *LoraAdapter
+	refs    int
+}
+
+// NewAdapterRegistry loads every LoRA adapter in dir and watches dir for
+// changes until the registry is closed. An adapter that fails to load is
+// reported to config.OnError and skipped. config may be nil.
+func (r *Runtime) NewAdapterRegistry(dir string, config *AdapterRegistryConfig) (*AdapterRegistry, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory watcher: %w", err)
+	}
+	// Watch before the initial scan so no change falls between the two.
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %q: %w", dir, err)
+	}
+
+	reg := &AdapterRegistry{
+		runtime:  r,
+		dir:      dir,
+		watcher:  watcher,
+		done:     make(chan struct{}),
+		adapters: make(map[string]*registeredAdapter),
+	}
+	if config != nil {
+		reg.config = *config
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to read %q: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if name, ok := adapterName(entry.Name()); ok && !entry.IsDir() {
+			reg.load(name)
+		}
+	}
+
+	reg.wg.Add(1)
+	go reg.watch()
+	return reg, nil
+}
+
+// adapterName returns the adapter name of a file in the registry directory.
+func adapterName(file string) (string, bool) {
+	name, ok := strings.CutSuffix(filepath.Base(file), LoraAdapterExt)
+	if !ok || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// watch applies directory changes until the registry is closed.
+func (reg *AdapterRegistry) watch() {
+	defer reg.wg.Done()
+	for {
+		select {
+		case <-reg.done:
+			return
+		case event, ok := <-reg.watcher.Events:
+			if !ok {
+				return
+			}
+			name, ok := adapterName(event.Name)
+			if !ok {
+				continue
+			}
+			switch {
+			case event.Has(fsnotify.Create), event.Has(fsnotify.Write):
+				reg.load(name)
+			case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+				reg.unload(name)
+			}
+		case err, ok := <-reg.watcher.Errors:
+			if !ok {
+				return
+			}
+			reg.reportError("", err)
+		}
+	}
+}
+
+// load loads or reloads the adapter stored under name.
+func (reg *AdapterRegistry) load(name string) {
+	adapter, err := reg.runtime.LoadLoraAdapterFromFile(filepath.Join(reg.dir, name+LoraAdapterExt))
+	if err != nil {
+		reg.reportError(name, err)
+		return
+	}
+
+	reg.mu.Lock()
+	if reg.closed {
+		reg.mu.Unlock()
+		adapter.Close()
+		return
+	}
+	old := reg.adapters[name]
+	reg.adapters[name] = &registeredAdapter{adapter: adapter, refs: 1}
+	reg.releaseLocked(old)
+	reg.mu.Unlock()
+
+	if reg.config.OnLoad != nil {
+		reg.config.OnLoad(name)
+	}
+}
+
+// unload drops the adapter stored under name, if any.
+func (reg *AdapterRegistry) unload(name string) {
+	reg.mu.Lock()
+	old, ok := reg.adapters[name]
+	delete(reg.adapters, name)
+	reg.releaseLocked(old)
+	reg.mu.Unlock()
+
+	if ok && reg.config.OnUnload != nil {
+		reg.config.OnUnload(name)
+	}
+}
+
+// releaseLocked drops one reference to ra, closing its adapter when none
+// are left. reg.mu must be held.
+func (reg *AdapterRegistry) releaseLocked(ra *registeredAdapter) {
+	if ra == nil {
+		return
+	}
+	ra.refs--
+	if ra.refs == 0 {
+		ra.adapter.Close()
+	}
+}
+
+func (reg *AdapterRegistry) reportError(name string, err error) {
+	if reg.config.OnError != nil {
+		reg.config.OnError(name, err)
+	}
+}
+
+// Names returns the names of the loaded adapters in sorted order.
+func (reg *AdapterRegistry) Names() []string {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	names := make([]string, 0, len(reg.adapters))
+	for name := range reg.adapters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Acquire returns the adapter stored under name and a function that must be
+// called once the caller stops using it. The adapter is not released before
+// then, even if it is replaced or removed from the directory.
+func (reg *AdapterRegistry) Acquire(name string) (*LoraAdapter, func(), error) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	ra, ok := reg.adapters[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrLoraAdapterNotFound, name)
+	}
+	ra.refs++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			reg.mu.Lock()
+			reg.releaseLocked(ra)
+			reg.mu.Unlock()
+		})
+	}
+	return ra.adapter, release, nil
+}
+
+// Close stops watching the directory and drops the registry's adapters.
+// Adapters still used by runs are released when those runs complete. It is
+// safe to call Close multiple times.
+func (reg *AdapterRegistry) Close() error {
+	reg.mu.Lock()
+	if reg.closed {
+		reg.mu.Unlock()
+		return nil
+	}
+	reg.closed = true
+	for name, ra := range reg.adapters {
+		delete(reg.adapters, name)
+		reg.releaseLocked(ra)
+	}
+	reg.mu.Unlock()
+
+	close(reg.done)
+	err := reg.watcher.Close()
+	reg.wg.Wait()
+	return err
+}
+
+// WithLoraAdapterName applies the adapters stored under names in the
+// session's AdapterRegistry to a single run. The run fails with an error
+// wrapping ErrLoraAdapterNotFound if a name is not loaded.
+func WithLoraAdapterName(names ...string) RunOption {
+	return func(c *runConfig) {
+		c.loraAdapterNames = append(c.loraAdapterNames, names...)
+	}
+}
+
+// acquireLoraAdapters resolves config.loraAdapterNames in registry, adding
+// the adapters to config.loraAdapters. The returned function releases them.
+func acquireLoraAdapters(registry *AdapterRegistry, config *runConfig) (func(), error) {
+	if len(config.loraAdapterNames) == 0 {
+		return func() {}, nil
+	}
+	if registry == nil {
+		return nil, fmt.Errorf("%w: session has no AdapterRegistry", ErrLoraAdapterNotFound)
+	}
+
+	var releases []func()
+	releaseAll := func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+	adapters := slices.Clone(config.loraAdapters)
+	for _, name := range config.loraAdapterNames {
+		adapter, release, err := registry.Acquire(name)
+		if err != nil {
+			releaseAll()
+			return nil, err
+		}
+		adapters = append(adapters, adapter)
+		releases = append(releases, release)
+	}
+	config.loraAdapters = adapters
+	return releaseAll, nil
+}
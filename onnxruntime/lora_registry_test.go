@@ -0,0 +1,148 @@
+package onnxruntime
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newFakeRegistry returns a registry holding placeholder adapters that are
+// never loaded, for testing reference counting without the native library.
+func newFakeRegistry(names ...string) *AdapterRegistry {
+	reg := &AdapterRegistry{adapters: make(map[string]*registeredAdapter)}
+	for _, name := range names {
+		reg.adapters[name] = &registeredAdapter{adapter: &LoraAdapter{}, refs: 1}
+	}
+	return reg
+}
+
+func TestAdapterName(t *testing.T) {
+	tests := []struct {
+		file string
+		name string
+		ok   bool
+	}{
+		{"/adapters/customer-123.onnx_adapter", "customer-123", true},
+		{"customer-123.onnx_adapter", "customer-123", true},
+		{"/adapters/.onnx_adapter", "", false},
+		{"/adapters/model.onnx", "", false},
+		{"/adapters/customer-123.onnx_adapter.tmp", "", false},
+	}
+	for _, tt := range tests {
+		name, ok := adapterName(tt.file)
+		if name != tt.name || ok != tt.ok {
+			t.Errorf("adapterName(%q) = %q, %v; want %q, %v", tt.file, name, ok, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestAdapterRegistryAcquireRelease(t *testing.T) {
+	reg := newFakeRegistry("a", "b")
+
+	if names := reg.Names(); !slices.Equal(names, []string{"a", "b"}) {
+		t.Errorf("Expected names [a b], got %v", names)
+	}
+
+	_, release, err := reg.Acquire("a")
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	ra := reg.adapters["a"]
+	if ra.refs != 2 {
+		t.Errorf("Expected 2 references while in use, got %d", ra.refs)
+	}
+
+	// Removing the adapter mid-run keeps it alive for the run.
+	reg.unload("a")
+	if ra.refs != 1 {
+		t.Errorf("Expected the run's reference to remain after unload, got %d", ra.refs)
+	}
+	if _, _, err := reg.Acquire("a"); !errors.Is(err, ErrLoraAdapterNotFound) {
+		t.Errorf("Expected ErrLoraAdapterNotFound after unload, got %v", err)
+	}
+
+	release()
+	release() // releasing twice is a no-op
+	if ra.refs != 0 {
+		t.Errorf("Expected no references after release, got %d", ra.refs)
+	}
+}
+
+func TestAcquireLoraAdapters(t *testing.T) {
+	reg := newFakeRegistry("a", "b")
+
+	config := &runConfig{loraAdapterNames: []string{"a", "missing"}}
+	if _, err := acquireLoraAdapters(reg, config); !errors.Is(err, ErrLoraAdapterNotFound) {
+		t.Fatalf("Expected ErrLoraAdapterNotFound, got %v", err)
+	}
+	if refs := reg.adapters["a"].refs; refs != 1 {
+		t.Errorf("Expected a failed acquire to release earlier adapters, got %d references", refs)
+	}
+
+	config = &runConfig{}
+	WithLoraAdapterName("a", "b")(config)
+	release, err := acquireLoraAdapters(reg, config)
+	if err != nil {
+		t.Fatalf("acquireLoraAdapters failed: %v", err)
+	}
+	if len(config.loraAdapters) != 2 {
+		t.Errorf("Expected 2 adapters, got %d", len(config.loraAdapters))
+	}
+	release()
+	if refs := reg.adapters["b"].refs; refs != 1 {
+		t.Errorf("Expected release to drop the run's reference, got %d references", refs)
+	}
+
+	config = &runConfig{loraAdapterNames: []string{"a"}}
+	if _, err := acquireLoraAdapters(nil, config); !errors.Is(err, ErrLoraAdapterNotFound) {
+		t.Errorf("Expected ErrLoraAdapterNotFound without a registry, got %v", err)
+	}
+}
+
+func TestAdapterRegistryWatch(t *testing.T) {
+	runtime := newTestRuntime(t)
+	dir := t.TempDir()
+
+	loadErrs := make(chan string, 10)
+	reg, err := runtime.NewAdapterRegistry(dir, &AdapterRegistryConfig{
+		OnError: func(name string, err error) { loadErrs <- name },
+	})
+	if err != nil {
+		t.Fatalf("NewAdapterRegistry failed: %v", err)
+	}
+	defer reg.Close()
+
+	// An invalid adapter file is reported and not loaded.
+	if err := os.WriteFile(filepath.Join(dir, "broken"+LoraAdapterExt), []byte("not an adapter"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case name := <-loadErrs:
+		if name != "broken" {
+			t.Errorf("Expected a load error for %q, got %q", "broken", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the load error")
+	}
+	if names := reg.Names(); len(names) != 0 {
+		t.Errorf("Expected no adapters, got %v", names)
+	}
+
+	if err := reg.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if err := reg.Close(); err != nil {
+		t.Errorf("Second Close failed: %v", err)
+	}
+}
+
+func TestNewAdapterRegistryMissingDir(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	if _, err := runtime.NewAdapterRegistry(filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
@@ -0,0 +1,134 @@
+package onnxruntime
+
+import (
+	"fmt"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// GetMemoryInfoForInputs returns where the session's execution providers
+// expect each input to be, in the order of InputNames. Inputs with no
+// expected location, such as inputs no node consumes, have nil entries.
+//
+// The memory infos are owned by the session and are only valid until it is
+// closed; closing them does nothing. Requires ONNX Runtime API version 23.
+func (s *Session) GetMemoryInfoForInputs() ([]*MemoryInfo, error) {
+	if s.ptr == 0 {
+		return nil, ErrSessionClosed
+	}
+	ptrs := make([]api.OrtMemoryInfo, len(s.inputNames))
+	if len(ptrs) > 0 {
+		status := s.runtime.apiFuncs.SessionGetMemoryInfoForInputs(s.ptr, &ptrs[0], uintptr(len(ptrs)))
+		if err := s.runtime.statusError(status, "SessionGetMemoryInfoForInputs"); err != nil {
+			return nil, fmt.Errorf("failed to get input memory info: %w", err)
+		}
+	}
+	return s.sessionMemoryInfos(ptrs), nil
+}
+
+// GetMemoryInfoForOutputs returns where the session's execution providers
+// produce each output, in the order of OutputNames. Pass an output's memory
+// info to IoBinding.BindOutputToDevice to keep it on its device.
+//
+// The memory infos are owned by the session and are only valid until it is
+// closed; closing them does nothing. Requires ONNX Runtime API version 23.
+func (s *Session) GetMemoryInfoForOutputs() ([]*MemoryInfo, error) {
+	if s.ptr == 0 {
+		return nil, ErrSessionClosed
+	}
+	ptrs := make([]api.OrtMemoryInfo, len(s.outputNames))
+	if len(ptrs) > 0 {
+		status := s.runtime.apiFuncs.SessionGetMemoryInfoForOutputs(s.ptr, &ptrs[0], uintptr(len(ptrs)))
+		if err := s.runtime.statusError(status, "SessionGetMemoryInfoForOutputs"); err != nil {
+			return nil, fmt.Errorf("failed to get output memory info: %w", err)
+		}
+	}
+	return s.sessionMemoryInfos(ptrs), nil
+}
+
+func (s *Session) sessionMemoryInfos(ptrs []api.OrtMemoryInfo) []*MemoryInfo {
+	infos := make([]*MemoryInfo, len(ptrs))
+	for i, ptr := range ptrs {
+		if ptr != 0 {
+			infos[i] = &MemoryInfo{ptr: ptr, runtime: s.runtime, owner: s}
+		}
+	}
+	return infos
+}
+
+// MemoryPlacement describes where a session input or output lives.
+// This is an immutable snapshot.
+type MemoryPlacement struct {
+	// Name is the memory info name, such as [MemoryInfoNameCPU] or
+	// [MemoryInfoNameCUDA].
+	Name       string
+	DeviceType MemoryDeviceType
+	DeviceID   int
+	MemType    MemType
+}
+
+// OnDevice reports whether the memory is on a device other than the host
+// CPU, so values bound to it through IoBinding avoid a host copy.
+func (p MemoryPlacement) OnDevice() bool {
+	return p.DeviceType != MemoryDeviceTypeCPU
+}
+
+// NewMemoryInfo creates a MemoryInfo for the placement, for binding inputs
+// and outputs at the same location. The caller must close it.
+func (p MemoryPlacement) NewMemoryInfo(r *Runtime) (*MemoryInfo, error) {
+	return r.NewMemoryInfo(p.Name, AllocatorTypeDevice, p.MemType, p.DeviceID)
+}
+
+// placement returns a snapshot of mi.
+func (mi *MemoryInfo) placement() (MemoryPlacement, error) {
+	name, err := mi.Name()
+	if err != nil {
+		return MemoryPlacement{}, err
+	}
+	id, err := mi.DeviceID()
+	if err != nil {
+		return MemoryPlacement{}, err
+	}
+	memType, err := mi.MemType()
+	if err != nil {
+		return MemoryPlacement{}, err
+	}
+	return MemoryPlacement{Name: name, DeviceType: mi.DeviceType(), DeviceID: id, MemType: memType}, nil
+}
+
+// GetInputPlacements returns where each input is expected to be, keyed by
+// input name. Inputs with no expected location are omitted. Requires ONNX
+// Runtime API version 23.
+func (s *Session) GetInputPlacements() (map[string]MemoryPlacement, error) {
+	infos, err := s.GetMemoryInfoForInputs()
+	if err != nil {
+		return nil, err
+	}
+	return placements(s.inputNames, infos)
+}
+
+// GetOutputPlacements returns where each output is produced, keyed by output
+// name. Outputs with no location are omitted. Requires ONNX Runtime API
+// version 23.
+func (s *Session) GetOutputPlacements() (map[string]MemoryPlacement, error) {
+	infos, err := s.GetMemoryInfoForOutputs()
+	if err != nil {
+		return nil, err
+	}
+	return placements(s.outputNames, infos)
+}
+
+func placements(names []string, infos []*MemoryInfo) (map[string]MemoryPlacement, error) {
+	result := make(map[string]MemoryPlacement, len(infos))
+	for i, mi := range infos {
+		if mi == nil {
+			continue
+		}
+		p, err := mi.placement()
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe memory of %q: %w", names[i], err)
+		}
+		result[names[i]] = p
+	}
+	return result, nil
+}
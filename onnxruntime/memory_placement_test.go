@@ -0,0 +1,78 @@
+package onnxruntime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemoryPlacementOnDevice(t *testing.T) {
+	if (MemoryPlacement{Name: MemoryInfoNameCPU, DeviceType: MemoryDeviceTypeCPU}).OnDevice() {
+		t.Error("CPU placement reported as on device")
+	}
+	if !(MemoryPlacement{Name: MemoryInfoNameCUDA, DeviceType: MemoryDeviceTypeGPU}).OnDevice() {
+		t.Error("GPU placement not reported as on device")
+	}
+}
+
+func TestNewCPUMemoryInfoDeviceType(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	memInfo, err := runtime.NewCPUMemoryInfo()
+	if err != nil {
+		t.Fatalf("Failed to create CPU memory info: %v", err)
+	}
+	defer memInfo.Close()
+
+	if got := memInfo.DeviceType(); got != MemoryDeviceTypeCPU {
+		t.Errorf("DeviceType() = %d, want MemoryDeviceTypeCPU", got)
+	}
+}
+
+func TestSessionPlacements(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	infos, err := session.GetMemoryInfoForInputs()
+	if errors.Is(err, ErrNotImplemented) {
+		t.Skipf("Skipping: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("GetMemoryInfoForInputs() error = %v", err)
+	}
+	if len(infos) != len(session.InputNames()) {
+		t.Fatalf("len(GetMemoryInfoForInputs()) = %d, want %d", len(infos), len(session.InputNames()))
+	}
+	for _, mi := range infos {
+		if mi != nil {
+			// Session-owned memory infos must survive Close.
+			mi.Close()
+		}
+	}
+
+	outputs, err := session.GetOutputPlacements()
+	if err != nil {
+		t.Fatalf("GetOutputPlacements() error = %v", err)
+	}
+	for name, p := range outputs {
+		if p.Name != MemoryInfoNameCPU || p.OnDevice() {
+			t.Errorf("output %q placement = %+v, want CPU", name, p)
+		}
+	}
+
+	inputs, err := session.GetInputPlacements()
+	if err != nil {
+		t.Fatalf("GetInputPlacements() error = %v", err)
+	}
+	if p, ok := inputs["input"]; ok {
+		mi, err := p.NewMemoryInfo(runtime)
+		if err != nil {
+			t.Fatalf("NewMemoryInfo() error = %v", err)
+		}
+		mi.Close()
+	}
+
+	session.Close()
+	if _, err := session.GetMemoryInfoForOutputs(); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("GetMemoryInfoForOutputs() after Close error = %v, want ErrSessionClosed", err)
+	}
+}
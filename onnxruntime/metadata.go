@@ -32,7 +32,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 
 	var metadataPtr api.OrtModelMetadata
 	status := s.runtime.apiFuncs.SessionGetModelMetadata(s.ptr, &metadataPtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "SessionGetModelMetadata"); err != nil {
 		return nil, fmt.Errorf("failed to get model metadata: %w", err)
 	}
 	defer s.runtime.apiFuncs.ReleaseModelMetadata(metadataPtr)
@@ -44,7 +44,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 	// Producer name
 	var namePtr *byte
 	status = s.runtime.apiFuncs.ModelMetadataGetProducerName(metadataPtr, alloc.ptr, &namePtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "ModelMetadataGetProducerName"); err != nil {
 		return nil, fmt.Errorf("failed to get producer name: %w", err)
 	}
 	result.ProducerName = cstrings.CStringToString(namePtr)
@@ -52,7 +52,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 
 	// Graph name
 	status = s.runtime.apiFuncs.ModelMetadataGetGraphName(metadataPtr, alloc.ptr, &namePtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "ModelMetadataGetGraphName"); err != nil {
 		return nil, fmt.Errorf("failed to get graph name: %w", err)
 	}
 	result.GraphName = cstrings.CStringToString(namePtr)
@@ -60,7 +60,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 
 	// Domain
 	status = s.runtime.apiFuncs.ModelMetadataGetDomain(metadataPtr, alloc.ptr, &namePtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "ModelMetadataGetDomain"); err != nil {
 		return nil, fmt.Errorf("failed to get domain: %w", err)
 	}
 	result.Domain = cstrings.CStringToString(namePtr)
@@ -68,7 +68,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 
 	// Description
 	status = s.runtime.apiFuncs.ModelMetadataGetDescription(metadataPtr, alloc.ptr, &namePtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "ModelMetadataGetDescription"); err != nil {
 		return nil, fmt.Errorf("failed to get description: %w", err)
 	}
 	result.Description = cstrings.CStringToString(namePtr)
@@ -76,7 +76,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 
 	// Version
 	status = s.runtime.apiFuncs.ModelMetadataGetVersion(metadataPtr, &result.Version)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "ModelMetadataGetVersion"); err != nil {
 		return nil, fmt.Errorf("failed to get version: %w", err)
 	}
 
@@ -84,7 +84,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 	var keysPtr **byte
 	var numKeys int64
 	status = s.runtime.apiFuncs.ModelMetadataGetCustomMetadataMapKeys(metadataPtr, alloc.ptr, &keysPtr, &numKeys)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "ModelMetadataGetCustomMetadataMapKeys"); err != nil {
 		return nil, fmt.Errorf("failed to get custom metadata keys: %w", err)
 	}
 
@@ -99,7 +99,7 @@ func (s *Session) GetModelMetadata() (*ModelMetadata, error) {
 			keyBytes := append([]byte(key), 0)
 			var valuePtr *byte
 			status = s.runtime.apiFuncs.ModelMetadataLookupCustomMetadataMap(metadataPtr, alloc.ptr, &keyBytes[0], &valuePtr)
-			if err := s.runtime.statusError(status); err != nil {
+			if err := s.runtime.statusError(status, "ModelMetadataLookupCustomMetadataMap"); err != nil {
 				return nil, fmt.Errorf("failed to get custom metadata value for key %q: %w", key, err)
 			}
 			result.CustomMetadata[key] = cstrings.CStringToString(valuePtr)
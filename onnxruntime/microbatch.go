@@ -0,0 +1,224 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Request states guarding ownership of a queued request's inputs.
+const (
+	requestPending   int32 = iota // queued, inputs not yet read
+	requestClaimed                // dispatcher is reading the inputs
+	requestAbandoned              // caller gave up before dispatch
+)
+
+// batchRequest is a single Run call waiting to be merged into a batch.
+type batchRequest struct {
+	ctx    context.Context
+	inputs map[string]*Value
+	state  atomic.Int32
+	result chan batchResult // buffered so the dispatcher never blocks
+}
+
+type batchResult struct {
+	outputs map[string]*Value
+	err     error
+}
+
+// microBatcher merges concurrent SessionPool.Run calls into batched inferences.
+// A single collector goroutine groups requests arriving within maxDelay of the
+// first one, up to maxSize, and hands each group to its own dispatch goroutine
+// so batches can run in parallel on different pool sessions.
+type microBatcher struct {
+	pool     *SessionPool
+	maxSize  int
+	maxDelay time.Duration
+
+	requests chan *batchRequest
+	done     chan struct{}
+	wg       sync.WaitGroup // collector and dispatch goroutines
+}
+
+func newMicroBatcher(pool *SessionPool, maxSize int, maxDelay time.Duration) *microBatcher {
+	b := &microBatcher{
+		pool:     pool,
+		maxSize:  maxSize,
+		maxDelay: maxDelay,
+		requests: make(chan *batchRequest),
+		done:     make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.collect()
+	return b
+}
+
+// submit queues inputs for the next batch and waits for its share of the outputs.
+func (b *microBatcher) submit(ctx context.Context, inputs map[string]*Value) (map[string]*Value, error) {
+	req := &batchRequest{
+		ctx:    ctx,
+		inputs: inputs,
+		result: make(chan batchResult, 1),
+	}
+
+	select {
+	case b.requests <- req:
+	case <-b.done:
+		return nil, ErrPoolClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-req.result:
+		return res.outputs, res.err
+	case <-ctx.Done():
+		if req.state.CompareAndSwap(requestPending, requestAbandoned) {
+			return nil, ctx.Err()
+		}
+		// The dispatcher already holds the inputs, so they must stay alive until
+		// it finishes. The batch context is cancelled once every caller in the
+		// batch has gone, so this returns promptly.
+		res := <-req.result
+		return res.outputs, res.err
+	}
+}
+
+// close stops accepting requests and waits for pending batches to finish.
+// Batches dispatched after the pool is marked closed fail fast.
+func (b *microBatcher) close() {
+	close(b.done)
+	b.wg.Wait()
+}
+
+// collect groups incoming requests into batches until the batcher is closed.
+func (b *microBatcher) collect() {
+	defer b.wg.Done()
+
+	for {
+		var first *batchRequest
+		select {
+		case first = <-b.requests:
+		case <-b.done:
+			return
+		}
+
+		batch := []*batchRequest{first}
+		timer := time.NewTimer(b.maxDelay)
+	fill:
+		for len(batch) < b.maxSize {
+			select {
+			case req := <-b.requests:
+				batch = append(batch, req)
+			case <-timer.C:
+				break fill
+			case <-b.done:
+				break fill
+			}
+		}
+		timer.Stop()
+
+		b.wg.Add(1)
+		go b.dispatch(batch)
+	}
+}
+
+// dispatch runs one batch and fans the outputs back out to its callers.
+func (b *microBatcher) dispatch(batch []*batchRequest) {
+	defer b.wg.Done()
+
+	active := make([]*batchRequest, 0, len(batch))
+	for _, req := range batch {
+		if req.state.CompareAndSwap(requestPending, requestClaimed) {
+			active = append(active, req)
+		}
+	}
+	if len(active) == 0 {
+		return
+	}
+	if len(active) == 1 {
+		b.runEach(active)
+		return
+	}
+
+	inputs := make([]map[string]*Value, len(active))
+	for i, req := range active {
+		inputs[i] = req.inputs
+	}
+	batchSizes, err := requestBatchSizes(inputs)
+	var batched map[string]*Value
+	if err == nil {
+		batched, err = b.pool.runtime.stackInputs(inputs)
+	}
+	if err != nil {
+		// Inputs that cannot be stacked are still valid requests on their own.
+		b.runEach(active)
+		return
+	}
+
+	ctx, cancel := batchContext(active)
+	defer cancel()
+
+	outputs, err := b.pool.runSession(ctx, batched, len(active))
+	closeValues(batched)
+	if err != nil {
+		for _, req := range active {
+			req.result <- batchResult{err: err}
+		}
+		return
+	}
+
+	results, err := b.pool.runtime.splitOutputs(outputs, batchSizes)
+	closeValues(outputs)
+	if err != nil {
+		err = fmt.Errorf("failed to split batched outputs: %w", err)
+		for _, req := range active {
+			req.result <- batchResult{err: err}
+		}
+		return
+	}
+
+	b.pool.totalBatched.Add(int64(len(active)))
+	for i, req := range active {
+		req.result <- batchResult{outputs: results[i]}
+	}
+}
+
+// runEach runs claimed requests individually and in parallel.
+func (b *microBatcher) runEach(reqs []*batchRequest) {
+	var wg sync.WaitGroup
+	for _, req := range reqs {
+		wg.Go(func() {
+			outputs, err := b.pool.runSession(req.ctx, req.inputs, 1)
+			req.result <- batchResult{outputs: outputs, err: err}
+		})
+	}
+	wg.Wait()
+}
+
+// batchContext returns a context for a merged run. It carries the values of the
+// first request's context and is cancelled only once every request's context
+// is done, so one caller giving up does not fail the others.
+func batchContext(reqs []*batchRequest) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(reqs[0].ctx))
+
+	var remaining atomic.Int64
+	remaining.Store(int64(len(reqs)))
+	stops := make([]func() bool, len(reqs))
+	for i, req := range reqs {
+		stops[i] = context.AfterFunc(req.ctx, func() {
+			if remaining.Add(-1) == 0 {
+				cancel()
+			}
+		})
+	}
+
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,229 @@
+package onnxruntime
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestBatchingPool(t *testing.T, size, maxBatch int, delay time.Duration, hooks ...Hook) *SessionPool {
+	t.Helper()
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	pool, err := NewSessionPool(runtime, env, modelData, size, &PoolConfig{
+		Hooks:         hooks,
+		MaxBatchSize:  maxBatch,
+		MaxBatchDelay: delay,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	return pool
+}
+
+func TestBatchContext(t *testing.T) {
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	ctx, cancel := batchContext([]*batchRequest{{ctx: ctx1}, {ctx: ctx2}})
+	defer cancel()
+
+	cancel1()
+	select {
+	case <-ctx.Done():
+		t.Fatal("Batch context cancelled while a request was still waiting")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel2()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Batch context not cancelled after all requests were cancelled")
+	}
+}
+
+func TestSessionPoolMicroBatching(t *testing.T) {
+	var maxSeen atomic.Int64
+	hook := AfterRunHook(func(info *RunInfo) {
+		for {
+			cur := maxSeen.Load()
+			if int64(info.BatchSize) <= cur || maxSeen.CompareAndSwap(cur, int64(info.BatchSize)) {
+				return
+			}
+		}
+	})
+
+	const callers = 8
+	pool := newTestBatchingPool(t, 2, callers, 100*time.Millisecond, hook)
+
+	// Reference outputs from an unbatched run.
+	expected := make([][]float32, callers)
+	for i := range callers {
+		data := make([]float32, 10)
+		for j := range data {
+			data[j] = float32(i*10 + j)
+		}
+		tensor, err := NewTensorValue(pool.runtime, data, []int64{1, 10})
+		if err != nil {
+			t.Fatalf("Failed to create tensor: %v", err)
+		}
+		defer tensor.Close()
+
+		// RunOptions bypass the batcher.
+		outputs, err := pool.Run(t.Context(), map[string]*Value{"input": tensor}, WithRunTag("reference"))
+		if err != nil {
+			t.Fatalf("Reference run failed: %v", err)
+		}
+		expected[i], _, err = GetTensorData[float32](outputs["logits"])
+		if err != nil {
+			t.Fatalf("Failed to get output: %v", err)
+		}
+		closeValues(outputs)
+	}
+	if maxSeen.Load() != 1 {
+		t.Fatalf("Runs with RunOptions should not be batched, saw batch size %d", maxSeen.Load())
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := range callers {
+		wg.Go(func() {
+			data := make([]float32, 10)
+			for j := range data {
+				data[j] = float32(i*10 + j)
+			}
+			tensor, err := NewTensorValue(pool.runtime, data, []int64{1, 10})
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer tensor.Close()
+
+			outputs, err := pool.Run(t.Context(), map[string]*Value{"input": tensor})
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer closeValues(outputs)
+
+			got, shape, err := GetTensorData[float32](outputs["logits"])
+			if err != nil {
+				errs <- err
+				return
+			}
+			if !slices.Equal(shape, []int64{1, 3}) {
+				t.Errorf("Caller %d: shape = %v, want [1 3]", i, shape)
+			}
+			for j := range got {
+				if diff := got[j] - expected[i][j]; diff > 1e-4 || diff < -1e-4 {
+					t.Errorf("Caller %d: logits[%d] = %v, want %v", i, j, got[j], expected[i][j])
+				}
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Batched run failed: %v", err)
+	}
+
+	if maxSeen.Load() < 2 {
+		t.Errorf("Expected concurrent calls to be merged, max batch size was %d", maxSeen.Load())
+	}
+	if pool.Stats().BatchedRequests == 0 {
+		t.Error("Expected BatchedRequests > 0")
+	}
+}
+
+func TestSessionPoolMicroBatchingMixedShapes(t *testing.T) {
+	pool := newTestBatchingPool(t, 2, 4, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for _, rows := range []int64{1, 2, 1, 3} {
+		wg.Go(func() {
+			tensor, err := NewTensorValue(pool.runtime, make([]float32, rows*10), []int64{rows, 10})
+			if err != nil {
+				t.Errorf("Failed to create tensor: %v", err)
+				return
+			}
+			defer tensor.Close()
+
+			outputs, err := pool.Run(t.Context(), map[string]*Value{"input": tensor})
+			if err != nil {
+				t.Errorf("Run failed: %v", err)
+				return
+			}
+			defer closeValues(outputs)
+
+			shape, err := outputs["logits"].GetTensorShape()
+			if err != nil {
+				t.Errorf("Failed to get shape: %v", err)
+				return
+			}
+			if shape[0] != rows {
+				t.Errorf("Output batch = %d, want %d", shape[0], rows)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestSessionPoolMicroBatchingCancelled(t *testing.T) {
+	pool := newTestBatchingPool(t, 1, 4, time.Second)
+
+	tensor, err := NewTensorValue(pool.runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = pool.Run(ctx, map[string]*Value{"input": tensor})
+	if err == nil {
+		t.Fatal("Expected error for request cancelled while waiting for a batch")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Cancelled request took %v to return", elapsed)
+	}
+}
+
+func TestSessionPoolMicroBatchingClose(t *testing.T) {
+	pool := newTestBatchingPool(t, 1, 4, 50*time.Millisecond)
+
+	outputs := runPoolInference(t, pool)
+	closeValues(outputs)
+
+	pool.Close()
+
+	tensor, err := NewTensorValue(pool.runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	if _, err := pool.Run(t.Context(), map[string]*Value{"input": tensor}); err == nil {
+		t.Error("Expected error running on closed pool")
+	}
+}
@@ -3,9 +3,11 @@ package onnxruntime
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 )
 
 // Model wraps Runtime + Env + Session into a single object for simple use cases.
@@ -35,7 +37,8 @@ type ModelConfig struct {
 	// If empty, searches standard system paths.
 	LibraryPath string
 
-	// APIVersion specifies which ORT API version to use (default: 23).
+	// APIVersion specifies which ORT API version to use (default: 23). If the
+	// library does not provide it, NewRuntime falls back to the highest one it does.
 	APIVersion uint32
 
 	// SessionOptions configures the inference session.
@@ -43,6 +46,20 @@ type ModelConfig struct {
 
 	// LogLevel sets the ORT logging level (default: LoggingLevelWarning).
 	LogLevel *LoggingLevel
+
+	// VerifySHA256, when set, is the hex-encoded SHA-256 digest the model
+	// data must match before it is handed to ONNX Runtime.
+	VerifySHA256 string
+
+	// VerifySignature, when set, is the Ed25519 public key the model data
+	// must be signed with. The signature is taken from Manifest or, for
+	// LoadModelFromFile, from a detached raw signature in the file
+	// path+".sig".
+	VerifySignature ed25519.PublicKey
+
+	// Manifest, when set, supplies the expected size, digest, and signature
+	// of models loaded with LoadModelFromFile, looked up by file name.
+	Manifest *ModelManifest
 }
 
 func (c *ModelConfig) apiVersion() uint32 {
@@ -74,7 +91,41 @@ func (c *ModelConfig) sessionOptions() *SessionOptions {
 }
 
 // LoadModel creates a Model from model data read from an io.Reader.
+//
+// Integrity checks in config fail with an error wrapping ErrModelIntegrity
+// before ONNX Runtime sees the data; this applies to every LoadModel
+// function.
 func LoadModel(modelReader io.Reader, config *ModelConfig) (*Model, error) {
+	if config.verifying() {
+		data, err := io.ReadAll(modelReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read model data: %w", err)
+		}
+		return LoadModelFromSource(ModelBytes(data), config)
+	}
+	return loadModel(config, func(rt *Runtime, env *Env) (*Session, error) {
+		return rt.NewSessionFromReader(env, modelReader, config.sessionOptions())
+	})
+}
+
+// LoadModelFromSource creates a Model from the data produced by source, such
+// as a DecryptedModel. See Runtime.NewSessionFromSource.
+func LoadModelFromSource(source ModelSource, config *ModelConfig) (*Model, error) {
+	if config.verifying() {
+		integrity, err := config.integrity("", "")
+		if err != nil {
+			return nil, err
+		}
+		source = &VerifiedModel{Source: source, Integrity: integrity}
+	}
+	return loadModel(config, func(rt *Runtime, env *Env) (*Session, error) {
+		return rt.NewSessionFromSource(env, source, config.sessionOptions())
+	})
+}
+
+// loadModel creates the runtime and environment of a Model and its session
+// with newSession.
+func loadModel(config *ModelConfig, newSession func(*Runtime, *Env) (*Session, error)) (*Model, error) {
 	rt, err := NewRuntime(config.libraryPath(), config.apiVersion())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create runtime: %w", err)
@@ -86,7 +137,7 @@ func LoadModel(modelReader io.Reader, config *ModelConfig) (*Model, error) {
 		return nil, fmt.Errorf("failed to create environment: %w", err)
 	}
 
-	session, err := rt.NewSessionFromReader(env, modelReader, config.sessionOptions())
+	session, err := newSession(rt, env)
 	if err != nil {
 		env.Close()
 		rt.Close()
@@ -102,6 +153,17 @@ func LoadModel(modelReader io.Reader, config *ModelConfig) (*Model, error) {
 
 // LoadModelFromFile creates a Model from a model file path.
 func LoadModelFromFile(path string, config *ModelConfig) (*Model, error) {
+	if config.verifying() {
+		integrity, err := config.integrity(filepath.Base(path), path+".sig")
+		if err != nil {
+			return nil, err
+		}
+		return loadModel(config, func(rt *Runtime, env *Env) (*Session, error) {
+			source := &VerifiedModel{Source: ModelFile(path), Integrity: integrity}
+			return rt.NewSessionFromSource(env, source, config.sessionOptions())
+		})
+	}
+
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open model file: %w", err)
@@ -121,6 +183,61 @@ func (m *Model) Run(ctx context.Context, inputs map[string]*Value, opts ...RunOp
 	return m.session.Run(ctx, inputs, opts...)
 }
 
+// RunSingle runs a model with one float32 input and one float32 output,
+// creating and closing the tensors internally. It returns the output data
+// and shape. See RunTyped for other element types.
+//
+// Example:
+//
+//	logits, shape, err := model.RunSingle(ctx, pixels, []int64{1, 3, 224, 224})
+func (m *Model) RunSingle(ctx context.Context, data []float32, shape []int64, opts ...RunOption) ([]float32, []int64, error) {
+	return RunTyped[float32, float32](ctx, m, data, shape, opts...)
+}
+
+// RunTyped runs a model with one input and one output, creating the input
+// tensor from data and shape and returning a copy of the output's data and
+// its shape, which must have element type TOut. Inputs set with
+// Session.SetConstantInput do not count, and WithOutputNames can select the
+// output of a model with several.
+//
+// Example:
+//
+//	ids, _, err := onnxruntime.RunTyped[int64, int64](ctx, model, tokens, []int64{1, int64(len(tokens))})
+func RunTyped[TIn, TOut TensorData](ctx context.Context, m *Model, data []TIn, shape []int64, opts ...RunOption) ([]TOut, []int64, error) {
+	var inputName string
+	var count int
+	constants := m.session.constantInputs()
+	for _, name := range m.InputNames() {
+		if _, ok := constants[name]; !ok {
+			inputName = name
+			count++
+		}
+	}
+	if count != 1 {
+		return nil, nil, fmt.Errorf("model takes %d inputs, want 1", count)
+	}
+
+	input, err := NewTensorValue(m.runtime, data, shape)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create input tensor: %w", err)
+	}
+	defer input.Close()
+
+	outputs, err := m.Run(ctx, map[string]*Value{inputName: input}, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeValues(outputs)
+	if len(outputs) != 1 {
+		return nil, nil, fmt.Errorf("model produced %d outputs, want 1", len(outputs))
+	}
+
+	for _, output := range outputs {
+		return GetTensorData[TOut](output)
+	}
+	return nil, nil, nil
+}
+
 // Session returns the underlying Session for advanced operations
 // like GetModelMetadata, GetInputInfo, or IoBinding.
 func (m *Model) Session() *Session {
@@ -0,0 +1,194 @@
+package onnxruntime
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// ErrModelIntegrity is returned when model data does not match its expected
+// checksum or signature, or when the expected values are unavailable.
+var ErrModelIntegrity = errors.New("model integrity check failed")
+
+// ModelIntegrity is the expected checksum and signature of a model artifact.
+// Zero fields are not checked.
+type ModelIntegrity struct {
+	// Size is the model size in bytes.
+	Size int64
+
+	// SHA256 is the hex-encoded SHA-256 digest of the model data.
+	SHA256 string
+
+	// PublicKey and Signature check an Ed25519 signature over the model data.
+	// Both must be set for the signature to be checked.
+	PublicKey ed25519.PublicKey
+	Signature []byte
+}
+
+// Verify checks data against i. Failures wrap ErrModelIntegrity.
+func (i *ModelIntegrity) Verify(data []byte) error {
+	if i.Size != 0 && int64(len(data)) != i.Size {
+		return fmt.Errorf("%w: model is %d bytes, expected %d", ErrModelIntegrity, len(data), i.Size)
+	}
+	if i.SHA256 != "" {
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, i.SHA256) {
+			return fmt.Errorf("%w: SHA-256 %s does not match expected %s", ErrModelIntegrity, got, i.SHA256)
+		}
+	}
+	if i.PublicKey != nil {
+		if len(i.PublicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid Ed25519 public key length %d", len(i.PublicKey))
+		}
+		if !ed25519.Verify(i.PublicKey, data, i.Signature) {
+			return fmt.Errorf("%w: invalid signature", ErrModelIntegrity)
+		}
+	}
+	return nil
+}
+
+// VerifiedModel is a ModelSource that checks the data of another source
+// before it is handed to ONNX Runtime.
+type VerifiedModel struct {
+	Source    ModelSource
+	Integrity ModelIntegrity
+}
+
+// Open opens the underlying source and verifies its data, releasing it if
+// verification fails.
+func (m *VerifiedModel) Open() ([]byte, func(), error) {
+	if m.Source == nil {
+		return nil, nil, fmt.Errorf("verified model requires a source")
+	}
+	data, release, err := m.Source.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := m.Integrity.Verify(data); err != nil {
+		release()
+		return nil, nil, err
+	}
+	return data, release, nil
+}
+
+// ModelManifest lists the checksums and signatures of model artifacts, so a
+// model registry can ship them alongside the models. It is stored as JSON:
+//
+//	{
+//	  "models": {
+//	    "resnet50.onnx": {
+//	      "size": 102442450,
+//	      "sha256": "7c2d...",
+//	      "signature": "base64 Ed25519 signature"
+//	    }
+//	  }
+//	}
+//
+// Models are keyed by file name; LoadModelFromFile looks a model up by the
+// base name of its path.
+type ModelManifest struct {
+	Models map[string]ManifestEntry `json:"models"`
+}
+
+// ManifestEntry is the expected size, digest, and signature of one model.
+type ManifestEntry struct {
+	// Size is the model size in bytes; zero is not checked.
+	Size int64 `json:"size,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 digest of the model.
+	SHA256 string `json:"sha256"`
+
+	// Signature is an Ed25519 signature over the model data, if signed.
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// NewManifestEntry returns the manifest entry for model data, signed with
+// key unless it is nil.
+func NewManifestEntry(data []byte, key ed25519.PrivateKey) ManifestEntry {
+	sum := sha256.Sum256(data)
+	e := ManifestEntry{Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}
+	if key != nil {
+		e.Signature = ed25519.Sign(key, data)
+	}
+	return e
+}
+
+// ParseModelManifest parses a JSON model manifest.
+func ParseModelManifest(data []byte) (*ModelManifest, error) {
+	var m ModelManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse model manifest: %w", err)
+	}
+	for name, e := range m.Models {
+		if _, err := hex.DecodeString(e.SHA256); err != nil || len(e.SHA256) != 2*sha256.Size {
+			return nil, fmt.Errorf("model manifest entry %q has an invalid SHA-256 digest", name)
+		}
+	}
+	return &m, nil
+}
+
+// ReadModelManifest reads a JSON model manifest from a file.
+func ReadModelManifest(path string) (*ModelManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model manifest: %w", err)
+	}
+	return ParseModelManifest(data)
+}
+
+// Entry returns the entry for the model file name. A missing entry wraps
+// ErrModelIntegrity.
+func (m *ModelManifest) Entry(name string) (ManifestEntry, error) {
+	e, ok := m.Models[name]
+	if !ok {
+		return ManifestEntry{}, fmt.Errorf("%w: model %q is not in the manifest", ErrModelIntegrity, name)
+	}
+	return e, nil
+}
+
+// verifying reports whether c requests integrity checks.
+func (c *ModelConfig) verifying() bool {
+	return c != nil && (c.VerifySHA256 != "" || c.VerifySignature != nil || c.Manifest != nil)
+}
+
+// integrity returns the checks c requests for the model named name, which
+// is empty when the model did not come from a file. A detached signature is
+// read from sigPath when c requires a signature the manifest does not have.
+func (c *ModelConfig) integrity(name, sigPath string) (ModelIntegrity, error) {
+	i := ModelIntegrity{SHA256: c.VerifySHA256, PublicKey: c.VerifySignature}
+	if c.Manifest != nil {
+		if name == "" {
+			return ModelIntegrity{}, fmt.Errorf("a model manifest requires loading the model from a file")
+		}
+		e, err := c.Manifest.Entry(name)
+		if err != nil {
+			return ModelIntegrity{}, err
+		}
+		if i.SHA256 != "" && !strings.EqualFold(i.SHA256, e.SHA256) {
+			return ModelIntegrity{}, fmt.Errorf("%w: manifest SHA-256 %s does not match expected %s", ErrModelIntegrity, e.SHA256, i.SHA256)
+		}
+		i.Size = e.Size
+		i.SHA256 = e.SHA256
+		i.Signature = e.Signature
+	}
+	if i.PublicKey != nil && i.Signature == nil {
+		if sigPath == "" {
+			return ModelIntegrity{}, fmt.Errorf("%w: no signature for the model", ErrModelIntegrity)
+		}
+		sig, err := os.ReadFile(sigPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return ModelIntegrity{}, fmt.Errorf("%w: no signature for the model at %s", ErrModelIntegrity, sigPath)
+		}
+		if err != nil {
+			return ModelIntegrity{}, fmt.Errorf("failed to read model signature: %w", err)
+		}
+		i.Signature = sig
+	}
+	return i, nil
+}
@@ -0,0 +1,184 @@
+package onnxruntime
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testSigningKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+func TestModelIntegrityVerify(t *testing.T) {
+	pub, priv := testSigningKey(t)
+	data := []byte("model bytes")
+	e := NewManifestEntry(data, priv)
+
+	good := ModelIntegrity{Size: e.Size, SHA256: strings.ToUpper(e.SHA256), PublicKey: pub, Signature: e.Signature}
+	if err := good.Verify(data); err != nil {
+		t.Errorf("Verify failed for matching data: %v", err)
+	}
+
+	tampered := []byte("model bytez")
+	otherPub, _ := testSigningKey(t)
+	bad := []struct {
+		integrity ModelIntegrity
+		data      []byte
+	}{
+		{ModelIntegrity{SHA256: e.SHA256}, tampered},
+		{ModelIntegrity{Size: e.Size + 1}, data},
+		{ModelIntegrity{PublicKey: pub, Signature: e.Signature}, tampered},
+		{ModelIntegrity{PublicKey: otherPub, Signature: e.Signature}, data},
+		{ModelIntegrity{PublicKey: pub}, data},
+	}
+	for i, c := range bad {
+		if err := c.integrity.Verify(c.data); !errors.Is(err, ErrModelIntegrity) {
+			t.Errorf("case %d: expected ErrModelIntegrity, got %v", i, err)
+		}
+	}
+}
+
+func TestVerifiedModel(t *testing.T) {
+	data := []byte("model bytes")
+	e := NewManifestEntry(data, nil)
+
+	released := 0
+	source := ModelSourceFunc(func() ([]byte, func(), error) {
+		return []byte("model bytez"), func() { released++ }, nil
+	})
+	m := &VerifiedModel{Source: source, Integrity: ModelIntegrity{SHA256: e.SHA256}}
+	if _, _, err := m.Open(); !errors.Is(err, ErrModelIntegrity) {
+		t.Errorf("Expected ErrModelIntegrity, got %v", err)
+	}
+	if released != 1 {
+		t.Errorf("Expected the source to be released after a failed check, got %d releases", released)
+	}
+
+	m.Source = ModelBytes(data)
+	got, release, err := m.Open()
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	release()
+	if string(got) != string(data) {
+		t.Errorf("Expected %q, got %q", data, got)
+	}
+}
+
+func TestModelManifest(t *testing.T) {
+	_, priv := testSigningKey(t)
+	m := ModelManifest{Models: map[string]ManifestEntry{
+		"model.onnx": NewManifestEntry([]byte("model bytes"), priv),
+	}}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ReadModelManifest(path)
+	if err != nil {
+		t.Fatalf("ReadModelManifest failed: %v", err)
+	}
+	e, err := parsed.Entry("model.onnx")
+	if err != nil {
+		t.Fatalf("Entry failed: %v", err)
+	}
+	if want := m.Models["model.onnx"]; e.SHA256 != want.SHA256 || e.Size != want.Size || string(e.Signature) != string(want.Signature) {
+		t.Errorf("Expected %+v after a round trip, got %+v", want, e)
+	}
+	if _, err := parsed.Entry("other.onnx"); !errors.Is(err, ErrModelIntegrity) {
+		t.Errorf("Expected ErrModelIntegrity for a missing entry, got %v", err)
+	}
+
+	for _, bad := range []string{`{"models":`, `{"models":{"m.onnx":{"sha256":"abc"}}}`} {
+		if _, err := ParseModelManifest([]byte(bad)); err == nil {
+			t.Errorf("Expected an error parsing %s", bad)
+		}
+	}
+}
+
+func TestModelConfigIntegrity(t *testing.T) {
+	pub, priv := testSigningKey(t)
+	data := []byte("model bytes")
+	e := NewManifestEntry(data, priv)
+	dir := t.TempDir()
+	sigPath := filepath.Join(dir, "model.onnx.sig")
+
+	// A detached signature file is used without a manifest.
+	config := &ModelConfig{VerifySignature: pub}
+	if _, err := config.integrity("model.onnx", sigPath); !errors.Is(err, ErrModelIntegrity) {
+		t.Errorf("Expected ErrModelIntegrity without a signature, got %v", err)
+	}
+	if err := os.WriteFile(sigPath, e.Signature, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	integrity, err := config.integrity("model.onnx", sigPath)
+	if err != nil {
+		t.Fatalf("integrity failed: %v", err)
+	}
+	if err := integrity.Verify(data); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+
+	// The manifest supplies the digest and signature.
+	manifest := &ModelManifest{Models: map[string]ManifestEntry{"model.onnx": e}}
+	config = &ModelConfig{VerifySignature: pub, Manifest: manifest}
+	integrity, err = config.integrity("model.onnx", "")
+	if err != nil {
+		t.Fatalf("integrity failed: %v", err)
+	}
+	if integrity.SHA256 != e.SHA256 || integrity.Size != e.Size || integrity.Verify(data) != nil {
+		t.Errorf("Unexpected integrity %+v", integrity)
+	}
+
+	config = &ModelConfig{VerifySHA256: strings.Repeat("0", 64), Manifest: manifest}
+	if _, err := config.integrity("model.onnx", ""); !errors.Is(err, ErrModelIntegrity) {
+		t.Errorf("Expected ErrModelIntegrity for conflicting digests, got %v", err)
+	}
+	if _, err := (&ModelConfig{Manifest: manifest}).integrity("", ""); err == nil {
+		t.Error("Expected an error for a manifest without a model name")
+	}
+}
+
+func TestLoadModelFromFileVerified(t *testing.T) {
+	_ = newTestRuntime(t)
+
+	data, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, priv := testSigningKey(t)
+	manifest := &ModelManifest{Models: map[string]ManifestEntry{"model.onnx": NewManifestEntry(data, priv)}}
+
+	model, err := LoadModelFromFile(testModelPath(), &ModelConfig{
+		LibraryPath:     libraryPath,
+		VerifySignature: pub,
+		Manifest:        manifest,
+	})
+	if err != nil {
+		t.Fatalf("Failed to load verified model: %v", err)
+	}
+	model.Close()
+
+	_, err = LoadModelFromBytes(append(data, 0), &ModelConfig{
+		LibraryPath:  libraryPath,
+		VerifySHA256: manifest.Models["model.onnx"].SHA256,
+	})
+	if !errors.Is(err, ErrModelIntegrity) {
+		t.Errorf("Expected ErrModelIntegrity for modified model data, got %v", err)
+	}
+}
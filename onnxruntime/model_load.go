@@ -0,0 +1,152 @@
+package onnxruntime
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// modelReadChunk is how much model data is read between progress reports.
+const modelReadChunk = 4 << 20
+
+// loadProgress returns the options' LoadProgress callback, or a no-op.
+func (o *SessionOptions) loadProgress() func(loaded, total int64) {
+	if o == nil || o.LoadProgress == nil {
+		return func(int64, int64) {}
+	}
+	return o.LoadProgress
+}
+
+// NewSessionFromReaderAt creates a session from the size bytes of a model
+// read from ra, such as an *os.File or an object storage reader. Unlike
+// NewSessionFromReader, which cannot know the model size up front, it reads
+// into a single buffer of exactly size bytes, so a multi-gigabyte model is
+// not copied while the buffer grows. Progress is reported to
+// options.LoadProgress.
+func (r *Runtime) NewSessionFromReaderAt(env *Env, ra io.ReaderAt, size int64, options *SessionOptions) (*Session, error) {
+	modelData, err := readModelAt(ra, size, options.loadProgress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model data: %w", err)
+	}
+	return r.newSessionFromBytes(env, modelData, options, nil)
+}
+
+// NewSessionFromFileMmap creates a session from a model file mapped into
+// memory instead of read into the Go heap. Its pages are backed by the file,
+// so the kernel can reclaim them under memory pressure and the model is
+// never held twice. The mapping is released once ONNX Runtime has loaded the
+// model, or when the session is closed if options.UseORTModelBytesDirectly
+// makes the session use it in place.
+//
+// On platforms without mmap support the file is read into a buffer of its
+// exact size, as with NewSessionFromReaderAt. options.LoadProgress is
+// called once the whole file is available.
+func (r *Runtime) NewSessionFromFileMmap(env *Env, modelPath string, options *SessionOptions) (*Session, error) {
+	f, err := os.Open(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open model file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat model file: %w", err)
+	}
+	modelData, unmap, err := mapModelFile(f, info.Size(), options.loadProgress())
+	if err != nil {
+		return nil, fmt.Errorf("failed to map model file: %w", err)
+	}
+
+	session, err := r.newSessionFromReleasableBytes(env, modelData, unmap, options)
+	if err != nil {
+		return nil, err
+	}
+	session.modelName = filepath.Base(modelPath)
+	session.modelPath = modelPath
+	return session, nil
+}
+
+// readModelAt reads size bytes from ra into a new buffer, reporting progress
+// after each chunk.
+func readModelAt(ra io.ReaderAt, size int64, progress func(loaded, total int64)) ([]byte, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("model size must be positive, got %d", size)
+	}
+	if size > math.MaxInt {
+		return nil, fmt.Errorf("model size %d exceeds the address space", size)
+	}
+
+	buf := make([]byte, size)
+	var off int64
+	for off < size {
+		end := min(off+modelReadChunk, size)
+		n, err := ra.ReadAt(buf[off:end], off)
+		off += int64(n)
+		if err != nil && !(errors.Is(err, io.EOF) && off == end) {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		progress(off, size)
+	}
+	return buf, nil
+}
+
+// readModel reads r to EOF like io.ReadAll, reporting progress after each
+// chunk. When r reports its remaining length, the buffer is allocated at
+// that size up front instead of grown by repeated copying.
+func readModel(r io.Reader, progress func(loaded, total int64)) ([]byte, error) {
+	total := remainingSize(r)
+	// One spare byte lets the final read observe EOF without growing.
+	buf := make([]byte, 0, max(total, 0)+1)
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		end := min(cap(buf), len(buf)+modelReadChunk)
+		n, err := r.Read(buf[len(buf):end])
+		buf = buf[:len(buf)+n]
+		if n > 0 {
+			if int64(len(buf)) > total {
+				total = -1 // the reader held more than it reported
+			}
+			progress(int64(len(buf)), total)
+		}
+		if errors.Is(err, io.EOF) {
+			return buf, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// remainingSize returns how many bytes r will yield, or -1 if unknown. It
+// recognizes in-memory readers and regular files.
+func remainingSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case interface{ Stat() (fs.FileInfo, error) }:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		seeker, ok := r.(io.Seeker)
+		if !ok {
+			return -1
+		}
+		off, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil || off > info.Size() {
+			return -1
+		}
+		return info.Size() - off
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,180 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+)
+
+type progressRecorder struct {
+	loaded, total []int64
+}
+
+func (p *progressRecorder) record(loaded, total int64) {
+	p.loaded = append(p.loaded, loaded)
+	p.total = append(p.total, total)
+}
+
+func TestReadModelAt(t *testing.T) {
+	data := bytes.Repeat([]byte{7}, modelReadChunk+10)
+
+	var p progressRecorder
+	got, err := readModelAt(bytes.NewReader(data), int64(len(data)), p.record)
+	if err != nil {
+		t.Fatalf("readModelAt failed: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("readModelAt returned different data")
+	}
+	if len(p.loaded) != 2 || p.loaded[0] != modelReadChunk || p.loaded[1] != int64(len(data)) || p.total[1] != int64(len(data)) {
+		t.Errorf("Unexpected progress %v of %v", p.loaded, p.total)
+	}
+
+	if _, err := readModelAt(bytes.NewReader(data[:5]), 10, p.record); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF for a short reader, got %v", err)
+	}
+	if _, err := readModelAt(bytes.NewReader(data), 0, p.record); err == nil {
+		t.Error("Expected an error for a zero size")
+	}
+}
+
+func TestReadModel(t *testing.T) {
+	data := []byte("model bytes")
+
+	var p progressRecorder
+	got, err := readModel(bytes.NewReader(data), p.record)
+	if err != nil {
+		t.Fatalf("readModel failed: %v", err)
+	}
+	if !bytes.Equal(got, data) || cap(got) != len(data)+1 {
+		t.Errorf("Expected %q in a buffer sized up front, got %q with capacity %d", data, got, cap(got))
+	}
+	if last := len(p.total) - 1; p.total[last] != int64(len(data)) {
+		t.Errorf("Expected the known size as total, got %v", p.total)
+	}
+
+	// A reader of unknown size reports -1 and is still read fully.
+	p = progressRecorder{}
+	got, err = readModel(iotest.OneByteReader(bytes.NewReader(data)), p.record)
+	if err != nil {
+		t.Fatalf("readModel failed: %v", err)
+	}
+	if !bytes.Equal(got, data) || len(p.loaded) != len(data) || p.total[0] != -1 {
+		t.Errorf("Unexpected result %q with progress %v of %v", got, p.loaded, p.total)
+	}
+
+	if _, err := readModel(iotest.ErrReader(io.ErrClosedPipe), p.record); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Expected the reader's error, got %v", err)
+	}
+}
+
+func TestRemainingSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(path, make([]byte, 100), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if got := remainingSize(f); got != 100 {
+		t.Errorf("Expected 100 bytes remaining, got %d", got)
+	}
+	if _, err := f.Seek(40, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if got := remainingSize(f); got != 60 {
+		t.Errorf("Expected 60 bytes remaining after seeking, got %d", got)
+	}
+	if got := remainingSize(iotest.OneByteReader(f)); got != -1 {
+		t.Errorf("Expected an unknown size for a wrapped reader, got %d", got)
+	}
+}
+
+func TestMapModelFile(t *testing.T) {
+	data := []byte("mapped model data")
+	path := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var p progressRecorder
+	got, unmap, err := mapModelFile(f, int64(len(data)), p.record)
+	if err != nil {
+		t.Fatalf("mapModelFile failed: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Expected %q, got %q", data, got)
+	}
+	unmap()
+	if last := len(p.loaded) - 1; last < 0 || p.loaded[last] != int64(len(data)) {
+		t.Errorf("Expected a final progress report of the whole file, got %v", p.loaded)
+	}
+}
+
+func TestNewSessionFromFileMmap(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	var p progressRecorder
+	session, err := runtime.NewSessionFromFileMmap(env, testModelPath(), &SessionOptions{LoadProgress: p.record})
+	if err != nil {
+		t.Fatalf("NewSessionFromFileMmap failed: %v", err)
+	}
+	defer session.Close()
+
+	if len(session.InputNames()) == 0 {
+		t.Error("Expected the session to have inputs")
+	}
+	if len(p.loaded) == 0 {
+		t.Error("Expected load progress to be reported")
+	}
+
+	if _, err := runtime.NewSessionFromFileMmap(env, filepath.Join(t.TempDir(), "missing.onnx"), nil); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestNewSessionFromReaderAt(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	f := mustOpenModel(t)
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var p progressRecorder
+	session, err := runtime.NewSessionFromReaderAt(env, f, info.Size(), &SessionOptions{LoadProgress: p.record})
+	if err != nil {
+		t.Fatalf("NewSessionFromReaderAt failed: %v", err)
+	}
+	defer session.Close()
+
+	if last := len(p.loaded) - 1; last < 0 || p.loaded[last] != info.Size() || p.total[last] != info.Size() {
+		t.Errorf("Expected progress to end at %d of %d, got %v of %v", info.Size(), info.Size(), p.loaded, p.total)
+	}
+}
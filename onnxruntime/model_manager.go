@@ -0,0 +1,295 @@
+package onnxruntime
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrModelNotFound is returned by ModelManager when a model or model version
+// is not being served.
+var ErrModelNotFound = errors.New("model not found")
+
+// ModelFetcher retrieves the data of a model version for a ModelManager, for
+// example from a model registry or object storage.
+type ModelFetcher interface {
+	Fetch(ctx context.Context, name, version string) ([]byte, error)
+}
+
+// ModelFetcherFunc adapts a function to the ModelFetcher interface.
+type ModelFetcherFunc func(ctx context.Context, name, version string) ([]byte, error)
+
+// Fetch calls f.
+func (f ModelFetcherFunc) Fetch(ctx context.Context, name, version string) ([]byte, error) {
+	return f(ctx, name, version)
+}
+
+// DirFetcher fetches models from a directory laid out as
+// <Root>/<name>/<version>/<FileName>.
+type DirFetcher struct {
+	Root string
+
+	// FileName is the model file in each version directory. Default
+	// "model.onnx".
+	FileName string
+
+	// Manifest, when set, is the name of a ModelManifest in each version
+	// directory that the model must match.
+	Manifest string
+
+	// PublicKey, when set, requires the model to be signed with this Ed25519
+	// key. The signature comes from the manifest or a detached FileName+".sig"
+	// file.
+	PublicKey ed25519.PublicKey
+}
+
+// Fetch reads the model file of the given version, verifying it if the
+// fetcher is configured to. Verification failures wrap ErrModelIntegrity.
+func (d *DirFetcher) Fetch(ctx context.Context, name, version string) ([]byte, error) {
+	fileName := d.FileName
+	if fileName == "" {
+		fileName = "model.onnx"
+	}
+	if !filepath.IsLocal(name) || !filepath.IsLocal(version) {
+		return nil, fmt.Errorf("invalid model name %q or version %q", name, version)
+	}
+	dir := filepath.Join(d.Root, name, version)
+	path := filepath.Join(dir, fileName)
+
+	config := &ModelConfig{VerifySignature: d.PublicKey}
+	if d.Manifest != "" {
+		manifest, err := ReadModelManifest(filepath.Join(dir, d.Manifest))
+		if err != nil {
+			return nil, err
+		}
+		config.Manifest = manifest
+	}
+	var integrity ModelIntegrity
+	if config.verifying() {
+		var err error
+		if integrity, err = config.integrity(fileName, path+".sig"); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model file: %w", err)
+	}
+	if err := integrity.Verify(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ModelManagerConfig configures a ModelManager.
+type ModelManagerConfig struct {
+	// PoolSize is the number of sessions per model. Default 1.
+	PoolSize int
+
+	// PoolConfig is used for every pool the manager creates.
+	PoolConfig *PoolConfig
+
+	// SwapConfig controls how new versions are warmed up and validated
+	// before they receive traffic.
+	SwapConfig *SwapConfig
+}
+
+// ModelManager serves named, versioned models, each from a SwappablePool.
+// Loading a new version of a served model hot-swaps it: the new version's
+// pool is built and validated while the current one keeps serving, traffic
+// switches atomically, and the old pool is drained and closed.
+//
+// A ModelManager is safe for concurrent use. Loads of the same model are
+// serialized; loads of different models proceed in parallel.
+//
+// Example:
+//
+//	manager := onnxruntime.NewModelManager(runtime, env, &onnxruntime.DirFetcher{Root: "/models"}, nil)
+//	defer manager.Close()
+//
+//	if err := manager.Load(ctx, "sentiment", "v3"); err != nil {
+//	    log.Fatal(err)
+//	}
+//	outputs, err := manager.Run(ctx, "sentiment", inputs)
+//
+//	// Later, in the background:
+//	err = manager.Load(ctx, "sentiment", "v4")
+type ModelManager struct {
+	runtime *Runtime
+	env     *Env
+	fetcher ModelFetcher
+	config  ModelManagerConfig
+
+	mu     sync.Mutex
+	models map[string]*managedModel
+	closed bool
+}
+
+// managedModel is the serving pool of one model name.
+type managedModel struct {
+	loadMu  sync.Mutex // serializes loads and unloading
+	pool    atomic.Pointer[SwappablePool]
+	removed bool
+}
+
+// NewModelManager creates a ModelManager that fetches models with fetcher
+// and creates their sessions with runtime and env. config may be nil.
+func NewModelManager(runtime *Runtime, env *Env, fetcher ModelFetcher, config *ModelManagerConfig) *ModelManager {
+	m := &ModelManager{
+		runtime: runtime,
+		env:     env,
+		fetcher: fetcher,
+		models:  make(map[string]*managedModel),
+	}
+	if config != nil {
+		m.config = *config
+	}
+	if m.config.PoolSize <= 0 {
+		m.config.PoolSize = 1
+	}
+	return m
+}
+
+// Load makes version the serving version of the named model. The first load
+// of a model creates its pool; later loads of another version hot-swap it,
+// and the previous version keeps serving if the new one fails to fetch,
+// load, or validate. Loading the version already being served does nothing.
+//
+// Load blocks until the model is serving or the load fails; run it in a
+// goroutine to swap in the background.
+func (m *ModelManager) Load(ctx context.Context, name, version string) error {
+	if name == "" || version == "" {
+		return fmt.Errorf("model name and version are required")
+	}
+
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return ErrPoolClosed
+	}
+	e := m.models[name]
+	if e == nil {
+		e = &managedModel{}
+		m.models[name] = e
+	}
+	m.mu.Unlock()
+
+	e.loadMu.Lock()
+	defer e.loadMu.Unlock()
+	if e.removed {
+		return fmt.Errorf("model %q was unloaded during load", name)
+	}
+	current := e.pool.Load()
+	if current != nil && current.Version() == version {
+		return nil
+	}
+
+	data, err := m.fetcher.Fetch(ctx, name, version)
+	if err != nil {
+		return fmt.Errorf("failed to fetch model %q version %q: %w", name, version, err)
+	}
+	if current != nil {
+		if err := current.Swap(ctx, version, data); err != nil {
+			return fmt.Errorf("failed to swap model %q: %w", name, err)
+		}
+		return nil
+	}
+
+	pool, err := NewSwappablePool(m.runtime, m.env, version, data, m.config.PoolSize, m.config.PoolConfig, m.config.SwapConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load model %q version %q: %w", name, version, err)
+	}
+	e.pool.Store(pool)
+	return nil
+}
+
+// Get returns the pool serving the named model. If version is non-empty it
+// must be the version being served. The pool follows later swaps, so callers
+// may keep it. It returns an error wrapping ErrModelNotFound if the model is
+// not being served.
+func (m *ModelManager) Get(name, version string) (*SwappablePool, error) {
+	m.mu.Lock()
+	e := m.models[name]
+	m.mu.Unlock()
+
+	var pool *SwappablePool
+	if e != nil {
+		pool = e.pool.Load()
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("%w: %q", ErrModelNotFound, name)
+	}
+	if serving := pool.Version(); version != "" && serving != version {
+		return nil, fmt.Errorf("%w: %q version %q (serving %q)", ErrModelNotFound, name, version, serving)
+	}
+	return pool, nil
+}
+
+// Run executes inference on the serving version of the named model.
+func (m *ModelManager) Run(ctx context.Context, name string, inputs map[string]*Value, opts ...RunOption) (map[string]*Value, error) {
+	pool, err := m.Get(name, "")
+	if err != nil {
+		return nil, err
+	}
+	return pool.Run(ctx, inputs, opts...)
+}
+
+// Models returns the serving version of every loaded model, by name.
+func (m *ModelManager) Models() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	versions := make(map[string]string, len(m.models))
+	for name, e := range m.models {
+		if pool := e.pool.Load(); pool != nil {
+			versions[name] = pool.Version()
+		}
+	}
+	return versions
+}
+
+// Unload stops serving the named model, waiting for an in-progress load and
+// for in-flight runs to finish. It returns an error wrapping
+// ErrModelNotFound if the model was never loaded.
+func (m *ModelManager) Unload(name string) error {
+	m.mu.Lock()
+	e := m.models[name]
+	delete(m.models, name)
+	m.mu.Unlock()
+
+	if e == nil {
+		return fmt.Errorf("%w: %q", ErrModelNotFound, name)
+	}
+	e.unload()
+	return nil
+}
+
+// Close unloads every model. Later loads fail with ErrPoolClosed. It is
+// safe to call Close multiple times.
+func (m *ModelManager) Close() {
+	m.mu.Lock()
+	m.closed = true
+	models := m.models
+	m.models = make(map[string]*managedModel)
+	m.mu.Unlock()
+
+	for _, e := range models {
+		e.unload()
+	}
+}
+
+func (e *managedModel) unload() {
+	e.loadMu.Lock()
+	defer e.loadMu.Unlock()
+
+	e.removed = true
+	if pool := e.pool.Swap(nil); pool != nil {
+		pool.Close()
+	}
+}
@@ -0,0 +1,193 @@
+package onnxruntime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// writeModelDir lays out data as <root>/<name>/<version>/model.onnx.
+func writeModelDir(t *testing.T, root, name, version string, data []byte) string {
+	t.Helper()
+	dir := filepath.Join(root, name, version)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "model.onnx")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDirFetcher(t *testing.T) {
+	root := t.TempDir()
+	data := []byte("sentiment v3")
+	writeModelDir(t, root, "sentiment", "v3", data)
+
+	f := &DirFetcher{Root: root}
+	got, err := f.Fetch(t.Context(), "sentiment", "v3")
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("Expected %q, got %q", data, got)
+	}
+
+	if _, err := f.Fetch(t.Context(), "sentiment", "v4"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist for a missing version, got %v", err)
+	}
+	if _, err := f.Fetch(t.Context(), "../sentiment", "v3"); err == nil {
+		t.Error("Expected an error for a name outside the root")
+	}
+}
+
+func TestDirFetcherManifest(t *testing.T) {
+	root := t.TempDir()
+	data := []byte("sentiment v3")
+	dir := writeModelDir(t, root, "sentiment", "v3", data)
+	pub, priv := testSigningKey(t)
+
+	writeManifest := func(e ManifestEntry) {
+		t.Helper()
+		m, err := json.Marshal(ModelManifest{Models: map[string]ManifestEntry{"model.onnx": e}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "manifest.json"), m, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f := &DirFetcher{Root: root, Manifest: "manifest.json", PublicKey: pub}
+	writeManifest(NewManifestEntry(data, priv))
+	if _, err := f.Fetch(t.Context(), "sentiment", "v3"); err != nil {
+		t.Errorf("Fetch failed for a model matching its manifest: %v", err)
+	}
+
+	writeManifest(NewManifestEntry([]byte("other model"), priv))
+	if _, err := f.Fetch(t.Context(), "sentiment", "v3"); !errors.Is(err, ErrModelIntegrity) {
+		t.Errorf("Expected ErrModelIntegrity for a mismatched model, got %v", err)
+	}
+}
+
+func TestModelManagerFetchError(t *testing.T) {
+	fetchErr := errors.New("registry unavailable")
+	fetcher := ModelFetcherFunc(func(ctx context.Context, name, version string) ([]byte, error) {
+		return nil, fetchErr
+	})
+	m := NewModelManager(nil, nil, fetcher, nil)
+	defer m.Close()
+
+	if err := m.Load(t.Context(), "sentiment", "v3"); !errors.Is(err, fetchErr) {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+	if _, err := m.Get("sentiment", ""); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound after a failed load, got %v", err)
+	}
+	if len(m.Models()) != 0 {
+		t.Errorf("Expected no models, got %v", m.Models())
+	}
+	if err := m.Load(t.Context(), "sentiment", ""); err == nil {
+		t.Error("Expected an error for an empty version")
+	}
+
+	m.Close()
+	if err := m.Load(t.Context(), "sentiment", "v3"); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed after Close, got %v", err)
+	}
+}
+
+func TestModelManagerHotSwap(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+	root := t.TempDir()
+	writeModelDir(t, root, "sentiment", "v3", modelData)
+	writeModelDir(t, root, "sentiment", "v4", modelData)
+
+	m := NewModelManager(runtime, env, &DirFetcher{Root: root}, &ModelManagerConfig{PoolSize: 2})
+	defer m.Close()
+
+	if err := m.Load(t.Context(), "sentiment", "v3"); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	pool, err := m.Get("sentiment", "v3")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	input, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	// Serve traffic throughout the swap.
+	ctx, cancel := context.WithCancel(t.Context())
+	var wg sync.WaitGroup
+	var runErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			outputs, err := m.Run(ctx, "sentiment", map[string]*Value{"input": input})
+			if err != nil {
+				if ctx.Err() == nil {
+					runErr = err
+				}
+				return
+			}
+			for _, v := range outputs {
+				v.Close()
+			}
+		}
+	}()
+
+	if err := m.Load(t.Context(), "sentiment", "v4"); err != nil {
+		t.Fatalf("Hot-swap failed: %v", err)
+	}
+	cancel()
+	wg.Wait()
+	if runErr != nil {
+		t.Errorf("Run failed during the swap: %v", runErr)
+	}
+
+	if pool.Version() != "v4" {
+		t.Errorf("Expected the pool handle to follow the swap, got version %q", pool.Version())
+	}
+	if _, err := m.Get("sentiment", "v3"); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound for the replaced version, got %v", err)
+	}
+	if got := m.Models(); got["sentiment"] != "v4" {
+		t.Errorf("Expected sentiment at v4, got %v", got)
+	}
+
+	// A failed swap keeps the current version serving.
+	if err := m.Load(t.Context(), "sentiment", "v5"); err == nil {
+		t.Error("Expected an error for a missing version")
+	}
+	if pool.Version() != "v4" {
+		t.Errorf("Expected v4 to keep serving, got %q", pool.Version())
+	}
+
+	if err := m.Unload("sentiment"); err != nil {
+		t.Fatalf("Unload failed: %v", err)
+	}
+	if _, err := m.Get("sentiment", ""); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound after Unload, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package onnxruntime
+
+import "os"
+
+// mapModelFile reads size bytes of f into memory, since mapping files is
+// not supported on this platform. The returned release function is a no-op.
+func mapModelFile(f *os.File, size int64, progress func(loaded, total int64)) ([]byte, func(), error) {
+	data, err := readModelAt(f, size, progress)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() {}, nil
+}
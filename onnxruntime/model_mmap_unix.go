@@ -0,0 +1,30 @@
+//go:build unix
+
+package onnxruntime
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// mapModelFile maps size bytes of f read-only and returns the mapping and a
+// function that unmaps it.
+func mapModelFile(f *os.File, size int64, progress func(loaded, total int64)) ([]byte, func(), error) {
+	if size <= 0 {
+		return nil, nil, fmt.Errorf("model file is empty")
+	}
+	if size > math.MaxInt {
+		return nil, nil, fmt.Errorf("model size %d exceeds the address space", size)
+	}
+	data, err := unix.Mmap(int(f.Fd()), 0, int(size), unix.PROT_READ, unix.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	// ONNX Runtime parses the model front to back.
+	_ = unix.Madvise(data, unix.MADV_SEQUENTIAL)
+	progress(size, size)
+	return data, func() { unix.Munmap(data) }, nil
+}
@@ -0,0 +1,160 @@
+package onnxruntime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+)
+
+// ModelSource produces the bytes of a model for NewSessionFromSource.
+type ModelSource interface {
+	// Open returns the model data and a function that releases it. The
+	// release function must be non-nil; it is called once ONNX Runtime no
+	// longer needs the data.
+	Open() (data []byte, release func(), err error)
+}
+
+// ModelSourceFunc adapts a function to the ModelSource interface.
+type ModelSourceFunc func() (data []byte, release func(), err error)
+
+// Open calls f.
+func (f ModelSourceFunc) Open() ([]byte, func(), error) {
+	return f()
+}
+
+// ModelBytes returns a ModelSource for model data already in memory.
+// Releasing it does nothing.
+func ModelBytes(data []byte) ModelSource {
+	return ModelSourceFunc(func() ([]byte, func(), error) {
+		return data, func() {}, nil
+	})
+}
+
+// ModelFile returns a ModelSource that maps the file at path into memory,
+// as NewSessionFromFileMmap does, and unmaps it on release.
+func ModelFile(path string) ModelSource {
+	return ModelSourceFunc(func() ([]byte, func(), error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open model file: %w", err)
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to stat model file: %w", err)
+		}
+		return mapModelFile(f, info.Size(), func(int64, int64) {})
+	})
+}
+
+// DecryptFunc decrypts ciphertext, appending the plaintext to dst and
+// returning the updated slice, like cipher.AEAD.Open. dst is empty with room
+// for len(ciphertext) bytes, and the plaintext must be written there rather
+// than to a new allocation.
+type DecryptFunc func(dst, ciphertext []byte) ([]byte, error)
+
+// ErrMemoryLockUnsupported is returned when plaintext model data cannot be
+// held in locked memory and DecryptedModel.AllowUnlocked is not set.
+var ErrMemoryLockUnsupported = errors.New("locked memory is not available")
+
+// DecryptedModel is a ModelSource for encrypted model artifacts. It decrypts
+// the model into memory that is locked against swapping and, where
+// supported, excluded from core dumps, so the plaintext never reaches disk.
+// On release the plaintext is overwritten with zeros before the memory is
+// returned to the operating system.
+//
+// NewSessionFromSource releases the plaintext as soon as ONNX Runtime has
+// created the session, unless options.UseORTModelBytesDirectly keeps it in
+// use until the session is closed. Note that ONNX Runtime holds its own
+// parsed copy of the weights in ordinary memory while the session is open.
+//
+// Example:
+//
+//	block, _ := aes.NewCipher(key)
+//	aead, _ := cipher.NewGCM(block)
+//	source := &onnxruntime.DecryptedModel{
+//	    Ciphertext: onnxruntime.ModelFile("model.onnx.enc"),
+//	    Decrypt: func(dst, ct []byte) ([]byte, error) {
+//	        nonce, ct := ct[:aead.NonceSize()], ct[aead.NonceSize():]
+//	        return aead.Open(dst, nonce, ct, nil)
+//	    },
+//	}
+//	session, err := runtime.NewSessionFromSource(env, source, nil)
+type DecryptedModel struct {
+	// Ciphertext provides the encrypted model. It is released once the model
+	// has been decrypted.
+	Ciphertext ModelSource
+
+	// Decrypt decrypts the model into the locked buffer it is given.
+	Decrypt DecryptFunc
+
+	// AllowUnlocked falls back to unlocked memory when the plaintext cannot
+	// be locked, for example on platforms without mlock or when it exceeds
+	// RLIMIT_MEMLOCK. The plaintext is still zeroed on release.
+	AllowUnlocked bool
+}
+
+// Open decrypts the model into locked memory.
+func (m *DecryptedModel) Open() ([]byte, func(), error) {
+	if m.Ciphertext == nil || m.Decrypt == nil {
+		return nil, nil, fmt.Errorf("decrypted model requires a ciphertext source and a decrypt function")
+	}
+	ciphertext, releaseCiphertext, err := m.Ciphertext.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer releaseCiphertext()
+	if len(ciphertext) == 0 {
+		return nil, nil, fmt.Errorf("model data cannot be empty")
+	}
+
+	buf, err := allocLocked(len(ciphertext), m.AllowUnlocked)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to allocate plaintext buffer: %w", err)
+	}
+	plaintext, err := m.Decrypt(buf.data[:0], ciphertext)
+	if err != nil {
+		buf.free()
+		return nil, nil, fmt.Errorf("failed to decrypt model: %w", err)
+	}
+	if len(plaintext) > 0 && unsafe.SliceData(plaintext) != unsafe.SliceData(buf.data) {
+		// The plaintext escaped the locked buffer; wipe the copy we can see.
+		clear(plaintext)
+		buf.free()
+		return nil, nil, fmt.Errorf("decrypt function must write the plaintext to the buffer it is given")
+	}
+	return plaintext, buf.free, nil
+}
+
+// NewSessionFromSource creates a session from the model data produced by
+// source, releasing it as soon as ONNX Runtime has loaded the model, or when
+// the session is closed if options.UseORTModelBytesDirectly makes the session
+// use it in place. Use it with DecryptedModel to load encrypted models.
+func (r *Runtime) NewSessionFromSource(env *Env, source ModelSource, options *SessionOptions) (*Session, error) {
+	if source == nil {
+		return nil, fmt.Errorf("model source cannot be nil")
+	}
+	modelData, release, err := source.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open model source: %w", err)
+	}
+	return r.newSessionFromReleasableBytes(env, modelData, release, options)
+}
+
+// newSessionFromReleasableBytes creates a session from modelData and calls
+// release once the session no longer needs it.
+func (r *Runtime) newSessionFromReleasableBytes(env *Env, modelData []byte, release func(), options *SessionOptions) (*Session, error) {
+	session, err := r.newSessionFromBytes(env, modelData, options, nil)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if session.retained.modelData != nil {
+		session.retained.releaseModelData = release
+	} else {
+		release()
+	}
+	return session, nil
+}
@@ -0,0 +1,192 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// xorDecrypt is a stand-in cipher that writes into dst.
+func xorDecrypt(dst, ciphertext []byte) ([]byte, error) {
+	for _, b := range ciphertext {
+		dst = append(dst, b^0x5a)
+	}
+	return dst, nil
+}
+
+func TestLockedBufferFree(t *testing.T) {
+	buf, err := allocLocked(64, true)
+	if err != nil {
+		t.Fatalf("allocLocked failed: %v", err)
+	}
+	if len(buf.data) != 64 {
+		t.Fatalf("Expected 64 bytes, got %d", len(buf.data))
+	}
+	copy(buf.data, "secret")
+	data := buf.data
+	buf.free()
+	if buf.data != nil {
+		t.Error("Expected free to drop the buffer")
+	}
+	if !buf.locked {
+		// Unlocked fallbacks stay addressable, so the wipe can be checked.
+		if !bytes.Equal(data, make([]byte, 64)) {
+			t.Error("Expected the buffer to be zeroed")
+		}
+	}
+	buf.free() // a second free is a no-op
+}
+
+func TestDecryptedModel(t *testing.T) {
+	plaintext := []byte("plaintext model bytes")
+	ciphertext, _ := xorDecrypt(nil, plaintext)
+
+	released := false
+	source := &DecryptedModel{
+		Ciphertext: ModelSourceFunc(func() ([]byte, func(), error) {
+			return ciphertext, func() { released = true }, nil
+		}),
+		Decrypt:       xorDecrypt,
+		AllowUnlocked: true,
+	}
+	data, release, err := source.Open()
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !bytes.Equal(data, plaintext) {
+		t.Errorf("Expected %q, got %q", plaintext, data)
+	}
+	if !released {
+		t.Error("Expected the ciphertext to be released after decryption")
+	}
+	release()
+}
+
+func TestDecryptedModelAESGCM(t *testing.T) {
+	block, err := aes.NewCipher(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := bytes.Repeat([]byte("weights"), 1000)
+	nonce := make([]byte, aead.NonceSize())
+	sealed := aead.Seal(nonce, nonce, plaintext, nil)
+
+	path := filepath.Join(t.TempDir(), "model.onnx.enc")
+	if err := os.WriteFile(path, sealed, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := &DecryptedModel{
+		Ciphertext: ModelFile(path),
+		Decrypt: func(dst, ct []byte) ([]byte, error) {
+			nonce, ct := ct[:aead.NonceSize()], ct[aead.NonceSize():]
+			return aead.Open(dst, nonce, ct, nil)
+		},
+		AllowUnlocked: true,
+	}
+	data, release, err := source.Open()
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer release()
+	if !bytes.Equal(data, plaintext) {
+		t.Error("Decrypted model does not match the plaintext")
+	}
+
+	// Tampered ciphertext fails authentication.
+	sealed[len(sealed)-1] ^= 1
+	source.Ciphertext = ModelBytes(sealed)
+	if _, _, err := source.Open(); err == nil {
+		t.Error("Expected an error for tampered ciphertext")
+	}
+}
+
+func TestDecryptedModelRejectsEscapedPlaintext(t *testing.T) {
+	var leaked []byte
+	source := &DecryptedModel{
+		Ciphertext: ModelBytes([]byte("ciphertext")),
+		Decrypt: func(dst, ciphertext []byte) ([]byte, error) {
+			leaked = []byte("plaintext!")
+			return leaked, nil
+		},
+		AllowUnlocked: true,
+	}
+	if _, _, err := source.Open(); err == nil {
+		t.Fatal("Expected an error for plaintext outside the given buffer")
+	}
+	if !bytes.Equal(leaked, make([]byte, len(leaked))) {
+		t.Errorf("Expected the escaped plaintext to be zeroed, got %q", leaked)
+	}
+}
+
+func TestDecryptedModelErrors(t *testing.T) {
+	decryptErr := errors.New("bad key")
+	bad := []*DecryptedModel{
+		{Decrypt: xorDecrypt},
+		{Ciphertext: ModelBytes([]byte("x"))},
+		{Ciphertext: ModelBytes(nil), Decrypt: xorDecrypt, AllowUnlocked: true},
+		{Ciphertext: ModelBytes([]byte("x")), Decrypt: func([]byte, []byte) ([]byte, error) { return nil, decryptErr }, AllowUnlocked: true},
+	}
+	for i, source := range bad {
+		if _, _, err := source.Open(); err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+	_, _, err := bad[3].Open()
+	if !errors.Is(err, decryptErr) {
+		t.Errorf("Expected the decrypt error to be wrapped, got %v", err)
+	}
+}
+
+func TestNewSessionFromSource(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	model, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, _ := xorDecrypt(nil, model)
+
+	for _, direct := range []bool{false, true} {
+		released := 0
+		source := ModelSourceFunc(func() ([]byte, func(), error) {
+			data, release, err := (&DecryptedModel{
+				Ciphertext:    ModelBytes(ciphertext),
+				Decrypt:       xorDecrypt,
+				AllowUnlocked: true,
+			}).Open()
+			return data, func() { released++; release() }, err
+		})
+		session, err := runtime.NewSessionFromSource(env, source, &SessionOptions{UseORTModelBytesDirectly: direct})
+		if err != nil {
+			t.Fatalf("NewSessionFromSource failed: %v", err)
+		}
+		if len(session.InputNames()) == 0 {
+			t.Error("Expected the session to have inputs")
+		}
+		if want := map[bool]int{false: 1, true: 0}[direct]; released != want {
+			t.Errorf("direct=%v: expected %d releases before Close, got %d", direct, want, released)
+		}
+		session.Close()
+		if released != 1 {
+			t.Errorf("direct=%v: expected one release after Close, got %d", direct, released)
+		}
+	}
+
+	if _, err := runtime.NewSessionFromSource(env, nil, nil); err == nil {
+		t.Error("Expected an error for a nil source")
+	}
+}
@@ -140,3 +140,28 @@ func TestModelDoubleClose(t *testing.T) {
 	model.Close()
 	model.Close() // should not panic
 }
+
+func TestModelRunSingle(t *testing.T) {
+	_ = newTestRuntime(t)
+
+	model, err := LoadModelFromFile(testModelPath(), &ModelConfig{
+		LibraryPath: libraryPath,
+	})
+	if err != nil {
+		t.Fatalf("Failed to load model: %v", err)
+	}
+	defer model.Close()
+
+	data, shape, err := model.RunSingle(context.Background(), make([]float32, 20), []int64{2, 10})
+	if err != nil {
+		t.Fatalf("RunSingle() error = %v", err)
+	}
+	if len(data) != 6 || len(shape) != 2 || shape[0] != 2 || shape[1] != 3 {
+		t.Errorf("RunSingle() = %d values of shape %v, want 6 of [2 3]", len(data), shape)
+	}
+
+	// The output is float32, so asking for int64 fails
+	if _, _, err := RunTyped[float32, int64](context.Background(), model, make([]float32, 10), []int64{1, 10}); err == nil {
+		t.Error("Expected error for a mismatched output type")
+	}
+}
@@ -0,0 +1,10 @@
+// Package modelinfo reads ONNX model files in pure Go, without loading the
+// ONNX Runtime library or creating a session.
+//
+// InspectModel reports a model's IR version, opset imports, producer,
+// metadata, graph inputs and outputs with their declared shapes, initializer
+// counts and sizes, and the operators the graph uses. It is intended for
+// command-line tooling and for pre-flight checks, such as rejecting a model
+// that needs a newer opset or an unavailable custom-op domain before paying
+// for session creation.
+package modelinfo
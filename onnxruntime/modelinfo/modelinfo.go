@@ -0,0 +1,323 @@
+package modelinfo
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/onnxproto"
+)
+
+// Info describes an ONNX model file.
+type Info struct {
+	IRVersion       int64
+	ProducerName    string
+	ProducerVersion string
+	Domain          string
+	ModelVersion    int64
+	DocString       string
+	GraphName       string
+	Metadata        map[string]string
+
+	// Opsets lists the imported operator sets. An empty Domain is the
+	// default ONNX domain.
+	Opsets []Opset
+
+	// Inputs are the graph inputs the caller must feed. Inputs that are
+	// also initializers (overridable constants in older exports) are
+	// listed in OverridableInitializers instead.
+	Inputs                  []Value
+	OverridableInitializers []Value
+	Outputs                 []Value
+
+	// Initializers is the number of constant tensors stored with the
+	// graph, and InitializerBytes their total size. ExternalInitializers
+	// counts those whose data lives in a separate file; their size is
+	// included in InitializerBytes.
+	Initializers         int
+	InitializerBytes     int64
+	ExternalInitializers int
+
+	// Nodes is the total number of nodes, including those in subgraphs of
+	// control-flow operators such as If and Loop.
+	Nodes int
+
+	// Operators lists each distinct operator used, sorted by domain and
+	// type.
+	Operators []Operator
+}
+
+// Opset is an imported operator set.
+type Opset struct {
+	Domain  string
+	Version int64
+}
+
+// OpsetVersion returns the imported version of domain, treating "" and
+// "ai.onnx" as the same domain, and whether the model imports it.
+func (i *Info) OpsetVersion(domain string) (int64, bool) {
+	for _, op := range i.Opsets {
+		if normalizeDomain(op.Domain) == normalizeDomain(domain) {
+			return op.Version, true
+		}
+	}
+	return 0, false
+}
+
+// Value is a graph input or output.
+type Value struct {
+	Name      string
+	Type      ort.ONNXType
+	DocString string
+
+	// TensorInfo is set for tensor and sparse tensor values. Unknown
+	// dimensions are -1, with the symbolic name, if any, in
+	// SymbolicDimNames. Shape is nil when the model declares no shape.
+	TensorInfo *ort.TensorTypeInfo
+}
+
+// TypeString returns the value's element type and shape, for example
+// "float[batch_size,3,224,224]", or the kind of a non-tensor value, such as
+// "seq". Unknown dimensions without a name are shown as "?".
+func (v *Value) TypeString() string {
+	switch v.Type {
+	case ort.ONNXTypeTensor, ort.ONNXTypeSparsetensor:
+		if v.TensorInfo == nil {
+			return "tensor"
+		}
+		s := elementTypeName(v.TensorInfo.ElementType)
+		if v.TensorInfo.Shape == nil {
+			return s
+		}
+		dims := make([]string, len(v.TensorInfo.Shape))
+		for i, d := range v.TensorInfo.Shape {
+			switch {
+			case d >= 0:
+				dims[i] = fmt.Sprint(d)
+			case i < len(v.TensorInfo.SymbolicDimNames) && v.TensorInfo.SymbolicDimNames[i] != "":
+				dims[i] = v.TensorInfo.SymbolicDimNames[i]
+			default:
+				dims[i] = "?"
+			}
+		}
+		return s + "[" + strings.Join(dims, ",") + "]"
+	case ort.ONNXTypeSequence:
+		return "seq"
+	case ort.ONNXTypeMap:
+		return "map"
+	case ort.ONNXTypeOptional:
+		return "optional"
+	default:
+		return "unknown"
+	}
+}
+
+// Operator is an operator type used by the graph.
+type Operator struct {
+	Domain string // "" for the default ONNX domain
+	OpType string
+	Count  int // number of nodes using the operator
+}
+
+// String returns the operator as domain::OpType, or just OpType for the
+// default domain.
+func (o Operator) String() string {
+	if o.Domain == "" {
+		return o.OpType
+	}
+	return o.Domain + "::" + o.OpType
+}
+
+// InspectModel reads and inspects the ONNX model file at path.
+func InspectModel(path string) (*Info, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model file: %w", err)
+	}
+	return Inspect(data)
+}
+
+// Inspect inspects serialized ONNX model data.
+func Inspect(data []byte) (*Info, error) {
+	if ort.DetectModelFormat(data) == ort.ModelFormatORT {
+		return nil, fmt.Errorf("%w: inspection requires an ONNX format model", ort.ErrUnsupportedModelFormat)
+	}
+
+	m, err := onnxproto.DecodeModel(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse model: %w", err)
+	}
+
+	g := m.Graph
+	info := &Info{
+		IRVersion:       m.IRVersion,
+		ProducerName:    m.ProducerName,
+		ProducerVersion: m.ProducerVersion,
+		Domain:          m.Domain,
+		ModelVersion:    m.ModelVersion,
+		DocString:       m.DocString,
+		GraphName:       g.Name,
+		Metadata:        m.MetadataProps,
+		Initializers:    len(g.Initializers),
+	}
+	for _, op := range m.OpsetImports {
+		info.Opsets = append(info.Opsets, Opset{Domain: op.Domain, Version: op.Version})
+	}
+
+	initializers := make(map[string]bool, len(g.Initializers))
+	for _, t := range g.Initializers {
+		initializers[t.Name] = true
+		info.InitializerBytes += initializerBytes(t)
+		if t.IsExternal() {
+			info.ExternalInitializers++
+		}
+	}
+	for _, in := range g.Inputs {
+		if initializers[in.Name] {
+			info.OverridableInitializers = append(info.OverridableInitializers, newValue(in))
+		} else {
+			info.Inputs = append(info.Inputs, newValue(in))
+		}
+	}
+	for _, out := range g.Outputs {
+		info.Outputs = append(info.Outputs, newValue(out))
+	}
+
+	counts := make(map[Operator]int)
+	info.Nodes = countOperators(g, counts)
+	for op, n := range counts {
+		op.Count = n
+		info.Operators = append(info.Operators, op)
+	}
+	slices.SortFunc(info.Operators, func(a, b Operator) int {
+		if c := strings.Compare(a.Domain, b.Domain); c != 0 {
+			return c
+		}
+		return strings.Compare(a.OpType, b.OpType)
+	})
+	return info, nil
+}
+
+// countOperators tallies operators in g and its subgraphs by domain and
+// type, returning the number of nodes visited.
+func countOperators(g *onnxproto.Graph, counts map[Operator]int) int {
+	nodes := 0
+	for _, n := range g.Nodes {
+		nodes++
+		counts[Operator{Domain: normalizeDomain(n.Domain), OpType: n.OpType}]++
+		for _, a := range n.Attributes {
+			if a.G != nil {
+				nodes += countOperators(a.G, counts)
+			}
+			for _, sub := range a.Graphs {
+				nodes += countOperators(sub, counts)
+			}
+		}
+	}
+	return nodes
+}
+
+// initializerBytes returns the stored size of t, using the external data
+// length when the tensor lives outside the model.
+func initializerBytes(t *onnxproto.Tensor) int64 {
+	if size := t.ByteSize(); size > 0 {
+		return size
+	}
+	if n, err := strconv.ParseInt(t.ExternalData["length"], 10, 64); err == nil {
+		return n
+	}
+	var n int64
+	for _, s := range t.StringData {
+		n += int64(len(s))
+	}
+	return n
+}
+
+func newValue(vi *onnxproto.ValueInfo) Value {
+	v := Value{Name: vi.Name, DocString: vi.DocString}
+	if vi.Type == nil {
+		return v
+	}
+	switch vi.Type.Kind {
+	case onnxproto.TypeTensor:
+		v.Type = ort.ONNXTypeTensor
+	case onnxproto.TypeSparseTensor:
+		v.Type = ort.ONNXTypeSparsetensor
+	case onnxproto.TypeSequence:
+		v.Type = ort.ONNXTypeSequence
+	case onnxproto.TypeMap:
+		v.Type = ort.ONNXTypeMap
+	case onnxproto.TypeOptional:
+		v.Type = ort.ONNXTypeOptional
+	default:
+		return v
+	}
+	if v.Type != ort.ONNXTypeTensor && v.Type != ort.ONNXTypeSparsetensor {
+		return v
+	}
+
+	t := &ort.TensorTypeInfo{ElementType: ort.ONNXTensorElementDataType(vi.Type.ElemType)}
+	if vi.Type.HasShape {
+		t.Shape = make([]int64, len(vi.Type.Shape))
+		t.SymbolicDimNames = make([]string, len(vi.Type.Shape))
+		for i, d := range vi.Type.Shape {
+			t.Shape[i] = -1
+			if d.HasValue {
+				t.Shape[i] = d.Value
+			}
+			t.SymbolicDimNames[i] = d.Param
+		}
+	}
+	v.TensorInfo = t
+	return v
+}
+
+func normalizeDomain(domain string) string {
+	if domain == "ai.onnx" {
+		return ""
+	}
+	return domain
+}
+
+// elementTypeName returns the ONNX name of a tensor element type.
+func elementTypeName(t ort.ONNXTensorElementDataType) string {
+	switch t {
+	case ort.ONNXTensorElementDataTypeFloat:
+		return "float"
+	case ort.ONNXTensorElementDataTypeUint8:
+		return "uint8"
+	case ort.ONNXTensorElementDataTypeInt8:
+		return "int8"
+	case ort.ONNXTensorElementDataTypeUint16:
+		return "uint16"
+	case ort.ONNXTensorElementDataTypeInt16:
+		return "int16"
+	case ort.ONNXTensorElementDataTypeInt32:
+		return "int32"
+	case ort.ONNXTensorElementDataTypeInt64:
+		return "int64"
+	case ort.ONNXTensorElementDataTypeString:
+		return "string"
+	case ort.ONNXTensorElementDataTypeBool:
+		return "bool"
+	case ort.ONNXTensorElementDataTypeFloat16:
+		return "float16"
+	case ort.ONNXTensorElementDataTypeDouble:
+		return "double"
+	case ort.ONNXTensorElementDataTypeUint32:
+		return "uint32"
+	case ort.ONNXTensorElementDataTypeUint64:
+		return "uint64"
+	case ort.ONNXTensorElementDataTypeComplex64:
+		return "complex64"
+	case ort.ONNXTensorElementDataTypeComplex128:
+		return "complex128"
+	case ort.ONNXTensorElementDataTypeBFloat16:
+		return "bfloat16"
+	default:
+		return fmt.Sprintf("type(%d)", int(t))
+	}
+}
@@ -0,0 +1,94 @@
+package modelinfo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+const testModelPath = "../internal/tests/testdata/model.onnx"
+
+func TestInspectModel(t *testing.T) {
+	info, err := InspectModel(testModelPath)
+	if err != nil {
+		t.Fatalf("InspectModel failed: %v", err)
+	}
+
+	if info.IRVersion == 0 {
+		t.Error("Expected a non-zero IR version")
+	}
+	if v, ok := info.OpsetVersion(""); !ok || v == 0 {
+		t.Errorf("Expected a default-domain opset import, got %v", info.Opsets)
+	}
+	if v, ok := info.OpsetVersion("ai.onnx"); !ok || v == 0 {
+		t.Error("Expected ai.onnx to match the default domain")
+	}
+
+	if len(info.Inputs) != 1 || info.Inputs[0].Name != "input" {
+		t.Fatalf("Unexpected inputs: %+v", info.Inputs)
+	}
+	in := info.Inputs[0]
+	if in.Type != ort.ONNXTypeTensor || in.TensorInfo.ElementType != ort.ONNXTensorElementDataTypeFloat {
+		t.Errorf("Expected float tensor input, got %+v", in)
+	}
+	if !slices.Equal(in.TensorInfo.Shape, []int64{-1, 10}) || in.TensorInfo.SymbolicDimNames[0] != "batch_size" {
+		t.Errorf("Unexpected input shape %v %v", in.TensorInfo.Shape, in.TensorInfo.SymbolicDimNames)
+	}
+	if got := in.TypeString(); got != "float[batch_size,10]" {
+		t.Errorf("Expected float[batch_size,10], got %s", got)
+	}
+
+	if len(info.Outputs) != 1 || info.Outputs[0].Name != "logits" {
+		t.Errorf("Unexpected outputs: %+v", info.Outputs)
+	}
+	if len(info.OverridableInitializers) != 0 {
+		t.Errorf("Expected no overridable initializers, got %+v", info.OverridableInitializers)
+	}
+
+	if info.Initializers == 0 || info.InitializerBytes == 0 {
+		t.Errorf("Expected initializers, got %d (%d bytes)", info.Initializers, info.InitializerBytes)
+	}
+	if info.Nodes != 3 {
+		t.Errorf("Expected 3 nodes, got %d", info.Nodes)
+	}
+	want := []Operator{{OpType: "Gemm", Count: 2}, {OpType: "Relu", Count: 1}}
+	if !slices.Equal(info.Operators, want) {
+		t.Errorf("Expected operators %v, got %v", want, info.Operators)
+	}
+}
+
+func TestInspectErrors(t *testing.T) {
+	if _, err := InspectModel("does-not-exist.onnx"); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+	if _, err := Inspect([]byte{0x08}); err == nil {
+		t.Error("Expected error for truncated data")
+	}
+
+	ortHeader := []byte{0x10, 0, 0, 0, 'O', 'R', 'T', 'M'}
+	if _, err := Inspect(ortHeader); !errors.Is(err, ort.ErrUnsupportedModelFormat) {
+		t.Errorf("Expected ErrUnsupportedModelFormat for an ORT format model, got %v", err)
+	}
+}
+
+func TestOperatorString(t *testing.T) {
+	if s := (Operator{OpType: "Conv"}).String(); s != "Conv" {
+		t.Errorf("Expected Conv, got %s", s)
+	}
+	if s := (Operator{Domain: "com.microsoft", OpType: "Attention"}).String(); s != "com.microsoft::Attention" {
+		t.Errorf("Expected com.microsoft::Attention, got %s", s)
+	}
+}
+
+func TestTypeStringUnknownDims(t *testing.T) {
+	v := Value{Type: ort.ONNXTypeTensor, TensorInfo: &ort.TensorTypeInfo{
+		ElementType:      ort.ONNXTensorElementDataTypeInt64,
+		Shape:            []int64{-1, -1},
+		SymbolicDimNames: []string{"", "seq"},
+	}}
+	if got := v.TypeString(); got != "int64[?,seq]" {
+		t.Errorf("Expected int64[?,seq], got %s", got)
+	}
+}
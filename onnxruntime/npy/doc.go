@@ -0,0 +1,27 @@
+// Package npy reads and writes NumPy .npy and .npz files, so arrays saved
+// with numpy.save and numpy.savez can be used as tensors and outputs written
+// back for inspection in Python.
+//
+// Arrays are represented as onnxpb.Tensor values, which need no native
+// library; LoadValue, SaveValue, LoadValues, and SaveValues convert directly
+// to and from ONNX Runtime values.
+//
+// The NumPy dtypes bool, int8 through int64, uint8 through uint64, float16,
+// float32, and float64 map to the ONNX element types of the same name, in
+// either byte order. Unicode (U) and byte string (S) arrays map to string
+// tensors and are written as Unicode arrays. Fortran-ordered arrays, object
+// arrays, and structured dtypes are rejected, as is bfloat16, which NumPy
+// has no dtype for.
+//
+// Example:
+//
+//	input, err := npy.LoadValue(runtime, "input.npy")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer input.Close()
+//
+//	outputs, err := session.Run(ctx, map[string]*onnxruntime.Value{"input": input})
+//	...
+//	err = npy.SaveValues("outputs.npz", outputs, nil)
+package npy
@@ -0,0 +1,470 @@
+package npy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/onnxpb"
+)
+
+// ErrFortranOrder is returned when reading an array stored in Fortran
+// (column-major) order. Save it with numpy.ascontiguousarray first.
+var ErrFortranOrder = errors.New("npy: Fortran-ordered arrays are not supported")
+
+const magic = "\x93NUMPY"
+
+// dtypes maps NumPy type codes, without byte order, to element types.
+var dtypes = map[string]ort.ONNXTensorElementDataType{
+	"b1": ort.ONNXTensorElementDataTypeBool,
+	"i1": ort.ONNXTensorElementDataTypeInt8,
+	"i2": ort.ONNXTensorElementDataTypeInt16,
+	"i4": ort.ONNXTensorElementDataTypeInt32,
+	"i8": ort.ONNXTensorElementDataTypeInt64,
+	"u1": ort.ONNXTensorElementDataTypeUint8,
+	"u2": ort.ONNXTensorElementDataTypeUint16,
+	"u4": ort.ONNXTensorElementDataTypeUint32,
+	"u8": ort.ONNXTensorElementDataTypeUint64,
+	"f2": ort.ONNXTensorElementDataTypeFloat16,
+	"f4": ort.ONNXTensorElementDataTypeFloat,
+	"f8": ort.ONNXTensorElementDataTypeDouble,
+}
+
+// header is the parsed dictionary of an .npy header.
+type header struct {
+	descr        string
+	fortranOrder bool
+	shape        []int64
+}
+
+// Read reads an .npy array from r.
+func Read(r io.Reader) (*onnxpb.Tensor, error) {
+	br := bufio.NewReader(r)
+	h, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if h.fortranOrder {
+		return nil, ErrFortranOrder
+	}
+
+	count := int64(1)
+	for _, d := range h.shape {
+		if d > 0 && count > math.MaxInt64/d {
+			return nil, fmt.Errorf("npy shape %v is too large", h.shape)
+		}
+		count *= d
+	}
+	t := &onnxpb.Tensor{Shape: h.shape}
+	if t.DataType, t.Data, err = readData(br, h.descr, count); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ReadFile reads the .npy file at path.
+func ReadFile(path string) (*onnxpb.Tensor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open npy file: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+func readHeader(r io.Reader) (header, error) {
+	var preamble [8]byte
+	if _, err := io.ReadFull(r, preamble[:]); err != nil {
+		return header{}, fmt.Errorf("failed to read npy header: %w", err)
+	}
+	if string(preamble[:6]) != magic {
+		return header{}, fmt.Errorf("not an npy file")
+	}
+
+	var n uint32
+	switch major := preamble[6]; major {
+	case 1:
+		var n16 uint16
+		if err := binary.Read(r, binary.LittleEndian, &n16); err != nil {
+			return header{}, fmt.Errorf("failed to read npy header: %w", err)
+		}
+		n = uint32(n16)
+	case 2, 3:
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return header{}, fmt.Errorf("failed to read npy header: %w", err)
+		}
+	default:
+		return header{}, fmt.Errorf("unsupported npy format version %d.%d", major, preamble[7])
+	}
+	if n > 1<<20 {
+		return header{}, fmt.Errorf("npy header of %d bytes is too large", n)
+	}
+	dict := make([]byte, n)
+	if _, err := io.ReadFull(r, dict); err != nil {
+		return header{}, fmt.Errorf("failed to read npy header: %w", err)
+	}
+	return parseHeader(string(dict))
+}
+
+// parseHeader parses the Python dictionary literal of an .npy header, such
+// as {'descr': '<f4', 'fortran_order': False, 'shape': (2, 3), }.
+func parseHeader(s string) (header, error) {
+	p := &literalParser{s: strings.TrimSpace(s)}
+	var h header
+	var seen int
+	if !p.consume("{") {
+		return header{}, fmt.Errorf("invalid npy header %q", s)
+	}
+	for !p.consume("}") {
+		key, err := p.str()
+		if err != nil {
+			return header{}, fmt.Errorf("invalid npy header %q: %w", s, err)
+		}
+		if !p.consume(":") {
+			return header{}, fmt.Errorf("invalid npy header %q", s)
+		}
+		switch key {
+		case "descr":
+			h.descr, err = p.str()
+		case "fortran_order":
+			h.fortranOrder, err = p.boolean()
+		case "shape":
+			h.shape, err = p.tuple()
+		default:
+			err = fmt.Errorf("unexpected key %q", key)
+		}
+		if err != nil {
+			return header{}, fmt.Errorf("invalid npy header %q: %w", s, err)
+		}
+		seen++
+		if !p.consume(",") && !strings.HasPrefix(p.s, "}") {
+			return header{}, fmt.Errorf("invalid npy header %q", s)
+		}
+	}
+	if seen != 3 || h.shape == nil {
+		return header{}, fmt.Errorf("npy header %q must have descr, fortran_order, and shape", s)
+	}
+	return h, nil
+}
+
+// literalParser parses the subset of Python literals used in .npy headers.
+type literalParser struct {
+	s string
+}
+
+// consume skips tok, and any space after it, if s starts with it.
+func (p *literalParser) consume(tok string) bool {
+	if !strings.HasPrefix(p.s, tok) {
+		return false
+	}
+	p.s = strings.TrimLeft(p.s[len(tok):], " ")
+	return true
+}
+
+func (p *literalParser) str() (string, error) {
+	if p.s == "" || (p.s[0] != '\'' && p.s[0] != '"') {
+		return "", fmt.Errorf("expected a string")
+	}
+	end := strings.IndexByte(p.s[1:], p.s[0])
+	if end < 0 {
+		return "", fmt.Errorf("unterminated string")
+	}
+	v := p.s[1 : end+1]
+	p.consume(p.s[:end+2])
+	return v, nil
+}
+
+func (p *literalParser) boolean() (bool, error) {
+	switch {
+	case p.consume("True"):
+		return true, nil
+	case p.consume("False"):
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected True or False")
+	}
+}
+
+func (p *literalParser) tuple() ([]int64, error) {
+	if !p.consume("(") {
+		return nil, fmt.Errorf("expected a tuple")
+	}
+	shape := []int64{}
+	for !p.consume(")") {
+		end := strings.IndexAny(p.s, ",)")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated tuple")
+		}
+		d, err := strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(p.s[:end], "L")), 10, 64)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid dimension %q", p.s[:end])
+		}
+		shape = append(shape, d)
+		p.s = p.s[end:]
+		if !p.consume(",") && !strings.HasPrefix(p.s, ")") {
+			return nil, fmt.Errorf("invalid tuple")
+		}
+	}
+	return shape, nil
+}
+
+// readData reads count elements of the NumPy dtype descr.
+func readData(r io.Reader, descr string, count int64) (ort.ONNXTensorElementDataType, any, error) {
+	if len(descr) < 3 {
+		return 0, nil, fmt.Errorf("unsupported dtype %q", descr)
+	}
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	case '=':
+		order = binary.NativeEndian
+	default:
+		return 0, nil, fmt.Errorf("unsupported dtype %q", descr)
+	}
+
+	if kind := descr[1]; kind == 'U' || kind == 'S' {
+		width, err := strconv.Atoi(descr[2:])
+		if err != nil || width <= 0 || (kind == 'U' && width > math.MaxInt/4) {
+			return 0, nil, fmt.Errorf("unsupported dtype %q", descr)
+		}
+		if kind == 'U' {
+			width *= 4
+		}
+		buf, err := readBytes(r, count, width)
+		if err != nil {
+			return 0, nil, err
+		}
+		strs, err := decodeStrings(buf, order, kind, width)
+		return ort.ONNXTensorElementDataTypeString, strs, err
+	}
+
+	dataType, ok := dtypes[descr[1:]]
+	if !ok {
+		return 0, nil, fmt.Errorf("unsupported dtype %q", descr)
+	}
+	buf, err := readBytes(r, count, binary.Size(newSlice(dataType, 1)))
+	if err != nil {
+		return 0, nil, err
+	}
+	data := newSlice(dataType, count)
+	if _, err := binary.Decode(buf, order, data); err != nil {
+		return 0, nil, fmt.Errorf("failed to decode npy data: %w", err)
+	}
+	return dataType, data, nil
+}
+
+// readBytes reads count elements of size bytes each. The buffer grows as
+// data arrives rather than up front, so a header that claims more data than
+// the stream holds fails at EOF instead of exhausting memory.
+func readBytes(r io.Reader, count int64, size int) ([]byte, error) {
+	if count > int64(math.MaxInt/size) {
+		return nil, fmt.Errorf("npy data of %d elements of %d bytes is too large", count, size)
+	}
+	const chunk = 1 << 20
+	n := int(count) * size
+	buf := make([]byte, 0, min(n, chunk))
+	for len(buf) < n {
+		m := min(n-len(buf), chunk)
+		buf = slices.Grow(buf, m)
+		if _, err := io.ReadFull(r, buf[len(buf):len(buf)+m]); err != nil {
+			return nil, fmt.Errorf("failed to read npy data: %w", err)
+		}
+		buf = buf[:len(buf)+m]
+	}
+	return buf, nil
+}
+
+// decodeStrings decodes fixed-width strings of size bytes each: UTF-32 code
+// points for the U kind and bytes for S, both padded with trailing zeros.
+func decodeStrings(buf []byte, order binary.ByteOrder, kind byte, size int) ([]string, error) {
+	strs := make([]string, len(buf)/size)
+	for i := range strs {
+		elem := buf[i*size : (i+1)*size]
+		if kind == 'S' {
+			strs[i] = string(bytes.TrimRight(elem, "\x00"))
+			continue
+		}
+		var sb strings.Builder
+		for j := 0; j < size; j += 4 {
+			c := order.Uint32(elem[j:])
+			if c == 0 {
+				break
+			}
+			if c > utf8.MaxRune {
+				return nil, fmt.Errorf("invalid code point %#x in string %d", c, i)
+			}
+			sb.WriteRune(rune(c))
+		}
+		strs[i] = sb.String()
+	}
+	return strs, nil
+}
+
+// Write writes t to w as an .npy array.
+func Write(w io.Writer, t *onnxpb.Tensor) error {
+	count := int64(1)
+	for _, d := range t.Shape {
+		if d < 0 {
+			return fmt.Errorf("invalid shape %v", t.Shape)
+		}
+		count *= d
+	}
+
+	var descr string
+	var data []byte
+	if strs, ok := t.Data.([]string); ok {
+		if int64(len(strs)) != count {
+			return fmt.Errorf("tensor has %d elements, shape %v requires %d", len(strs), t.Shape, count)
+		}
+		descr, data = encodeStrings(strs)
+	} else {
+		var err error
+		if descr, err = dtypeDescr(t.DataType); err != nil {
+			return err
+		}
+		if reflect.TypeOf(newSlice(t.DataType, 0)) != reflect.TypeOf(t.Data) {
+			return fmt.Errorf("tensor has %T data for data type %d", t.Data, t.DataType)
+		}
+		if data, err = binary.Append(nil, binary.LittleEndian, t.Data); err != nil {
+			return err
+		}
+		if n := int64(len(data) / binary.Size(newSlice(t.DataType, 1))); n != count {
+			return fmt.Errorf("tensor has %d elements, shape %v requires %d", n, t.Shape, count)
+		}
+	}
+
+	if _, err := w.Write(encodeHeader(descr, t.Shape)); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// WriteFile writes t to the .npy file at path.
+func WriteFile(path string, t *onnxpb.Tensor) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create npy file: %w", err)
+	}
+	if err := Write(f, t); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// encodeHeader returns the preamble and header of an .npy file in the
+// layout numpy.save writes: version 1.0 when the header fits, padded so the
+// data starts at a multiple of 64 bytes.
+func encodeHeader(descr string, shape []int64) []byte {
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = strconv.FormatInt(d, 10)
+	}
+	tuple := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		tuple += ","
+	}
+	dict := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, tuple)
+
+	buf := []byte(magic)
+	lenSize := 2
+	if len(dict)+1+10 > 1<<16 {
+		lenSize = 4
+	}
+	preamble := len(magic) + 2 + lenSize
+	pad := 64 - (preamble+len(dict)+1)%64
+	n := len(dict) + pad + 1
+	if lenSize == 2 {
+		buf = append(buf, 1, 0)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(n))
+	} else {
+		buf = append(buf, 2, 0)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(n))
+	}
+	buf = append(buf, dict...)
+	buf = append(buf, bytes.Repeat([]byte{' '}, pad)...)
+	return append(buf, '\n')
+}
+
+// dtypeDescr returns the little-endian NumPy dtype of an element type.
+func dtypeDescr(dataType ort.ONNXTensorElementDataType) (string, error) {
+	for code, dt := range dtypes {
+		if dt == dataType {
+			if code[1] == '1' {
+				return "|" + code, nil
+			}
+			return "<" + code, nil
+		}
+	}
+	if dataType == ort.ONNXTensorElementDataTypeBFloat16 {
+		return "", fmt.Errorf("bfloat16 has no NumPy dtype")
+	}
+	return "", fmt.Errorf("unsupported data type %d", dataType)
+}
+
+// encodeStrings encodes strs as a little-endian Unicode array as wide as
+// its longest string.
+func encodeStrings(strs []string) (string, []byte) {
+	width := 1
+	for _, s := range strs {
+		width = max(width, utf8.RuneCountInString(s))
+	}
+	data := make([]byte, 0, len(strs)*width*4)
+	for _, s := range strs {
+		n := 0
+		for _, c := range s {
+			data = binary.LittleEndian.AppendUint32(data, uint32(c))
+			n++
+		}
+		data = append(data, make([]byte, (width-n)*4)...)
+	}
+	return "<U" + strconv.Itoa(width), data
+}
+
+// newSlice returns a slice of n elements of the Go type onnxpb.Tensor uses
+// for a fixed-size element type.
+func newSlice(dataType ort.ONNXTensorElementDataType, n int64) any {
+	switch dataType {
+	case ort.ONNXTensorElementDataTypeBool:
+		return make([]bool, n)
+	case ort.ONNXTensorElementDataTypeInt8:
+		return make([]int8, n)
+	case ort.ONNXTensorElementDataTypeInt16:
+		return make([]int16, n)
+	case ort.ONNXTensorElementDataTypeInt32:
+		return make([]int32, n)
+	case ort.ONNXTensorElementDataTypeInt64:
+		return make([]int64, n)
+	case ort.ONNXTensorElementDataTypeUint8:
+		return make([]uint8, n)
+	case ort.ONNXTensorElementDataTypeUint16:
+		return make([]uint16, n)
+	case ort.ONNXTensorElementDataTypeUint32:
+		return make([]uint32, n)
+	case ort.ONNXTensorElementDataTypeUint64:
+		return make([]uint64, n)
+	case ort.ONNXTensorElementDataTypeFloat16:
+		return make([]ort.Float16, n)
+	case ort.ONNXTensorElementDataTypeFloat:
+		return make([]float32, n)
+	case ort.ONNXTensorElementDataTypeDouble:
+		return make([]float64, n)
+	default:
+		return nil
+	}
+}
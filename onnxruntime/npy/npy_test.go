@@ -0,0 +1,198 @@
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/onnxpb"
+)
+
+// npyFile builds an .npy file with a version 1.0 header.
+func npyFile(dict string, data []byte) []byte {
+	buf := []byte(magic + "\x01\x00")
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(dict)+1))
+	buf = append(buf, dict+"\n"...)
+	return append(buf, data...)
+}
+
+func TestWriteMatchesNumPy(t *testing.T) {
+	// The output of numpy.save(f, numpy.arange(6, dtype='<f4').reshape(2, 3)).
+	want := []byte(magic + "\x01\x00\x76\x00" +
+		"{'descr': '<f4', 'fortran_order': False, 'shape': (2, 3), }" + strings.Repeat(" ", 58) + "\n")
+	for i := range 6 {
+		want = binary.LittleEndian.AppendUint32(want, math.Float32bits(float32(i)))
+	}
+
+	var buf bytes.Buffer
+	err := Write(&buf, &onnxpb.Tensor{Shape: []int64{2, 3}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{0, 1, 2, 3, 4, 5}})
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Expected\n%q\ngot\n%q", want, buf.Bytes())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tensors := []*onnxpb.Tensor{
+		{Shape: []int64{2, 2}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{1, -2.5, 3, 4}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeDouble, Data: []float64{0.125, -1e300}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeFloat16, Data: []ort.Float16{ort.NewFloat16(1.5), ort.NewFloat16(-2)}},
+		{Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeInt8, Data: []int8{-128, 0, 127}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeInt16, Data: []int16{-32768, 32767}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeInt32, Data: []int32{-1 << 31, 7}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeInt64, Data: []int64{-1 << 62, 9}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint8, Data: []uint8{0, 255}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint16, Data: []uint16{0, 65535}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint32, Data: []uint32{0, 1<<32 - 1}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint64, Data: []uint64{0, 1<<64 - 1}},
+		{Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeBool, Data: []bool{true, false, true}},
+		{Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeString, Data: []string{"a", "", "héllo"}},
+		{Shape: []int64{}, DataType: ort.ONNXTensorElementDataTypeInt64, Data: []int64{42}},
+		{Shape: []int64{0, 4}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{}},
+	}
+	for _, in := range tensors {
+		var buf bytes.Buffer
+		if err := Write(&buf, in); err != nil {
+			t.Fatalf("Write(%T) failed: %v", in.Data, err)
+		}
+		if headerLen(buf.Bytes())%64 != 0 {
+			t.Errorf("Header of %T is not 64-byte aligned", in.Data)
+		}
+		out, err := Read(&buf)
+		if err != nil {
+			t.Fatalf("Read(%T) failed: %v", in.Data, err)
+		}
+		if !reflect.DeepEqual(out, in) {
+			t.Errorf("Round trip mismatch:\n got %+v\nwant %+v", out, in)
+		}
+	}
+}
+
+// headerLen returns the length of the preamble and header of an .npy file.
+func headerLen(b []byte) int {
+	return 10 + int(binary.LittleEndian.Uint16(b[8:]))
+}
+
+func TestReadVariants(t *testing.T) {
+	tests := []struct {
+		name string
+		file []byte
+		want *onnxpb.Tensor
+	}{
+		{
+			"big endian",
+			npyFile("{'descr': '>i4', 'fortran_order': False, 'shape': (2,), }", []byte{0, 0, 0, 1, 0xff, 0xff, 0xff, 0xfe}),
+			&onnxpb.Tensor{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeInt32, Data: []int32{1, -2}},
+		},
+		{
+			"python 2 long dims",
+			npyFile("{'descr': '<u2', 'fortran_order': False, 'shape': (1L, 2L), }", []byte{1, 0, 2, 0}),
+			&onnxpb.Tensor{Shape: []int64{1, 2}, DataType: ort.ONNXTensorElementDataTypeUint16, Data: []uint16{1, 2}},
+		},
+		{
+			"key order and quotes",
+			npyFile(`{"shape": (), "fortran_order": False, "descr": "|b1"}`, []byte{1}),
+			&onnxpb.Tensor{Shape: []int64{}, DataType: ort.ONNXTensorElementDataTypeBool, Data: []bool{true}},
+		},
+		{
+			"byte strings",
+			npyFile("{'descr': '|S3', 'fortran_order': False, 'shape': (2,), }", []byte("ab\x00xyz")),
+			&onnxpb.Tensor{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeString, Data: []string{"ab", "xyz"}},
+		},
+		{
+			"version 2",
+			func() []byte {
+				dict := "{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }\n"
+				b := binary.LittleEndian.AppendUint32([]byte(magic+"\x02\x00"), uint32(len(dict)))
+				b = append(b, dict...)
+				return binary.LittleEndian.AppendUint64(b, math.Float64bits(0.5))
+			}(),
+			&onnxpb.Tensor{Shape: []int64{1}, DataType: ort.ONNXTensorElementDataTypeDouble, Data: []float64{0.5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(bytes.NewReader(tt.file))
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	_, err := Read(bytes.NewReader(npyFile("{'descr': '<f4', 'fortran_order': True, 'shape': (2, 2), }", make([]byte, 16))))
+	if !errors.Is(err, ErrFortranOrder) {
+		t.Errorf("Expected ErrFortranOrder, got %v", err)
+	}
+
+	tests := map[string][]byte{
+		"bad magic":      []byte("NUMPY\x01\x00\x00\x00"),
+		"truncated":      npyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (2,), }", make([]byte, 4)),
+		"object dtype":   npyFile("{'descr': '|O', 'fortran_order': False, 'shape': (1,), }", make([]byte, 8)),
+		"complex dtype":  npyFile("{'descr': '<c8', 'fortran_order': False, 'shape': (1,), }", make([]byte, 8)),
+		"missing key":    npyFile("{'descr': '<f4', 'shape': (1,), }", make([]byte, 4)),
+		"bad shape":      npyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (-1,), }", nil),
+		"bad literal":    npyFile("{'descr': '<f4', 'fortran_order': Maybe, 'shape': (1,), }", make([]byte, 4)),
+		"bad version":    []byte(magic + "\x09\x00\x00\x00"),
+		"huge count":     npyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (4611686018427387904,), }", make([]byte, 4)),
+		"huge width":     npyFile("{'descr': '<U999999999999', 'fortran_order': False, 'shape': (1,), }", make([]byte, 4)),
+		"zero width":     npyFile("{'descr': '<U0', 'fortran_order': False, 'shape': (1,), }", nil),
+		"shape overflow": npyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (4294967296, 4294967296, 3), }", nil),
+	}
+	for name, file := range tests {
+		if _, err := Read(bytes.NewReader(file)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	tests := map[string]*onnxpb.Tensor{
+		"bfloat16":       {Shape: []int64{1}, DataType: ort.ONNXTensorElementDataTypeBFloat16, Data: []ort.BFloat16{1}},
+		"count mismatch": {Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{1}},
+		"type mismatch":  {Shape: []int64{1}, DataType: ort.ONNXTensorElementDataTypeInt64, Data: []float32{1}},
+		"strings":        {Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeString, Data: []string{"a"}},
+	}
+	for name, tensor := range tests {
+		if err := Write(&bytes.Buffer{}, tensor); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	tensors := []*onnxpb.Tensor{
+		{Name: "logits", Shape: []int64{1, 3}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{0.1, 0.2, 0.7}},
+		{Name: "labels", Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeString, Data: []string{"cat", "dog"}},
+	}
+	for _, compress := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "arrays.npz")
+		if err := WriteNPZFile(path, tensors, &NPZOptions{Compress: compress}); err != nil {
+			t.Fatalf("WriteNPZFile failed: %v", err)
+		}
+		got, err := ReadNPZFile(path)
+		if err != nil {
+			t.Fatalf("ReadNPZFile failed: %v", err)
+		}
+		if !reflect.DeepEqual(got, tensors) {
+			t.Errorf("Round trip (compress=%v) mismatch:\n got %+v\nwant %+v", compress, got, tensors)
+		}
+	}
+
+	dup := []*onnxpb.Tensor{tensors[0], tensors[0]}
+	if err := WriteNPZ(&bytes.Buffer{}, dup, nil); err == nil {
+		t.Error("Expected an error for duplicate names")
+	}
+}
@@ -0,0 +1,108 @@
+package npy
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/onnxpb"
+)
+
+// NPZOptions configures WriteNPZ.
+type NPZOptions struct {
+	// Compress deflates each array, as numpy.savez_compressed does.
+	Compress bool
+}
+
+// ReadNPZ reads the arrays of an .npz archive in archive order. Each
+// array's Name is its key in the archive, without the .npy suffix.
+func ReadNPZ(r io.ReaderAt, size int64) ([]*onnxpb.Tensor, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open npz archive: %w", err)
+	}
+
+	tensors := make([]*onnxpb.Tensor, 0, len(zr.File))
+	for _, f := range zr.File {
+		name, ok := strings.CutSuffix(f.Name, ".npy")
+		if !ok {
+			continue
+		}
+		t, err := readNPZEntry(f)
+		if err != nil {
+			return nil, fmt.Errorf("array %q: %w", name, err)
+		}
+		t.Name = name
+		tensors = append(tensors, t)
+	}
+	return tensors, nil
+}
+
+func readNPZEntry(f *zip.File) (*onnxpb.Tensor, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return Read(rc)
+}
+
+// ReadNPZFile reads the .npz file at path.
+func ReadNPZFile(path string) ([]*onnxpb.Tensor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open npz file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat npz file: %w", err)
+	}
+	return ReadNPZ(f, info.Size())
+}
+
+// WriteNPZ writes tensors to w as an .npz archive, keyed by their names,
+// which must be unique and non-empty. opts may be nil.
+func WriteNPZ(w io.Writer, tensors []*onnxpb.Tensor, opts *NPZOptions) error {
+	method := zip.Store
+	if opts != nil && opts.Compress {
+		method = zip.Deflate
+	}
+
+	zw := zip.NewWriter(w)
+	seen := make(map[string]bool, len(tensors))
+	for _, t := range tensors {
+		if t.Name == "" || seen[t.Name] {
+			return fmt.Errorf("npz array names must be unique and non-empty, got %q", t.Name)
+		}
+		seen[t.Name] = true
+
+		entry, err := zw.CreateHeader(&zip.FileHeader{Name: t.Name + ".npy", Method: method})
+		if err != nil {
+			return fmt.Errorf("failed to write npz archive: %w", err)
+		}
+		if err := Write(entry, t); err != nil {
+			return fmt.Errorf("array %q: %w", t.Name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write npz archive: %w", err)
+	}
+	return nil
+}
+
+// WriteNPZFile writes tensors to the .npz file at path. opts may be nil.
+func WriteNPZFile(path string, tensors []*onnxpb.Tensor, opts *NPZOptions) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create npz file: %w", err)
+	}
+	if err := WriteNPZ(f, tensors, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
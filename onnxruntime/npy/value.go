@@ -0,0 +1,65 @@
+package npy
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/onnxpb"
+)
+
+// LoadValue reads the .npy file at path into an ONNX Runtime tensor. The
+// caller must close it.
+func LoadValue(r *ort.Runtime, path string) (*ort.Value, error) {
+	t, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return t.Value(r)
+}
+
+// SaveValue writes an ONNX Runtime tensor to the .npy file at path.
+func SaveValue(path string, v *ort.Value) error {
+	t, err := onnxpb.NewTensor(v, "")
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, t)
+}
+
+// LoadValues reads the arrays of the .npz file at path into ONNX Runtime
+// tensors, keyed by name. The caller must close them.
+func LoadValues(r *ort.Runtime, path string) (map[string]*ort.Value, error) {
+	tensors, err := ReadNPZFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]*ort.Value, len(tensors))
+	for _, t := range tensors {
+		v, err := t.Value(r)
+		if err != nil {
+			for _, v := range values {
+				v.Close()
+			}
+			return nil, fmt.Errorf("array %q: %w", t.Name, err)
+		}
+		values[t.Name] = v
+	}
+	return values, nil
+}
+
+// SaveValues writes ONNX Runtime tensors, such as the outputs of a run, to
+// the .npz file at path, keyed by name in sorted order. opts may be nil.
+func SaveValues(path string, values map[string]*ort.Value, opts *NPZOptions) error {
+	tensors := make([]*onnxpb.Tensor, 0, len(values))
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		t, err := onnxpb.NewTensor(values[name], name)
+		if err != nil {
+			return fmt.Errorf("value %q: %w", name, err)
+		}
+		tensors = append(tensors, t)
+	}
+	return WriteNPZFile(path, tensors, opts)
+}
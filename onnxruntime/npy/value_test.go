@@ -0,0 +1,51 @@
+package npy
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func TestValues(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	v, err := ort.NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6}, []int64{2, 3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer v.Close()
+
+	dir := t.TempDir()
+	npyPath := filepath.Join(dir, "x.npy")
+	if err := SaveValue(npyPath, v); err != nil {
+		t.Fatalf("SaveValue failed: %v", err)
+	}
+	loaded, err := LoadValue(runtime, npyPath)
+	if err != nil {
+		t.Fatalf("LoadValue failed: %v", err)
+	}
+	defer loaded.Close()
+	data, shape, err := ort.GetTensorData[float32](loaded)
+	if err != nil || !slices.Equal(data, []float32{1, 2, 3, 4, 5, 6}) || !slices.Equal(shape, []int64{2, 3}) {
+		t.Errorf("Unexpected loaded tensor %v %v (%v)", data, shape, err)
+	}
+
+	npzPath := filepath.Join(dir, "x.npz")
+	if err := SaveValues(npzPath, map[string]*ort.Value{"x": v}, nil); err != nil {
+		t.Fatalf("SaveValues failed: %v", err)
+	}
+	values, err := LoadValues(runtime, npzPath)
+	if err != nil {
+		t.Fatalf("LoadValues failed: %v", err)
+	}
+	defer func() {
+		for _, v := range values {
+			v.Close()
+		}
+	}()
+	if data, _, err := ort.GetTensorData[float32](values["x"]); err != nil || !slices.Equal(data, []float32{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Unexpected npz tensor %v (%v)", data, err)
+	}
+}
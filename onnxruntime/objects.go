@@ -0,0 +1,251 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// EnvDebug is the environment variable that enables debug mode for every
+// runtime created by NewRuntime when set to a value other than "" or "0".
+// See Runtime.SetDebug.
+const EnvDebug = "ORT_DEBUG"
+
+// LiveObject describes an object created from a runtime in debug mode that
+// has not been closed.
+type LiveObject struct {
+	// Kind is the kind of object, such as "session", "environment",
+	// "IO binding", "value" or "LoRA adapter".
+	Kind string
+
+	// Stack is the stack trace of the goroutine that created the object.
+	Stack string
+}
+
+// objectKind is a kind of object whose Close calls into the library, which
+// Runtime.Close waits for.
+type objectKind int
+
+const (
+	kindSession objectKind = iota
+	kindEnv
+	kindIoBinding
+	kindValue
+	kindLoraAdapter
+	kindPrepackedWeights
+	kindMemoryInfo
+	kindAllocator
+	kindCustomOpDomain
+	kindThreadingOptions
+	kindTrainingSession
+	kindCheckpoint
+	numObjectKinds
+)
+
+var objectKindNames = [numObjectKinds]string{
+	kindSession:          "session",
+	kindEnv:              "environment",
+	kindIoBinding:        "IO binding",
+	kindValue:            "value",
+	kindLoraAdapter:      "LoRA adapter",
+	kindPrepackedWeights: "prepacked weights container",
+	kindMemoryInfo:       "memory info",
+	kindAllocator:        "allocator",
+	kindCustomOpDomain:   "custom op domain",
+	kindThreadingOptions: "threading options",
+	kindTrainingSession:  "training session",
+	kindCheckpoint:       "checkpoint state",
+}
+
+func (k objectKind) String() string {
+	return objectKindNames[k]
+}
+
+// objectTracker counts the objects of each kind created from a runtime that
+// are still open, so that Runtime.Close does not release the library
+// functions they need. In debug mode it also records where each object was
+// created.
+type objectTracker struct {
+	counts [numObjectKinds]atomic.Int64
+
+	debug  atomic.Bool
+	nextID atomic.Uint64
+	mu     sync.Mutex
+	live   map[uint64]LiveObject // open objects created in debug mode
+	leaks  func([]LiveObject)    // see Runtime.SetLeakHandler
+}
+
+// add counts a new object of kind and returns its tracking ID, which is
+// zero unless debug mode is enabled.
+func (t *objectTracker) add(kind objectKind) uint64 {
+	t.counts[kind].Add(1)
+	if !t.debug.Load() {
+		return 0
+	}
+	id := t.nextID.Add(1)
+	t.mu.Lock()
+	if t.live == nil {
+		t.live = make(map[uint64]LiveObject)
+	}
+	t.live[id] = LiveObject{Kind: kind.String(), Stack: string(debug.Stack())}
+	t.mu.Unlock()
+	return id
+}
+
+// remove uncounts an object of kind closed after add returned id.
+func (t *objectTracker) remove(kind objectKind, id uint64) {
+	t.counts[kind].Add(-1)
+	if id == 0 {
+		return
+	}
+	t.mu.Lock()
+	delete(t.live, id)
+	t.mu.Unlock()
+}
+
+// reclaim uncounts an object of kind that was garbage collected without
+// being closed after add returned id, passing it to the leak handler if it
+// was created in debug mode.
+func (t *objectTracker) reclaim(kind objectKind, id uint64) {
+	t.counts[kind].Add(-1)
+	if id == 0 {
+		return
+	}
+	t.mu.Lock()
+	obj, ok := t.live[id]
+	delete(t.live, id)
+	leaks := t.leaks
+	t.mu.Unlock()
+	if ok && leaks != nil {
+		leaks([]LiveObject{obj})
+	}
+}
+
+// liveObjects returns the open objects created in debug mode in creation
+// order.
+func (t *objectTracker) liveObjects() []LiveObject {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	objects := make([]LiveObject, 0, len(t.live))
+	for _, id := range slices.Sorted(maps.Keys(t.live)) {
+		objects = append(objects, t.live[id])
+	}
+	return objects
+}
+
+// check returns an error matching ErrRuntimeInUse if any object is open,
+// listing where the objects created in debug mode were created, and passes
+// them to the leak handler.
+func (t *objectTracker) check() error {
+	var open []string
+	for kind := range numObjectKinds {
+		if n := t.counts[kind].Load(); n != 0 {
+			open = append(open, fmt.Sprintf("%d %s", n, kind))
+		}
+	}
+	if len(open) == 0 {
+		return nil
+	}
+
+	live := t.liveObjects()
+	t.mu.Lock()
+	leaks := t.leaks
+	t.mu.Unlock()
+	if leaks != nil {
+		leaks(live)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "open objects: %s", strings.Join(open, ", "))
+	for _, obj := range live {
+		fmt.Fprintf(&b, "\n\n%s created at:\n%s", obj.Kind, obj.Stack)
+	}
+	return fmt.Errorf("%w: %s", ErrRuntimeInUse, b.String())
+}
+
+// untracked is the kind of native objects that are released when their
+// wrapper is garbage collected but not counted, such as the type and shape
+// info cached by a Value.
+const untracked objectKind = -1
+
+// leak is the argument of the cleanup that releases the native object of a
+// wrapper garbage collected without being closed. It must not reference the
+// wrapper, or the wrapper would never be collected.
+type leak struct {
+	runtime *Runtime
+	kind    objectKind
+	id      uint64 // tracking ID returned by objectTracker.add
+	ptr     uintptr
+
+	// release frees ptr. keep is passed to it.
+	release func(funcs api.APIFuncs, ptr uintptr, keep any)
+
+	// keep holds what release needs, and objects the native object depends
+	// on, such as the session of an IO binding, so that they are not
+	// reclaimed first.
+	keep any
+}
+
+// guard arranges for l.release to free the native object of obj if obj is
+// garbage collected without being closed. The object is uncounted from
+// Runtime.objects and, if created in debug mode, reported to the leak
+// handler. Close must stop the returned cleanup.
+func guard[T any](obj *T, l leak) runtime.Cleanup {
+	return runtime.AddCleanup(obj, reclaim, l)
+}
+
+// reclaim releases the native object of a leaked wrapper.
+func reclaim(l leak) {
+	if funcs := l.runtime.apiFuncs; funcs != nil {
+		l.release(funcs, l.ptr, l.keep)
+	}
+	if l.kind != untracked {
+		l.runtime.objects.reclaim(l.kind, l.id)
+	}
+}
+
+// debugFromEnv reports whether EnvDebug enables debug mode.
+func debugFromEnv() bool {
+	v := os.Getenv(EnvDebug)
+	return v != "" && v != "0"
+}
+
+// SetDebug enables or disables debug mode, in which the runtime records
+// where each object that must be closed is created, so that
+// leaks can be traced with LiveObjects, SetLeakHandler and the error of
+// Close. Debug mode only applies to objects created while it is enabled and
+// slows their creation down, so it is meant for tests and for tracking down
+// leaks. Setting EnvDebug enables it for every new runtime.
+func (r *Runtime) SetDebug(enabled bool) {
+	r.objects.debug.Store(enabled)
+}
+
+// LiveObjects returns the objects created in debug mode that have not been
+// closed, oldest first. Tests can
+// check it is empty once the code under test is done.
+func (r *Runtime) LiveObjects() []LiveObject {
+	return r.objects.liveObjects()
+}
+
+// SetLeakHandler sets a function that Close calls with the objects created
+// in debug mode that are still open when Close is called, before failing with
+// ErrRuntimeInUse. The handler is called even if none of the open objects
+// were created in debug mode, with an empty slice.
+//
+// Objects that are garbage collected without being closed are released by a
+// safety net, which also calls the handler with the object if it was created
+// in debug mode. These calls come from a background goroutine. Pass nil to
+// remove the handler.
+func (r *Runtime) SetLeakHandler(handler func(leaks []LiveObject)) {
+	r.objects.mu.Lock()
+	r.objects.leaks = handler
+	r.objects.mu.Unlock()
+}
@@ -0,0 +1,209 @@
+package onnxruntime
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+func TestObjectTracker(t *testing.T) {
+	var tracker objectTracker
+	id := tracker.add(kindSession)
+	if id != 0 {
+		t.Errorf("add() outside debug mode = %d, want 0", id)
+	}
+	if err := tracker.check(); !errors.Is(err, ErrRuntimeInUse) {
+		t.Errorf("check() with an open session error = %v, want ErrRuntimeInUse", err)
+	}
+	tracker.remove(kindSession, id)
+	if err := tracker.check(); err != nil {
+		t.Errorf("check() with no open objects error = %v", err)
+	}
+
+	tracker.debug.Store(true)
+	valueID := tracker.add(kindValue)
+	envID := tracker.add(kindEnv)
+	tracker.remove(kindEnv, envID)
+	err := tracker.check()
+	if !errors.Is(err, ErrRuntimeInUse) {
+		t.Fatalf("check() with an open value error = %v, want ErrRuntimeInUse", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "value created at:") || !strings.Contains(msg, "TestObjectTracker") {
+		t.Errorf("check() error does not include the value's creation stack: %s", msg)
+	}
+	if strings.Contains(msg, "environment created at:") {
+		t.Errorf("check() error includes a closed environment: %s", msg)
+	}
+	tracker.remove(kindValue, valueID)
+	if err := tracker.check(); err != nil {
+		t.Errorf("check() after closing all objects error = %v", err)
+	}
+}
+
+func TestObjectTrackerLeakHandler(t *testing.T) {
+	var tracker objectTracker
+	tracker.debug.Store(true)
+	var leaks []LiveObject
+	tracker.leaks = func(l []LiveObject) { leaks = l }
+
+	first := tracker.add(kindSession)
+	binding := tracker.add(kindIoBinding)
+	if live := tracker.liveObjects(); len(live) != 2 || live[0].Kind != "session" || live[1].Kind != "IO binding" {
+		t.Errorf("liveObjects() = %v, want a session and an IO binding in creation order", live)
+	}
+	if err := tracker.check(); !errors.Is(err, ErrRuntimeInUse) {
+		t.Fatalf("check() error = %v, want ErrRuntimeInUse", err)
+	}
+	if len(leaks) != 2 || !strings.Contains(leaks[0].Stack, "TestObjectTrackerLeakHandler") {
+		t.Errorf("leak handler got %v, want 2 objects with creation stacks", leaks)
+	}
+
+	tracker.remove(kindSession, first)
+	tracker.remove(kindIoBinding, binding)
+	leaks = nil
+	if err := tracker.check(); err != nil {
+		t.Errorf("check() error = %v", err)
+	}
+	if leaks != nil {
+		t.Error("Expected no leak handler call without open objects")
+	}
+}
+
+func TestObjectTrackerKinds(t *testing.T) {
+	for kind := range numObjectKinds {
+		if kind.String() == "" {
+			t.Errorf("object kind %d has no name", kind)
+		}
+	}
+
+	var tracker objectTracker
+	tracker.add(kindLoraAdapter)
+	tracker.add(kindMemoryInfo)
+	tracker.add(kindMemoryInfo)
+	err := tracker.check()
+	if err == nil || !strings.Contains(err.Error(), "1 LoRA adapter, 2 memory info") {
+		t.Errorf("check() error = %v, want it to count the open adapter and memory infos", err)
+	}
+}
+
+func TestBridgeTrack(t *testing.T) {
+	runtime := &Runtime{}
+	runtime.objects.debug.Store(true)
+	untrack := bridgeTrack(runtime, "training session")
+	if live := runtime.LiveObjects(); len(live) != 1 || live[0].Kind != "training session" {
+		t.Errorf("LiveObjects() = %v, want one training session", live)
+	}
+	untrack(false)
+	if err := runtime.objects.check(); err != nil {
+		t.Errorf("check() after untrack error = %v", err)
+	}
+}
+
+func TestDebugFromEnv(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "0": false, "1": true, "true": true} {
+		t.Setenv(EnvDebug, value)
+		if got := debugFromEnv(); got != want {
+			t.Errorf("debugFromEnv() with %s=%q = %v, want %v", EnvDebug, value, got, want)
+		}
+	}
+}
+
+func TestRuntimeCloseInUse(t *testing.T) {
+	t.Setenv(EnvDebug, "1")
+	runtime := newTestRuntime(t)
+	var leaks []LiveObject
+	runtime.SetLeakHandler(func(l []LiveObject) { leaks = l })
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("NewEnv() error = %v", err)
+	}
+	value, err := NewTensorValue(runtime, []float32{1, 2}, []int64{2})
+	if err != nil {
+		t.Fatalf("NewTensorValue() error = %v", err)
+	}
+
+	err = runtime.Close()
+	if !errors.Is(err, ErrRuntimeInUse) {
+		t.Fatalf("Close() with open objects error = %v, want ErrRuntimeInUse", err)
+	}
+	if !strings.Contains(err.Error(), "TestRuntimeCloseInUse") {
+		t.Errorf("Close() error does not include creation stacks: %v", err)
+	}
+	if len(leaks) != 2 || leaks[0].Kind != "environment" || leaks[1].Kind != "value" {
+		t.Errorf("leak handler got %v, want the environment and the value", leaks)
+	}
+	if runtime.apiFuncs == nil {
+		t.Fatal("Expected the runtime to stay usable after a failed Close")
+	}
+
+	value.Close()
+	env.Close()
+	if live := runtime.LiveObjects(); len(live) != 0 {
+		t.Errorf("LiveObjects() after closing all objects = %v", live)
+	}
+	if err := runtime.Close(); err != nil {
+		t.Fatalf("Close() after closing all objects error = %v", err)
+	}
+	if runtime.apiFuncs != nil {
+		t.Error("Expected the runtime to be released")
+	}
+}
+
+// releaseCountingAPI counts released values.
+type releaseCountingAPI struct {
+	api.APIFuncs
+	released atomic.Int32
+}
+
+func (a *releaseCountingAPI) ReleaseValue(api.OrtValue) {
+	a.released.Add(1)
+}
+
+func TestGuardReclaimsLeakedValue(t *testing.T) {
+	funcs := &releaseCountingAPI{}
+	r := &Runtime{apiFuncs: funcs}
+	r.SetDebug(true)
+	reported := make(chan []LiveObject, 1)
+	r.SetLeakHandler(func(leaks []LiveObject) { reported <- leaks })
+
+	func() { _ = r.newValueFromPtr(1) }()
+
+	var leaks []LiveObject
+	for i := 0; leaks == nil && i < 100; i++ {
+		runtime.GC()
+		select {
+		case leaks = <-reported:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if len(leaks) != 1 || leaks[0].Kind != "value" || !strings.Contains(leaks[0].Stack, "TestGuardReclaimsLeakedValue") {
+		t.Fatalf("leak handler got %+v, want the leaked value", leaks)
+	}
+	if n := funcs.released.Load(); n != 1 {
+		t.Errorf("ReleaseValue called %d times, want 1", n)
+	}
+	if err := r.objects.check(); err != nil {
+		t.Errorf("check() after reclaim error = %v", err)
+	}
+}
+
+func TestGuardStoppedByClose(t *testing.T) {
+	funcs := &releaseCountingAPI{}
+	r := &Runtime{apiFuncs: funcs}
+	r.newValueFromPtr(1).Close()
+
+	for range 3 {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if n := funcs.released.Load(); n != 1 {
+		t.Errorf("ReleaseValue called %d times, want 1", n)
+	}
+}
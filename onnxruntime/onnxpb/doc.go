@@ -0,0 +1,25 @@
+// Package onnxpb converts between ONNX TensorProto messages and tensors.
+//
+// TensorProto is the interchange format of ONNX test data directories
+// (input_0.pb, output_0.pb) and a common choice for tensors on the wire.
+// Unmarshal and Marshal convert between serialized TensorProtos and Tensor,
+// a plain Go representation that needs no native library, and
+// ValueFromTensorProto and TensorProtoFromValue convert directly to and from
+// ONNX Runtime values.
+//
+// Every element type is supported, including float16, bfloat16, and string,
+// whether the data is stored in raw_data or in the typed repeated fields.
+// Tensors whose data is stored externally are not.
+//
+// Example:
+//
+//	input, name, err := onnxpb.ValueFromTensorProto(runtime, data)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer input.Close()
+//
+//	outputs, err := session.Run(ctx, map[string]*onnxruntime.Value{name: input})
+//	...
+//	expected, err := onnxpb.ReadFile("test_data_set_0/output_0.pb")
+package onnxpb
@@ -0,0 +1,263 @@
+package onnxpb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/onnxproto"
+)
+
+// Tensor is a decoded TensorProto.
+//
+// Data holds the elements in row-major order as a slice of the Go type for
+// DataType: []float32, []float64, []int8, []int16, []int32, []int64,
+// []uint8, []uint16, []uint32, []uint64, []bool, []ort.Float16,
+// []ort.BFloat16, or []string.
+type Tensor struct {
+	Name     string
+	Shape    []int64
+	DataType ort.ONNXTensorElementDataType
+	Data     any
+}
+
+// MarshalOptions configures Marshal.
+type MarshalOptions struct {
+	// TypedFields stores elements in the typed repeated fields (float_data,
+	// int32_data, int64_data, double_data, uint64_data) instead of raw_data,
+	// as some tools expect. Float16 and bfloat16 elements are stored as their
+	// bit patterns in int32_data.
+	TypedFields bool
+}
+
+// Unmarshal decodes a serialized TensorProto.
+func Unmarshal(data []byte) (*Tensor, error) {
+	p, err := onnxproto.DecodeTensor(data)
+	if err != nil {
+		return nil, err
+	}
+	limit := int64(math.MaxInt)
+	if size := onnxproto.ElementSize(p.DataType); size > 0 {
+		limit /= int64(size)
+	}
+	count := int64(1)
+	for _, d := range p.Dims {
+		if d < 0 || (d > 0 && count > limit/d) {
+			return nil, fmt.Errorf("tensor %q has invalid dims %v", p.Name, p.Dims)
+		}
+		count *= d
+	}
+
+	t := &Tensor{Name: p.Name, Shape: p.Dims, DataType: ort.ONNXTensorElementDataType(p.DataType)}
+	if t.Shape == nil {
+		t.Shape = []int64{}
+	}
+	if t.DataType == ort.ONNXTensorElementDataTypeString {
+		if int64(len(p.StringData)) != count {
+			return nil, fmt.Errorf("tensor %q has %d strings, dims %v require %d", p.Name, len(p.StringData), p.Dims, count)
+		}
+		strs := make([]string, len(p.StringData))
+		for i, s := range p.StringData {
+			strs[i] = string(s)
+		}
+		t.Data = strs
+		return t, nil
+	}
+
+	if newSlice(t.DataType, 0) == nil {
+		return nil, fmt.Errorf("unsupported data type %d", p.DataType)
+	}
+	// Bytes checks the data against the dims, so the slice is only
+	// allocated for data the message actually holds.
+	raw, err := p.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	t.Data = newSlice(t.DataType, int(count))
+	if _, err := binary.Decode(raw, binary.LittleEndian, t.Data); err != nil {
+		return nil, fmt.Errorf("tensor %q: %w", p.Name, err)
+	}
+	return t, nil
+}
+
+// ReadFile reads and decodes the TensorProto file at path.
+func ReadFile(path string) (*Tensor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tensor file: %w", err)
+	}
+	return Unmarshal(data)
+}
+
+// Marshal serializes t as a TensorProto. opts may be nil.
+func Marshal(t *Tensor, opts *MarshalOptions) ([]byte, error) {
+	count := int64(1)
+	for _, d := range t.Shape {
+		if d < 0 {
+			return nil, fmt.Errorf("tensor %q has invalid shape %v", t.Name, t.Shape)
+		}
+		count *= d
+	}
+	if n := dataLen(t.Data); int64(n) != count {
+		return nil, fmt.Errorf("tensor %q has %d elements, shape %v requires %d", t.Name, n, t.Shape, count)
+	}
+
+	p := &onnxproto.Tensor{Name: t.Name, Dims: t.Shape, DataType: int32(t.DataType)}
+	if strs, ok := t.Data.([]string); ok {
+		if t.DataType != ort.ONNXTensorElementDataTypeString {
+			return nil, fmt.Errorf("tensor %q has string data for data type %d", t.Name, t.DataType)
+		}
+		p.StringData = make([][]byte, len(strs))
+		for i, s := range strs {
+			p.StringData[i] = []byte(s)
+		}
+		return onnxproto.EncodeTensor(p), nil
+	}
+	if want := newSlice(t.DataType, 0); want == nil || reflect.TypeOf(want) != reflect.TypeOf(t.Data) {
+		return nil, fmt.Errorf("tensor %q has %T data for data type %d", t.Name, t.Data, t.DataType)
+	}
+
+	if opts != nil && opts.TypedFields && count > 0 {
+		setTypedFields(p, t.Data)
+		return onnxproto.EncodeTensor(p), nil
+	}
+	raw, err := binary.Append(nil, binary.LittleEndian, t.Data)
+	if err != nil {
+		return nil, fmt.Errorf("tensor %q: %w", t.Name, err)
+	}
+	p.RawData = raw
+	return onnxproto.EncodeTensor(p), nil
+}
+
+// WriteFile serializes t as a TensorProto to the file at path. opts may be
+// nil.
+func WriteFile(path string, t *Tensor, opts *MarshalOptions) error {
+	data, err := Marshal(t, opts)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write tensor file: %w", err)
+	}
+	return nil
+}
+
+// newSlice returns a slice of n elements of the Go type for a fixed-size
+// data type, or nil if the type has none.
+func newSlice(dataType ort.ONNXTensorElementDataType, n int) any {
+	switch dataType {
+	case ort.ONNXTensorElementDataTypeFloat:
+		return make([]float32, n)
+	case ort.ONNXTensorElementDataTypeDouble:
+		return make([]float64, n)
+	case ort.ONNXTensorElementDataTypeInt8:
+		return make([]int8, n)
+	case ort.ONNXTensorElementDataTypeInt16:
+		return make([]int16, n)
+	case ort.ONNXTensorElementDataTypeInt32:
+		return make([]int32, n)
+	case ort.ONNXTensorElementDataTypeInt64:
+		return make([]int64, n)
+	case ort.ONNXTensorElementDataTypeUint8:
+		return make([]uint8, n)
+	case ort.ONNXTensorElementDataTypeUint16:
+		return make([]uint16, n)
+	case ort.ONNXTensorElementDataTypeUint32:
+		return make([]uint32, n)
+	case ort.ONNXTensorElementDataTypeUint64:
+		return make([]uint64, n)
+	case ort.ONNXTensorElementDataTypeBool:
+		return make([]bool, n)
+	case ort.ONNXTensorElementDataTypeFloat16:
+		return make([]ort.Float16, n)
+	case ort.ONNXTensorElementDataTypeBFloat16:
+		return make([]ort.BFloat16, n)
+	default:
+		return nil
+	}
+}
+
+// dataLen returns the number of elements in a Tensor's Data, or -1 if it is
+// not a supported slice type.
+func dataLen(data any) int {
+	switch d := data.(type) {
+	case []float32:
+		return len(d)
+	case []float64:
+		return len(d)
+	case []int8:
+		return len(d)
+	case []int16:
+		return len(d)
+	case []int32:
+		return len(d)
+	case []int64:
+		return len(d)
+	case []uint8:
+		return len(d)
+	case []uint16:
+		return len(d)
+	case []uint32:
+		return len(d)
+	case []uint64:
+		return len(d)
+	case []bool:
+		return len(d)
+	case []ort.Float16:
+		return len(d)
+	case []ort.BFloat16:
+		return len(d)
+	case []string:
+		return len(d)
+	default:
+		return -1
+	}
+}
+
+// setTypedFields stores data in the TensorProto field for its type.
+func setTypedFields(p *onnxproto.Tensor, data any) {
+	switch d := data.(type) {
+	case []float32:
+		p.FloatData = d
+	case []float64:
+		p.DoubleData = d
+	case []int64:
+		p.Int64Data = d
+	case []uint64:
+		p.Uint64Data = d
+	case []uint32:
+		p.Uint64Data = widen[uint64](d)
+	case []int8:
+		p.Int32Data = widen[int32](d)
+	case []int16:
+		p.Int32Data = widen[int32](d)
+	case []int32:
+		p.Int32Data = d
+	case []uint8:
+		p.Int32Data = widen[int32](d)
+	case []uint16:
+		p.Int32Data = widen[int32](d)
+	case []ort.Float16:
+		p.Int32Data = widen[int32](d)
+	case []ort.BFloat16:
+		p.Int32Data = widen[int32](d)
+	case []bool:
+		p.Int32Data = make([]int32, len(d))
+		for i, b := range d {
+			if b {
+				p.Int32Data[i] = 1
+			}
+		}
+	}
+}
+
+func widen[W int32 | uint64, T ~int8 | ~int16 | ~uint8 | ~uint16 | ~uint32](data []T) []W {
+	out := make([]W, len(data))
+	for i, v := range data {
+		out[i] = W(v)
+	}
+	return out
+}
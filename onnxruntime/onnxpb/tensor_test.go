@@ -0,0 +1,98 @@
+package onnxpb
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	tensors := []*Tensor{
+		{Name: "f", Shape: []int64{2, 2}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{1, -2.5, 3, 4}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeDouble, Data: []float64{0.125, -1e300}},
+		{Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeInt8, Data: []int8{-128, 0, 127}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeInt16, Data: []int16{-32768, 32767}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeInt32, Data: []int32{-1 << 31, 7}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeInt64, Data: []int64{-1 << 62, 9}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint8, Data: []uint8{0, 255}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint16, Data: []uint16{0, 65535}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint32, Data: []uint32{0, 1<<32 - 1}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeUint64, Data: []uint64{0, 1<<64 - 1}},
+		{Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeBool, Data: []bool{true, false, true}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeFloat16, Data: []ort.Float16{ort.NewFloat16(1.5), ort.NewFloat16(-2)}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeBFloat16, Data: []ort.BFloat16{ort.NewBFloat16(1.5), ort.NewBFloat16(-2)}},
+		{Name: "s", Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeString, Data: []string{"a", "", "héllo"}},
+		{Shape: []int64{}, DataType: ort.ONNXTensorElementDataTypeInt64, Data: []int64{42}},
+		{Shape: []int64{0, 4}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{}},
+	}
+	for _, typed := range []bool{false, true} {
+		for _, in := range tensors {
+			data, err := Marshal(in, &MarshalOptions{TypedFields: typed})
+			if err != nil {
+				t.Fatalf("Marshal(%T, typed=%v) failed: %v", in.Data, typed, err)
+			}
+			out, err := Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal(%T, typed=%v) failed: %v", in.Data, typed, err)
+			}
+			if !reflect.DeepEqual(out, in) {
+				t.Errorf("Round trip (typed=%v) mismatch:\n got %+v\nwant %+v", typed, out, in)
+			}
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	tests := map[string]*Tensor{
+		"count mismatch":   {Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{1}},
+		"type mismatch":    {Shape: []int64{1}, DataType: ort.ONNXTensorElementDataTypeInt64, Data: []float32{1}},
+		"strings mismatch": {Shape: []int64{1}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []string{"a"}},
+		"negative shape":   {Shape: []int64{-1}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{}},
+		"unsupported data": {Shape: []int64{1}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []int{1}},
+	}
+	for name, tensor := range tests {
+		if _, err := Marshal(tensor, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := map[string][]byte{
+		"truncated": {0x0a, 0x05},
+		// dims [1], data_type COMPLEX64, 8 bytes of raw_data
+		"unsupported": {0x0a, 0x01, 0x01, 0x10, 14, 0x4a, 0x08, 0, 0, 0, 0, 0, 0, 0, 0},
+		// dims [2], data_type FLOAT, 4 bytes of raw_data
+		"short data": {0x0a, 0x01, 0x02, 0x10, 1, 0x4a, 0x04, 0, 0, 0, 0},
+		// dims [2], data_type STRING, one string
+		"short strings": {0x0a, 0x01, 0x02, 0x10, 8, 0x32, 0x01, 'a'},
+		// dims [1<<62], data_type FLOAT, no data
+		"huge dims": {0x0a, 0x09, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x40, 0x10, 1},
+		// dims [1<<32, 1<<32], data_type FLOAT, no data
+		"dims overflow": {0x0a, 0x0a, 0x80, 0x80, 0x80, 0x80, 0x10, 0x80, 0x80, 0x80, 0x80, 0x10, 0x10, 1},
+		// dims [2], data_type FLOAT, one float_data value
+		"short typed data": {0x0a, 0x01, 0x02, 0x10, 1, 0x22, 0x04, 0, 0, 0, 0},
+	}
+	for name, data := range tests {
+		if _, err := Unmarshal(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input_0.pb")
+	in := &Tensor{Name: "input", Shape: []int64{1, 3}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{1, 2, 3}}
+	if err := WriteFile(path, in, nil); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	out, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("Expected %+v, got %+v", in, out)
+	}
+}
@@ -0,0 +1,79 @@
+package onnxpb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Value creates an ONNX Runtime tensor from t. The caller must close it.
+func (t *Tensor) Value(r *ort.Runtime) (*ort.Value, error) {
+	if strs, ok := t.Data.([]string); ok {
+		return r.NewStringTensorValue(strs, t.Shape)
+	}
+	if dataLen(t.Data) < 0 {
+		return nil, fmt.Errorf("tensor %q has unsupported data %T", t.Name, t.Data)
+	}
+	raw, err := binary.Append(nil, binary.LittleEndian, t.Data)
+	if err != nil {
+		return nil, fmt.Errorf("tensor %q: %w", t.Name, err)
+	}
+	return ort.NewTensorValueFromBytes(r, raw, t.Shape, t.DataType)
+}
+
+// NewTensor copies an ONNX Runtime tensor into a Tensor with the given name.
+func NewTensor(v *ort.Value, name string) (*Tensor, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get element type: %w", err)
+	}
+	t := &Tensor{Name: name, DataType: elemType}
+	if elemType == ort.ONNXTensorElementDataTypeString {
+		t.Data, t.Shape, err = ort.GetStringTensorData(v)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+
+	raw, shape, _, err := ort.GetTensorBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	elem := newSlice(elemType, 1)
+	if elem == nil {
+		return nil, fmt.Errorf("unsupported tensor element type %d", elemType)
+	}
+	t.Data = newSlice(elemType, len(raw)/binary.Size(elem))
+	if _, err := binary.Decode(raw, binary.LittleEndian, t.Data); err != nil {
+		return nil, err
+	}
+	t.Shape = shape
+	return t, nil
+}
+
+// ValueFromTensorProto creates an ONNX Runtime tensor from a serialized
+// TensorProto. It returns the tensor and the name stored in the proto, which
+// may be empty. The caller must close the returned Value.
+func ValueFromTensorProto(r *ort.Runtime, data []byte) (*ort.Value, string, error) {
+	t, err := Unmarshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	v, err := t.Value(r)
+	if err != nil {
+		return nil, "", err
+	}
+	return v, t.Name, nil
+}
+
+// TensorProtoFromValue serializes an ONNX Runtime tensor as a TensorProto
+// with the given name. opts may be nil.
+func TensorProtoFromValue(v *ort.Value, name string, opts *MarshalOptions) ([]byte, error) {
+	t, err := NewTensor(v, name)
+	if err != nil {
+		return nil, err
+	}
+	return Marshal(t, opts)
+}
@@ -0,0 +1,48 @@
+package onnxpb
+
+import (
+	"reflect"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func TestValueRoundTrip(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	tensors := []*Tensor{
+		{Name: "f", Shape: []int64{2, 2}, DataType: ort.ONNXTensorElementDataTypeFloat, Data: []float32{1, -2.5, 3, 4}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeFloat16, Data: []ort.Float16{ort.NewFloat16(1.5), ort.NewFloat16(-2)}},
+		{Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeBFloat16, Data: []ort.BFloat16{ort.NewBFloat16(1.5), ort.NewBFloat16(-2)}},
+		{Shape: []int64{3}, DataType: ort.ONNXTensorElementDataTypeBool, Data: []bool{true, false, true}},
+		{Name: "s", Shape: []int64{2}, DataType: ort.ONNXTensorElementDataTypeString, Data: []string{"a", "héllo"}},
+	}
+	for _, typed := range []bool{false, true} {
+		for _, in := range tensors {
+			data, err := Marshal(in, &MarshalOptions{TypedFields: typed})
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			v, name, err := ValueFromTensorProto(runtime, data)
+			if err != nil {
+				t.Fatalf("ValueFromTensorProto(%T, typed=%v) failed: %v", in.Data, typed, err)
+			}
+			if name != in.Name {
+				t.Errorf("Expected name %q, got %q", in.Name, name)
+			}
+
+			data, err = TensorProtoFromValue(v, in.Name, &MarshalOptions{TypedFields: typed})
+			v.Close()
+			if err != nil {
+				t.Fatalf("TensorProtoFromValue(%T) failed: %v", in.Data, err)
+			}
+			out, err := Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(out, in) {
+				t.Errorf("Round trip (typed=%v) mismatch:\n got %+v\nwant %+v", typed, out, in)
+			}
+		}
+	}
+}
@@ -10,6 +10,30 @@ import (
 var (
 	// ErrSessionClosed is returned when an operation is attempted on a closed session.
 	ErrSessionClosed = errors.New("session is closed")
+
+	// ErrPoolClosed is returned when an operation is attempted on a closed session pool.
+	ErrPoolClosed = errors.New("session pool is closed")
+
+	// ErrPoolDrained is matched by the errors of runs terminated because
+	// SessionPool.Drain's context ended before they finished.
+	ErrPoolDrained = errors.New("run aborted by pool drain")
+
+	// ErrRunStuck is matched by the errors of pool runs terminated because
+	// they exceeded PoolConfig.StuckRunThreshold.
+	ErrRunStuck = errors.New("run exceeded the pool's stuck run threshold")
+
+	// ErrRuntimeInUse is matched by the error of Runtime.Close while
+	// objects created from the runtime, such as sessions, environments or
+	// values, are open.
+	ErrRuntimeInUse = errors.New("runtime is still in use")
+
+	// ErrProviderUnavailable is returned when a requested execution provider is not
+	// compiled into the loaded ONNX Runtime library. The concrete error is a
+	// *ProviderUnavailableError listing the providers that are available.
+	ErrProviderUnavailable = errors.New("execution provider unavailable")
+
+	// ErrInjectedFault is returned by runs failed deliberately by a FaultInjector.
+	ErrInjectedFault = errors.New("injected fault")
 )
 
 // ErrorCode represents error codes returned by the ONNX Runtime C API.
@@ -174,20 +198,61 @@ func (e ExecutionMode) String() string {
 	}
 }
 
-// allocatorType represents memory allocator types.
-type allocatorType = api.OrtAllocatorType
+// AllocatorType represents memory allocator types.
+type AllocatorType = api.OrtAllocatorType
 
 // Memory allocator types.
 const (
-	// allocatorTypeDevice indicates a device-specific allocator.
-	allocatorTypeDevice allocatorType = 0
+	// AllocatorTypeDevice indicates a device-specific allocator.
+	AllocatorTypeDevice AllocatorType = 0
+	// AllocatorTypeArena indicates an arena-based allocator.
+	AllocatorTypeArena AllocatorType = 1
 )
 
-// memType represents memory types for allocations.
-type memType = api.OrtMemType
+// MemType represents memory types for allocations.
+type MemType = api.OrtMemType
 
 // Memory types for allocations.
 const (
-	// memTypeCPU indicates general CPU memory.
-	memTypeCPU memType = 0
+	// MemTypeCPUInput indicates CPU-accessible memory used as input by a non-CPU execution provider.
+	MemTypeCPUInput MemType = -2
+	// MemTypeCPUOutput indicates CPU-accessible memory produced by a non-CPU execution provider.
+	MemTypeCPUOutput MemType = -1
+	// MemTypeDefault indicates the default memory of the device.
+	MemTypeDefault MemType = 0
+)
+
+// MemoryDeviceType is the kind of device that memory lives on.
+type MemoryDeviceType = api.OrtMemoryInfoDeviceType
+
+// Memory device types.
+const (
+	// MemoryDeviceTypeCPU indicates host memory.
+	MemoryDeviceTypeCPU MemoryDeviceType = 0
+	// MemoryDeviceTypeGPU indicates GPU memory.
+	MemoryDeviceTypeGPU MemoryDeviceType = 1
+	// MemoryDeviceTypeFPGA indicates FPGA memory.
+	MemoryDeviceTypeFPGA MemoryDeviceType = 2
+	// MemoryDeviceTypeNPU indicates NPU memory.
+	MemoryDeviceTypeNPU MemoryDeviceType = 3
+)
+
+// Memory info names recognized by ONNX Runtime's built-in execution providers.
+const (
+	MemoryInfoNameCPU        = "Cpu"
+	MemoryInfoNameCUDA       = "Cuda"
+	MemoryInfoNameCUDAPinned = "CudaPinned"
+	MemoryInfoNameDML        = "DML"
+)
+
+// Standard ONNX dimension denotations, for use as keys of
+// SessionOptions.FreeDimensionOverridesByDenotation.
+const (
+	DimensionDenotationBatch            = "DATA_BATCH"
+	DimensionDenotationChannel          = "DATA_CHANNEL"
+	DimensionDenotationTime             = "DATA_TIME"
+	DimensionDenotationFeature          = "DATA_FEATURE"
+	DimensionDenotationFilterInChannel  = "FILTER_IN_CHANNEL"
+	DimensionDenotationFilterOutChannel = "FILTER_OUT_CHANNEL"
+	DimensionDenotationFilterSpatial    = "FILTER_SPATIAL"
 )
@@ -0,0 +1,154 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ModelFormat identifies the serialization format of a model.
+type ModelFormat int
+
+const (
+	// ModelFormatAuto lets ONNX Runtime detect the format from the file
+	// extension or the model bytes.
+	ModelFormatAuto ModelFormat = iota
+
+	// ModelFormatONNX is the standard protobuf-based ONNX format.
+	ModelFormatONNX
+
+	// ModelFormatORT is the flatbuffer-based ORT format produced by
+	// onnxruntime.tools.convert_onnx_models_to_ort. It is the only format
+	// accepted by minimal (reduced-size) builds used on mobile and edge devices.
+	ModelFormatORT
+)
+
+// String returns the format name.
+func (f ModelFormat) String() string {
+	switch f {
+	case ModelFormatAuto:
+		return "auto"
+	case ModelFormatONNX:
+		return "ONNX"
+	case ModelFormatORT:
+		return "ORT"
+	default:
+		return fmt.Sprintf("ModelFormat(%d)", int(f))
+	}
+}
+
+// Session config keys for ORT format models.
+// See onnxruntime_session_options_config_keys.h.
+const (
+	// ConfigKeyLoadModelFormat forces the format used to load the model ("ORT" or "ONNX").
+	ConfigKeyLoadModelFormat = "session.load_model_format"
+
+	// ConfigKeySaveModelFormat sets the format used for OptimizedModelFilePath ("ORT" or "ONNX").
+	ConfigKeySaveModelFormat = "session.save_model_format"
+
+	// ConfigKeyUseORTModelBytesDirectly makes the session use the model buffer
+	// in place instead of copying it ("1" to enable). ORT format only.
+	ConfigKeyUseORTModelBytesDirectly = "session.use_ort_model_bytes_directly"
+
+	// ConfigKeyUseORTModelBytesForInitializers makes initializers point into the
+	// model buffer instead of being copied ("1" to enable). Requires
+	// ConfigKeyUseORTModelBytesDirectly.
+	ConfigKeyUseORTModelBytesForInitializers = "session.use_ort_model_bytes_for_initializers"
+)
+
+// ErrUnsupportedModelFormat is returned when the loaded library cannot read the
+// model's format, such as an ONNX model on a minimal build or an ORT format
+// model on a build without ORT format support.
+var ErrUnsupportedModelFormat = errors.New("unsupported model format")
+
+// ortFileIdentifier is the flatbuffer file identifier of ORT format models,
+// stored at byte offset 4.
+var ortFileIdentifier = []byte("ORTM")
+
+// DetectModelFormat reports the format of serialized model data.
+// It returns ModelFormatORT for flatbuffers carrying the ORT file identifier
+// and ModelFormatONNX otherwise.
+func DetectModelFormat(data []byte) ModelFormat {
+	if len(data) >= 8 && bytes.Equal(data[4:8], ortFileIdentifier) {
+		return ModelFormatORT
+	}
+	return ModelFormatONNX
+}
+
+// DetectModelFormatFromFile reports the format of a model file by inspecting
+// its header. Files with a .ort extension are reported as ORT format when the
+// header is too short to decide.
+func DetectModelFormatFromFile(path string) (ModelFormat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ModelFormatAuto, fmt.Errorf("failed to open model file: %w", err)
+	}
+	defer f.Close()
+
+	header := make([]byte, 8)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return ModelFormatAuto, fmt.Errorf("failed to read model header: %w", err)
+	}
+	if n < len(header) && strings.EqualFold(filepath.Ext(path), ".ort") {
+		return ModelFormatORT, nil
+	}
+	return DetectModelFormat(header[:n]), nil
+}
+
+// modelFormatConfigEntries returns the session config entries implied by the
+// ORT format options.
+func (o *SessionOptions) modelFormatConfigEntries() map[string]string {
+	entries := make(map[string]string)
+	if o.ModelFormat != ModelFormatAuto {
+		entries[ConfigKeyLoadModelFormat] = o.ModelFormat.String()
+	}
+	if o.OptimizedModelFormat != ModelFormatAuto {
+		entries[ConfigKeySaveModelFormat] = o.OptimizedModelFormat.String()
+	}
+	if o.UseORTModelBytesDirectly {
+		entries[ConfigKeyUseORTModelBytesDirectly] = "1"
+		entries[ConfigKeyUseORTModelBytesForInitializers] = "1"
+	}
+	return entries
+}
+
+// checkModelFormat validates the requested load format against the detected one.
+func checkModelFormat(options *SessionOptions, detected ModelFormat) error {
+	if options == nil {
+		return nil
+	}
+	if options.ModelFormat != ModelFormatAuto && detected != ModelFormatAuto && options.ModelFormat != detected {
+		return fmt.Errorf("%w: model is in %s format but ModelFormat is %s", ErrUnsupportedModelFormat, detected, options.ModelFormat)
+	}
+	if options.UseORTModelBytesDirectly && detected != ModelFormatORT {
+		return fmt.Errorf("UseORTModelBytesDirectly requires an ORT format model, got %s", detected)
+	}
+	return nil
+}
+
+// wrapModelFormatError annotates session creation errors caused by a library
+// build that cannot read the model's format.
+func wrapModelFormatError(err error, format ModelFormat) error {
+	var rtErr *RuntimeError
+	if !errors.As(err, &rtErr) {
+		return err
+	}
+
+	msg := strings.ToLower(rtErr.Message)
+	switch {
+	case format == ModelFormatONNX && strings.Contains(msg, "onnx format model is not supported"):
+		return fmt.Errorf("%w: this is a minimal build that only loads ORT format models; "+
+			"convert the model with python -m onnxruntime.tools.convert_onnx_models_to_ort: %w",
+			ErrUnsupportedModelFormat, err)
+	case format == ModelFormatORT && strings.Contains(msg, "ort format"):
+		return fmt.Errorf("%w: the library was built without ORT format support: %w",
+			ErrUnsupportedModelFormat, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,199 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ortHeader returns the first bytes of a minimal ORT format flatbuffer.
+func ortHeader() []byte {
+	return []byte{0x10, 0x00, 0x00, 0x00, 'O', 'R', 'T', 'M', 0x00, 0x00}
+}
+
+func TestDetectModelFormat(t *testing.T) {
+	onnxData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want ModelFormat
+	}{
+		{"onnx", onnxData, ModelFormatONNX},
+		{"ort", ortHeader(), ModelFormatORT},
+		{"short", []byte{1, 2, 3}, ModelFormatONNX},
+		{"empty", nil, ModelFormatONNX},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectModelFormat(tt.data); got != tt.want {
+				t.Errorf("DetectModelFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectModelFormatFromFile(t *testing.T) {
+	got, err := DetectModelFormatFromFile(testModelPath())
+	if err != nil {
+		t.Fatalf("DetectModelFormatFromFile failed: %v", err)
+	}
+	if got != ModelFormatONNX {
+		t.Errorf("Expected ONNX format, got %v", got)
+	}
+
+	dir := t.TempDir()
+	ortPath := filepath.Join(dir, "model.ort")
+	if err := os.WriteFile(ortPath, ortHeader(), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	got, err = DetectModelFormatFromFile(ortPath)
+	if err != nil {
+		t.Fatalf("DetectModelFormatFromFile failed: %v", err)
+	}
+	if got != ModelFormatORT {
+		t.Errorf("Expected ORT format, got %v", got)
+	}
+
+	if _, err := DetectModelFormatFromFile(filepath.Join(dir, "missing.onnx")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestModelFormatConfigEntries(t *testing.T) {
+	opts := &SessionOptions{
+		ModelFormat:              ModelFormatORT,
+		OptimizedModelFormat:     ModelFormatORT,
+		UseORTModelBytesDirectly: true,
+	}
+	entries := opts.modelFormatConfigEntries()
+
+	want := map[string]string{
+		ConfigKeyLoadModelFormat:                 "ORT",
+		ConfigKeySaveModelFormat:                 "ORT",
+		ConfigKeyUseORTModelBytesDirectly:        "1",
+		ConfigKeyUseORTModelBytesForInitializers: "1",
+	}
+	for k, v := range want {
+		if entries[k] != v {
+			t.Errorf("entries[%q] = %q, want %q", k, entries[k], v)
+		}
+	}
+
+	if entries := (&SessionOptions{}).modelFormatConfigEntries(); len(entries) != 0 {
+		t.Errorf("Expected no entries for default options, got %v", entries)
+	}
+}
+
+func TestCheckModelFormat(t *testing.T) {
+	if err := checkModelFormat(nil, ModelFormatORT); err != nil {
+		t.Errorf("Unexpected error for nil options: %v", err)
+	}
+
+	err := checkModelFormat(&SessionOptions{ModelFormat: ModelFormatORT}, ModelFormatONNX)
+	if !errors.Is(err, ErrUnsupportedModelFormat) {
+		t.Errorf("Expected ErrUnsupportedModelFormat, got %v", err)
+	}
+
+	if err := checkModelFormat(&SessionOptions{UseORTModelBytesDirectly: true}, ModelFormatONNX); err == nil {
+		t.Error("Expected error for UseORTModelBytesDirectly with ONNX model")
+	}
+
+	if err := checkModelFormat(&SessionOptions{ModelFormat: ModelFormatORT, UseORTModelBytesDirectly: true}, ModelFormatORT); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestWrapModelFormatError(t *testing.T) {
+	minimal := &RuntimeError{Code: ErrorCodeFail, Message: "ONNX format model is not supported in this build."}
+	if err := wrapModelFormatError(minimal, ModelFormatONNX); !errors.Is(err, ErrUnsupportedModelFormat) {
+		t.Errorf("Expected ErrUnsupportedModelFormat, got %v", err)
+	}
+
+	noOrt := &RuntimeError{Code: ErrorCodeFail, Message: "ORT format model is not supported in this build."}
+	err := wrapModelFormatError(noOrt, ModelFormatORT)
+	if !errors.Is(err, ErrUnsupportedModelFormat) {
+		t.Errorf("Expected ErrUnsupportedModelFormat, got %v", err)
+	}
+	var rtErr *RuntimeError
+	if !errors.As(err, &rtErr) {
+		t.Error("Expected wrapped RuntimeError to be preserved")
+	}
+
+	other := &RuntimeError{Code: ErrorCodeInvalidGraph, Message: "bad graph"}
+	if err := wrapModelFormatError(other, ModelFormatONNX); err != other {
+		t.Errorf("Expected unrelated error to pass through, got %v", err)
+	}
+}
+
+func TestSessionModelFormat(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	if got := session.ModelFormat(); got != ModelFormatONNX {
+		t.Errorf("ModelFormat() = %v, want ONNX", got)
+	}
+}
+
+func TestSessionModelFormatMismatch(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	_, err = runtime.NewSession(env, testModelPath(), &SessionOptions{ModelFormat: ModelFormatORT})
+	if !errors.Is(err, ErrUnsupportedModelFormat) {
+		t.Fatalf("Expected ErrUnsupportedModelFormat, got %v", err)
+	}
+}
+
+func TestSessionSaveORTFormat(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	ortPath := filepath.Join(t.TempDir(), "model.ort")
+	session, err := runtime.NewSession(env, testModelPath(), &SessionOptions{
+		GraphOptimization:      GraphOptimizationBasic,
+		OptimizedModelFilePath: ortPath,
+		OptimizedModelFormat:   ModelFormatORT,
+	})
+	if err != nil {
+		t.Skipf("Skipping: library cannot save ORT format models: %v", err)
+	}
+	session.Close()
+
+	data, err := os.ReadFile(ortPath)
+	if err != nil {
+		t.Fatalf("Failed to read ORT model: %v", err)
+	}
+	if DetectModelFormat(data) != ModelFormatORT {
+		t.Fatal("Saved model is not in ORT format")
+	}
+
+	ortSession, err := runtime.NewSessionFromReader(env, bytes.NewReader(data), &SessionOptions{
+		ModelFormat:              ModelFormatORT,
+		UseORTModelBytesDirectly: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to load ORT format model: %v", err)
+	}
+	defer ortSession.Close()
+
+	if got := ortSession.ModelFormat(); got != ModelFormatORT {
+		t.Errorf("ModelFormat() = %v, want ORT", got)
+	}
+	runInference(t, runtime, ortSession)
+}
@@ -0,0 +1,111 @@
+package orttraining
+
+import (
+	"fmt"
+	"runtime"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/bridge"
+)
+
+// CheckpointState holds the trainable parameters and, optionally, the
+// optimizer state of a model. It must stay open while a TrainingSession
+// created from it is in use.
+type CheckpointState struct {
+	ptr     api.OrtCheckpointState
+	runtime *ort.Runtime
+	native  *bridge.Runtime
+	untrack func(leaked bool)
+	cleanup runtime.Cleanup
+}
+
+// LoadCheckpoint loads the checkpoint at path, as written by the Python
+// artifact generation or by CheckpointState.Save.
+func LoadCheckpoint(rt *ort.Runtime, path string) (*CheckpointState, error) {
+	native, err := trainingRuntime(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	pathBytes := append([]byte(path), 0)
+	var ptr api.OrtCheckpointState
+	status := native.Funcs.LoadCheckpoint(&pathBytes[0], &ptr)
+	if err := native.StatusError(status, "LoadCheckpoint"); err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	c := &CheckpointState{
+		ptr:     ptr,
+		runtime: rt,
+		native:  native,
+		untrack: native.Track("checkpoint state"),
+	}
+	c.cleanup = runtime.AddCleanup(c, reclaim, leak{
+		release: func() { native.Funcs.ReleaseCheckpointState(ptr) },
+		untrack: c.untrack,
+	})
+	return c, nil
+}
+
+// Save writes the checkpoint to path. The parameters reflect every
+// OptimizerStep taken by sessions using this checkpoint. If
+// includeOptimizerState is true, the optimizer state is saved as well so
+// training can resume where it left off.
+func (c *CheckpointState) Save(path string, includeOptimizerState bool) error {
+	if c.ptr == 0 {
+		return fmt.Errorf("checkpoint state is closed")
+	}
+
+	pathBytes := append([]byte(path), 0)
+	status := c.native.Funcs.SaveCheckpoint(c.ptr, &pathBytes[0], includeOptimizerState)
+	if err := c.native.StatusError(status, "SaveCheckpoint"); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Close releases the checkpoint state. It is safe to call Close multiple
+// times.
+func (c *CheckpointState) Close() {
+	if c.ptr != 0 {
+		c.cleanup.Stop()
+		c.native.Funcs.ReleaseCheckpointState(c.ptr)
+		c.ptr = 0
+		c.untrack(false)
+	}
+}
+
+// leak is the argument of the cleanup that releases the native object of a
+// wrapper garbage collected without being closed. It must not reference the
+// wrapper, or the wrapper would never be collected.
+type leak struct {
+	release func()
+	untrack func(leaked bool)
+
+	// objects the native object depends on, such as the checkpoint of a
+	// training session, so that they are not reclaimed first
+	keep any
+}
+
+// reclaim releases the native object of a leaked wrapper.
+func reclaim(l leak) {
+	l.release()
+	l.untrack(true)
+}
+
+// trainingRuntime returns the native side of rt, or an error if the loaded
+// library is not a training build.
+func trainingRuntime(rt *ort.Runtime) (*bridge.Runtime, error) {
+	if rt == nil {
+		return nil, fmt.Errorf("runtime is nil")
+	}
+	native, err := bridge.RuntimeOf(rt)
+	if err != nil {
+		return nil, err
+	}
+	if !native.Funcs.HasTrainingAPI() {
+		return nil, fmt.Errorf("%w: the ONNX Runtime library is not a training build", ort.ErrNotImplemented)
+	}
+	return native, nil
+}
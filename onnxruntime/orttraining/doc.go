@@ -0,0 +1,29 @@
+// Package orttraining runs on-device training with the ONNX Runtime training
+// API, for fine-tuning or personalizing a model inside a Go service.
+//
+// Training needs a training build of ONNX Runtime (the onnxruntime-training
+// packages) and the artifacts produced by the Python onnxruntime.training
+// tooling: a checkpoint, a training model, and optionally an eval model and
+// an optimizer model. With a regular build every function returns an error
+// wrapping onnxruntime.ErrNotImplemented.
+//
+// A typical loop:
+//
+//	checkpoint, err := orttraining.LoadCheckpoint(runtime, "artifacts/checkpoint")
+//	defer checkpoint.Close()
+//
+//	session, err := orttraining.NewTrainingSession(runtime, env, checkpoint, orttraining.Artifacts{
+//	    TrainModelPath:     "artifacts/training_model.onnx",
+//	    EvalModelPath:      "artifacts/eval_model.onnx",
+//	    OptimizerModelPath: "artifacts/optimizer_model.onnx",
+//	}, nil)
+//	defer session.Close()
+//
+//	for _, batch := range batches {
+//	    loss, err := session.TrainStep(ctx, []*onnxruntime.Value{batch.Inputs, batch.Labels})
+//	    err = session.OptimizerStep(ctx)
+//	    err = session.LazyResetGrad()
+//	}
+//	err = checkpoint.Save("artifacts/checkpoint", false)
+//	err = session.ExportModelForInferencing("model.onnx", []string{"output"})
+package orttraining
@@ -0,0 +1,315 @@
+package orttraining
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/bridge"
+)
+
+// Artifacts locates the models produced by the Python training artifact
+// generation. Only TrainModelPath is required: without an eval model EvalStep
+// fails, and without an optimizer model OptimizerStep fails.
+type Artifacts struct {
+	TrainModelPath     string
+	EvalModelPath      string
+	OptimizerModelPath string
+}
+
+// TrainingSession trains a model whose parameters live in a CheckpointState.
+//
+// A TrainingSession is not safe for concurrent use.
+type TrainingSession struct {
+	ptr        api.OrtTrainingSession
+	runtime    *ort.Runtime
+	native     *bridge.Runtime
+	checkpoint *CheckpointState
+	untrack    func(leaked bool)
+	cleanup    runtime.Cleanup
+
+	trainInputNames  []string
+	trainOutputNames []string
+	evalInputNames   []string
+	evalOutputNames  []string
+}
+
+// NewTrainingSession creates a training session for the artifacts, reading
+// and updating the parameters in checkpoint. opts configures the underlying
+// sessions, for example execution providers; it may be nil.
+func NewTrainingSession(rt *ort.Runtime, env *ort.Env, checkpoint *CheckpointState, artifacts Artifacts, opts *ort.SessionOptions) (*TrainingSession, error) {
+	native, err := trainingRuntime(rt)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		return nil, fmt.Errorf("env is nil")
+	}
+	if checkpoint == nil || checkpoint.ptr == 0 {
+		return nil, fmt.Errorf("checkpoint state is nil or closed")
+	}
+	if artifacts.TrainModelPath == "" {
+		return nil, fmt.Errorf("training model path cannot be empty")
+	}
+
+	optsPtr, cleanupOpts, err := bridge.SessionOptions(rt, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanupOpts()
+
+	trainPath := append([]byte(artifacts.TrainModelPath), 0)
+	var sessionPtr api.OrtTrainingSession
+	status := native.Funcs.CreateTrainingSession(bridge.Env(env), optsPtr, checkpoint.ptr,
+		&trainPath[0], optionalPath(artifacts.EvalModelPath), optionalPath(artifacts.OptimizerModelPath), &sessionPtr)
+	if err := native.StatusError(status, "CreateTrainingSession"); err != nil {
+		return nil, fmt.Errorf("failed to create training session: %w", err)
+	}
+
+	s := &TrainingSession{
+		ptr:        sessionPtr,
+		runtime:    rt,
+		native:     native,
+		checkpoint: checkpoint,
+		untrack:    native.Track("training session"),
+	}
+	s.cleanup = runtime.AddCleanup(s, reclaim, leak{
+		release: func() { native.Funcs.ReleaseTrainingSession(sessionPtr) },
+		untrack: s.untrack,
+		keep:    checkpoint,
+	})
+
+	type nameSource struct {
+		dst   *[]string
+		count func(api.OrtTrainingSession, *uintptr) api.OrtStatus
+		name  func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus
+		op    string
+	}
+	f := native.Funcs
+	names := []nameSource{
+		{&s.trainInputNames, f.TrainingSessionGetTrainingModelInputCount, f.TrainingSessionGetTrainingModelInputName, "TrainingSessionGetTrainingModelInput"},
+		{&s.trainOutputNames, f.TrainingSessionGetTrainingModelOutputCount, f.TrainingSessionGetTrainingModelOutputName, "TrainingSessionGetTrainingModelOutput"},
+	}
+	if artifacts.EvalModelPath != "" {
+		names = append(names,
+			nameSource{&s.evalInputNames, f.TrainingSessionGetEvalModelInputCount, f.TrainingSessionGetEvalModelInputName, "TrainingSessionGetEvalModelInput"},
+			nameSource{&s.evalOutputNames, f.TrainingSessionGetEvalModelOutputCount, f.TrainingSessionGetEvalModelOutputName, "TrainingSessionGetEvalModelOutput"},
+		)
+	}
+	for _, n := range names {
+		if *n.dst, err = s.modelNames(n.count, n.name, n.op); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// modelNames reads the input or output names of one of the session's models.
+func (s *TrainingSession) modelNames(
+	count func(api.OrtTrainingSession, *uintptr) api.OrtStatus,
+	name func(api.OrtTrainingSession, uintptr, api.OrtAllocator, **byte) api.OrtStatus,
+	op string,
+) ([]string, error) {
+	var n uintptr
+	if err := s.native.StatusError(count(s.ptr, &n), op+"Count"); err != nil {
+		return nil, fmt.Errorf("failed to get model name count: %w", err)
+	}
+	names := make([]string, n)
+	for i := range names {
+		var namePtr *byte
+		if err := s.native.StatusError(name(s.ptr, uintptr(i), s.native.Allocator, &namePtr), op+"Name"); err != nil {
+			return nil, fmt.Errorf("failed to get model name: %w", err)
+		}
+		names[i] = s.native.FreeString(namePtr)
+	}
+	return names, nil
+}
+
+// TrainingInputNames returns the training model's input names, the order
+// in which TrainStep expects its inputs.
+func (s *TrainingSession) TrainingInputNames() []string { return s.trainInputNames }
+
+// TrainingOutputNames returns the training model's output names, typically
+// just the loss.
+func (s *TrainingSession) TrainingOutputNames() []string { return s.trainOutputNames }
+
+// EvalInputNames returns the eval model's input names, or nil without an
+// eval model.
+func (s *TrainingSession) EvalInputNames() []string { return s.evalInputNames }
+
+// EvalOutputNames returns the eval model's output names, or nil without an
+// eval model.
+func (s *TrainingSession) EvalOutputNames() []string { return s.evalOutputNames }
+
+// TrainStep runs a forward and backward pass over one batch, accumulating
+// gradients, and returns the training model's outputs in
+// TrainingOutputNames order. inputs are given in TrainingInputNames order.
+// The parameters do not change until OptimizerStep. The caller must close
+// the returned values.
+func (s *TrainingSession) TrainStep(ctx context.Context, inputs []*ort.Value) ([]*ort.Value, error) {
+	return s.step(ctx, inputs, false)
+}
+
+// EvalStep runs the eval model over one batch without computing gradients
+// and returns its outputs in EvalOutputNames order. inputs are given in
+// EvalInputNames order. The caller must close the returned values.
+func (s *TrainingSession) EvalStep(ctx context.Context, inputs []*ort.Value) ([]*ort.Value, error) {
+	return s.step(ctx, inputs, true)
+}
+
+// step runs EvalStep if eval is set and TrainStep otherwise.
+func (s *TrainingSession) step(ctx context.Context, inputs []*ort.Value, eval bool) ([]*ort.Value, error) {
+	if s.ptr == 0 {
+		return nil, fmt.Errorf("training session is closed")
+	}
+
+	fn, op, numOutputs := s.native.Funcs.TrainStep, "TrainStep", len(s.trainOutputNames)
+	if eval {
+		fn, op, numOutputs = s.native.Funcs.EvalStep, "EvalStep", len(s.evalOutputNames)
+	}
+
+	runOpts, err := bridge.NewRunOptions(s.runtime, ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer runOpts.Close()
+
+	inputPtrs := make([]api.OrtValue, len(inputs))
+	for i, input := range inputs {
+		if inputPtrs[i] = bridge.Value(input); inputPtrs[i] == 0 {
+			return nil, fmt.Errorf("input %d is nil or closed", i)
+		}
+	}
+	outputPtrs := make([]api.OrtValue, numOutputs)
+
+	var inputsArg, outputsArg *api.OrtValue
+	if len(inputPtrs) > 0 {
+		inputsArg = &inputPtrs[0]
+	}
+	if len(outputPtrs) > 0 {
+		outputsArg = &outputPtrs[0]
+	}
+	status := fn(s.ptr, runOpts.Ptr, uintptr(len(inputPtrs)), inputsArg, uintptr(len(outputPtrs)), outputsArg)
+	if err := s.native.StatusError(status, op); err != nil {
+		return nil, runOpts.WrapError(fmt.Errorf("failed to run %s: %w", op, err))
+	}
+
+	outputs := make([]*ort.Value, len(outputPtrs))
+	for i, ptr := range outputPtrs {
+		outputs[i] = bridge.NewValue(s.runtime, ptr).(*ort.Value)
+	}
+	return outputs, nil
+}
+
+// OptimizerStep updates the parameters from the gradients accumulated by
+// TrainStep. It requires an optimizer model.
+func (s *TrainingSession) OptimizerStep(ctx context.Context) error {
+	if s.ptr == 0 {
+		return fmt.Errorf("training session is closed")
+	}
+
+	runOpts, err := bridge.NewRunOptions(s.runtime, ctx)
+	if err != nil {
+		return err
+	}
+	defer runOpts.Close()
+
+	status := s.native.Funcs.OptimizerStep(s.ptr, runOpts.Ptr)
+	if err := s.native.StatusError(status, "OptimizerStep"); err != nil {
+		return runOpts.WrapError(fmt.Errorf("failed to run optimizer step: %w", err))
+	}
+	return nil
+}
+
+// LazyResetGrad resets the gradients to zero before the next TrainStep.
+// Call it after OptimizerStep; gradients otherwise keep accumulating, which
+// can be used for gradient accumulation over several batches.
+func (s *TrainingSession) LazyResetGrad() error {
+	if s.ptr == 0 {
+		return fmt.Errorf("training session is closed")
+	}
+
+	status := s.native.Funcs.LazyResetGrad(s.ptr)
+	if err := s.native.StatusError(status, "LazyResetGrad"); err != nil {
+		return fmt.Errorf("failed to reset gradients: %w", err)
+	}
+	return nil
+}
+
+// SetLearningRate sets the optimizer's learning rate.
+func (s *TrainingSession) SetLearningRate(learningRate float32) error {
+	if s.ptr == 0 {
+		return fmt.Errorf("training session is closed")
+	}
+
+	status := s.native.Funcs.SetLearningRate(s.ptr, learningRate)
+	if err := s.native.StatusError(status, "SetLearningRate"); err != nil {
+		return fmt.Errorf("failed to set learning rate: %w", err)
+	}
+	return nil
+}
+
+// LearningRate returns the optimizer's current learning rate.
+func (s *TrainingSession) LearningRate() (float32, error) {
+	if s.ptr == 0 {
+		return 0, fmt.Errorf("training session is closed")
+	}
+
+	var learningRate float32
+	status := s.native.Funcs.GetLearningRate(s.ptr, &learningRate)
+	if err := s.native.StatusError(status, "GetLearningRate"); err != nil {
+		return 0, fmt.Errorf("failed to get learning rate: %w", err)
+	}
+	return learningRate, nil
+}
+
+// ExportModelForInferencing writes an inference model with the trained
+// parameters to path, keeping only the graph outputs named in outputNames.
+// It requires an eval model, from which the inference graph is derived. The
+// result loads with onnxruntime.Runtime.NewSession on any build.
+func (s *TrainingSession) ExportModelForInferencing(path string, outputNames []string) error {
+	if s.ptr == 0 {
+		return fmt.Errorf("training session is closed")
+	}
+	if len(outputNames) == 0 {
+		return fmt.Errorf("at least one output name is required")
+	}
+
+	pathBytes := append([]byte(path), 0)
+	nameBytes := make([][]byte, len(outputNames))
+	namePtrs := make([]*byte, len(outputNames))
+	for i, name := range outputNames {
+		nameBytes[i] = append([]byte(name), 0)
+		namePtrs[i] = &nameBytes[i][0]
+	}
+
+	status := s.native.Funcs.ExportModelForInferencing(s.ptr, &pathBytes[0], uintptr(len(namePtrs)), &namePtrs[0])
+	if err := s.native.StatusError(status, "ExportModelForInferencing"); err != nil {
+		return fmt.Errorf("failed to export model for inferencing: %w", err)
+	}
+	return nil
+}
+
+// Close releases the training session. The checkpoint state stays open. It
+// is safe to call Close multiple times.
+func (s *TrainingSession) Close() {
+	if s.ptr != 0 {
+		s.cleanup.Stop()
+		s.native.Funcs.ReleaseTrainingSession(s.ptr)
+		s.ptr = 0
+		s.untrack(false)
+	}
+	s.checkpoint = nil
+}
+
+// optionalPath returns a C string for path, or nil if it is empty.
+func optionalPath(path string) *byte {
+	if path == "" {
+		return nil
+	}
+	return &append([]byte(path), 0)[0]
+}
@@ -0,0 +1,131 @@
+package orttraining
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// trainingArtifactsDir returns the directory holding checkpoint,
+// training_model.onnx, eval_model.onnx and optimizer_model.onnx generated by
+// onnxruntime.training.artifacts, skipping the test if it is not set.
+func trainingArtifactsDir(t *testing.T, runtime *ort.Runtime) string {
+	t.Helper()
+
+	dir := os.Getenv("ORT_TRAINING_ARTIFACTS")
+	if dir == "" {
+		t.Skip("Skipping: ORT_TRAINING_ARTIFACTS not set")
+	}
+	checkpoint, err := LoadCheckpoint(runtime, filepath.Join(dir, "checkpoint"))
+	if errors.Is(err, ort.ErrNotImplemented) {
+		t.Skip("Skipping: ONNX Runtime library is not a training build")
+	}
+	if err == nil {
+		checkpoint.Close()
+	}
+	return dir
+}
+
+func TestLoadCheckpointNilRuntime(t *testing.T) {
+	if _, err := LoadCheckpoint(nil, "checkpoint"); err == nil {
+		t.Error("Expected an error for a nil runtime")
+	}
+}
+
+func TestLoadCheckpointClosedRuntime(t *testing.T) {
+	runtime := newTestRuntime(t)
+	runtime.Close()
+
+	if _, err := LoadCheckpoint(runtime, "checkpoint"); err == nil {
+		t.Error("Expected an error for a closed runtime")
+	}
+}
+
+func TestLoadCheckpointMissing(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	_, err := LoadCheckpoint(runtime, filepath.Join(t.TempDir(), "missing"))
+	if err == nil {
+		t.Fatal("Expected an error for a missing checkpoint")
+	}
+	// Regular builds have no training API; training builds fail to read the file.
+	var runtimeErr *ort.RuntimeError
+	if !errors.Is(err, ort.ErrNotImplemented) && !errors.As(err, &runtimeErr) {
+		t.Errorf("Expected ErrNotImplemented or a RuntimeError, got %v", err)
+	}
+}
+
+func TestTrainingLoop(t *testing.T) {
+	runtime := newTestRuntime(t)
+	dir := trainingArtifactsDir(t, runtime)
+
+	env, err := runtime.NewEnv("test", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	checkpoint, err := LoadCheckpoint(runtime, filepath.Join(dir, "checkpoint"))
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	defer checkpoint.Close()
+
+	session, err := NewTrainingSession(runtime, env, checkpoint, Artifacts{
+		TrainModelPath:     filepath.Join(dir, "training_model.onnx"),
+		EvalModelPath:      filepath.Join(dir, "eval_model.onnx"),
+		OptimizerModelPath: filepath.Join(dir, "optimizer_model.onnx"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewTrainingSession failed: %v", err)
+	}
+	defer session.Close()
+
+	if len(session.TrainingInputNames()) == 0 || len(session.TrainingOutputNames()) == 0 {
+		t.Fatalf("Expected training model inputs and outputs, got %v and %v",
+			session.TrainingInputNames(), session.TrainingOutputNames())
+	}
+
+	if err := session.SetLearningRate(0.01); err != nil {
+		t.Fatalf("SetLearningRate failed: %v", err)
+	}
+	if lr, err := session.LearningRate(); err != nil || lr != 0.01 {
+		t.Errorf("Expected learning rate 0.01, got %v (err %v)", lr, err)
+	}
+	if err := session.LazyResetGrad(); err != nil {
+		t.Errorf("LazyResetGrad failed: %v", err)
+	}
+
+	exported := filepath.Join(t.TempDir(), "inference.onnx")
+	if err := session.ExportModelForInferencing(exported, session.EvalOutputNames()[:1]); err != nil {
+		t.Fatalf("ExportModelForInferencing failed: %v", err)
+	}
+	if _, err := runtime.NewSession(env, exported, nil); err != nil {
+		t.Errorf("Failed to load the exported model: %v", err)
+	}
+
+	if err := checkpoint.Save(filepath.Join(t.TempDir(), "checkpoint"), true); err != nil {
+		t.Errorf("Save failed: %v", err)
+	}
+}
+
+func TestClosedTrainingSession(t *testing.T) {
+	s := &TrainingSession{}
+	s.Close()
+
+	if _, err := s.TrainStep(t.Context(), nil); err == nil {
+		t.Error("Expected TrainStep to fail on a closed session")
+	}
+	if err := s.OptimizerStep(t.Context()); err == nil {
+		t.Error("Expected OptimizerStep to fail on a closed session")
+	}
+	if err := s.LazyResetGrad(); err == nil {
+		t.Error("Expected LazyResetGrad to fail on a closed session")
+	}
+	if err := s.ExportModelForInferencing("model.onnx", []string{"output"}); err == nil {
+		t.Error("Expected ExportModelForInferencing to fail on a closed session")
+	}
+}
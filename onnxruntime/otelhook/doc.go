@@ -0,0 +1,7 @@
+// Package otelhook traces ONNX Runtime inference with OpenTelemetry.
+//
+// The Hook in this package starts a span for every SessionPool.Run, parented
+// to the span in the context passed to Run, so traces continue through the
+// inference layer. It is a module of its own, so importing onnxruntime does
+// not bring in OpenTelemetry.
+package otelhook
@@ -0,0 +1,131 @@
+package otelhook
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanName is the name of the span recorded for each inference.
+const SpanName = "onnxruntime.Run"
+
+// Attribute keys set on inference spans.
+const (
+	AttrModel       = attribute.Key("onnxruntime.model")
+	AttrInputs      = attribute.Key("onnxruntime.inputs")
+	AttrOutputs     = attribute.Key("onnxruntime.outputs")
+	AttrBatchSize   = attribute.Key("onnxruntime.batch_size")
+	AttrDurationMs  = attribute.Key("onnxruntime.duration_ms")
+	AttrFLOPs       = attribute.Key("onnxruntime.cost.flops")
+	AttrMemoryBytes = attribute.Key("onnxruntime.cost.memory_bytes")
+)
+
+// inputShapePrefix prefixes per-input shape attributes, e.g.
+// "onnxruntime.input.pixel_values.shape".
+const inputShapePrefix = "onnxruntime.input."
+
+// Hook is an [ort.Hook] that records a span per inference.
+//
+// Example:
+//
+//	pool, _ := onnxruntime.NewSessionPool(runtime, env, modelData, 4, &onnxruntime.PoolConfig{
+//	    ModelName: "resnet50",
+//	    Hooks: []onnxruntime.Hook{
+//	        otelhook.New(otel.Tracer("inference")),
+//	    },
+//	})
+type Hook struct {
+	tracer      trace.Tracer
+	inputShapes bool
+
+	// spans started in BeforeRun, keyed by the RunInfo passed to both calls
+	spans sync.Map
+}
+
+// Option configures a Hook.
+type Option func(*Hook)
+
+// WithInputShapes records the shape of every input tensor as an attribute.
+// Shapes are read from the native tensors on each run, so this is off by
+// default.
+func WithInputShapes() Option {
+	return func(h *Hook) {
+		h.inputShapes = true
+	}
+}
+
+// New creates a Hook that records spans with tracer.
+func New(tracer trace.Tracer, opts ...Option) *Hook {
+	h := &Hook{tracer: tracer}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// BeforeRun starts the span for the run.
+func (h *Hook) BeforeRun(info *ort.RunInfo) {
+	ctx := info.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attrs := []attribute.KeyValue{
+		AttrInputs.StringSlice(sorted(info.InputNames)),
+		AttrBatchSize.Int(max(info.BatchSize, 1)),
+	}
+	if info.ModelName != "" {
+		attrs = append(attrs, AttrModel.String(info.ModelName))
+	}
+	if info.Cost != nil {
+		attrs = append(attrs,
+			AttrFLOPs.Int64(info.Cost.FLOPs),
+			AttrMemoryBytes.Int64(info.Cost.MemoryBytes),
+		)
+	}
+	if h.inputShapes {
+		for name, v := range info.Inputs {
+			if shape, err := v.GetTensorShape(); err == nil {
+				attrs = append(attrs, attribute.Int64Slice(inputShapePrefix+name+".shape", shape))
+			}
+		}
+	}
+
+	_, span := h.tracer.Start(ctx, SpanName,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...),
+	)
+	h.spans.Store(info, span)
+}
+
+// AfterRun records the outcome and ends the span.
+func (h *Hook) AfterRun(info *ort.RunInfo) {
+	v, ok := h.spans.LoadAndDelete(info)
+	if !ok {
+		return
+	}
+	span := v.(trace.Span)
+
+	span.SetAttributes(
+		AttrOutputs.StringSlice(sorted(info.OutputNames)),
+		AttrDurationMs.Float64(float64(info.Duration.Nanoseconds())/1e6),
+	)
+	if info.Error != nil {
+		span.RecordError(info.Error)
+		span.SetStatus(codes.Error, info.Error.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
+
+func sorted(names []string) []string {
+	names = slices.Clone(names)
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package otelhook
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder(t *testing.T) (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return recorder, provider
+}
+
+func attrMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, kv := range attrs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestHookRecordsSpan(t *testing.T) {
+	recorder, provider := newRecorder(t)
+	hook := New(provider.Tracer("test"))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	info := &ort.RunInfo{
+		Context:    ctx,
+		ModelName:  "model.onnx",
+		InputNames: []string{"b", "a"},
+		BatchSize:  4,
+		Cost:       &ort.RunCost{FLOPs: 1000, MemoryBytes: 64},
+	}
+	hook.BeforeRun(info)
+	info.Duration = 1500 * time.Microsecond
+	info.OutputNames = []string{"logits"}
+	hook.AfterRun(info)
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 ended spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != SpanName {
+		t.Errorf("Expected span name %q, got %q", SpanName, span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Expected inference span to be a child of the request span")
+	}
+	if span.Status().Code != codes.Ok {
+		t.Errorf("Expected Ok status, got %v", span.Status())
+	}
+
+	attrs := attrMap(span.Attributes())
+	if got := attrs[AttrModel].AsString(); got != "model.onnx" {
+		t.Errorf("Expected model attribute, got %q", got)
+	}
+	if got := attrs[AttrInputs].AsStringSlice(); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected sorted inputs [a b], got %v", got)
+	}
+	if got := attrs[AttrOutputs].AsStringSlice(); !slices.Equal(got, []string{"logits"}) {
+		t.Errorf("Expected outputs [logits], got %v", got)
+	}
+	if got := attrs[AttrBatchSize].AsInt64(); got != 4 {
+		t.Errorf("Expected batch size 4, got %d", got)
+	}
+	if got := attrs[AttrDurationMs].AsFloat64(); got != 1.5 {
+		t.Errorf("Expected duration 1.5ms, got %v", got)
+	}
+	if got := attrs[AttrFLOPs].AsInt64(); got != 1000 {
+		t.Errorf("Expected 1000 FLOPs, got %d", got)
+	}
+}
+
+func TestHookRecordsError(t *testing.T) {
+	recorder, provider := newRecorder(t)
+	hook := New(provider.Tracer("test"))
+
+	info := &ort.RunInfo{InputNames: []string{"input"}}
+	hook.BeforeRun(info)
+	info.Error = errors.New("boom")
+	hook.AfterRun(info)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 ended span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Status().Code != codes.Error || span.Status().Description != "boom" {
+		t.Errorf("Expected error status, got %v", span.Status())
+	}
+	if len(span.Events()) != 1 || span.Events()[0].Name != "exception" {
+		t.Errorf("Expected a recorded exception event, got %v", span.Events())
+	}
+	if _, ok := attrMap(span.Attributes())[AttrModel]; ok {
+		t.Error("Expected no model attribute when ModelName is empty")
+	}
+}
+
+func TestHookAfterRunWithoutBeforeRun(t *testing.T) {
+	recorder, provider := newRecorder(t)
+	hook := New(provider.Tracer("test"))
+
+	hook.AfterRun(&ort.RunInfo{})
+	if n := len(recorder.Ended()); n != 0 {
+		t.Errorf("Expected no spans, got %d", n)
+	}
+}
+
+func TestHookInputShapes(t *testing.T) {
+	runtime := newTestRuntime(t)
+	recorder, provider := newRecorder(t)
+	hook := New(provider.Tracer("test"), WithInputShapes())
+
+	input, err := ort.NewTensorValue(runtime, make([]float32, 6), []int64{2, 3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	info := &ort.RunInfo{
+		Inputs:     map[string]*ort.Value{"input": input},
+		InputNames: []string{"input"},
+	}
+	hook.BeforeRun(info)
+	hook.AfterRun(info)
+
+	attrs := attrMap(recorder.Ended()[0].Attributes())
+	if got := attrs["onnxruntime.input.input.shape"].AsInt64Slice(); !slices.Equal(got, []int64{2, 3}) {
+		t.Errorf("Expected input shape [2 3], got %v", got)
+	}
+}
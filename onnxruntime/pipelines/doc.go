@@ -0,0 +1,17 @@
+// Package pipelines provides task-level wrappers that combine preprocessing,
+// inference, and postprocessing for common model types.
+//
+// A TextClassifier tokenizes text, pads and batches the token IDs, runs a
+// sequence-classification model (BERT, RoBERTa, DistilBERT, and similar),
+// and turns the logits into labelled probabilities. Tokenization is supplied
+// by the caller through the Tokenizer interface, so this package does not
+// depend on any particular tokenizer library.
+//
+// An ImageClassifier resizes, crops, and normalizes Go images into the
+// model's input layout, runs an image-classification model, and returns the
+// top-k classes.
+//
+// An Embedder runs a sentence-transformer model and pools its token
+// embeddings (mean, CLS, or max) into one optionally L2-normalized vector
+// per text, ready for a vector index.
+package pipelines
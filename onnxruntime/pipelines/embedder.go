@@ -0,0 +1,184 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Pooling reduces per-token embeddings to a single vector.
+type Pooling int
+
+const (
+	// PoolingMean averages the embeddings of real (unmasked) tokens. This is
+	// what most sentence-transformers models are trained with.
+	PoolingMean Pooling = iota
+	// PoolingCLS takes the embedding of the first token.
+	PoolingCLS
+	// PoolingMax takes the element-wise maximum over real tokens.
+	PoolingMax
+)
+
+// String returns the pooling strategy name.
+func (p Pooling) String() string {
+	switch p {
+	case PoolingMean:
+		return "mean"
+	case PoolingCLS:
+		return "cls"
+	case PoolingMax:
+		return "max"
+	default:
+		return fmt.Sprintf("Pooling(%d)", int(p))
+	}
+}
+
+// Embedder turns text into fixed-size vectors with a sentence-transformer
+// model. It is safe for concurrent use if the tokenizer is.
+type Embedder struct {
+	textModel
+	config
+}
+
+// NewEmbedder creates an embedder for model, which takes the same inputs as
+// a text classifier and outputs either token embeddings shaped
+// [batch, sequence, hidden] (usually last_hidden_state), which are pooled,
+// or already pooled embeddings shaped [batch, hidden], which are used as is.
+//
+// Example:
+//
+//	model, _ := onnxruntime.LoadModelFromFile("all-MiniLM-L6-v2.onnx", nil)
+//	embedder, err := pipelines.NewEmbedder(model, tokenizer,
+//	    pipelines.WithMaxLength(256), pipelines.WithL2Normalize())
+//	vectors, err := embedder.Embed(ctx, []string{"first document", "second document"})
+func NewEmbedder(model *ort.Model, tokenizer Tokenizer, opts ...Option) (*Embedder, error) {
+	e := &Embedder{config: newConfig(opts)}
+	switch e.pooling {
+	case PoolingMean, PoolingCLS, PoolingMax:
+	default:
+		return nil, fmt.Errorf("unsupported pooling %v", e.pooling)
+	}
+
+	var err error
+	if e.textModel, err = newTextModel(model, tokenizer, &e.config); err != nil {
+		return nil, err
+	}
+	if e.outputName, err = selectOutput(model, e.outputName, "last_hidden_state"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Embed returns one embedding per text, in order. Texts are truncated to
+// the maximum length and run in batches.
+func (e *Embedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.batchSize {
+		batch := texts[start:min(start+e.batchSize, len(texts))]
+		vectors, err := e.embedBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, vectors...)
+	}
+	return embeddings, nil
+}
+
+func (e *Embedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	outputs, batch, err := e.run(ctx, texts, &e.config)
+	if err != nil {
+		return nil, err
+	}
+	defer closeValues(outputs)
+
+	data, shape, err := ort.GetTensorData[float32](outputs[e.outputName])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", e.outputName, err)
+	}
+	if len(shape) == 0 || shape[0] != int64(len(texts)) {
+		return nil, fmt.Errorf("expected %s with batch size %d, got shape %v", e.outputName, len(texts), shape)
+	}
+
+	embeddings := make([][]float32, len(texts))
+	switch len(shape) {
+	case 2:
+		hidden := int(shape[1])
+		for i := range embeddings {
+			embeddings[i] = append([]float32(nil), data[i*hidden:(i+1)*hidden]...)
+		}
+	case 3:
+		if shape[1] != int64(batch.seqLen) {
+			return nil, fmt.Errorf("expected %s sequence length %d, got shape %v", e.outputName, batch.seqLen, shape)
+		}
+		embeddings = pool(data, batch.mask, len(texts), batch.seqLen, int(shape[2]), e.pooling)
+	default:
+		return nil, fmt.Errorf("expected %s of rank 2 or 3, got shape %v", e.outputName, shape)
+	}
+
+	if e.normalize {
+		for _, v := range embeddings {
+			l2Normalize(v)
+		}
+	}
+	return embeddings, nil
+}
+
+// pool reduces row-major [batch, seqLen, hidden] token embeddings to
+// [batch][hidden], considering only tokens whose mask is non-zero.
+func pool(tokens []float32, mask []int64, batch, seqLen, hidden int, p Pooling) [][]float32 {
+	out := make([][]float32, batch)
+	for i := range out {
+		v := make([]float32, hidden)
+		out[i] = v
+		row := tokens[i*seqLen*hidden : (i+1)*seqLen*hidden]
+
+		if p == PoolingCLS {
+			copy(v, row[:hidden])
+			continue
+		}
+
+		count := 0
+		for j := range seqLen {
+			if mask[i*seqLen+j] == 0 {
+				continue
+			}
+			token := row[j*hidden : (j+1)*hidden]
+			switch {
+			case p == PoolingMean:
+				for k, x := range token {
+					v[k] += x
+				}
+			case count == 0:
+				copy(v, token)
+			default:
+				for k, x := range token {
+					v[k] = max(v[k], x)
+				}
+			}
+			count++
+		}
+		if p == PoolingMean && count > 0 {
+			for k := range v {
+				v[k] /= float32(count)
+			}
+		}
+	}
+	return out
+}
+
+// l2Normalize scales v to unit length in place. Zero vectors are unchanged.
+func l2Normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+}
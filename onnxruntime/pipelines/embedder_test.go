@@ -0,0 +1,81 @@
+package pipelines
+
+import (
+	"math"
+	"os"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func TestPool(t *testing.T) {
+	// Two texts, three tokens, two hidden units; the second text has one
+	// padding token whose embedding must be ignored.
+	tokens := []float32{
+		1, 2, 3, 4, 5, 0,
+		2, 2, 4, 0, 100, 100,
+	}
+	mask := []int64{1, 1, 1, 1, 1, 0}
+
+	tests := []struct {
+		pooling Pooling
+		want    [][]float32
+	}{
+		{PoolingMean, [][]float32{{3, 2}, {3, 1}}},
+		{PoolingCLS, [][]float32{{1, 2}, {2, 2}}},
+		{PoolingMax, [][]float32{{5, 4}, {4, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pooling.String(), func(t *testing.T) {
+			got := pool(tokens, mask, 2, 3, 2, tt.pooling)
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPoolMaxNegative(t *testing.T) {
+	got := pool([]float32{-3, -1, -2, -5}, []int64{1, 1}, 1, 2, 2, PoolingMax)
+	if want := []float32{-2, -1}; !slices.Equal(got[0], want) {
+		t.Errorf("Expected %v, got %v", want, got[0])
+	}
+}
+
+func TestL2Normalize(t *testing.T) {
+	v := []float32{3, 4}
+	l2Normalize(v)
+	if math.Abs(float64(v[0]-0.6)) > 1e-6 || math.Abs(float64(v[1]-0.8)) > 1e-6 {
+		t.Errorf("Expected [0.6 0.8], got %v", v)
+	}
+
+	zero := []float32{0, 0}
+	l2Normalize(zero)
+	if !slices.Equal(zero, []float32{0, 0}) {
+		t.Errorf("Expected zero vector unchanged, got %v", zero)
+	}
+}
+
+func TestNewEmbedderArgs(t *testing.T) {
+	if _, err := NewEmbedder(nil, splitTokenizer{}); err == nil {
+		t.Error("Expected error for nil model")
+	}
+	if _, err := NewEmbedder(nil, splitTokenizer{}, WithPooling(Pooling(9))); err == nil {
+		t.Error("Expected error for unknown pooling")
+	}
+}
+
+func TestNewEmbedderUnsupportedModel(t *testing.T) {
+	model, err := ort.LoadModelFromFile("../internal/tests/testdata/model.onnx", &ort.ModelConfig{
+		LibraryPath: os.Getenv("ONNXRUNTIME_LIB_PATH"),
+	})
+	if err != nil {
+		t.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	defer model.Close()
+
+	if _, err := NewEmbedder(model, splitTokenizer{}); err == nil {
+		t.Error("Expected error for a model without input_ids")
+	}
+}
@@ -0,0 +1,208 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"image"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/imagetensor"
+)
+
+// Class is one entry of an image classifier's top-k result.
+type Class struct {
+	Index int
+	Label string
+	Score float32
+}
+
+// ImageClassifier runs an image-classification model (ResNet, EfficientNet,
+// ViT, MobileNet, and similar) over Go images. It is safe for concurrent use.
+type ImageClassifier struct {
+	model  *ort.Model
+	labels []string
+	config
+
+	inputName  string
+	preprocess imagetensor.InputOptions
+}
+
+// NewImageClassifier creates a classifier for model, which must take a
+// single float32 image tensor of rank 4 and produce scores shaped
+// [batch, len(labels)]. If labels is nil, labels are named LABEL_0,
+// LABEL_1, and so on.
+//
+// Preprocessing defaults follow the ImageNet evaluation recipe, which most
+// published classifiers expect: resize the shorter side to 256/224 of the
+// crop, center crop to the model's input size (224x224 if dynamic), scale
+// to [0, 1], and normalize with ImageNet mean and std. The tensor layout
+// and channel count are read from the model. Check these against the
+// model's documentation; a mismatch produces confident but wrong results
+// rather than an error. See WithResize, WithCrop, WithNormalization,
+// WithLayout, and WithBGR.
+//
+// Example:
+//
+//	model, _ := onnxruntime.LoadModelFromFile("resnet50.onnx", nil)
+//	classifier, err := pipelines.NewImageClassifier(model, imagenetLabels, pipelines.WithTopK(3))
+//	top, err := classifier.Classify(ctx, img)
+//	fmt.Printf("%s (%.1f%%)\n", top[0].Label, top[0].Score*100)
+func NewImageClassifier(model *ort.Model, labels []string, opts ...Option) (*ImageClassifier, error) {
+	if model == nil {
+		return nil, fmt.Errorf("model is required")
+	}
+
+	c := &ImageClassifier{
+		model:  model,
+		labels: labels,
+		config: newConfig(opts),
+	}
+	if c.batchSize <= 0 || c.topK <= 0 {
+		return nil, fmt.Errorf("batch size and top-k must be positive")
+	}
+
+	infos, err := model.Session().GetInputInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get input info: %w", err)
+	}
+	if len(infos) != 1 {
+		return nil, fmt.Errorf("model must have one image input, has %d inputs", len(infos))
+	}
+	info := infos[0]
+	if info.TensorInfo == nil || len(info.TensorInfo.Shape) != 4 {
+		return nil, fmt.Errorf("input %q must be a rank-4 image tensor", info.Name)
+	}
+	if info.TensorInfo.ElementType != ort.ONNXTensorElementDataTypeFloat {
+		return nil, fmt.Errorf("input %q must be float32", info.Name)
+	}
+	c.inputName = info.Name
+
+	c.preprocess, err = imageInputOptions(info.TensorInfo.Shape, c.image)
+	if err != nil {
+		return nil, fmt.Errorf("input %q: %w", info.Name, err)
+	}
+
+	if c.outputName, err = selectOutput(model, c.outputName, "logits"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// imageInputOptions derives preprocessing from the declared input shape
+// and explicit overrides.
+func imageInputOptions(shape []int64, cfg imageConfig) (imagetensor.InputOptions, error) {
+	isChannels := func(d int64) bool { return d == 1 || d == 3 }
+
+	layout := imagetensor.LayoutNCHW
+	if cfg.layoutSet {
+		layout = cfg.layout
+	} else if isChannels(shape[3]) && !isChannels(shape[1]) {
+		layout = imagetensor.LayoutNHWC
+	}
+
+	channels, height, width := shape[1], shape[2], shape[3]
+	if layout == imagetensor.LayoutNHWC {
+		height, width, channels = shape[1], shape[2], shape[3]
+	}
+	if channels > 0 && !isChannels(channels) {
+		return imagetensor.InputOptions{}, fmt.Errorf("expected 1 or 3 channels in %s layout, got shape %v", layout, shape)
+	}
+
+	o := imagetensor.InputOptions{
+		Layout:    layout,
+		Width:     224,
+		Height:    224,
+		Grayscale: channels == 1,
+		BGR:       cfg.bgr,
+		Mean:      imagetensor.ImageNetMean,
+		Std:       imagetensor.ImageNetStd,
+	}
+	if width > 0 && height > 0 {
+		o.Width, o.Height = int(width), int(height)
+	}
+	if cfg.width > 0 && cfg.height > 0 {
+		if (width > 0 && int64(cfg.width) != width) || (height > 0 && int64(cfg.height) != height) {
+			return imagetensor.InputOptions{}, fmt.Errorf("crop %dx%d does not match the model's fixed size %dx%d", cfg.width, cfg.height, width, height)
+		}
+		o.Width, o.Height = cfg.width, cfg.height
+	}
+	o.ResizeShorter = min(o.Width, o.Height) * 256 / 224
+	if cfg.resizeShorter > 0 {
+		o.ResizeShorter = cfg.resizeShorter
+	}
+	if cfg.normalize {
+		o.Mean, o.Std = cfg.mean, cfg.std
+	}
+	if o.Grayscale && !cfg.normalize {
+		// ImageNet statistics are per RGB channel; use their average.
+		o.Mean, o.Std = []float32{0.449}, []float32{0.226}
+	}
+	return o, nil
+}
+
+// Classify returns the top-k classes for img, most probable first.
+func (c *ImageClassifier) Classify(ctx context.Context, img image.Image) ([]Class, error) {
+	results, err := c.ClassifyBatch(ctx, []image.Image{img})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// ClassifyBatch returns the top-k classes for each image, in order.
+func (c *ImageClassifier) ClassifyBatch(ctx context.Context, images []image.Image) ([][]Class, error) {
+	results := make([][]Class, 0, len(images))
+	for start := 0; start < len(images); start += c.batchSize {
+		batch := images[start:min(start+c.batchSize, len(images))]
+		classes, err := c.classifyBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, classes...)
+	}
+	return results, nil
+}
+
+func (c *ImageClassifier) classifyBatch(ctx context.Context, images []image.Image) ([][]Class, error) {
+	input, err := imagetensor.FromImages(c.model.Runtime(), images, &c.preprocess)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preprocess images: %w", err)
+	}
+	defer input.Close()
+
+	outputs, err := c.model.Run(ctx, map[string]*ort.Value{c.inputName: input}, ort.WithOutputNames(c.outputName))
+	if err != nil {
+		return nil, err
+	}
+	defer closeValues(outputs)
+
+	scores, shape, err := ort.GetTensorData[float32](outputs[c.outputName])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", c.outputName, err)
+	}
+	if len(shape) != 2 || shape[0] != int64(len(images)) {
+		return nil, fmt.Errorf("expected %s shaped [%d, classes], got %v", c.outputName, len(images), shape)
+	}
+	numClasses := int(shape[1])
+	if c.labels != nil && numClasses != len(c.labels) {
+		return nil, fmt.Errorf("model has %d classes, classifier was given %d labels", numClasses, len(c.labels))
+	}
+
+	results := make([][]Class, len(images))
+	for i := range results {
+		results[i] = c.top(scores[i*numClasses : (i+1)*numClasses])
+	}
+	return results, nil
+}
+
+func (c *ImageClassifier) top(scores []float32) []Class {
+	if !c.probabilities {
+		scores = Softmax(scores)
+	}
+	indices := topK(scores, c.topK)
+	classes := make([]Class, len(indices))
+	for i, idx := range indices {
+		classes[i] = Class{Index: idx, Label: labelFor(c.labels, idx), Score: scores[idx]}
+	}
+	return classes
+}
@@ -0,0 +1,120 @@
+package pipelines
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/imagetensor"
+)
+
+func TestImageInputOptionsDefaults(t *testing.T) {
+	o, err := imageInputOptions([]int64{-1, 3, 224, 224}, imageConfig{})
+	if err != nil {
+		t.Fatalf("imageInputOptions failed: %v", err)
+	}
+	if o.Layout != imagetensor.LayoutNCHW || o.Width != 224 || o.Height != 224 || o.ResizeShorter != 256 {
+		t.Errorf("Unexpected defaults %+v", o)
+	}
+	if !slices.Equal(o.Mean, imagetensor.ImageNetMean) || !slices.Equal(o.Std, imagetensor.ImageNetStd) {
+		t.Errorf("Expected ImageNet normalization, got mean %v std %v", o.Mean, o.Std)
+	}
+
+	// Dynamic spatial dimensions fall back to 224.
+	o, err = imageInputOptions([]int64{-1, 3, -1, -1}, imageConfig{})
+	if err != nil {
+		t.Fatalf("imageInputOptions failed: %v", err)
+	}
+	if o.Width != 224 || o.Height != 224 {
+		t.Errorf("Expected 224x224 for dynamic size, got %dx%d", o.Width, o.Height)
+	}
+}
+
+func TestImageInputOptionsLayout(t *testing.T) {
+	o, err := imageInputOptions([]int64{1, 299, 299, 3}, imageConfig{})
+	if err != nil {
+		t.Fatalf("imageInputOptions failed: %v", err)
+	}
+	if o.Layout != imagetensor.LayoutNHWC || o.Width != 299 || o.ResizeShorter != 341 {
+		t.Errorf("Expected NHWC 299 crop resized from 341, got %+v", o)
+	}
+
+	o, err = imageInputOptions([]int64{1, 1, 28, 28}, imageConfig{})
+	if err != nil {
+		t.Fatalf("imageInputOptions failed: %v", err)
+	}
+	if !o.Grayscale || len(o.Mean) != 1 || len(o.Std) != 1 {
+		t.Errorf("Expected single-channel normalization, got %+v", o)
+	}
+
+	// An explicit layout wins over inference.
+	o, err = imageInputOptions([]int64{-1, -1, -1, 3}, imageConfig{layout: imagetensor.LayoutNCHW, layoutSet: true})
+	if err != nil {
+		t.Fatalf("imageInputOptions failed: %v", err)
+	}
+	if o.Layout != imagetensor.LayoutNCHW {
+		t.Errorf("Expected explicit NCHW layout, got %v", o.Layout)
+	}
+	if _, err := imageInputOptions([]int64{1, 224, 224, 3}, imageConfig{layout: imagetensor.LayoutNCHW, layoutSet: true}); err == nil {
+		t.Error("Expected error for NCHW layout with 224 channels")
+	}
+}
+
+func TestImageInputOptionsOverrides(t *testing.T) {
+	o, err := imageInputOptions([]int64{-1, 3, -1, -1}, imageConfig{
+		width: 384, height: 384, resizeShorter: 384, normalize: true, bgr: true,
+	})
+	if err != nil {
+		t.Fatalf("imageInputOptions failed: %v", err)
+	}
+	if o.Width != 384 || o.ResizeShorter != 384 || o.Mean != nil || o.Std != nil || !o.BGR {
+		t.Errorf("Expected overrides applied, got %+v", o)
+	}
+
+	if _, err := imageInputOptions([]int64{-1, 3, 224, 224}, imageConfig{width: 256, height: 256}); err == nil {
+		t.Error("Expected error for crop that does not match a fixed input size")
+	}
+	if _, err := imageInputOptions([]int64{-1, 10, 224, 224}, imageConfig{}); err == nil {
+		t.Error("Expected error for 10 channels")
+	}
+}
+
+func TestTopK(t *testing.T) {
+	if got := topK([]float32{0.1, 0.5, 0.2, 0.5}, 3); !slices.Equal(got, []int{1, 3, 2}) {
+		t.Errorf("Expected [1 3 2], got %v", got)
+	}
+	if got := topK([]float32{0.1, 0.9}, 5); !slices.Equal(got, []int{1, 0}) {
+		t.Errorf("Expected k clamped to 2 classes, got %v", got)
+	}
+}
+
+func TestImageClassifierTop(t *testing.T) {
+	c := &ImageClassifier{labels: []string{"cat", "dog", "fish"}, config: newConfig([]Option{WithTopK(2)})}
+	classes := c.top([]float32{0, 3, 1})
+	if len(classes) != 2 || classes[0].Label != "dog" || classes[1].Index != 2 {
+		t.Errorf("Unexpected classes %+v", classes)
+	}
+	if classes[0].Score <= 0.5 || classes[0].Score >= 1 {
+		t.Errorf("Expected softmax probability, got %v", classes[0].Score)
+	}
+
+	c.probabilities = true
+	if classes := c.top([]float32{0.2, 0.7, 0.1}); classes[0].Score != 0.7 {
+		t.Errorf("Expected raw probability 0.7, got %v", classes[0].Score)
+	}
+}
+
+func TestNewImageClassifierUnsupportedModel(t *testing.T) {
+	model, err := ort.LoadModelFromFile("../internal/tests/testdata/model.onnx", &ort.ModelConfig{
+		LibraryPath: os.Getenv("ONNXRUNTIME_LIB_PATH"),
+	})
+	if err != nil {
+		t.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	defer model.Close()
+
+	if _, err := NewImageClassifier(model, nil); err == nil {
+		t.Error("Expected error for a model without a rank-4 image input")
+	}
+}
@@ -0,0 +1,153 @@
+package pipelines
+
+import (
+	"github.com/benedoc-inc/onnxer/onnxruntime/imagetensor"
+)
+
+// Option configures a pipeline. Options that do not apply to a pipeline are
+// ignored by it.
+type Option func(*config)
+
+type config struct {
+	maxLength  int
+	batchSize  int
+	padID      int64
+	outputName string
+
+	topK          int
+	probabilities bool
+	image         imageConfig
+
+	pooling   Pooling
+	normalize bool
+}
+
+// imageConfig holds preprocessing overrides; zero fields are derived from
+// the model.
+type imageConfig struct {
+	resizeShorter int
+	width, height int
+	mean, std     []float32
+	normalize     bool // mean and std were set explicitly
+	layout        imagetensor.Layout
+	layoutSet     bool
+	bgr           bool
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		maxLength: 512,
+		batchSize: 8,
+		topK:      5,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// WithMaxLength truncates token sequences longer than n. Default 512.
+func WithMaxLength(n int) Option {
+	return func(c *config) {
+		c.maxLength = n
+	}
+}
+
+// WithBatchSize sets the number of inputs run per inference. Default 8.
+func WithBatchSize(n int) Option {
+	return func(c *config) {
+		c.batchSize = n
+	}
+}
+
+// WithPadID sets the token ID used to pad shorter sequences in a batch.
+// Default 0 (BERT); RoBERTa models use 1.
+func WithPadID(id int64) Option {
+	return func(c *config) {
+		c.padID = id
+	}
+}
+
+// WithOutputName selects the model output to read. By default a pipeline
+// uses its conventional output name ("logits" for classifiers) if the model
+// has it, otherwise the first output.
+func WithOutputName(name string) Option {
+	return func(c *config) {
+		c.outputName = name
+	}
+}
+
+// WithTopK sets how many of the most probable classes an image classifier
+// returns. Default 5.
+func WithTopK(k int) Option {
+	return func(c *config) {
+		c.topK = k
+	}
+}
+
+// WithProbabilities declares that the model already outputs probabilities,
+// so no softmax is applied.
+func WithProbabilities() Option {
+	return func(c *config) {
+		c.probabilities = true
+	}
+}
+
+// WithPooling sets how an embedder reduces token embeddings to one vector
+// per text. Default PoolingMean.
+func WithPooling(p Pooling) Option {
+	return func(c *config) {
+		c.pooling = p
+	}
+}
+
+// WithL2Normalize scales embeddings to unit length, so that dot product
+// equals cosine similarity.
+func WithL2Normalize() Option {
+	return func(c *config) {
+		c.normalize = true
+	}
+}
+
+// WithResize resizes images so their shorter side is n pixels before the
+// center crop. Default is the crop size scaled by 256/224, the ImageNet
+// evaluation convention.
+func WithResize(n int) Option {
+	return func(c *config) {
+		c.image.resizeShorter = n
+	}
+}
+
+// WithCrop sets the center-crop size fed to the model. Default is the
+// model's fixed input size, or 224x224 if the model accepts any size.
+func WithCrop(width, height int) Option {
+	return func(c *config) {
+		c.image.width, c.image.height = width, height
+	}
+}
+
+// WithNormalization sets per-channel mean and std applied to pixels scaled
+// to [0, 1]. Default is ImageNet statistics; pass nil for both to feed
+// unnormalized [0, 1] values.
+func WithNormalization(mean, std []float32) Option {
+	return func(c *config) {
+		c.image.mean, c.image.std = mean, std
+		c.image.normalize = true
+	}
+}
+
+// WithLayout sets the image tensor layout. Default is inferred from the
+// model's input shape, falling back to NCHW.
+func WithLayout(layout imagetensor.Layout) Option {
+	return func(c *config) {
+		c.image.layout = layout
+		c.image.layoutSet = true
+	}
+}
+
+// WithBGR feeds color channels in blue, green, red order.
+func WithBGR() Option {
+	return func(c *config) {
+		c.image.bgr = true
+	}
+}
@@ -0,0 +1,78 @@
+package pipelines
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// selectOutput returns name if the model has it, or when name is empty the
+// conventional output if present, otherwise the model's first output.
+func selectOutput(model *ort.Model, name, conventional string) (string, error) {
+	outputs := model.OutputNames()
+	if name == "" {
+		if slices.Contains(outputs, conventional) {
+			return conventional, nil
+		}
+		return outputs[0], nil
+	}
+	if !slices.Contains(outputs, name) {
+		return "", fmt.Errorf("model has no output %q", name)
+	}
+	return name, nil
+}
+
+// labelFor returns labels[i], or LABEL_i when no labels were given.
+func labelFor(labels []string, i int) string {
+	if labels == nil {
+		return fmt.Sprintf("LABEL_%d", i)
+	}
+	return labels[i]
+}
+
+// topK returns the indices of the k largest scores, highest first. Ties
+// keep index order.
+func topK(scores []float32, k int) []int {
+	idx := make([]int, len(scores))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int {
+		switch {
+		case scores[a] > scores[b]:
+			return -1
+		case scores[a] < scores[b]:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return idx[:min(k, len(idx))]
+}
+
+// Softmax converts logits to probabilities that sum to 1.
+func Softmax(logits []float32) []float32 {
+	maxLogit := float32(math.Inf(-1))
+	for _, v := range logits {
+		maxLogit = max(maxLogit, v)
+	}
+
+	var sum float32
+	probs := make([]float32, len(logits))
+	for i, v := range logits {
+		probs[i] = float32(math.Exp(float64(v - maxLogit)))
+		sum += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= sum
+	}
+	return probs
+}
+
+func closeValues(values map[string]*ort.Value) {
+	for _, v := range values {
+		v.Close()
+	}
+}
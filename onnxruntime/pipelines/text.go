@@ -0,0 +1,170 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// textModel is the tokenize-pack-run stage shared by text pipelines.
+type textModel struct {
+	model     *ort.Model
+	tokenizer Tokenizer
+
+	inputNames []string
+	seqLen     int64 // fixed sequence length declared by the model, or -1
+	int32IO    bool  // model inputs are int32 rather than int64
+}
+
+// newTextModel checks that model takes input_ids and optionally
+// attention_mask and token_type_ids, and clamps cfg.maxLength to a fixed
+// sequence length declared by the model.
+func newTextModel(model *ort.Model, tokenizer Tokenizer, cfg *config) (textModel, error) {
+	if model == nil || tokenizer == nil {
+		return textModel{}, fmt.Errorf("model and tokenizer are required")
+	}
+	if cfg.maxLength <= 0 || cfg.batchSize <= 0 {
+		return textModel{}, fmt.Errorf("max length and batch size must be positive")
+	}
+
+	m := textModel{model: model, tokenizer: tokenizer, seqLen: -1}
+	infos, err := model.Session().GetInputInfo()
+	if err != nil {
+		return textModel{}, fmt.Errorf("failed to get input info: %w", err)
+	}
+	hasIDs := false
+	for _, info := range infos {
+		switch info.Name {
+		case InputIDs, AttentionMask, TokenTypeIDs:
+		default:
+			return textModel{}, fmt.Errorf("unsupported model input %q", info.Name)
+		}
+		if info.TensorInfo == nil || len(info.TensorInfo.Shape) != 2 {
+			return textModel{}, fmt.Errorf("input %q must be a [batch, sequence] tensor", info.Name)
+		}
+		elemType := info.TensorInfo.ElementType
+		if elemType != ort.ONNXTensorElementDataTypeInt64 && elemType != ort.ONNXTensorElementDataTypeInt32 {
+			return textModel{}, fmt.Errorf("input %q must be int64 or int32", info.Name)
+		}
+		if elemType != infos[0].TensorInfo.ElementType {
+			return textModel{}, fmt.Errorf("inputs must share one integer type")
+		}
+		m.int32IO = elemType == ort.ONNXTensorElementDataTypeInt32
+		if d := info.TensorInfo.Shape[1]; d > 0 {
+			if m.seqLen > 0 && m.seqLen != d {
+				return textModel{}, fmt.Errorf("inputs declare different sequence lengths")
+			}
+			m.seqLen = d
+		}
+		hasIDs = hasIDs || info.Name == InputIDs
+		m.inputNames = append(m.inputNames, info.Name)
+	}
+	if !hasIDs {
+		return textModel{}, fmt.Errorf("model has no %s input", InputIDs)
+	}
+	if m.seqLen > 0 {
+		cfg.maxLength = min(cfg.maxLength, int(m.seqLen))
+	}
+	return m, nil
+}
+
+// run tokenizes and packs texts into one batch and runs the model, returning
+// cfg.outputName and the packed batch. The caller must close the outputs.
+func (m *textModel) run(ctx context.Context, texts []string, cfg *config) (map[string]*ort.Value, tokenBatch, error) {
+	encodings := make([]Encoding, len(texts))
+	for i, text := range texts {
+		enc, err := m.tokenizer.Encode(text)
+		if err != nil {
+			return nil, tokenBatch{}, fmt.Errorf("failed to tokenize text %d: %w", i, err)
+		}
+		encodings[i] = enc
+	}
+
+	batch := packBatch(encodings, cfg.maxLength, m.seqLen, cfg.padID)
+	shape := []int64{int64(len(texts)), int64(batch.seqLen)}
+
+	runtime := m.model.Runtime()
+	inputs := make(map[string]*ort.Value, len(m.inputNames))
+	defer closeValues(inputs)
+	for _, name := range m.inputNames {
+		data := batch.ids
+		switch name {
+		case AttentionMask:
+			data = batch.mask
+		case TokenTypeIDs:
+			data = batch.typeIDs
+		}
+
+		var v *ort.Value
+		var err error
+		if m.int32IO {
+			v, err = ort.NewTensorValue(runtime, toInt32(data), shape)
+		} else {
+			v, err = ort.NewTensorValue(runtime, data, shape)
+		}
+		if err != nil {
+			return nil, tokenBatch{}, fmt.Errorf("failed to create %s tensor: %w", name, err)
+		}
+		inputs[name] = v
+	}
+
+	outputs, err := m.model.Run(ctx, inputs, ort.WithOutputNames(cfg.outputName))
+	if err != nil {
+		return nil, tokenBatch{}, err
+	}
+	return outputs, batch, nil
+}
+
+// tokenBatch holds padded, row-major [batch, seqLen] model inputs.
+type tokenBatch struct {
+	ids, mask, typeIDs []int64
+	seqLen             int
+}
+
+// packBatch truncates encodings to maxLength and pads them to fixedLen if
+// positive, otherwise to the longest encoding (at least one token).
+func packBatch(encodings []Encoding, maxLength int, fixedLen int64, padID int64) tokenBatch {
+	seqLen := 1
+	for _, enc := range encodings {
+		seqLen = max(seqLen, min(len(enc.IDs), maxLength))
+	}
+	if fixedLen > 0 {
+		seqLen = int(fixedLen)
+	}
+
+	n := len(encodings) * seqLen
+	b := tokenBatch{
+		ids:     make([]int64, n),
+		mask:    make([]int64, n),
+		typeIDs: make([]int64, n),
+		seqLen:  seqLen,
+	}
+	for i, enc := range encodings {
+		row := i * seqLen
+		length := min(len(enc.IDs), seqLen)
+		for j := range seqLen {
+			if j >= length {
+				b.ids[row+j] = padID
+				continue
+			}
+			b.ids[row+j] = enc.IDs[j]
+			b.mask[row+j] = 1
+			if j < len(enc.AttentionMask) {
+				b.mask[row+j] = enc.AttentionMask[j]
+			}
+			if j < len(enc.TypeIDs) {
+				b.typeIDs[row+j] = enc.TypeIDs[j]
+			}
+		}
+	}
+	return b
+}
+
+func toInt32(data []int64) []int32 {
+	out := make([]int32, len(data))
+	for i, v := range data {
+		out[i] = int32(v)
+	}
+	return out
+}
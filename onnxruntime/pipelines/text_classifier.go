@@ -0,0 +1,127 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Standard input names of Hugging Face sequence-classification exports.
+const (
+	InputIDs      = "input_ids"
+	AttentionMask = "attention_mask"
+	TokenTypeIDs  = "token_type_ids"
+)
+
+// Encoding is the tokenized form of one text.
+type Encoding struct {
+	// IDs are the token IDs, including any special tokens the model expects.
+	IDs []int64
+
+	// AttentionMask marks real tokens with 1. If nil, every token is real.
+	AttentionMask []int64
+
+	// TypeIDs are segment IDs for models with a token_type_ids input. If
+	// nil, they are all zero.
+	TypeIDs []int64
+}
+
+// Tokenizer converts text to token IDs. Implementations typically wrap a
+// Hugging Face tokenizer.json loader.
+type Tokenizer interface {
+	Encode(text string) (Encoding, error)
+}
+
+// Prediction is the classification result for one text.
+type Prediction struct {
+	// Label and Score are the most probable label and its probability.
+	Label string
+	Score float32
+
+	// Scores holds the probability of every label, in label order.
+	Scores []float32
+}
+
+// TextClassifier runs a sequence-classification model over raw text.
+// It is safe for concurrent use if the tokenizer is.
+type TextClassifier struct {
+	textModel
+	labels []string
+	config
+}
+
+// NewTextClassifier creates a classifier for model, which must take
+// input_ids and optionally attention_mask and token_type_ids (int64 or
+// int32), and produce logits shaped [batch, len(labels)]. If labels is nil,
+// labels are named LABEL_0, LABEL_1, and so on.
+//
+// Example:
+//
+//	model, _ := onnxruntime.LoadModelFromFile("sentiment.onnx", nil)
+//	classifier, err := pipelines.NewTextClassifier(model, tokenizer,
+//	    []string{"negative", "neutral", "positive"}, pipelines.WithPadID(1))
+//	predictions, err := classifier.Classify(ctx, []string{"great film", "awful service"})
+func NewTextClassifier(model *ort.Model, tokenizer Tokenizer, labels []string, opts ...Option) (*TextClassifier, error) {
+	c := &TextClassifier{
+		labels: labels,
+		config: newConfig(opts),
+	}
+	var err error
+	if c.textModel, err = newTextModel(model, tokenizer, &c.config); err != nil {
+		return nil, err
+	}
+	if c.outputName, err = selectOutput(model, c.outputName, "logits"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Classify classifies texts, returning one prediction per text in order.
+// Texts are tokenized, truncated to the maximum length, padded to the
+// longest sequence in each batch (or to the model's fixed sequence length),
+// and run in batches.
+func (c *TextClassifier) Classify(ctx context.Context, texts []string) ([]Prediction, error) {
+	predictions := make([]Prediction, 0, len(texts))
+	for start := 0; start < len(texts); start += c.batchSize {
+		batch := texts[start:min(start+c.batchSize, len(texts))]
+		preds, err := c.classifyBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		predictions = append(predictions, preds...)
+	}
+	return predictions, nil
+}
+
+func (c *TextClassifier) classifyBatch(ctx context.Context, texts []string) ([]Prediction, error) {
+	outputs, _, err := c.run(ctx, texts, &c.config)
+	if err != nil {
+		return nil, err
+	}
+	defer closeValues(outputs)
+
+	logits, logitsShape, err := ort.GetTensorData[float32](outputs[c.outputName])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", c.outputName, err)
+	}
+	if len(logitsShape) != 2 || logitsShape[0] != int64(len(texts)) {
+		return nil, fmt.Errorf("expected %s shaped [%d, labels], got %v", c.outputName, len(texts), logitsShape)
+	}
+	numLabels := int(logitsShape[1])
+	if c.labels != nil && numLabels != len(c.labels) {
+		return nil, fmt.Errorf("model has %d labels, classifier was given %d", numLabels, len(c.labels))
+	}
+
+	predictions := make([]Prediction, len(texts))
+	for i := range predictions {
+		predictions[i] = c.predict(logits[i*numLabels : (i+1)*numLabels])
+	}
+	return predictions, nil
+}
+
+func (c *TextClassifier) predict(logits []float32) Prediction {
+	scores := Softmax(logits)
+	best := topK(scores, 1)[0]
+	return Prediction{Label: labelFor(c.labels, best), Score: scores[best], Scores: scores}
+}
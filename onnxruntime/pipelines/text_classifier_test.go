@@ -0,0 +1,105 @@
+package pipelines
+
+import (
+	"math"
+	"os"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+type splitTokenizer struct{}
+
+func (splitTokenizer) Encode(text string) (Encoding, error) {
+	ids := make([]int64, len(text))
+	for i := range text {
+		ids[i] = int64(text[i])
+	}
+	return Encoding{IDs: ids}, nil
+}
+
+func TestPackBatch(t *testing.T) {
+	encodings := []Encoding{
+		{IDs: []int64{5, 6, 7, 8}, TypeIDs: []int64{0, 0, 1, 1}},
+		{IDs: []int64{9}, AttentionMask: []int64{1}},
+	}
+
+	b := packBatch(encodings, 3, -1, 1)
+	if b.seqLen != 3 {
+		t.Fatalf("Expected sequence length 3 after truncation, got %d", b.seqLen)
+	}
+	if want := []int64{5, 6, 7, 9, 1, 1}; !slices.Equal(b.ids, want) {
+		t.Errorf("Expected ids %v, got %v", want, b.ids)
+	}
+	if want := []int64{1, 1, 1, 1, 0, 0}; !slices.Equal(b.mask, want) {
+		t.Errorf("Expected mask %v, got %v", want, b.mask)
+	}
+	if want := []int64{0, 0, 1, 0, 0, 0}; !slices.Equal(b.typeIDs, want) {
+		t.Errorf("Expected type ids %v, got %v", want, b.typeIDs)
+	}
+}
+
+func TestPackBatchFixedLength(t *testing.T) {
+	b := packBatch([]Encoding{{IDs: []int64{5}}}, 8, 4, 0)
+	if b.seqLen != 4 || !slices.Equal(b.ids, []int64{5, 0, 0, 0}) {
+		t.Errorf("Expected padding to fixed length 4, got %d %v", b.seqLen, b.ids)
+	}
+
+	b = packBatch([]Encoding{{}}, 8, -1, 0)
+	if b.seqLen != 1 {
+		t.Errorf("Expected at least one token for empty input, got %d", b.seqLen)
+	}
+}
+
+func TestSoftmax(t *testing.T) {
+	probs := Softmax([]float32{1, 2, 3})
+	var sum float32
+	for _, p := range probs {
+		sum += p
+	}
+	if math.Abs(float64(sum-1)) > 1e-6 {
+		t.Errorf("Expected probabilities to sum to 1, got %v", sum)
+	}
+	if !(probs[2] > probs[1] && probs[1] > probs[0]) {
+		t.Errorf("Expected probabilities ordered like logits, got %v", probs)
+	}
+
+	// Large logits must not overflow.
+	if probs := Softmax([]float32{1000, 1000}); probs[0] != 0.5 {
+		t.Errorf("Expected 0.5 for equal large logits, got %v", probs)
+	}
+}
+
+func TestPredict(t *testing.T) {
+	c := &TextClassifier{labels: []string{"negative", "positive"}}
+	p := c.predict([]float32{-1, 2})
+	if p.Label != "positive" || p.Score != p.Scores[1] || len(p.Scores) != 2 {
+		t.Errorf("Unexpected prediction %+v", p)
+	}
+
+	c.labels = nil
+	if p := c.predict([]float32{3, 0, 1}); p.Label != "LABEL_0" {
+		t.Errorf("Expected LABEL_0, got %s", p.Label)
+	}
+}
+
+func TestNewTextClassifierUnsupportedModel(t *testing.T) {
+	model, err := ort.LoadModelFromFile("../internal/tests/testdata/model.onnx", &ort.ModelConfig{
+		LibraryPath: os.Getenv("ONNXRUNTIME_LIB_PATH"),
+	})
+	if err != nil {
+		t.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	defer model.Close()
+
+	if _, err := NewTextClassifier(model, splitTokenizer{}, nil); err == nil {
+		t.Error("Expected error for a model without input_ids")
+	}
+}
+
+func TestNewTextClassifierArgs(t *testing.T) {
+	if _, err := NewTextClassifier(nil, splitTokenizer{}, nil); err == nil {
+		t.Error("Expected error for nil model")
+	}
+}
@@ -2,6 +2,7 @@ package onnxruntime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -22,15 +23,44 @@ import (
 //	// Safe to call from many goroutines:
 //	outputs, err := pool.Run(ctx, map[string]*Value{"input": tensor})
 type SessionPool struct {
-	sessions chan *Session
-	runtime  *Runtime
-	closed   atomic.Bool
-	hooks    []Hook
-	inflight sync.WaitGroup // tracks in-flight Run calls
+	sessions  chan *Session   // idle sessions; its capacity is the pool size
+	slots     []*affinitySlot // per-session idle channels with SessionAffinity, used instead of sessions
+	nextSlot  atomic.Uint64   // slot for the next call without a key
+	runtime   *Runtime
+	closed    atomic.Bool
+	hooks     []Hook
+	costModel *CostModel
+	batcher   *microBatcher  // nil unless micro-batching is enabled
+	profiler  *poolProfiler  // nil unless profiling is enabled
+	watchdog  *poolWatchdog  // nil unless StuckRunThreshold is set
+	inflight  sync.WaitGroup // tracks in-flight Run calls
+	state     atomic.Int32   // PoolState
+
+	// abortCtx is cancelled when Drain's context ends, terminating the runs
+	// still in flight.
+	abortCtx  context.Context
+	abortRuns context.CancelFunc
+	aborted   atomic.Int64
+
+	// detailedRunInfo describes the tensors of every run in RunInfo
+	detailedRunInfo bool
+
+	// recreate creates a session replacing a stuck or unhealthy one, warmed
+	// up if warmReplacements is set
+	recreate         func() (*Session, error)
+	warmReplacements bool
+
+	// ready is closed once every session has been created; initErr is the
+	// error that stopped background creation, set before ready is closed
+	ready    chan struct{}
+	initErr  error
+	building sync.WaitGroup // tracks background session creation
 
 	// cached from first session (all sessions share the same model)
 	inputNames  []string
 	outputNames []string
+	inputInfo   []InputInfo
+	outputInfo  []OutputInfo
 
 	// prepacked weights shared across all sessions
 	prepackedWeights     *PrepackedWeightsContainer
@@ -40,6 +70,28 @@ type SessionPool struct {
 	totalRuns    atomic.Int64
 	totalErrors  atomic.Int64
 	totalLatency atomic.Int64 // nanoseconds
+	totalBatched atomic.Int64 // requests served through the micro-batcher
+	replaced     atomic.Int64 // stuck or unhealthy sessions replaced
+	latency      latencyHistogram
+	window       *latencyWindow
+
+	// borrow metrics
+	usageMu        sync.RWMutex
+	usage          map[*Session]*sessionUsage // changes only when a profiled session is replaced
+	usageOrder     []*sessionUsage            // creation order
+	waiting        atomic.Int64               // callers waiting for a session
+	borrowed       atomic.Int64               // sessions currently borrowed
+	peakBorrowed   atomic.Int64
+	totalQueueTime atomic.Int64 // nanoseconds
+	queueTime      latencyHistogram
+	statsSince     atomic.Int64 // unix nanoseconds of creation or last ResetStats
+}
+
+// sessionUsage accumulates per-session metrics.
+type sessionUsage struct {
+	index    int
+	runs     atomic.Int64
+	busyTime atomic.Int64 // nanoseconds
 }
 
 // PoolConfig configures session pool behavior.
@@ -50,39 +102,165 @@ type PoolConfig struct {
 	// Hooks are called around every Run invocation.
 	Hooks []Hook
 
+	// ModelName identifies the model in RunInfo and profiling labels. Pool
+	// sessions are created from bytes, so there is no file name to use.
+	ModelName string
+
 	// SharePrepackedWeights enables sharing of pre-packed kernel weights across
 	// all sessions in the pool. This significantly reduces memory usage because
 	// the packed weight buffers are allocated once and shared rather than
 	// duplicated per session. Recommended for pools with 2+ sessions.
+	// To share weights across pools, set SessionOptions.PrepackedWeights
+	// instead; it takes precedence over the pool's own container.
 	SharePrepackedWeights bool
+
+	// CostModel, when set, estimates FLOPs and memory traffic for every run
+	// and reports them in RunInfo.Cost for usage-based accounting.
+	CostModel *CostModel
+
+	// MaxBatchSize enables micro-batching when greater than 1. Concurrent Run
+	// calls arriving within MaxBatchDelay of each other are stacked along the
+	// batch dimension (axis 0) into a single inference of up to MaxBatchSize
+	// requests, and the outputs are split back to each caller. The model's
+	// first input and output dimension must be the batch dimension.
+	//
+	// Calls that pass RunOptions are never batched. Requests whose inputs
+	// cannot be stacked (different names, element types, or trailing
+	// dimensions) are run individually.
+	MaxBatchSize int
+
+	// MaxBatchDelay is how long the first request of a batch waits for more
+	// requests before the batch is dispatched. Zero dispatches as soon as no
+	// more requests are immediately pending.
+	MaxBatchDelay time.Duration
+
+	// StatsWindow is the span of recent runs summarized in PoolStats.Window.
+	// Default 1 minute.
+	StatsWindow time.Duration
+
+	// Profiling enables continuous profiling of one session of the pool. See
+	// PoolProfiling.
+	Profiling PoolProfiling
+
+	// WarmupFromMetadata runs SessionPool.WarmupFromMetadata once the pool is
+	// created, failing creation if the model has no warmup inputs or they do
+	// not run.
+	WarmupFromMetadata bool
+
+	// FastReplicaInit optimizes the model once rather than once per session.
+	// The first session saves its graph-optimized model, to
+	// SessionOptions.OptimizedModelFilePath if set or else to a temporary
+	// file, and the other sessions load it with graph optimization disabled
+	// and share pre-packed weights as with SharePrepackedWeights. This
+	// mostly speeds up pools of large models with GraphOptimization set.
+	// Sessions fall back to the original model if the optimized one cannot
+	// be saved or loaded.
+	FastReplicaInit bool
+
+	// BackgroundInit creates only the first session before the pool
+	// constructor returns and the others in a background goroutine, so a
+	// service can start serving at reduced capacity right away. Ready is
+	// closed once they are all created, and InitError reports the error
+	// that stopped creation, if any; the pool keeps serving with the
+	// sessions created before it. With WarmupFromMetadata, each session
+	// is warmed up before it serves.
+	BackgroundInit bool
+
+	// SessionAffinity routes every RunWithKey call with the same key to the
+	// same session, waiting for it if it is busy, so that a worker keeps
+	// the CPU caches and any per-session state of its session warm. Run
+	// calls and empty keys take an idle session if there is one, or else
+	// the sessions in turn. Keys are spread over the sessions that exist,
+	// so they move while BackgroundInit is still creating sessions, or when
+	// a session that panicked or got stuck cannot be replaced.
+	SessionAffinity bool
+
+	// StuckRunThreshold enables a watchdog that removes the session of any
+	// run lasting longer than it. Terminating a run is cooperative and some
+	// kernels ignore it, so a stuck run can hold its session indefinitely.
+	// The watchdog terminates the run, which then fails with ErrRunStuck if
+	// it returns at all, replaces its session with a new one, and calls the
+	// hooks implementing StuckRunHook. The stuck session is closed once its
+	// run returns; Drain and Close do not wait for it. Set the threshold
+	// well above the slowest expected run. Zero disables the watchdog.
+	StuckRunThreshold time.Duration
+
+	// DetailedRunInfo sets the tensor shapes, element types and byte sizes
+	// in the RunInfo passed to hooks. It is off by default since reading
+	// them takes native calls for every input and output of every run.
+	DetailedRunInfo bool
 }
 
 // NewSessionPool creates a pool of n sessions from the given model data.
 // All sessions share the same Runtime and Env but are independent for concurrent use.
 func NewSessionPool(runtime *Runtime, env *Env, modelData []byte, n int, config *PoolConfig) (*SessionPool, error) {
+	if len(modelData) == 0 && n > 0 {
+		return nil, fmt.Errorf("model data cannot be empty")
+	}
+	return newPool(runtime, env, n, config, func(opts *SessionOptions, prepackedWeights *PrepackedWeightsContainer) (*Session, error) {
+		return runtime.newSessionFromBytes(env, modelData, opts, prepackedWeights)
+	})
+}
+
+// NewSessionPoolFromFile creates a pool of n sessions from a model file path.
+func NewSessionPoolFromFile(runtime *Runtime, env *Env, modelPath string, n int, config *PoolConfig) (*SessionPool, error) {
+	return newPool(runtime, env, n, config, func(opts *SessionOptions, prepackedWeights *PrepackedWeightsContainer) (*Session, error) {
+		return runtime.newSessionFromFile(env, modelPath, opts, prepackedWeights)
+	})
+}
+
+// newPool creates a pool of n sessions, each loaded from the model by load
+// with the given options and the pool's pre-packed weights container.
+func newPool(runtime *Runtime, env *Env, n int, config *PoolConfig, load func(*SessionOptions, *PrepackedWeightsContainer) (*Session, error)) (*SessionPool, error) {
 	if n <= 0 {
 		return nil, fmt.Errorf("pool size must be positive, got %d", n)
 	}
-	if len(modelData) == 0 {
-		return nil, fmt.Errorf("model data cannot be empty")
-	}
 
 	var opts *SessionOptions
 	var hooks []Hook
+	var modelName string
 	var shareWeights bool
+	var costModel *CostModel
+	var maxBatchSize int
+	var maxBatchDelay time.Duration
+	statsWindow := time.Minute
 	if config != nil {
 		opts = config.SessionOptions
 		hooks = config.Hooks
-		shareWeights = config.SharePrepackedWeights
+		modelName = config.ModelName
+		shareWeights = config.SharePrepackedWeights || config.FastReplicaInit
+		costModel = config.CostModel
+		maxBatchSize = config.MaxBatchSize
+		maxBatchDelay = config.MaxBatchDelay
+		if config.StatsWindow > 0 {
+			statsWindow = config.StatsWindow
+		}
+	}
+
+	if err := checkGlobalThreadPools(env, opts); err != nil {
+		return nil, err
 	}
 
 	pool := &SessionPool{
-		sessions: make(chan *Session, n),
-		runtime:  runtime,
-		hooks:    hooks,
+		sessions:  make(chan *Session, n),
+		runtime:   runtime,
+		hooks:     hooks,
+		costModel: costModel,
+		window:    newLatencyWindow(statsWindow),
+		usage:     make(map[*Session]*sessionUsage, n),
+		ready:     make(chan struct{}),
 	}
+	pool.statsSince.Store(time.Now().UnixNano())
+	pool.abortCtx, pool.abortRuns = context.WithCancel(context.Background())
+	if config != nil && config.SessionAffinity {
+		pool.slots = make([]*affinitySlot, n)
+		for i := range pool.slots {
+			pool.slots[i] = &affinitySlot{idle: make(chan *Session, 1), lost: make(chan struct{})}
+		}
+	}
+	pool.detailedRunInfo = config != nil && config.DetailedRunInfo
 
-	if shareWeights {
+	if shareWeights && (opts == nil || opts.PrepackedWeights == nil) {
 		container, err := runtime.NewPrepackedWeightsContainer()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create prepacked weights container: %w", err)
@@ -91,74 +269,136 @@ func NewSessionPool(runtime *Runtime, env *Env, modelData []byte, n int, config
 		pool.ownsPrepackedWeights = true
 	}
 
-	for i := 0; i < n; i++ {
-		session, err := runtime.newSessionFromBytes(env, modelData, opts, pool.prepackedWeights)
+	newSession := func(opts *SessionOptions) (*Session, error) {
+		session, err := load(opts, pool.prepackedWeights)
 		if err != nil {
-			pool.Close()
-			return nil, fmt.Errorf("failed to create session %d: %w", i, err)
+			return nil, err
 		}
-		if i == 0 {
-			pool.inputNames = session.InputNames()
-			pool.outputNames = session.OutputNames()
+		session.modelName = modelName
+		return session, nil
+	}
+	if config != nil && config.Profiling.Enabled {
+		pool.profiler = newPoolProfiler(config.Profiling, opts, newSession)
+	}
+	if config != nil && config.StuckRunThreshold > 0 {
+		pool.watchdog = &poolWatchdog{threshold: config.StuckRunThreshold}
+	}
+	pool.recreate = func() (*Session, error) { return newSession(opts) }
+	pool.warmReplacements = config != nil && config.WarmupFromMetadata
+
+	create := newSession
+	var replicas *replicaFactory
+	if config != nil && config.FastReplicaInit && n > 1 {
+		replicas = &replicaFactory{
+			runtime:          runtime,
+			env:              env,
+			prepackedWeights: pool.prepackedWeights,
+			modelName:        modelName,
+			newSession:       newSession,
 		}
-		pool.sessions <- session
+		create = replicas.create
+	}
+	if err := pool.fill(n, opts, create, replicas, config); err != nil {
+		return nil, err
 	}
 
-	return pool, nil
-}
-
-// NewSessionPoolFromFile creates a pool of n sessions from a model file path.
-func NewSessionPoolFromFile(runtime *Runtime, env *Env, modelPath string, n int, config *PoolConfig) (*SessionPool, error) {
-	if n <= 0 {
-		return nil, fmt.Errorf("pool size must be positive, got %d", n)
+	if maxBatchSize > 1 {
+		pool.batcher = newMicroBatcher(pool, maxBatchSize, maxBatchDelay)
 	}
 
-	var opts *SessionOptions
-	var hooks []Hook
-	var shareWeights bool
-	if config != nil {
-		opts = config.SessionOptions
-		hooks = config.Hooks
-		shareWeights = config.SharePrepackedWeights
+	if config != nil && config.WarmupFromMetadata && !config.BackgroundInit {
+		if err := pool.WarmupFromMetadata(context.Background()); err != nil {
+			pool.Close()
+			return nil, err
+		}
 	}
 
-	pool := &SessionPool{
-		sessions: make(chan *Session, n),
-		runtime:  runtime,
-		hooks:    hooks,
+	return pool, nil
+}
+
+// addSession registers a newly created session and makes it available.
+func (p *SessionPool) addSession(session *Session) {
+	p.usageMu.Lock()
+	u := &sessionUsage{index: len(p.usageOrder)}
+	p.usage[session] = u
+	p.usageOrder = append(p.usageOrder, u)
+	p.usageMu.Unlock()
+	if p.slots != nil {
+		p.slots[u.index].live.Store(true)
 	}
+	p.release(session)
+}
 
-	if shareWeights {
-		container, err := runtime.NewPrepackedWeightsContainer()
-		if err != nil {
-			return nil, fmt.Errorf("failed to create prepacked weights container: %w", err)
-		}
-		pool.prepackedWeights = container
-		pool.ownsPrepackedWeights = true
+// fill creates the n sessions of a new pool with create, or only the first
+// with PoolConfig.BackgroundInit, and closes the pool if one fails.
+func (p *SessionPool) fill(n int, opts *SessionOptions, create func(*SessionOptions) (*Session, error), replicas *replicaFactory, config *PoolConfig) error {
+	background := config != nil && config.BackgroundInit
+	warmup := background && config.WarmupFromMetadata
+	first := n
+	if background {
+		first = 1
 	}
 
-	for i := 0; i < n; i++ {
-		session, err := runtime.newSessionFromFile(env, modelPath, opts, pool.prepackedWeights)
+	for i := 0; i < first; i++ {
+		var session *Session
+		var err error
+		if i == 0 && p.profiler != nil {
+			session, err = p.profiler.start()
+		} else {
+			session, err = create(opts)
+		}
 		if err != nil {
-			pool.Close()
-			return nil, fmt.Errorf("failed to create session %d: %w", i, err)
+			replicas.close()
+			p.Close()
+			return fmt.Errorf("failed to create session %d: %w", i, err)
 		}
 		if i == 0 {
-			pool.inputNames = session.InputNames()
-			pool.outputNames = session.OutputNames()
+			if err := p.cacheModelInfo(session); err != nil {
+				session.Close()
+				replicas.close()
+				p.Close()
+				return err
+			}
+		}
+		if warmup {
+			if err := session.WarmupFromMetadata(context.Background()); err != nil {
+				session.Close()
+				replicas.close()
+				p.Close()
+				return err
+			}
 		}
-		pool.sessions <- session
+		p.addSession(session)
 	}
 
-	return pool, nil
+	if first == n {
+		replicas.close()
+		close(p.ready)
+		return nil
+	}
+	p.building.Add(1)
+	go p.build(first, n, func() (*Session, error) { return create(opts) }, warmup, replicas)
+	return nil
+}
+
+// replaceSession moves the usage metrics of a borrowed session to its
+// replacement.
+func (p *SessionPool) replaceSession(old, replacement *Session) {
+	p.usageMu.Lock()
+	p.usage[replacement] = p.usage[old]
+	delete(p.usage, old)
+	p.usageMu.Unlock()
 }
 
 // Run borrows a session from the pool, executes inference, and returns the session.
 // It blocks until a session is available or ctx is cancelled.
 // This is safe to call from multiple goroutines concurrently.
+//
+// When micro-batching is enabled (PoolConfig.MaxBatchSize), calls without
+// RunOptions may be merged with concurrent calls into a single inference.
 func (p *SessionPool) Run(ctx context.Context, inputs map[string]*Value, opts ...RunOption) (map[string]*Value, error) {
 	if p.closed.Load() {
-		return nil, fmt.Errorf("session pool is closed")
+		return nil, ErrPoolClosed
 	}
 
 	// Fast path: short-circuit if context is already cancelled
@@ -166,43 +406,138 @@ func (p *SessionPool) Run(ctx context.Context, inputs map[string]*Value, opts ..
 		return nil, err
 	}
 
-	// Track in-flight run so Close() waits for us
+	if p.batcher != nil && len(opts) == 0 {
+		return p.batcher.submit(ctx, inputs)
+	}
+	return p.runSession(ctx, inputs, 1, opts...)
+}
+
+// runSession borrows a session and runs a single inference with hooks and metrics.
+// batchSize is the number of Run calls merged into inputs.
+func (p *SessionPool) runSession(ctx context.Context, inputs map[string]*Value, batchSize int, opts ...RunOption) (map[string]*Value, error) {
+	return p.runSessionFor(ctx, "", inputs, batchSize, opts...)
+}
+
+// runSessionFor is runSession borrowing the session for key, see idle.
+func (p *SessionPool) runSessionFor(ctx context.Context, key string, inputs map[string]*Value, batchSize int, opts ...RunOption) (map[string]*Value, error) {
+	return p.borrowRun(ctx, key, inputs, batchSize, runTag(opts), func(ctx context.Context, session *Session) (map[string]*Value, error) {
+		return session.Run(ctx, inputs, opts...)
+	})
+}
+
+// borrowRun borrows the session for key, see idle, and runs exec on it with
+// hooks and metrics. inputs and tag describe the run to hooks; inputs are nil
+// for bound runs.
+func (p *SessionPool) borrowRun(ctx context.Context, key string, inputs map[string]*Value, batchSize int, tag string, exec func(context.Context, *Session) (map[string]*Value, error)) (map[string]*Value, error) {
+	if p.closed.Load() {
+		return nil, ErrPoolClosed
+	}
+
+	// Track in-flight run so Close() waits for us. A stuck run hands its
+	// count to the replacement of its session.
+	var stuck bool
 	p.inflight.Add(1)
-	defer p.inflight.Done()
+	defer func() {
+		if !stuck {
+			p.inflight.Done()
+		}
+	}()
+
+	// Terminate the run if Drain gives up waiting for it.
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(p.abortCtx, func() { cancel(ErrPoolDrained) })
+	defer stop()
 
 	// Borrow a session
-	var session *Session
-	select {
-	case session = <-p.sessions:
-	case <-ctx.Done():
+	waitStart := time.Now()
+	p.waiting.Add(1)
+	session, err := p.borrow(runCtx, key)
+	if err != nil {
+		p.waiting.Add(-1)
+		if context.Cause(runCtx) == ErrPoolDrained {
+			p.aborted.Add(1)
+			return nil, ErrPoolDrained
+		}
 		return nil, ctx.Err()
 	}
+	p.waiting.Add(-1)
+	queueTime := time.Since(waitStart)
+	p.totalQueueTime.Add(int64(queueTime))
+	p.queueTime.record(queueTime)
+	borrowed := p.borrowed.Add(1)
+	for {
+		peak := p.peakBorrowed.Load()
+		if borrowed <= peak || p.peakBorrowed.CompareAndSwap(peak, borrowed) {
+			break
+		}
+	}
+	p.usageMu.RLock()
+	usage := p.usage[session]
+	p.usageMu.RUnlock()
 
-	// Always return the session to the pool
+	// Always return the session to the pool, or hand it to the profiler to
+	// be replaced
+	var rotate bool
 	defer func() {
-		if !p.closed.Load() {
-			p.sessions <- session
-		} else {
+		p.borrowed.Add(-1)
+		switch {
+		case stuck, p.closed.Load():
 			session.Close()
+		case !session.Healthy():
+			p.inflight.Add(1)
+			go p.replaceUnhealthy(session)
+		case rotate:
+			p.inflight.Add(1)
+			go p.rotateProfile(session)
+		default:
+			p.release(session)
 		}
 	}()
 
 	// Run hooks
 	info := &RunInfo{
+		Context:    ctx,
+		ModelName:  session.modelName,
+		Inputs:     inputs,
 		InputNames: keys(inputs),
+		BatchSize:  batchSize,
+
+		QueueTime:       queueTime,
+		SessionIndex:    usage.index,
+		ActiveBorrowers: int(borrowed),
+		RunTag:          tag,
+	}
+	if p.detailedRunInfo {
+		info.InputTensors, info.InputBytes = describeValues(inputs)
+	}
+	if p.costModel != nil && inputs != nil {
+		cost := p.costModel.EstimateValues(inputs)
+		info.Cost = &cost
 	}
 	for _, h := range p.hooks {
 		h.BeforeRun(info)
 	}
 
 	start := time.Now()
-	outputs, err := session.Run(ctx, inputs, opts...)
+	watch := p.watch(session, usage.index, cancel)
+	outputs, err := exec(runCtx, session)
 	elapsed := time.Since(start)
+	stuck = watch.stop()
+	if stuck && err != nil && !errors.Is(err, ErrRunStuck) {
+		err = fmt.Errorf("%w: %w", ErrRunStuck, err)
+	}
+	if err != nil && context.Cause(runCtx) == ErrPoolDrained {
+		p.aborted.Add(1)
+	}
 
 	info.Duration = elapsed
 	info.Error = err
 	if outputs != nil {
 		info.OutputNames = keys(outputs)
+		if p.detailedRunInfo {
+			info.OutputTensors, info.OutputBytes = describeValues(outputs)
+		}
 	}
 
 	p.totalRuns.Add(1)
@@ -210,6 +545,11 @@ func (p *SessionPool) Run(ctx context.Context, inputs map[string]*Value, opts ..
 	if err != nil {
 		p.totalErrors.Add(1)
 	}
+	p.latency.record(elapsed)
+	p.window.record(start.Add(elapsed), elapsed, err != nil)
+	usage.runs.Add(1)
+	usage.busyTime.Add(int64(elapsed))
+	rotate = !stuck && session.Healthy() && p.profiler != nil && p.profiler.due(session)
 
 	for _, h := range p.hooks {
 		h.AfterRun(info)
@@ -218,24 +558,77 @@ func (p *SessionPool) Run(ctx context.Context, inputs map[string]*Value, opts ..
 	return outputs, err
 }
 
-// Size returns the total number of sessions in the pool.
+// Size returns the total number of sessions in the pool, including those
+// still being created with PoolConfig.BackgroundInit.
 func (p *SessionPool) Size() int {
 	return cap(p.sessions)
 }
 
 // Available returns the number of idle sessions currently available.
 func (p *SessionPool) Available() int {
+	if p.slots != nil {
+		n := 0
+		for _, slot := range p.slots {
+			n += len(slot.idle)
+		}
+		return n
+	}
 	return len(p.sessions)
 }
 
 // Stats returns pool usage statistics.
 func (p *SessionPool) Stats() PoolStats {
+	var all latencySnapshot
+	p.latency.addTo(&all)
+	var queue latencySnapshot
+	p.queueTime.addTo(&queue)
+	now := time.Now()
+	recent, recentErrors := p.window.snapshot(now)
+
+	elapsed := now.Sub(time.Unix(0, p.statsSince.Load()))
+	p.usageMu.RLock()
+	usageOrder := p.usageOrder
+	p.usageMu.RUnlock()
+	sessions := make([]SessionStats, len(usageOrder))
+	for i, u := range usageOrder {
+		busy := time.Duration(u.busyTime.Load())
+		sessions[i] = SessionStats{Runs: u.runs.Load(), BusyTime: busy}
+		if elapsed > 0 {
+			sessions[i].Utilization = min(float64(busy)/float64(elapsed), 1)
+		}
+	}
+
 	return PoolStats{
 		TotalRuns:         p.totalRuns.Load(),
 		TotalErrors:       p.totalErrors.Load(),
 		TotalLatency:      time.Duration(p.totalLatency.Load()),
 		PoolSize:          cap(p.sessions),
-		AvailableSessions: len(p.sessions),
+		AvailableSessions: p.Available(),
+		BatchedRequests:   p.totalBatched.Load(),
+		P50Latency:        all.percentile(0.50),
+		P90Latency:        all.percentile(0.90),
+		P99Latency:        all.percentile(0.99),
+		MaxLatency:        time.Duration(all.max),
+		ActiveBorrowers:   int(p.borrowed.Load()),
+		PeakBorrowers:     int(p.peakBorrowed.Load()),
+		WaitingCallers:    int(p.waiting.Load()),
+		Borrows:           queue.total,
+		TotalQueueTime:    time.Duration(p.totalQueueTime.Load()),
+		P50QueueTime:      queue.percentile(0.50),
+		P99QueueTime:      queue.percentile(0.99),
+		MaxQueueTime:      time.Duration(queue.max),
+		StuckRuns:         p.stuckRuns(),
+		ReplacedSessions:  p.replaced.Load(),
+		Sessions:          sessions,
+		Window: WindowStats{
+			Duration:   p.window.duration(),
+			Runs:       recent.total,
+			Errors:     recentErrors,
+			P50Latency: recent.percentile(0.50),
+			P90Latency: recent.percentile(0.90),
+			P99Latency: recent.percentile(0.99),
+			MaxLatency: time.Duration(recent.max),
+		},
 	}
 }
 
@@ -246,6 +639,71 @@ type PoolStats struct {
 	TotalLatency      time.Duration
 	PoolSize          int
 	AvailableSessions int
+
+	// BatchedRequests is the number of Run calls the micro-batcher merged into
+	// batched inferences. Each batch counts once in TotalRuns.
+	BatchedRequests int64
+
+	// Latency percentiles and maximum over all runs since the pool was
+	// created or ResetStats was called. Percentiles are accurate to about 6%.
+	P50Latency time.Duration
+	P90Latency time.Duration
+	P99Latency time.Duration
+	MaxLatency time.Duration
+
+	// ActiveBorrowers is the number of sessions currently borrowed by Run
+	// calls, PeakBorrowers the highest value it has reached, and
+	// WaitingCallers the number of Run calls blocked waiting for a session.
+	// PeakBorrowers reaching PoolSize while WaitingCallers or QueueTime grow
+	// indicates the pool is too small.
+	ActiveBorrowers int
+	PeakBorrowers   int
+	WaitingCallers  int
+
+	// Borrows counts sessions handed out to Run calls. Queue times measure how
+	// long each of those calls waited for a session; calls cancelled while
+	// waiting are not included.
+	Borrows        int64
+	TotalQueueTime time.Duration
+	P50QueueTime   time.Duration
+	P99QueueTime   time.Duration
+	MaxQueueTime   time.Duration
+
+	// StuckRuns counts runs whose session the watchdog replaced; see
+	// PoolConfig.StuckRunThreshold.
+	StuckRuns int64
+
+	// ReplacedSessions counts sessions the pool replaced with new ones
+	// because their run got stuck or panicked.
+	ReplacedSessions int64
+
+	// Sessions reports per-session usage, indexed by RunInfo.SessionIndex.
+	Sessions []SessionStats
+
+	// Window summarizes runs completed within the last PoolConfig.StatsWindow.
+	Window WindowStats
+}
+
+// SessionStats contains usage statistics for one session in a pool.
+type SessionStats struct {
+	Runs     int64
+	BusyTime time.Duration
+
+	// Utilization is BusyTime as a fraction of the time since the pool was
+	// created or ResetStats was called.
+	Utilization float64
+}
+
+// WindowStats summarizes runs completed within a recent time window.
+// Runs expire in steps of one sixth of the window.
+type WindowStats struct {
+	Duration   time.Duration
+	Runs       int64
+	Errors     int64
+	P50Latency time.Duration
+	P90Latency time.Duration
+	P99Latency time.Duration
+	MaxLatency time.Duration
 }
 
 // AvgLatency returns the average inference latency, or 0 if no runs have completed.
@@ -256,6 +714,15 @@ func (s PoolStats) AvgLatency() time.Duration {
 	return s.TotalLatency / time.Duration(s.TotalRuns)
 }
 
+// AvgQueueTime returns the average time Run calls waited for a session, or 0
+// if no sessions have been borrowed.
+func (s PoolStats) AvgQueueTime() time.Duration {
+	if s.Borrows == 0 {
+		return 0
+	}
+	return s.TotalQueueTime / time.Duration(s.Borrows)
+}
+
 // Warmup pre-runs inference on every session in the pool to warm JIT caches,
 // trigger graph optimizations, and allocate internal buffers. This reduces
 // latency variance on the first real requests.
@@ -264,7 +731,7 @@ func (s PoolStats) AvgLatency() time.Duration {
 // input schema. Each session runs inference once and the outputs are discarded.
 func (p *SessionPool) Warmup(ctx context.Context, inputs map[string]*Value) error {
 	if p.closed.Load() {
-		return fmt.Errorf("session pool is closed")
+		return ErrPoolClosed
 	}
 
 	size := cap(p.sessions)
@@ -293,7 +760,7 @@ func (p *SessionPool) Warmup(ctx context.Context, inputs map[string]*Value) erro
 // Use this in readiness probes (e.g., Kubernetes /healthz endpoints).
 func (p *SessionPool) HealthCheck(ctx context.Context, inputs map[string]*Value) error {
 	if p.closed.Load() {
-		return fmt.Errorf("session pool is closed")
+		return ErrPoolClosed
 	}
 
 	outputs, err := p.Run(ctx, inputs)
@@ -311,28 +778,159 @@ func (p *SessionPool) ResetStats() {
 	p.totalRuns.Store(0)
 	p.totalErrors.Store(0)
 	p.totalLatency.Store(0)
+	p.totalBatched.Store(0)
+	p.latency.reset()
+	p.window.reset()
+	p.totalQueueTime.Store(0)
+	p.queueTime.reset()
+	if p.watchdog != nil {
+		p.watchdog.stuckRuns.Store(0)
+	}
+	p.replaced.Store(0)
+	p.peakBorrowed.Store(p.borrowed.Load())
+	p.usageMu.RLock()
+	usageOrder := p.usageOrder
+	p.usageMu.RUnlock()
+	for _, u := range usageOrder {
+		u.runs.Store(0)
+		u.busyTime.Store(0)
+	}
+	p.statsSince.Store(time.Now().UnixNano())
 }
 
-// Close waits for in-flight runs to complete, then drains the pool and closes all sessions.
-func (p *SessionPool) Close() {
+// PoolState is the lifecycle state of a SessionPool.
+type PoolState int32
+
+const (
+	// PoolActive pools accept runs.
+	PoolActive PoolState = iota
+	// PoolDraining pools reject new runs while in-flight runs finish.
+	PoolDraining
+	// PoolClosed pools have released their sessions.
+	PoolClosed
+)
+
+// String returns the state's name.
+func (s PoolState) String() string {
+	switch s {
+	case PoolActive:
+		return "active"
+	case PoolDraining:
+		return "draining"
+	case PoolClosed:
+		return "closed"
+	default:
+		return fmt.Sprintf("PoolState(%d)", int32(s))
+	}
+}
+
+// State returns the pool's lifecycle state.
+func (p *SessionPool) State() PoolState {
+	return PoolState(p.state.Load())
+}
+
+// Drain stops the pool accepting new runs, waits for in-flight runs to
+// finish, and closes it. If ctx ends first, the remaining runs are
+// terminated and fail with an error matching ErrPoolDrained; Drain waits for
+// them to return, which ONNX Runtime does between operators, and then
+// returns ctx's error. It reports how many inferences were aborted.
+//
+// Drain returns ErrPoolClosed if the pool is already draining or closed.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if aborted, err := pool.Drain(ctx); err != nil {
+//	    log.Printf("shutdown deadline reached, aborted %d runs", aborted)
+//	}
+func (p *SessionPool) Drain(ctx context.Context) (aborted int, err error) {
 	if !p.closed.CompareAndSwap(false, true) {
-		return
+		return 0, ErrPoolClosed
 	}
+	p.state.Store(int32(PoolDraining))
 
-	// Wait for in-flight runs to finish and return their sessions
-	p.inflight.Wait()
+	done := make(chan struct{})
+	go func() {
+		// Stop accepting batched requests and wait for pending batches
+		if p.batcher != nil {
+			p.batcher.close()
+		}
+		// Wait for a session being created in the background
+		p.building.Wait()
+		// Wait for in-flight runs to finish and return their sessions
+		p.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		p.abortRuns()
+		<-done
+	}
 
 	// Drain and close all sessions
 	close(p.sessions)
 	for session := range p.sessions {
 		session.Close()
 	}
+	for _, slot := range p.slots {
+		close(slot.idle)
+		for session := range slot.idle {
+			session.Close()
+		}
+	}
 
 	// Release shared prepacked weights after all sessions are closed
 	if p.ownsPrepackedWeights && p.prepackedWeights != nil {
 		p.prepackedWeights.Close()
 		p.prepackedWeights = nil
 	}
+
+	p.abortRuns()
+	p.state.Store(int32(PoolClosed))
+	return int(p.aborted.Load()), err
+}
+
+// Close waits for in-flight runs to complete, then drains the pool and closes
+// all sessions. It is safe to call Close multiple times. Use Drain to bound
+// the wait.
+func (p *SessionPool) Close() {
+	p.Drain(context.Background())
+}
+
+// cacheModelInfo records the model's inputs and outputs from its first session.
+func (p *SessionPool) cacheModelInfo(session *Session) error {
+	var err error
+	if p.inputInfo, err = session.GetInputInfo(); err != nil {
+		return fmt.Errorf("failed to get input info: %w", err)
+	}
+	if p.outputInfo, err = session.GetOutputInfo(); err != nil {
+		return fmt.Errorf("failed to get output info: %w", err)
+	}
+	p.inputNames = session.InputNames()
+	p.outputNames = session.OutputNames()
+	return nil
+}
+
+// InputInfo returns the names, types, and shapes of the model's inputs.
+// This is safe to call concurrently — it is cached at pool creation time.
+func (p *SessionPool) InputInfo() []InputInfo {
+	return p.inputInfo
+}
+
+// OutputInfo returns the names, types, and shapes of the model's outputs.
+// This is safe to call concurrently — it is cached at pool creation time.
+func (p *SessionPool) OutputInfo() []OutputInfo {
+	return p.outputInfo
+}
+
+// Runtime returns the runtime the pool's sessions were created with, for
+// creating input tensors.
+func (p *SessionPool) Runtime() *Runtime {
+	return p.runtime
 }
 
 // InputNames returns the model's input names.
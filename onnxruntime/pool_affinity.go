@@ -0,0 +1,156 @@
+package onnxruntime
+
+import (
+	"context"
+	"hash/fnv"
+	"sync/atomic"
+)
+
+// RunWithKey is like Run, but with PoolConfig.SessionAffinity every call
+// with the same key runs on the same session, waiting for it if another
+// call with a key mapped to it is running. Go has no goroutine identity, so
+// pass a stable key such as a worker, connection, or tenant ID. Keys are
+// spread over the sessions by hash, so distinct keys may share a session,
+// and a key moves to another session if its session is lost.
+//
+// Without SessionAffinity the key is ignored. Calls are never micro-batched.
+//
+// Example:
+//
+//	pool, _ := onnxruntime.NewSessionPool(runtime, env, modelBytes, 4,
+//	    &onnxruntime.PoolConfig{SessionAffinity: true})
+//	for w := range 4 {
+//	    go func() {
+//	        key := strconv.Itoa(w)
+//	        for req := range requests {
+//	            outputs, err := pool.RunWithKey(ctx, key, req.Inputs)
+//	            ...
+//	        }
+//	    }()
+//	}
+func (p *SessionPool) RunWithKey(ctx context.Context, key string, inputs map[string]*Value, opts ...RunOption) (map[string]*Value, error) {
+	if p.closed.Load() {
+		return nil, ErrPoolClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p.runSessionFor(ctx, key, inputs, 1, opts...)
+}
+
+// affinitySlot holds the session of one slot with SessionAffinity while it
+// is idle.
+type affinitySlot struct {
+	idle chan *Session
+
+	// set once the slot's session is created, cleared if it is lost
+	live atomic.Bool
+
+	// closed once the slot's session is lost, so that runs waiting for it
+	// pick another
+	lost chan struct{}
+}
+
+// borrow waits until the session for key, see idle, is idle or ctx is done.
+func (p *SessionPool) borrow(ctx context.Context, key string) (*Session, error) {
+	for {
+		idle, lost := p.idle(key)
+		select {
+		case session := <-idle:
+			return session, nil
+		case <-lost:
+			// The session was lost; wait for another
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// idle returns the channel to borrow a session from, and a channel closed
+// if that session is lost while waiting for it. Without SessionAffinity it
+// is the shared channel. With it, keys are spread over the slots that have
+// a session, skipping those still being created with BackgroundInit or
+// whose session could not be replaced, and an empty key takes an idle
+// session if there is one, or else the next session in turn. If no slot has
+// a session, both channels are nil.
+func (p *SessionPool) idle(key string) (idle chan *Session, lost <-chan struct{}) {
+	if p.slots == nil {
+		return p.sessions, nil
+	}
+	var slot *affinitySlot
+	if key == "" {
+		next := p.nextSlot.Add(1)
+		n := uint64(len(p.slots))
+		for i := range n {
+			if s := p.slots[(next+i)%n]; s.live.Load() && len(s.idle) > 0 {
+				return s.idle, s.lost
+			}
+		}
+		slot = p.liveSlot(next)
+	} else {
+		slot = p.liveSlot(keyHash(key))
+	}
+	if slot == nil {
+		return nil, nil
+	}
+	return slot.idle, slot.lost
+}
+
+// liveSlot returns the slot at index i modulo the number of slots with a
+// session, counting only those, or nil if there are none.
+func (p *SessionPool) liveSlot(i uint64) *affinitySlot {
+	var live uint64
+	for _, slot := range p.slots {
+		if slot.live.Load() {
+			live++
+		}
+	}
+	if live == 0 {
+		return nil
+	}
+	i %= live
+	for _, slot := range p.slots {
+		if !slot.live.Load() {
+			continue
+		}
+		if i == 0 {
+			return slot
+		}
+		i--
+	}
+	// A slot was lost since counting
+	return p.liveSlot(0)
+}
+
+// keyHash hashes key to pick its session.
+func keyHash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
+// loseSlot marks the slot of a session closed without a replacement, so that
+// runs with SessionAffinity stop waiting for it.
+func (p *SessionPool) loseSlot(session *Session) {
+	if p.slots == nil {
+		return
+	}
+	p.usageMu.RLock()
+	u := p.usage[session]
+	p.usageMu.RUnlock()
+	if slot := p.slots[u.index]; slot.live.Swap(false) {
+		close(slot.lost)
+	}
+}
+
+// release makes a session available again.
+func (p *SessionPool) release(session *Session) {
+	if p.slots == nil {
+		p.sessions <- session
+		return
+	}
+	p.usageMu.RLock()
+	u := p.usage[session]
+	p.usageMu.RUnlock()
+	p.slots[u.index].idle <- session
+}
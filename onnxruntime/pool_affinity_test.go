@@ -0,0 +1,180 @@
+package onnxruntime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSessionPoolRunWithKey(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+	pool, err := NewSessionPool(runtime, env, modelData, 3, &PoolConfig{SessionAffinity: true})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	input, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	for range 5 {
+		outputs, err := pool.RunWithKey(context.Background(), "worker-1", map[string]*Value{"input": input})
+		if err != nil {
+			t.Fatalf("RunWithKey() error = %v", err)
+		}
+		closeValues(outputs)
+	}
+	if pool.Available() != 3 {
+		t.Errorf("Expected 3 idle sessions, got %d", pool.Available())
+	}
+
+	pinned := int(keyHash("worker-1") % 3)
+	for i, s := range pool.Stats().Sessions {
+		want := int64(0)
+		if i == pinned {
+			want = 5
+		}
+		if s.Runs != want {
+			t.Errorf("Session %d ran %d times, want %d", i, s.Runs, want)
+		}
+	}
+
+	// Calls without a key take the sessions in turn
+	pool.ResetStats()
+	for range 3 {
+		closeValues(runPoolInference(t, pool))
+	}
+	for i, s := range pool.Stats().Sessions {
+		if s.Runs != 1 {
+			t.Errorf("Session %d ran %d times, want 1", i, s.Runs)
+		}
+	}
+}
+
+func TestKeyHash(t *testing.T) {
+	seen := make(map[uint64]bool)
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		i := keyHash(key) % 4
+		if keyHash(key)%4 != i {
+			t.Errorf("keyHash(%q) is not stable", key)
+		}
+		seen[i] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Expected keys to spread over sessions, got %v", seen)
+	}
+}
+
+// newAffinityTestPool returns a pool with SessionAffinity and n slots, none
+// of which has a session yet.
+func newAffinityTestPool(n int) *SessionPool {
+	pool := &SessionPool{
+		sessions: make(chan *Session, n),
+		usage:    make(map[*Session]*sessionUsage),
+		slots:    make([]*affinitySlot, n),
+	}
+	for i := range pool.slots {
+		pool.slots[i] = &affinitySlot{idle: make(chan *Session, 1), lost: make(chan struct{})}
+	}
+	return pool
+}
+
+func TestAffinitySkipsPendingSlots(t *testing.T) {
+	// With BackgroundInit, only the first session exists at first
+	pool := newAffinityTestPool(3)
+	first := &Session{id: 1}
+	pool.addSession(first)
+
+	for _, key := range []string{"", "a", "b", "c", "d"} {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		session, err := pool.borrow(ctx, key)
+		cancel()
+		if err != nil {
+			t.Fatalf("borrow(%q) error = %v, want the only session", key, err)
+		}
+		if session != first {
+			t.Errorf("borrow(%q) = session %d, want %d", key, session.id, first.id)
+		}
+		pool.release(session)
+	}
+
+	// Once created, the other sessions take their share of the keys
+	pool.addSession(&Session{id: 2})
+	pool.addSession(&Session{id: 3})
+	for _, key := range []string{"a", "b", "c", "d"} {
+		idle, _ := pool.idle(key)
+		if want := pool.slots[keyHash(key)%3].idle; idle != want {
+			t.Errorf("idle(%q) is not slot %d", key, keyHash(key)%3)
+		}
+	}
+}
+
+func TestAffinityFailedReplacement(t *testing.T) {
+	pool := newAffinityTestPool(2)
+	pool.recreate = func() (*Session, error) { return nil, errors.New("out of memory") }
+	sessions := []*Session{{id: 1}, {id: 2}}
+	for _, s := range sessions {
+		pool.addSession(s)
+	}
+
+	// Borrow the session of a key and wait for it again
+	const key = "worker"
+	busy, err := pool.borrow(t.Context(), key)
+	if err != nil {
+		t.Fatalf("borrow() error = %v", err)
+	}
+	waiter := make(chan *Session, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		defer cancel()
+		session, _ := pool.borrow(ctx, key)
+		waiter <- session
+	}()
+	time.Sleep(10 * time.Millisecond) // let the waiter block
+
+	// Its run panics and no replacement can be created, so the waiter
+	// moves on to the remaining session
+	pool.inflight.Add(1)
+	pool.replaceUnhealthy(busy)
+	session := <-waiter
+	if session == nil || session == busy {
+		t.Fatalf("Waiter got %v, want the remaining session", session)
+	}
+	pool.release(session)
+
+	for _, key := range []string{"", "a", "b", "c"} {
+		idle, _ := pool.idle(key)
+		if idle != pool.slots[session.id-1].idle {
+			t.Errorf("idle(%q) is not the remaining session's slot", key)
+		}
+	}
+}
+
+func TestAffinityAllLost(t *testing.T) {
+	pool := newAffinityTestPool(1)
+	session := &Session{id: 1}
+	pool.addSession(session)
+	<-pool.slots[0].idle
+	pool.loseSlot(session)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.borrow(ctx, "a"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("borrow() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,69 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+)
+
+// build creates sessions from through n-1 of a pool with
+// PoolConfig.BackgroundInit and closes Ready when done. It stops early if
+// the pool is closed or a session cannot be created.
+func (p *SessionPool) build(from, n int, create func() (*Session, error), warmup bool, replicas *replicaFactory) {
+	defer p.building.Done()
+	defer close(p.ready)
+	defer replicas.close()
+
+	for i := from; i < n; i++ {
+		if p.closed.Load() {
+			return
+		}
+		session, err := create()
+		if err == nil && warmup {
+			if err = session.WarmupFromMetadata(context.Background()); err != nil {
+				session.Close()
+			}
+		}
+		if err != nil {
+			p.initErr = fmt.Errorf("failed to create session %d: %w", i, err)
+			return
+		}
+		// Drain waits for this goroutine before closing the sessions
+		// channel, so the session is either added or closed here
+		if p.closed.Load() {
+			session.Close()
+			return
+		}
+		p.addSession(session)
+	}
+}
+
+// Ready returns a channel that is closed once every session of the pool has
+// been created. Without PoolConfig.BackgroundInit it is closed when the pool
+// is returned.
+//
+// Example:
+//
+//	pool, err := onnxruntime.NewSessionPool(runtime, env, modelBytes, 8,
+//	    &onnxruntime.PoolConfig{BackgroundInit: true})
+//	...
+//	go func() {
+//	    <-pool.Ready()
+//	    if err := pool.InitError(); err != nil {
+//	        log.Printf("pool running at reduced capacity: %v", err)
+//	    }
+//	}()
+func (p *SessionPool) Ready() <-chan struct{} {
+	return p.ready
+}
+
+// InitError returns the error that stopped background session creation, or
+// nil if creation succeeded or has not finished. Check it once Ready is
+// closed.
+func (p *SessionPool) InitError() error {
+	select {
+	case <-p.ready:
+		return p.initErr
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package onnxruntime
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSessionPoolBackgroundInit(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	pool, err := NewSessionPool(runtime, env, modelData, 3, &PoolConfig{BackgroundInit: true})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	// The first session serves while the others are created
+	outputs := runPoolInference(t, pool)
+	for _, v := range outputs {
+		v.Close()
+	}
+
+	select {
+	case <-pool.Ready():
+	case <-time.After(30 * time.Second):
+		t.Fatal("Timed out waiting for the pool to be ready")
+	}
+	if err := pool.InitError(); err != nil {
+		t.Errorf("InitError() = %v", err)
+	}
+	if pool.Available() != 3 || len(pool.Stats().Sessions) != 3 {
+		t.Errorf("Expected 3 sessions once ready, got %d available", pool.Available())
+	}
+}
+
+func TestSessionPoolBackgroundInitClose(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	pool, err := NewSessionPoolFromFile(runtime, env, testModelPath(), 4, &PoolConfig{BackgroundInit: true})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+
+	// Closing stops background creation and closes what it made
+	pool.Close()
+	select {
+	case <-pool.Ready():
+	default:
+		t.Error("Expected Ready to be closed once the pool is closed")
+	}
+	if pool.Available() != 0 {
+		t.Errorf("Expected no sessions after Close, got %d", pool.Available())
+	}
+}
+
+func TestSessionPoolBuildError(t *testing.T) {
+	pool := &SessionPool{
+		sessions: make(chan *Session, 3),
+		usage:    make(map[*Session]*sessionUsage),
+		ready:    make(chan struct{}),
+	}
+	errBuild := errors.New("out of memory")
+
+	calls := 0
+	pool.building.Add(1)
+	go pool.build(1, 3, func() (*Session, error) {
+		calls++
+		if calls == 2 {
+			return nil, errBuild
+		}
+		return &Session{}, nil
+	}, false, nil)
+
+	if err := pool.InitError(); err != nil && !errors.Is(err, errBuild) {
+		t.Errorf("InitError() before Ready = %v", err)
+	}
+	<-pool.Ready()
+	if err := pool.InitError(); !errors.Is(err, errBuild) {
+		t.Errorf("InitError() = %v, want %v", err, errBuild)
+	}
+	// The session created before the failure still serves
+	if pool.Available() != 1 {
+		t.Errorf("Expected 1 session, got %d", pool.Available())
+	}
+}
@@ -0,0 +1,69 @@
+package onnxruntime
+
+import (
+	"context"
+)
+
+// RunBound borrows a session and runs it with the session's persistent
+// IoBinding, returning the bound outputs. setup binds the inputs and
+// outputs of the run; the caller must close the returned Values.
+//
+// Each session keeps its IoBinding across calls, with whatever the previous
+// RunBound call on it bound, so for repeated fixed-shape inference setup
+// only needs to rebind what changed. Outputs bound to preallocated tensors
+// or to a device are reused rather than allocated on every run. Use
+// IoBinding.Session to keep per-session state, such as those tensors, and
+// bind every model output, since outputs are returned in model order.
+// Hooks are called with no Inputs.
+//
+// Example:
+//
+//	outputs := make(map[*onnxruntime.Session]*onnxruntime.Value)
+//	var mu sync.Mutex
+//	results, err := pool.RunBound(ctx, func(b *onnxruntime.IoBinding) error {
+//	    mu.Lock()
+//	    out, ok := outputs[b.Session()]
+//	    mu.Unlock()
+//	    if !ok {
+//	        var err error
+//	        if out, err = b.Session().NewOutputTensor("logits", []int64{1, 3}); err != nil {
+//	            return err
+//	        }
+//	        if err := b.BindOutput("logits", out); err != nil {
+//	            return err
+//	        }
+//	        mu.Lock()
+//	        outputs[b.Session()] = out
+//	        mu.Unlock()
+//	    }
+//	    return b.BindInput("input", input)
+//	})
+func (p *SessionPool) RunBound(ctx context.Context, setup func(b *IoBinding) error) (map[string]*Value, error) {
+	if p.closed.Load() {
+		return nil, ErrPoolClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p.borrowRun(ctx, "", nil, 1, "", func(ctx context.Context, session *Session) (map[string]*Value, error) {
+		if session.poolBinding == nil {
+			b, err := session.NewIoBinding()
+			if err != nil {
+				return nil, err
+			}
+			// The binding is owned by the session, whose safety net
+			// releases it; its own would keep the session reachable.
+			b.cleanup.Stop()
+			session.poolBinding = b
+			session.retained.poolBinding = b.ptr
+		}
+		b := session.poolBinding
+		if err := setup(b); err != nil {
+			return nil, err
+		}
+		if err := b.Run(ctx); err != nil {
+			return nil, err
+		}
+		return b.GetOutputValues()
+	})
+}
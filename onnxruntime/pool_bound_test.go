@@ -0,0 +1,72 @@
+package onnxruntime
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSessionPoolRunBound(t *testing.T) {
+	pool := newTestPool(t, 1)
+
+	input, err := NewTensorValue(pool.runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	want := runPoolInference(t, pool)
+	defer closeValues(want)
+	wantData, _, err := GetTensorData[float32](want["logits"])
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	var sessions []*Session
+	setup := func(b *IoBinding) error {
+		sessions = append(sessions, b.Session())
+		if len(sessions) > 1 {
+			// The binding still holds the first call's input and output
+			return nil
+		}
+		if err := b.BindInput("input", input); err != nil {
+			return err
+		}
+		out, err := b.Session().NewOutputTensor("logits", []int64{1, 3})
+		if err != nil {
+			return err
+		}
+		t.Cleanup(out.Close)
+		return b.BindOutput("logits", out)
+	}
+
+	for range 3 {
+		outputs, err := pool.RunBound(context.Background(), setup)
+		if err != nil {
+			t.Fatalf("RunBound() error = %v", err)
+		}
+		got, _, err := GetTensorData[float32](outputs["logits"])
+		if err != nil {
+			t.Fatalf("Failed to read bound output: %v", err)
+		}
+		if !slices.Equal(got, wantData) {
+			t.Errorf("RunBound() logits = %v, want %v", got, wantData)
+		}
+		closeValues(outputs)
+	}
+	if len(sessions) != 3 || sessions[0] != sessions[2] {
+		t.Errorf("Expected setup to run on the pool's only session 3 times, got %d", len(sessions))
+	}
+	if stats := pool.Stats(); stats.TotalRuns != 4 || pool.Available() != 1 {
+		t.Errorf("Expected 4 runs and the session returned, got %d runs, %d available", stats.TotalRuns, pool.Available())
+	}
+
+	errSetup := errors.New("no input")
+	if _, err := pool.RunBound(context.Background(), func(*IoBinding) error { return errSetup }); !errors.Is(err, errSetup) {
+		t.Errorf("RunBound() error = %v, want %v", err, errSetup)
+	}
+	if pool.Stats().TotalErrors != 1 {
+		t.Error("Expected the setup error to be counted")
+	}
+}
@@ -0,0 +1,53 @@
+package onnxruntime
+
+import (
+	"context"
+)
+
+// replaceUnhealthy replaces a session whose run panicked with a new one. It
+// runs in the background after the run, counted in p.inflight. If no
+// replacement can be created, the pool runs with one session fewer rather
+// than reuse the session's possibly corrupt state.
+func (p *SessionPool) replaceUnhealthy(session *Session) {
+	defer p.inflight.Done()
+	defer session.Close()
+
+	if p.closed.Load() {
+		return
+	}
+	replacement, err := p.newReplacement(session)
+	if err != nil {
+		p.loseSlot(session)
+		return
+	}
+	p.replaceSession(session, replacement)
+	p.release(replacement)
+}
+
+// newReplacement creates a session to take the place of session, profiled
+// if session was the pool's profiled session.
+func (p *SessionPool) newReplacement(session *Session) (*Session, error) {
+	if p.profiler != nil && p.profiler.session.Load() == session {
+		replacement, err := p.profiler.start()
+		if err != nil {
+			p.profiler.session.Store(nil)
+			p.profiler.setError(err)
+			return nil, err
+		}
+		p.replaced.Add(1)
+		return replacement, nil
+	}
+
+	replacement, err := p.recreate()
+	if err != nil {
+		return nil, err
+	}
+	if p.warmReplacements {
+		if err := replacement.WarmupFromMetadata(context.Background()); err != nil {
+			replacement.Close()
+			return nil, err
+		}
+	}
+	p.replaced.Add(1)
+	return replacement, nil
+}
@@ -0,0 +1,172 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/profiling"
+)
+
+// PoolProfiling configures continuous profiling of one session of a
+// SessionPool.
+//
+// ONNX Runtime can only start profiling when a session is created, so the
+// profiled session is replaced by a new one every RotateEveryNRuns runs: its
+// profile is ended, written to a new file in OutputDir, and parsed for
+// SessionPool.ProfilingSummary while the replacement is created in the
+// background. The pool runs with one session fewer until the replacement is
+// ready.
+type PoolProfiling struct {
+	// Enabled turns on profiling of the pool's first session.
+	Enabled bool
+
+	// OutputDir is the directory profile files are written to. Default
+	// os.TempDir().
+	OutputDir string
+
+	// RotateEveryNRuns is the number of runs of the profiled session per
+	// profile file. Default 1000.
+	RotateEveryNRuns int
+
+	// MaxFiles is the number of profile files kept; older files written by
+	// the pool are deleted. Default 10; a negative value keeps all files.
+	MaxFiles int
+}
+
+// poolProfiler manages the profiled session of a pool.
+type poolProfiler struct {
+	config     PoolProfiling
+	opts       SessionOptions
+	newSession func(opts *SessionOptions) (*Session, error)
+
+	// session is the profiled session, or nil if profiling stopped because a
+	// replacement could not be created.
+	session atomic.Pointer[Session]
+	runs    atomic.Int64
+
+	mu      sync.Mutex
+	seq     int
+	files   []string
+	summary *profiling.Summary
+	err     error
+}
+
+func newPoolProfiler(config PoolProfiling, opts *SessionOptions, newSession func(*SessionOptions) (*Session, error)) *poolProfiler {
+	if config.OutputDir == "" {
+		config.OutputDir = os.TempDir()
+	}
+	if config.RotateEveryNRuns <= 0 {
+		config.RotateEveryNRuns = 1000
+	}
+	if config.MaxFiles == 0 {
+		config.MaxFiles = 10
+	}
+	pp := &poolProfiler{config: config, newSession: newSession}
+	if opts != nil {
+		pp.opts = *opts
+	}
+	return pp
+}
+
+// start creates a new profiled session writing to the next profile file.
+func (pp *poolProfiler) start() (*Session, error) {
+	pp.mu.Lock()
+	pp.seq++
+	opts := pp.opts
+	opts.ProfilingOutputPath = filepath.Join(pp.config.OutputDir, fmt.Sprintf("onnxer_profile_%d_%06d", os.Getpid(), pp.seq))
+	pp.mu.Unlock()
+
+	session, err := pp.newSession(&opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profiled session: %w", err)
+	}
+	pp.session.Store(session)
+	pp.runs.Store(0)
+	return session, nil
+}
+
+// due counts a finished run of session and reports whether its profile
+// should be rotated.
+func (pp *poolProfiler) due(session *Session) bool {
+	return session == pp.session.Load() && pp.runs.Add(1)%int64(pp.config.RotateEveryNRuns) == 0
+}
+
+// finish parses the profile file at path, deleting the oldest files beyond
+// MaxFiles.
+func (pp *poolProfiler) finish(path string) {
+	profile, err := profiling.ReadFile(path)
+
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	pp.files = append(pp.files, path)
+	if pp.config.MaxFiles > 0 {
+		for len(pp.files) > pp.config.MaxFiles {
+			os.Remove(pp.files[0])
+			pp.files = pp.files[1:]
+		}
+	}
+	if err != nil {
+		pp.err = err
+		return
+	}
+	pp.summary = profile.Summarize(0)
+	pp.err = nil
+}
+
+func (pp *poolProfiler) setError(err error) {
+	pp.mu.Lock()
+	pp.err = err
+	pp.mu.Unlock()
+}
+
+// rotateProfile ends the profile of the profiled session, replaces it with
+// a new profiled session, and parses the profile. It runs in the background
+// after the session's run, counted in p.inflight.
+func (p *SessionPool) rotateProfile(session *Session) {
+	defer p.inflight.Done()
+	pp := p.profiler
+
+	path, err := session.EndProfiling()
+	if err != nil {
+		pp.setError(err)
+	}
+
+	var replacement *Session
+	if !p.closed.Load() {
+		replacement, err = pp.start()
+		if err != nil {
+			// Keep serving with the unprofiled session.
+			pp.session.Store(nil)
+			pp.setError(err)
+		}
+	}
+	if replacement != nil {
+		p.replaceSession(session, replacement)
+		session.Close()
+		p.release(replacement)
+	} else {
+		p.release(session)
+	}
+
+	if path != "" {
+		pp.finish(path)
+	}
+}
+
+// ProfilingSummary returns the summary of the most recently completed
+// profile of a pool with PoolConfig.Profiling enabled, or nil if no profile
+// has been completed. The error reports why the latest rotation failed, in
+// which case the summary is from an earlier profile; profiling stops if the
+// profiled session could not be replaced.
+func (p *SessionPool) ProfilingSummary() (*profiling.Summary, error) {
+	if p.profiler == nil {
+		return nil, fmt.Errorf("profiling is not enabled for this pool")
+	}
+	pp := p.profiler
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	return pp.summary, pp.err
+}
@@ -0,0 +1,110 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPoolProfilerDefaults(t *testing.T) {
+	pp := newPoolProfiler(PoolProfiling{Enabled: true}, nil, nil)
+	if pp.config.OutputDir != os.TempDir() || pp.config.RotateEveryNRuns != 1000 || pp.config.MaxFiles != 10 {
+		t.Errorf("config = %+v, want temp dir, 1000 runs, 10 files", pp.config)
+	}
+}
+
+func TestPoolProfilerFinish(t *testing.T) {
+	dir := t.TempDir()
+	pp := newPoolProfiler(PoolProfiling{Enabled: true, OutputDir: dir, MaxFiles: 2}, nil, nil)
+
+	var paths []string
+	for i := range 3 {
+		path := filepath.Join(dir, fmt.Sprintf("profile_%d.json", i))
+		profile := fmt.Sprintf(`[{"cat": "Node", "name": "n_kernel_time", "ph": "X", "dur": %d, "args": {"op_name": "Add", "provider": "CPUExecutionProvider"}}]`, 10*(i+1))
+		if err := os.WriteFile(path, []byte(profile), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		pp.finish(path)
+		paths = append(paths, path)
+	}
+
+	if _, err := os.Stat(paths[0]); !os.IsNotExist(err) {
+		t.Errorf("oldest profile was not deleted: %v", err)
+	}
+	for _, path := range paths[1:] {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("profile %s was deleted: %v", path, err)
+		}
+	}
+	if pp.summary == nil || pp.summary.KernelTime != 30*time.Microsecond {
+		t.Errorf("summary = %+v, want latest profile with 30µs kernel time", pp.summary)
+	}
+
+	missing := filepath.Join(dir, "missing.json")
+	pp.finish(missing)
+	if pp.err == nil || pp.summary.KernelTime != 30*time.Microsecond {
+		t.Errorf("after unreadable profile: err = %v, summary = %+v; want error and previous summary", pp.err, pp.summary)
+	}
+}
+
+func TestSessionPoolProfiling(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	dir := t.TempDir()
+	pool, err := NewSessionPoolFromFile(runtime, env, testModelPath(), 1, &PoolConfig{
+		Profiling: PoolProfiling{Enabled: true, OutputDir: dir, RotateEveryNRuns: 2, MaxFiles: 2},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	if summary, err := pool.ProfilingSummary(); summary != nil || err != nil {
+		t.Errorf("ProfilingSummary() before rotation = %v, %v, want nil, nil", summary, err)
+	}
+
+	// With one session every run is profiled, so six runs rotate the
+	// profile three times.
+	for range 6 {
+		outputs := runPoolInference(t, pool)
+		closeValues(outputs)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		summary, err := pool.ProfilingSummary()
+		if len(files) == 2 && summary != nil {
+			if err != nil {
+				t.Errorf("ProfilingSummary() error = %v", err)
+			}
+			if summary.Runs != 2 {
+				t.Errorf("summary.Runs = %d, want 2", summary.Runs)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("profiles not rotated: files %v, summary %v, err %v", files, summary, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if stats := pool.Stats(); stats.TotalRuns != 6 || stats.Sessions[0].Runs != 6 {
+		t.Errorf("Stats() = %d runs, session %d, want 6", stats.TotalRuns, stats.Sessions[0].Runs)
+	}
+	// The pool still runs with the replacement session.
+	closeValues(runPoolInference(t, pool))
+
+	_, err = newTestPool(t, 1).ProfilingSummary()
+	if err == nil {
+		t.Error("ProfilingSummary() without profiling succeeded, want error")
+	}
+}
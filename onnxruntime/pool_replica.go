@@ -0,0 +1,113 @@
+package onnxruntime
+
+import (
+	"os"
+)
+
+// replicaFactory creates the sessions of a pool with PoolConfig.FastReplicaInit.
+// The first session saves its graph-optimized model; the others load that
+// model with graph optimization disabled, so the model is optimized once
+// instead of once per session. If the optimized model cannot be saved or
+// loaded, sessions are created from the original model as usual.
+type replicaFactory struct {
+	runtime          *Runtime
+	env              *Env
+	prepackedWeights *PrepackedWeightsContainer
+	modelName        string
+
+	// path of the original model file, taken from the first session; empty
+	// for models loaded from memory
+	modelPath string
+
+	// newSession creates a session from the original model.
+	newSession func(*SessionOptions) (*Session, error)
+
+	seeded    bool
+	optimized []byte
+
+	// tempPath is the optimized model file to remove, if the factory
+	// created it.
+	tempPath string
+}
+
+// create returns the next session of the pool.
+func (f *replicaFactory) create(opts *SessionOptions) (*Session, error) {
+	if !f.seeded {
+		f.seeded = true
+		session, err := f.seed(opts)
+		if err == nil {
+			f.modelPath = session.modelPath
+		}
+		return session, err
+	}
+	if f.optimized == nil {
+		return f.newSession(opts)
+	}
+
+	var replicaOpts SessionOptions
+	if opts != nil {
+		replicaOpts = *opts
+	}
+	replicaOpts.GraphOptimization = GraphOptimizationDisabled
+	replicaOpts.OptimizedModelFilePath = ""
+	replicaOpts.ModelFormat = ModelFormatAuto
+	replicaOpts.UseORTModelBytesDirectly = false
+	replicaOpts.LoadProgress = nil
+
+	session, err := f.runtime.newSessionFromBytes(f.env, f.optimized, &replicaOpts, f.prepackedWeights)
+	if err != nil {
+		// Stop using an optimized model the runtime cannot load
+		f.optimized = nil
+		return f.newSession(opts)
+	}
+	session.modelName = f.modelName
+	session.modelPath = f.modelPath
+	return session, nil
+}
+
+// seed creates the first session, saving its optimized model unless the
+// options already name a file to save it to.
+func (f *replicaFactory) seed(opts *SessionOptions) (*Session, error) {
+	var seedOpts SessionOptions
+	if opts != nil {
+		seedOpts = *opts
+	}
+	if seedOpts.OptimizedModelFilePath == "" {
+		file, err := os.CreateTemp("", "onnxer_replica_*.onnx")
+		if err != nil {
+			return f.newSession(opts)
+		}
+		file.Close()
+		f.tempPath = file.Name()
+		seedOpts.OptimizedModelFilePath = f.tempPath
+		seedOpts.OptimizedModelFormat = ModelFormatONNX
+	}
+
+	session, err := f.newSession(&seedOpts)
+	if err != nil {
+		if f.tempPath == "" {
+			return nil, err
+		}
+		// Saving the optimized model may fail where loading does not, as
+		// for models over the 2GB protobuf limit
+		f.close()
+		return f.newSession(opts)
+	}
+	if data, err := os.ReadFile(seedOpts.OptimizedModelFilePath); err == nil && len(data) > 0 {
+		f.optimized = data
+	}
+	return session, nil
+}
+
+// close removes the optimized model file if the factory created it. The
+// sessions do not need it once created. It does nothing on a nil factory.
+func (f *replicaFactory) close() {
+	if f == nil {
+		return
+	}
+	if f.tempPath != "" {
+		os.Remove(f.tempPath)
+		f.tempPath = ""
+	}
+	f.optimized = nil
+}
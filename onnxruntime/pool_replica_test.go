@@ -0,0 +1,123 @@
+package onnxruntime
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSessionPoolFastReplicaInit(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	opts := &SessionOptions{GraphOptimization: GraphOptimizationAll}
+	pool, err := NewSessionPoolFromFile(runtime, env, testModelPath(), 3, &PoolConfig{
+		SessionOptions:  opts,
+		FastReplicaInit: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	if pool.prepackedWeights == nil {
+		t.Error("Expected FastReplicaInit to share pre-packed weights")
+	}
+	if opts.OptimizedModelFilePath != "" || opts.GraphOptimization != GraphOptimizationAll {
+		t.Error("FastReplicaInit modified the caller's SessionOptions")
+	}
+	if leftover, _ := filepath.Glob(filepath.Join(tmp, "onnxer_replica_*")); len(leftover) != 0 {
+		t.Errorf("Expected the optimized model file to be removed, found %v", leftover)
+	}
+
+	reference := newTestPool(t, 1)
+	want := runPoolInference(t, reference)
+	defer want["logits"].Close()
+	wantData, _, err := GetTensorData[float32](want["logits"])
+	if err != nil {
+		t.Fatalf("Failed to read reference output: %v", err)
+	}
+
+	// Each run borrows the next idle session, so all replicas are used
+	for range pool.Size() {
+		got := runPoolInference(t, pool)
+		gotData, _, err := GetTensorData[float32](got["logits"])
+		got["logits"].Close()
+		if err != nil {
+			t.Fatalf("Failed to read output: %v", err)
+		}
+		if !slices.Equal(gotData, wantData) {
+			t.Errorf("Replica output = %v, want %v", gotData, wantData)
+		}
+	}
+}
+
+func TestSessionPoolFastReplicaInitKeepsOptimizedModel(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "optimized.onnx")
+	pool, err := NewSessionPool(runtime, env, modelData, 2, &PoolConfig{
+		SessionOptions:  &SessionOptions{GraphOptimization: GraphOptimizationExtended, OptimizedModelFilePath: path},
+		FastReplicaInit: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		t.Errorf("Expected the optimized model to be kept at %s: %v", path, err)
+	}
+}
+
+func TestReplicaFactoryFallback(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	// Sessions that never write the optimized model leave the factory
+	// creating them from the original model.
+	var calls []string
+	f := &replicaFactory{newSession: func(opts *SessionOptions) (*Session, error) {
+		path := ""
+		if opts != nil {
+			path = opts.OptimizedModelFilePath
+		}
+		calls = append(calls, path)
+		return &Session{}, nil
+	}}
+	defer f.close()
+
+	for range 3 {
+		if _, err := f.create(nil); err != nil {
+			t.Fatalf("create() error = %v", err)
+		}
+	}
+	if len(calls) != 3 || calls[0] == "" || calls[1] != "" || calls[2] != "" {
+		t.Errorf("Optimized model paths = %q, want one for the first session only", calls)
+	}
+	if f.optimized != nil {
+		t.Error("Expected no optimized model")
+	}
+
+	f.close()
+	if _, err := os.Stat(calls[0]); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed, got %v", calls[0], err)
+	}
+}
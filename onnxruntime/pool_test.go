@@ -2,6 +2,7 @@ package onnxruntime
 
 import (
 	"context"
+	"errors"
 	"os"
 	"sync"
 	"sync/atomic"
@@ -52,6 +53,29 @@ func runPoolInference(t *testing.T, pool *SessionPool) map[string]*Value {
 	return outputs
 }
 
+func TestNewPool(t *testing.T) {
+	errLoad := errors.New("no such model")
+	opts := &SessionOptions{}
+	var loaded []*SessionOptions
+	_, err := newPool(&Runtime{}, &Env{}, 2, &PoolConfig{SessionOptions: opts}, func(opts *SessionOptions, prepackedWeights *PrepackedWeightsContainer) (*Session, error) {
+		loaded = append(loaded, opts)
+		return nil, errLoad
+	})
+	if !errors.Is(err, errLoad) {
+		t.Errorf("newPool() error = %v, want %v", err, errLoad)
+	}
+	if len(loaded) != 1 || loaded[0] != opts {
+		t.Errorf("load called with %v, want the pool's session options once", loaded)
+	}
+
+	if _, err := NewSessionPool(&Runtime{}, &Env{}, nil, 2, nil); err == nil {
+		t.Error("Expected an error for empty model data")
+	}
+	if _, err := NewSessionPoolFromFile(&Runtime{}, &Env{}, "model.onnx", 0, nil); err == nil {
+		t.Error("Expected an error for a pool size of 0")
+	}
+}
+
 func TestSessionPoolBasic(t *testing.T) {
 	pool := newTestPool(t, 2)
 
@@ -225,6 +249,68 @@ func TestSessionPoolClose(t *testing.T) {
 	pool.Close()
 }
 
+func TestSessionPoolDrain(t *testing.T) {
+	pool := newTestPool(t, 2)
+	if pool.State() != PoolActive {
+		t.Errorf("Expected a new pool to be active, got %v", pool.State())
+	}
+
+	aborted, err := pool.Drain(t.Context())
+	if err != nil || aborted != 0 {
+		t.Errorf("Drain of an idle pool = %d, %v; want 0, nil", aborted, err)
+	}
+	if pool.State() != PoolClosed {
+		t.Errorf("Expected a drained pool to be closed, got %v", pool.State())
+	}
+	if _, err := pool.Drain(t.Context()); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed draining twice, got %v", err)
+	}
+}
+
+func TestSessionPoolDrainDeadline(t *testing.T) {
+	// A pool with no free sessions keeps its callers in flight.
+	pool := &SessionPool{sessions: make(chan *Session), window: newLatencyWindow(time.Minute)}
+	pool.abortCtx, pool.abortRuns = context.WithCancel(context.Background())
+
+	const callers = 3
+	errs := make(chan error, callers)
+	for range callers {
+		go func() {
+			_, err := pool.runSession(context.Background(), nil, 1)
+			errs <- err
+		}()
+	}
+	for pool.waiting.Load() < callers {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	stateDuring := make(chan PoolState, 1)
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		stateDuring <- pool.State()
+	}()
+	aborted, err := pool.Drain(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if aborted != callers {
+		t.Errorf("Expected %d aborted runs, got %d", callers, aborted)
+	}
+	for range callers {
+		if err := <-errs; !errors.Is(err, ErrPoolDrained) {
+			t.Errorf("Expected ErrPoolDrained, got %v", err)
+		}
+	}
+	if s := <-stateDuring; s != PoolDraining {
+		t.Errorf("Expected the pool to be draining, got %v", s)
+	}
+	if pool.State() != PoolClosed {
+		t.Errorf("Expected the pool to be closed, got %v", pool.State())
+	}
+}
+
 func TestSessionPoolStats(t *testing.T) {
 	pool := newTestPool(t, 2)
 
@@ -249,6 +335,12 @@ func TestSessionPoolStats(t *testing.T) {
 	if stats.AvgLatency() == 0 {
 		t.Error("Expected non-zero avg latency")
 	}
+	if stats.P50Latency == 0 || stats.P50Latency > stats.P99Latency || stats.P99Latency > stats.MaxLatency {
+		t.Errorf("Expected ordered non-zero percentiles, got p50=%v p99=%v max=%v", stats.P50Latency, stats.P99Latency, stats.MaxLatency)
+	}
+	if stats.Window.Runs != 5 || stats.Window.Duration != time.Minute {
+		t.Errorf("Expected 5 runs in a 1m window, got %d in %v", stats.Window.Runs, stats.Window.Duration)
+	}
 }
 
 func TestSessionPoolTimeout(t *testing.T) {
@@ -346,6 +438,98 @@ func TestSessionPoolResetStats(t *testing.T) {
 	if stats.TotalRuns != 0 {
 		t.Errorf("Expected 0 runs after reset, got %d", stats.TotalRuns)
 	}
+	if stats.MaxLatency != 0 || stats.Window.Runs != 0 {
+		t.Errorf("Expected latency stats cleared after reset, got max=%v window runs=%d", stats.MaxLatency, stats.Window.Runs)
+	}
+}
+
+func TestSessionPoolBorrowStats(t *testing.T) {
+	var mu sync.Mutex
+	var infos []RunInfo
+	pool := newTestPool(t, 2, AfterRunHook(func(info *RunInfo) {
+		mu.Lock()
+		infos = append(infos, *info)
+		mu.Unlock()
+	}))
+
+	for i := 0; i < 4; i++ {
+		outputs := runPoolInference(t, pool)
+		closeValues(outputs)
+	}
+
+	stats := pool.Stats()
+	if stats.Borrows != 4 {
+		t.Errorf("Expected 4 borrows, got %d", stats.Borrows)
+	}
+	if stats.ActiveBorrowers != 0 || stats.WaitingCallers != 0 {
+		t.Errorf("Expected no active or waiting callers, got %d and %d", stats.ActiveBorrowers, stats.WaitingCallers)
+	}
+	if stats.PeakBorrowers != 1 {
+		t.Errorf("Expected peak of 1 borrower for sequential runs, got %d", stats.PeakBorrowers)
+	}
+	if len(stats.Sessions) != 2 {
+		t.Fatalf("Expected stats for 2 sessions, got %d", len(stats.Sessions))
+	}
+	var runs int64
+	for i, s := range stats.Sessions {
+		runs += s.Runs
+		if s.Runs > 0 && (s.BusyTime <= 0 || s.Utilization <= 0 || s.Utilization > 1) {
+			t.Errorf("Session %d: unexpected busy time %v, utilization %v", i, s.BusyTime, s.Utilization)
+		}
+	}
+	if runs != 4 {
+		t.Errorf("Expected per-session runs to sum to 4, got %d", runs)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, info := range infos {
+		if info.SessionIndex < 0 || info.SessionIndex >= 2 {
+			t.Errorf("SessionIndex out of range: %d", info.SessionIndex)
+		}
+		if info.ActiveBorrowers != 1 {
+			t.Errorf("Expected 1 active borrower, got %d", info.ActiveBorrowers)
+		}
+	}
+
+	pool.ResetStats()
+	stats = pool.Stats()
+	if stats.Borrows != 0 || stats.TotalQueueTime != 0 || stats.Sessions[0].Runs != 0 || stats.Sessions[1].Runs != 0 {
+		t.Errorf("Expected borrow stats cleared after reset, got %+v", stats)
+	}
+}
+
+func TestSessionPoolQueueTime(t *testing.T) {
+	var queueTime atomic.Int64
+	pool := newTestPool(t, 1, AfterRunHook(func(info *RunInfo) {
+		queueTime.Store(int64(info.QueueTime))
+	}))
+
+	// Hold the only session so the next Run has to wait.
+	session := <-pool.sessions
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		outputs := runPoolInference(t, pool)
+		closeValues(outputs)
+	}()
+
+	for pool.Stats().WaitingCallers != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	const hold = 20 * time.Millisecond
+	time.Sleep(hold)
+	pool.sessions <- session
+	<-done
+
+	if got := time.Duration(queueTime.Load()); got < hold {
+		t.Errorf("Expected RunInfo.QueueTime >= %v, got %v", hold, got)
+	}
+	stats := pool.Stats()
+	if stats.MaxQueueTime < hold || stats.AvgQueueTime() < hold {
+		t.Errorf("Expected queue time >= %v, got max=%v avg=%v", hold, stats.MaxQueueTime, stats.AvgQueueTime())
+	}
 }
 
 func TestSessionPoolSlogHook(t *testing.T) {
@@ -0,0 +1,129 @@
+package onnxruntime
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// StuckRunEvent describes a run that exceeded PoolConfig.StuckRunThreshold.
+type StuckRunEvent struct {
+	// ModelName is PoolConfig.ModelName, or empty if unset.
+	ModelName string
+
+	// SessionIndex identifies the stuck session, matching RunInfo.SessionIndex.
+	// Its replacement takes the same index.
+	SessionIndex int
+
+	// Elapsed is how long the run had been running when it was found stuck.
+	Elapsed time.Duration
+
+	// ReplaceError is the error that prevented the pool from creating a
+	// replacement session, leaving the pool one session short, or nil if
+	// the session was replaced.
+	ReplaceError error
+}
+
+// StuckRunHook is implemented by hooks that want to be told about stuck
+// runs, for instance to alert an operator. The pool calls StuckRun on every
+// hook in PoolConfig.Hooks that implements it, once it has replaced the
+// stuck session.
+//
+// Example:
+//
+//	type alertHook struct{ onnxruntime.Hook }
+//
+//	func (h alertHook) StuckRun(event *onnxruntime.StuckRunEvent) {
+//	    log.Printf("session %d stuck for %v", event.SessionIndex, event.Elapsed)
+//	}
+type StuckRunHook interface {
+	StuckRun(event *StuckRunEvent)
+}
+
+// poolWatchdog finds the runs of a pool that exceed
+// PoolConfig.StuckRunThreshold.
+type poolWatchdog struct {
+	threshold time.Duration
+	stuckRuns atomic.Int64
+}
+
+// runWatch watches one run of a pool session.
+type runWatch struct {
+	timer *time.Timer
+	state atomic.Int32 // watchRunning, watchDone or watchStuck
+}
+
+const (
+	watchRunning int32 = iota
+	watchDone
+	watchStuck
+)
+
+// watch starts watching a run of session, which cancel terminates. It
+// returns nil if the pool has no watchdog.
+func (p *SessionPool) watch(session *Session, index int, cancel context.CancelCauseFunc) *runWatch {
+	wd := p.watchdog
+	if wd == nil {
+		return nil
+	}
+	w := &runWatch{}
+	start := time.Now()
+	w.timer = time.AfterFunc(wd.threshold, func() {
+		if !w.state.CompareAndSwap(watchRunning, watchStuck) {
+			return
+		}
+		wd.stuckRuns.Add(1)
+		// Terminating may not work, which is why the session is replaced
+		cancel(ErrRunStuck)
+		p.replaceStuck(session, index, time.Since(start))
+	})
+	return w
+}
+
+// stop ends the watch once the run returns and reports whether the run was
+// found stuck, in which case its session has been removed from the pool and
+// the run's count in p.inflight handed to the replacement. It does nothing
+// on a nil watch.
+func (w *runWatch) stop() (stuck bool) {
+	if w == nil {
+		return false
+	}
+	w.timer.Stop()
+	return !w.state.CompareAndSwap(watchRunning, watchDone)
+}
+
+// replaceStuck replaces the stuck session of a run with a new session and
+// tells the hooks. It takes over the run's count in p.inflight, so Drain
+// waits for the replacement but not for the stuck run.
+func (p *SessionPool) replaceStuck(session *Session, index int, elapsed time.Duration) {
+	defer p.inflight.Done()
+
+	event := &StuckRunEvent{
+		ModelName:    session.modelName,
+		SessionIndex: index,
+		Elapsed:      elapsed,
+	}
+	if p.closed.Load() {
+		event.ReplaceError = ErrPoolClosed
+	} else if replacement, err := p.newReplacement(session); err != nil {
+		event.ReplaceError = err
+		p.loseSlot(session)
+	} else {
+		p.replaceSession(session, replacement)
+		p.release(replacement)
+	}
+
+	for _, h := range p.hooks {
+		if sh, ok := h.(StuckRunHook); ok {
+			sh.StuckRun(event)
+		}
+	}
+}
+
+// stuckRuns returns the number of runs found stuck since the last ResetStats.
+func (p *SessionPool) stuckRuns() int64 {
+	if p.watchdog == nil {
+		return 0
+	}
+	return p.watchdog.stuckRuns.Load()
+}
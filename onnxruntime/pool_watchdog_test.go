@@ -0,0 +1,108 @@
+package onnxruntime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// stuckRunRecorder is a hook recording stuck run events.
+type stuckRunRecorder struct {
+	events chan *StuckRunEvent
+}
+
+func (h *stuckRunRecorder) BeforeRun(*RunInfo) {}
+func (h *stuckRunRecorder) AfterRun(*RunInfo)  {}
+func (h *stuckRunRecorder) StuckRun(event *StuckRunEvent) {
+	h.events <- event
+}
+
+func TestSessionPoolStuckRunThreshold(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	// The injected latency stands in for a kernel that takes too long
+	faults := NewFaultInjector(FaultConfig{Latency: time.Second, Seed: 1})
+	hook := &stuckRunRecorder{events: make(chan *StuckRunEvent, 1)}
+	pool, err := NewSessionPool(runtime, env, modelData, 1, &PoolConfig{
+		SessionOptions:    &SessionOptions{FaultInjector: faults},
+		Hooks:             []Hook{hook},
+		ModelName:         "stuck",
+		StuckRunThreshold: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	input, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	_, err = pool.Run(context.Background(), map[string]*Value{"input": input})
+	if !errors.Is(err, ErrRunStuck) {
+		t.Fatalf("Expected ErrRunStuck, got %v", err)
+	}
+
+	select {
+	case event := <-hook.events:
+		if event.ModelName != "stuck" || event.SessionIndex != 0 {
+			t.Errorf("Unexpected event %+v", event)
+		}
+		if event.Elapsed < 20*time.Millisecond {
+			t.Errorf("Elapsed = %v, want at least the threshold", event.Elapsed)
+		}
+		if event.ReplaceError != nil {
+			t.Errorf("Failed to replace the stuck session: %v", event.ReplaceError)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the stuck run event")
+	}
+	if stats := pool.Stats(); stats.StuckRuns != 1 || stats.ReplacedSessions != 1 {
+		t.Errorf("StuckRuns = %d, ReplacedSessions = %d, want 1 and 1", stats.StuckRuns, stats.ReplacedSessions)
+	}
+
+	// The replacement serves the next run
+	faults.SetConfig(FaultConfig{})
+	outputs, err := pool.Run(context.Background(), map[string]*Value{"input": input})
+	if err != nil {
+		t.Fatalf("Run() after replacement error = %v", err)
+	}
+	closeValues(outputs)
+	if pool.Available() != 1 {
+		t.Errorf("Expected 1 idle session, got %d", pool.Available())
+	}
+}
+
+func TestRunWatchStop(t *testing.T) {
+	p := &SessionPool{watchdog: &poolWatchdog{threshold: time.Hour}}
+	cancelled := false
+	w := p.watch(&Session{}, 0, func(error) { cancelled = true })
+	if w.stop() {
+		t.Error("stop() reported a run that finished in time as stuck")
+	}
+	if cancelled {
+		t.Error("A run that finished in time was terminated")
+	}
+
+	var none *runWatch
+	if none.stop() {
+		t.Error("stop() on a nil watch reported a stuck run")
+	}
+	if (&SessionPool{}).watch(&Session{}, 0, func(error) {}) != nil {
+		t.Error("Expected no watch without a watchdog")
+	}
+}
@@ -0,0 +1,115 @@
+package postprocess
+
+import (
+	"fmt"
+	"math"
+)
+
+// Box is an axis-aligned bounding box given by its top-left (X1, Y1) and
+// bottom-right (X2, Y2) corners, in the model's input coordinates.
+type Box struct {
+	X1, Y1, X2, Y2 float32
+}
+
+// Width returns the box's width, or 0 if it is empty.
+func (b Box) Width() float32 {
+	return max(b.X2-b.X1, 0)
+}
+
+// Height returns the box's height, or 0 if it is empty.
+func (b Box) Height() float32 {
+	return max(b.Y2-b.Y1, 0)
+}
+
+// Area returns the box's area, or 0 if it is empty.
+func (b Box) Area() float32 {
+	return b.Width() * b.Height()
+}
+
+// IoU returns the intersection over union of b and o, from 0 for disjoint
+// boxes to 1 for identical ones.
+func (b Box) IoU(o Box) float32 {
+	inter := Box{max(b.X1, o.X1), max(b.Y1, o.Y1), min(b.X2, o.X2), min(b.Y2, o.Y2)}.Area()
+	union := b.Area() + o.Area() - inter
+	if union <= 0 {
+		return 0
+	}
+	return inter / union
+}
+
+// Scale returns b with its x coordinates multiplied by sx and its y
+// coordinates by sy, for example to map boxes from the model's input size
+// back to the original image.
+func (b Box) Scale(sx, sy float32) Box {
+	return Box{b.X1 * sx, b.Y1 * sy, b.X2 * sx, b.Y2 * sy}
+}
+
+// BoxFormat describes how a model encodes a box in four values.
+type BoxFormat int
+
+const (
+	// BoxCXCYWH is center x, center y, width, height, as YOLOv5 and YOLOv8
+	// output.
+	BoxCXCYWH BoxFormat = iota
+	// BoxXYXY is left, top, right, bottom.
+	BoxXYXY
+	// BoxXYWH is left, top, width, height.
+	BoxXYWH
+	// BoxYXYX is top, left, bottom, right, as TensorFlow detection models
+	// output.
+	BoxYXYX
+)
+
+// String returns a human-readable name for the format.
+func (f BoxFormat) String() string {
+	switch f {
+	case BoxCXCYWH:
+		return "CXCYWH"
+	case BoxXYXY:
+		return "XYXY"
+	case BoxXYWH:
+		return "XYWH"
+	case BoxYXYX:
+		return "YXYX"
+	default:
+		return fmt.Sprintf("BoxFormat(%d)", int(f))
+	}
+}
+
+// DecodeBox converts four values in format f into a Box.
+func DecodeBox(v [4]float32, f BoxFormat) Box {
+	switch f {
+	case BoxXYXY:
+		return Box{v[0], v[1], v[2], v[3]}
+	case BoxXYWH:
+		return Box{v[0], v[1], v[0] + v[2], v[1] + v[3]}
+	case BoxYXYX:
+		return Box{v[1], v[0], v[3], v[2]}
+	default:
+		return Box{v[0] - v[2]/2, v[1] - v[3]/2, v[0] + v[2]/2, v[1] + v[3]/2}
+	}
+}
+
+// Anchor is a prior box, given by its center and size, that SSD-style
+// models predict offsets from.
+type Anchor struct {
+	CX, CY, W, H float32
+}
+
+// DefaultVariances are the SSD box coder variances for the center and size
+// offsets. TensorFlow models express them as scale factors 10, 10, 5, 5.
+var DefaultVariances = [2]float32{0.1, 0.2}
+
+// DecodeAnchor applies the offsets (dx, dy, dw, dh) predicted for anchor a:
+//
+//	cx = a.CX + dx*variances[0]*a.W
+//	cy = a.CY + dy*variances[0]*a.H
+//	w  = a.W * exp(dw*variances[1])
+//	h  = a.H * exp(dh*variances[1])
+func DecodeAnchor(d [4]float32, a Anchor, variances [2]float32) Box {
+	cx := a.CX + d[0]*variances[0]*a.W
+	cy := a.CY + d[1]*variances[0]*a.H
+	w := a.W * float32(math.Exp(float64(d[2]*variances[1])))
+	h := a.H * float32(math.Exp(float64(d[3]*variances[1])))
+	return DecodeBox([4]float32{cx, cy, w, h}, BoxCXCYWH)
+}
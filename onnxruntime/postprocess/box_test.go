@@ -0,0 +1,65 @@
+package postprocess
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecodeBox(t *testing.T) {
+	tests := []struct {
+		format BoxFormat
+		v      [4]float32
+	}{
+		{BoxCXCYWH, [4]float32{20, 30, 20, 40}},
+		{BoxXYXY, [4]float32{10, 10, 30, 50}},
+		{BoxXYWH, [4]float32{10, 10, 20, 40}},
+		{BoxYXYX, [4]float32{10, 10, 50, 30}},
+	}
+	want := Box{10, 10, 30, 50}
+	for _, tt := range tests {
+		if got := DecodeBox(tt.v, tt.format); got != want {
+			t.Errorf("%v: DecodeBox(%v) = %v, want %v", tt.format, tt.v, got, want)
+		}
+	}
+}
+
+func TestBoxIoU(t *testing.T) {
+	a := Box{0, 0, 10, 10}
+	tests := []struct {
+		b    Box
+		want float32
+	}{
+		{a, 1},
+		{Box{5, 0, 15, 10}, 50.0 / 150},
+		{Box{10, 10, 20, 20}, 0},
+		{Box{20, 20, 30, 30}, 0},
+		{Box{2, 2, 4, 4}, 4.0 / 100},
+	}
+	for _, tt := range tests {
+		if got := a.IoU(tt.b); math.Abs(float64(got-tt.want)) > 1e-6 {
+			t.Errorf("IoU(%v, %v) = %v, want %v", a, tt.b, got, tt.want)
+		}
+	}
+	if got := (Box{}).IoU(Box{}); got != 0 {
+		t.Errorf("Expected IoU of empty boxes to be 0, got %v", got)
+	}
+	if got := (Box{5, 5, 1, 1}).Area(); got != 0 {
+		t.Errorf("Expected an inverted box to have no area, got %v", got)
+	}
+}
+
+func TestDecodeAnchor(t *testing.T) {
+	a := Anchor{CX: 0.5, CY: 0.5, W: 0.2, H: 0.4}
+	if got := DecodeAnchor([4]float32{}, a, DefaultVariances); got != (Box{0.4, 0.3, 0.6, 0.7}) {
+		t.Errorf("Expected zero offsets to give the anchor box, got %v", got)
+	}
+
+	// dx = 1 shifts by variance * width; dw = ln(2) / variance doubles the width.
+	got := DecodeAnchor([4]float32{1, 0, float32(math.Ln2 / 0.2), 0}, a, DefaultVariances)
+	want := Box{0.32, 0.3, 0.72, 0.7}
+	for i, pair := range [][2]float32{{got.X1, want.X1}, {got.Y1, want.Y1}, {got.X2, want.X2}, {got.Y2, want.Y2}} {
+		if math.Abs(float64(pair[0]-pair[1])) > 1e-6 {
+			t.Errorf("coordinate %d: got %v, want %v", i, pair[0], pair[1])
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package postprocess
+
+import (
+	"fmt"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// DecodeOptions configures how detection outputs are read.
+type DecodeOptions struct {
+	// Format is the encoding of the box values. The zero value, BoxCXCYWH,
+	// matches YOLOv5 and YOLOv8. It is ignored when Anchors is set.
+	Format BoxFormat
+
+	// Transposed reads feature-major outputs, [4+C, N] per image, as
+	// YOLOv8 and later produce, instead of one row per candidate.
+	Transposed bool
+
+	// Objectness reads an objectness score after the box values, as YOLOv5
+	// outputs; each class score is multiplied by it.
+	Objectness bool
+
+	// ScoreAndClassID reads the two values after the box as a score and a
+	// class index instead of per-class scores, as end-to-end models such
+	// as YOLOv10 output.
+	ScoreAndClassID bool
+
+	// SkipBackground ignores class 0, the background class of SSD models.
+	// Class IDs of the remaining classes are unchanged.
+	SkipBackground bool
+
+	// ScoreThreshold drops candidates whose best class score is lower.
+	ScoreThreshold float32
+
+	// Anchors, when set, makes the box values offsets from the anchor of
+	// the same index, decoded with DecodeAnchor. There must be one anchor
+	// per candidate.
+	Anchors []Anchor
+
+	// Variances scale anchor offsets. Zero means DefaultVariances.
+	Variances [2]float32
+
+	// NMS, when set, applies non-maximum suppression to each image's
+	// detections. Leave it nil for models with built-in NMS.
+	NMS *NMSOptions
+}
+
+// Decode reads the detections of a YOLO-style output holding, for every
+// candidate, four box values followed by class scores (and, with
+// opts.Objectness, an objectness score before them). The output's shape is
+// [batch, N, features], or [batch, features, N] with opts.Transposed; the
+// batch dimension may be omitted. Float32, float16, and float64 outputs
+// are supported.
+//
+// It returns one slice of detections per image.
+//
+// Example:
+//
+//	detections, err := postprocess.Decode(outputs["output0"], &postprocess.DecodeOptions{
+//	    Transposed:     true, // YOLOv8: [1, 84, 8400]
+//	    ScoreThreshold: 0.25,
+//	    NMS:            &postprocess.NMSOptions{IoUThreshold: 0.7},
+//	})
+func Decode(v *ort.Value, opts *DecodeOptions) ([][]Detection, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("decode options are required")
+	}
+	data, shape, err := ort.GetTensorDataAsFloat32(v)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := newRowReader(data, shape, opts.Transposed)
+	if err != nil {
+		return nil, err
+	}
+	return decode(rows, rows, 4, opts)
+}
+
+// DecodeSplit reads the detections of an SSD-style model that returns box
+// values and class scores as separate outputs of shape [batch, N, 4] and
+// [batch, N, C]; the batch dimension may be omitted. opts.Transposed and
+// opts.Objectness apply to the scores output.
+//
+// It returns one slice of detections per image.
+func DecodeSplit(boxes, scores *ort.Value, opts *DecodeOptions) ([][]Detection, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("decode options are required")
+	}
+	boxData, boxShape, err := ort.GetTensorDataAsFloat32(boxes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read boxes: %w", err)
+	}
+	scoreData, scoreShape, err := ort.GetTensorDataAsFloat32(scores)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scores: %w", err)
+	}
+	boxRows, err := newRowReader(boxData, boxShape, false)
+	if err != nil {
+		return nil, fmt.Errorf("boxes: %w", err)
+	}
+	scoreRows, err := newRowReader(scoreData, scoreShape, opts.Transposed)
+	if err != nil {
+		return nil, fmt.Errorf("scores: %w", err)
+	}
+	if boxRows.features != 4 {
+		return nil, fmt.Errorf("boxes have %d values per candidate, expected 4", boxRows.features)
+	}
+	if boxRows.batch != scoreRows.batch || boxRows.n != scoreRows.n {
+		return nil, fmt.Errorf("boxes shape %v does not match scores shape %v", boxShape, scoreShape)
+	}
+	return decode(boxRows, scoreRows, 0, opts)
+}
+
+// rowReader reads candidate features from a [batch, n, features] or, when
+// transposed, [batch, features, n] tensor.
+type rowReader struct {
+	data               []float32
+	batch, n, features int
+	transposed         bool
+}
+
+func newRowReader(data []float32, shape []int64, transposed bool) (rowReader, error) {
+	if len(shape) == 2 {
+		shape = append([]int64{1}, shape...)
+	}
+	if len(shape) != 3 {
+		return rowReader{}, fmt.Errorf("unsupported detection output shape %v: expected rank 2 or 3", shape)
+	}
+	r := rowReader{data: data, batch: int(shape[0]), n: int(shape[1]), features: int(shape[2]), transposed: transposed}
+	if transposed {
+		r.n, r.features = r.features, r.n
+	}
+	if r.batch*r.n*r.features != len(data) {
+		return rowReader{}, fmt.Errorf("shape %v does not match data length %d", shape, len(data))
+	}
+	return r, nil
+}
+
+// at returns feature j of candidate i in image b.
+func (r rowReader) at(b, i, j int) float32 {
+	if r.transposed {
+		return r.data[(b*r.features+j)*r.n+i]
+	}
+	return r.data[(b*r.n+i)*r.features+j]
+}
+
+// decode reads the box values from columns 0-3 of boxes and the scores
+// from column offset on of scores.
+func decode(boxes, scores rowReader, offset int, o *DecodeOptions) ([][]Detection, error) {
+	classStart := offset
+	if o.Objectness {
+		classStart++
+	}
+	switch {
+	case o.ScoreAndClassID && scores.features < offset+2:
+		return nil, fmt.Errorf("%d values per candidate, expected a box, score, and class ID", scores.features)
+	case !o.ScoreAndClassID && scores.features <= classStart:
+		return nil, fmt.Errorf("%d values per candidate leave no class scores", scores.features)
+	}
+	if o.Anchors != nil && len(o.Anchors) != boxes.n {
+		return nil, fmt.Errorf("got %d anchors for %d candidates", len(o.Anchors), boxes.n)
+	}
+	variances := o.Variances
+	if variances == [2]float32{} {
+		variances = DefaultVariances
+	}
+
+	out := make([][]Detection, scores.batch)
+	for b := range out {
+		var dets []Detection
+		for i := range scores.n {
+			var score float32
+			classID := -1
+			if o.ScoreAndClassID {
+				score, classID = scores.at(b, i, offset), int(scores.at(b, i, offset+1))
+			} else {
+				first := classStart
+				if o.SkipBackground {
+					first++
+				}
+				for j := first; j < scores.features; j++ {
+					if s := scores.at(b, i, j); classID < 0 || s > score {
+						score, classID = s, j-classStart
+					}
+				}
+				if o.Objectness {
+					score *= scores.at(b, i, offset)
+				}
+			}
+			if classID < 0 || (o.SkipBackground && classID == 0) || score < o.ScoreThreshold {
+				continue
+			}
+
+			v := [4]float32{boxes.at(b, i, 0), boxes.at(b, i, 1), boxes.at(b, i, 2), boxes.at(b, i, 3)}
+			box := DecodeBox(v, o.Format)
+			if o.Anchors != nil {
+				box = DecodeAnchor(v, o.Anchors[i], variances)
+			}
+			dets = append(dets, Detection{Box: box, Score: score, ClassID: classID})
+		}
+		if o.NMS != nil {
+			dets = NMS(dets, *o.NMS)
+		}
+		out[b] = dets
+	}
+	return out, nil
+}
@@ -0,0 +1,149 @@
+package postprocess
+
+import (
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func mustRows(t *testing.T, data []float32, shape []int64, transposed bool) rowReader {
+	t.Helper()
+	r, err := newRowReader(data, shape, transposed)
+	if err != nil {
+		t.Fatalf("newRowReader failed: %v", err)
+	}
+	return r
+}
+
+func TestDecodeYOLOv5(t *testing.T) {
+	// [1, 3, 4+1+2]: cx, cy, w, h, objectness, class scores
+	data := []float32{
+		20, 30, 20, 40, 0.9, 0.1, 0.8,
+		21, 31, 20, 40, 0.8, 0.1, 0.9, // overlaps the first, lower product score
+		50, 50, 10, 10, 0.2, 0.9, 0.1, // below threshold after objectness
+	}
+	rows := mustRows(t, data, []int64{1, 3, 7}, false)
+	got, err := decode(rows, rows, 4, &DecodeOptions{
+		Objectness:     true,
+		ScoreThreshold: 0.25,
+		NMS:            &NMSOptions{},
+	})
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(got) != 1 || len(got[0]) != 1 {
+		t.Fatalf("Expected one detection, got %v", got)
+	}
+	d := got[0][0]
+	if d.ClassID != 1 || d.Box != (Box{10, 10, 30, 50}) || d.Score < 0.71 || d.Score > 0.73 {
+		t.Errorf("Unexpected detection %+v", d)
+	}
+}
+
+func TestDecodeYOLOv8Transposed(t *testing.T) {
+	// [1, 4+2, 2] feature-major: two candidates, columns are candidates.
+	data := []float32{
+		20, 60, // cx
+		30, 60, // cy
+		20, 10, // w
+		40, 10, // h
+		0.1, 0.7, // class 0
+		0.6, 0.2, // class 1
+	}
+	rows := mustRows(t, data, []int64{1, 6, 2}, true)
+	got, err := decode(rows, rows, 4, &DecodeOptions{Transposed: true})
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	want := []Detection{
+		{Box: Box{10, 10, 30, 50}, Score: 0.6, ClassID: 1},
+		{Box: Box{55, 55, 65, 65}, Score: 0.7, ClassID: 0},
+	}
+	if !slices.Equal(got[0], want) {
+		t.Errorf("Expected %v, got %v", want, got[0])
+	}
+}
+
+func TestDecodeScoreAndClassID(t *testing.T) {
+	// YOLOv10: [x1, y1, x2, y2, score, class] per row, batch of two.
+	data := []float32{
+		10, 10, 30, 50, 0.9, 3,
+		0, 0, 1, 1, 0.1, 2,
+		5, 5, 6, 6, 0.5, 7,
+		0, 0, 1, 1, 0.0, 0,
+	}
+	rows := mustRows(t, data, []int64{2, 2, 6}, false)
+	got, err := decode(rows, rows, 4, &DecodeOptions{Format: BoxXYXY, ScoreAndClassID: true, ScoreThreshold: 0.25})
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected two images, got %d", len(got))
+	}
+	if want := []Detection{{Box: Box{10, 10, 30, 50}, Score: 0.9, ClassID: 3}}; !slices.Equal(got[0], want) {
+		t.Errorf("Image 0: expected %v, got %v", want, got[0])
+	}
+	if want := []Detection{{Box: Box{5, 5, 6, 6}, Score: 0.5, ClassID: 7}}; !slices.Equal(got[1], want) {
+		t.Errorf("Image 1: expected %v, got %v", want, got[1])
+	}
+}
+
+func TestDecodeSSDAnchors(t *testing.T) {
+	boxes := mustRows(t, []float32{0, 0, 0, 0, 0, 0, 0, 0}, []int64{2, 4}, false)
+	// Background, class 1, class 2. The first candidate is mostly background.
+	scores := mustRows(t, []float32{0.9, 0.05, 0.05, 0.1, 0.2, 0.7}, []int64{2, 3}, false)
+	anchors := []Anchor{{CX: 0.5, CY: 0.5, W: 0.2, H: 0.2}, {CX: 0.25, CY: 0.25, W: 0.5, H: 0.5}}
+
+	got, err := decode(boxes, scores, 0, &DecodeOptions{SkipBackground: true, ScoreThreshold: 0.3, Anchors: anchors})
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if want := []Detection{{Box: Box{0, 0, 0.5, 0.5}, Score: 0.7, ClassID: 2}}; !slices.Equal(got[0], want) {
+		t.Errorf("Expected %v, got %v", want, got[0])
+	}
+
+	if _, err := decode(boxes, scores, 0, &DecodeOptions{Anchors: anchors[:1]}); err == nil {
+		t.Error("Expected an error for a mismatched anchor count")
+	}
+}
+
+func TestDecodeShapeErrors(t *testing.T) {
+	if _, err := newRowReader(make([]float32, 6), []int64{6}, false); err == nil {
+		t.Error("Expected an error for a rank-1 output")
+	}
+	if _, err := newRowReader(make([]float32, 5), []int64{1, 2, 3}, false); err == nil {
+		t.Error("Expected an error for a shape mismatch")
+	}
+	rows := mustRows(t, make([]float32, 8), []int64{2, 4}, false)
+	if _, err := decode(rows, rows, 4, &DecodeOptions{}); err == nil {
+		t.Error("Expected an error for rows without class scores")
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	v, err := ort.NewTensorValue(runtime, []float32{20, 30, 20, 40, 0.2, 0.8}, []int64{1, 1, 6})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer v.Close()
+
+	got, err := Decode(v, &DecodeOptions{ScoreThreshold: 0.5})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if want := []Detection{{Box: Box{10, 10, 30, 50}, Score: 0.8, ClassID: 1}}; len(got) != 1 || !slices.Equal(got[0], want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	boxes, err := ort.NewTensorValue(runtime, []float32{10, 10, 30, 50}, []int64{1, 1, 4})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer boxes.Close()
+	if _, err := DecodeSplit(boxes, v, &DecodeOptions{}); err != nil {
+		t.Errorf("DecodeSplit failed: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+// Package postprocess turns raw model outputs into results: scored,
+// class-labelled bounding boxes for detection models and probabilities and
+// top-k classes for classifiers.
+//
+// Decode reads YOLO-style outputs, one row of box coordinates and scores
+// per candidate, in either row-major ([N, 4+C], YOLOv5) or feature-major
+// ([4+C, N], YOLOv8) layout. DecodeSplit reads SSD-style models that return
+// boxes and class scores as separate outputs, optionally as offsets from a
+// set of anchors. Both filter candidates by score and can apply
+// non-maximum suppression, which is also available on its own as NMS.
+//
+// Softmax, Argmax, and TopK apply the usual classification post-processing
+// to any axis of an output Value.
+package postprocess
@@ -0,0 +1,57 @@
+package postprocess
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Detection is a scored, classified box.
+type Detection struct {
+	Box     Box
+	Score   float32
+	ClassID int
+}
+
+// NMSOptions configures non-maximum suppression.
+type NMSOptions struct {
+	// IoUThreshold is the overlap above which the lower-scoring of two
+	// boxes is suppressed. Zero means 0.45.
+	IoUThreshold float32
+
+	// ClassAgnostic lets boxes suppress boxes of other classes. By default
+	// only boxes of the same class suppress each other.
+	ClassAgnostic bool
+
+	// MaxDetections, when positive, limits the number of boxes kept.
+	MaxDetections int
+}
+
+// NMS performs greedy non-maximum suppression: it keeps the highest-scoring
+// box, drops the boxes overlapping it by more than opts.IoUThreshold, and
+// repeats with the remaining boxes. It returns the kept detections ordered
+// by descending score and does not modify dets.
+func NMS(dets []Detection, opts NMSOptions) []Detection {
+	threshold := opts.IoUThreshold
+	if threshold == 0 {
+		threshold = 0.45
+	}
+
+	sorted := slices.Clone(dets)
+	slices.SortStableFunc(sorted, func(a, b Detection) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	var kept []Detection
+	for _, d := range sorted {
+		if opts.MaxDetections > 0 && len(kept) == opts.MaxDetections {
+			break
+		}
+		suppressed := slices.ContainsFunc(kept, func(k Detection) bool {
+			return (opts.ClassAgnostic || k.ClassID == d.ClassID) && k.Box.IoU(d.Box) > threshold
+		})
+		if !suppressed {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,51 @@
+package postprocess
+
+import (
+	"slices"
+	"testing"
+)
+
+func scores(dets []Detection) []float32 {
+	out := make([]float32, len(dets))
+	for i, d := range dets {
+		out[i] = d.Score
+	}
+	return out
+}
+
+func TestNMS(t *testing.T) {
+	dets := []Detection{
+		{Box: Box{0, 0, 10, 10}, Score: 0.8, ClassID: 0},
+		{Box: Box{1, 1, 11, 11}, Score: 0.9, ClassID: 0}, // overlaps the first
+		{Box: Box{1, 0, 11, 10}, Score: 0.7, ClassID: 1}, // overlaps, other class
+		{Box: Box{50, 50, 60, 60}, Score: 0.6, ClassID: 0},
+	}
+	input := slices.Clone(dets)
+
+	got := NMS(dets, NMSOptions{IoUThreshold: 0.5})
+	if want := []float32{0.9, 0.7, 0.6}; !slices.Equal(scores(got), want) {
+		t.Errorf("Per-class NMS kept scores %v, want %v", scores(got), want)
+	}
+	if !slices.Equal(dets, input) {
+		t.Error("NMS modified its input")
+	}
+
+	got = NMS(dets, NMSOptions{IoUThreshold: 0.5, ClassAgnostic: true})
+	if want := []float32{0.9, 0.6}; !slices.Equal(scores(got), want) {
+		t.Errorf("Class-agnostic NMS kept scores %v, want %v", scores(got), want)
+	}
+
+	got = NMS(dets, NMSOptions{IoUThreshold: 0.5, MaxDetections: 2})
+	if want := []float32{0.9, 0.7}; !slices.Equal(scores(got), want) {
+		t.Errorf("Limited NMS kept scores %v, want %v", scores(got), want)
+	}
+
+	// A threshold above the overlap keeps both boxes.
+	got = NMS(dets[:2], NMSOptions{IoUThreshold: 0.9})
+	if len(got) != 2 {
+		t.Errorf("Expected both boxes to be kept, got %v", got)
+	}
+	if got := NMS(nil, NMSOptions{}); len(got) != 0 {
+		t.Errorf("Expected no detections, got %v", got)
+	}
+}
@@ -0,0 +1,185 @@
+package postprocess
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Softmax converts the logits in v to probabilities along axis, returning
+// them with v's shape. A negative axis counts from the last dimension, so -1
+// is the usual class axis. Float32, float16, bfloat16, and float64 tensors
+// are supported.
+//
+// The computation subtracts each slice's maximum before exponentiating, so
+// large logits do not overflow. Infinite logits take the limit: the +Inf
+// entries of a slice share its probability, and a slice of only -Inf
+// entries is uniform. A NaN logit makes its whole slice NaN.
+func Softmax(v *ort.Value, axis int) ([]float32, []int64, error) {
+	data, shape, err := ort.GetTensorDataAsFloat32(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	l, err := newAxisLayout(shape, axis)
+	if err != nil {
+		return nil, nil, err
+	}
+	softmax(data, l)
+	return data, shape, nil
+}
+
+// Argmax returns the index of the largest element along axis for every
+// other position, with v's shape minus that axis. Ties go to the lowest
+// index and NaN elements are ignored unless a slice holds nothing else.
+func Argmax(v *ort.Value, axis int) ([]int64, []int64, error) {
+	data, shape, err := ort.GetTensorDataAsFloat32(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	l, err := newAxisLayout(shape, axis)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return argmax(data, l), slices.Delete(slices.Clone(shape), l.axis, l.axis+1), nil
+}
+
+// TopK returns the indices and values of the k largest elements along axis,
+// largest first, with v's shape except that axis has size k. k larger than
+// the axis is reduced to its size. Ties keep index order and NaN elements
+// sort last.
+//
+// Example:
+//
+//	indices, scores, _, err := postprocess.TopK(outputs["logits"], 5, -1)
+//	for i, class := range indices {
+//	    fmt.Println(labels[class], scores[i])
+//	}
+func TopK(v *ort.Value, k, axis int) ([]int64, []float32, []int64, error) {
+	if k <= 0 {
+		return nil, nil, nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+	data, shape, err := ort.GetTensorDataAsFloat32(v)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	l, err := newAxisLayout(shape, axis)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	k = min(k, l.n)
+	indices, values := topK(data, l, k)
+
+	outShape := slices.Clone(shape)
+	outShape[l.axis] = int64(k)
+	return indices, values, outShape, nil
+}
+
+// axisLayout views a row-major tensor as [outer, n, inner] around one axis.
+type axisLayout struct {
+	axis            int
+	outer, n, inner int
+}
+
+func newAxisLayout(shape []int64, axis int) (axisLayout, error) {
+	if axis < 0 {
+		axis += len(shape)
+	}
+	if axis < 0 || axis >= len(shape) {
+		return axisLayout{}, fmt.Errorf("axis %d out of range for shape %v", axis, shape)
+	}
+	l := axisLayout{axis: axis, outer: 1, n: int(shape[axis]), inner: 1}
+	for _, d := range shape[:axis] {
+		l.outer *= int(d)
+	}
+	for _, d := range shape[axis+1:] {
+		l.inner *= int(d)
+	}
+	if l.n == 0 {
+		return axisLayout{}, fmt.Errorf("axis %d of shape %v is empty", axis, shape)
+	}
+	return l, nil
+}
+
+// index returns the flat index of element j along the axis at outer
+// position o and inner position in.
+func (l axisLayout) index(o, j, in int) int {
+	return (o*l.n+j)*l.inner + in
+}
+
+// softmax replaces data with its softmax along the layout's axis.
+func softmax(data []float32, l axisLayout) {
+	for o := range l.outer {
+		for in := range l.inner {
+			top := math.Inf(-1)
+			for j := range l.n {
+				top = max(top, float64(data[l.index(o, j, in)]))
+			}
+
+			var sum float64
+			for j := range l.n {
+				i := l.index(o, j, in)
+				var e float64
+				if math.IsInf(top, 0) {
+					// Only the entries equal to an infinite maximum count.
+					if float64(data[i]) == top {
+						e = 1
+					}
+				} else {
+					e = math.Exp(float64(data[i]) - top)
+				}
+				data[i] = float32(e)
+				sum += e
+			}
+			for j := range l.n {
+				i := l.index(o, j, in)
+				data[i] = float32(float64(data[i]) / sum)
+			}
+		}
+	}
+}
+
+// argmax returns the index of the largest element along the layout's axis
+// at every other position.
+func argmax(data []float32, l axisLayout) []int64 {
+	out := make([]int64, l.outer*l.inner)
+	for o := range l.outer {
+		for in := range l.inner {
+			best := 0
+			for j := 1; j < l.n; j++ {
+				if cmp.Compare(data[l.index(o, j, in)], data[l.index(o, best, in)]) > 0 {
+					best = j
+				}
+			}
+			out[o*l.inner+in] = int64(best)
+		}
+	}
+	return out
+}
+
+// topK returns the indices and values of the k largest elements along the
+// layout's axis, laid out as [outer, k, inner].
+func topK(data []float32, l axisLayout, k int) ([]int64, []float32) {
+	indices := make([]int64, l.outer*k*l.inner)
+	values := make([]float32, len(indices))
+	order := make([]int, l.n)
+	for o := range l.outer {
+		for in := range l.inner {
+			for j := range order {
+				order[j] = j
+			}
+			slices.SortStableFunc(order, func(a, b int) int {
+				return cmp.Compare(data[l.index(o, b, in)], data[l.index(o, a, in)])
+			})
+			for j, idx := range order[:k] {
+				out := (o*k+j)*l.inner + in
+				indices[out] = int64(idx)
+				values[out] = data[l.index(o, idx, in)]
+			}
+		}
+	}
+	return indices, values
+}
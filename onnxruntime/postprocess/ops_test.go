@@ -0,0 +1,192 @@
+package postprocess
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func mustLayout(t testing.TB, shape []int64, axis int) axisLayout {
+	t.Helper()
+	l, err := newAxisLayout(shape, axis)
+	if err != nil {
+		t.Fatalf("newAxisLayout(%v, %d) failed: %v", shape, axis, err)
+	}
+	return l
+}
+
+func approxEqual(a, b []float32) bool {
+	return slices.EqualFunc(a, b, func(x, y float32) bool { return math.Abs(float64(x-y)) < 1e-6 })
+}
+
+func TestAxisLayout(t *testing.T) {
+	l := mustLayout(t, []int64{2, 3, 4}, 1)
+	if l.outer != 2 || l.n != 3 || l.inner != 4 {
+		t.Errorf("Unexpected layout %+v", l)
+	}
+	if l := mustLayout(t, []int64{2, 3, 4}, -1); l.axis != 2 || l.inner != 1 {
+		t.Errorf("Expected axis -1 to be the last axis, got %+v", l)
+	}
+	for _, axis := range []int{3, -4} {
+		if _, err := newAxisLayout([]int64{2, 3, 4}, axis); err == nil {
+			t.Errorf("Expected an error for axis %d", axis)
+		}
+	}
+	if _, err := newAxisLayout([]int64{2, 0}, 1); err == nil {
+		t.Error("Expected an error for an empty axis")
+	}
+}
+
+func TestSoftmax(t *testing.T) {
+	data := []float32{1, 2, 3, 1000, 1000, 1000}
+	softmax(data, mustLayout(t, []int64{2, 3}, -1))
+	e := []float64{math.Exp(-2), math.Exp(-1), 1}
+	sum := e[0] + e[1] + e[2]
+	want := []float32{float32(e[0] / sum), float32(e[1] / sum), float32(e[2] / sum), 1.0 / 3, 1.0 / 3, 1.0 / 3}
+	if !approxEqual(data, want) {
+		t.Errorf("Expected %v, got %v", want, data)
+	}
+
+	// Along axis 0 of a [2, 2] tensor, columns are normalized.
+	data = []float32{0, 5, 0, 5}
+	softmax(data, mustLayout(t, []int64{2, 2}, 0))
+	if want := []float32{0.5, 0.5, 0.5, 0.5}; !approxEqual(data, want) {
+		t.Errorf("Expected %v, got %v", want, data)
+	}
+}
+
+func TestSoftmaxInfinities(t *testing.T) {
+	inf := float32(math.Inf(1))
+	data := []float32{inf, 1, inf, -inf, -inf}
+	softmax(data, mustLayout(t, []int64{1, 5}, -1))
+	if want := []float32{0.5, 0, 0.5, 0, 0}; !slices.Equal(data, want) {
+		t.Errorf("Expected +Inf entries to share the probability, got %v", data)
+	}
+
+	data = []float32{-inf, -inf}
+	softmax(data, mustLayout(t, []int64{2}, 0))
+	if want := []float32{0.5, 0.5}; !slices.Equal(data, want) {
+		t.Errorf("Expected a uniform result for all -Inf, got %v", data)
+	}
+}
+
+func TestArgmax(t *testing.T) {
+	nan := float32(math.NaN())
+	data := []float32{
+		1, 9, 3,
+		7, 7, 2,
+		nan, 4, 5,
+	}
+	if got := argmax(data, mustLayout(t, []int64{3, 3}, -1)); !slices.Equal(got, []int64{1, 0, 2}) {
+		t.Errorf("Row argmax = %v, want [1 0 2]", got)
+	}
+	if got := argmax(data, mustLayout(t, []int64{3, 3}, 0)); !slices.Equal(got, []int64{1, 0, 2}) {
+		t.Errorf("Column argmax = %v, want [1 0 2]", got)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	data := []float32{
+		0.1, 0.5, 0.2, 0.5,
+		4, 3, 2, 1,
+	}
+	l := mustLayout(t, []int64{2, 4}, -1)
+	indices, values := topK(data, l, 3)
+	if !slices.Equal(indices, []int64{1, 3, 2, 0, 1, 2}) {
+		t.Errorf("Unexpected indices %v", indices)
+	}
+	if !slices.Equal(values, []float32{0.5, 0.5, 0.2, 4, 3, 2}) {
+		t.Errorf("Unexpected values %v", values)
+	}
+
+	// Along axis 0, each column keeps its larger element first.
+	indices, values = topK(data, mustLayout(t, []int64{2, 4}, 0), 1)
+	if !slices.Equal(indices, []int64{1, 1, 1, 1}) || !slices.Equal(values, []float32{4, 3, 2, 1}) {
+		t.Errorf("Unexpected column top-1: %v %v", indices, values)
+	}
+}
+
+func TestOpsOnValue(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	v, err := ort.NewTensorValue(runtime, []float32{1, 3, 2, 6, 5, 4}, []int64{2, 3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer v.Close()
+
+	probs, shape, err := Softmax(v, -1)
+	if err != nil {
+		t.Fatalf("Softmax failed: %v", err)
+	}
+	if !slices.Equal(shape, []int64{2, 3}) || len(probs) != 6 {
+		t.Errorf("Unexpected softmax shape %v", shape)
+	}
+
+	am, shape, err := Argmax(v, 1)
+	if err != nil {
+		t.Fatalf("Argmax failed: %v", err)
+	}
+	if !slices.Equal(am, []int64{1, 0}) || !slices.Equal(shape, []int64{2}) {
+		t.Errorf("Argmax = %v of shape %v, want [1 0] of shape [2]", am, shape)
+	}
+
+	indices, scores, shape, err := TopK(v, 5, -1)
+	if err != nil {
+		t.Fatalf("TopK failed: %v", err)
+	}
+	if !slices.Equal(shape, []int64{2, 3}) || !slices.Equal(indices, []int64{1, 2, 0, 0, 1, 2}) || !slices.Equal(scores, []float32{3, 2, 1, 6, 5, 4}) {
+		t.Errorf("Unexpected TopK %v %v of shape %v", indices, scores, shape)
+	}
+	if _, _, _, err := TopK(v, 0, -1); err == nil {
+		t.Error("Expected an error for k = 0")
+	}
+}
+
+// FuzzSoftmax checks that softmax of finite logits is a probability
+// distribution that preserves their order.
+func FuzzSoftmax(f *testing.F) {
+	f.Add([]byte{0, 0, 128, 63, 0, 0, 0, 64}, uint8(2))
+	f.Add([]byte{0, 0, 0x7f, 0x7f, 0, 0, 0x7f, 0xff, 0, 0, 0, 0}, uint8(3))
+	f.Fuzz(func(t *testing.T, raw []byte, n uint8) {
+		if n == 0 || len(raw) < 4*int(n) {
+			return
+		}
+		logits := make([]float32, len(raw)/4/int(n)*int(n))
+		for i := range logits {
+			logits[i] = math.Float32frombits(binary.LittleEndian.Uint32(raw[i*4:]))
+			if math.IsNaN(float64(logits[i])) || math.IsInf(float64(logits[i]), 0) {
+				return
+			}
+		}
+		shape := []int64{int64(len(logits) / int(n)), int64(n)}
+		l := mustLayout(t, shape, -1)
+
+		probs := slices.Clone(logits)
+		softmax(probs, l)
+		indices, _ := topK(logits, l, 1)
+		if want := argmax(logits, l); !slices.Equal(indices, want) {
+			t.Fatalf("topK(1) = %v, argmax = %v", indices, want)
+		}
+		for row := range l.outer {
+			p := probs[row*int(n) : (row+1)*int(n)]
+			var sum float64
+			for _, v := range p {
+				if !(v >= 0 && v <= 1) {
+					t.Fatalf("probability %v outside [0, 1] for logits %v", v, logits)
+				}
+				sum += float64(v)
+			}
+			if math.Abs(sum-1) > 1e-4 {
+				t.Fatalf("probabilities %v sum to %v", p, sum)
+			}
+			// The most likely class is the largest logit.
+			if best := p[indices[row]]; best != slices.Max(p) {
+				t.Fatalf("argmax %d has probability %v, below the maximum %v", indices[row], best, slices.Max(p))
+			}
+		}
+	})
+}
@@ -13,35 +13,56 @@ import (
 // the packed weight buffers are allocated once and shared.
 //
 // Use SharePrepackedWeights in PoolConfig for the simplest integration,
-// or manage a container directly for advanced scenarios like sharing
-// across multiple pools.
+// or create a container and set it as SessionOptions.PrepackedWeights to
+// share it between standalone sessions or across multiple pools.
 type PrepackedWeightsContainer struct {
 	ptr     api.OrtPrepackedWeightsContainer
 	runtime *Runtime
+
+	// ID of the container in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the container is not closed
+	cleanup runtime.Cleanup
 }
 
 // NewPrepackedWeightsContainer creates a new empty container for sharing
 // pre-packed weights across sessions.
+//
+// Example:
+//
+//	weights, err := runtime.NewPrepackedWeightsContainer()
+//	defer weights.Close()
+//	opts := &onnxruntime.SessionOptions{PrepackedWeights: weights}
+//	chat, err := onnxruntime.NewSessionPool(runtime, env, modelData, 4, &onnxruntime.PoolConfig{SessionOptions: opts})
+//	embed, err := onnxruntime.NewSessionPool(runtime, env, modelData, 2, &onnxruntime.PoolConfig{SessionOptions: opts})
 func (r *Runtime) NewPrepackedWeightsContainer() (*PrepackedWeightsContainer, error) {
 	var ptr api.OrtPrepackedWeightsContainer
 	status := r.apiFuncs.CreatePrepackedWeightsContainer(&ptr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreatePrepackedWeightsContainer"); err != nil {
 		return nil, fmt.Errorf("failed to create prepacked weights container: %w", err)
 	}
 
 	c := &PrepackedWeightsContainer{
 		ptr:     ptr,
 		runtime: r,
+		trackID: r.objects.add(kindPrepackedWeights),
 	}
-	runtime.AddCleanup(c, func(_ struct{}) { c.Close() }, struct{}{})
+	c.cleanup = guard(c, leak{runtime: r, kind: kindPrepackedWeights, id: c.trackID, ptr: uintptr(ptr), release: releaseLeakedPrepackedWeights})
 	return c, nil
 }
 
+func releaseLeakedPrepackedWeights(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleasePrepackedWeightsContainer(api.OrtPrepackedWeightsContainer(ptr))
+}
+
 // Close releases the prepacked weights container and its resources.
 // It is safe to call Close multiple times.
 func (c *PrepackedWeightsContainer) Close() {
 	if c.ptr != 0 && c.runtime != nil && c.runtime.apiFuncs != nil {
+		c.cleanup.Stop()
 		c.runtime.apiFuncs.ReleasePrepackedWeightsContainer(c.ptr)
 		c.ptr = 0
+		c.runtime.objects.remove(kindPrepackedWeights, c.trackID)
 	}
 }
@@ -1,6 +1,7 @@
 package onnxruntime
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"testing"
@@ -98,3 +99,60 @@ func TestSessionPoolFromFileWithPrepackedWeights(t *testing.T) {
 		v.Close()
 	}
 }
+
+func TestPrepackedWeightsAcrossPools(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	container, err := runtime.NewPrepackedWeightsContainer()
+	if err != nil {
+		t.Fatalf("Failed to create prepacked weights container: %v", err)
+	}
+	defer container.Close()
+	opts := &SessionOptions{PrepackedWeights: container}
+
+	first, err := NewSessionPool(runtime, env, modelData, 2, &PoolConfig{SessionOptions: opts, SharePrepackedWeights: true})
+	if err != nil {
+		t.Fatalf("Failed to create first pool: %v", err)
+	}
+	if first.prepackedWeights != nil {
+		t.Error("Expected the pool not to create its own container when SessionOptions.PrepackedWeights is set")
+	}
+	second, err := NewSessionPoolFromFile(runtime, env, testModelPath(), 2, &PoolConfig{SessionOptions: opts})
+	if err != nil {
+		t.Fatalf("Failed to create second pool: %v", err)
+	}
+
+	standalone, err := runtime.NewSessionFromReader(env, bytes.NewReader(modelData), opts)
+	if err != nil {
+		t.Fatalf("Failed to create standalone session: %v", err)
+	}
+	defer standalone.Close()
+	runInference(t, runtime, standalone)
+
+	// Closing one pool must leave the shared container usable by the other.
+	first.Close()
+	tensor, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+	outputs, err := second.Run(context.Background(), map[string]*Value{"input": tensor})
+	if err != nil {
+		t.Fatalf("Run on second pool failed: %v", err)
+	}
+	for _, v := range outputs {
+		v.Close()
+	}
+	second.Close()
+}
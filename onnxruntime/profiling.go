@@ -10,7 +10,8 @@ import (
 // EndProfiling stops profiling and returns the path to the profile output file.
 // Profiling must have been enabled via SessionOptions.ProfilingOutputPath.
 //
-// The returned file is a JSON file containing per-operator timing data.
+// The returned file is a JSON file containing per-operator timing data, which
+// the profiling package parses and summarizes.
 //
 // Example:
 //
@@ -28,7 +29,7 @@ func (s *Session) EndProfiling() (string, error) {
 
 	var pathPtr *byte
 	status := s.runtime.apiFuncs.SessionEndProfiling(s.ptr, s.runtime.allocator.ptr, &pathPtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "SessionEndProfiling"); err != nil {
 		return "", fmt.Errorf("failed to end profiling: %w", err)
 	}
 
@@ -47,7 +48,7 @@ func (s *Session) ProfilingStartTimeNs() (uint64, error) {
 
 	var startTime uint64
 	status := s.runtime.apiFuncs.SessionGetProfilingStartTimeNs(s.ptr, &startTime)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "SessionGetProfilingStartTimeNs"); err != nil {
 		return 0, fmt.Errorf("failed to get profiling start time: %w", err)
 	}
 
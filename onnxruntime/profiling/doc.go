@@ -0,0 +1,23 @@
+// Package profiling parses the Chrome trace JSON files written by ONNX
+// Runtime's profiler and summarizes where inference time goes.
+//
+// Enable profiling with SessionOptions.ProfilingOutputPath, run the model,
+// and pass the path returned by Session.EndProfiling to ReadFile. Summarize
+// totals kernel time by operator type and by execution provider and lists
+// the slowest nodes, and Summary.WriteText prints it as a report. For
+// interactive exploration, WriteFolded writes folded stacks for flame graph
+// tools such as flamegraph.pl and speedscope, and WritePprof writes a
+// profile for go tool pprof.
+//
+// Example:
+//
+//	path, err := session.EndProfiling()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	profile, err := profiling.ReadFile(path)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	profile.Summarize(10).WriteText(os.Stdout)
+package profiling
@@ -0,0 +1,180 @@
+package profiling
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// stack returns the frames of a kernel event from root to leaf: execution
+// provider, operator type, and node.
+func stack(e *Event) []string {
+	return []string{orUnknown(e.Provider()), orUnknown(e.OpType()), orUnknown(e.NodeName())}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// aggregate sums kernel time by stack, returning the stacks in sorted order.
+func (p *Profile) aggregate() (stacks [][]string, calls []int64, totals []time.Duration) {
+	index := make(map[string]int)
+	for i := range p.Events {
+		e := &p.Events[i]
+		if !e.IsKernel() {
+			continue
+		}
+		frames := stack(e)
+		key := strings.Join(frames, "\x00")
+		j, ok := index[key]
+		if !ok {
+			j = len(stacks)
+			index[key] = j
+			stacks = append(stacks, frames)
+			calls = append(calls, 0)
+			totals = append(totals, 0)
+		}
+		calls[j]++
+		totals[j] += e.Duration
+	}
+
+	order := make([]int, len(stacks))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return slices.Compare(stacks[a], stacks[b]) })
+	sortedStacks := make([][]string, len(order))
+	sortedCalls := make([]int64, len(order))
+	sortedTotals := make([]time.Duration, len(order))
+	for i, j := range order {
+		sortedStacks[i], sortedCalls[i], sortedTotals[i] = stacks[j], calls[j], totals[j]
+	}
+	return sortedStacks, sortedCalls, sortedTotals
+}
+
+// WriteFolded writes the profile's kernel time as folded stacks, one line
+// per node of the form "provider;op type;node microseconds", the input
+// format of flamegraph.pl, inferno, and speedscope.
+func (p *Profile) WriteFolded(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	escape := strings.NewReplacer(";", "_", " ", "_")
+	stacks, _, totals := p.aggregate()
+	for i, frames := range stacks {
+		for j, frame := range frames {
+			frames[j] = escape.Replace(frame)
+		}
+		fmt.Fprintf(bw, "%s %d\n", strings.Join(frames, ";"), totals[i].Microseconds())
+	}
+	return bw.Flush()
+}
+
+// WritePprof writes the profile's kernel time as a gzipped pprof profile
+// with "calls" and "time" sample types and one sample per node, whose stack
+// is its execution provider, operator type, and node name.
+//
+//	go tool pprof -http=:8080 profile.pb.gz
+func (p *Profile) WritePprof(w io.Writer) error {
+	stacks, calls, totals := p.aggregate()
+
+	strs := []string{""}
+	stringIndex := map[string]int64{"": 0}
+	str := func(s string) int64 {
+		i, ok := stringIndex[s]
+		if !ok {
+			i = int64(len(strs))
+			stringIndex[s] = i
+			strs = append(strs, s)
+		}
+		return i
+	}
+
+	var b []byte
+	valueType := func(field uint64, typ, unit string) {
+		var vt []byte
+		vt = appendVarintField(vt, 1, uint64(str(typ)))
+		vt = appendVarintField(vt, 2, uint64(str(unit)))
+		b = appendBytesField(b, field, vt)
+	}
+	valueType(1, "calls", "count")
+	valueType(1, "time", "nanoseconds")
+
+	// Each distinct frame gets one function and one location with the same
+	// ID. Frames are keyed by depth as well as name so a node named like its
+	// operator type does not appear recursive.
+	type frameKey struct {
+		depth int
+		name  string
+	}
+	frameIDs := make(map[frameKey]uint64)
+	var frames []string
+	for i, stack := range stacks {
+		var ids []byte
+		for j := len(stack) - 1; j >= 0; j-- {
+			key := frameKey{j, stack[j]}
+			id, ok := frameIDs[key]
+			if !ok {
+				frames = append(frames, stack[j])
+				id = uint64(len(frames))
+				frameIDs[key] = id
+			}
+			ids = binary.AppendUvarint(ids, id)
+		}
+		var values []byte
+		values = binary.AppendUvarint(values, uint64(calls[i]))
+		values = binary.AppendUvarint(values, uint64(totals[i].Nanoseconds()))
+
+		var sample []byte
+		sample = appendBytesField(sample, 1, ids)
+		sample = appendBytesField(sample, 2, values)
+		b = appendBytesField(b, 2, sample)
+	}
+
+	for i, frame := range frames {
+		id := uint64(i + 1)
+
+		line := appendVarintField(nil, 1, id)
+		var location []byte
+		location = appendVarintField(location, 1, id)
+		location = appendBytesField(location, 4, line)
+		b = appendBytesField(b, 4, location)
+
+		var function []byte
+		function = appendVarintField(function, 1, id)
+		function = appendVarintField(function, 2, uint64(str(frame)))
+		b = appendBytesField(b, 5, function)
+	}
+
+	valueType(11, "time", "nanoseconds")
+	b = appendVarintField(b, 14, uint64(str("time")))
+
+	for _, s := range strs {
+		b = appendBytesField(b, 6, []byte(s))
+	}
+
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(b); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// appendVarintField appends a protobuf varint field.
+func appendVarintField(b []byte, field, v uint64) []byte {
+	b = binary.AppendUvarint(b, field<<3)
+	return binary.AppendUvarint(b, v)
+}
+
+// appendBytesField appends a protobuf length-delimited field.
+func appendBytesField(b []byte, field uint64, v []byte) []byte {
+	b = binary.AppendUvarint(b, field<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
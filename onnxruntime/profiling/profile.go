@@ -0,0 +1,158 @@
+package profiling
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Event categories written by ONNX Runtime.
+const (
+	// CategorySession marks session-level events such as model loading,
+	// initialization, and each model_run.
+	CategorySession = "Session"
+
+	// CategoryNode marks per-node events: the kernel execution of each node
+	// and the fences around it.
+	CategoryNode = "Node"
+)
+
+// kernelSuffix ends the names of node events timing kernel execution.
+const kernelSuffix = "_kernel_time"
+
+// Event is one complete event of a profile. Timestamps and durations have
+// microsecond resolution.
+type Event struct {
+	Category string
+	Name     string
+	Phase    string
+	PID      int64
+	TID      int64
+
+	// Timestamp is the start of the event relative to the start of
+	// profiling.
+	Timestamp time.Duration
+	Duration  time.Duration
+
+	// Args holds the event's arguments. Node events carry op_name, provider,
+	// node_index, and input and output shapes.
+	Args map[string]any
+}
+
+// jsonEvent is the trace encoding of an Event.
+type jsonEvent struct {
+	Category string         `json:"cat"`
+	Name     string         `json:"name"`
+	Phase    string         `json:"ph"`
+	PID      int64          `json:"pid"`
+	TID      int64          `json:"tid"`
+	TS       int64          `json:"ts"`
+	Dur      int64          `json:"dur"`
+	Args     map[string]any `json:"args"`
+}
+
+// IsKernel reports whether e times the kernel execution of a node.
+func (e *Event) IsKernel() bool {
+	return e.Category == CategoryNode && strings.HasSuffix(e.Name, kernelSuffix)
+}
+
+// NodeName returns the name of the node a node event belongs to, without
+// the event suffix, or "" for other events.
+func (e *Event) NodeName() string {
+	if e.Category != CategoryNode {
+		return ""
+	}
+	for _, suffix := range []string{kernelSuffix, "_fence_before", "_fence_after"} {
+		if name, ok := strings.CutSuffix(e.Name, suffix); ok {
+			return name
+		}
+	}
+	return e.Name
+}
+
+// OpType returns the operator type of a node event, such as "Conv".
+func (e *Event) OpType() string {
+	return e.stringArg("op_name")
+}
+
+// Provider returns the execution provider that ran a node event, such as
+// "CPUExecutionProvider".
+func (e *Event) Provider() string {
+	return e.stringArg("provider")
+}
+
+func (e *Event) stringArg(key string) string {
+	s, _ := e.Args[key].(string)
+	return s
+}
+
+// Profile is a parsed profile.
+type Profile struct {
+	Events []Event
+}
+
+// Parse reads a profile in the Chrome trace format written by ONNX Runtime:
+// a JSON array of events, or an object with the array in traceEvents.
+// Events other than complete ("X") events are skipped.
+func Parse(r io.Reader) (*Profile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	var events []jsonEvent
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		var wrapper struct {
+			TraceEvents []jsonEvent `json:"traceEvents"`
+		}
+		err = json.Unmarshal(data, &wrapper)
+		events = wrapper.TraceEvents
+	} else {
+		err = json.Unmarshal(data, &events)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	p := &Profile{Events: make([]Event, 0, len(events))}
+	for _, e := range events {
+		if e.Phase != "X" {
+			continue
+		}
+		p.Events = append(p.Events, Event{
+			Category:  e.Category,
+			Name:      e.Name,
+			Phase:     e.Phase,
+			PID:       e.PID,
+			TID:       e.TID,
+			Timestamp: time.Duration(e.TS) * time.Microsecond,
+			Duration:  time.Duration(e.Dur) * time.Microsecond,
+			Args:      e.Args,
+		})
+	}
+	return p, nil
+}
+
+// ReadFile parses the profile file at path.
+func ReadFile(path string) (*Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open profile: %w", err)
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Kernels returns the events timing kernel execution, in file order.
+func (p *Profile) Kernels() []Event {
+	var kernels []Event
+	for _, e := range p.Events {
+		if e.IsKernel() {
+			kernels = append(kernels, e)
+		}
+	}
+	return kernels
+}
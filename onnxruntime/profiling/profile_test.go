@@ -0,0 +1,204 @@
+package profiling
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testProfile follows the layout of ONNX Runtime's profiler output.
+const testProfile = `[
+{"cat" : "Session","pid" :4711,"tid" :4711,"dur" :1520,"ts" :3,"ph" : "X","name" :"model_loading_uri","args" : {}},
+{"cat" : "Session","pid" :4711,"tid" :4711,"dur" :2100,"ts" :1600,"ph" : "X","name" :"session_initialization","args" : {}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :0,"ts" :4000,"ph" : "X","name" :"conv1_fence_before","args" : {"op_name" : "Conv"}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :300,"ts" :4001,"ph" : "X","name" :"conv1_kernel_time","args" : {"op_name" : "Conv","provider" : "CPUExecutionProvider","node_index" : "0","output_type_shape" : [{"float":[1,8,4,4]}]}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :0,"ts" :4302,"ph" : "X","name" :"conv1_fence_after","args" : {"op_name" : "Conv"}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :100,"ts" :4303,"ph" : "X","name" :"conv2_kernel_time","args" : {"op_name" : "Conv","provider" : "CUDAExecutionProvider","node_index" : "1"}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :50,"ts" :4404,"ph" : "X","name" :"relu_kernel_time","args" : {"op_name" : "Relu","provider" : "CUDAExecutionProvider","node_index" : "2"}},
+{"cat" : "Session","pid" :4711,"tid" :4711,"dur" :500,"ts" :3990,"ph" : "X","name" :"model_run","args" : {}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :200,"ts" :5001,"ph" : "X","name" :"conv1_kernel_time","args" : {"op_name" : "Conv","provider" : "CPUExecutionProvider","node_index" : "0"}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :100,"ts" :5202,"ph" : "X","name" :"conv2_kernel_time","args" : {"op_name" : "Conv","provider" : "CUDAExecutionProvider","node_index" : "1"}},
+{"cat" : "Node","pid" :4711,"tid" :4711,"dur" :50,"ts" :5303,"ph" : "X","name" :"relu_kernel_time","args" : {"op_name" : "Relu","provider" : "CUDAExecutionProvider","node_index" : "2"}},
+{"cat" : "Session","pid" :4711,"tid" :4711,"dur" :400,"ts" :4990,"ph" : "X","name" :"model_run","args" : {}}
+]
+`
+
+func parseTestProfile(t *testing.T) *Profile {
+	t.Helper()
+	p, err := Parse(strings.NewReader(testProfile))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return p
+}
+
+func TestParse(t *testing.T) {
+	p := parseTestProfile(t)
+
+	if len(p.Events) != 12 {
+		t.Fatalf("len(Events) = %d, want 12", len(p.Events))
+	}
+	e := p.Events[3]
+	if !e.IsKernel() || e.NodeName() != "conv1" || e.OpType() != "Conv" || e.Provider() != "CPUExecutionProvider" {
+		t.Errorf("Events[3] = kernel %v node %q op %q provider %q", e.IsKernel(), e.NodeName(), e.OpType(), e.Provider())
+	}
+	if e.Timestamp != 4001*time.Microsecond || e.Duration != 300*time.Microsecond {
+		t.Errorf("Events[3] timing = %v +%v, want 4.001ms +300µs", e.Timestamp, e.Duration)
+	}
+	if fence := p.Events[2]; fence.IsKernel() || fence.NodeName() != "conv1" {
+		t.Errorf("Events[2] = kernel %v node %q, want fence of conv1", fence.IsKernel(), fence.NodeName())
+	}
+	if session := p.Events[0]; session.IsKernel() || session.NodeName() != "" {
+		t.Errorf("Events[0] = kernel %v node %q, want session event", session.IsKernel(), session.NodeName())
+	}
+	if n := len(p.Kernels()); n != 6 {
+		t.Errorf("len(Kernels()) = %d, want 6", n)
+	}
+}
+
+func TestParseTraceEventsObject(t *testing.T) {
+	p, err := Parse(strings.NewReader(`{"traceEvents": [
+		{"cat": "Node", "name": "a_kernel_time", "ph": "X", "dur": 5},
+		{"cat": "Node", "name": "marker", "ph": "i"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(p.Events) != 1 || p.Events[0].Duration != 5*time.Microsecond {
+		t.Errorf("Events = %+v, want one complete event", p.Events)
+	}
+
+	if _, err := Parse(strings.NewReader(`[{"cat": `)); err == nil {
+		t.Error("Parse() of truncated profile succeeded, want error")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := parseTestProfile(t).Summarize(2)
+
+	if s.Runs != 2 {
+		t.Errorf("Runs = %d, want 2", s.Runs)
+	}
+	if s.KernelTime != 800*time.Microsecond {
+		t.Errorf("KernelTime = %v, want 800µs", s.KernelTime)
+	}
+
+	wantOps := []OpSummary{
+		{OpType: "Conv", Calls: 4, Total: 700 * time.Microsecond, Percent: 87.5},
+		{OpType: "Relu", Calls: 2, Total: 100 * time.Microsecond, Percent: 12.5},
+	}
+	if !slices.Equal(s.Ops, wantOps) {
+		t.Errorf("Ops = %+v, want %+v", s.Ops, wantOps)
+	}
+
+	wantNodes := []NodeSummary{
+		{Name: "conv1", OpType: "Conv", Provider: "CPUExecutionProvider", Calls: 2, Total: 500 * time.Microsecond, Max: 300 * time.Microsecond},
+		{Name: "conv2", OpType: "Conv", Provider: "CUDAExecutionProvider", Calls: 2, Total: 200 * time.Microsecond, Max: 100 * time.Microsecond},
+	}
+	if !slices.Equal(s.SlowestNodes, wantNodes) {
+		t.Errorf("SlowestNodes = %+v, want %+v", s.SlowestNodes, wantNodes)
+	}
+	if mean := s.SlowestNodes[0].Mean(); mean != 250*time.Microsecond {
+		t.Errorf("Mean() = %v, want 250µs", mean)
+	}
+
+	wantProviders := []ProviderSummary{
+		{Provider: "CPUExecutionProvider", Nodes: 1, Calls: 2, Total: 500 * time.Microsecond, Percent: 62.5},
+		{Provider: "CUDAExecutionProvider", Nodes: 2, Calls: 4, Total: 300 * time.Microsecond, Percent: 37.5},
+	}
+	if !slices.Equal(s.Providers, wantProviders) {
+		t.Errorf("Providers = %+v, want %+v", s.Providers, wantProviders)
+	}
+}
+
+func TestSummaryWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := parseTestProfile(t).Summarize(0).WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Runs: 2",
+		"Kernel time: 800µs",
+		"Conv     4      700µs  87.5",
+		"relu   Relu     CUDAExecutionProvider  2      100µs  50µs   50µs",
+		"CPUExecutionProvider   1      2      500µs  62.5",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteText() output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	var buf bytes.Buffer
+	if err := parseTestProfile(t).WriteFolded(&buf); err != nil {
+		t.Fatalf("WriteFolded() error = %v", err)
+	}
+	want := "CPUExecutionProvider;Conv;conv1 500\n" +
+		"CUDAExecutionProvider;Conv;conv2 200\n" +
+		"CUDAExecutionProvider;Relu;relu 100\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteFolded() = %q, want %q", got, want)
+	}
+}
+
+func TestWritePprof(t *testing.T) {
+	var buf bytes.Buffer
+	if err := parseTestProfile(t).WritePprof(&buf); err != nil {
+		t.Fatalf("WritePprof() error = %v", err)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	b, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	counts := make(map[uint64]int)
+	var strs []string
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			t.Fatalf("invalid tag at %d bytes from the end", len(b))
+		}
+		b = b[n:]
+		num := tag >> 3
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			t.Fatalf("invalid field %d", num)
+		}
+		b = b[n:]
+		if tag&7 == 2 {
+			if v > uint64(len(b)) {
+				t.Fatalf("field %d of %d bytes is truncated", num, v)
+			}
+			if num == 6 {
+				strs = append(strs, string(b[:v]))
+			}
+			b = b[v:]
+		}
+		counts[num]++
+	}
+
+	// Two sample types, three samples, and seven distinct frames: two
+	// providers, two op types, and three nodes.
+	if counts[1] != 2 || counts[2] != 3 || counts[4] != 7 || counts[5] != 7 {
+		t.Errorf("field counts = %v, want 2 sample types, 3 samples, 7 locations and functions", counts)
+	}
+	if len(strs) == 0 || strs[0] != "" {
+		t.Fatalf("string table = %q, want leading empty string", strs)
+	}
+	for _, want := range []string{"time", "nanoseconds", "conv1", "Relu", "CUDAExecutionProvider"} {
+		if !slices.Contains(strs, want) {
+			t.Errorf("string table %q missing %q", strs, want)
+		}
+	}
+}
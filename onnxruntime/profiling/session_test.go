@@ -0,0 +1,65 @@
+package profiling_test
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/profiling"
+)
+
+func testModelPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "internal", "tests", "testdata", "model.onnx")
+}
+
+func TestReadFileFromSession(t *testing.T) {
+	rt := newTestRuntime(t)
+
+	env, err := rt.NewEnv("test", ort.LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	session, err := rt.NewSession(env, testModelPath(), &ort.SessionOptions{
+		ProfilingOutputPath: filepath.Join(t.TempDir(), "ort_profile"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	defer session.Close()
+
+	tensor, err := ort.NewTensorValue(rt, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+	for range 3 {
+		outputs, err := session.Run(context.Background(), map[string]*ort.Value{"input": tensor})
+		if err != nil {
+			t.Fatalf("Failed to run inference: %v", err)
+		}
+		for _, v := range outputs {
+			v.Close()
+		}
+	}
+
+	path, err := session.EndProfiling()
+	if err != nil {
+		t.Fatalf("EndProfiling() error = %v", err)
+	}
+	profile, err := profiling.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	s := profile.Summarize(0)
+	if s.Runs != 3 {
+		t.Errorf("Runs = %d, want 3", s.Runs)
+	}
+	if len(s.Ops) == 0 || len(s.Providers) == 0 {
+		t.Errorf("Summarize() = %+v, want op and provider totals", s)
+	}
+}
@@ -0,0 +1,176 @@
+package profiling
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Summary aggregates the kernel time of a profile.
+type Summary struct {
+	// Runs is the number of model runs profiled.
+	Runs int
+
+	// KernelTime is the total time spent in node kernels.
+	KernelTime time.Duration
+
+	// Ops totals kernel time by operator type, slowest first.
+	Ops []OpSummary
+
+	// SlowestNodes lists the nodes with the most kernel time, slowest first.
+	SlowestNodes []NodeSummary
+
+	// Providers totals kernel time by execution provider, slowest first.
+	Providers []ProviderSummary
+}
+
+// OpSummary is the kernel time of one operator type.
+type OpSummary struct {
+	OpType string
+	Calls  int
+	Total  time.Duration
+
+	// Percent is the share of Summary.KernelTime, from 0 to 100.
+	Percent float64
+}
+
+// NodeSummary is the kernel time of one node.
+type NodeSummary struct {
+	Name     string
+	OpType   string
+	Provider string
+	Calls    int
+	Total    time.Duration
+	Max      time.Duration
+}
+
+// Mean returns the mean kernel time per call.
+func (n NodeSummary) Mean() time.Duration {
+	if n.Calls == 0 {
+		return 0
+	}
+	return n.Total / time.Duration(n.Calls)
+}
+
+// ProviderSummary is the kernel time of one execution provider.
+type ProviderSummary struct {
+	Provider string
+
+	// Nodes is the number of distinct nodes assigned to the provider.
+	Nodes int
+	Calls int
+	Total time.Duration
+
+	// Percent is the share of Summary.KernelTime, from 0 to 100.
+	Percent float64
+}
+
+// Summarize aggregates the profile's kernel events, listing the topNodes
+// slowest nodes; zero or less means 10.
+func (p *Profile) Summarize(topNodes int) *Summary {
+	if topNodes <= 0 {
+		topNodes = 10
+	}
+
+	s := &Summary{}
+	ops := make(map[string]*OpSummary)
+	nodes := make(map[string]*NodeSummary)
+	providers := make(map[string]*ProviderSummary)
+	for i := range p.Events {
+		e := &p.Events[i]
+		if e.Category == CategorySession && e.Name == "model_run" {
+			s.Runs++
+		}
+		if !e.IsKernel() {
+			continue
+		}
+		s.KernelTime += e.Duration
+
+		op := entry(ops, e.OpType(), func(name string) *OpSummary { return &OpSummary{OpType: name} })
+		op.Calls++
+		op.Total += e.Duration
+
+		node := entry(nodes, e.NodeName(), func(name string) *NodeSummary {
+			return &NodeSummary{Name: name, OpType: e.OpType(), Provider: e.Provider()}
+		})
+		node.Calls++
+		node.Total += e.Duration
+		node.Max = max(node.Max, e.Duration)
+
+		provider := entry(providers, e.Provider(), func(name string) *ProviderSummary { return &ProviderSummary{Provider: name} })
+		if node.Calls == 1 {
+			provider.Nodes++
+		}
+		provider.Calls++
+		provider.Total += e.Duration
+	}
+
+	for _, op := range ops {
+		op.Percent = percent(op.Total, s.KernelTime)
+		s.Ops = append(s.Ops, *op)
+	}
+	slices.SortFunc(s.Ops, func(a, b OpSummary) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.OpType, b.OpType))
+	})
+
+	for _, node := range nodes {
+		s.SlowestNodes = append(s.SlowestNodes, *node)
+	}
+	slices.SortFunc(s.SlowestNodes, func(a, b NodeSummary) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.Name, b.Name))
+	})
+	if len(s.SlowestNodes) > topNodes {
+		s.SlowestNodes = s.SlowestNodes[:topNodes]
+	}
+
+	for _, provider := range providers {
+		provider.Percent = percent(provider.Total, s.KernelTime)
+		s.Providers = append(s.Providers, *provider)
+	}
+	slices.SortFunc(s.Providers, func(a, b ProviderSummary) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.Provider, b.Provider))
+	})
+	return s
+}
+
+// entry returns m[key], adding a new entry if there is none.
+func entry[T any](m map[string]*T, key string, newEntry func(string) *T) *T {
+	v, ok := m[key]
+	if !ok {
+		v = newEntry(key)
+		m[key] = v
+	}
+	return v
+}
+
+func percent(part, total time.Duration) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+// WriteText writes the summary as a plain-text report of aligned tables.
+func (s *Summary) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Runs: %d\nKernel time: %v\n", s.Runs, s.KernelTime)
+
+	fmt.Fprintf(tw, "\nOp type\tCalls\tTotal\t%%\n")
+	for _, op := range s.Ops {
+		fmt.Fprintf(tw, "%s\t%d\t%v\t%.1f\n", op.OpType, op.Calls, op.Total, op.Percent)
+	}
+
+	fmt.Fprintf(tw, "\nNode\tOp type\tProvider\tCalls\tTotal\tMean\tMax\n")
+	for _, node := range s.SlowestNodes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%v\t%v\t%v\n", node.Name, node.OpType, node.Provider, node.Calls, node.Total, node.Mean(), node.Max)
+	}
+
+	fmt.Fprintf(tw, "\nProvider\tNodes\tCalls\tTotal\t%%\n")
+	for _, provider := range s.Providers {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%v\t%.1f\n", provider.Provider, provider.Nodes, provider.Calls, provider.Total, provider.Percent)
+	}
+	return tw.Flush()
+}
@@ -1,65 +1,170 @@
 package onnxruntime
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"slices"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
 )
 
-// NewSessionWithProviderFallback creates a session trying each provider in order.
-// It returns the session and the name of the provider that was used.
-// If all requested providers fail, it falls back to CPUExecutionProvider.
-//
-// The modelReader is read once and buffered in memory so multiple provider
-// attempts can be made.
-//
-// Example:
-//
-//	session, provider, err := runtime.NewSessionWithProviderFallback(env, modelReader, nil,
-//	    ExecutionProvider{Name: "CUDAExecutionProvider", Options: map[string]string{"device_id": "0"}},
-//	    ExecutionProvider{Name: "CoreMLExecutionProvider"},
-//	)
-//	fmt.Println("Using provider:", provider)
-func (r *Runtime) NewSessionWithProviderFallback(env *Env, modelReader io.Reader, baseOptions *SessionOptions, providers ...ExecutionProvider) (*Session, string, error) {
-	if baseOptions == nil {
-		baseOptions = &SessionOptions{}
-	}
+// providerAppendNames maps provider names as reported by GetAvailableProviders
+// to the short names accepted by SessionOptionsAppendExecutionProvider.
+var providerAppendNames = map[string]string{
+	"CoreMLExecutionProvider":   "CoreML",
+	"QNNExecutionProvider":      "QNN",
+	"SNPEExecutionProvider":     "SNPE",
+	"XnnpackExecutionProvider":  "XNNPACK",
+	"WebNNExecutionProvider":    "WEBNN",
+	"WebGpuExecutionProvider":   "WebGPU",
+	"AzureExecutionProvider":    "AZURE",
+	"OpenVINOExecutionProvider": "OpenVINO",
+	"VitisAIExecutionProvider":  "VitisAI",
+}
 
-	// Buffer the model data so we can retry with different providers
-	modelData, err := io.ReadAll(modelReader)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to read model data: %w", err)
+// appendProviderName returns the name to pass to SessionOptionsAppendExecutionProvider.
+// Unknown names are passed through unchanged.
+func appendProviderName(name string) string {
+	if short, ok := providerAppendNames[name]; ok {
+		return short
 	}
+	return name
+}
 
-	// Check which providers are actually available
-	available, err := r.GetAvailableProviders()
+// validateExecutionProviders checks that every requested provider is compiled
+// into the loaded library, so a missing provider fails with a clear error
+// instead of a late, library-specific one.
+func (r *Runtime) validateExecutionProviders(providers []ExecutionProvider) error {
+	available, err := r.AvailableProviders()
 	if err != nil {
-		return nil, "", err
+		return err
 	}
 
-	// Try each requested provider in order
 	for _, provider := range providers {
 		if !slices.Contains(available, provider.Name) {
+			return &ProviderUnavailableError{Provider: provider.Name, Available: available}
+		}
+	}
+	return nil
+}
+
+// cpuExecutionProvider is the provider ORT always has and falls back to.
+const cpuExecutionProvider = "CPUExecutionProvider"
+
+// cudaExecutionProvider is the only provider that accepts an ArenaConfig.
+const cudaExecutionProvider = "CUDAExecutionProvider"
+
+// appendCUDAProvider appends provider through the CUDA V2 options, which
+// unlike the generic string options can carry an arena config. The returned
+// function releases the options and arena config.
+func (r *Runtime) appendCUDAProvider(optsPtr api.OrtSessionOptions, provider ExecutionProvider) (func(), error) {
+	if provider.Name != cudaExecutionProvider {
+		return nil, fmt.Errorf("execution provider %q does not support an arena config", provider.Name)
+	}
+
+	cfg, err := r.newArenaCfg(provider.Arena)
+	if err != nil {
+		return nil, err
+	}
+	var cudaOpts api.OrtCUDAProviderOptionsV2
+	status := r.apiFuncs.CreateCUDAProviderOptions(&cudaOpts)
+	if err := r.statusError(status, "CreateCUDAProviderOptions"); err != nil {
+		cfg.release()
+		return nil, fmt.Errorf("failed to create CUDA provider options: %w", err)
+	}
+	release := func() {
+		cfg.release()
+		r.apiFuncs.ReleaseCUDAProviderOptions(cudaOpts)
+	}
+
+	if n := len(provider.Options); n > 0 {
+		keys := make([]*byte, 0, n)
+		values := make([]*byte, 0, n)
+		for k, v := range provider.Options {
+			kBytes := append([]byte(k), 0)
+			vBytes := append([]byte(v), 0)
+			keys = append(keys, &kBytes[0])
+			values = append(values, &vBytes[0])
+		}
+		status = r.apiFuncs.UpdateCUDAProviderOptions(cudaOpts, &keys[0], &values[0], uintptr(n))
+		if err := r.statusError(status, "UpdateCUDAProviderOptions"); err != nil {
+			release()
+			return nil, fmt.Errorf("failed to update CUDA provider options: %w", err)
+		}
+	}
+
+	key := append([]byte("default_memory_arena_cfg"), 0)
+	status = r.apiFuncs.UpdateCUDAProviderOptionsWithValue(cudaOpts, &key[0], uintptr(cfg.ptr))
+	if err := r.statusError(status, "UpdateCUDAProviderOptionsWithValue"); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to set CUDA arena config: %w", err)
+	}
+
+	status = r.apiFuncs.SessionOptionsAppendExecutionProviderCUDAV2(optsPtr, cudaOpts)
+	if err := r.statusError(status, "SessionOptionsAppendExecutionProvider_CUDA_V2"); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to append execution provider %q: %w", provider.Name, err)
+	}
+	return release, nil
+}
+
+// newSessionWithFallbackChain creates a session by trying each provider in
+// options.ProviderFallbackChain in order, skipping providers not compiled into
+// the library, then falling back to CPU. create is called with a copy of options
+// that has the chain cleared and ExecutionProviders set to the single candidate.
+func (r *Runtime) newSessionWithFallbackChain(options *SessionOptions, create func(*SessionOptions) (*Session, error)) (*Session, error) {
+	for _, provider := range options.ProviderFallbackChain {
+		if !r.HasProvider(provider.Name) {
 			continue
 		}
 
-		opts := *baseOptions
+		opts := *options
+		opts.ProviderFallbackChain = nil
 		opts.ExecutionProviders = []ExecutionProvider{provider}
 
-		session, err := r.NewSessionFromReader(env, bytes.NewReader(modelData), &opts)
+		session, err := create(&opts)
 		if err != nil {
 			continue
 		}
-		return session, provider.Name, nil
+		return session, nil
 	}
 
 	// Fall back to CPU
-	opts := *baseOptions
+	opts := *options
+	opts.ProviderFallbackChain = nil
 	opts.ExecutionProviders = nil
-	session, err := r.NewSessionFromReader(env, bytes.NewReader(modelData), &opts)
+	return create(&opts)
+}
+
+// NewSessionWithProviderFallback creates a session trying each provider in order.
+// It returns the session and the name of the provider that was used.
+// If all requested providers fail, it falls back to CPUExecutionProvider.
+//
+// The modelReader is read once and buffered in memory so multiple provider
+// attempts can be made. It is equivalent to setting SessionOptions.ProviderFallbackChain
+// and reading Session.ActiveProvider.
+//
+// Example:
+//
+//	session, provider, err := runtime.NewSessionWithProviderFallback(env, modelReader, nil,
+//	    ExecutionProvider{Name: "CUDAExecutionProvider", Options: map[string]string{"device_id": "0"}},
+//	    ExecutionProvider{Name: "CoreMLExecutionProvider"},
+//	)
+//	fmt.Println("Using provider:", provider)
+func (r *Runtime) NewSessionWithProviderFallback(env *Env, modelReader io.Reader, baseOptions *SessionOptions, providers ...ExecutionProvider) (*Session, string, error) {
+	opts := SessionOptions{}
+	if baseOptions != nil {
+		opts = *baseOptions
+	}
+	opts.ProviderFallbackChain = providers
+	if len(providers) == 0 {
+		// An empty chain would disable fallback; force plain CPU instead.
+		opts.ExecutionProviders = nil
+	}
+
+	session, err := r.NewSessionFromReader(env, modelReader, &opts)
 	if err != nil {
 		return nil, "", err
 	}
-	return session, "CPUExecutionProvider", nil
+	return session, session.ActiveProvider(), nil
 }
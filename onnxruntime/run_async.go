@@ -0,0 +1,193 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	"github.com/ebitengine/purego"
+)
+
+// RunAsyncCallback receives the result of [Session.RunAsync]. On success the
+// callback owns outputs and must close them; on failure outputs is nil.
+type RunAsyncCallback func(outputs map[string]*Value, err error)
+
+// asyncRun holds everything a pending RunAsync call needs until ONNX Runtime
+// invokes the completion callback.
+type asyncRun struct {
+	session     *Session
+	inputs      []*Value
+	outputNames []string
+	outputPtrs  []api.OrtValue
+	pinner      runtime.Pinner
+	runOpts     *runHandle
+	callback    RunAsyncCallback
+}
+
+var (
+	// purego callbacks are never freed, so a single trampoline is shared by
+	// all runs and dispatches on the user data pointer.
+	runAsyncCallbackOnce sync.Once
+	runAsyncCallbackPtr  uintptr
+
+	asyncRuns      sync.Map // uintptr -> *asyncRun
+	nextAsyncRunID atomic.Uintptr
+)
+
+func runAsyncCallback() uintptr {
+	runAsyncCallbackOnce.Do(func() {
+		runAsyncCallbackPtr = purego.NewCallback(runAsyncComplete)
+	})
+	return runAsyncCallbackPtr
+}
+
+// RunAsync starts inference and returns immediately. callback is invoked on
+// a new goroutine once ONNX Runtime finishes, so a single session can overlap
+// inference with Go-side pre- and post-processing without blocking a thread
+// per run.
+//
+// The inputs must not be closed until the callback runs. Cancelling ctx
+// terminates the run, and the callback then receives an error. If RunAsync
+// returns an error, the callback is not invoked.
+//
+// Inference runs on the session's intra-op thread pool, which ONNX Runtime
+// requires to have at least two threads; IntraOpNumThreads of 1 is rejected.
+func (s *Session) RunAsync(ctx context.Context, inputs map[string]*Value, callback RunAsyncCallback, opts ...RunOption) (err error) {
+	if callback == nil {
+		return fmt.Errorf("callback cannot be nil")
+	}
+	if s.ptr == 0 {
+		return ErrSessionClosed
+	}
+	if len(s.hooks) > 0 {
+		// The hooks see the run end when the callback is invoked, or now if
+		// the run does not start.
+		afterRun := s.beforeRun(ctx, inputs, keys(inputs), runTag(opts))
+		userCallback := callback
+		callback = func(outputs map[string]*Value, err error) {
+			afterRun(keys(outputs), err)
+			userCallback(outputs, err)
+		}
+		defer func() {
+			if err != nil {
+				afterRun(nil, err)
+			}
+		}()
+	}
+	if s.faults != nil {
+		if err := s.faults.inject(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	config := &runConfig{
+		outputNames:  s.outputNames, // default: all outputs
+		loraRegistry: s.loraRegistry,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	inputNames, inputValues := s.orderedInputs(inputs)
+	if len(inputNames) == 0 {
+		return fmt.Errorf("no inputs to run")
+	}
+	if len(config.outputNames) == 0 {
+		return fmt.Errorf("no outputs to compute")
+	}
+	inputNamePtrs, inputValuePtrs, outputNamePtrs := s.runArgs(inputNames, inputValues, config.outputNames)
+
+	runOpts, err := s.runtime.createRunOptions(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	run := &asyncRun{
+		session:     s,
+		inputs:      inputValues,
+		outputNames: config.outputNames,
+		outputPtrs:  make([]api.OrtValue, len(config.outputNames)),
+		runOpts:     runOpts,
+		callback:    callback,
+	}
+
+	// ONNX Runtime reads the argument arrays from a pool thread after
+	// RunAsync returns, so they must stay in place until completion.
+	for _, p := range inputNamePtrs {
+		if p != nil {
+			run.pinner.Pin(p)
+		}
+	}
+	for _, p := range outputNamePtrs {
+		run.pinner.Pin(p)
+	}
+	run.pinner.Pin(&inputNamePtrs[0])
+	run.pinner.Pin(&inputValuePtrs[0])
+	run.pinner.Pin(&outputNamePtrs[0])
+	run.pinner.Pin(&run.outputPtrs[0])
+
+	id := nextAsyncRunID.Add(1)
+	asyncRuns.Store(id, run)
+	s.inflight.Add(1)
+
+	status := s.runtime.apiFuncs.RunAsync(
+		s.ptr,
+		runOpts.ptr,
+		&inputNamePtrs[0],
+		&inputValuePtrs[0],
+		uintptr(len(inputValues)),
+		&outputNamePtrs[0],
+		uintptr(len(outputNamePtrs)),
+		&run.outputPtrs[0],
+		runAsyncCallback(),
+		id,
+	)
+	if err := s.runtime.statusError(status, "RunAsync"); err != nil {
+		asyncRuns.Delete(id)
+		run.finish()
+		return fmt.Errorf("failed to start async inference: %w", err)
+	}
+	return nil
+}
+
+// runAsyncComplete is the C callback invoked by ONNX Runtime on a pool thread
+// when an async run finishes. The callback owns status.
+func runAsyncComplete(userData, _ uintptr, _ uintptr, status uintptr) uintptr {
+	v, ok := asyncRuns.LoadAndDelete(userData)
+	if !ok {
+		return 0
+	}
+	run := v.(*asyncRun)
+	r := run.session.runtime
+
+	var outputs map[string]*Value
+	err := r.statusError(api.OrtStatus(status), "RunAsync")
+	if err != nil {
+		err = run.runOpts.wrapError(fmt.Errorf("failed to run inference: %w", err))
+		for _, ptr := range run.outputPtrs {
+			if ptr != 0 {
+				r.apiFuncs.ReleaseValue(ptr)
+			}
+		}
+	} else {
+		outputs = make(map[string]*Value, len(run.outputPtrs))
+		for i, ptr := range run.outputPtrs {
+			outputs[run.outputNames[i]] = r.newValueFromPtr(ptr)
+		}
+	}
+
+	run.finish()
+	go run.callback(outputs, err)
+	return 0
+}
+
+// finish releases per-run resources and marks the run as no longer in flight.
+func (run *asyncRun) finish() {
+	run.pinner.Unpin()
+	run.runOpts.close()
+	run.inputs = nil
+	run.session.inflight.Done()
+}
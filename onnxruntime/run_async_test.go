@@ -0,0 +1,146 @@
+package onnxruntime
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func newAsyncTestSession(t *testing.T, runtime *Runtime) *Session {
+	t.Helper()
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	modelFile, err := os.Open(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model file: %v", err)
+	}
+	defer modelFile.Close()
+
+	session, err := runtime.NewSessionFromReader(env, modelFile, &SessionOptions{IntraOpNumThreads: 2})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+type asyncResult struct {
+	outputs map[string]*Value
+	err     error
+}
+
+func TestSessionRunAsync(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newAsyncTestSession(t, runtime)
+
+	input, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create input tensor: %v", err)
+	}
+	defer input.Close()
+
+	const runs = 4
+	results := make(chan asyncResult, runs)
+	for range runs {
+		err := session.RunAsync(t.Context(), map[string]*Value{"input": input}, func(outputs map[string]*Value, err error) {
+			results <- asyncResult{outputs, err}
+		})
+		if err != nil {
+			t.Fatalf("RunAsync failed: %v", err)
+		}
+	}
+
+	for range runs {
+		select {
+		case res := <-results:
+			if res.err != nil {
+				t.Fatalf("Async run failed: %v", res.err)
+			}
+			logits, ok := res.outputs["logits"]
+			if !ok {
+				t.Fatal("Expected logits output")
+			}
+			shape, err := logits.GetTensorShape()
+			if err != nil {
+				t.Fatalf("Failed to get output shape: %v", err)
+			}
+			if len(shape) != 2 || shape[0] != 1 || shape[1] != 3 {
+				t.Errorf("Expected shape [1 3], got %v", shape)
+			}
+			closeValues(res.outputs)
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timed out waiting for async run")
+		}
+	}
+}
+
+func TestSessionRunAsyncCloseWaits(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newAsyncTestSession(t, runtime)
+
+	input, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create input tensor: %v", err)
+	}
+	defer input.Close()
+
+	done := make(chan asyncResult, 1)
+	err = session.RunAsync(context.Background(), map[string]*Value{"input": input}, func(outputs map[string]*Value, err error) {
+		done <- asyncResult{outputs, err}
+	})
+	if err != nil {
+		t.Fatalf("RunAsync failed: %v", err)
+	}
+
+	// Close must not release the session while the run is in flight.
+	session.Close()
+
+	res := <-done
+	if res.err != nil {
+		t.Fatalf("Async run failed: %v", res.err)
+	}
+	closeValues(res.outputs)
+}
+
+func TestSessionRunAsyncErrors(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newAsyncTestSession(t, runtime)
+
+	if err := session.RunAsync(t.Context(), nil, nil); err == nil {
+		t.Error("Expected error for nil callback")
+	}
+
+	session.Close()
+	err := session.RunAsync(t.Context(), nil, func(map[string]*Value, error) {})
+	if err != ErrSessionClosed {
+		t.Errorf("Expected ErrSessionClosed, got %v", err)
+	}
+}
+
+func TestSessionRunAsyncEmptyArgs(t *testing.T) {
+	// The argument arrays are pinned and passed by their first element, so
+	// empty ones are rejected before reaching ONNX Runtime.
+	runtime := &Runtime{apiFuncs: stubRunAPI{}}
+	callback := func(map[string]*Value, error) { t.Error("Callback invoked for a run that did not start") }
+
+	noInputs := newScratchTestSession(nil, []string{"logits"})
+	noInputs.ptr = 1
+	noInputs.runtime = runtime
+	if err := noInputs.RunAsync(t.Context(), nil, callback); err == nil {
+		t.Error("Expected error for a model without inputs")
+	}
+
+	session := newScratchTestSession([]string{"input"}, []string{"logits"})
+	session.ptr = 1
+	session.runtime = runtime
+	input := &Value{ptr: 1, runtime: runtime}
+	if err := session.RunAsync(t.Context(), map[string]*Value{"input": input}, callback, WithOutputNames()); err == nil {
+		t.Error("Expected error for no outputs")
+	}
+}
@@ -0,0 +1,138 @@
+package onnxruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrRunTerminated is matched with errors.Is by the error of a run stopped
+// with RunHandle.Terminate or Runtime.TerminateRun.
+var ErrRunTerminated = errors.New("run terminated")
+
+// RunHandle is an inference started with Session.Start. It lets code other
+// than the caller, such as an admin endpoint, stop a long-running inference.
+type RunHandle struct {
+	tag    string
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+
+	// set before done is closed
+	outputs map[string]*Value
+	err     error
+}
+
+// Start begins an inference in a new goroutine and returns a handle to wait
+// for or terminate it. Its arguments are the same as Run's. The session must
+// not be used for another run or closed until the run completes; Close waits
+// for it.
+//
+// Example:
+//
+//	h, err := session.Start(ctx, inputs, ort.WithRunTag(requestID))
+//	// elsewhere: h.Terminate(), or runtime.TerminateRun(requestID)
+//	outputs, err := h.Wait()
+//	if errors.Is(err, ort.ErrRunTerminated) {
+//	    // stopped early
+//	}
+func (s *Session) Start(ctx context.Context, inputs map[string]*Value, opts ...RunOption) (*RunHandle, error) {
+	if s.ptr == 0 {
+		return nil, ErrSessionClosed
+	}
+
+	config := &runConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	h := &RunHandle{
+		tag:    config.runTag,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	s.inflight.Add(1)
+	go func() {
+		defer s.inflight.Done()
+		h.outputs, h.err = s.Run(ctx, inputs, opts...)
+		// A run terminated before it reached ONNX Runtime fails with the
+		// context's error instead.
+		if h.err != nil && context.Cause(ctx) == ErrRunTerminated && !errors.Is(h.err, ErrRunTerminated) {
+			h.err = fmt.Errorf("%w: %w", ErrRunTerminated, h.err)
+		}
+		cancel(nil)
+		close(h.done)
+	}()
+	return h, nil
+}
+
+// Tag returns the run tag set with WithRunTag, or "" if none was set.
+func (h *RunHandle) Tag() string {
+	return h.tag
+}
+
+// Done returns a channel that is closed when the run completes.
+func (h *RunHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait waits for the run to complete and returns its outputs, which the
+// caller must close. If the run was terminated, the error matches
+// ErrRunTerminated, or the context's error if the context was done first.
+func (h *RunHandle) Wait() (map[string]*Value, error) {
+	<-h.done
+	return h.outputs, h.err
+}
+
+// Terminate stops the run as soon as ONNX Runtime checks for termination,
+// typically between operators. It does not wait for the run to stop and has
+// no effect once the run has completed.
+func (h *RunHandle) Terminate() {
+	h.cancel(ErrRunTerminated)
+}
+
+// TerminateRun stops every in-flight run tagged with WithRunTag(tag) on a
+// session of this runtime, including runs in session pools, and returns how
+// many it stopped. Their errors match ErrRunTerminated with errors.Is.
+func (r *Runtime) TerminateRun(tag string) int {
+	r.runsMu.Lock()
+	defer r.runsMu.Unlock()
+
+	for h := range r.runsByTag[tag] {
+		h.terminate(ErrRunTerminated)
+	}
+	return len(r.runsByTag[tag])
+}
+
+// trackRun makes h findable by TerminateRun if it has a tag.
+func (r *Runtime) trackRun(h *runHandle) {
+	if h.tag == "" {
+		return
+	}
+	r.runsMu.Lock()
+	defer r.runsMu.Unlock()
+
+	if r.runsByTag == nil {
+		r.runsByTag = make(map[string]map[*runHandle]struct{})
+	}
+	if r.runsByTag[h.tag] == nil {
+		r.runsByTag[h.tag] = make(map[*runHandle]struct{})
+	}
+	r.runsByTag[h.tag][h] = struct{}{}
+}
+
+// untrackRun removes h from TerminateRun's index. It must be called before
+// the run options are released.
+func (r *Runtime) untrackRun(h *runHandle) {
+	if h.tag == "" {
+		return
+	}
+	r.runsMu.Lock()
+	defer r.runsMu.Unlock()
+
+	delete(r.runsByTag[h.tag], h)
+	if len(r.runsByTag[h.tag]) == 0 {
+		delete(r.runsByTag, h.tag)
+	}
+}
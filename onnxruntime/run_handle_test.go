@@ -0,0 +1,117 @@
+package onnxruntime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunHandleWrapErrorCause(t *testing.T) {
+	runErr := &RuntimeError{Code: ErrorCodeFail, Message: "Exiting due to terminate flag being set to true."}
+	h := &runHandle{ctx: context.Background(), cause: ErrRunTerminated}
+	h.terminated.Store(true)
+
+	err := h.wrapError(runErr)
+	if !errors.Is(err, ErrRunTerminated) {
+		t.Errorf("Expected error matching ErrRunTerminated, got %v", err)
+	}
+	var rtErr *RuntimeError
+	if !errors.As(err, &rtErr) || rtErr != runErr {
+		t.Errorf("Expected the runtime error to remain accessible, got %v", err)
+	}
+}
+
+func TestTrackRun(t *testing.T) {
+	r := &Runtime{}
+	a := &runHandle{tag: "req-1"}
+	b := &runHandle{tag: "req-1"}
+	untagged := &runHandle{}
+
+	r.trackRun(a)
+	r.trackRun(b)
+	r.trackRun(untagged)
+	if n := len(r.runsByTag["req-1"]); n != 2 {
+		t.Errorf("Expected 2 tracked runs, got %d", n)
+	}
+	if n := len(r.runsByTag); n != 1 {
+		t.Errorf("Expected untagged runs not to be tracked, got %d tags", n)
+	}
+
+	r.untrackRun(a)
+	r.untrackRun(b)
+	r.untrackRun(untagged)
+	if len(r.runsByTag) != 0 {
+		t.Errorf("Expected no tracked runs, got %v", r.runsByTag)
+	}
+	if n := r.TerminateRun("req-1"); n != 0 {
+		t.Errorf("Expected TerminateRun to find no runs, got %d", n)
+	}
+}
+
+func TestSessionStart(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	input, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	h, err := session.Start(t.Context(), map[string]*Value{"input": input}, WithRunTag("start-test"))
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if h.Tag() != "start-test" {
+		t.Errorf("Expected tag %q, got %q", "start-test", h.Tag())
+	}
+
+	outputs, err := h.Wait()
+	if err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	for _, v := range outputs {
+		v.Close()
+	}
+	<-h.Done()
+	h.Terminate() // no effect after completion
+
+	if n := runtime.TerminateRun("start-test"); n != 0 {
+		t.Errorf("Expected no in-flight runs after completion, got %d", n)
+	}
+}
+
+func TestSessionStartTerminate(t *testing.T) {
+	runtime := newTestRuntime(t)
+	faults := NewFaultInjector(FaultConfig{Latency: time.Minute})
+	session := newSessionWithOptions(t, runtime, &SessionOptions{FaultInjector: faults})
+
+	input, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	h, err := session.Start(t.Context(), map[string]*Value{"input": input})
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	h.Terminate()
+
+	select {
+	case <-h.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Terminate did not stop the run")
+	}
+	if _, err := h.Wait(); !errors.Is(err, ErrRunTerminated) {
+		t.Errorf("Expected ErrRunTerminated, got %v", err)
+	}
+}
+
+func TestSessionStartClosed(t *testing.T) {
+	s := &Session{}
+	if _, err := s.Start(t.Context(), nil); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("Expected ErrSessionClosed, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// RunIndexed executes the model like Run, but identifies inputs and outputs
+// by their positions in InputNames and OutputNames instead of by name. Hot
+// paths can resolve the positions once with InputIndex and OutputIndex and
+// then run without any name lookups.
+//
+// inputs[i] is the value of input inputIndices[i], and the constant inputs
+// set with SetConstantInput fill in the inputs left out; if inputIndices is
+// nil, inputs must hold every input in InputNames order. The outputs are returned
+// in the order of outputIndices; if outputIndices is nil, all outputs are
+// returned in OutputNames order. WithOutputNames is ignored.
+//
+// Example:
+//
+//	logits := session.OutputIndex("logits")
+//	outputs, err := session.RunIndexed(ctx, nil, []*onnxruntime.Value{input}, []int{logits})
+func (s *Session) RunIndexed(ctx context.Context, inputIndices []int, inputs []*Value, outputIndices []int, opts ...RunOption) (_ []*Value, err error) {
+	if s.ptr == 0 {
+		return nil, ErrSessionClosed
+	}
+	if len(s.hooks) > 0 {
+		afterRun := s.beforeRun(ctx, nil, indexedNames(s.inputNames, inputIndices), runTag(opts))
+		defer func() { afterRun(indexedNames(s.outputNames, outputIndices), err) }()
+	}
+	defer s.recoverRun(&err)
+	if inputIndices == nil && len(inputs) != len(s.inputNames) {
+		return nil, fmt.Errorf("got %d inputs, model has %d", len(inputs), len(s.inputNames))
+	}
+	if inputIndices != nil && len(inputIndices) != len(inputs) {
+		return nil, fmt.Errorf("got %d input indices for %d inputs", len(inputIndices), len(inputs))
+	}
+	if len(inputs) == 0 && s.constantInputs() == nil {
+		return nil, fmt.Errorf("no inputs to run")
+	}
+	if outputIndices != nil && len(outputIndices) == 0 {
+		return nil, fmt.Errorf("no outputs to compute")
+	}
+	if s.faults != nil {
+		if err := s.faults.inject(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+
+	sc := s.getScratch()
+	defer s.scratch.Put(sc)
+	inputNamePtrs, inputValuePtrs, outputNamePtrs, outputValuePtrs, err := sc.prepareIndexed(s, inputIndices, inputs, outputIndices)
+	if err != nil {
+		return nil, err
+	}
+	return s.runPrepared(ctx, s.runConfig(opts), inputNamePtrs, inputValuePtrs, outputNamePtrs, outputValuePtrs)
+}
+
+// prepareIndexed returns the argument arrays for RunIndexed. With nil
+// indices, the name arrays are the session's own.
+func (sc *runScratch) prepareIndexed(s *Session, inputIndices []int, inputs []*Value, outputIndices []int) (inputNamePtrs []*byte, inputValuePtrs []api.OrtValue, outputNamePtrs []*byte, outputValuePtrs []api.OrtValue, err error) {
+	inputNamePtrs = s.inputNamePtrs
+	if inputIndices != nil {
+		sc.inputNamePtrs = sc.inputNamePtrs[:0]
+		for _, index := range inputIndices {
+			if index < 0 || index >= len(s.inputNamePtrs) {
+				return nil, nil, nil, nil, fmt.Errorf("input index %d is out of range for %d inputs", index, len(s.inputNamePtrs))
+			}
+			sc.inputNamePtrs = append(sc.inputNamePtrs, s.inputNamePtrs[index])
+		}
+	}
+
+	sc.inputValuePtrs = sc.inputValuePtrs[:0]
+	for _, value := range inputs {
+		var ptr api.OrtValue
+		if value != nil {
+			ptr = value.ptr
+		}
+		sc.inputValuePtrs = append(sc.inputValuePtrs, ptr)
+	}
+
+	// Add the constant inputs the indices leave out
+	if constants := s.constantInputs(); inputIndices != nil && constants != nil {
+		for i, name := range s.inputNames {
+			if value, ok := constants[name]; ok && !slices.Contains(inputIndices, i) {
+				sc.inputNamePtrs = append(sc.inputNamePtrs, s.inputNamePtrs[i])
+				sc.inputValuePtrs = append(sc.inputValuePtrs, value.ptr)
+			}
+		}
+	}
+	if inputIndices != nil {
+		inputNamePtrs = sc.inputNamePtrs
+	}
+
+	outputNamePtrs = s.outputNamePtrs
+	if outputIndices != nil {
+		sc.outputNamePtrs = sc.outputNamePtrs[:0]
+		for _, index := range outputIndices {
+			if index < 0 || index >= len(s.outputNamePtrs) {
+				return nil, nil, nil, nil, fmt.Errorf("output index %d is out of range for %d outputs", index, len(s.outputNamePtrs))
+			}
+			sc.outputNamePtrs = append(sc.outputNamePtrs, s.outputNamePtrs[index])
+		}
+		outputNamePtrs = sc.outputNamePtrs
+	}
+
+	sc.outputValuePtrs = slices.Grow(sc.outputValuePtrs[:0], len(outputNamePtrs))[:len(outputNamePtrs)]
+	clear(sc.outputValuePtrs)
+	return inputNamePtrs, sc.inputValuePtrs, outputNamePtrs, sc.outputValuePtrs, nil
+}
+
+// indexedNames returns the names at indices, or all names if indices is nil.
+// Indices out of range are skipped.
+func indexedNames(names []string, indices []int) []string {
+	if indices == nil {
+		return names
+	}
+	result := make([]string, 0, len(indices))
+	for _, index := range indices {
+		if index >= 0 && index < len(names) {
+			result = append(result, names[index])
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package onnxruntime
+
+import (
+	"testing"
+)
+
+func TestSessionInputOutputIndex(t *testing.T) {
+	session := newTestSession(t, newTestRuntime(t))
+
+	if got := session.InputIndex("input"); got != 0 {
+		t.Errorf("InputIndex(input) = %d, want 0", got)
+	}
+	if got := session.OutputIndex("logits"); got != 0 {
+		t.Errorf("OutputIndex(logits) = %d, want 0", got)
+	}
+	if got := session.InputIndex("missing"); got != -1 {
+		t.Errorf("InputIndex(missing) = %d, want -1", got)
+	}
+	if got := session.OutputIndex("missing"); got != -1 {
+		t.Errorf("OutputIndex(missing) = %d, want -1", got)
+	}
+}
+
+func TestSessionRunIndexed(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	inputTensor, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create input tensor: %v", err)
+	}
+	defer inputTensor.Close()
+
+	logits := session.OutputIndex("logits")
+	outputs, err := session.RunIndexed(t.Context(), []int{session.InputIndex("input")}, []*Value{inputTensor}, []int{logits})
+	if err != nil {
+		t.Fatalf("Failed to run inference: %v", err)
+	}
+	defer outputs[0].Close()
+
+	if len(outputs) != 1 {
+		t.Fatalf("Expected 1 output, got %d", len(outputs))
+	}
+	outputData, _, err := GetTensorData[float32](outputs[0])
+	if err != nil {
+		t.Fatalf("Failed to get output data: %v", err)
+	}
+	if len(outputData) != 3 {
+		t.Fatalf("Output length mismatch: expected 3, got %d", len(outputData))
+	}
+
+	// nil indices run every input and output in model order
+	all, err := session.RunIndexed(t.Context(), nil, []*Value{inputTensor}, nil)
+	if err != nil {
+		t.Fatalf("Failed to run inference with nil indices: %v", err)
+	}
+	defer all[0].Close()
+	if len(all) != len(session.OutputNames()) {
+		t.Errorf("Expected %d outputs, got %d", len(session.OutputNames()), len(all))
+	}
+}
+
+func TestSessionRunIndexedInvalid(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	inputTensor, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create input tensor: %v", err)
+	}
+	defer inputTensor.Close()
+
+	tests := []struct {
+		name          string
+		inputIndices  []int
+		inputs        []*Value
+		outputIndices []int
+	}{
+		{"missing inputs", nil, nil, nil},
+		{"index count mismatch", []int{0, 0}, []*Value{inputTensor}, nil},
+		{"input index out of range", []int{1}, []*Value{inputTensor}, nil},
+		{"output index out of range", nil, []*Value{inputTensor}, []int{1}},
+		{"no outputs", nil, []*Value{inputTensor}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := session.RunIndexed(t.Context(), tt.inputIndices, tt.inputs, tt.outputIndices); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package onnxruntime
+
+import (
+	"testing"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// newScratchTestSession returns a session with the metadata prepare uses,
+// without a runtime.
+func newScratchTestSession(inputs, outputs []string) *Session {
+	s := &Session{
+		inputNames:  inputs,
+		outputNames: outputs,
+		inputIndex:  make(map[string]int),
+		outputIndex: make(map[string]int),
+	}
+	for i, name := range inputs {
+		s.inputNameCStrs = append(s.inputNameCStrs, append([]byte(name), 0))
+		s.inputIndex[name] = i
+	}
+	for i, name := range outputs {
+		s.outputNameCStrs = append(s.outputNameCStrs, append([]byte(name), 0))
+		s.outputIndex[name] = i
+	}
+	for i := range s.inputNameCStrs {
+		s.inputNamePtrs = append(s.inputNamePtrs, &s.inputNameCStrs[i][0])
+	}
+	for i := range s.outputNameCStrs {
+		s.outputNamePtrs = append(s.outputNamePtrs, &s.outputNameCStrs[i][0])
+	}
+	return s
+}
+
+func TestRunScratchFullInputs(t *testing.T) {
+	s := newScratchTestSession([]string{"a", "b"}, []string{"x", "y"})
+	inputs := map[string]*Value{"b": {ptr: 2}, "a": {ptr: 1}}
+
+	var sc runScratch
+	inNames, inValues, outNames, outValues := sc.prepare(s, inputs, s.outputNames)
+
+	if &inNames[0] != &s.inputNamePtrs[0] {
+		t.Error("input names should be the session's own when all inputs are provided")
+	}
+	if &outNames[0] != &s.outputNamePtrs[0] {
+		t.Error("output names should be the session's own for the default outputs")
+	}
+	if len(inValues) != 2 || inValues[0] != 1 || inValues[1] != 2 {
+		t.Errorf("input values = %v, want [1 2]", inValues)
+	}
+	if len(outValues) != 2 || outValues[0] != 0 || outValues[1] != 0 {
+		t.Errorf("output values = %v, want two zero values", outValues)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		sc.prepare(s, inputs, s.outputNames)
+	})
+	if allocs != 0 {
+		t.Errorf("prepare allocated %v times per run, want 0", allocs)
+	}
+}
+
+func TestRunScratchMissingInput(t *testing.T) {
+	s := newScratchTestSession([]string{"a", "b"}, []string{"x", "y"})
+
+	var sc runScratch
+	inNames, inValues, _, _ := sc.prepare(s, map[string]*Value{"b": {ptr: 2}}, s.outputNames)
+
+	if inNames[0] != &emptyCString[0] {
+		t.Error("missing input should be passed with an empty name")
+	}
+	if inNames[1] != s.inputNamePtrs[1] {
+		t.Error("provided input should keep its cached name")
+	}
+	if s.inputNamePtrs[0] != &s.inputNameCStrs[0][0] {
+		t.Error("session input names were modified")
+	}
+	if inValues[0] != 0 || inValues[1] != 2 {
+		t.Errorf("input values = %v, want [0 2]", inValues)
+	}
+}
+
+func TestRunScratchOutputSubset(t *testing.T) {
+	s := newScratchTestSession([]string{"a"}, []string{"x", "y"})
+
+	var sc runScratch
+	_, _, outNames, outValues := sc.prepare(s, map[string]*Value{"a": {ptr: 1}}, []string{"y"})
+
+	if len(outNames) != 1 || outNames[0] != s.outputNamePtrs[1] {
+		t.Error("output subset should use the cached name of each output")
+	}
+	if len(outValues) != 1 {
+		t.Errorf("got %d output values, want 1", len(outValues))
+	}
+
+	// Reusing the scratch for the defaults must not see the subset
+	sc.outputValuePtrs[0] = api.OrtValue(7)
+	_, _, outNames, outValues = sc.prepare(s, map[string]*Value{"a": {ptr: 1}}, s.outputNames)
+	if len(outNames) != 2 || len(outValues) != 2 || outValues[0] != 0 {
+		t.Errorf("reused scratch returned names %d, values %v", len(outNames), outValues)
+	}
+}
+
+func TestRunScratchIndexed(t *testing.T) {
+	s := newScratchTestSession([]string{"a", "b"}, []string{"x", "y"})
+
+	var sc runScratch
+	inNames, inValues, outNames, outValues, err := sc.prepareIndexed(s, []int{1}, []*Value{{ptr: 2}}, []int{1, 0})
+	if err != nil {
+		t.Fatalf("prepareIndexed failed: %v", err)
+	}
+	if len(inNames) != 1 || inNames[0] != s.inputNamePtrs[1] || inValues[0] != 2 {
+		t.Errorf("inputs = %v %v, want input b with value 2", inNames, inValues)
+	}
+	if len(outNames) != 2 || outNames[0] != s.outputNamePtrs[1] || outNames[1] != s.outputNamePtrs[0] {
+		t.Error("outputs should follow the order of the indices")
+	}
+	if len(outValues) != 2 {
+		t.Errorf("got %d output values, want 2", len(outValues))
+	}
+
+	inNames, _, outNames, _, err = sc.prepareIndexed(s, nil, []*Value{{ptr: 1}, {ptr: 2}}, nil)
+	if err != nil {
+		t.Fatalf("prepareIndexed failed: %v", err)
+	}
+	if &inNames[0] != &s.inputNamePtrs[0] || &outNames[0] != &s.outputNamePtrs[0] {
+		t.Error("nil indices should use the session's own names")
+	}
+
+	if _, _, _, _, err := sc.prepareIndexed(s, []int{2}, []*Value{{ptr: 1}}, nil); err == nil {
+		t.Error("expected error for an input index out of range")
+	}
+	if _, _, _, _, err := sc.prepareIndexed(s, nil, []*Value{{ptr: 1}, {ptr: 2}}, []int{-1}); err == nil {
+		t.Error("expected error for a negative output index")
+	}
+}
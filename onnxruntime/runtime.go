@@ -2,33 +2,29 @@ package onnxruntime
 
 import (
 	"fmt"
-	"runtime"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"unsafe"
 
 	"github.com/benedoc-inc/onnxer/internal/cstrings"
 	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+	v21 "github.com/benedoc-inc/onnxer/onnxruntime/internal/api/v21"
+	v22 "github.com/benedoc-inc/onnxer/onnxruntime/internal/api/v22"
 	v23 "github.com/benedoc-inc/onnxer/onnxruntime/internal/api/v23"
 	v24 "github.com/benedoc-inc/onnxer/onnxruntime/internal/api/v24"
+	"github.com/benedoc-inc/onnxer/onnxruntime/liblocator"
 	"github.com/ebitengine/purego"
 )
 
-// supportedAPIVersions lists all API versions supported by this library.
-var supportedAPIVersions = []uint32{23, 24}
-
-// getDefaultLibraryName returns the default library name based on the current platform.
-func getDefaultLibraryName() string {
-	switch runtime.GOOS {
-	case "darwin":
-		return "libonnxruntime.dylib"
-	case "linux":
-		return "libonnxruntime.so"
-	case "windows":
-		return "onnxruntime.dll"
-	default:
-		return "libonnxruntime.so"
-	}
-}
+// supportedAPIVersions lists all API versions supported by this library,
+// in ascending order.
+var supportedAPIVersions = []uint32{21, 22, 23, 24}
+
+// APIVersionAuto asks NewRuntime to use the highest API version supported by
+// both this package and the loaded library.
+const APIVersionAuto uint32 = 0
 
 // Runtime represents an instance of the ONNX Runtime library.
 // Multiple Runtime instances can coexist, allowing the use of different
@@ -38,29 +34,51 @@ type Runtime struct {
 	apiVersion    uint32
 	versionString string
 
+	// cleanup removes the library written by NewRuntimeFromBytes, if any
+	cleanup func()
+
 	// API function pointers (version-specific)
 	apiFuncs api.APIFuncs
 
 	// Default allocator and memory info
 	allocator     *allocator
 	cpuMemoryInfo *memoryInfo
+
+	// cached result of GetAvailableProviders, nil until a query succeeds
+	providersMu sync.Mutex
+	providers   []string
+
+	// in-flight runs with a run tag, for TerminateRun
+	runsMu    sync.Mutex
+	runsByTag map[string]map[*runHandle]struct{}
+
+	// isDefault marks the runtime returned by Init, closed by reference count
+	isDefault bool
+
+	// open objects created from the runtime, which Close waits for
+	objects objectTracker
 }
 
 // NewRuntime loads the ONNX Runtime shared library from the specified path and
 // initializes the C API interface with the specified API version.
 // The libraryPath should point to the ONNX Runtime shared library
 // (e.g., "libonnxruntime.so", "libonnxruntime.dylib", or "onnxruntime.dll").
-// If libraryPath is empty, the system will search for the library in standard locations
+// If libraryPath is empty, the library is located with liblocator.Find, which checks
+// ONNXRUNTIME_LIB_PATH, ORT_HOME, the loader path, system, Homebrew, NuGet and pip
+// locations; failing that, the system loader searches its standard paths.
 // The apiVersion parameter specifies which ONNX Runtime C API version to use (e.g., 23, 24).
+// If the library does not provide it, or this package has no bindings for it,
+// the highest supported version the library does provide is used instead, so
+// one binary works against several installed ONNX Runtime releases. Pass
+// APIVersionAuto to always pick the highest; GetAPIVersion reports the result.
 func NewRuntime(libraryPath string, apiVersion uint32) (*Runtime, error) {
-	// Validate API version is supported
-	if !isSupportedAPIVersion(apiVersion) {
-		return nil, fmt.Errorf("unsupported API version %d (supported: %v)", apiVersion, supportedAPIVersions)
-	}
-
-	// If no path is provided, use the default library name and let the system search standard paths
+	// If no path is provided, look in well-known locations and fall back to
+	// letting the system search its standard paths
 	if libraryPath == "" {
-		libraryPath = getDefaultLibraryName()
+		var err error
+		if libraryPath, err = liblocator.Find(); err != nil {
+			libraryPath = liblocator.LibraryName()
+		}
 	}
 
 	libraryHandle, err := purego.Dlopen(libraryPath, purego.RTLD_NOW|purego.RTLD_GLOBAL)
@@ -70,6 +88,14 @@ func NewRuntime(libraryPath string, apiVersion uint32) (*Runtime, error) {
 
 	versionString, err := getVersionString(libraryHandle)
 	if err != nil {
+		versionString = ""
+	}
+
+	apiVersion, err = negotiateAPIVersion(libraryHandle, versionString, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	if versionString == "" {
 		// Non-fatal, just use a default
 		versionString = fmt.Sprintf("unknown (API version %d)", apiVersion)
 	}
@@ -79,6 +105,7 @@ func NewRuntime(libraryPath string, apiVersion uint32) (*Runtime, error) {
 		apiVersion:    apiVersion,
 		versionString: versionString,
 	}
+	runtime.objects.debug.Store(debugFromEnv())
 
 	// Initialize API functions based on specified version
 	if err := runtime.initializeAPI(); err != nil {
@@ -103,9 +130,50 @@ func isSupportedAPIVersion(version uint32) bool {
 	return slices.Contains(supportedAPIVersions, version)
 }
 
+// negotiateAPIVersion picks the API version to bind: requested if this
+// package supports it and the library provides it, otherwise the highest
+// supported version the library provides.
+func negotiateAPIVersion(libraryHandle uintptr, versionString string, requested uint32) (uint32, error) {
+	// Versions above the library's release are known to be missing; skipping
+	// them avoids probing, which makes ONNX Runtime print to stderr.
+	libraryVersion, known := releaseAPIVersion(versionString)
+	available := func(version uint32) bool {
+		return (!known || version <= libraryVersion) && hasAPIVersion(libraryHandle, version)
+	}
+
+	if requested != APIVersionAuto && isSupportedAPIVersion(requested) && available(requested) {
+		return requested, nil
+	}
+	for _, version := range slices.Backward(supportedAPIVersions) {
+		if available(version) {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("library provides none of the supported API versions %v", supportedAPIVersions)
+}
+
+// releaseAPIVersion returns the C API version of an ONNX Runtime release
+// string such as "1.22.1", which equals its minor version.
+func releaseAPIVersion(versionString string) (uint32, bool) {
+	major, rest, ok := strings.Cut(versionString, ".")
+	if !ok || major != "1" {
+		return 0, false
+	}
+	minor, _, _ := strings.Cut(rest, ".")
+	version, err := strconv.ParseUint(minor, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(version), true
+}
+
 // initializeAPI initializes the API function pointers based on the detected version.
 func (r *Runtime) initializeAPI() error {
 	switch r.apiVersion {
+	case 21:
+		return r.initializeV21API()
+	case 22:
+		return r.initializeV22API()
 	case 23:
 		return r.initializeV23API()
 	case 24:
@@ -115,6 +183,26 @@ func (r *Runtime) initializeAPI() error {
 	}
 }
 
+// initializeV21API initializes the v21 API function pointers.
+func (r *Runtime) initializeV21API() error {
+	apiFuncs, err := v21.InitializeFuncs(r.libraryHandle)
+	if err != nil {
+		return err
+	}
+	r.apiFuncs = apiFuncs
+	return nil
+}
+
+// initializeV22API initializes the v22 API function pointers.
+func (r *Runtime) initializeV22API() error {
+	apiFuncs, err := v22.InitializeFuncs(r.libraryHandle)
+	if err != nil {
+		return err
+	}
+	r.apiFuncs = apiFuncs
+	return nil
+}
+
 // initializeV23API initializes the v23 API function pointers.
 func (r *Runtime) initializeV23API() error {
 	apiFuncs, err := v23.InitializeFuncs(r.libraryHandle)
@@ -139,7 +227,7 @@ func (r *Runtime) initializeV24API() error {
 func (r *Runtime) initializeAllocator() error {
 	var allocPtr api.OrtAllocator
 	status := r.apiFuncs.GetAllocatorWithDefaultOptions(&allocPtr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "GetAllocatorWithDefaultOptions"); err != nil {
 		return fmt.Errorf("failed to get default allocator: %w", err)
 	}
 
@@ -152,7 +240,7 @@ func (r *Runtime) initializeAllocator() error {
 
 // initializeMemoryInfo initializes the default CPU memory info for this runtime.
 func (r *Runtime) initializeMemoryInfo() error {
-	memInfo, err := r.createCPUMemoryInfo(allocatorTypeDevice, memTypeCPU)
+	memInfo, err := r.createCPUMemoryInfo(AllocatorTypeDevice, MemTypeDefault)
 	if err != nil {
 		return fmt.Errorf("failed to create CPU memory info: %w", err)
 	}
@@ -161,10 +249,10 @@ func (r *Runtime) initializeMemoryInfo() error {
 }
 
 // createCPUMemoryInfo creates memory info for CPU.
-func (r *Runtime) createCPUMemoryInfo(allocType allocatorType, memType memType) (*memoryInfo, error) {
+func (r *Runtime) createCPUMemoryInfo(allocType AllocatorType, memType MemType) (*memoryInfo, error) {
 	var memInfoPtr api.OrtMemoryInfo
 	status := r.apiFuncs.CreateCpuMemoryInfo(allocType, memType, &memInfoPtr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateCpuMemoryInfo"); err != nil {
 		return nil, fmt.Errorf("failed to create CPU memory info: %w", err)
 	}
 
@@ -177,7 +265,35 @@ func (r *Runtime) createCPUMemoryInfo(allocType allocatorType, memType memType)
 // Close releases resources associated with the ONNX Runtime library.
 // This should be called when the runtime is no longer needed, typically
 // using defer after NewRuntime. It is safe to call Close multiple times.
+//
+// The objects created from the runtime use its library functions until
+// they are closed: sessions, environments, values, IO bindings, memory
+// infos, allocators, LoRA adapters, prepacked weights containers, custom op
+// domains, threading options, and orttraining sessions and checkpoints. Close
+// fails with an error matching ErrRuntimeInUse while any is open, leaving
+// the runtime usable. SetDebug makes the error say where they were created.
+//
+// Earlier releases released the runtime regardless, leaving open objects to
+// call into an unloaded library; code that never closed the output values
+// of its runs must now close them for Close to succeed.
+//
+// Closing the default runtime returned by Init drops the reference that
+// Init call took; the runtime is only released once every reference is
+// dropped.
 func (r *Runtime) Close() error {
+	if r.isDefault {
+		return releaseDefault(r)
+	}
+	return r.close()
+}
+
+// close releases the runtime's resources unless objects created from it
+// are still open.
+func (r *Runtime) close() error {
+	if err := r.objects.check(); err != nil {
+		return err
+	}
+
 	// Release default memory info
 	if r.cpuMemoryInfo != nil {
 		r.cpuMemoryInfo.release()
@@ -194,10 +310,16 @@ func (r *Runtime) Close() error {
 		// Note: purego doesn't provide Dlclose, so we just clear our reference
 		r.libraryHandle = 0
 	}
+
+	if r.cleanup != nil {
+		r.cleanup()
+		r.cleanup = nil
+	}
 	return nil
 }
 
-// GetAPIVersion returns the API version of this runtime instance.
+// GetAPIVersion returns the API version of this runtime instance, which may
+// be lower than the version passed to NewRuntime after negotiation.
 func (r *Runtime) GetAPIVersion() uint32 {
 	return r.apiVersion
 }
@@ -217,8 +339,9 @@ func (r *Runtime) GetBuildInfo() string {
 	return cstrings.CStringToString((*byte)(ptr))
 }
 
-// statusError converts an OrtStatus to a Go error.
-func (r *Runtime) statusError(status api.OrtStatus) error {
+// statusError converts an OrtStatus to a Go error. op names the C API
+// function that returned the status.
+func (r *Runtime) statusError(status api.OrtStatus, op string) error {
 	if status == 0 {
 		return nil
 	}
@@ -232,6 +355,7 @@ func (r *Runtime) statusError(status api.OrtStatus) error {
 
 	return &RuntimeError{
 		Code:    code,
+		Op:      op,
 		Message: message,
 	}
 }
@@ -243,7 +367,7 @@ func (r *Runtime) GetAvailableProviders() ([]string, error) {
 	var providersPtr **byte
 	var length int32
 	status := r.apiFuncs.GetAvailableProviders(&providersPtr, &length)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "GetAvailableProviders"); err != nil {
 		return nil, fmt.Errorf("failed to get available providers: %w", err)
 	}
 
@@ -257,9 +381,45 @@ func (r *Runtime) GetAvailableProviders() ([]string, error) {
 
 	// Release the allocated provider list
 	status = r.apiFuncs.ReleaseAvailableProviders(providersPtr, length)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "ReleaseAvailableProviders"); err != nil {
 		return nil, fmt.Errorf("failed to release available providers: %w", err)
 	}
 
 	return providers, nil
 }
+
+// AvailableProviders returns the execution providers compiled into the loaded library.
+// The list is cached for the lifetime of the Runtime once queried successfully;
+// a failed query is retried on the next call. Callers receive a copy they may
+// modify. Applications can use it at startup to decide
+// whether to request an accelerator such as CUDA or TensorRT.
+func (r *Runtime) AvailableProviders() ([]string, error) {
+	providers, err := r.cachedProviders()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(providers), nil
+}
+
+// HasProvider reports whether the named execution provider is compiled into the
+// loaded library (e.g., "CUDAExecutionProvider"). It returns false if the
+// provider list cannot be queried.
+func (r *Runtime) HasProvider(name string) bool {
+	providers, _ := r.cachedProviders()
+	return slices.Contains(providers, name)
+}
+
+// cachedProviders returns the shared cached provider list without copying,
+// querying it if no query has succeeded yet.
+func (r *Runtime) cachedProviders() ([]string, error) {
+	r.providersMu.Lock()
+	defer r.providersMu.Unlock()
+	if r.providers == nil {
+		providers, err := r.GetAvailableProviders()
+		if err != nil {
+			return nil, err
+		}
+		r.providers = providers
+	}
+	return r.providers, nil
+}
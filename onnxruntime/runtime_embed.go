@@ -0,0 +1,63 @@
+package onnxruntime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/liblocator"
+)
+
+// NewRuntimeFromBytes loads the ONNX Runtime shared library from libData,
+// typically embedded in the binary with go:embed, so a single executable can
+// carry its native runtime. On Linux the library is loaded from an anonymous
+// sealed memory file (memfd_create) and never touches the file system; elsewhere,
+// or if memfd_create is unavailable, it is written to a private temporary
+// directory. Either is released by Close.
+//
+// Execution providers shipped as separate shared libraries (CUDA, TensorRT)
+// are looked up next to the main library and are therefore not found; use the
+// CPU provider or NewRuntime with an installed library for those.
+//
+// Example:
+//
+//	//go:embed libonnxruntime.so
+//	var libonnxruntime []byte
+//
+//	runtime, err := onnxruntime.NewRuntimeFromBytes(libonnxruntime, onnxruntime.APIVersionAuto)
+func NewRuntimeFromBytes(libData []byte, apiVersion uint32) (*Runtime, error) {
+	if len(libData) == 0 {
+		return nil, errors.New("library data is empty")
+	}
+
+	path, cleanup, err := writeEmbeddedLibrary(libData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write embedded library: %w", err)
+	}
+
+	runtime, err := NewRuntime(path, apiVersion)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	runtime.cleanup = cleanup
+	return runtime, nil
+}
+
+// writeTempLibrary writes libData to a new directory readable only by the
+// current user and returns the library path and a function removing it.
+func writeTempLibrary(libData []byte) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "onnxer-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	path := filepath.Join(dir, liblocator.LibraryName())
+	if err := os.WriteFile(path, libData, 0o700); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
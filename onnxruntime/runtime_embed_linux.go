@@ -0,0 +1,34 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/liblocator"
+	"golang.org/x/sys/unix"
+)
+
+// writeEmbeddedLibrary places libData in a sealed memfd and returns its
+// /proc/self/fd path, falling back to a temporary file if memfd_create fails.
+func writeEmbeddedLibrary(libData []byte) (string, func(), error) {
+	fd, err := unix.MemfdCreate(liblocator.LibraryName(), unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return writeTempLibrary(libData)
+	}
+	f := os.NewFile(uintptr(fd), liblocator.LibraryName())
+	if _, err := f.Write(libData); err != nil {
+		f.Close()
+		return "", nil, err
+	}
+
+	// Seal the contents so nothing can modify the library once loaded.
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err := unix.FcntlInt(f.Fd(), unix.F_ADD_SEALS, seals); err != nil {
+		f.Close()
+		return "", nil, fmt.Errorf("failed to seal memfd: %w", err)
+	}
+
+	// The loader maps the file, so closing the descriptor later does not
+	// unload the library.
+	return fmt.Sprintf("/proc/self/fd/%d", f.Fd()), func() { f.Close() }, nil
+}
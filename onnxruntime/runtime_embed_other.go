@@ -0,0 +1,9 @@
+//go:build !linux
+
+package onnxruntime
+
+// writeEmbeddedLibrary writes libData to a private temporary file. Windows
+// keeps loaded DLLs locked, so there the file may outlive Runtime.Close.
+func writeEmbeddedLibrary(libData []byte) (string, func(), error) {
+	return writeTempLibrary(libData)
+}
@@ -0,0 +1,58 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestNewRuntimeFromBytesInvalid(t *testing.T) {
+	if _, err := NewRuntimeFromBytes(nil, 23); err == nil {
+		t.Error("Expected error for empty library data")
+	}
+	if _, err := NewRuntimeFromBytes([]byte("not a shared library"), 23); err == nil {
+		t.Error("Expected error for invalid library data")
+	}
+}
+
+func TestWriteEmbeddedLibrary(t *testing.T) {
+	data := []byte("library contents")
+	path, cleanup, err := writeEmbeddedLibrary(data)
+	if err != nil {
+		t.Fatalf("writeEmbeddedLibrary failed: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Expected %q, got %q", data, got)
+	}
+
+	cleanup()
+	if _, err := os.Stat(path); err == nil {
+		t.Errorf("Expected %s to be gone after cleanup", path)
+	}
+}
+
+func TestNewRuntimeFromBytes(t *testing.T) {
+	if libraryPath == "" {
+		t.Skip("Skipping: ONNXRUNTIME_LIB_PATH not set")
+	}
+	data, err := os.ReadFile(libraryPath)
+	if err != nil {
+		t.Skipf("Skipping: cannot read %s: %v", libraryPath, err)
+	}
+
+	runtime, err := NewRuntimeFromBytes(data, 23)
+	if err != nil {
+		t.Fatalf("NewRuntimeFromBytes failed: %v", err)
+	}
+	if runtime.GetVersionString() == "" {
+		t.Error("Expected a version string")
+	}
+	if err := runtime.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
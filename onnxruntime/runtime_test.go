@@ -3,6 +3,9 @@ package onnxruntime
 import (
 	"slices"
 	"testing"
+	"unsafe"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
 )
 
 func TestGetAvailableProviders(t *testing.T) {
@@ -19,3 +22,126 @@ func TestGetAvailableProviders(t *testing.T) {
 		t.Error("Expected CPUExecutionProvider to be available")
 	}
 }
+
+func TestAvailableProvidersCached(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	providers, err := runtime.AvailableProviders()
+	if err != nil {
+		t.Fatalf("Failed to get available providers: %v", err)
+	}
+	if !slices.Contains(providers, "CPUExecutionProvider") {
+		t.Errorf("Expected CPUExecutionProvider in %v", providers)
+	}
+
+	// Mutating the returned slice must not affect the cache.
+	providers[0] = "Mutated"
+	again, _ := runtime.AvailableProviders()
+	if slices.Contains(again, "Mutated") {
+		t.Error("AvailableProviders should return a copy")
+	}
+
+	if !runtime.HasProvider("CPUExecutionProvider") {
+		t.Error("Expected HasProvider(CPUExecutionProvider) to be true")
+	}
+	if runtime.HasProvider("NonExistentProvider") {
+		t.Error("Expected HasProvider(NonExistentProvider) to be false")
+	}
+}
+
+// flakyProvidersAPI fails GetAvailableProviders a number of times before
+// reporting the CPU provider.
+type flakyProvidersAPI struct {
+	api.APIFuncs
+	failures int
+	calls    int
+}
+
+var (
+	cpuProviderName = []byte("CPUExecutionProvider\x00")
+	providerList    = []*byte{&cpuProviderName[0]}
+	flakyMessage    = []byte("transient failure\x00")
+)
+
+func (f *flakyProvidersAPI) GetAvailableProviders(out ***byte, length *int32) api.OrtStatus {
+	f.calls++
+	if f.calls <= f.failures {
+		return 1
+	}
+	*out = &providerList[0]
+	*length = int32(len(providerList))
+	return 0
+}
+
+func (f *flakyProvidersAPI) ReleaseAvailableProviders(**byte, int32) api.OrtStatus { return 0 }
+func (f *flakyProvidersAPI) GetErrorCode(api.OrtStatus) api.OrtErrorCode           { return 1 }
+func (f *flakyProvidersAPI) GetErrorMessage(api.OrtStatus) unsafe.Pointer {
+	return unsafe.Pointer(&flakyMessage[0])
+}
+func (f *flakyProvidersAPI) ReleaseStatus(api.OrtStatus) {}
+
+func TestAvailableProvidersRetriesAfterError(t *testing.T) {
+	fake := &flakyProvidersAPI{failures: 1}
+	runtime := &Runtime{apiFuncs: fake}
+
+	if _, err := runtime.AvailableProviders(); err == nil {
+		t.Fatal("Expected the first query to fail")
+	}
+	if !runtime.HasProvider("CPUExecutionProvider") {
+		t.Error("Expected HasProvider to retry after a failed query")
+	}
+	providers, err := runtime.AvailableProviders()
+	if err != nil || !slices.Equal(providers, []string{"CPUExecutionProvider"}) {
+		t.Errorf("AvailableProviders() = %v, %v, want [CPUExecutionProvider]", providers, err)
+	}
+	if fake.calls != 2 {
+		t.Errorf("GetAvailableProviders called %d times, want 2 (successful result cached)", fake.calls)
+	}
+}
+
+func TestReleaseAPIVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    uint32
+		ok      bool
+	}{
+		{"1.22.1", 22, true},
+		{"1.24.0", 24, true},
+		{"1.21", 21, true},
+		{"", 0, false},
+		{"2.0.0", 0, false},
+		{"1.x.0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := releaseAPIVersion(tt.version)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("releaseAPIVersion(%q) = %d, %v; want %d, %v", tt.version, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNewRuntimeNegotiatesAPIVersion(t *testing.T) {
+	runtime, err := NewRuntime(libraryPath, APIVersionAuto)
+	if err != nil {
+		t.Skipf("Skipping: ONNX Runtime library not available: %v", err)
+	}
+	defer runtime.Close()
+
+	version := runtime.GetAPIVersion()
+	if !isSupportedAPIVersion(version) {
+		t.Fatalf("Negotiated unsupported API version %d", version)
+	}
+	if want, ok := releaseAPIVersion(runtime.GetVersionString()); ok && want <= supportedAPIVersions[len(supportedAPIVersions)-1] && version != want {
+		t.Errorf("Expected API version %d for library %s, got %d", want, runtime.GetVersionString(), version)
+	}
+
+	// A version newer than any binding falls back instead of failing.
+	newer, err := NewRuntime(libraryPath, 1000)
+	if err != nil {
+		t.Fatalf("Expected fallback for API version 1000: %v", err)
+	}
+	defer newer.Close()
+	if newer.GetAPIVersion() != version {
+		t.Errorf("Expected fallback to %d, got %d", version, newer.GetAPIVersion())
+	}
+}
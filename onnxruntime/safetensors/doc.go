@@ -0,0 +1,25 @@
+// Package safetensors reads the safetensors format used by Hugging Face to
+// store model weights, and turns its tensors into ONNX Runtime values.
+//
+// Open maps a file into memory, and Value creates tensors that use the
+// mapped bytes in place when they are aligned for their element type, so
+// multi-gigabyte weight files are not copied into the Go heap. The values
+// can be passed to SessionOptions.ExternalInitializers to supply a model's
+// weights, or used as inputs.
+//
+// Example:
+//
+//	weights, err := safetensors.Open("model.safetensors")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer weights.Close()
+//
+//	initializers, err := weights.Values(runtime)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	session, err := runtime.NewSession(env, "model.onnx", &onnxruntime.SessionOptions{
+//	    ExternalInitializers: initializers,
+//	})
+package safetensors
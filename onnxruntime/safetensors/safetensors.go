@@ -0,0 +1,244 @@
+package safetensors
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"unsafe"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// maxHeaderSize bounds the JSON header, as the reference implementation
+// does, so a corrupt length cannot trigger a huge allocation.
+const maxHeaderSize = 100 << 20
+
+// dtypes maps safetensors dtype names to element types.
+var dtypes = map[string]ort.ONNXTensorElementDataType{
+	"BOOL": ort.ONNXTensorElementDataTypeBool,
+	"U8":   ort.ONNXTensorElementDataTypeUint8,
+	"I8":   ort.ONNXTensorElementDataTypeInt8,
+	"U16":  ort.ONNXTensorElementDataTypeUint16,
+	"I16":  ort.ONNXTensorElementDataTypeInt16,
+	"F16":  ort.ONNXTensorElementDataTypeFloat16,
+	"BF16": ort.ONNXTensorElementDataTypeBFloat16,
+	"U32":  ort.ONNXTensorElementDataTypeUint32,
+	"I32":  ort.ONNXTensorElementDataTypeInt32,
+	"F32":  ort.ONNXTensorElementDataTypeFloat,
+	"U64":  ort.ONNXTensorElementDataTypeUint64,
+	"I64":  ort.ONNXTensorElementDataTypeInt64,
+	"F64":  ort.ONNXTensorElementDataTypeDouble,
+}
+
+// elementSizes are the sizes in bytes of the element types in dtypes.
+var elementSizes = map[ort.ONNXTensorElementDataType]int64{
+	ort.ONNXTensorElementDataTypeBool:     1,
+	ort.ONNXTensorElementDataTypeUint8:    1,
+	ort.ONNXTensorElementDataTypeInt8:     1,
+	ort.ONNXTensorElementDataTypeUint16:   2,
+	ort.ONNXTensorElementDataTypeInt16:    2,
+	ort.ONNXTensorElementDataTypeFloat16:  2,
+	ort.ONNXTensorElementDataTypeBFloat16: 2,
+	ort.ONNXTensorElementDataTypeUint32:   4,
+	ort.ONNXTensorElementDataTypeInt32:    4,
+	ort.ONNXTensorElementDataTypeFloat:    4,
+	ort.ONNXTensorElementDataTypeUint64:   8,
+	ort.ONNXTensorElementDataTypeInt64:    8,
+	ort.ONNXTensorElementDataTypeDouble:   8,
+}
+
+// TensorInfo describes a tensor in a safetensors file.
+type TensorInfo struct {
+	Name     string
+	DType    string
+	DataType ort.ONNXTensorElementDataType
+	Shape    []int64
+
+	// Offset and Size locate the tensor's bytes in the file.
+	Offset, Size int64
+}
+
+// File is a parsed safetensors file. It is safe for concurrent use.
+type File struct {
+	// Metadata is the file's free-form __metadata__ section, if any.
+	Metadata map[string]string
+
+	data    []byte
+	tensors map[string]TensorInfo
+
+	mu      sync.Mutex
+	release func()
+}
+
+// Open maps the safetensors file at path into memory. Close the File once
+// the values created from it, and any sessions using them, are closed.
+func Open(path string) (*File, error) {
+	data, release, err := ort.ModelFile(path).Open()
+	if err != nil {
+		return nil, err
+	}
+	f, err := Parse(data)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	f.release = release
+	return f, nil
+}
+
+// Parse parses safetensors data held in memory. The File and the values
+// created from it use data in place, so it must not be modified while they
+// are in use.
+func Parse(data []byte) (*File, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("safetensors data of %d bytes is too short", len(data))
+	}
+	n := binary.LittleEndian.Uint64(data)
+	if n > maxHeaderSize || n > uint64(len(data)-8) {
+		return nil, fmt.Errorf("invalid safetensors header size %d", n)
+	}
+
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(data[8:8+n], &header); err != nil {
+		return nil, fmt.Errorf("invalid safetensors header: %w", err)
+	}
+
+	start := 8 + int64(n)
+	f := &File{data: data, tensors: make(map[string]TensorInfo, len(header))}
+	for name, raw := range header {
+		if name == "__metadata__" {
+			if err := json.Unmarshal(raw, &f.Metadata); err != nil {
+				return nil, fmt.Errorf("invalid safetensors metadata: %w", err)
+			}
+			continue
+		}
+		info, err := parseTensorInfo(name, raw, start, int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		f.tensors[name] = info
+	}
+	return f, nil
+}
+
+func parseTensorInfo(name string, raw json.RawMessage, start, end int64) (TensorInfo, error) {
+	var entry struct {
+		DType       string   `json:"dtype"`
+		Shape       []int64  `json:"shape"`
+		DataOffsets [2]int64 `json:"data_offsets"`
+	}
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return TensorInfo{}, fmt.Errorf("invalid safetensors entry %q: %w", name, err)
+	}
+	dataType, ok := dtypes[entry.DType]
+	if !ok {
+		return TensorInfo{}, fmt.Errorf("tensor %q has unsupported dtype %q", name, entry.DType)
+	}
+
+	count := int64(1)
+	for _, d := range entry.Shape {
+		if d < 0 || (d > 0 && count > end/d) {
+			return TensorInfo{}, fmt.Errorf("tensor %q has invalid shape %v", name, entry.Shape)
+		}
+		count *= d
+	}
+	begin, stop := entry.DataOffsets[0], entry.DataOffsets[1]
+	if begin < 0 || stop < begin || stop > end-start {
+		return TensorInfo{}, fmt.Errorf("tensor %q has data offsets %v outside the file", name, entry.DataOffsets)
+	}
+	if size := count * elementSizes[dataType]; stop-begin != size {
+		return TensorInfo{}, fmt.Errorf("tensor %q has %d bytes, shape %v of %s requires %d", name, stop-begin, entry.Shape, entry.DType, size)
+	}
+
+	shape := entry.Shape
+	if shape == nil {
+		shape = []int64{}
+	}
+	return TensorInfo{
+		Name:     name,
+		DType:    entry.DType,
+		DataType: dataType,
+		Shape:    shape,
+		Offset:   start + begin,
+		Size:     stop - begin,
+	}, nil
+}
+
+// Names returns the names of the file's tensors in sorted order.
+func (f *File) Names() []string {
+	return slices.Sorted(maps.Keys(f.tensors))
+}
+
+// Info returns the description of the named tensor.
+func (f *File) Info(name string) (TensorInfo, bool) {
+	info, ok := f.tensors[name]
+	return info, ok
+}
+
+// Bytes returns the raw little-endian bytes of the named tensor. They alias
+// the file's data and are read-only for files from Open.
+func (f *File) Bytes(name string) ([]byte, error) {
+	info, ok := f.tensors[name]
+	if !ok {
+		return nil, fmt.Errorf("tensor %q not found", name)
+	}
+	return f.data[info.Offset : info.Offset+info.Size : info.Offset+info.Size], nil
+}
+
+// Value creates an ONNX Runtime tensor from the named tensor. It uses the
+// file's bytes in place when they are aligned for the element type, and a
+// copy otherwise. The caller must close it, before closing the File. Do not
+// modify tensors from files opened with Open; their memory is read-only.
+func (f *File) Value(r *ort.Runtime, name string) (*ort.Value, error) {
+	raw, err := f.Bytes(name)
+	if err != nil {
+		return nil, err
+	}
+	info := f.tensors[name]
+	if len(raw) > 0 && uintptr(unsafe.Pointer(unsafe.SliceData(raw)))%uintptr(elementSizes[info.DataType]) != 0 {
+		words := make([]uint64, (len(raw)+7)/8)
+		aligned := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(words))), len(raw))
+		copy(aligned, raw)
+		raw = aligned
+	}
+	v, err := ort.NewTensorValueFromBytes(r, raw, info.Shape, info.DataType)
+	if err != nil {
+		return nil, fmt.Errorf("tensor %q: %w", name, err)
+	}
+	return v, nil
+}
+
+// Values creates ONNX Runtime tensors for the named tensors, or for every
+// tensor when no names are given, keyed by name, ready for
+// SessionOptions.ExternalInitializers. The caller must close them.
+func (f *File) Values(r *ort.Runtime, names ...string) (map[string]*ort.Value, error) {
+	if len(names) == 0 {
+		names = f.Names()
+	}
+	values := make(map[string]*ort.Value, len(names))
+	for _, name := range names {
+		v, err := f.Value(r, name)
+		if err != nil {
+			for _, v := range values {
+				v.Close()
+			}
+			return nil, err
+		}
+		values[name] = v
+	}
+	return values, nil
+}
+
+// Close unmaps a file from Open. It is safe to call Close multiple times.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.release != nil {
+		f.release()
+		f.release = nil
+	}
+	return nil
+}
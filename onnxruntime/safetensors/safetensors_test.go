@@ -0,0 +1,170 @@
+package safetensors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// testTensor is a tensor for encodeFile.
+type testTensor struct {
+	name  string
+	dtype string
+	shape []int64
+	data  []byte
+}
+
+// encodeFile builds a safetensors file, padding the header with spaces so
+// the data starts 8-byte aligned, as the reference writer does.
+func encodeFile(t *testing.T, metadata map[string]string, tensors ...testTensor) []byte {
+	t.Helper()
+
+	header := map[string]any{}
+	if metadata != nil {
+		header["__metadata__"] = metadata
+	}
+	var data []byte
+	for _, tt := range tensors {
+		header[tt.name] = map[string]any{
+			"dtype":        tt.dtype,
+			"shape":        tt.shape,
+			"data_offsets": []int{len(data), len(data) + len(tt.data)},
+		}
+		data = append(data, tt.data...)
+	}
+	h, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(h)%8 != 0 {
+		h = append(h, ' ')
+	}
+	buf := binary.LittleEndian.AppendUint64(nil, uint64(len(h)))
+	buf = append(buf, h...)
+	return append(buf, data...)
+}
+
+func float32Bytes(values ...float32) []byte {
+	var b []byte
+	for _, v := range values {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+	}
+	return b
+}
+
+func TestParse(t *testing.T) {
+	data := encodeFile(t, map[string]string{"format": "pt"},
+		testTensor{"weight", "F32", []int64{2, 2}, float32Bytes(1, 2, 3, 4)},
+		testTensor{"bias", "BF16", []int64{2}, []byte{0x80, 0x3f, 0, 0x40}},
+		testTensor{"step", "I64", []int64{}, binary.LittleEndian.AppendUint64(nil, 7)},
+	)
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	defer f.Close()
+
+	if f.Metadata["format"] != "pt" {
+		t.Errorf("Expected metadata format=pt, got %v", f.Metadata)
+	}
+	if names := f.Names(); !slices.Equal(names, []string{"bias", "step", "weight"}) {
+		t.Errorf("Unexpected names %v", names)
+	}
+
+	info, ok := f.Info("weight")
+	if !ok || info.DataType != ort.ONNXTensorElementDataTypeFloat || !slices.Equal(info.Shape, []int64{2, 2}) || info.Size != 16 {
+		t.Errorf("Unexpected info %+v", info)
+	}
+	raw, err := f.Bytes("weight")
+	if err != nil || !bytes.Equal(raw, float32Bytes(1, 2, 3, 4)) {
+		t.Errorf("Unexpected bytes %v (%v)", raw, err)
+	}
+	if &raw[0] != &data[info.Offset] {
+		t.Error("Expected Bytes to alias the file data")
+	}
+	if info, _ := f.Info("step"); len(info.Shape) != 0 || info.Size != 8 {
+		t.Errorf("Unexpected scalar info %+v", info)
+	}
+	if _, err := f.Bytes("missing"); err == nil {
+		t.Error("Expected an error for a missing tensor")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := encodeFile(t, nil, testTensor{"w", "F32", []int64{2}, float32Bytes(1, 2)})
+	tests := map[string][]byte{
+		"short":          {1, 2, 3},
+		"header size":    binary.LittleEndian.AppendUint64(nil, 1<<40),
+		"invalid json":   append(binary.LittleEndian.AppendUint64(nil, 2), "{x"...),
+		"truncated data": valid[:len(valid)-1],
+		"dtype":          encodeFile(t, nil, testTensor{"w", "F8_E4M3", []int64{1}, []byte{0}}),
+		"size mismatch":  encodeFile(t, nil, testTensor{"w", "F32", []int64{3}, float32Bytes(1, 2)}),
+		"negative shape": encodeFile(t, nil, testTensor{"w", "F32", []int64{-1}, nil}),
+		"huge shape":     encodeFile(t, nil, testTensor{"w", "U8", []int64{1 << 40, 1 << 40}, nil}),
+	}
+	for name, data := range tests {
+		if _, err := Parse(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.safetensors")
+	if err := os.WriteFile(path, encodeFile(t, nil, testTensor{"w", "F32", []int64{2}, float32Bytes(1, 2)}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	raw, err := f.Bytes("w")
+	if err != nil || !bytes.Equal(raw, float32Bytes(1, 2)) {
+		t.Errorf("Unexpected bytes %v (%v)", raw, err)
+	}
+	f.Close()
+	f.Close()
+
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.safetensors")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestValues(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	// A one-byte tensor first leaves the float tensor unaligned.
+	f, err := Parse(encodeFile(t, nil,
+		testTensor{"flag", "BOOL", []int64{1}, []byte{1}},
+		testTensor{"weight", "F32", []int64{2, 2}, float32Bytes(1, 2, 3, 4)},
+	))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	defer f.Close()
+
+	values, err := f.Values(runtime)
+	if err != nil {
+		t.Fatalf("Values failed: %v", err)
+	}
+	defer func() {
+		for _, v := range values {
+			v.Close()
+		}
+	}()
+	data, shape, err := ort.GetTensorData[float32](values["weight"])
+	if err != nil || !slices.Equal(data, []float32{1, 2, 3, 4}) || !slices.Equal(shape, []int64{2, 2}) {
+		t.Errorf("Unexpected weight %v %v (%v)", data, shape, err)
+	}
+	flags, _, err := ort.GetTensorData[bool](values["flag"])
+	if err != nil || !slices.Equal(flags, []bool{true}) {
+		t.Errorf("Unexpected flag %v (%v)", flags, err)
+	}
+}
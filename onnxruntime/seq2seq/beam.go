@@ -0,0 +1,179 @@
+package seq2seq
+
+import (
+	"math"
+	"slices"
+)
+
+// hypothesis is a finished beam.
+type hypothesis struct {
+	tokens []int64
+	score  float64
+}
+
+// beamSearch tracks numBeams running hypotheses per source sequence, laid
+// out batch-major, and the best finished hypotheses of each.
+type beamSearch struct {
+	batch, beams  int
+	eos, pad      int64
+	lengthPenalty float64
+
+	scores   []float64 // log-probability of each running hypothesis
+	finished [][]hypothesis
+	done     []bool
+}
+
+func newBeamSearch(batch, beams int, eos, pad int64, lengthPenalty float64) *beamSearch {
+	bs := &beamSearch{
+		batch:         batch,
+		beams:         beams,
+		eos:           eos,
+		pad:           pad,
+		lengthPenalty: lengthPenalty,
+		scores:        make([]float64, batch*beams),
+		finished:      make([][]hypothesis, batch),
+		done:          make([]bool, batch),
+	}
+	// All beams start with the same sequence, so only the first may expand
+	// at the first step or the beams would be duplicates.
+	for i := range bs.scores {
+		if i%beams != 0 {
+			bs.scores[i] = math.Inf(-1)
+		}
+	}
+	return bs
+}
+
+// candidate is a possible extension of a running hypothesis.
+type candidate struct {
+	source int
+	token  int64
+	score  float64
+}
+
+// step extends the running hypotheses, whose generated tokens are given,
+// by their next-token log-probabilities. It returns, for each new
+// hypothesis, the hypothesis it extends and the token appended.
+func (bs *beamSearch) step(tokens [][]int64, logprobs [][]float32) (sources []int, next []int64) {
+	n := bs.batch * bs.beams
+	sources = make([]int, n)
+	next = make([]int64, n)
+	scores := make([]float64, n)
+
+	for b := range bs.batch {
+		first := b * bs.beams
+		if bs.done[b] {
+			for k := range bs.beams {
+				sources[first+k], next[first+k], scores[first+k] = first+k, bs.pad, bs.scores[first+k]
+			}
+			continue
+		}
+
+		// Keep twice as many candidates as beams, so there are enough
+		// running ones even if up to half end with EOS.
+		top := make([]candidate, 0, 2*bs.beams)
+		for k := range bs.beams {
+			h := first + k
+			if math.IsInf(bs.scores[h], -1) {
+				continue
+			}
+			for v, lp := range logprobs[h] {
+				c := candidate{source: h, token: int64(v), score: bs.scores[h] + float64(lp)}
+				if len(top) == cap(top) && c.score <= top[len(top)-1].score {
+					continue
+				}
+				i, _ := slices.BinarySearchFunc(top, c.score, func(c candidate, score float64) int {
+					if c.score >= score {
+						return -1
+					}
+					return 1
+				})
+				if len(top) < cap(top) {
+					top = append(top, candidate{})
+				}
+				copy(top[i+1:], top[i:len(top)-1])
+				top[i] = c
+			}
+		}
+
+		k := 0
+		for rank, c := range top {
+			if k == bs.beams {
+				break
+			}
+			if c.token == bs.eos {
+				if rank < bs.beams {
+					bs.addFinished(b, tokens[c.source], c.score)
+				}
+				continue
+			}
+			sources[first+k], next[first+k], scores[first+k] = c.source, c.token, c.score
+			k++
+		}
+		// Fill any remaining beams with dead copies of the first.
+		for ; k < bs.beams; k++ {
+			sources[first+k], next[first+k], scores[first+k] = first, bs.pad, math.Inf(-1)
+		}
+
+		// The source is done once no running beam can beat the worst of
+		// enough finished ones.
+		if len(bs.finished[b]) == bs.beams {
+			best := scores[first] / bs.penalty(len(tokens[first])+1)
+			bs.done[b] = best <= bs.finished[b][bs.beams-1].score
+		}
+	}
+	bs.scores = scores
+	return sources, next
+}
+
+// addFinished records a finished hypothesis of source b, keeping the best
+// numBeams.
+func (bs *beamSearch) addFinished(b int, tokens []int64, score float64) {
+	h := hypothesis{tokens: slices.Clone(tokens), score: score / bs.penalty(len(tokens)+1)}
+	list := bs.finished[b]
+	if len(list) == bs.beams && h.score <= list[len(list)-1].score {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(list, h.score, func(h hypothesis, score float64) int {
+		if h.score >= score {
+			return -1
+		}
+		return 1
+	})
+	list = slices.Insert(list, i, h)
+	if len(list) > bs.beams {
+		list = list[:bs.beams]
+	}
+	bs.finished[b] = list
+}
+
+func (bs *beamSearch) penalty(length int) float64 {
+	return math.Pow(float64(length), bs.lengthPenalty)
+}
+
+// allDone reports whether every source sequence is done.
+func (bs *beamSearch) allDone() bool {
+	return !slices.Contains(bs.done, false)
+}
+
+// best returns the best hypothesis of each source sequence, treating
+// running hypotheses as finished.
+func (bs *beamSearch) best(tokens [][]int64) [][]int64 {
+	results := make([][]int64, bs.batch)
+	for b := range bs.batch {
+		if !bs.done[b] {
+			for k := range bs.beams {
+				h := b*bs.beams + k
+				if !math.IsInf(bs.scores[h], -1) {
+					bs.addFinished(b, tokens[h], bs.scores[h])
+				}
+			}
+		}
+		if len(bs.finished[b]) > 0 {
+			results[b] = bs.finished[b][0].tokens
+		} else {
+			results[b] = []int64{}
+		}
+	}
+	return results
+}
@@ -0,0 +1,30 @@
+// Package seq2seq generates text with encoder-decoder models, such as T5,
+// BART, and Marian translation models, exported to ONNX without a
+// generation loop.
+//
+// A Generator runs the encoder once, then the decoder one token at a time
+// with greedy or beam search until every sequence produces the EOS token or
+// reaches the maximum length. For decoders exported with a key/value cache
+// (Hugging Face Optimum's decoder_model_merged.onnx, or the pair
+// decoder_model.onnx and decoder_with_past_model.onnx), each present.*
+// output is fed back as the past_key_values.* input of the same name, so
+// every step only processes the newest token. Stream delivers tokens as
+// they are generated.
+//
+// Tokenization is left to the caller: Generate takes and returns token IDs.
+//
+// Example:
+//
+//	encoder, _ := runtime.NewSession(env, "encoder_model.onnx", nil)
+//	decoder, _ := runtime.NewSession(env, "decoder_model_merged.onnx", nil)
+//	gen, err := seq2seq.New(runtime, encoder, decoder, &seq2seq.Config{
+//	    DecoderStartID: 0, EOSID: 1, PadID: 0, MaxLength: 64,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	_, err = gen.Stream(ctx, [][]int64{ids}, func(_ int, token int64) error {
+//	    fmt.Print(tokenizer.Decode([]int64{token}))
+//	    return nil
+//	})
+package seq2seq
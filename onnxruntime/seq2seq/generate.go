@@ -0,0 +1,370 @@
+package seq2seq
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// state holds the tensors that live across decoding steps. Each source
+// sequence has numBeams rows.
+type state struct {
+	hidden *ort.Value
+	mask   []int64 // encoder attention mask, rows × source length
+	srcLen int
+	past   map[string]*ort.Value
+
+	// encMask is the encoder attention mask tensor, created on first use.
+	encMask *ort.Value
+}
+
+func (s *state) close() {
+	if s.hidden != nil {
+		s.hidden.Close()
+	}
+	if s.encMask != nil {
+		s.encMask.Close()
+	}
+	for _, v := range s.past {
+		v.Close()
+	}
+}
+
+func (g *Generator) generate(ctx context.Context, inputIDs [][]int64, fn StreamFunc) ([][]int64, error) {
+	if len(inputIDs) == 0 {
+		return nil, fmt.Errorf("no source sequences")
+	}
+	beams := g.config.NumBeams
+	rows := len(inputIDs) * beams
+
+	st, err := g.encode(ctx, inputIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer st.close()
+
+	// tokens holds each row's decoder input: the start token, then the
+	// generated tokens, padded once the row has finished.
+	tokens := make([][]int64, rows)
+	for i := range tokens {
+		tokens[i] = []int64{g.config.DecoderStartID}
+	}
+
+	var search *beamSearch
+	var results [][]int64
+	var finished []bool
+	if beams > 1 {
+		search = newBeamSearch(len(inputIDs), beams, g.config.EOSID, g.config.PadID, g.config.LengthPenalty)
+	} else {
+		results = make([][]int64, rows)
+		for i := range results {
+			results[i] = []int64{}
+		}
+		finished = make([]bool, rows)
+	}
+
+	for step := range g.config.MaxLength {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		dec := g.decoder
+		if step == 0 && g.initial != nil {
+			dec = g.initial
+		}
+		logits, err := g.decode(ctx, dec, st, tokens)
+		if err != nil {
+			return nil, err
+		}
+
+		if search == nil {
+			for i, row := range logits {
+				token := g.config.PadID
+				if !finished[i] {
+					token = argmax(row)
+					if token == g.config.EOSID {
+						finished[i] = true
+					} else {
+						results[i] = append(results[i], token)
+						if fn != nil {
+							if err := fn(i, token); err != nil {
+								return nil, err
+							}
+						}
+					}
+				}
+				tokens[i] = append(tokens[i], token)
+			}
+			if !slices.Contains(finished, false) {
+				break
+			}
+			continue
+		}
+
+		generated := make([][]int64, rows)
+		for i := range tokens {
+			generated[i] = tokens[i][1:]
+			logSoftmax(logits[i])
+		}
+		sources, next := search.step(generated, logits)
+		reordered := make([][]int64, rows)
+		for i, src := range sources {
+			reordered[i] = append(slices.Clone(tokens[src]), next[i])
+		}
+		tokens = reordered
+		if search.allDone() {
+			break
+		}
+		if err := g.reorderPast(st, sources); err != nil {
+			return nil, err
+		}
+	}
+
+	if search == nil {
+		return results, nil
+	}
+	generated := make([][]int64, rows)
+	for i := range tokens {
+		generated[i] = tokens[i][1:]
+	}
+	return search.best(generated), nil
+}
+
+// encode pads the source sequences, runs the encoder, and repeats its
+// output for each beam.
+func (g *Generator) encode(ctx context.Context, inputIDs [][]int64) (*state, error) {
+	batch, beams := len(inputIDs), g.config.NumBeams
+	srcLen := 0
+	for i, ids := range inputIDs {
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("source sequence %d is empty", i)
+		}
+		srcLen = max(srcLen, len(ids))
+	}
+	ids := make([]int64, batch*srcLen)
+	mask := make([]int64, batch*srcLen)
+	for i, seq := range inputIDs {
+		row := ids[i*srcLen : (i+1)*srcLen]
+		copy(row, seq)
+		for j := len(seq); j < srcLen; j++ {
+			row[j] = g.config.PadID
+		}
+		for j := range seq {
+			mask[i*srcLen+j] = 1
+		}
+	}
+
+	inputs := make(map[string]*ort.Value, 2)
+	defer func() {
+		for _, v := range inputs {
+			v.Close()
+		}
+	}()
+	shape := []int64{int64(batch), int64(srcLen)}
+	var err error
+	if inputs[InputIDs], err = newIDs(g.runtime, g.encoder.inputs[InputIDs], ids, shape); err != nil {
+		return nil, fmt.Errorf("failed to create encoder input: %w", err)
+	}
+	if info, ok := g.encoder.inputs[AttentionMask]; ok {
+		if inputs[AttentionMask], err = newIDs(g.runtime, info, mask, shape); err != nil {
+			return nil, fmt.Errorf("failed to create encoder attention mask: %w", err)
+		}
+	}
+
+	outputs, err := g.encoder.runner.Run(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("encoder failed: %w", err)
+	}
+	hidden, ok := outputs[LastHiddenState]
+	if !ok && len(outputs) == 1 {
+		for _, v := range outputs {
+			hidden = v
+		}
+	}
+	for _, v := range outputs {
+		if v != hidden {
+			v.Close()
+		}
+	}
+	if hidden == nil {
+		return nil, fmt.Errorf("encoder has no %q output", LastHiddenState)
+	}
+
+	st := &state{hidden: hidden, mask: mask, srcLen: srcLen, past: make(map[string]*ort.Value)}
+	if beams > 1 {
+		expand := make([]int, batch*beams)
+		for i := range expand {
+			expand[i] = i / beams
+		}
+		expanded, err := gatherRows(g.runtime, hidden, expand)
+		hidden.Close()
+		st.hidden = nil
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand encoder output for beams: %w", err)
+		}
+		st.hidden = expanded
+		st.mask = make([]int64, 0, len(expand)*srcLen)
+		for _, row := range expand {
+			st.mask = append(st.mask, mask[row*srcLen:(row+1)*srcLen]...)
+		}
+	}
+	return st, nil
+}
+
+// decode runs one decoding step of dec and returns the next-token logits of
+// each row, storing its present outputs as the cache for the next step.
+func (g *Generator) decode(ctx context.Context, dec *model, st *state, tokens [][]int64) ([][]float32, error) {
+	rows := len(tokens)
+	inputs := make(map[string]*ort.Value, len(dec.inputs))
+	var created []*ort.Value
+	defer func() {
+		for _, v := range created {
+			v.Close()
+		}
+	}()
+
+	// A decoder with a cache is fed the latest token; one without is fed
+	// the whole sequence.
+	var ids []int64
+	length := 1
+	if len(dec.past) > 0 {
+		for _, seq := range tokens {
+			ids = append(ids, seq[len(seq)-1])
+		}
+	} else {
+		length = len(tokens[0])
+		for _, seq := range tokens {
+			ids = append(ids, seq...)
+		}
+	}
+	v, err := newIDs(g.runtime, dec.inputs[InputIDs], ids, []int64{int64(rows), int64(length)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decoder input: %w", err)
+	}
+	created = append(created, v)
+	inputs[InputIDs] = v
+
+	if _, ok := dec.inputs[EncoderHiddenStates]; ok {
+		inputs[EncoderHiddenStates] = st.hidden
+	}
+	if info, ok := dec.inputs[EncoderAttentionMask]; ok {
+		if st.encMask == nil {
+			if st.encMask, err = newIDs(g.runtime, info, st.mask, []int64{int64(rows), int64(st.srcLen)}); err != nil {
+				return nil, fmt.Errorf("failed to create encoder attention mask: %w", err)
+			}
+		}
+		inputs[EncoderAttentionMask] = st.encMask
+	}
+	if _, ok := dec.inputs[UseCacheBranch]; ok {
+		v, err := ort.NewTensorValue(g.runtime, []bool{len(st.past) > 0}, []int64{1})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", UseCacheBranch, err)
+		}
+		created = append(created, v)
+		inputs[UseCacheBranch] = v
+	}
+	for _, name := range dec.past {
+		if v := st.past[name]; v != nil {
+			inputs[name] = v
+			continue
+		}
+		v, err := emptyPast(g.runtime, dec.inputs[name], rows)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, v)
+		inputs[name] = v
+	}
+
+	outputs, err := dec.runner.Run(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("decoder failed: %w", err)
+	}
+	defer func() {
+		for _, v := range outputs {
+			v.Close()
+		}
+	}()
+
+	for _, name := range g.decoder.past {
+		present := PresentPrefix + strings.TrimPrefix(name, PastPrefix)
+		v, ok := outputs[present]
+		if !ok {
+			// Decoders exported with past inputs may not repeat the
+			// cross-attention cache, which is fixed after the first step.
+			if st.past[name] == nil {
+				return nil, fmt.Errorf("decoder has no %q output for cache input %q", present, name)
+			}
+			continue
+		}
+		if old := st.past[name]; old != nil {
+			old.Close()
+		}
+		st.past[name] = v
+		delete(outputs, present)
+	}
+
+	logits, ok := outputs[Logits]
+	if !ok {
+		return nil, fmt.Errorf("decoder has no %q output", Logits)
+	}
+	data, shape, err := ort.GetTensorDataAsFloat32(logits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logits: %w", err)
+	}
+	if len(shape) < 2 || shape[0] != int64(rows) {
+		return nil, fmt.Errorf("unexpected logits shape %v for %d rows", shape, rows)
+	}
+	vocab := int(shape[len(shape)-1])
+	perRow := len(data) / rows
+	result := make([][]float32, rows)
+	for i := range result {
+		// The next-token logits are those of the last position.
+		end := (i + 1) * perRow
+		result[i] = data[end-vocab : end]
+	}
+	return result, nil
+}
+
+// reorderPast rearranges the cache rows to follow the beams selected by a
+// beam search step.
+func (g *Generator) reorderPast(st *state, sources []int) error {
+	if isIdentity(sources) {
+		return nil
+	}
+	for name, v := range st.past {
+		reordered, err := gatherRows(g.runtime, v, sources)
+		if err != nil {
+			return fmt.Errorf("failed to reorder %q: %w", name, err)
+		}
+		v.Close()
+		st.past[name] = reordered
+	}
+	return nil
+}
+
+func argmax(row []float32) int64 {
+	best := 0
+	for i, v := range row {
+		if v > row[best] {
+			best = i
+		}
+	}
+	return int64(best)
+}
+
+// logSoftmax converts logits to log-probabilities in place.
+func logSoftmax(row []float32) {
+	maxLogit := row[argmax(row)]
+	var sum float64
+	for _, v := range row {
+		sum += math.Exp(float64(v - maxLogit))
+	}
+	logSum := float64(maxLogit) + math.Log(sum)
+	for i, v := range row {
+		row[i] = float32(float64(v) - logSum)
+	}
+}
@@ -0,0 +1,198 @@
+package seq2seq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// Input and output names of Hugging Face Optimum encoder-decoder exports.
+const (
+	InputIDs             = "input_ids"
+	AttentionMask        = "attention_mask"
+	EncoderHiddenStates  = "encoder_hidden_states"
+	EncoderAttentionMask = "encoder_attention_mask"
+	UseCacheBranch       = "use_cache_branch"
+	LastHiddenState      = "last_hidden_state"
+	Logits               = "logits"
+
+	// PastPrefix and PresentPrefix start the names of the key/value cache
+	// inputs and outputs of a decoder, such as past_key_values.0.decoder.key
+	// and present.0.decoder.key.
+	PastPrefix    = "past_key_values"
+	PresentPrefix = "present"
+)
+
+// Config configures a Generator. Token IDs have no defaults since zero is a
+// valid ID; copy them from the model's generation_config.json.
+type Config struct {
+	// DecoderStartID is the first token fed to the decoder
+	// (decoder_start_token_id; 0 for T5, 2 for BART and Marian).
+	DecoderStartID int64
+
+	// EOSID ends a sequence (eos_token_id).
+	EOSID int64
+
+	// PadID pads source sequences of different lengths and the output of
+	// sequences that finished early (pad_token_id).
+	PadID int64
+
+	// MaxLength is the maximum number of tokens generated per sequence,
+	// excluding the decoder start token. Default 128.
+	MaxLength int
+
+	// NumBeams is the beam width. Default 1, greedy search.
+	NumBeams int
+
+	// LengthPenalty is the exponent applied to a finished beam's length
+	// before dividing its log-probability. Values above zero favour longer
+	// sequences; Transformers uses 1.0. Zero compares raw log-probabilities.
+	LengthPenalty float64
+
+	// InitialDecoder, if set, runs the first decoding step instead of the
+	// decoder, for exports that split the decoder into decoder_model.onnx
+	// (no cache inputs) and decoder_with_past_model.onnx. Its present
+	// outputs seed the cache.
+	InitialDecoder ort.Runner
+}
+
+// StreamFunc receives each token generated for source sequence index, in
+// order, excluding the decoder start and EOS tokens. Returning an error
+// stops generation.
+type StreamFunc func(index int, token int64) error
+
+// model is a runner and the declared inputs it is fed by name.
+type model struct {
+	runner ort.Runner
+	inputs map[string]ort.InputInfo
+	past   []string // cache input names, in declaration order
+}
+
+func newModel(r ort.Runner, name string) (*model, error) {
+	infos, err := inputInfo(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s input info: %w", name, err)
+	}
+	m := &model{runner: r, inputs: make(map[string]ort.InputInfo, len(infos))}
+	for _, info := range infos {
+		if info.TensorInfo == nil {
+			return nil, fmt.Errorf("%s input %q is not a tensor", name, info.Name)
+		}
+		m.inputs[info.Name] = info
+		if strings.HasPrefix(info.Name, PastPrefix) {
+			m.past = append(m.past, info.Name)
+		}
+	}
+	if _, ok := m.inputs[InputIDs]; !ok {
+		return nil, fmt.Errorf("%s has no %q input", name, InputIDs)
+	}
+	return m, nil
+}
+
+// Runners describe their inputs in one of these ways.
+type (
+	sessionInfo interface {
+		GetInputInfo() ([]ort.InputInfo, error)
+	}
+	poolInfo interface {
+		InputInfo() []ort.InputInfo
+	}
+	modelInfo interface {
+		Session() *ort.Session
+	}
+)
+
+// inputInfo returns the input info of a Session, SessionPool, or Model.
+func inputInfo(r ort.Runner) ([]ort.InputInfo, error) {
+	switch r := r.(type) {
+	case sessionInfo:
+		return r.GetInputInfo()
+	case poolInfo:
+		return r.InputInfo(), nil
+	case modelInfo:
+		return r.Session().GetInputInfo()
+	default:
+		return nil, fmt.Errorf("%T does not describe its inputs", r)
+	}
+}
+
+// Generator runs greedy or beam search over an encoder and a decoder.
+// It is safe for concurrent use if the runners are.
+type Generator struct {
+	runtime *ort.Runtime
+	encoder *model
+	decoder *model
+	initial *model
+	config  Config
+}
+
+// New creates a Generator for the encoder and decoder, which may be
+// Sessions, SessionPools, or Models created with runtime.
+//
+// The encoder takes input_ids and optionally attention_mask and produces
+// last_hidden_state (or its only output, if named otherwise). The decoder takes
+// input_ids, and optionally encoder_hidden_states, encoder_attention_mask,
+// use_cache_branch, and past_key_values.* cache inputs, and produces logits
+// and present.* cache outputs. A decoder with cache inputs is fed only the
+// latest token, with each past_key_values input wired to the present output
+// of the same name; one without is fed the whole sequence at every step.
+//
+// Example:
+//
+//	gen, err := seq2seq.New(runtime, encoder, decoder, &seq2seq.Config{
+//	    DecoderStartID: 0, EOSID: 1, PadID: 0, NumBeams: 4, LengthPenalty: 1,
+//	})
+//	ids, err := gen.Generate(ctx, [][]int64{tokenizer.Encode("translate English to German: Hello")})
+func New(runtime *ort.Runtime, encoder, decoder ort.Runner, config *Config) (*Generator, error) {
+	if runtime == nil || encoder == nil || decoder == nil {
+		return nil, fmt.Errorf("runtime, encoder and decoder are required")
+	}
+	g := &Generator{runtime: runtime}
+	if config != nil {
+		g.config = *config
+	}
+	if g.config.MaxLength == 0 {
+		g.config.MaxLength = 128
+	}
+	if g.config.NumBeams == 0 {
+		g.config.NumBeams = 1
+	}
+	if g.config.MaxLength < 0 || g.config.NumBeams < 0 {
+		return nil, fmt.Errorf("max length and number of beams must be positive")
+	}
+
+	var err error
+	if g.encoder, err = newModel(encoder, "encoder"); err != nil {
+		return nil, err
+	}
+	if g.decoder, err = newModel(decoder, "decoder"); err != nil {
+		return nil, err
+	}
+	if g.config.InitialDecoder != nil {
+		if g.decoder.past == nil {
+			return nil, fmt.Errorf("an initial decoder requires a decoder with %s inputs", PastPrefix)
+		}
+		if g.initial, err = newModel(g.config.InitialDecoder, "initial decoder"); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Generate generates a token sequence for each source sequence. The
+// results exclude the decoder start token and the EOS token.
+func (g *Generator) Generate(ctx context.Context, inputIDs [][]int64) ([][]int64, error) {
+	return g.generate(ctx, inputIDs, nil)
+}
+
+// Stream is like Generate but also passes each token to fn as soon as it
+// is generated. Streaming requires greedy search, since beam search only
+// settles on a sequence at the end.
+func (g *Generator) Stream(ctx context.Context, inputIDs [][]int64, fn StreamFunc) ([][]int64, error) {
+	if g.config.NumBeams > 1 {
+		return nil, fmt.Errorf("streaming requires greedy search, got %d beams", g.config.NumBeams)
+	}
+	return g.generate(ctx, inputIDs, fn)
+}
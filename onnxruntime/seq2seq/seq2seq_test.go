@@ -0,0 +1,174 @@
+package seq2seq
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+func logs(probs ...float64) []float32 {
+	out := make([]float32, len(probs))
+	for i, p := range probs {
+		out[i] = float32(math.Log(p))
+	}
+	return out
+}
+
+func TestBeamSearch(t *testing.T) {
+	// Token 0 is EOS; beams start at log-probability zero.
+	bs := newBeamSearch(1, 2, 0, 9, 0)
+
+	sources, next := bs.step([][]int64{{}, {}}, [][]float32{logs(0.1, 0.6, 0.3), logs(0.1, 0.6, 0.3)})
+	if !slices.Equal(sources, []int{0, 0}) || !slices.Equal(next, []int64{1, 2}) {
+		t.Fatalf("step 1 = %v %v, want both beams from beam 0 with tokens [1 2]", sources, next)
+	}
+	if bs.allDone() {
+		t.Fatal("done after step 1")
+	}
+	if best := bs.best([][]int64{{1}, {2}}); !slices.Equal(best[0], []int64{1}) {
+		t.Errorf("best running = %v, want [1]", best[0])
+	}
+
+	bs = newBeamSearch(1, 2, 0, 9, 0)
+	bs.step([][]int64{{}, {}}, [][]float32{logs(0.1, 0.6, 0.3), logs(0.1, 0.6, 0.3)})
+	// Both beams end with EOS as their best continuation; the remaining
+	// running beams cannot beat them.
+	sources, next = bs.step([][]int64{{1}, {2}}, [][]float32{logs(0.9, 0.05, 0.05), logs(0.5, 0.25, 0.25)})
+	if !slices.Equal(sources, []int{1, 1}) || !slices.Equal(next, []int64{1, 2}) {
+		t.Errorf("step 2 = %v %v, want beam 1 extended by [1 2]", sources, next)
+	}
+	if !bs.allDone() {
+		t.Fatal("not done after two finished hypotheses")
+	}
+	if best := bs.best([][]int64{{2, 1}, {2, 2}}); !slices.Equal(best[0], []int64{1}) {
+		t.Errorf("best = %v, want [1]", best[0])
+	}
+
+	// A finished source keeps its beams in place and pads them.
+	sources, next = bs.step([][]int64{{2, 1}, {2, 2}}, [][]float32{logs(0.5, 0.5, 0), logs(0.5, 0.5, 0)})
+	if !slices.Equal(sources, []int{0, 1}) || !slices.Equal(next, []int64{9, 9}) {
+		t.Errorf("step after done = %v %v, want identity with padding", sources, next)
+	}
+}
+
+func TestBeamSearchLengthPenalty(t *testing.T) {
+	bs := newBeamSearch(1, 2, 0, 9, 1)
+	bs.addFinished(0, []int64{1}, math.Log(0.25))
+	bs.addFinished(0, []int64{1, 2, 3}, math.Log(0.1))
+	bs.done[0] = true
+	// log(0.25)/2 ≈ -0.69 is worse than log(0.1)/4 ≈ -0.58.
+	if best := bs.best(nil); !slices.Equal(best[0], []int64{1, 2, 3}) {
+		t.Errorf("best = %v, want the longer sequence", best[0])
+	}
+}
+
+func TestLogSoftmax(t *testing.T) {
+	row := []float32{1, 2, 3, 1000}
+	logSoftmax(row)
+	var sum float64
+	for _, v := range row {
+		sum += math.Exp(float64(v))
+	}
+	if math.Abs(sum-1) > 1e-6 || argmax(row) != 3 {
+		t.Errorf("logSoftmax = %v, want log-probabilities peaking at 3", row)
+	}
+}
+
+// infoRunner is a Runner that describes its inputs.
+type infoRunner struct {
+	inputs []ort.InputInfo
+}
+
+func (r *infoRunner) Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error) {
+	return nil, nil
+}
+
+func (r *infoRunner) InputInfo() []ort.InputInfo {
+	return r.inputs
+}
+
+type blindRunner struct{}
+
+func (blindRunner) Run(ctx context.Context, inputs map[string]*ort.Value, opts ...ort.RunOption) (map[string]*ort.Value, error) {
+	return nil, nil
+}
+
+func TestNewErrors(t *testing.T) {
+	ids := ort.InputInfo{Name: InputIDs, Type: ort.ONNXTypeTensor, TensorInfo: &ort.TensorTypeInfo{
+		ElementType: ort.ONNXTensorElementDataTypeInt64, Shape: []int64{-1, -1},
+	}}
+	valid := &infoRunner{inputs: []ort.InputInfo{ids}}
+	runtime := &ort.Runtime{}
+
+	tests := []struct {
+		name             string
+		runtime          *ort.Runtime
+		encoder, decoder ort.Runner
+		config           *Config
+		want             string
+	}{
+		{"no runtime", nil, valid, valid, nil, "required"},
+		{"negative beams", runtime, valid, valid, &Config{NumBeams: -1}, "must be positive"},
+		{"no input info", runtime, blindRunner{}, valid, nil, "does not describe its inputs"},
+		{"no input ids", runtime, valid, &infoRunner{}, nil, `decoder has no "input_ids" input`},
+		{"initial without cache", runtime, valid, valid, &Config{InitialDecoder: valid}, "requires a decoder with past_key_values"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.runtime, tt.encoder, tt.decoder, tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	gen, err := New(runtime, valid, valid, &Config{NumBeams: 2})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := gen.Stream(context.Background(), [][]int64{{1}}, nil); err == nil {
+		t.Error("Stream() with beam search succeeded, want error")
+	}
+	if _, err := gen.Generate(context.Background(), nil); err == nil {
+		t.Error("Generate() without sources succeeded, want error")
+	}
+}
+
+func TestGatherRows(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	v, err := ort.NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6}, []int64{3, 2})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer v.Close()
+
+	gathered, err := gatherRows(runtime, v, []int{2, 2, 0, 1})
+	if err != nil {
+		t.Fatalf("gatherRows() error = %v", err)
+	}
+	defer gathered.Close()
+	data, shape, err := ort.GetTensorData[float32](gathered)
+	if err != nil {
+		t.Fatalf("GetTensorData() error = %v", err)
+	}
+	if !slices.Equal(data, []float32{5, 6, 5, 6, 1, 2, 3, 4}) || !slices.Equal(shape, []int64{4, 2}) {
+		t.Errorf("gatherRows() = %v %v", data, shape)
+	}
+
+	info := ort.InputInfo{Name: "past_key_values.0.decoder.key", TensorInfo: &ort.TensorTypeInfo{
+		ElementType: ort.ONNXTensorElementDataTypeFloat16, Shape: []int64{-1, 8, -1, 64},
+	}}
+	empty, err := emptyPast(runtime, info, 3)
+	if err != nil {
+		t.Fatalf("emptyPast() error = %v", err)
+	}
+	defer empty.Close()
+	if shape, _ := empty.GetTensorShape(); !slices.Equal(shape, []int64{3, 8, 0, 64}) {
+		t.Errorf("emptyPast() shape = %v, want [3 8 0 64]", shape)
+	}
+}
@@ -0,0 +1,75 @@
+package seq2seq
+
+import (
+	"fmt"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+)
+
+// newIDs creates an integer tensor of the given shape with the element type
+// of info, which must be int64 or int32.
+func newIDs(r *ort.Runtime, info ort.InputInfo, data []int64, shape []int64) (*ort.Value, error) {
+	switch info.TensorInfo.ElementType {
+	case ort.ONNXTensorElementDataTypeInt64:
+		return ort.NewTensorValue(r, data, shape)
+	case ort.ONNXTensorElementDataTypeInt32:
+		data32 := make([]int32, len(data))
+		for i, id := range data {
+			data32[i] = int32(id)
+		}
+		return ort.NewTensorValue(r, data32, shape)
+	default:
+		return nil, fmt.Errorf("input %q must be int64 or int32", info.Name)
+	}
+}
+
+// emptyPast creates the cache input described by info for the first step,
+// with n rows and no past positions. Other dynamic dimensions are also
+// zero, as the cache is not read until it has been filled.
+func emptyPast(r *ort.Runtime, info ort.InputInfo, n int) (*ort.Value, error) {
+	shape := make([]int64, len(info.TensorInfo.Shape))
+	for i, d := range info.TensorInfo.Shape {
+		switch {
+		case i == 0:
+			shape[i] = int64(n)
+		case d < 0:
+			shape[i] = 0
+		default:
+			shape[i] = d
+		}
+	}
+	v, err := ort.NewTensorValueFromBytes(r, nil, shape, info.TensorInfo.ElementType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create empty %q: %w", info.Name, err)
+	}
+	return v, nil
+}
+
+// gatherRows returns a tensor whose i-th slice along the first axis is
+// slice rows[i] of v.
+func gatherRows(r *ort.Runtime, v *ort.Value, rows []int) (*ort.Value, error) {
+	raw, shape, elemType, err := ort.GetTensorBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) == 0 || shape[0] == 0 {
+		return nil, fmt.Errorf("cannot gather rows of a tensor shaped %v", shape)
+	}
+	rowSize := len(raw) / int(shape[0])
+	out := make([]byte, len(rows)*rowSize)
+	for i, row := range rows {
+		copy(out[i*rowSize:(i+1)*rowSize], raw[row*rowSize:(row+1)*rowSize])
+	}
+	shape[0] = int64(len(rows))
+	return ort.NewTensorValueFromBytes(r, out, shape, elemType)
+}
+
+// isIdentity reports whether rows maps every row to itself.
+func isIdentity(rows []int) bool {
+	for i, row := range rows {
+		if i != row {
+			return false
+		}
+	}
+	return true
+}
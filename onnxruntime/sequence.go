@@ -10,7 +10,7 @@ import (
 func (v *Value) GetSequenceLength() (int, error) {
 	var count uintptr
 	status := v.runtime.apiFuncs.GetValueCount(v.ptr, &count)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetValueCount"); err != nil {
 		return 0, fmt.Errorf("failed to get sequence length: %w", err)
 	}
 	return int(count), nil
@@ -32,7 +32,7 @@ func (v *Value) GetSequenceValues() ([]*Value, error) {
 	for i := 0; i < count; i++ {
 		var elemPtr api.OrtValue
 		status := v.runtime.apiFuncs.GetValue(v.ptr, int32(i), v.runtime.allocator.ptr, &elemPtr)
-		if err := v.runtime.statusError(status); err != nil {
+		if err := v.runtime.statusError(status, "GetValue"); err != nil {
 			// Clean up already-extracted values on error
 			for j := 0; j < i; j++ {
 				values[j].Close()
@@ -56,14 +56,14 @@ func (v *Value) GetMapKeyValue() (keys *Value, values *Value, err error) {
 	// Map has exactly 2 elements: index 0 = keys, index 1 = values
 	var keysPtr api.OrtValue
 	status := v.runtime.apiFuncs.GetValue(v.ptr, 0, v.runtime.allocator.ptr, &keysPtr)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetValue"); err != nil {
 		return nil, nil, fmt.Errorf("failed to get map keys: %w", err)
 	}
 	keysValue := v.runtime.newValueFromPtr(keysPtr)
 
 	var valsPtr api.OrtValue
 	status = v.runtime.apiFuncs.GetValue(v.ptr, 1, v.runtime.allocator.ptr, &valsPtr)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetValue"); err != nil {
 		keysValue.Close()
 		return nil, nil, fmt.Errorf("failed to get map values: %w", err)
 	}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	goruntime "runtime"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/benedoc-inc/onnxer/internal/cstrings"
@@ -22,6 +25,10 @@ type ExecutionProvider struct {
 	// For example, CUDA provider accepts "device_id", "gpu_mem_limit", etc.
 	// If nil, the provider is configured with default settings.
 	Options map[string]string
+
+	// Arena configures the provider's device memory arena. Only
+	// CUDAExecutionProvider supports it; nil keeps the provider default.
+	Arena *ArenaConfig
 }
 
 // SessionOptions configures options for creating an inference session.
@@ -38,6 +45,13 @@ type SessionOptions struct {
 	// If empty, the default provider(s) will be used.
 	ExecutionProviders []ExecutionProvider
 
+	// ProviderFallbackChain lists execution providers to try one at a time, in order.
+	// Session creation skips providers not compiled into the library, attempts each
+	// remaining one, and falls back to CPUExecutionProvider if all fail. The provider
+	// that was selected is reported by Session.ActiveProvider.
+	// When set, it takes precedence over ExecutionProviders.
+	ProviderFallbackChain []ExecutionProvider
+
 	// GraphOptimization sets the graph optimization level.
 	// Zero value (GraphOptimizationDisabled) means no optimization.
 	GraphOptimization GraphOptimizationLevel
@@ -63,10 +77,44 @@ type SessionOptions struct {
 	// This improves memory allocation and kernel selection for models with dynamic shapes.
 	FreeDimensionOverrides map[string]int64
 
+	// FreeDimensionOverridesByDenotation fixes dimensions by their ONNX
+	// denotation, such as DimensionDenotationBatch ("DATA_BATCH"), at
+	// session creation time. Some exporters set denotations rather than
+	// naming dimensions, in which case FreeDimensionOverrides has no effect.
+	FreeDimensionOverridesByDenotation map[string]int64
+
 	// DeterministicCompute when non-nil enables or disables deterministic computation.
 	// When true, ORT avoids non-deterministic GPU kernels for reproducible results.
 	DeterministicCompute *bool
 
+	// ExternalInitializers supplies initializer tensors by name, overriding
+	// the model's own initializers or external data references. Use it to
+	// inject weights fetched from object storage without writing them to
+	// disk. The session keeps the Values reachable, but they must not be
+	// closed before the session is.
+	ExternalInitializers map[string]*Value
+
+	// ExternalInitializerFiles supplies the contents of external data files
+	// by the file name the model references (its "location" entry), so the
+	// files need not exist on disk. The buffers must not be modified while
+	// the session is open.
+	ExternalInitializerFiles map[string][]byte
+
+	// CustomOpDomains registers Go custom operators created with
+	// NewCustomOpDomain for use by the model.
+	CustomOpDomains []*CustomOpDomain
+
+	// AdapterRegistry resolves the LoRA adapter names passed to
+	// WithLoraAdapterName. The registry must stay open while the session
+	// runs.
+	AdapterRegistry *AdapterRegistry
+
+	// PrepackedWeights shares pre-packed kernel weights with every other
+	// session created with the same container, including sessions in
+	// different SessionPools serving the same base model. The container
+	// must not be closed before the sessions using it.
+	PrepackedWeights *PrepackedWeightsContainer
+
 	// ConfigEntries provides arbitrary key-value configuration entries.
 	ConfigEntries map[string]string
 
@@ -80,10 +128,89 @@ type SessionOptions struct {
 	// avoiding re-optimization and reducing session creation time.
 	OptimizedModelFilePath string
 
+	// DisablePrepacking stops kernels from pre-packing constant weights,
+	// lowering memory use at some cost in speed.
+	DisablePrepacking bool
+
+	// UseDeviceAllocatorForInitializers allocates initializers with the
+	// device allocator instead of the arena, so they do not inflate it.
+	UseDeviceAllocatorForInitializers bool
+
+	// IntraOpSpinning controls whether intra-op threads spin while waiting
+	// for work. nil means use the ORT default (enabled). Disabling it lowers
+	// CPU usage at some cost in latency.
+	IntraOpSpinning *bool
+
+	// InterOpSpinning controls whether inter-op threads spin while waiting
+	// for work. nil means use the ORT default (enabled).
+	InterOpSpinning *bool
+
+	// DisableQuantQDQ stops the optimizer from fusing QuantizeLinear and
+	// DequantizeLinear pairs into quantized operators.
+	DisableQuantQDQ bool
+
+	// EnableQuantQDQCleanup removes QuantizeLinear/DequantizeLinear pairs
+	// left over after optimization, trading accuracy for speed.
+	EnableQuantQDQCleanup bool
+
+	// DisableCPUEPFallback makes session creation fail instead of placing
+	// nodes unsupported by the requested providers on the CPU provider.
+	DisableCPUEPFallback bool
+
+	// DynamicBlockBase sets the block size base used to split work across
+	// intra-op threads. Zero keeps the ORT default; a value such as 4
+	// improves load balancing for models with uneven operator costs.
+	DynamicBlockBase int
+
+	// UseEnvAllocators makes the session use allocators registered on its Env
+	// with Env.CreateAndRegisterAllocator, so sessions share one arena.
+	UseEnvAllocators bool
+
 	// DisablePerSessionThreads prevents the session from creating its own thread pools.
 	// Set to true when using an Env created with NewEnvWithGlobalThreadPools
-	// so sessions use the shared global thread pool instead.
+	// so sessions use the shared global thread pool instead. Any other Env
+	// is rejected; NewSessionPool checks this before creating sessions.
 	DisablePerSessionThreads bool
+
+	// ModelFormat forces the format used to load the model.
+	// Zero value (ModelFormatAuto) lets ONNX Runtime detect it.
+	ModelFormat ModelFormat
+
+	// OptimizedModelFormat sets the format written to OptimizedModelFilePath.
+	// Use ModelFormatORT to produce a model loadable by minimal builds.
+	// Zero value (ModelFormatAuto) picks the format from the file extension.
+	OptimizedModelFormat ModelFormat
+
+	// UseORTModelBytesDirectly makes a session created from in-memory ORT format
+	// data use the buffer in place, including for initializers, instead of
+	// copying it. This reduces peak memory on constrained devices. The session
+	// keeps the buffer alive; it must not be modified afterwards.
+	UseORTModelBytesDirectly bool
+
+	// LoadProgress, if set, is called as NewSessionFromReader,
+	// NewSessionFromReaderAt, and NewSessionFromFileMmap read the model, with
+	// the bytes loaded so far and the model size, or -1 if the size is not
+	// known in advance. Files that ONNX Runtime reads itself, as with
+	// NewSession, are not reported.
+	LoadProgress func(loaded, total int64)
+
+	// FaultInjector, when set, injects artificial errors, panics, latency and
+	// session poisoning into every Run. For testing only.
+	FaultInjector *FaultInjector
+
+	// Hooks are called around every Run, RunIndexed, RunAsync and
+	// IoBinding.Run of the session, as PoolConfig.Hooks are around pool
+	// runs. The pool-specific RunInfo fields are left zero. Sessions of a
+	// pool whose SessionOptions set Hooks call them in addition to
+	// PoolConfig.Hooks.
+	Hooks []Hook
+
+	// ProfilingLabels sets pprof labels identifying the session, its model
+	// and the run tag around every native run, so that CPU profiles can
+	// attribute time spent in ONNX Runtime. It costs a few allocations per
+	// run, so it is off by default. Runs are marked as runtime/trace regions
+	// whenever an execution trace is recorded, regardless of this option.
+	ProfilingLabels bool
 }
 
 // Session represents an ONNX Runtime inference session that can execute
@@ -103,6 +230,69 @@ type Session struct {
 	// cached null-terminated name bytes to avoid per-Run allocations
 	inputNameCStrs  [][]byte
 	outputNameCStrs [][]byte
+
+	// pointers to the cached names, in model order, passed to Run as is
+	inputNamePtrs  []*byte
+	outputNamePtrs []*byte
+
+	// positions of the input and output names, for name lookups on hot paths
+	inputIndex  map[string]int
+	outputIndex map[string]int
+
+	// *runScratch argument arrays reused across runs
+	scratch sync.Pool
+
+	// configuration of runs without options, shared since they never modify it
+	defaultRun runConfig
+
+	// identifiers used for pprof labels and trace regions
+	id        uint64
+	modelName string
+
+	// path of the model file, used to find warmup sidecar files; empty for
+	// sessions created from bytes
+	modelPath string
+
+	// execution provider selected at creation time
+	activeProvider string
+
+	// serialization format of the loaded model
+	modelFormat ModelFormat
+
+	// buffers and objects the native session references
+	retained *sessionRetained
+
+	// registry resolving WithLoraAdapterName
+	loraRegistry *AdapterRegistry
+
+	// test-only fault injection, nil in production
+	faults *FaultInjector
+
+	// hooks called around every run, from SessionOptions.Hooks
+	hooks []Hook
+
+	// RunAsync calls that have not completed yet
+	inflight sync.WaitGroup
+
+	// persistent IO binding used by SessionPool.RunBound, created on first use
+	poolBinding *IoBinding
+
+	// set once a run panics, see Healthy
+	unhealthy atomic.Bool
+
+	// ID of the session in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the session is not closed
+	cleanup goruntime.Cleanup
+
+	// pprof labels set around native runs, nil unless
+	// SessionOptions.ProfilingLabels is set
+	labels *profilingLabels
+
+	// inputs added to runs that do not provide them, see SetConstantInput
+	constants   atomic.Pointer[map[string]*Value]
+	constantsMu sync.Mutex // serializes SetConstantInput
 }
 
 // NewSession creates a new inference session from a model file.
@@ -112,8 +302,11 @@ func (r *Runtime) NewSession(env *Env, modelPath string, options *SessionOptions
 
 // NewSessionFromReader creates a new inference session from a model loaded from modelReader.
 // The modelReader contains the ONNX model data, and options configures session-specific settings (may be nil for defaults).
+// The model is read into memory first; for multi-gigabyte models prefer
+// NewSessionFromFileMmap or NewSessionFromReaderAt, which avoid growing the
+// buffer while reading.
 func (r *Runtime) NewSessionFromReader(env *Env, modelReader io.Reader, options *SessionOptions) (*Session, error) {
-	modelData, err := io.ReadAll(modelReader)
+	modelData, err := readModel(modelReader, options.loadProgress())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read model data: %w", err)
 	}
@@ -122,6 +315,24 @@ func (r *Runtime) NewSessionFromReader(env *Env, modelReader io.Reader, options
 
 // newSessionFromFile creates a session from a file path with optional prepacked weights sharing.
 func (r *Runtime) newSessionFromFile(env *Env, modelPath string, options *SessionOptions, prepackedWeights *PrepackedWeightsContainer) (*Session, error) {
+	if options != nil && len(options.ProviderFallbackChain) > 0 {
+		return r.newSessionWithFallbackChain(options, func(opts *SessionOptions) (*Session, error) {
+			return r.newSessionFromFile(env, modelPath, opts, prepackedWeights)
+		})
+	}
+
+	if prepackedWeights == nil && options != nil {
+		prepackedWeights = options.PrepackedWeights
+	}
+
+	format, err := DetectModelFormatFromFile(modelPath)
+	if err != nil {
+		format = ModelFormatAuto
+	}
+	if err := checkModelFormat(options, format); err != nil {
+		return nil, err
+	}
+
 	optsPtr, cleanupOpts, err := r.createAndConfigureSessionOptions(options)
 	if err != nil {
 		return nil, err
@@ -132,17 +343,26 @@ func (r *Runtime) newSessionFromFile(env *Env, modelPath string, options *Sessio
 	var sessionPtr api.OrtSession
 	var status api.OrtStatus
 
+	op := "CreateSession"
 	if prepackedWeights != nil && prepackedWeights.ptr != 0 {
+		op = "CreateSessionWithPrepackedWeightsContainer"
 		status = r.apiFuncs.CreateSessionWithPrepackedWeightsContainer(
 			env.ptr, &modelPathBytes[0], optsPtr, prepackedWeights.ptr, &sessionPtr)
 	} else {
 		status = r.apiFuncs.CreateSession(env.ptr, &modelPathBytes[0], optsPtr, &sessionPtr)
 	}
-	if err := r.statusError(status); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+	if err := r.statusError(status, op); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", wrapModelFormatError(err, format))
 	}
 
-	return r.finalizeSession(sessionPtr)
+	session, err := r.finalizeSession(sessionPtr, options)
+	if err != nil {
+		return nil, err
+	}
+	session.modelName = filepath.Base(modelPath)
+	session.modelPath = modelPath
+	session.modelFormat = format
+	return session, nil
 }
 
 // newSessionFromBytes creates a session from in-memory model data with optional prepacked weights sharing.
@@ -151,6 +371,21 @@ func (r *Runtime) newSessionFromBytes(env *Env, modelData []byte, options *Sessi
 		return nil, fmt.Errorf("model data cannot be empty")
 	}
 
+	if options != nil && len(options.ProviderFallbackChain) > 0 {
+		return r.newSessionWithFallbackChain(options, func(opts *SessionOptions) (*Session, error) {
+			return r.newSessionFromBytes(env, modelData, opts, prepackedWeights)
+		})
+	}
+
+	if prepackedWeights == nil && options != nil {
+		prepackedWeights = options.PrepackedWeights
+	}
+
+	format := DetectModelFormat(modelData)
+	if err := checkModelFormat(options, format); err != nil {
+		return nil, err
+	}
+
 	optsPtr, cleanupOpts, err := r.createAndConfigureSessionOptions(options)
 	if err != nil {
 		return nil, err
@@ -160,7 +395,9 @@ func (r *Runtime) newSessionFromBytes(env *Env, modelData []byte, options *Sessi
 	var sessionPtr api.OrtSession
 	var status api.OrtStatus
 
+	op := "CreateSessionFromArray"
 	if prepackedWeights != nil && prepackedWeights.ptr != 0 {
+		op = "CreateSessionFromArrayWithPrepackedWeightsContainer"
 		status = r.apiFuncs.CreateSessionFromArrayWithPrepackedWeightsContainer(
 			env.ptr, unsafe.Pointer(&modelData[0]), uintptr(len(modelData)),
 			optsPtr, prepackedWeights.ptr, &sessionPtr)
@@ -169,11 +406,19 @@ func (r *Runtime) newSessionFromBytes(env *Env, modelData []byte, options *Sessi
 			env.ptr, unsafe.Pointer(&modelData[0]), uintptr(len(modelData)),
 			optsPtr, &sessionPtr)
 	}
-	if err := r.statusError(status); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+	if err := r.statusError(status, op); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", wrapModelFormatError(err, format))
 	}
 
-	return r.finalizeSession(sessionPtr)
+	session, err := r.finalizeSession(sessionPtr, options)
+	if err != nil {
+		return nil, err
+	}
+	session.modelFormat = format
+	if options != nil && options.UseORTModelBytesDirectly {
+		session.retained.modelData = modelData
+	}
+	return session, nil
 }
 
 // createAndConfigureSessionOptions creates and configures ORT session options.
@@ -185,7 +430,7 @@ func (r *Runtime) createAndConfigureSessionOptions(options *SessionOptions) (api
 
 	var optsPtr api.OrtSessionOptions
 	status := r.apiFuncs.CreateSessionOptions(&optsPtr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateSessionOptions"); err != nil {
 		return 0, nil, fmt.Errorf("failed to create session options: %w", err)
 	}
 
@@ -200,16 +445,46 @@ func (r *Runtime) createAndConfigureSessionOptions(options *SessionOptions) (api
 		return 0, nil, err
 	}
 
-	return optsPtr, cleanup, nil
+	// Provider options such as arena configs must stay alive until the
+	// session has created its allocators.
+	releaseProviders, err := r.configureExecutionProviders(optsPtr, options)
+	if err != nil {
+		cleanup()
+		return 0, nil, err
+	}
+
+	return optsPtr, func() {
+		releaseProviders()
+		cleanup()
+	}, nil
 }
 
 // finalizeSession wraps a raw session pointer and initializes its metadata.
-func (r *Runtime) finalizeSession(sessionPtr api.OrtSession) (*Session, error) {
+func (r *Runtime) finalizeSession(sessionPtr api.OrtSession, options *SessionOptions) (*Session, error) {
 	session := &Session{
-		ptr:     sessionPtr,
-		runtime: r,
+		ptr:            sessionPtr,
+		runtime:        r,
+		id:             nextSessionID.Add(1),
+		activeProvider: cpuExecutionProvider,
+		trackID:        r.objects.add(kindSession),
+		retained:       &sessionRetained{},
+	}
+	if options != nil && len(options.ExecutionProviders) > 0 {
+		session.activeProvider = options.ExecutionProviders[0].Name
+	}
+	if options != nil {
+		session.faults = options.FaultInjector
+		session.hooks = options.Hooks
+		if options.ProfilingLabels {
+			session.labels = &profilingLabels{}
+		}
+		session.loraRegistry = options.AdapterRegistry
+		session.retained.prepackedWeights = options.PrepackedWeights
+		session.retained.customOpDomains = options.CustomOpDomains
+		session.retained.externalInitializers = options.ExternalInitializers
+		session.retained.externalInitializerFiles = options.ExternalInitializerFiles
 	}
-	goruntime.AddCleanup(session, func(_ struct{}) { session.Close() }, struct{}{})
+	session.cleanup = guard(session, leak{runtime: r, kind: kindSession, id: session.trackID, ptr: uintptr(sessionPtr), release: releaseLeakedSession, keep: session.retained})
 
 	if err := session.initializeMetadata(); err != nil {
 		session.Close()
@@ -229,6 +504,7 @@ func (s *Session) initializeMetadata() error {
 
 	s.inputNames = make([]string, inputCount)
 	s.inputNameCStrs = make([][]byte, inputCount)
+	s.inputIndex = make(map[string]int, inputCount)
 	for i := range inputCount {
 		name, err := s.getInputName(i)
 		if err != nil {
@@ -236,6 +512,8 @@ func (s *Session) initializeMetadata() error {
 		}
 		s.inputNames[i] = name
 		s.inputNameCStrs[i] = append([]byte(name), 0)
+		s.inputNamePtrs = append(s.inputNamePtrs, &s.inputNameCStrs[i][0])
+		s.inputIndex[name] = i
 	}
 
 	// Get output count and names
@@ -246,6 +524,7 @@ func (s *Session) initializeMetadata() error {
 
 	s.outputNames = make([]string, outputCount)
 	s.outputNameCStrs = make([][]byte, outputCount)
+	s.outputIndex = make(map[string]int, outputCount)
 	for i := range outputCount {
 		name, err := s.getOutputName(i)
 		if err != nil {
@@ -253,11 +532,29 @@ func (s *Session) initializeMetadata() error {
 		}
 		s.outputNames[i] = name
 		s.outputNameCStrs[i] = append([]byte(name), 0)
+		s.outputNamePtrs = append(s.outputNamePtrs, &s.outputNameCStrs[i][0])
+		s.outputIndex[name] = i
 	}
 
+	s.defaultRun = runConfig{
+		outputNames:  s.outputNames, // default: all outputs
+		loraRegistry: s.loraRegistry,
+	}
 	return nil
 }
 
+// ModelFormat returns the serialization format of the loaded model.
+func (s *Session) ModelFormat() ModelFormat {
+	return s.modelFormat
+}
+
+// ActiveProvider returns the name of the execution provider selected when the
+// session was created: the provider chosen from SessionOptions.ProviderFallbackChain,
+// the first of SessionOptions.ExecutionProviders, or "CPUExecutionProvider".
+func (s *Session) ActiveProvider() string {
+	return s.activeProvider
+}
+
 // InputNames returns all input names for the model.
 func (s *Session) InputNames() []string {
 	return s.inputNames
@@ -268,6 +565,24 @@ func (s *Session) OutputNames() []string {
 	return s.outputNames
 }
 
+// InputIndex returns the position of the named input in InputNames, or -1
+// if the model has no such input.
+func (s *Session) InputIndex(name string) int {
+	if i, ok := s.inputIndex[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// OutputIndex returns the position of the named output in OutputNames, or
+// -1 if the model has no such output.
+func (s *Session) OutputIndex(name string) int {
+	if i, ok := s.outputIndex[name]; ok {
+		return i
+	}
+	return -1
+}
+
 // getInputCount retrieves the input count from ONNX Runtime (internal use)
 func (s *Session) getInputCount() (int, error) {
 	if s.ptr == 0 {
@@ -276,7 +591,7 @@ func (s *Session) getInputCount() (int, error) {
 
 	var count uintptr
 	status := s.runtime.apiFuncs.SessionGetInputCount(s.ptr, &count)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "SessionGetInputCount"); err != nil {
 		return 0, fmt.Errorf("failed to get input count: %w", err)
 	}
 
@@ -291,7 +606,7 @@ func (s *Session) getOutputCount() (int, error) {
 
 	var count uintptr
 	status := s.runtime.apiFuncs.SessionGetOutputCount(s.ptr, &count)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "SessionGetOutputCount"); err != nil {
 		return 0, fmt.Errorf("failed to get output count: %w", err)
 	}
 
@@ -310,7 +625,7 @@ func (s *Session) getInputName(index int) (string, error) {
 
 	var namePtr *byte
 	status := s.runtime.apiFuncs.SessionGetInputName(s.ptr, uintptr(index), s.runtime.allocator.ptr, &namePtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "SessionGetInputName"); err != nil {
 		return "", fmt.Errorf("failed to get input name: %w", err)
 	}
 
@@ -334,7 +649,7 @@ func (s *Session) getOutputName(index int) (string, error) {
 
 	var namePtr *byte
 	status := s.runtime.apiFuncs.SessionGetOutputName(s.ptr, uintptr(index), s.runtime.allocator.ptr, &namePtr)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "SessionGetOutputName"); err != nil {
 		return "", fmt.Errorf("failed to get output name: %w", err)
 	}
 
@@ -350,9 +665,11 @@ func (s *Session) getOutputName(index int) (string, error) {
 type RunOption func(*runConfig)
 
 type runConfig struct {
-	outputNames  []string
-	loraAdapters []*LoraAdapter
-	runTag       string
+	outputNames      []string
+	loraAdapters     []*LoraAdapter
+	loraAdapterNames []string
+	loraRegistry     *AdapterRegistry
+	runTag           string
 }
 
 // WithOutputNames specifies which outputs to compute during inference.
@@ -371,26 +688,86 @@ func WithRunTag(tag string) RunOption {
 	}
 }
 
+// runTag returns the tag opts set with WithRunTag.
+func runTag(opts []RunOption) string {
+	if len(opts) == 0 {
+		return ""
+	}
+	var config runConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config.runTag
+}
+
 // Run executes the model with the provided inputs and returns the computed outputs.
 // The inputs parameter is a map from input name to tensor value.
-func (s *Session) Run(ctx context.Context, inputs map[string]*Value, opts ...RunOption) (map[string]*Value, error) {
+//
+// If ctx is done while the model runs, the run is terminated and the returned
+// error matches context.Canceled or context.DeadlineExceeded with errors.Is.
+//
+// A panic during the run is returned as a *PanicError and marks the session
+// unhealthy; see Healthy.
+func (s *Session) Run(ctx context.Context, inputs map[string]*Value, opts ...RunOption) (outputs map[string]*Value, err error) {
 	if s.ptr == 0 {
 		return nil, ErrSessionClosed
 	}
+	if len(s.hooks) > 0 {
+		afterRun := s.beforeRun(ctx, inputs, keys(inputs), runTag(opts))
+		defer func() { afterRun(keys(outputs), err) }()
+	}
+	defer s.recoverRun(&err)
+	if s.faults != nil {
+		if err := s.faults.inject(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+
+	config := s.runConfig(opts)
+
+	// Call the low-level run method
+	outputValues, err := s.run(ctx, inputs, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert output arrays to map
+	outputs = make(map[string]*Value, len(outputValues))
+	for i, value := range outputValues {
+		outputs[config.outputNames[i]] = value
+	}
+	return outputs, nil
+}
 
+// runConfig returns the configuration of a run with opts. Runs without
+// options share the session's default configuration.
+func (s *Session) runConfig(opts []RunOption) *runConfig {
+	if len(opts) == 0 {
+		return &s.defaultRun
+	}
 	config := &runConfig{
-		outputNames: s.outputNames, // default: all outputs
+		outputNames:  s.outputNames, // default: all outputs
+		loraRegistry: s.loraRegistry,
 	}
 	for _, opt := range opts {
 		opt(config)
 	}
+	return config
+}
 
-	// Build input arrays from map using cached metadata
+// orderedInputs builds input arrays from the map and the constant inputs in
+// model input order using cached metadata.
+func (s *Session) orderedInputs(inputs map[string]*Value) ([]string, []*Value) {
 	inputNames := make([]string, 0, len(s.inputNames))
 	inputValues := make([]*Value, 0, len(s.inputNames))
 
+	constants := s.constantInputs()
 	for _, name := range s.inputNames {
-		if value, ok := inputs[name]; ok {
+		value, ok := inputs[name]
+		if !ok {
+			value, ok = constants[name]
+		}
+		if ok {
 			inputNames = append(inputNames, name)
 			inputValues = append(inputValues, value)
 		} else {
@@ -398,34 +775,94 @@ func (s *Session) Run(ctx context.Context, inputs map[string]*Value, opts ...Run
 			inputValues = append(inputValues, nil)
 		}
 	}
+	return inputNames, inputValues
+}
 
-	// Call the low-level run method
-	outputValues, err := s.run(ctx, inputNames, inputValues, config)
+// runHandle owns the OrtRunOptions of one run and terminates the run when
+// its context is done or Runtime.TerminateRun is called with its tag.
+type runHandle struct {
+	ptr           api.OrtRunOptions // zero when no run options are needed
+	ctx           context.Context
+	tag           string
+	terminateOnce sync.Once
+	cause         error // why the run was terminated, set before terminated
+	terminated    atomic.Bool
+	done          chan struct{}
+	wg            sync.WaitGroup
+	runtime       *Runtime
+
+	// release drops the references to adapters taken from an AdapterRegistry
+	release func()
+}
+
+// close stops the cancellation watcher and releases the run options. Waiting
+// for the watcher first prevents RunOptionsSetTerminate being called on freed
+// memory.
+func (h *runHandle) close() {
+	if h.ptr != 0 {
+		close(h.done)
+		h.wg.Wait()
+		h.runtime.untrackRun(h)
+		h.runtime.apiFuncs.ReleaseRunOptions(h.ptr)
+	}
+	if h.release != nil {
+		h.release()
+	}
+}
+
+// terminate asks ONNX Runtime to stop the run, recording cause for
+// wrapError. The run options must not have been released.
+func (h *runHandle) terminate(cause error) {
+	h.terminateOnce.Do(func() {
+		h.cause = cause
+		h.terminated.Store(true)
+		h.runtime.apiFuncs.RunOptionsSetTerminate(h.ptr)
+	})
+}
+
+// wrapError makes err from a run match the context's error, or
+// ErrRunTerminated, with errors.Is when the run failed because it was
+// terminated.
+func (h *runHandle) wrapError(err error) error {
+	if err == nil || !h.terminated.Load() {
+		return err
+	}
+	cause := h.cause
+	if cause == nil {
+		cause = h.ctx.Err()
+	}
+	return fmt.Errorf("%w: %w", cause, err)
+}
+
+// createRunOptions creates OrtRunOptions with context cancellation and LoRA
+// adapter support. The returned handle must be closed after the run, which
+// also releases adapters acquired from config.loraRegistry.
+func (r *Runtime) createRunOptions(ctx context.Context, config *runConfig) (*runHandle, error) {
+	release, err := acquireLoraAdapters(config.loraRegistry, config)
 	if err != nil {
 		return nil, err
 	}
-
-	// Convert output arrays to map
-	outputs := make(map[string]*Value, len(outputValues))
-	for i, value := range outputValues {
-		outputs[config.outputNames[i]] = value
+	h, err := r.newRunHandle(ctx, config)
+	if err != nil {
+		release()
+		return nil, err
 	}
-	return outputs, nil
+	h.release = release
+	return h, nil
 }
 
-// createRunOptions creates OrtRunOptions with context cancellation and LoRA adapter support.
-// Returns the run options pointer and a cleanup function that must be called.
-func (s *Session) createRunOptions(ctx context.Context, config *runConfig) (api.OrtRunOptions, func(), error) {
+// newRunHandle creates the run options for createRunOptions.
+func (r *Runtime) newRunHandle(ctx context.Context, config *runConfig) (*runHandle, error) {
 	needsRunOpts := (ctx != nil && ctx.Done() != nil) || len(config.loraAdapters) > 0 || config.runTag != ""
 
 	if !needsRunOpts {
-		return 0, func() {}, nil
+		return &runHandle{}, nil
 	}
 
 	var runOpts api.OrtRunOptions
-	status := s.runtime.apiFuncs.CreateRunOptions(&runOpts)
-	if err := s.runtime.statusError(status); err != nil {
-		return 0, nil, fmt.Errorf("failed to create run options: %w", err)
+	status := r.apiFuncs.CreateRunOptions(&runOpts)
+	if err := r.statusError(status, "CreateRunOptions"); err != nil {
+		return nil, fmt.Errorf("failed to create run options: %w", err)
 	}
 
 	// Attach LoRA adapters to run options
@@ -433,64 +870,165 @@ func (s *Session) createRunOptions(ctx context.Context, config *runConfig) (api.
 		if adapter == nil || adapter.ptr == 0 {
 			continue
 		}
-		status := s.runtime.apiFuncs.RunOptionsAddActiveLoraAdapter(runOpts, adapter.ptr)
-		if err := s.runtime.statusError(status); err != nil {
-			s.runtime.apiFuncs.ReleaseRunOptions(runOpts)
-			return 0, nil, fmt.Errorf("failed to add LoRA adapter to run options: %w", err)
+		status := r.apiFuncs.RunOptionsAddActiveLoraAdapter(runOpts, adapter.ptr)
+		if err := r.statusError(status, "RunOptionsAddActiveLoraAdapter"); err != nil {
+			r.apiFuncs.ReleaseRunOptions(runOpts)
+			return nil, fmt.Errorf("failed to add LoRA adapter to run options: %w", err)
 		}
 	}
 
 	// Set run tag
 	if config.runTag != "" {
 		tagBytes := append([]byte(config.runTag), 0)
-		status := s.runtime.apiFuncs.RunOptionsSetRunTag(runOpts, &tagBytes[0])
-		if err := s.runtime.statusError(status); err != nil {
-			s.runtime.apiFuncs.ReleaseRunOptions(runOpts)
-			return 0, nil, fmt.Errorf("failed to set run tag: %w", err)
+		status := r.apiFuncs.RunOptionsSetRunTag(runOpts, &tagBytes[0])
+		if err := r.statusError(status, "RunOptionsSetRunTag"); err != nil {
+			r.apiFuncs.ReleaseRunOptions(runOpts)
+			return nil, fmt.Errorf("failed to set run tag: %w", err)
 		}
 	}
 
+	h := &runHandle{
+		ptr:     runOpts,
+		ctx:     ctx,
+		tag:     config.runTag,
+		done:    make(chan struct{}),
+		runtime: r,
+	}
+	r.trackRun(h)
+
 	// Watch for context cancellation in a goroutine.
-	// Use WaitGroup to ensure the goroutine has fully exited before
-	// we release the run options, preventing a race where
-	// RunOptionsSetTerminate is called on freed memory.
-	var wg sync.WaitGroup
-	done := make(chan struct{})
 	if ctx != nil && ctx.Done() != nil {
-		wg.Add(1)
+		h.wg.Add(1)
 		go func() {
-			defer wg.Done()
+			defer h.wg.Done()
 			select {
 			case <-ctx.Done():
-				s.runtime.apiFuncs.RunOptionsSetTerminate(runOpts)
-			case <-done:
+				h.terminate(context.Cause(ctx))
+			case <-h.done:
 			}
 		}()
 	}
 
-	cleanup := func() {
-		close(done)
-		wg.Wait()
-		s.runtime.apiFuncs.ReleaseRunOptions(runOpts)
+	return h, nil
+}
+
+// run executes the model with the provided inputs and returns the computed outputs.
+func (s *Session) run(ctx context.Context, inputs map[string]*Value, config *runConfig) ([]*Value, error) {
+	sc := s.getScratch()
+	defer s.scratch.Put(sc)
+	inputNamePtrs, inputValuePtrs, outputNamePtrs, outputValuePtrs := sc.prepare(s, inputs, config.outputNames)
+	return s.runPrepared(ctx, config, inputNamePtrs, inputValuePtrs, outputNamePtrs, outputValuePtrs)
+}
+
+// runPrepared runs the session with argument arrays built by a runScratch
+// and wraps the output values.
+func (s *Session) runPrepared(ctx context.Context, config *runConfig, inputNamePtrs []*byte, inputValuePtrs []api.OrtValue, outputNamePtrs []*byte, outputValuePtrs []api.OrtValue) ([]*Value, error) {
+	runOpts, err := s.runtime.createRunOptions(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer runOpts.close()
+
+	// Call Run
+	var status api.OrtStatus
+	s.traceNative(ctx, regionRun, config.runTag, func() {
+		status = s.runtime.apiFuncs.Run(
+			s.ptr,
+			runOpts.ptr,
+			&inputNamePtrs[0],
+			&inputValuePtrs[0],
+			uintptr(len(inputValuePtrs)),
+			&outputNamePtrs[0],
+			uintptr(len(outputValuePtrs)),
+			&outputValuePtrs[0],
+		)
+	})
+	if err := s.runtime.statusError(status, "Run"); err != nil {
+		return nil, runOpts.wrapError(fmt.Errorf("failed to run inference: %w", err))
+	}
+
+	// Wrap output values
+	outputs := make([]*Value, len(outputValuePtrs))
+	for i, ptr := range outputValuePtrs {
+		outputs[i] = s.runtime.newValueFromPtr(ptr)
 	}
 
-	return runOpts, cleanup, nil
+	return outputs, nil
 }
 
-// run executes the model with the provided inputs and returns the computed outputs.
-func (s *Session) run(ctx context.Context, inputNames []string, inputs []*Value, config *runConfig) ([]*Value, error) {
-	if len(inputNames) != len(inputs) {
-		return nil, fmt.Errorf("number of input names (%d) must match number of inputs (%d)", len(inputNames), len(inputs))
+// getScratch returns argument arrays for a run, to be put back into
+// s.scratch once the run returns.
+func (s *Session) getScratch() *runScratch {
+	if sc, ok := s.scratch.Get().(*runScratch); ok {
+		return sc
 	}
+	return &runScratch{}
+}
 
-	outputNames := config.outputNames
+// runScratch holds the argument arrays of a synchronous run. Sessions reuse
+// them across runs so that Run does not allocate them on every call.
+type runScratch struct {
+	inputNamePtrs   []*byte
+	inputValuePtrs  []api.OrtValue
+	outputNamePtrs  []*byte
+	outputValuePtrs []api.OrtValue
+}
 
-	runOpts, cleanup, err := s.createRunOptions(ctx, config)
-	if err != nil {
-		return nil, err
+// emptyCString is the name passed for model inputs missing from a run.
+var emptyCString = []byte{0}
+
+// prepare returns the argument arrays for running s with inputs and its
+// constant inputs, in model input order, and outputNames. When every input is provided and the
+// outputs are the defaults, the name arrays are the session's own.
+func (sc *runScratch) prepare(s *Session, inputs map[string]*Value, outputNames []string) (inputNamePtrs []*byte, inputValuePtrs []api.OrtValue, outputNamePtrs []*byte, outputValuePtrs []api.OrtValue) {
+	sc.inputValuePtrs = slices.Grow(sc.inputValuePtrs[:0], len(s.inputNames))
+	inputNamePtrs = s.inputNamePtrs
+	constants := s.constantInputs()
+	for i, name := range s.inputNames {
+		value, ok := inputs[name]
+		if !ok {
+			value, ok = constants[name]
+		}
+		if !ok {
+			if &inputNamePtrs[0] == &s.inputNamePtrs[0] {
+				// An input is missing: switch to a copy of the names
+				sc.inputNamePtrs = append(sc.inputNamePtrs[:0], s.inputNamePtrs...)
+				inputNamePtrs = sc.inputNamePtrs
+			}
+			inputNamePtrs[i] = &emptyCString[0]
+			sc.inputValuePtrs = append(sc.inputValuePtrs, 0)
+			continue
+		}
+		var ptr api.OrtValue
+		if value != nil {
+			ptr = value.ptr
+		}
+		sc.inputValuePtrs = append(sc.inputValuePtrs, ptr)
+	}
+
+	if len(outputNames) == len(s.outputNames) && (len(outputNames) == 0 || &outputNames[0] == &s.outputNames[0]) {
+		outputNamePtrs = s.outputNamePtrs
+	} else {
+		sc.outputNamePtrs = sc.outputNamePtrs[:0]
+		for _, name := range outputNames {
+			if i, ok := s.outputIndex[name]; ok {
+				sc.outputNamePtrs = append(sc.outputNamePtrs, s.outputNamePtrs[i])
+			} else {
+				nameBytes := append([]byte(name), 0)
+				sc.outputNamePtrs = append(sc.outputNamePtrs, &nameBytes[0])
+			}
+		}
+		outputNamePtrs = sc.outputNamePtrs
 	}
-	defer cleanup()
 
+	sc.outputValuePtrs = slices.Grow(sc.outputValuePtrs[:0], len(outputNames))[:len(outputNames)]
+	clear(sc.outputValuePtrs)
+	return inputNamePtrs, sc.inputValuePtrs, outputNamePtrs, sc.outputValuePtrs
+}
+
+// runArgs converts input names, input values and output names into the
+// C arrays passed to Run and RunAsync.
+func (s *Session) runArgs(inputNames []string, inputs []*Value, outputNames []string) ([]*byte, []api.OrtValue, []*byte) {
 	// Prepare input name pointers using cached C strings
 	inputNamePtrs := make([]*byte, len(inputNames))
 	for i, name := range inputNames {
@@ -521,90 +1059,70 @@ func (s *Session) run(ctx context.Context, inputNames []string, inputs []*Value,
 		}
 	}
 
-	// Prepare output value pointers
-	outputValuePtrs := make([]api.OrtValue, len(outputNames))
-
-	// Call Run
-	status := s.runtime.apiFuncs.Run(
-		s.ptr,
-		runOpts,
-		&inputNamePtrs[0],
-		&inputValuePtrs[0],
-		uintptr(len(inputs)),
-		&outputNamePtrs[0],
-		uintptr(len(outputNames)),
-		&outputValuePtrs[0],
-	)
-	if err := s.runtime.statusError(status); err != nil {
-		return nil, fmt.Errorf("failed to run inference: %w", err)
-	}
-
-	// Wrap output values
-	outputs := make([]*Value, len(outputValuePtrs))
-	for i, ptr := range outputValuePtrs {
-		outputs[i] = s.runtime.newValueFromPtr(ptr)
-	}
-
-	return outputs, nil
+	return inputNamePtrs, inputValuePtrs, outputNamePtrs
 }
 
 // configureSessionOptions applies all session options to the ORT session options pointer.
 func (r *Runtime) configureSessionOptions(optsPtr api.OrtSessionOptions, options *SessionOptions) error {
 	if options.IntraOpNumThreads > 0 {
 		status := r.apiFuncs.SetIntraOpNumThreads(optsPtr, int32(options.IntraOpNumThreads))
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "SetIntraOpNumThreads"); err != nil {
 			return fmt.Errorf("failed to set intra-op num threads: %w", err)
 		}
 	}
 
 	if options.InterOpNumThreads > 0 {
 		status := r.apiFuncs.SetInterOpNumThreads(optsPtr, int32(options.InterOpNumThreads))
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "SetInterOpNumThreads"); err != nil {
 			return fmt.Errorf("failed to set inter-op num threads: %w", err)
 		}
 	}
 
 	if options.GraphOptimization != 0 {
 		status := r.apiFuncs.SetSessionGraphOptimizationLevel(optsPtr, int32(options.GraphOptimization))
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "SetSessionGraphOptimizationLevel"); err != nil {
 			return fmt.Errorf("failed to set graph optimization level: %w", err)
 		}
 	}
 
 	if options.ExecutionMode != 0 {
 		status := r.apiFuncs.SetSessionExecutionMode(optsPtr, int32(options.ExecutionMode))
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "SetSessionExecutionMode"); err != nil {
 			return fmt.Errorf("failed to set execution mode: %w", err)
 		}
 	}
 
 	if options.CpuMemArena != nil {
 		var status api.OrtStatus
+		op := "EnableCpuMemArena"
 		if *options.CpuMemArena {
 			status = r.apiFuncs.EnableCpuMemArena(optsPtr)
 		} else {
+			op = "DisableCpuMemArena"
 			status = r.apiFuncs.DisableCpuMemArena(optsPtr)
 		}
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, op); err != nil {
 			return fmt.Errorf("failed to configure CPU memory arena: %w", err)
 		}
 	}
 
 	if options.MemPattern != nil {
 		var status api.OrtStatus
+		op := "EnableMemPattern"
 		if *options.MemPattern {
 			status = r.apiFuncs.EnableMemPattern(optsPtr)
 		} else {
+			op = "DisableMemPattern"
 			status = r.apiFuncs.DisableMemPattern(optsPtr)
 		}
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, op); err != nil {
 			return fmt.Errorf("failed to configure memory pattern: %w", err)
 		}
 	}
 
 	if options.LogSeverityLevel != nil {
 		status := r.apiFuncs.SetSessionLogSeverityLevel(optsPtr, int32(*options.LogSeverityLevel))
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "SetSessionLogSeverityLevel"); err != nil {
 			return fmt.Errorf("failed to set log severity level: %w", err)
 		}
 	}
@@ -612,27 +1130,61 @@ func (r *Runtime) configureSessionOptions(optsPtr api.OrtSessionOptions, options
 	for name, size := range options.FreeDimensionOverrides {
 		nameBytes := append([]byte(name), 0)
 		status := r.apiFuncs.AddFreeDimensionOverrideByName(optsPtr, &nameBytes[0], size)
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "AddFreeDimensionOverrideByName"); err != nil {
 			return fmt.Errorf("failed to add free dimension override %q: %w", name, err)
 		}
 	}
 
+	for denotation, size := range options.FreeDimensionOverridesByDenotation {
+		denotationBytes := append([]byte(denotation), 0)
+		status := r.apiFuncs.AddFreeDimensionOverride(optsPtr, &denotationBytes[0], size)
+		if err := r.statusError(status, "AddFreeDimensionOverride"); err != nil {
+			return fmt.Errorf("failed to add free dimension override for denotation %q: %w", denotation, err)
+		}
+	}
+
+	if err := r.configureExternalInitializers(optsPtr, options); err != nil {
+		return err
+	}
+
+	for _, domain := range options.CustomOpDomains {
+		if domain == nil || domain.ptr == 0 {
+			return fmt.Errorf("custom op domain is nil or closed")
+		}
+		status := r.apiFuncs.AddCustomOpDomain(optsPtr, domain.ptr)
+		if err := r.statusError(status, "AddCustomOpDomain"); err != nil {
+			return fmt.Errorf("failed to add custom op domain: %w", err)
+		}
+	}
+
 	if options.DeterministicCompute != nil {
 		val := int32(0)
 		if *options.DeterministicCompute {
 			val = 1
 		}
 		status := r.apiFuncs.SetDeterministicCompute(optsPtr, val)
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "SetDeterministicCompute"); err != nil {
 			return fmt.Errorf("failed to set deterministic compute: %w", err)
 		}
 	}
 
+	for k, v := range options.impliedConfigEntries() {
+		if _, ok := options.ConfigEntries[k]; ok {
+			continue
+		}
+		keyBytes := append([]byte(k), 0)
+		valBytes := append([]byte(v), 0)
+		status := r.apiFuncs.AddSessionConfigEntry(optsPtr, &keyBytes[0], &valBytes[0])
+		if err := r.statusError(status, "AddSessionConfigEntry"); err != nil {
+			return fmt.Errorf("failed to add session config entry %q: %w", k, err)
+		}
+	}
+
 	for k, v := range options.ConfigEntries {
 		keyBytes := append([]byte(k), 0)
 		valBytes := append([]byte(v), 0)
 		status := r.apiFuncs.AddSessionConfigEntry(optsPtr, &keyBytes[0], &valBytes[0])
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "AddSessionConfigEntry"); err != nil {
 			return fmt.Errorf("failed to add session config entry %q: %w", k, err)
 		}
 	}
@@ -640,7 +1192,7 @@ func (r *Runtime) configureSessionOptions(optsPtr api.OrtSessionOptions, options
 	if options.ProfilingOutputPath != "" {
 		pathBytes := append([]byte(options.ProfilingOutputPath), 0)
 		status := r.apiFuncs.EnableProfiling(optsPtr, &pathBytes[0])
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "EnableProfiling"); err != nil {
 			return fmt.Errorf("failed to enable profiling: %w", err)
 		}
 	}
@@ -648,33 +1200,51 @@ func (r *Runtime) configureSessionOptions(optsPtr api.OrtSessionOptions, options
 	if options.OptimizedModelFilePath != "" {
 		pathBytes := append([]byte(options.OptimizedModelFilePath), 0)
 		status := r.apiFuncs.SetOptimizedModelFilePath(optsPtr, &pathBytes[0])
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "SetOptimizedModelFilePath"); err != nil {
 			return fmt.Errorf("failed to set optimized model file path: %w", err)
 		}
 	}
 
 	if options.DisablePerSessionThreads {
 		status := r.apiFuncs.DisablePerSessionThreads(optsPtr)
-		if err := r.statusError(status); err != nil {
+		if err := r.statusError(status, "DisablePerSessionThreads"); err != nil {
 			return fmt.Errorf("failed to disable per-session threads: %w", err)
 		}
 	}
 
-	if err := r.configureExecutionProviders(optsPtr, options); err != nil {
-		return err
-	}
-
 	return nil
 }
 
-// configureExecutionProviders configures execution providers for the session options.
-func (r *Runtime) configureExecutionProviders(optsPtr api.OrtSessionOptions, options *SessionOptions) error {
+// configureExecutionProviders configures execution providers for the session
+// options. The returned function releases provider resources and must be
+// called after the session is created.
+func (r *Runtime) configureExecutionProviders(optsPtr api.OrtSessionOptions, options *SessionOptions) (func(), error) {
+	var releases []func()
+	release := func() {
+		for _, fn := range releases {
+			fn()
+		}
+	}
 	if len(options.ExecutionProviders) == 0 {
-		return nil
+		return release, nil
+	}
+
+	if err := r.validateExecutionProviders(options.ExecutionProviders); err != nil {
+		return nil, err
 	}
 
 	for _, provider := range options.ExecutionProviders {
-		providerNameBytes := append([]byte(provider.Name), 0)
+		if provider.Arena != nil {
+			releaseCUDA, err := r.appendCUDAProvider(optsPtr, provider)
+			if err != nil {
+				release()
+				return nil, err
+			}
+			releases = append(releases, releaseCUDA)
+			continue
+		}
+
+		providerNameBytes := append([]byte(appendProviderName(provider.Name)), 0)
 
 		var keyPtrs **byte
 		var valuePtrs **byte
@@ -700,12 +1270,13 @@ func (r *Runtime) configureExecutionProviders(optsPtr api.OrtSessionOptions, opt
 			valuePtrs,
 			numOpts,
 		)
-		if err := r.statusError(status); err != nil {
-			return fmt.Errorf("failed to append execution provider %q: %w", provider.Name, err)
+		if err := r.statusError(status, "SessionOptionsAppendExecutionProvider"); err != nil {
+			release()
+			return nil, fmt.Errorf("failed to append execution provider %q: %w", provider.Name, err)
 		}
 	}
 
-	return nil
+	return release, nil
 }
 
 // cachedCStr returns a pointer to a cached null-terminated C string for the given name,
@@ -720,10 +1291,64 @@ func (s *Session) cachedCStr(name string, names []string, cstrs [][]byte) *byte
 }
 
 // Close releases the session and associated resources.
-// It is safe to call Close multiple times.
+// It waits for pending RunAsync calls to complete and is safe to call
+// multiple times.
 func (s *Session) Close() {
+	s.inflight.Wait()
+	if s.poolBinding != nil {
+		s.poolBinding.Close()
+		s.poolBinding = nil
+	}
 	if s.ptr != 0 && s.runtime != nil && s.runtime.apiFuncs != nil {
+		s.cleanup.Stop()
 		s.runtime.apiFuncs.ReleaseSession(s.ptr)
 		s.ptr = 0
+		s.runtime.objects.remove(kindSession, s.trackID)
+		s.retained.release()
+		s.loraRegistry = nil
+	}
+}
+
+// sessionRetained holds the buffers and objects a native session references,
+// which must stay reachable until it is released. It is allocated apart from
+// the Session so the safety net can keep it without keeping the session.
+type sessionRetained struct {
+	// model buffer referenced by the session when UseORTModelBytesDirectly is set
+	modelData []byte
+
+	// releases modelData once the session is released, such as unmapping a
+	// memory-mapped model file; nil if there is nothing to release
+	releaseModelData func()
+
+	// custom op domains used by the session
+	customOpDomains []*CustomOpDomain
+
+	// container holding the session's shared pre-packed weights
+	prepackedWeights *PrepackedWeightsContainer
+
+	// external initializer values and file buffers
+	externalInitializers     map[string]*Value
+	externalInitializerFiles map[string][]byte
+
+	// binding used by SessionPool.RunBound, released before a leaked session
+	poolBinding api.OrtIoBinding
+}
+
+// release drops the references once the native session is released.
+func (r *sessionRetained) release() {
+	if r == nil {
+		return
+	}
+	if r.releaseModelData != nil {
+		r.releaseModelData()
+	}
+	*r = sessionRetained{}
+}
+
+func releaseLeakedSession(funcs api.APIFuncs, ptr uintptr, keep any) {
+	if binding := keep.(*sessionRetained).poolBinding; binding != 0 {
+		funcs.ReleaseIoBinding(binding)
 	}
+	funcs.ReleaseSession(api.OrtSession(ptr))
+	keep.(*sessionRetained).release()
 }
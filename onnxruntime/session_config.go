@@ -0,0 +1,74 @@
+package onnxruntime
+
+import "strconv"
+
+// Session config keys for common settings, set through typed SessionOptions
+// fields. See onnxruntime_session_options_config_keys.h.
+const (
+	// ConfigKeyDisablePrepacking disables weight pre-packing ("1" to disable).
+	ConfigKeyDisablePrepacking = "session.disable_prepacking"
+
+	// ConfigKeyUseDeviceAllocatorForInitializers allocates initializers
+	// outside the arena ("1" to enable).
+	ConfigKeyUseDeviceAllocatorForInitializers = "session.use_device_allocator_for_initializers"
+
+	// ConfigKeyIntraOpAllowSpinning lets intra-op threads spin ("0" or "1").
+	ConfigKeyIntraOpAllowSpinning = "session.intra_op.allow_spinning"
+
+	// ConfigKeyInterOpAllowSpinning lets inter-op threads spin ("0" or "1").
+	ConfigKeyInterOpAllowSpinning = "session.inter_op.allow_spinning"
+
+	// ConfigKeyDisableQuantQDQ disables QDQ fusion ("1" to disable).
+	ConfigKeyDisableQuantQDQ = "session.disable_quant_qdq"
+
+	// ConfigKeyEnableQuantQDQCleanup removes leftover QDQ pairs ("1" to enable).
+	ConfigKeyEnableQuantQDQCleanup = "session.enable_quant_qdq_cleanup"
+
+	// ConfigKeyDisableCPUEPFallback forbids falling back to the CPU provider
+	// for unsupported nodes ("1" to disable fallback).
+	ConfigKeyDisableCPUEPFallback = "session.disable_cpu_ep_fallback"
+
+	// ConfigKeyDynamicBlockBase sets the intra-op work split block base
+	// (a positive integer).
+	ConfigKeyDynamicBlockBase = "session.dynamic_block_base"
+)
+
+// impliedConfigEntries returns the session config entries implied by typed
+// options. Explicit ConfigEntries take precedence.
+func (o *SessionOptions) impliedConfigEntries() map[string]string {
+	entries := o.modelFormatConfigEntries()
+	flags := []struct {
+		set bool
+		key string
+	}{
+		{o.UseEnvAllocators, ConfigKeyUseEnvAllocators},
+		{o.DisablePrepacking, ConfigKeyDisablePrepacking},
+		{o.UseDeviceAllocatorForInitializers, ConfigKeyUseDeviceAllocatorForInitializers},
+		{o.DisableQuantQDQ, ConfigKeyDisableQuantQDQ},
+		{o.EnableQuantQDQCleanup, ConfigKeyEnableQuantQDQCleanup},
+		{o.DisableCPUEPFallback, ConfigKeyDisableCPUEPFallback},
+	}
+	for _, f := range flags {
+		if f.set {
+			entries[f.key] = "1"
+		}
+	}
+	if o.IntraOpSpinning != nil {
+		entries[ConfigKeyIntraOpAllowSpinning] = boolConfigValue(*o.IntraOpSpinning)
+	}
+	if o.InterOpSpinning != nil {
+		entries[ConfigKeyInterOpAllowSpinning] = boolConfigValue(*o.InterOpSpinning)
+	}
+	if o.DynamicBlockBase > 0 {
+		entries[ConfigKeyDynamicBlockBase] = strconv.Itoa(o.DynamicBlockBase)
+	}
+	return entries
+}
+
+// boolConfigValue formats a boolean session config value.
+func boolConfigValue(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
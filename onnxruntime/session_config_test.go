@@ -0,0 +1,48 @@
+package onnxruntime
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestSessionConfigShortcuts(t *testing.T) {
+	disabled := false
+	opts := &SessionOptions{
+		DisablePrepacking:                 true,
+		UseDeviceAllocatorForInitializers: true,
+		IntraOpSpinning:                   &disabled,
+		DisableQuantQDQ:                   true,
+		EnableQuantQDQCleanup:             true,
+		DisableCPUEPFallback:              true,
+		DynamicBlockBase:                  4,
+	}
+	want := map[string]string{
+		ConfigKeyDisablePrepacking:                 "1",
+		ConfigKeyUseDeviceAllocatorForInitializers: "1",
+		ConfigKeyIntraOpAllowSpinning:              "0",
+		ConfigKeyDisableQuantQDQ:                   "1",
+		ConfigKeyEnableQuantQDQCleanup:             "1",
+		ConfigKeyDisableCPUEPFallback:              "1",
+		ConfigKeyDynamicBlockBase:                  "4",
+	}
+	if got := opts.impliedConfigEntries(); !maps.Equal(got, want) {
+		t.Errorf("impliedConfigEntries() = %v, want %v", got, want)
+	}
+}
+
+func TestSessionOptionsConfigShortcuts(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	disabled := false
+	session := newSessionWithOptions(t, runtime, &SessionOptions{
+		DisablePrepacking: true,
+		IntraOpSpinning:   &disabled,
+		InterOpSpinning:   &disabled,
+		DynamicBlockBase:  4,
+		ConfigEntries: map[string]string{
+			// Explicit entries win over the typed fields.
+			ConfigKeyDynamicBlockBase: "2",
+		},
+	})
+	runInference(t, runtime, session)
+}
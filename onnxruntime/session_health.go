@@ -0,0 +1,43 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned by a run that panicked, such as when the purego
+// call into ONNX Runtime panics on arguments it cannot handle. The panic is
+// recovered so that it does not take the process down, but the session may
+// be left in an inconsistent state, so it is marked unhealthy.
+type PanicError struct {
+	// SessionID identifies the session that panicked.
+	SessionID uint64
+
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the goroutine stack at the time of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("run panicked in session %d: %v", e.SessionID, e.Value)
+}
+
+// Healthy reports whether the session can be trusted with further runs. It
+// is false once a run has panicked; such a session should be closed and
+// replaced, as SessionPool does.
+func (s *Session) Healthy() bool {
+	return !s.unhealthy.Load()
+}
+
+// recoverRun recovers a panic during a run of s, setting *err to a
+// *PanicError and marking s unhealthy. It must be deferred directly.
+func (s *Session) recoverRun(err *error) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	s.unhealthy.Store(true)
+	*err = &PanicError{SessionID: s.id, Value: p, Stack: debug.Stack()}
+}
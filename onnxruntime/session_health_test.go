@@ -0,0 +1,101 @@
+package onnxruntime
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSessionRecoverRun(t *testing.T) {
+	s := &Session{id: 7}
+	if !s.Healthy() {
+		t.Fatal("Expected a new session to be healthy")
+	}
+
+	err := func() (err error) {
+		defer s.recoverRun(&err)
+		panic("boom")
+	}()
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected *PanicError, got %v", err)
+	}
+	if panicErr.SessionID != 7 || panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Errorf("Unexpected panic error %+v", panicErr)
+	}
+	if s.Healthy() {
+		t.Error("Expected the session to be unhealthy after a panic")
+	}
+}
+
+func TestSessionRunPanic(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+	session.faults = NewFaultInjector(FaultConfig{PanicRate: 1})
+
+	tensor, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	_, err = session.Run(t.Context(), map[string]*Value{"input": tensor})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected *PanicError, got %v", err)
+	}
+	if session.Healthy() {
+		t.Error("Expected the session to be unhealthy after a panic")
+	}
+}
+
+func TestSessionPoolReplacesUnhealthySession(t *testing.T) {
+	runtime := newTestRuntime(t)
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	defer env.Close()
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	faults := NewFaultInjector(FaultConfig{PanicRate: 1})
+	pool, err := NewSessionPool(runtime, env, modelData, 1, &PoolConfig{
+		SessionOptions: &SessionOptions{FaultInjector: faults},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	tensor, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	var panicErr *PanicError
+	if _, err := pool.Run(t.Context(), map[string]*Value{"input": tensor}); !errors.As(err, &panicErr) {
+		t.Fatalf("Expected *PanicError, got %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.Stats().ReplacedSessions != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the session to be replaced")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	faults.SetConfig(FaultConfig{})
+	outputs, err := pool.Run(t.Context(), map[string]*Value{"input": tensor})
+	if err != nil {
+		t.Fatalf("Run() after replacement error = %v", err)
+	}
+	closeValues(outputs)
+}
@@ -0,0 +1,33 @@
+package onnxruntime
+
+import (
+	"context"
+	"time"
+)
+
+// beforeRun calls the BeforeRun hooks of s for a run with inputs, named
+// inputNames, and returns the function that calls the AfterRun hooks with
+// the names of the run's outputs and its error.
+func (s *Session) beforeRun(ctx context.Context, inputs map[string]*Value, inputNames []string, tag string) func(outputNames []string, err error) {
+	info := &RunInfo{
+		Context:    ctx,
+		ModelName:  s.modelName,
+		Inputs:     inputs,
+		InputNames: inputNames,
+		BatchSize:  1,
+		RunTag:     tag,
+	}
+	for _, h := range s.hooks {
+		h.BeforeRun(info)
+	}
+
+	start := time.Now()
+	return func(outputNames []string, err error) {
+		info.Duration = time.Since(start)
+		info.Error = err
+		info.OutputNames = outputNames
+		for _, h := range s.hooks {
+			h.AfterRun(info)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package onnxruntime
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// recordingHook records the RunInfo of every hook call.
+type recordingHook struct {
+	before atomic.Int32
+	after  chan *RunInfo
+}
+
+func (h *recordingHook) BeforeRun(*RunInfo)     { h.before.Add(1) }
+func (h *recordingHook) AfterRun(info *RunInfo) { h.after <- info }
+
+func newHookedTestSession(t *testing.T, runtime *Runtime, hook Hook) *Session {
+	t.Helper()
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	session, err := runtime.NewSession(env, testModelPath(), &SessionOptions{Hooks: []Hook{hook}})
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func TestSessionHooks(t *testing.T) {
+	runtime := newTestRuntime(t)
+	hook := &recordingHook{after: make(chan *RunInfo, 1)}
+	session := newHookedTestSession(t, runtime, hook)
+
+	input, err := NewTensorValue(runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	outputs, err := session.Run(t.Context(), map[string]*Value{"input": input}, WithRunTag("tagged"))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	closeValues(outputs)
+
+	info := <-hook.after
+	if hook.before.Load() != 1 {
+		t.Errorf("Expected 1 BeforeRun call, got %d", hook.before.Load())
+	}
+	if !slices.Equal(info.InputNames, []string{"input"}) || !slices.Equal(info.OutputNames, []string{"logits"}) {
+		t.Errorf("Unexpected names: inputs %v, outputs %v", info.InputNames, info.OutputNames)
+	}
+	if info.RunTag != "tagged" || info.Duration <= 0 || info.Error != nil {
+		t.Errorf("Unexpected run info %+v", info)
+	}
+
+	// RunIndexed reports the names of the indices
+	indexed, err := session.RunIndexed(t.Context(), nil, []*Value{input}, []int{0})
+	if err != nil {
+		t.Fatalf("RunIndexed() error = %v", err)
+	}
+	indexed[0].Close()
+	info = <-hook.after
+	if !slices.Equal(info.InputNames, []string{"input"}) || !slices.Equal(info.OutputNames, []string{"logits"}) {
+		t.Errorf("Unexpected indexed names: inputs %v, outputs %v", info.InputNames, info.OutputNames)
+	}
+}
+
+func TestSessionHooksError(t *testing.T) {
+	runtime := newTestRuntime(t)
+	hook := &recordingHook{after: make(chan *RunInfo, 1)}
+	session := newHookedTestSession(t, runtime, hook)
+
+	wrong, err := NewTensorValue(runtime, make([]float32, 5), []int64{1, 5})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer wrong.Close()
+
+	if _, err := session.Run(t.Context(), map[string]*Value{"input": wrong}); err == nil {
+		t.Fatal("Expected error for a wrong input shape")
+	}
+	if info := <-hook.after; info.Error == nil {
+		t.Error("Expected the hook to see the run's error")
+	}
+}
+
+func TestIndexedNames(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	if got := indexedNames(names, nil); !slices.Equal(got, names) {
+		t.Errorf("indexedNames(nil) = %v, want all names", got)
+	}
+	if got := indexedNames(names, []int{2, 0, 5}); !slices.Equal(got, []string{"c", "a"}) {
+		t.Errorf("indexedNames() = %v, want [c a]", got)
+	}
+}
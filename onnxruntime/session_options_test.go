@@ -2,7 +2,9 @@ package onnxruntime
 
 import (
 	"bytes"
+	"errors"
 	"os"
+	"slices"
 	"testing"
 )
 
@@ -157,6 +159,19 @@ func TestSessionOptionsFreeDimensionOverrides(t *testing.T) {
 	runInference(t, runtime, session)
 }
 
+func TestSessionOptionsFreeDimensionOverridesByDenotation(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	// The test model has no denotations, so the override leaves the batch
+	// dimension dynamic.
+	session := newSessionWithOptions(t, runtime, &SessionOptions{
+		FreeDimensionOverridesByDenotation: map[string]int64{
+			DimensionDenotationBatch: 1,
+		},
+	})
+	runInference(t, runtime, session)
+}
+
 func TestWithRunTag(t *testing.T) {
 	runtime := newTestRuntime(t)
 	session := newTestSession(t, runtime)
@@ -218,3 +233,49 @@ func runInference(t *testing.T, runtime *Runtime, session *Session) {
 		v.Close()
 	}
 }
+
+func TestSessionOptionsUnavailableProvider(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+
+	_, err = runtime.NewSession(env, testModelPath(), &SessionOptions{
+		ExecutionProviders: []ExecutionProvider{{Name: "NonExistentProvider"}},
+	})
+	if !errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("Expected ErrProviderUnavailable, got %v", err)
+	}
+
+	var unavailable *ProviderUnavailableError
+	if !errors.As(err, &unavailable) || !slices.Contains(unavailable.Available, "CPUExecutionProvider") {
+		t.Errorf("Expected available providers to include CPUExecutionProvider, got %v", err)
+	}
+}
+
+func TestSessionOptionsProviderFallbackChain(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	session := newSessionWithOptions(t, runtime, &SessionOptions{
+		ProviderFallbackChain: []ExecutionProvider{
+			{Name: "NonExistentProvider"},
+			{Name: "CPUExecutionProvider"},
+		},
+	})
+	if got := session.ActiveProvider(); got != "CPUExecutionProvider" {
+		t.Errorf("Expected CPUExecutionProvider, got %s", got)
+	}
+	runInference(t, runtime, session)
+}
+
+func TestSessionActiveProviderDefault(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	session := newSessionWithOptions(t, runtime, nil)
+	if got := session.ActiveProvider(); got != "CPUExecutionProvider" {
+		t.Errorf("Expected CPUExecutionProvider, got %s", got)
+	}
+}
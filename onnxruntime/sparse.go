@@ -0,0 +1,333 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// SparseFormat represents the storage format of a sparse tensor.
+type SparseFormat = api.OrtSparseFormat
+
+// Sparse tensor storage formats.
+const (
+	// SparseFormatUndefined indicates a sparse tensor with no indices set.
+	SparseFormatUndefined SparseFormat = 0
+	// SparseFormatCOO indicates coordinate (COO) format.
+	SparseFormatCOO SparseFormat = 1
+	// SparseFormatCSR indicates compressed sparse row (CSR) format.
+	SparseFormatCSR SparseFormat = 2
+	// SparseFormatBlockSparse indicates block sparse format.
+	SparseFormatBlockSparse SparseFormat = 4
+)
+
+// SparseIndicesFormat selects which indices of a sparse tensor to read.
+type SparseIndicesFormat = api.OrtSparseIndicesFormat
+
+// Sparse tensor indices.
+const (
+	// SparseIndicesCOO selects the indices of a COO tensor.
+	SparseIndicesCOO SparseIndicesFormat = 0
+	// SparseIndicesCSRInner selects the column indices of a CSR tensor.
+	SparseIndicesCSRInner SparseIndicesFormat = 1
+	// SparseIndicesCSROuter selects the row offsets of a CSR tensor.
+	SparseIndicesCSROuter SparseIndicesFormat = 2
+	// SparseIndicesBlockSparse selects the indices of a block sparse tensor.
+	SparseIndicesBlockSparse SparseIndicesFormat = 3
+)
+
+// NewSparseCOOTensor creates a sparse tensor in coordinate (COO) format.
+// values holds the non-zero elements and denseShape the shape of the
+// equivalent dense tensor. indices locates each value either as a linear
+// index into the dense tensor (len(values) entries) or as one coordinate per
+// dimension (len(values) * len(denseShape) entries, row-major).
+//
+// The tensor wraps values and indices without copying them and keeps them
+// reachable until Close. They must not be modified while the tensor is in use.
+func NewSparseCOOTensor[T TensorData](r *Runtime, values []T, indices []int64, denseShape []int64) (*Value, error) {
+	if err := checkDenseShape(denseShape); err != nil {
+		return nil, err
+	}
+	if err := checkCOOIndices(len(values), indices, denseShape); err != nil {
+		return nil, err
+	}
+
+	v, err := newSparseTensor(r, values, denseShape)
+	if err != nil {
+		return nil, err
+	}
+
+	status := r.apiFuncs.UseCooIndices(v.ptr, sliceData(indices), uintptr(len(indices)))
+	if err := r.statusError(status, "UseCooIndices"); err != nil {
+		v.Close()
+		return nil, fmt.Errorf("failed to set COO indices: %w", err)
+	}
+	v.goData = []any{values, indices}
+	return v, nil
+}
+
+// NewSparseCSRTensor creates a 2-D sparse tensor in compressed sparse row
+// (CSR) format. innerIndices holds the column of each value and outerIndices
+// holds denseShape[0]+1 offsets into values marking where each row starts.
+//
+// The tensor wraps values and indices without copying them and keeps them
+// reachable until Close. They must not be modified while the tensor is in use.
+func NewSparseCSRTensor[T TensorData](r *Runtime, values []T, innerIndices, outerIndices []int64, denseShape []int64) (*Value, error) {
+	if len(denseShape) != 2 {
+		return nil, fmt.Errorf("CSR format requires a 2-D dense shape, got %v", denseShape)
+	}
+	if err := checkDenseShape(denseShape); err != nil {
+		return nil, err
+	}
+	if err := checkCSRIndices(len(values), innerIndices, outerIndices, denseShape); err != nil {
+		return nil, err
+	}
+
+	v, err := newSparseTensor(r, values, denseShape)
+	if err != nil {
+		return nil, err
+	}
+
+	status := r.apiFuncs.UseCsrIndices(v.ptr,
+		sliceData(innerIndices), uintptr(len(innerIndices)),
+		sliceData(outerIndices), uintptr(len(outerIndices)))
+	if err := r.statusError(status, "UseCsrIndices"); err != nil {
+		v.Close()
+		return nil, fmt.Errorf("failed to set CSR indices: %w", err)
+	}
+	v.goData = []any{values, innerIndices, outerIndices}
+	return v, nil
+}
+
+// newSparseTensor creates a sparse tensor over values with no indices set.
+// The caller must keep values reachable through goData.
+func newSparseTensor[T TensorData](r *Runtime, values []T, denseShape []int64) (*Value, error) {
+	dataType := tensorDataType[T]()
+	if dataType == ONNXTensorElementDataTypeUndefined {
+		return nil, fmt.Errorf("unsupported data type")
+	}
+	if r.cpuMemoryInfo == nil {
+		return nil, fmt.Errorf("default memory info not initialized")
+	}
+
+	// ORT requires a non-null values pointer even when there are no values.
+	var placeholder uint64
+	data := unsafe.Pointer(&placeholder)
+	if len(values) > 0 {
+		data = unsafe.Pointer(&values[0])
+	}
+	valuesShape := []int64{int64(len(values))}
+
+	var valuePtr api.OrtValue
+	status := r.apiFuncs.CreateSparseTensorWithValuesAsOrtValue(r.cpuMemoryInfo.ptr, data,
+		sliceData(denseShape), uintptr(len(denseShape)),
+		&valuesShape[0], uintptr(len(valuesShape)),
+		dataType, &valuePtr)
+	if err := r.statusError(status, "CreateSparseTensorWithValuesAsOrtValue"); err != nil {
+		return nil, fmt.Errorf("failed to create sparse tensor: %w", err)
+	}
+	return r.newValueFromPtr(valuePtr), nil
+}
+
+func checkDenseShape(shape []int64) error {
+	if len(shape) == 0 {
+		return fmt.Errorf("sparse tensor requires a non-scalar dense shape")
+	}
+	for _, d := range shape {
+		if d < 0 {
+			return fmt.Errorf("invalid dimension %d in dense shape %v", d, shape)
+		}
+	}
+	return nil
+}
+
+func checkCOOIndices(nnz int, indices []int64, denseShape []int64) error {
+	rank := len(denseShape)
+	switch len(indices) {
+	case nnz:
+		total := int64(1)
+		for _, d := range denseShape {
+			total *= d
+		}
+		for i, idx := range indices {
+			if idx < 0 || idx >= total {
+				return fmt.Errorf("COO index %d at position %d is out of range for dense shape %v", idx, i, denseShape)
+			}
+		}
+	case nnz * rank:
+		for i, idx := range indices {
+			if d := denseShape[i%rank]; idx < 0 || idx >= d {
+				return fmt.Errorf("COO coordinate %d at position %d is out of range for dense shape %v", idx, i, denseShape)
+			}
+		}
+	default:
+		return fmt.Errorf("COO indices have %d entries, expected %d or %d for %d values", len(indices), nnz, nnz*rank, nnz)
+	}
+	return nil
+}
+
+func checkCSRIndices(nnz int, inner, outer []int64, denseShape []int64) error {
+	rows, cols := denseShape[0], denseShape[1]
+	if len(inner) != nnz {
+		return fmt.Errorf("CSR inner indices have %d entries, expected %d", len(inner), nnz)
+	}
+	if int64(len(outer)) != rows+1 {
+		return fmt.Errorf("CSR outer indices have %d entries, expected %d", len(outer), rows+1)
+	}
+	if outer[0] != 0 || outer[rows] != int64(nnz) {
+		return fmt.Errorf("CSR outer indices must start at 0 and end at %d", nnz)
+	}
+	for i := 1; i < len(outer); i++ {
+		if outer[i] < outer[i-1] {
+			return fmt.Errorf("CSR outer indices must be non-decreasing")
+		}
+	}
+	for i, col := range inner {
+		if col < 0 || col >= cols {
+			return fmt.Errorf("CSR column index %d at position %d is out of range for %d columns", col, i, cols)
+		}
+	}
+	return nil
+}
+
+// sliceData returns a pointer to the first element of s, or nil if s is empty.
+func sliceData(s []int64) *int64 {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s[0]
+}
+
+// IsSparseTensor reports whether the value is a sparse tensor.
+func (v *Value) IsSparseTensor() (bool, error) {
+	var result int32
+	status := v.runtime.apiFuncs.IsSparseTensor(v.ptr, &result)
+	if err := v.runtime.statusError(status, "IsSparseTensor"); err != nil {
+		return false, fmt.Errorf("failed to check if value is a sparse tensor: %w", err)
+	}
+	return result != 0, nil
+}
+
+// GetSparseTensorFormat returns the storage format of a sparse tensor.
+// The dense shape is available through [Value.GetTensorShape].
+func (v *Value) GetSparseTensorFormat() (SparseFormat, error) {
+	var format SparseFormat
+	status := v.runtime.apiFuncs.GetSparseTensorFormat(v.ptr, &format)
+	if err := v.runtime.statusError(status, "GetSparseTensorFormat"); err != nil {
+		return SparseFormatUndefined, fmt.Errorf("failed to get sparse tensor format: %w", err)
+	}
+	return format, nil
+}
+
+// GetSparseTensorValuesShape returns the shape of a sparse tensor's values.
+// For COO and CSR tensors this is [number of non-zero values].
+func (v *Value) GetSparseTensorValuesShape() ([]int64, error) {
+	shape, _, err := v.sparseValuesInfo()
+	return shape, err
+}
+
+// GetSparseTensorValues returns a copy of the non-zero values of a sparse tensor.
+func GetSparseTensorValues[T TensorData](v *Value) ([]T, error) {
+	shape, elemType, err := v.sparseValuesInfo()
+	if err != nil {
+		return nil, err
+	}
+	if expected := tensorDataType[T](); elemType != expected {
+		return nil, fmt.Errorf("element type mismatch: expected %d, got %d", expected, elemType)
+	}
+
+	count := shapeElementCount(shape)
+	result := make([]T, count)
+	if count == 0 {
+		return result, nil
+	}
+
+	var dataPtr unsafe.Pointer
+	status := v.runtime.apiFuncs.GetSparseTensorValues(v.ptr, &dataPtr)
+	if err := v.runtime.statusError(status, "GetSparseTensorValues"); err != nil {
+		return nil, fmt.Errorf("failed to get sparse tensor values: %w", err)
+	}
+	copy(result, unsafe.Slice((*T)(dataPtr), count))
+	return result, nil
+}
+
+// GetSparseTensorIndices returns a copy of the requested indices of a sparse
+// tensor. Block sparse indices, which ONNX Runtime stores as int32, are
+// widened to int64.
+func (v *Value) GetSparseTensorIndices(format SparseIndicesFormat) ([]int64, error) {
+	var infoPtr api.OrtTensorTypeAndShapeInfo
+	status := v.runtime.apiFuncs.GetSparseTensorIndicesTypeShape(v.ptr, format, &infoPtr)
+	if err := v.runtime.statusError(status, "GetSparseTensorIndicesTypeShape"); err != nil {
+		return nil, fmt.Errorf("failed to get sparse tensor indices type: %w", err)
+	}
+	_, elemType, err := v.runtime.readTypeAndShape(infoPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	var num uintptr
+	var dataPtr unsafe.Pointer
+	status = v.runtime.apiFuncs.GetSparseTensorIndices(v.ptr, format, &num, &dataPtr)
+	if err := v.runtime.statusError(status, "GetSparseTensorIndices"); err != nil {
+		return nil, fmt.Errorf("failed to get sparse tensor indices: %w", err)
+	}
+
+	result := make([]int64, num)
+	if num == 0 {
+		return result, nil
+	}
+	switch elemType {
+	case ONNXTensorElementDataTypeInt64:
+		copy(result, unsafe.Slice((*int64)(dataPtr), num))
+	case ONNXTensorElementDataTypeInt32:
+		for i, idx := range unsafe.Slice((*int32)(dataPtr), num) {
+			result[i] = int64(idx)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sparse indices type %d", elemType)
+	}
+	return result, nil
+}
+
+func (v *Value) sparseValuesInfo() ([]int64, ONNXTensorElementDataType, error) {
+	var infoPtr api.OrtTensorTypeAndShapeInfo
+	status := v.runtime.apiFuncs.GetSparseTensorValuesTypeAndShape(v.ptr, &infoPtr)
+	if err := v.runtime.statusError(status, "GetSparseTensorValuesTypeAndShape"); err != nil {
+		return nil, ONNXTensorElementDataTypeUndefined, fmt.Errorf("failed to get sparse tensor values type and shape: %w", err)
+	}
+	return v.runtime.readTypeAndShape(infoPtr)
+}
+
+// readTypeAndShape reads the shape and element type from info and releases it.
+func (r *Runtime) readTypeAndShape(info api.OrtTensorTypeAndShapeInfo) ([]int64, ONNXTensorElementDataType, error) {
+	defer r.apiFuncs.ReleaseTensorTypeAndShapeInfo(info)
+
+	var elemType ONNXTensorElementDataType
+	status := r.apiFuncs.GetTensorElementType(info, &elemType)
+	if err := r.statusError(status, "GetTensorElementType"); err != nil {
+		return nil, ONNXTensorElementDataTypeUndefined, fmt.Errorf("failed to get element type: %w", err)
+	}
+
+	var dimCount uintptr
+	status = r.apiFuncs.GetDimensionsCount(info, &dimCount)
+	if err := r.statusError(status, "GetDimensionsCount"); err != nil {
+		return nil, ONNXTensorElementDataTypeUndefined, fmt.Errorf("failed to get dimensions count: %w", err)
+	}
+	dims := make([]int64, dimCount)
+	if dimCount > 0 {
+		status = r.apiFuncs.GetDimensions(info, &dims[0], dimCount)
+		if err := r.statusError(status, "GetDimensions"); err != nil {
+			return nil, ONNXTensorElementDataTypeUndefined, fmt.Errorf("failed to get dimensions: %w", err)
+		}
+	}
+	return dims, elemType, nil
+}
+
+func shapeElementCount(shape []int64) int {
+	count := 1
+	for _, d := range shape {
+		count *= int(d)
+	}
+	return count
+}
@@ -0,0 +1,184 @@
+package onnxruntime
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewSparseCOOTensorValidation(t *testing.T) {
+	values := []float32{1, 2}
+	tests := []struct {
+		name       string
+		indices    []int64
+		denseShape []int64
+	}{
+		{"scalar shape", []int64{0, 1}, nil},
+		{"negative dim", []int64{0, 1}, []int64{-1, 2}},
+		{"wrong count", []int64{0, 1, 2}, []int64{2, 2}},
+		{"linear out of range", []int64{0, 4}, []int64{2, 2}},
+		{"coordinate out of range", []int64{0, 0, 1, 2}, []int64{2, 2}},
+		{"negative index", []int64{-1, 0}, []int64{2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSparseCOOTensor(nil, values, tt.indices, tt.denseShape); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestNewSparseCSRTensorValidation(t *testing.T) {
+	values := []float32{1, 2}
+	tests := []struct {
+		name       string
+		inner      []int64
+		outer      []int64
+		denseShape []int64
+	}{
+		{"not 2-D", []int64{0, 1}, []int64{0, 2}, []int64{4}},
+		{"inner count", []int64{0}, []int64{0, 1, 2}, []int64{2, 2}},
+		{"outer count", []int64{0, 1}, []int64{0, 2}, []int64{2, 2}},
+		{"outer end", []int64{0, 1}, []int64{0, 1, 1}, []int64{2, 2}},
+		{"outer decreasing", []int64{0, 1}, []int64{0, 3, 2}, []int64{2, 2}},
+		{"column out of range", []int64{0, 2}, []int64{0, 1, 2}, []int64{2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSparseCSRTensor(nil, values, tt.inner, tt.outer, tt.denseShape); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestSparseCOOTensor(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	// 3x4 matrix with non-zeros at (0,1), (1,3) and (2,0).
+	values := []float32{1.5, 2.5, 3.5}
+	indices := []int64{1, 7, 8}
+	tensor, err := NewSparseCOOTensor(runtime, values, indices, []int64{3, 4})
+	if err != nil {
+		t.Fatalf("NewSparseCOOTensor failed: %v", err)
+	}
+	defer tensor.Close()
+
+	isSparse, err := tensor.IsSparseTensor()
+	if err != nil {
+		t.Fatalf("IsSparseTensor failed: %v", err)
+	}
+	if !isSparse {
+		t.Error("Expected a sparse tensor")
+	}
+
+	format, err := tensor.GetSparseTensorFormat()
+	if err != nil {
+		t.Fatalf("GetSparseTensorFormat failed: %v", err)
+	}
+	if format != SparseFormatCOO {
+		t.Errorf("Expected COO format, got %d", format)
+	}
+
+	shape, err := tensor.GetTensorShape()
+	if err != nil {
+		t.Fatalf("GetTensorShape failed: %v", err)
+	}
+	if !slices.Equal(shape, []int64{3, 4}) {
+		t.Errorf("Expected dense shape [3 4], got %v", shape)
+	}
+
+	gotValues, err := GetSparseTensorValues[float32](tensor)
+	if err != nil {
+		t.Fatalf("GetSparseTensorValues failed: %v", err)
+	}
+	if !slices.Equal(gotValues, values) {
+		t.Errorf("Expected values %v, got %v", values, gotValues)
+	}
+
+	gotIndices, err := tensor.GetSparseTensorIndices(SparseIndicesCOO)
+	if err != nil {
+		t.Fatalf("GetSparseTensorIndices failed: %v", err)
+	}
+	if !slices.Equal(gotIndices, indices) {
+		t.Errorf("Expected indices %v, got %v", indices, gotIndices)
+	}
+
+	if _, err := GetSparseTensorValues[int64](tensor); err == nil {
+		t.Error("Expected error for mismatched element type")
+	}
+}
+
+func TestSparseCSRTensor(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	// [[0 1 0]
+	//  [0 0 0]
+	//  [2 0 3]]
+	values := []int32{1, 2, 3}
+	inner := []int64{1, 0, 2}
+	outer := []int64{0, 1, 1, 3}
+	tensor, err := NewSparseCSRTensor(runtime, values, inner, outer, []int64{3, 3})
+	if err != nil {
+		t.Fatalf("NewSparseCSRTensor failed: %v", err)
+	}
+	defer tensor.Close()
+
+	format, err := tensor.GetSparseTensorFormat()
+	if err != nil {
+		t.Fatalf("GetSparseTensorFormat failed: %v", err)
+	}
+	if format != SparseFormatCSR {
+		t.Errorf("Expected CSR format, got %d", format)
+	}
+
+	valuesShape, err := tensor.GetSparseTensorValuesShape()
+	if err != nil {
+		t.Fatalf("GetSparseTensorValuesShape failed: %v", err)
+	}
+	if !slices.Equal(valuesShape, []int64{3}) {
+		t.Errorf("Expected values shape [3], got %v", valuesShape)
+	}
+
+	gotValues, err := GetSparseTensorValues[int32](tensor)
+	if err != nil {
+		t.Fatalf("GetSparseTensorValues failed: %v", err)
+	}
+	if !slices.Equal(gotValues, values) {
+		t.Errorf("Expected values %v, got %v", values, gotValues)
+	}
+
+	for _, tc := range []struct {
+		format SparseIndicesFormat
+		want   []int64
+	}{
+		{SparseIndicesCSRInner, inner},
+		{SparseIndicesCSROuter, outer},
+	} {
+		got, err := tensor.GetSparseTensorIndices(tc.format)
+		if err != nil {
+			t.Fatalf("GetSparseTensorIndices(%d) failed: %v", tc.format, err)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Indices %d: expected %v, got %v", tc.format, tc.want, got)
+		}
+	}
+}
+
+func TestDenseTensorIsNotSparse(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	tensor, err := NewTensorValue(runtime, []float32{1, 2}, []int64{2})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	isSparse, err := tensor.IsSparseTensor()
+	if err != nil {
+		t.Fatalf("IsSparseTensor failed: %v", err)
+	}
+	if isSparse {
+		t.Error("Expected a dense tensor")
+	}
+}
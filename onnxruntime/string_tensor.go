@@ -32,7 +32,7 @@ func (r *Runtime) NewStringTensorValue(data []string, shape []int64) (*Value, er
 		ONNXTensorElementDataTypeString,
 		&valuePtr,
 	)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateTensorAsOrtValue"); err != nil {
 		return nil, fmt.Errorf("failed to create string tensor: %w", err)
 	}
 
@@ -47,7 +47,7 @@ func (r *Runtime) NewStringTensorValue(data []string, shape []int64) (*Value, er
 	}
 
 	status = r.apiFuncs.FillStringTensor(valuePtr, &cstrings[0], uintptr(len(data)))
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "FillStringTensor"); err != nil {
 		r.apiFuncs.ReleaseValue(valuePtr)
 		return nil, fmt.Errorf("failed to fill string tensor: %w", err)
 	}
@@ -87,7 +87,7 @@ func GetStringTensorData(v *Value) ([]string, []int64, error) {
 	// Get total data length
 	var totalLen uintptr
 	status := v.runtime.apiFuncs.GetStringTensorDataLength(v.ptr, &totalLen)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetStringTensorDataLength"); err != nil {
 		return nil, nil, fmt.Errorf("failed to get string tensor data length: %w", err)
 	}
 
@@ -101,7 +101,7 @@ func GetStringTensorData(v *Value) ([]string, []int64, error) {
 	}
 
 	status = v.runtime.apiFuncs.GetStringTensorContent(v.ptr, bufPtr, totalLen, &offsets[0], uintptr(count))
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetStringTensorContent"); err != nil {
 		return nil, nil, fmt.Errorf("failed to get string tensor content: %w", err)
 	}
 
@@ -125,7 +125,7 @@ func GetStringTensorData(v *Value) ([]string, []int64, error) {
 func (v *Value) IsTensor() (bool, error) {
 	var out int32
 	status := v.runtime.apiFuncs.IsTensor(v.ptr, &out)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "IsTensor"); err != nil {
 		return false, fmt.Errorf("failed to check if value is tensor: %w", err)
 	}
 	return out != 0, nil
@@ -135,7 +135,7 @@ func (v *Value) IsTensor() (bool, error) {
 func (v *Value) SetStringTensorElement(index int, s string) error {
 	b := append([]byte(s), 0)
 	status := v.runtime.apiFuncs.FillStringTensorElement(v.ptr, &b[0], uintptr(index))
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "FillStringTensorElement"); err != nil {
 		return fmt.Errorf("failed to set string tensor element: %w", err)
 	}
 	return nil
@@ -146,7 +146,7 @@ func (v *Value) GetStringTensorElement(index int) (string, error) {
 	// Get element length
 	var length uintptr
 	status := v.runtime.apiFuncs.GetStringTensorElementLength(v.ptr, uintptr(index), &length)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetStringTensorElementLength"); err != nil {
 		return "", fmt.Errorf("failed to get string tensor element length: %w", err)
 	}
 
@@ -158,7 +158,7 @@ func (v *Value) GetStringTensorElement(index int) (string, error) {
 	}
 
 	status = v.runtime.apiFuncs.GetStringTensorElement(v.ptr, length, uintptr(index), bufPtr)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetStringTensorElement"); err != nil {
 		return "", fmt.Errorf("failed to get string tensor element: %w", err)
 	}
 
@@ -0,0 +1,283 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SwapConfig configures warm standby swaps for a SwappablePool.
+type SwapConfig struct {
+	// WarmupInputs, when set, are run on every session of a new pool before it
+	// receives traffic.
+	WarmupInputs map[string]*Value
+
+	// HealthCheck validates a new pool before it is promoted, for example by
+	// comparing outputs against golden values, and again at the end of
+	// SoakPeriod if set. If it fails the old pool keeps or resumes serving.
+	// When nil and WarmupInputs is set, SessionPool.HealthCheck with
+	// WarmupInputs is used.
+	HealthCheck func(ctx context.Context, pool *SessionPool) error
+
+	// SoakPeriod keeps the previous version open for this long after the new
+	// one starts serving, so that it can be rolled back if the new version
+	// fails under real traffic: if more than MaxSoakErrorRate of its runs
+	// fail during the period, or HealthCheck fails at its end, the previous
+	// version serves again and the new one is closed once its runs finish.
+	// Runs that already failed on the new version are not retried. Swap
+	// returns once the period is over. Zero promotes the new version without
+	// a soak.
+	SoakPeriod time.Duration
+
+	// MaxSoakErrorRate is the fraction of runs, between 0 and 1, that may
+	// fail on the new version during SoakPeriod. Zero disables the check,
+	// leaving only HealthCheck.
+	MaxSoakErrorRate float64
+
+	// MaxOverlapBytes limits the estimated memory of the standby pool, which is
+	// held in addition to the serving pool until the swap completes. The estimate
+	// is the model size per session, or once when PoolConfig.SharePrepackedWeights
+	// is set. Swaps exceeding the budget are rejected before anything is loaded.
+	// Zero means no limit.
+	MaxOverlapBytes int64
+}
+
+// SwappablePool serves traffic from a SessionPool that can be replaced by a
+// new model version without downtime (blue/green deployment).
+//
+// Swap builds the new version's pool while the current one keeps serving,
+// warms and health-checks it, then atomically switches traffic. The old pool
+// is drained and closed once in-flight runs finish. If the standby fails to
+// load, warm up, or pass its health check, the swap is rolled back and the
+// previous version continues serving. With SwapConfig.SoakPeriod, the new
+// version can also be rolled back after it has served for a while.
+//
+// A SwappablePool is safe for concurrent use.
+//
+// Example:
+//
+//	sp, err := onnxruntime.NewSwappablePool(runtime, env, "v1", v1Data, 4, nil, &onnxruntime.SwapConfig{
+//	    WarmupInputs: sampleInputs,
+//	})
+//	// serve with sp.Run(...)
+//
+//	go func() {
+//	    if err := sp.Swap(ctx, "v2", v2Data); err != nil {
+//	        log.Printf("swap to v2 rolled back: %v", err)
+//	    }
+//	}()
+type SwappablePool struct {
+	runtime    *Runtime
+	env        *Env
+	size       int
+	poolConfig *PoolConfig
+	swapConfig SwapConfig
+
+	swapMu sync.Mutex // serializes Swap and Close
+	active atomic.Pointer[poolVersion]
+	closed atomic.Bool
+}
+
+// poolVersion is a serving pool together with its version label.
+type poolVersion struct {
+	pool    *SessionPool
+	version string
+
+	// held for reading by runs on pool, so that retire can wait for them
+	mu      sync.RWMutex
+	retired bool
+}
+
+// retire waits for the runs started on v, then closes its pool. Runs that
+// load v afterwards move on to the serving version instead of failing on
+// the closed pool.
+func (v *poolVersion) retire() {
+	v.mu.Lock()
+	v.retired = true
+	v.mu.Unlock()
+	v.pool.Close()
+}
+
+// NewSwappablePool creates a SwappablePool serving the given model version with
+// a pool of n sessions. poolConfig is used for every pool the SwappablePool
+// creates, and swapConfig (which may be nil) controls how swaps are validated.
+func NewSwappablePool(runtime *Runtime, env *Env, version string, modelData []byte, n int, poolConfig *PoolConfig, swapConfig *SwapConfig) (*SwappablePool, error) {
+	pool, err := NewSessionPool(runtime, env, modelData, n, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SwappablePool{
+		runtime:    runtime,
+		env:        env,
+		size:       n,
+		poolConfig: poolConfig,
+	}
+	if swapConfig != nil {
+		s.swapConfig = *swapConfig
+	}
+	s.active.Store(&poolVersion{pool: pool, version: version})
+	return s, nil
+}
+
+// Run executes inference on the currently serving pool. Runs that race with a
+// completed swap or a rollback move on to the pool serving after it.
+func (s *SwappablePool) Run(ctx context.Context, inputs map[string]*Value, opts ...RunOption) (map[string]*Value, error) {
+	for {
+		current := s.active.Load()
+		current.mu.RLock()
+		if current.retired {
+			current.mu.RUnlock()
+			if s.closed.Load() {
+				return nil, ErrPoolClosed
+			}
+			continue
+		}
+		outputs, err := current.pool.Run(ctx, inputs, opts...)
+		current.mu.RUnlock()
+		return outputs, err
+	}
+}
+
+// Pool returns the currently serving pool.
+func (s *SwappablePool) Pool() *SessionPool {
+	return s.active.Load().pool
+}
+
+// Runtime returns the runtime the pools are created with.
+func (s *SwappablePool) Runtime() *Runtime {
+	return s.runtime
+}
+
+// InputInfo returns the inputs of the currently serving model.
+func (s *SwappablePool) InputInfo() []InputInfo {
+	return s.active.Load().pool.InputInfo()
+}
+
+// OutputInfo returns the outputs of the currently serving model.
+func (s *SwappablePool) OutputInfo() []OutputInfo {
+	return s.active.Load().pool.OutputInfo()
+}
+
+// Version returns the version label of the currently serving pool.
+func (s *SwappablePool) Version() string {
+	return s.active.Load().version
+}
+
+// Swap replaces the serving model with a new version. It blocks until the swap
+// completes or is rolled back; run it in a goroutine to swap in the background.
+// On error the previous version is still serving.
+func (s *SwappablePool) Swap(ctx context.Context, version string, modelData []byte) error {
+	s.swapMu.Lock()
+	defer s.swapMu.Unlock()
+
+	if s.closed.Load() {
+		return ErrPoolClosed
+	}
+
+	if budget := s.swapConfig.MaxOverlapBytes; budget > 0 {
+		if estimate := s.estimateOverlap(len(modelData)); estimate > budget {
+			return fmt.Errorf("standby pool for version %q needs an estimated %d bytes, exceeding the overlap budget of %d bytes", version, estimate, budget)
+		}
+	}
+
+	standby, err := NewSessionPool(s.runtime, s.env, modelData, s.size, s.poolConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create standby pool for version %q: %w", version, err)
+	}
+
+	if s.swapConfig.WarmupInputs != nil {
+		if err := standby.Warmup(ctx, s.swapConfig.WarmupInputs); err != nil {
+			standby.Close()
+			return fmt.Errorf("standby warmup for version %q failed: %w", version, err)
+		}
+	}
+	if err := s.healthCheck(ctx, standby); err != nil {
+		standby.Close()
+		return fmt.Errorf("standby health check for version %q failed: %w", version, err)
+	}
+
+	promoted := &poolVersion{pool: standby, version: version}
+	previous := s.active.Swap(promoted)
+
+	// Soak the new pool under traffic; the old one stays open for rollback.
+	if err := s.soak(ctx, standby); err != nil {
+		s.active.Store(previous)
+		promoted.retire()
+		return fmt.Errorf("version %q failed after promotion, rolled back to %q: %w", version, previous.version, err)
+	}
+
+	// Retiring waits for in-flight runs, draining the old pool.
+	previous.retire()
+	return nil
+}
+
+// soak watches a newly promoted pool for SwapConfig.SoakPeriod and returns
+// an error if it should be rolled back.
+func (s *SwappablePool) soak(ctx context.Context, pool *SessionPool) error {
+	if s.swapConfig.SoakPeriod <= 0 {
+		return nil
+	}
+	before := pool.Stats()
+	timer := time.NewTimer(s.swapConfig.SoakPeriod)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if limit := s.swapConfig.MaxSoakErrorRate; limit > 0 {
+		if rate := soakErrorRate(before, pool.Stats()); rate > limit {
+			return fmt.Errorf("error rate %.3f during soak exceeds %.3f", rate, limit)
+		}
+	}
+	if err := s.healthCheck(ctx, pool); err != nil {
+		return fmt.Errorf("health check after soak failed: %w", err)
+	}
+	return nil
+}
+
+// soakErrorRate returns the fraction of runs between two snapshots of a
+// pool's stats that failed, or zero if there were none.
+func soakErrorRate(before, after PoolStats) float64 {
+	runs := after.TotalRuns - before.TotalRuns
+	if runs <= 0 {
+		return 0
+	}
+	return float64(after.TotalErrors-before.TotalErrors) / float64(runs)
+}
+
+// Close closes the serving pool. It is safe to call Close multiple times.
+func (s *SwappablePool) Close() {
+	s.swapMu.Lock()
+	defer s.swapMu.Unlock()
+
+	if !s.closed.CompareAndSwap(false, true) {
+		return
+	}
+	s.active.Load().retire()
+}
+
+// healthCheck runs the configured health check against pool, if any.
+func (s *SwappablePool) healthCheck(ctx context.Context, pool *SessionPool) error {
+	switch {
+	case s.swapConfig.HealthCheck != nil:
+		return s.swapConfig.HealthCheck(ctx, pool)
+	case s.swapConfig.WarmupInputs != nil:
+		return pool.HealthCheck(ctx, s.swapConfig.WarmupInputs)
+	default:
+		return nil
+	}
+}
+
+// estimateOverlap estimates the memory held by a standby pool for a model of
+// modelSize bytes.
+func (s *SwappablePool) estimateOverlap(modelSize int) int64 {
+	if s.poolConfig != nil && s.poolConfig.SharePrepackedWeights {
+		return int64(modelSize)
+	}
+	return int64(modelSize) * int64(s.size)
+}
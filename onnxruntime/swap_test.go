@@ -0,0 +1,199 @@
+package onnxruntime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestSwappablePool(t *testing.T, swapConfig *SwapConfig) (*SwappablePool, []byte) {
+	t.Helper()
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+
+	sp, err := NewSwappablePool(runtime, env, "v1", modelData, 2, nil, swapConfig)
+	if err != nil {
+		t.Fatalf("Failed to create swappable pool: %v", err)
+	}
+	t.Cleanup(sp.Close)
+
+	return sp, modelData
+}
+
+func TestSwappablePoolEstimateOverlap(t *testing.T) {
+	s := &SwappablePool{size: 4}
+	if got := s.estimateOverlap(100); got != 400 {
+		t.Errorf("estimateOverlap = %d, want 400", got)
+	}
+
+	s.poolConfig = &PoolConfig{SharePrepackedWeights: true}
+	if got := s.estimateOverlap(100); got != 100 {
+		t.Errorf("estimateOverlap with shared weights = %d, want 100", got)
+	}
+}
+
+func TestSwappablePoolSwap(t *testing.T) {
+	sp, modelData := newTestSwappablePool(t, nil)
+
+	old := sp.Pool()
+	if err := sp.Swap(t.Context(), "v2", modelData); err != nil {
+		t.Fatalf("Swap failed: %v", err)
+	}
+	if got := sp.Version(); got != "v2" {
+		t.Errorf("Version() = %q, want v2", got)
+	}
+	if sp.Pool() == old {
+		t.Error("Expected serving pool to change")
+	}
+	if _, err := old.Run(t.Context(), nil); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected old pool to be closed, got %v", err)
+	}
+
+	tensor, err := NewTensorValue(sp.runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	outputs, err := sp.Run(t.Context(), map[string]*Value{"input": tensor})
+	if err != nil {
+		t.Fatalf("Run after swap failed: %v", err)
+	}
+	closeValues(outputs)
+}
+
+func TestSwappablePoolSwapInvalidModel(t *testing.T) {
+	sp, _ := newTestSwappablePool(t, nil)
+
+	old := sp.Pool()
+	if err := sp.Swap(t.Context(), "broken", []byte("not a model")); err == nil {
+		t.Fatal("Expected error swapping to an invalid model")
+	}
+	if sp.Version() != "v1" || sp.Pool() != old {
+		t.Error("Expected v1 to keep serving after failed swap")
+	}
+}
+
+func TestSwappablePoolRollback(t *testing.T) {
+	var checks atomic.Int32
+	sp, modelData := newTestSwappablePool(t, &SwapConfig{
+		HealthCheck: func(ctx context.Context, pool *SessionPool) error {
+			// Pass the pre-promotion check, fail the one after the soak.
+			if checks.Add(1) == 2 {
+				return errors.New("golden output mismatch")
+			}
+			return nil
+		},
+		SoakPeriod: time.Millisecond,
+	})
+
+	old := sp.Pool()
+	if err := sp.Swap(t.Context(), "v2", modelData); err == nil {
+		t.Fatal("Expected swap to be rolled back")
+	}
+	if checks.Load() != 2 {
+		t.Errorf("Expected 2 health checks, got %d", checks.Load())
+	}
+	if sp.Version() != "v1" || sp.Pool() != old {
+		t.Error("Expected v1 to serve after rollback")
+	}
+	runPoolInference(t, old)
+}
+
+func TestSwappablePoolNoSoak(t *testing.T) {
+	var checks atomic.Int32
+	sp, modelData := newTestSwappablePool(t, &SwapConfig{
+		HealthCheck: func(ctx context.Context, pool *SessionPool) error {
+			checks.Add(1)
+			return nil
+		},
+	})
+
+	if err := sp.Swap(t.Context(), "v2", modelData); err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+	if checks.Load() != 1 {
+		t.Errorf("Expected 1 health check without a soak period, got %d", checks.Load())
+	}
+}
+
+func TestSoakErrorRate(t *testing.T) {
+	tests := []struct {
+		before, after PoolStats
+		want          float64
+	}{
+		{PoolStats{}, PoolStats{}, 0},
+		{PoolStats{TotalRuns: 10, TotalErrors: 1}, PoolStats{TotalRuns: 10, TotalErrors: 1}, 0},
+		{PoolStats{TotalRuns: 10, TotalErrors: 1}, PoolStats{TotalRuns: 30, TotalErrors: 6}, 0.25},
+		// Stats reset during the soak
+		{PoolStats{TotalRuns: 10}, PoolStats{TotalRuns: 2, TotalErrors: 2}, 0},
+	}
+	for _, tt := range tests {
+		if got := soakErrorRate(tt.before, tt.after); got != tt.want {
+			t.Errorf("soakErrorRate(%+v, %+v) = %v, want %v", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestSwappablePoolOverlapBudget(t *testing.T) {
+	sp, modelData := newTestSwappablePool(t, &SwapConfig{MaxOverlapBytes: 1})
+
+	if err := sp.Swap(t.Context(), "v2", modelData); err == nil {
+		t.Fatal("Expected swap to exceed overlap budget")
+	}
+	if sp.Version() != "v1" {
+		t.Errorf("Version() = %q, want v1", sp.Version())
+	}
+}
+
+func TestSwappablePoolConcurrentSwap(t *testing.T) {
+	sp, modelData := newTestSwappablePool(t, nil)
+
+	tensor, err := NewTensorValue(sp.runtime, make([]float32, 10), []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				outputs, err := sp.Run(t.Context(), map[string]*Value{"input": tensor})
+				if err != nil {
+					t.Errorf("Run during swap failed: %v", err)
+					return
+				}
+				closeValues(outputs)
+			}
+		})
+	}
+
+	for _, version := range []string{"v2", "v3"} {
+		if err := sp.Swap(t.Context(), version, modelData); err != nil {
+			t.Errorf("Swap to %s failed: %v", version, err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,251 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
+)
+
+// TensorPool reuses tensors of the same shape and element type across
+// inference calls. Buffers are allocated once by ONNX Runtime's default
+// allocator and recycled with Get and Put, avoiding a Go slice and an OrtValue
+// allocation per Run in high-QPS pipelines.
+//
+// A TensorPool is safe for concurrent use.
+//
+// Example:
+//
+//	pool := runtime.NewTensorPool(16)
+//	defer pool.Close()
+//
+//	for req := range requests {
+//	    input, data, err := onnxruntime.GetPooledTensor[float32](pool, []int64{1, 10})
+//	    copy(data, req.Features)
+//	    outputs, err := session.Run(ctx, map[string]*onnxruntime.Value{"input": input})
+//	    pool.Put(input)
+//	    ...
+//	}
+type TensorPool struct {
+	runtime     *Runtime
+	maxPerShape int
+
+	mu     sync.Mutex
+	free   map[ONNXTensorElementDataType]map[string][]*Value // by dtype, then shape key
+	closed bool
+
+	gets      int64
+	hits      int64
+	puts      int64
+	discarded int64
+}
+
+// shapeKey returns the raw bytes of shape for use as a map key. Indexing a map
+// with string(shapeKey(shape)) does not allocate.
+func shapeKey(shape []int64) []byte {
+	if len(shape) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&shape[0])), len(shape)*8)
+}
+
+// TensorPoolStats contains tensor pool usage statistics.
+type TensorPoolStats struct {
+	// Gets is the number of Get calls; Hits is how many reused an idle tensor.
+	Gets int64
+	Hits int64
+
+	// Puts is the number of tensors returned to the pool; Discarded is how many
+	// of those were closed because the pool was full or closed.
+	Puts      int64
+	Discarded int64
+
+	// Idle is the number of tensors currently held by the pool.
+	Idle int
+}
+
+// NewTensorPool creates a tensor pool that keeps at most maxPerShape idle
+// tensors for each distinct shape and element type. A value of 0 or less
+// means no limit.
+func (r *Runtime) NewTensorPool(maxPerShape int) *TensorPool {
+	return &TensorPool{
+		runtime:     r,
+		maxPerShape: maxPerShape,
+		free:        make(map[ONNXTensorElementDataType]map[string][]*Value),
+	}
+}
+
+// Get returns a tensor with the given shape and element type, reusing an idle
+// one when available. The contents of a reused tensor are left over from its
+// previous use; callers must overwrite them. String tensors are not supported.
+func (p *TensorPool) Get(shape []int64, dataType ONNXTensorElementDataType) (*Value, error) {
+	if tensorElementSize(dataType) == 0 {
+		return nil, fmt.Errorf("unsupported element type %d for tensor pool", dataType)
+	}
+	key := shapeKey(shape)
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("tensor pool is closed")
+	}
+	p.gets++
+	byShape := p.free[dataType]
+	if idle := byShape[string(key)]; len(idle) > 0 {
+		v := idle[len(idle)-1]
+		idle[len(idle)-1] = nil
+		byShape[string(key)] = idle[:len(idle)-1]
+		p.hits++
+		p.mu.Unlock()
+		return v, nil
+	}
+	p.mu.Unlock()
+
+	return p.runtime.newAllocatedTensor(shape, dataType)
+}
+
+// GetPooledTensor returns a tensor of element type T from the pool together
+// with a slice aliasing its data, which the caller fills before Run.
+// The slice is only valid until the tensor is returned with Put or closed.
+func GetPooledTensor[T TensorData](p *TensorPool, shape []int64) (*Value, []T, error) {
+	v, err := p.Get(shape, tensorDataType[T]())
+	if err != nil {
+		return nil, nil, err
+	}
+	data, _, err := GetTensorDataUnsafe[T](v)
+	if err != nil {
+		p.Put(v)
+		return nil, nil, err
+	}
+	return v, data, nil
+}
+
+// Put returns a tensor obtained from Get to the pool. The caller must not use
+// v afterwards. Tensors that exceed the per-shape limit, or are returned after
+// Close, are closed instead.
+func (p *TensorPool) Put(v *Value) {
+	if v == nil || v.ptr == 0 {
+		return
+	}
+	shape, err := v.GetTensorShape()
+	if err != nil {
+		v.Close()
+		return
+	}
+	dataType, err := v.GetTensorElementType()
+	if err != nil {
+		v.Close()
+		return
+	}
+	key := shapeKey(shape)
+
+	p.mu.Lock()
+	p.puts++
+	byShape := p.free[dataType]
+	if p.closed || (p.maxPerShape > 0 && len(byShape[string(key)]) >= p.maxPerShape) {
+		p.discarded++
+		p.mu.Unlock()
+		v.Close()
+		return
+	}
+	if byShape == nil {
+		byShape = make(map[string][]*Value)
+		p.free[dataType] = byShape
+	}
+	byShape[string(key)] = append(byShape[string(key)], v)
+	p.mu.Unlock()
+}
+
+// Stats returns tensor pool usage statistics.
+func (p *TensorPool) Stats() TensorPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	idle := 0
+	for _, byShape := range p.free {
+		for _, values := range byShape {
+			idle += len(values)
+		}
+	}
+	return TensorPoolStats{
+		Gets:      p.gets,
+		Hits:      p.hits,
+		Puts:      p.puts,
+		Discarded: p.discarded,
+		Idle:      idle,
+	}
+}
+
+// Close releases all idle tensors. Tensors still checked out remain valid and
+// are closed when they are Put back. It is safe to call Close multiple times.
+func (p *TensorPool) Close() {
+	p.mu.Lock()
+	free := p.free
+	p.free = make(map[ONNXTensorElementDataType]map[string][]*Value)
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, byShape := range free {
+		for _, values := range byShape {
+			for _, v := range values {
+				v.Close()
+			}
+		}
+	}
+}
+
+// newAllocatedTensor creates an uninitialized tensor whose buffer is owned by
+// ONNX Runtime's default CPU allocator.
+func (r *Runtime) newAllocatedTensor(shape []int64, dataType ONNXTensorElementDataType) (*Value, error) {
+	if r.allocator == nil {
+		return nil, fmt.Errorf("allocator not initialized")
+	}
+
+	var valuePtr api.OrtValue
+	var shapePtr *int64
+	if len(shape) > 0 {
+		shapePtr = &shape[0]
+	}
+
+	status := r.apiFuncs.CreateTensorAsOrtValue(r.allocator.ptr, shapePtr, uintptr(len(shape)), dataType, &valuePtr)
+	if err := r.statusError(status, "CreateTensorAsOrtValue"); err != nil {
+		return nil, fmt.Errorf("failed to create tensor: %w", err)
+	}
+	return r.newValueFromPtr(valuePtr), nil
+}
+
+// tensorDataType returns the ONNX element type for T.
+func tensorDataType[T TensorData]() ONNXTensorElementDataType {
+	var zero T
+	switch any(zero).(type) {
+	case float32:
+		return ONNXTensorElementDataTypeFloat
+	case float64:
+		return ONNXTensorElementDataTypeDouble
+	case int8:
+		return ONNXTensorElementDataTypeInt8
+	case int16:
+		return ONNXTensorElementDataTypeInt16
+	case int32:
+		return ONNXTensorElementDataTypeInt32
+	case int64:
+		return ONNXTensorElementDataTypeInt64
+	case uint8:
+		return ONNXTensorElementDataTypeUint8
+	case Float16:
+		return ONNXTensorElementDataTypeFloat16
+	case BFloat16:
+		return ONNXTensorElementDataTypeBFloat16
+	case uint16:
+		return ONNXTensorElementDataTypeUint16
+	case uint32:
+		return ONNXTensorElementDataTypeUint32
+	case uint64:
+		return ONNXTensorElementDataTypeUint64
+	case bool:
+		return ONNXTensorElementDataTypeBool
+	default:
+		return ONNXTensorElementDataTypeUndefined
+	}
+}
@@ -0,0 +1,155 @@
+package onnxruntime
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTensorDataType(t *testing.T) {
+	if got := tensorDataType[float32](); got != ONNXTensorElementDataTypeFloat {
+		t.Errorf("float32: got %d", got)
+	}
+	if got := tensorDataType[Float16](); got != ONNXTensorElementDataTypeFloat16 {
+		t.Errorf("Float16: got %d", got)
+	}
+	if got := tensorDataType[bool](); got != ONNXTensorElementDataTypeBool {
+		t.Errorf("bool: got %d", got)
+	}
+}
+
+func TestShapeKey(t *testing.T) {
+	if string(shapeKey([]int64{1, 10})) == string(shapeKey([]int64{10, 1})) {
+		t.Error("Different shapes produced the same key")
+	}
+	if string(shapeKey([]int64{2, 3})) != string(shapeKey([]int64{2, 3})) {
+		t.Error("Equal shapes produced different keys")
+	}
+	if len(shapeKey(nil)) != 0 {
+		t.Error("Expected empty key for scalar shape")
+	}
+}
+
+func TestTensorPoolUnsupportedType(t *testing.T) {
+	pool := (&Runtime{}).NewTensorPool(0)
+	if _, err := pool.Get([]int64{1}, ONNXTensorElementDataTypeString); err == nil {
+		t.Error("Expected error for string tensors")
+	}
+}
+
+func TestTensorPoolReuse(t *testing.T) {
+	runtime := newTestRuntime(t)
+	pool := runtime.NewTensorPool(0)
+	defer pool.Close()
+
+	v, data, err := GetPooledTensor[float32](pool, []int64{2, 5})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(data) != 10 {
+		t.Fatalf("len(data) = %d, want 10", len(data))
+	}
+	for i := range data {
+		data[i] = float32(i)
+	}
+	pool.Put(v)
+
+	reused, err := pool.Get([]int64{2, 5}, ONNXTensorElementDataTypeFloat)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if reused != v {
+		t.Error("Expected idle tensor to be reused")
+	}
+
+	other, err := pool.Get([]int64{5, 2}, ONNXTensorElementDataTypeFloat)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if other == v {
+		t.Error("Tensor reused for a different shape")
+	}
+	shape, err := other.GetTensorShape()
+	if err != nil {
+		t.Fatalf("GetTensorShape failed: %v", err)
+	}
+	if !slices.Equal(shape, []int64{5, 2}) {
+		t.Errorf("shape = %v, want [5 2]", shape)
+	}
+	pool.Put(reused)
+	pool.Put(other)
+
+	stats := pool.Stats()
+	if stats.Gets != 3 || stats.Hits != 1 || stats.Puts != 3 || stats.Idle != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestTensorPoolMaxPerShape(t *testing.T) {
+	runtime := newTestRuntime(t)
+	pool := runtime.NewTensorPool(1)
+	defer pool.Close()
+
+	a, err := pool.Get([]int64{4}, ONNXTensorElementDataTypeInt64)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	b, err := pool.Get([]int64{4}, ONNXTensorElementDataTypeInt64)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	pool.Put(a)
+	pool.Put(b)
+
+	stats := pool.Stats()
+	if stats.Idle != 1 || stats.Discarded != 1 {
+		t.Errorf("Expected 1 idle and 1 discarded tensor, got %+v", stats)
+	}
+}
+
+func TestTensorPoolClose(t *testing.T) {
+	runtime := newTestRuntime(t)
+	pool := runtime.NewTensorPool(0)
+
+	v, err := pool.Get([]int64{1, 10}, ONNXTensorElementDataTypeFloat)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	pool.Close()
+	pool.Close()
+
+	pool.Put(v)
+	if v.ptr != 0 {
+		t.Error("Expected tensor returned after Close to be released")
+	}
+	if _, err := pool.Get([]int64{1, 10}, ONNXTensorElementDataTypeFloat); err == nil {
+		t.Error("Expected error from Get after Close")
+	}
+}
+
+func TestTensorPoolRun(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+	pool := runtime.NewTensorPool(0)
+	defer pool.Close()
+
+	for range 3 {
+		input, data, err := GetPooledTensor[float32](pool, []int64{1, 10})
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		for i := range data {
+			data[i] = float32(i)
+		}
+
+		outputs, err := session.Run(t.Context(), map[string]*Value{"input": input})
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		closeValues(outputs)
+		pool.Put(input)
+	}
+
+	if stats := pool.Stats(); stats.Hits != 2 {
+		t.Errorf("Hits = %d, want 2", stats.Hits)
+	}
+}
@@ -0,0 +1,69 @@
+package onnxruntime
+
+import (
+	"fmt"
+
+	"github.com/benedoc-inc/onnxer/onnxruntime/internal/onnxproto"
+)
+
+// NewTensorValueFromProto creates a tensor from a serialized ONNX TensorProto,
+// the format of the input_N.pb and output_N.pb files in ONNX test data
+// directories. It returns the tensor and the name stored in the proto, which
+// may be empty. Tensors with external data are not supported. The caller must
+// close the returned Value.
+func NewTensorValueFromProto(r *Runtime, data []byte) (*Value, string, error) {
+	t, err := onnxproto.DecodeTensor(data)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, d := range t.Dims {
+		if d < 0 {
+			return nil, "", fmt.Errorf("tensor %q has invalid dims %v", t.Name, t.Dims)
+		}
+	}
+
+	dataType := ONNXTensorElementDataType(t.DataType)
+	if dataType == ONNXTensorElementDataTypeString {
+		strs := make([]string, len(t.StringData))
+		for i, s := range t.StringData {
+			strs[i] = string(s)
+		}
+		v, err := r.NewStringTensorValue(strs, t.Dims)
+		return v, t.Name, err
+	}
+
+	raw, err := t.Bytes()
+	if err != nil {
+		return nil, "", err
+	}
+	// Copy so the tensor does not alias data and is aligned to its elements.
+	v, err := NewTensorValueFromBytes(r, append(make([]byte, 0, len(raw)), raw...), t.Dims, dataType)
+	return v, t.Name, err
+}
+
+// MarshalTensorProto serializes a tensor as an ONNX TensorProto with the given
+// name, storing fixed-size elements in raw_data.
+func MarshalTensorProto(v *Value, name string) ([]byte, error) {
+	shape, err := v.GetTensorShape()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tensor shape: %w", err)
+	}
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get element type: %w", err)
+	}
+
+	t := &onnxproto.Tensor{Name: name, Dims: shape, DataType: int32(elemType)}
+	if elemType == ONNXTensorElementDataTypeString {
+		strs, _, err := GetStringTensorData(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range strs {
+			t.StringData = append(t.StringData, []byte(s))
+		}
+	} else if t.RawData, _, err = v.tensorBytes(); err != nil {
+		return nil, err
+	}
+	return onnxproto.EncodeTensor(t), nil
+}
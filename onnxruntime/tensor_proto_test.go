@@ -0,0 +1,68 @@
+package onnxruntime
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTensorProtoRoundTrip(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	tensor, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6}, []int64{2, 3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	data, err := MarshalTensorProto(tensor, "input")
+	if err != nil {
+		t.Fatalf("MarshalTensorProto failed: %v", err)
+	}
+
+	decoded, name, err := NewTensorValueFromProto(runtime, data)
+	if err != nil {
+		t.Fatalf("NewTensorValueFromProto failed: %v", err)
+	}
+	defer decoded.Close()
+
+	if name != "input" {
+		t.Errorf("Expected name 'input', got %q", name)
+	}
+	assertTensorData(t, decoded, []float32{1, 2, 3, 4, 5, 6}, []int64{2, 3})
+}
+
+func TestTensorProtoStrings(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	tensor, err := runtime.NewStringTensorValue([]string{"a", "bc"}, []int64{2})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	data, err := MarshalTensorProto(tensor, "")
+	if err != nil {
+		t.Fatalf("MarshalTensorProto failed: %v", err)
+	}
+	decoded, _, err := NewTensorValueFromProto(runtime, data)
+	if err != nil {
+		t.Fatalf("NewTensorValueFromProto failed: %v", err)
+	}
+	defer decoded.Close()
+
+	strs, _, err := GetStringTensorData(decoded)
+	if err != nil {
+		t.Fatalf("GetStringTensorData failed: %v", err)
+	}
+	if !slices.Equal(strs, []string{"a", "bc"}) {
+		t.Errorf("Expected [a bc], got %v", strs)
+	}
+}
+
+func TestNewTensorValueFromProtoInvalid(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	if _, _, err := NewTensorValueFromProto(runtime, []byte{0x4a, 0x10, 0x00}); err == nil {
+		t.Error("Expected error for truncated proto")
+	}
+}
@@ -0,0 +1,186 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Tensor is a typed, shape-aware view of a tensor Value, replacing flat-index
+// arithmetic with multi-dimensional accessors. It reads the Value's memory
+// directly, like GetTensorDataUnsafe: a Tensor and every view derived from
+// it are valid only while the Value is open, and writes through Set are
+// visible in the Value.
+//
+// Example:
+//
+//	logits, err := ort.AsTensor[float32](outputs["logits"]) // [batch, classes]
+//	for i, row := range logits.Rows() {
+//	    fmt.Println(i, row.At(0), row.At(1))
+//	}
+type Tensor[T TensorData] struct {
+	value   *Value
+	data    []T
+	shape   []int64
+	strides []int64
+}
+
+// AsTensor returns a view of v, which must hold elements of type T.
+func AsTensor[T TensorData](v *Value) (*Tensor[T], error) {
+	data, shape, err := GetTensorDataUnsafe[T](v)
+	if err != nil {
+		return nil, err
+	}
+	return newTensorView(v, data, shape), nil
+}
+
+func newTensorView[T TensorData](v *Value, data []T, shape []int64) *Tensor[T] {
+	strides := make([]int64, len(shape))
+	stride := int64(1)
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+	return &Tensor[T]{value: v, data: data, shape: shape, strides: strides}
+}
+
+// Value returns the Value the view reads from.
+func (t *Tensor[T]) Value() *Value {
+	return t.value
+}
+
+// Shape returns the view's dimensions. The caller must not modify it.
+func (t *Tensor[T]) Shape() []int64 {
+	return t.shape
+}
+
+// Strides returns the number of elements between consecutive indices of
+// each dimension. The caller must not modify it.
+func (t *Tensor[T]) Strides() []int64 {
+	return t.strides
+}
+
+// Data returns the view's elements in row-major order.
+func (t *Tensor[T]) Data() []T {
+	return t.data
+}
+
+// Len returns the number of elements in the view.
+func (t *Tensor[T]) Len() int {
+	return len(t.data)
+}
+
+// offset returns the flat index of indices, panicking like a slice index
+// expression if they are out of range.
+func (t *Tensor[T]) offset(indices []int) int {
+	if len(indices) != len(t.shape) {
+		panic(fmt.Sprintf("onnxruntime: got %d indices for a tensor of rank %d", len(indices), len(t.shape)))
+	}
+	var off int64
+	for i, idx := range indices {
+		if idx < 0 || int64(idx) >= t.shape[i] {
+			panic(fmt.Sprintf("onnxruntime: index %d out of range for dimension %d of size %d", idx, i, t.shape[i]))
+		}
+		off += int64(idx) * t.strides[i]
+	}
+	return int(off)
+}
+
+// At returns the element at indices, one per dimension. It panics if the
+// number of indices does not match the rank or an index is out of range.
+func (t *Tensor[T]) At(indices ...int) T {
+	return t.data[t.offset(indices)]
+}
+
+// Set stores x at indices, one per dimension, writing to the Value's
+// memory. It panics like At.
+func (t *Tensor[T]) Set(x T, indices ...int) {
+	t.data[t.offset(indices)] = x
+}
+
+// Dim returns the size of the outermost dimension, or 0 for a scalar.
+func (t *Tensor[T]) Dim() int {
+	if len(t.shape) == 0 {
+		return 0
+	}
+	return int(t.shape[0])
+}
+
+// Row returns the view at index i of the outermost dimension, with one
+// dimension fewer. It panics for a scalar or if i is out of range.
+func (t *Tensor[T]) Row(i int) *Tensor[T] {
+	if len(t.shape) == 0 {
+		panic("onnxruntime: Row of a scalar tensor")
+	}
+	if i < 0 || i >= t.Dim() {
+		panic(fmt.Sprintf("onnxruntime: row %d out of range for dimension of size %d", i, t.shape[0]))
+	}
+	n := int(t.strides[0])
+	return &Tensor[T]{
+		value:   t.value,
+		data:    t.data[i*n : (i+1)*n : (i+1)*n],
+		shape:   t.shape[1:],
+		strides: t.strides[1:],
+	}
+}
+
+// Slice returns the view of indices [start, end) of the outermost
+// dimension, keeping the rank. It panics for a scalar or if the range is
+// invalid.
+func (t *Tensor[T]) Slice(start, end int) *Tensor[T] {
+	if len(t.shape) == 0 {
+		panic("onnxruntime: Slice of a scalar tensor")
+	}
+	if start < 0 || end < start || end > t.Dim() {
+		panic(fmt.Sprintf("onnxruntime: slice [%d:%d] out of range for dimension of size %d", start, end, t.shape[0]))
+	}
+	n := int(t.strides[0])
+	shape := slices.Clone(t.shape)
+	shape[0] = int64(end - start)
+	return &Tensor[T]{
+		value:   t.value,
+		data:    t.data[start*n : end*n : end*n],
+		shape:   shape,
+		strides: t.strides,
+	}
+}
+
+// Reshape returns a view of the same elements with a new shape. One
+// dimension may be -1, in which case it is inferred from the others.
+func (t *Tensor[T]) Reshape(shape ...int64) (*Tensor[T], error) {
+	shape = slices.Clone(shape)
+	infer := -1
+	known := int64(1)
+	for i, d := range shape {
+		switch {
+		case d == -1 && infer < 0:
+			infer = i
+		case d < 0:
+			return nil, fmt.Errorf("invalid shape %v", shape)
+		default:
+			known *= d
+		}
+	}
+	n := int64(len(t.data))
+	if infer >= 0 {
+		if known == 0 || n%known != 0 {
+			return nil, fmt.Errorf("cannot reshape %d elements to %v", n, shape)
+		}
+		shape[infer] = n / known
+	} else if known != n {
+		return nil, fmt.Errorf("cannot reshape %d elements to %v", n, shape)
+	}
+	return newTensorView(t.value, t.data, shape), nil
+}
+
+// Rows iterates over the outermost dimension, yielding each index and its
+// Row.
+func (t *Tensor[T]) Rows() iter.Seq2[int, *Tensor[T]] {
+	return func(yield func(int, *Tensor[T]) bool) {
+		for i := range t.Dim() {
+			if !yield(i, t.Row(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package onnxruntime
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTensorView(t *testing.T) {
+	data := make([]float32, 24)
+	for i := range data {
+		data[i] = float32(i)
+	}
+	tensor := newTensorView(nil, data, []int64{2, 3, 4})
+
+	if !slices.Equal(tensor.Strides(), []int64{12, 4, 1}) {
+		t.Errorf("Expected strides [12 4 1], got %v", tensor.Strides())
+	}
+	if got := tensor.At(1, 2, 3); got != 23 {
+		t.Errorf("At(1, 2, 3) = %v, want 23", got)
+	}
+	if got := tensor.At(0, 1, 2); got != 6 {
+		t.Errorf("At(0, 1, 2) = %v, want 6", got)
+	}
+
+	row := tensor.Row(1)
+	if !slices.Equal(row.Shape(), []int64{3, 4}) || row.At(0, 0) != 12 {
+		t.Errorf("Row(1) has shape %v and first element %v, want [3 4] and 12", row.Shape(), row.At(0, 0))
+	}
+	row.Set(-1, 0, 0)
+	if data[12] != -1 {
+		t.Error("Expected Set on a row to write through to the tensor")
+	}
+
+	sliced := tensor.Slice(1, 2)
+	if !slices.Equal(sliced.Shape(), []int64{1, 3, 4}) || sliced.Len() != 12 {
+		t.Errorf("Slice(1, 2) has shape %v and %d elements, want [1 3 4] and 12", sliced.Shape(), sliced.Len())
+	}
+
+	reshaped, err := tensor.Reshape(6, -1)
+	if err != nil {
+		t.Fatalf("Reshape failed: %v", err)
+	}
+	if !slices.Equal(reshaped.Shape(), []int64{6, 4}) || reshaped.At(5, 3) != 23 {
+		t.Errorf("Reshape(6, -1) has shape %v and At(5, 3) = %v, want [6 4] and 23", reshaped.Shape(), reshaped.At(5, 3))
+	}
+	for _, shape := range [][]int64{{5, 5}, {-1, -1}, {7, -1}, {-2, 12}} {
+		if _, err := tensor.Reshape(shape...); err == nil {
+			t.Errorf("Expected Reshape(%v) to fail", shape)
+		}
+	}
+
+	var rows []int
+	for i, row := range tensor.Rows() {
+		rows = append(rows, i)
+		if row.Len() != 12 {
+			t.Errorf("Row %d has %d elements, want 12", i, row.Len())
+		}
+	}
+	if !slices.Equal(rows, []int{0, 1}) {
+		t.Errorf("Expected rows [0 1], got %v", rows)
+	}
+}
+
+func TestTensorViewPanics(t *testing.T) {
+	tensor := newTensorView(nil, make([]int64, 6), []int64{2, 3})
+	scalar := newTensorView(nil, make([]int64, 1), []int64{})
+
+	tests := map[string]func(){
+		"wrong rank":       func() { tensor.At(1) },
+		"index too large":  func() { tensor.At(0, 3) },
+		"negative index":   func() { tensor.At(-1, 0) },
+		"row out of range": func() { tensor.Row(2) },
+		"invalid slice":    func() { tensor.Slice(2, 1) },
+		"row of scalar":    func() { scalar.Row(0) },
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected a panic")
+				}
+			}()
+			fn()
+		})
+	}
+
+	if scalar.At() != 0 || scalar.Dim() != 0 {
+		t.Error("Expected a scalar view with no dimensions")
+	}
+}
+
+func TestAsTensor(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	value, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6}, []int64{2, 3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer value.Close()
+
+	tensor, err := AsTensor[float32](value)
+	if err != nil {
+		t.Fatalf("AsTensor failed: %v", err)
+	}
+	if tensor.At(1, 2) != 6 || tensor.Value() != value {
+		t.Errorf("Expected At(1, 2) = 6, got %v", tensor.At(1, 2))
+	}
+
+	if _, err := AsTensor[int64](value); err == nil {
+		t.Error("Expected an element type mismatch error")
+	}
+}
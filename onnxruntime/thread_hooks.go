@@ -0,0 +1,148 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ebitengine/purego"
+)
+
+// ThreadHooks customizes the OS threads ONNX Runtime starts for the global
+// thread pools of an environment created with NewEnvWithGlobalThreadPools.
+// With hooks installed, each pool thread is a Go goroutine locked to its OS
+// thread, so the application can name it, set its scheduling priority, or
+// move it into a cgroup before ONNX Runtime runs work on it.
+//
+// Hooks are called from ONNX Runtime threads, possibly concurrently, and must
+// not call back into ONNX Runtime.
+type ThreadHooks struct {
+	// Name returns the name of the n-th thread created, counting from 0.
+	// Names are applied on Linux, where they are truncated to 15 bytes and
+	// show up in ps, top and debuggers; other platforms ignore them.
+	// If nil, DefaultThreadName is used.
+	Name func(n int) string
+
+	// OnStart runs on the new thread before ONNX Runtime uses it.
+	OnStart func(n int)
+
+	// OnExit runs on the thread after ONNX Runtime is done with it, when
+	// the environment is released. The OS thread is then returned to the Go
+	// scheduler, so OnExit should undo changes made by OnStart, such as a
+	// raised priority, that must not carry over to other goroutines.
+	OnExit func(n int)
+}
+
+// DefaultThreadName names pool threads "ort-intra-0", "ort-intra-1", and so on.
+func DefaultThreadName(n int) string {
+	return "ort-intra-" + strconv.Itoa(n)
+}
+
+// threadHooks is the registered state for one SetThreadHooks call.
+type threadHooks struct {
+	ThreadHooks
+	created atomic.Int64
+}
+
+var (
+	// purego callbacks are never freed, so one pair of trampolines is shared
+	// by all threading options and dispatches on the creation options
+	// parameter, which carries a threadHooksRegistry key.
+	threadCallbacksOnce sync.Once
+	createThreadPtr     uintptr
+	joinThreadPtr       uintptr
+
+	// Entries are never removed: ONNX Runtime may create threads for as long
+	// as an environment built from the options lives.
+	threadHooksRegistry sync.Map // uintptr -> *threadHooks
+	nextThreadHooksID   atomic.Uintptr
+
+	customThreads      sync.Map // uintptr -> chan struct{}, closed when the thread exits
+	nextCustomThreadID atomic.Uintptr
+)
+
+func threadCallbacks() (create, join uintptr) {
+	threadCallbacksOnce.Do(func() {
+		createThreadPtr = purego.NewCallback(createThread)
+		joinThreadPtr = purego.NewCallback(joinThread)
+	})
+	return createThreadPtr, joinThreadPtr
+}
+
+// createThread implements OrtCustomCreateThreadFn. It runs workerFn(param)
+// on a new locked OS thread and returns a handle for joinThread.
+func createThread(options, workerFn, param uintptr) uintptr {
+	hooks := &threadHooks{}
+	if h, ok := threadHooksRegistry.Load(options); ok {
+		hooks = h.(*threadHooks)
+	}
+	n := int(hooks.created.Add(1) - 1)
+
+	handle := nextCustomThreadID.Add(1)
+	done := make(chan struct{})
+	customThreads.Store(handle, done)
+
+	go func() {
+		defer close(done)
+		// The thread must be unlocked before the goroutine exits: letting Go
+		// terminate an OS thread that has run a purego callback crashes the
+		// process. The thread therefore returns to the scheduler, so its
+		// name is restored here and OnExit must undo any other changes.
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		name := DefaultThreadName
+		if hooks.Name != nil {
+			name = hooks.Name
+		}
+		defer setThreadName(name(n))()
+		if hooks.OnStart != nil {
+			hooks.OnStart(n)
+		}
+		purego.SyscallN(workerFn, param)
+		if hooks.OnExit != nil {
+			hooks.OnExit(n)
+		}
+	}()
+	return handle
+}
+
+// joinThread implements OrtCustomJoinThreadFn.
+func joinThread(handle uintptr) {
+	if done, ok := customThreads.LoadAndDelete(handle); ok {
+		<-done.(chan struct{})
+	}
+}
+
+// SetThreadHooks makes the global thread pools create their threads through
+// Go so hooks can customize them. Use the zero ThreadHooks to only name the
+// threads with DefaultThreadName.
+//
+// Example:
+//
+//	threadOpts.SetThreadHooks(onnxruntime.ThreadHooks{
+//	    OnStart: func(n int) { syscall.Setpriority(syscall.PRIO_PROCESS, 0, 10) },
+//	})
+//	env, err := runtime.NewEnvWithGlobalThreadPools("app", onnxruntime.LoggingLevelWarning, threadOpts)
+func (t *ThreadingOptions) SetThreadHooks(hooks ThreadHooks) error {
+	create, join := threadCallbacks()
+	id := nextThreadHooksID.Add(1)
+	threadHooksRegistry.Store(id, &threadHooks{ThreadHooks: hooks})
+
+	status := t.runtime.apiFuncs.SetGlobalCustomThreadCreationOptions(t.ptr, id)
+	if err := t.runtime.statusError(status, "SetGlobalCustomThreadCreationOptions"); err != nil {
+		threadHooksRegistry.Delete(id)
+		return fmt.Errorf("failed to set custom thread creation options: %w", err)
+	}
+	status = t.runtime.apiFuncs.SetGlobalCustomCreateThreadFn(t.ptr, create)
+	if err := t.runtime.statusError(status, "SetGlobalCustomCreateThreadFn"); err != nil {
+		return fmt.Errorf("failed to set custom create thread function: %w", err)
+	}
+	status = t.runtime.apiFuncs.SetGlobalCustomJoinThreadFn(t.ptr, join)
+	if err := t.runtime.statusError(status, "SetGlobalCustomJoinThreadFn"); err != nil {
+		return fmt.Errorf("failed to set custom join thread function: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package onnxruntime
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ebitengine/purego"
+)
+
+func TestDefaultThreadName(t *testing.T) {
+	if got := DefaultThreadName(3); got != "ort-intra-3" {
+		t.Errorf("Expected ort-intra-3, got %q", got)
+	}
+}
+
+func TestCreateAndJoinThread(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	id := nextThreadHooksID.Add(1)
+	threadHooksRegistry.Store(id, &threadHooks{ThreadHooks: ThreadHooks{
+		OnStart: func(n int) { record("start") },
+		OnExit:  func(n int) { record("exit") },
+	}})
+	defer threadHooksRegistry.Delete(id)
+
+	var param uintptr
+	worker := purego.NewCallback(func(p uintptr) {
+		record("work")
+		param = p
+	})
+
+	handle := createThread(id, worker, 42)
+	joinThread(handle)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 3 || events[0] != "start" || events[1] != "work" || events[2] != "exit" {
+		t.Errorf("Expected start, work, exit; got %v", events)
+	}
+	if param != 42 {
+		t.Errorf("Expected worker parameter 42, got %d", param)
+	}
+
+	// Joining an unknown or already joined handle returns immediately.
+	joinThread(handle)
+}
+
+func TestEnvWithThreadHooks(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	threadOpts, err := runtime.NewThreadingOptions()
+	if err != nil {
+		t.Fatalf("Failed to create threading options: %v", err)
+	}
+	defer threadOpts.Close()
+	if err := threadOpts.SetIntraOpNumThreads(3); err != nil {
+		t.Fatalf("Failed to set intra-op threads: %v", err)
+	}
+
+	var mu sync.Mutex
+	started := map[int]bool{}
+	if err := threadOpts.SetThreadHooks(ThreadHooks{
+		OnStart: func(n int) {
+			mu.Lock()
+			defer mu.Unlock()
+			started[n] = true
+		},
+	}); err != nil {
+		t.Fatalf("SetThreadHooks failed: %v", err)
+	}
+
+	env, err := runtime.NewEnvWithGlobalThreadPools("test-thread-hooks", LoggingLevelWarning, threadOpts)
+	if err != nil {
+		t.Fatalf("Failed to create env with global thread pools: %v", err)
+	}
+	env.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(started) == 0 {
+		t.Error("Expected ONNX Runtime to create threads through the hooks")
+	}
+}
@@ -0,0 +1,26 @@
+package onnxruntime
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// setThreadName names the calling OS thread and returns a function restoring
+// the previous name. Linux limits names to 15 bytes.
+func setThreadName(name string) (restore func()) {
+	var old [16]byte
+	if err := unix.Prctl(unix.PR_GET_NAME, uintptr(unsafe.Pointer(&old[0])), 0, 0, 0); err != nil {
+		return func() {}
+	}
+	prctlSetName(name)
+	return func() { prctlSetName(unix.ByteSliceToString(old[:])) }
+}
+
+func prctlSetName(name string) {
+	p, err := unix.BytePtrFromString(name[:min(len(name), 15)])
+	if err != nil {
+		return
+	}
+	unix.Prctl(unix.PR_SET_NAME, uintptr(unsafe.Pointer(p)), 0, 0, 0)
+}
@@ -0,0 +1,36 @@
+package onnxruntime
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestSetThreadName(t *testing.T) {
+	got := make(chan string)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		before := threadName()
+		restore := setThreadName("ort-intra-123456789")
+		got <- threadName()
+		restore()
+		got <- before
+		got <- threadName()
+	}()
+	if name := <-got; name != "ort-intra-12345" {
+		t.Errorf("Expected name truncated to ort-intra-12345, got %q", name)
+	}
+	if before, after := <-got, <-got; before != after {
+		t.Errorf("Expected name restored to %q, got %q", before, after)
+	}
+}
+
+func threadName() string {
+	var buf [16]byte
+	unix.Prctl(unix.PR_GET_NAME, uintptr(unsafe.Pointer(&buf[0])), 0, 0, 0)
+	return unix.ByteSliceToString(buf[:])
+}
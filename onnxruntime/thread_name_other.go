@@ -0,0 +1,8 @@
+//go:build !linux
+
+package onnxruntime
+
+// setThreadName is a no-op; thread names are only applied on Linux.
+func setThreadName(name string) (restore func()) {
+	return func() {}
+}
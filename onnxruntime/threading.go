@@ -1,6 +1,7 @@
 package onnxruntime
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 
@@ -29,21 +30,28 @@ import (
 type ThreadingOptions struct {
 	ptr     api.OrtThreadingOptions
 	runtime *Runtime
+
+	// ID of the options in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety net releasing ptr if the options are not closed
+	cleanup runtime.Cleanup
 }
 
 // NewThreadingOptions creates new threading options for configuring global thread pools.
 func (r *Runtime) NewThreadingOptions() (*ThreadingOptions, error) {
 	var ptr api.OrtThreadingOptions
 	status := r.apiFuncs.CreateThreadingOptions(&ptr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateThreadingOptions"); err != nil {
 		return nil, fmt.Errorf("failed to create threading options: %w", err)
 	}
 
 	t := &ThreadingOptions{
 		ptr:     ptr,
 		runtime: r,
+		trackID: r.objects.add(kindThreadingOptions),
 	}
-	runtime.AddCleanup(t, func(_ struct{}) { t.Close() }, struct{}{})
+	t.cleanup = guard(t, leak{runtime: r, kind: kindThreadingOptions, id: t.trackID, ptr: uintptr(ptr), release: releaseLeakedThreadingOptions})
 	return t, nil
 }
 
@@ -51,7 +59,7 @@ func (r *Runtime) NewThreadingOptions() (*ThreadingOptions, error) {
 // individual operators (e.g., matrix multiply, convolution).
 func (t *ThreadingOptions) SetIntraOpNumThreads(n int) error {
 	status := t.runtime.apiFuncs.SetGlobalIntraOpNumThreads(t.ptr, int32(n))
-	if err := t.runtime.statusError(status); err != nil {
+	if err := t.runtime.statusError(status, "SetGlobalIntraOpNumThreads"); err != nil {
 		return fmt.Errorf("failed to set global intra-op threads: %w", err)
 	}
 	return nil
@@ -61,7 +69,7 @@ func (t *ThreadingOptions) SetIntraOpNumThreads(n int) error {
 // independent operators in the graph.
 func (t *ThreadingOptions) SetInterOpNumThreads(n int) error {
 	status := t.runtime.apiFuncs.SetGlobalInterOpNumThreads(t.ptr, int32(n))
-	if err := t.runtime.statusError(status); err != nil {
+	if err := t.runtime.statusError(status, "SetGlobalInterOpNumThreads"); err != nil {
 		return fmt.Errorf("failed to set global inter-op threads: %w", err)
 	}
 	return nil
@@ -76,18 +84,52 @@ func (t *ThreadingOptions) SetSpinControl(allow bool) error {
 		val = 1
 	}
 	status := t.runtime.apiFuncs.SetGlobalSpinControl(t.ptr, val)
-	if err := t.runtime.statusError(status); err != nil {
+	if err := t.runtime.statusError(status, "SetGlobalSpinControl"); err != nil {
 		return fmt.Errorf("failed to set global spin control: %w", err)
 	}
 	return nil
 }
 
+// SetIntraOpThreadAffinity pins the global intra-op threads to logical
+// processors. The string has one entry per thread, excluding the caller's
+// thread, so it holds IntraOpNumThreads-1 entries separated by ";". Each entry
+// is a comma-separated list of 1-based processor IDs ("1,2,3") or an inclusive
+// range ("1-4"). For example, with 3 intra-op threads, "1,2;3,4" pins the first
+// thread to processors 1 and 2 and the second to 3 and 4.
+func (t *ThreadingOptions) SetIntraOpThreadAffinity(affinity string) error {
+	affinityBytes := append([]byte(affinity), 0)
+	status := t.runtime.apiFuncs.SetGlobalIntraOpThreadAffinity(t.ptr, &affinityBytes[0])
+	if err := t.runtime.statusError(status, "SetGlobalIntraOpThreadAffinity"); err != nil {
+		return fmt.Errorf("failed to set global intra-op thread affinity: %w", err)
+	}
+	return nil
+}
+
+// SetDenormalAsZero makes the global thread pools flush denormal floating-point
+// numbers to zero, avoiding the large slowdowns denormals cause on many CPUs at
+// the cost of tiny precision loss near zero. Sessions should also set the
+// "session.set_denormal_as_zero" config entry to "1" so initializers are
+// flushed too.
+func (t *ThreadingOptions) SetDenormalAsZero() error {
+	status := t.runtime.apiFuncs.SetGlobalDenormalAsZero(t.ptr)
+	if err := t.runtime.statusError(status, "SetGlobalDenormalAsZero"); err != nil {
+		return fmt.Errorf("failed to set global denormal as zero: %w", err)
+	}
+	return nil
+}
+
+func releaseLeakedThreadingOptions(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseThreadingOptions(api.OrtThreadingOptions(ptr))
+}
+
 // Close releases the threading options.
 // It is safe to call Close multiple times.
 func (t *ThreadingOptions) Close() {
 	if t.ptr != 0 && t.runtime != nil && t.runtime.apiFuncs != nil {
+		t.cleanup.Stop()
 		t.runtime.apiFuncs.ReleaseThreadingOptions(t.ptr)
 		t.ptr = 0
+		t.runtime.objects.remove(kindThreadingOptions, t.trackID)
 	}
 }
 
@@ -100,14 +142,34 @@ func (r *Runtime) NewEnvWithGlobalThreadPools(logID string, logLevel LoggingLeve
 	var envPtr api.OrtEnv
 
 	status := r.apiFuncs.CreateEnvWithGlobalThreadPools(logLevel, &logIDBytes[0], threadingOpts.ptr, &envPtr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateEnvWithGlobalThreadPools"); err != nil {
 		return nil, fmt.Errorf("failed to create environment with global thread pools: %w", err)
 	}
 
 	env := &Env{
-		ptr:     envPtr,
-		runtime: r,
+		ptr:               envPtr,
+		runtime:           r,
+		globalThreadPools: true,
+		trackID:           r.objects.add(kindEnv),
 	}
-	runtime.AddCleanup(env, func(_ struct{}) { env.Close() }, struct{}{})
+	env.setCleanup()
 	return env, nil
 }
+
+// HasGlobalThreadPools reports whether the environment was created with
+// NewEnvWithGlobalThreadPools, which sessions setting DisablePerSessionThreads
+// require.
+func (e *Env) HasGlobalThreadPools() bool {
+	return e.globalThreadPools
+}
+
+// checkGlobalThreadPools returns an error if opts disable per-session threads
+// but env has no global thread pools for sessions to run on. ONNX Runtime
+// rejects that combination only when each session is created.
+func checkGlobalThreadPools(env *Env, opts *SessionOptions) error {
+	if opts == nil || !opts.DisablePerSessionThreads || env.HasGlobalThreadPools() {
+		return nil
+	}
+	return errors.New("SessionOptions.DisablePerSessionThreads requires an Env created with NewEnvWithGlobalThreadPools; " +
+		"create the env with global thread pools or leave per-session threads enabled")
+}
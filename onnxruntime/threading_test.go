@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -25,6 +26,12 @@ func TestThreadingOptions(t *testing.T) {
 	if err := opts.SetSpinControl(false); err != nil {
 		t.Fatalf("Failed to set spin control: %v", err)
 	}
+	if err := opts.SetIntraOpThreadAffinity("1;2;3"); err != nil {
+		t.Fatalf("Failed to set intra-op thread affinity: %v", err)
+	}
+	if err := opts.SetDenormalAsZero(); err != nil {
+		t.Fatalf("Failed to set denormal as zero: %v", err)
+	}
 
 	// Double close should not panic
 	opts.Close()
@@ -52,6 +59,9 @@ func TestEnvWithGlobalThreadPools(t *testing.T) {
 		t.Fatalf("Failed to create env with global thread pools: %v", err)
 	}
 	defer env.Close()
+	if !env.HasGlobalThreadPools() {
+		t.Error("Expected HasGlobalThreadPools to be true")
+	}
 
 	// Create a session that uses the global thread pool
 	modelData, err := os.ReadFile(testModelPath())
@@ -92,3 +102,44 @@ func TestSessionOptionsDisablePerSessionThreads(t *testing.T) {
 	})
 	runInference(t, runtime, session)
 }
+
+func TestCheckGlobalThreadPools(t *testing.T) {
+	disable := &SessionOptions{DisablePerSessionThreads: true}
+
+	if err := checkGlobalThreadPools(&Env{}, disable); err == nil {
+		t.Error("Expected error for DisablePerSessionThreads without global thread pools")
+	}
+	if err := checkGlobalThreadPools(&Env{globalThreadPools: true}, disable); err != nil {
+		t.Errorf("Unexpected error with global thread pools: %v", err)
+	}
+	if err := checkGlobalThreadPools(&Env{}, &SessionOptions{}); err != nil {
+		t.Errorf("Unexpected error with per-session threads: %v", err)
+	}
+	if err := checkGlobalThreadPools(&Env{}, nil); err != nil {
+		t.Errorf("Unexpected error with nil options: %v", err)
+	}
+}
+
+func TestSessionPoolRequiresGlobalThreadPools(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create environment: %v", err)
+	}
+	defer env.Close()
+	if env.HasGlobalThreadPools() {
+		t.Error("Expected HasGlobalThreadPools to be false for NewEnv")
+	}
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+	_, err = NewSessionPool(runtime, env, modelData, 2, &PoolConfig{
+		SessionOptions: &SessionOptions{DisablePerSessionThreads: true},
+	})
+	if err == nil || !strings.Contains(err.Error(), "NewEnvWithGlobalThreadPools") {
+		t.Errorf("Expected error pointing at NewEnvWithGlobalThreadPools, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package onnxruntime
+
+import (
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// nextSessionID assigns process-unique session identifiers for profiling labels.
+var nextSessionID atomic.Uint64
+
+// Trace region names of native calls.
+const (
+	regionRun            = "onnxruntime.Run"
+	regionRunWithBinding = "onnxruntime.RunWithBinding"
+)
+
+// profilingLabels holds the pprof labels of a session created with
+// SessionOptions.ProfilingLabels. They are built on the first run, once the
+// session's model name is known.
+type profilingLabels struct {
+	once   sync.Once
+	static []string
+	set    pprof.LabelSet
+}
+
+// get returns the static labels of session s and their label set.
+func (l *profilingLabels) get(s *Session) ([]string, pprof.LabelSet) {
+	l.once.Do(func() {
+		l.static = []string{"onnxruntime.session", strconv.FormatUint(s.id, 10)}
+		if s.modelName != "" {
+			l.static = append(l.static, "onnxruntime.model", s.modelName)
+		}
+		l.static = slices.Clip(l.static)
+		l.set = pprof.Labels(l.static...)
+	})
+	return l.static, l.set
+}
+
+// traceNative runs fn, a native ORT call, inside a runtime/trace region while
+// an execution trace is being recorded, and with pprof labels identifying the
+// session if it was created with SessionOptions.ProfilingLabels. This lets Go
+// execution traces and CPU profiles separate time spent inside ONNX Runtime
+// from Go-side processing. Otherwise fn is called directly, keeping Run free
+// of tracing overhead.
+//
+// Labels set:
+//   - onnxruntime.session: the session's process-unique ID
+//   - onnxruntime.model: the model file name, when loaded from a file
+//   - onnxruntime.run_tag: the run tag, when set with WithRunTag
+//
+// region names the trace region, such as regionRun.
+func (s *Session) traceNative(ctx context.Context, region, runTag string, fn func()) {
+	if s.labels == nil {
+		if !trace.IsEnabled() {
+			fn()
+			return
+		}
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		defer trace.StartRegion(ctx, region).End()
+		fn()
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	static, labels := s.labels.get(s)
+	if runTag != "" {
+		labels = pprof.Labels(append(static, "onnxruntime.run_tag", runTag)...)
+	}
+	pprof.Do(ctx, labels, func(ctx context.Context) {
+		defer trace.StartRegion(ctx, region).End()
+		fn()
+	})
+}
@@ -0,0 +1,73 @@
+package onnxruntime
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"slices"
+	"testing"
+)
+
+func TestTraceNative(t *testing.T) {
+	session := &Session{id: 7, modelName: "model.onnx", labels: &profilingLabels{}}
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("Skipping: tracing unavailable: %v", err)
+	}
+
+	// Run tolerates a nil context, so traceNative must as well.
+	var nilCtx context.Context
+	called := false
+	session.traceNative(nilCtx, regionRun, "tag-1", func() { called = true })
+	session.traceNative(context.Background(), regionRunWithBinding, "", func() {})
+	trace.Stop()
+
+	if !called {
+		t.Fatal("traceNative did not invoke the native call")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("onnxruntime.Run")) {
+		t.Error("Expected trace to contain the onnxruntime.Run region")
+	}
+}
+
+func TestProfilingLabels(t *testing.T) {
+	session := &Session{id: 7, modelName: "model.onnx", labels: &profilingLabels{}}
+
+	static, set := session.labels.get(session)
+	want := []string{"onnxruntime.session", "7", "onnxruntime.model", "model.onnx"}
+	if !slices.Equal(static, want) {
+		t.Errorf("static labels = %v, want %v", static, want)
+	}
+	ctx := pprof.WithLabels(context.Background(), set)
+	if model, _ := pprof.Label(ctx, "onnxruntime.model"); model != "model.onnx" {
+		t.Errorf("onnxruntime.model label = %q, want model.onnx", model)
+	}
+
+	// Runs with a tag must not modify the shared static labels
+	session.traceNative(context.Background(), regionRun, "tag-1", func() {})
+	session.traceNative(context.Background(), regionRun, "tag-2", func() {})
+	if again, _ := session.labels.get(session); !slices.Equal(again, want) {
+		t.Errorf("static labels after tagged runs = %v, want %v", again, want)
+	}
+}
+
+func TestTraceNativeNoAllocs(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("Skipping: an execution trace is being recorded")
+	}
+	session := &Session{id: 7, modelName: "model.onnx"}
+	ctx := context.Background()
+	calls := 0
+	fn := func() { calls++ }
+	allocs := testing.AllocsPerRun(100, func() {
+		session.traceNative(ctx, regionRun, "tag", fn)
+	})
+	if allocs != 0 {
+		t.Errorf("traceNative without labels or tracing allocated %v times per call, want 0", allocs)
+	}
+	if calls == 0 {
+		t.Error("traceNative did not invoke the native call")
+	}
+}
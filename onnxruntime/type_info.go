@@ -2,6 +2,7 @@ package onnxruntime
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/benedoc-inc/onnxer/internal/cstrings"
 	"github.com/benedoc-inc/onnxer/onnxruntime/internal/api"
@@ -87,12 +88,14 @@ func (s *Session) getTypeInfo(isInput bool, index int) (*rawTypeInfo, error) {
 	var typeInfoPtr api.OrtTypeInfo
 	var status api.OrtStatus
 
+	op := "SessionGetOutputTypeInfo"
 	if isInput {
+		op = "SessionGetInputTypeInfo"
 		status = s.runtime.apiFuncs.SessionGetInputTypeInfo(s.ptr, uintptr(index), &typeInfoPtr)
 	} else {
 		status = s.runtime.apiFuncs.SessionGetOutputTypeInfo(s.ptr, uintptr(index), &typeInfoPtr)
 	}
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, op); err != nil {
 		return nil, err
 	}
 	defer s.runtime.apiFuncs.ReleaseTypeInfo(typeInfoPtr)
@@ -100,7 +103,7 @@ func (s *Session) getTypeInfo(isInput bool, index int) (*rawTypeInfo, error) {
 	// Get ONNX type
 	var onnxType ONNXType
 	status = s.runtime.apiFuncs.GetOnnxTypeFromTypeInfo(typeInfoPtr, &onnxType)
-	if err := s.runtime.statusError(status); err != nil {
+	if err := s.runtime.statusError(status, "GetOnnxTypeFromTypeInfo"); err != nil {
 		return nil, fmt.Errorf("failed to get ONNX type: %w", err)
 	}
 
@@ -110,27 +113,27 @@ func (s *Session) getTypeInfo(isInput bool, index int) (*rawTypeInfo, error) {
 	if onnxType == ONNXTypeTensor {
 		var tensorInfoPtr api.OrtTensorTypeAndShapeInfo
 		status = s.runtime.apiFuncs.CastTypeInfoToTensorInfo(typeInfoPtr, &tensorInfoPtr)
-		if err := s.runtime.statusError(status); err != nil {
+		if err := s.runtime.statusError(status, "CastTypeInfoToTensorInfo"); err != nil {
 			return nil, fmt.Errorf("failed to cast to tensor info: %w", err)
 		}
 		// Note: tensorInfoPtr is owned by typeInfoPtr — do NOT release separately
 
 		var elemType ONNXTensorElementDataType
 		status = s.runtime.apiFuncs.GetTensorElementType(tensorInfoPtr, &elemType)
-		if err := s.runtime.statusError(status); err != nil {
+		if err := s.runtime.statusError(status, "GetTensorElementType"); err != nil {
 			return nil, fmt.Errorf("failed to get element type: %w", err)
 		}
 
 		var dimCount uintptr
 		status = s.runtime.apiFuncs.GetDimensionsCount(tensorInfoPtr, &dimCount)
-		if err := s.runtime.statusError(status); err != nil {
+		if err := s.runtime.statusError(status, "GetDimensionsCount"); err != nil {
 			return nil, fmt.Errorf("failed to get dimensions count: %w", err)
 		}
 
 		dims := make([]int64, dimCount)
 		if dimCount > 0 {
 			status = s.runtime.apiFuncs.GetDimensions(tensorInfoPtr, &dims[0], dimCount)
-			if err := s.runtime.statusError(status); err != nil {
+			if err := s.runtime.statusError(status, "GetDimensions"); err != nil {
 				return nil, fmt.Errorf("failed to get dimensions: %w", err)
 			}
 		}
@@ -140,7 +143,7 @@ func (s *Session) getTypeInfo(isInput bool, index int) (*rawTypeInfo, error) {
 		if dimCount > 0 {
 			symPtrs := make([]*byte, dimCount)
 			status = s.runtime.apiFuncs.GetSymbolicDimensions(tensorInfoPtr, &symPtrs[0], dimCount)
-			if err := s.runtime.statusError(status); err == nil {
+			if err := s.runtime.statusError(status, "GetSymbolicDimensions"); err == nil {
 				for j := uintptr(0); j < dimCount; j++ {
 					if symPtrs[j] != nil {
 						symbolicNames[j] = cstrings.CStringToString(symPtrs[j])
@@ -159,3 +162,127 @@ func (s *Session) getTypeInfo(isInput bool, index int) (*rawTypeInfo, error) {
 
 	return result, nil
 }
+
+// FreeDimensionNames returns the names of the model's symbolic dimensions,
+// such as "batch_size" and "sequence_length", in order of first appearance
+// in the inputs and then the outputs. Dynamic dimensions without a name are
+// not included. The names are the keys SessionOptions.FreeDimensionOverrides
+// accepts, so they can be used to fix dimensions when creating another
+// session:
+//
+//	names, _ := session.FreeDimensionNames()
+//	overrides := make(map[string]int64)
+//	for _, name := range names {
+//	    overrides[name] = 1
+//	}
+//	fixed, err := runtime.NewSession(env, modelPath, &ort.SessionOptions{FreeDimensionOverrides: overrides})
+func (s *Session) FreeDimensionNames() ([]string, error) {
+	inputs, err := s.GetInputInfo()
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := s.GetOutputInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*TensorTypeInfo, 0, len(inputs)+len(outputs))
+	for _, in := range inputs {
+		infos = append(infos, in.TensorInfo)
+	}
+	for _, out := range outputs {
+		infos = append(infos, out.TensorInfo)
+	}
+
+	var names []string
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		for i, d := range info.Shape {
+			if name := info.SymbolicDimNames[i]; d < 0 && name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// ResolveOutputShapes computes concrete output shapes for the given input
+// shapes by substituting the model's symbolic dimensions, so outputs can be
+// preallocated for IoBinding (see [Session.NewOutputTensor]) without running
+// inference first. For example, an input declared [batch_size, 10] given
+// shape [4, 10] resolves an output declared [batch_size, 3] to [4, 3].
+//
+// Inputs whose declared shape is fully fixed may be omitted. Only tensor
+// outputs are included in the result. An error is returned if an input
+// shape contradicts the model, if a symbolic dimension is given two
+// different sizes, or if an output dimension is not named after an input
+// dimension and so depends on the input data or on shape inference.
+func (s *Session) ResolveOutputShapes(inputShapes map[string][]int64) (map[string][]int64, error) {
+	inputs, err := s.GetInputInfo()
+	if err != nil {
+		return nil, err
+	}
+	for name := range inputShapes {
+		if !slices.Contains(s.inputNames, name) {
+			return nil, fmt.Errorf("unknown input %q", name)
+		}
+	}
+
+	dims := make(map[string]int64)
+	for _, in := range inputs {
+		shape, ok := inputShapes[in.Name]
+		if !ok || in.TensorInfo == nil {
+			continue
+		}
+		declared := in.TensorInfo.Shape
+		if len(shape) != len(declared) {
+			return nil, fmt.Errorf("input %q has rank %d, got shape %v", in.Name, len(declared), shape)
+		}
+		for i, d := range shape {
+			if d < 0 {
+				return nil, fmt.Errorf("invalid dimension %d in shape %v for input %q", d, shape, in.Name)
+			}
+			if declared[i] >= 0 {
+				if d != declared[i] {
+					return nil, fmt.Errorf("input %q has shape %v, got %v", in.Name, declared, shape)
+				}
+				continue
+			}
+			sym := in.TensorInfo.SymbolicDimNames[i]
+			if sym == "" {
+				continue
+			}
+			if prev, ok := dims[sym]; ok && prev != d {
+				return nil, fmt.Errorf("dimension %q is %d in input %q but %d in another input", sym, d, in.Name, prev)
+			}
+			dims[sym] = d
+		}
+	}
+
+	outputs, err := s.GetOutputInfo()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]int64, len(outputs))
+	for _, out := range outputs {
+		if out.TensorInfo == nil {
+			continue
+		}
+		shape := slices.Clone(out.TensorInfo.Shape)
+		for i, d := range shape {
+			if d >= 0 {
+				continue
+			}
+			sym := out.TensorInfo.SymbolicDimNames[i]
+			v, ok := dims[sym]
+			if sym == "" || !ok {
+				return nil, fmt.Errorf("cannot resolve dimension %d (%q) of output %q from the input shapes", i, sym, out.Name)
+			}
+			shape[i] = v
+		}
+		result[out.Name] = shape
+	}
+	return result, nil
+}
@@ -133,6 +133,46 @@ func TestSymbolicDimensions(t *testing.T) {
 		t.Errorf("SymbolicDimNames length %d != Shape length %d",
 			len(info.TensorInfo.SymbolicDimNames), len(info.TensorInfo.Shape))
 	}
+
+	// The test model's input is [batch_size, 10]
+	if !slices.Equal(info.TensorInfo.Shape, []int64{-1, 10}) {
+		t.Errorf("Expected shape [-1 10], got %v", info.TensorInfo.Shape)
+	}
+	if !slices.Equal(info.TensorInfo.SymbolicDimNames, []string{"batch_size", ""}) {
+		t.Errorf("Expected symbolic dims [batch_size \"\"], got %q", info.TensorInfo.SymbolicDimNames)
+	}
+}
+
+func TestFreeDimensionNames(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	names, err := session.FreeDimensionNames()
+	if err != nil {
+		t.Fatalf("Failed to get free dimension names: %v", err)
+	}
+	if !slices.Equal(names, []string{"batch_size"}) {
+		t.Fatalf("Expected [batch_size], got %v", names)
+	}
+
+	// Overriding every free dimension fixes the input shape.
+	overrides := make(map[string]int64)
+	for _, name := range names {
+		overrides[name] = 1
+	}
+	fixed := newSessionWithOptions(t, runtime, &SessionOptions{FreeDimensionOverrides: overrides})
+	infos, err := fixed.GetInputInfo()
+	if err != nil {
+		t.Fatalf("Failed to get input info: %v", err)
+	}
+	if got := infos[0].TensorInfo.Shape; !slices.Equal(got, []int64{1, 10}) {
+		t.Errorf("Expected overridden shape [1 10], got %v", got)
+	}
+
+	session.Close()
+	if _, err := session.FreeDimensionNames(); err == nil {
+		t.Error("Expected error for closed session")
+	}
 }
 
 func TestGetInputInfoClosedSession(t *testing.T) {
@@ -145,3 +185,46 @@ func TestGetInputInfoClosedSession(t *testing.T) {
 		t.Error("Expected error for closed session")
 	}
 }
+
+func TestResolveOutputShapes(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	shapes, err := session.ResolveOutputShapes(map[string][]int64{"input": {4, 10}})
+	if err != nil {
+		t.Fatalf("Failed to resolve output shapes: %v", err)
+	}
+	if got := shapes["logits"]; !slices.Equal(got, []int64{4, 3}) {
+		t.Errorf("Expected logits shape [4 3], got %v", got)
+	}
+
+	// The resolved shape can be used to preallocate the output.
+	out, err := session.NewOutputTensor("logits", shapes["logits"])
+	if err != nil {
+		t.Fatalf("Failed to allocate output: %v", err)
+	}
+	out.Close()
+}
+
+func TestResolveOutputShapesErrors(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	tests := []struct {
+		name   string
+		shapes map[string][]int64
+	}{
+		{"missing batch", map[string][]int64{}},
+		{"wrong fixed dim", map[string][]int64{"input": {4, 5}}},
+		{"wrong rank", map[string][]int64{"input": {10}}},
+		{"negative dim", map[string][]int64{"input": {-1, 10}}},
+		{"unknown input", map[string][]int64{"input": {4, 10}, "other": {1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := session.ResolveOutputShapes(tt.shapes); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// InputMismatch lists the problems found with one input by
+// [Session.ValidateInputs].
+type InputMismatch struct {
+	Name     string
+	Problems []string
+}
+
+func (m InputMismatch) String() string {
+	return fmt.Sprintf("input '%s': %s", m.Name, strings.Join(m.Problems, "; "))
+}
+
+// InputValidationError is returned by [Session.ValidateInputs] and lists
+// every input that does not match the model, one per line.
+type InputValidationError struct {
+	Mismatches []InputMismatch
+}
+
+func (e *InputValidationError) Error() string {
+	lines := make([]string, len(e.Mismatches))
+	for i, m := range e.Mismatches {
+		lines[i] = m.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateInputs checks inputs against the model's declared inputs before
+// Run, reporting missing and unknown names, value and element types, ranks,
+// and fixed dimensions. Symbolic dimensions accept any size. It returns nil
+// if the inputs match, or an *InputValidationError describing every
+// mismatch, which is far easier to act on than the first error ONNX Runtime
+// reports.
+func (s *Session) ValidateInputs(inputs map[string]*Value) error {
+	infos, err := s.GetInputInfo()
+	if err != nil {
+		return err
+	}
+
+	var mismatches []InputMismatch
+	for _, info := range infos {
+		v, ok := inputs[info.Name]
+		var problems []string
+		switch {
+		case !ok:
+			problems = []string{"missing"}
+		case v == nil || v.ptr == 0:
+			problems = []string{"value is nil or closed"}
+		default:
+			problems = validateInput(info, v)
+		}
+		if len(problems) > 0 {
+			mismatches = append(mismatches, InputMismatch{Name: info.Name, Problems: problems})
+		}
+	}
+
+	var unknown []string
+	for name := range inputs {
+		if !slices.Contains(s.inputNames, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	for _, name := range unknown {
+		mismatches = append(mismatches, InputMismatch{
+			Name:     name,
+			Problems: []string{fmt.Sprintf("not a model input (expected one of %s)", strings.Join(s.inputNames, ", "))},
+		})
+	}
+
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return &InputValidationError{Mismatches: mismatches}
+}
+
+// validateInput returns the ways v differs from the declared input.
+func validateInput(info InputInfo, v *Value) []string {
+	valueType, err := v.GetValueType()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if valueType != info.Type {
+		return []string{fmt.Sprintf("expected %s, got %s", onnxTypeName(info.Type), onnxTypeName(valueType))}
+	}
+	if info.TensorInfo == nil {
+		return nil
+	}
+
+	var problems []string
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if elemType != info.TensorInfo.ElementType {
+		problems = append(problems, fmt.Sprintf("expected %s, got %s",
+			elementTypeName(info.TensorInfo.ElementType), elementTypeName(elemType)))
+	}
+
+	shape, err := v.GetTensorShape()
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	declared := info.TensorInfo.Shape
+	if len(shape) != len(declared) {
+		return append(problems, fmt.Sprintf("expected rank %d %s, got rank %d %v",
+			len(declared), declaredShapeString(info.TensorInfo), len(shape), shape))
+	}
+	for i, d := range declared {
+		if d > 0 && shape[i] != d {
+			problems = append(problems, fmt.Sprintf("dim %d expected %d got %d", i, d, shape[i]))
+		}
+	}
+	return problems
+}
+
+// declaredShapeString formats a declared shape, naming symbolic dimensions.
+func declaredShapeString(info *TensorTypeInfo) string {
+	dims := make([]string, len(info.Shape))
+	for i, d := range info.Shape {
+		switch {
+		case d > 0:
+			dims[i] = fmt.Sprint(d)
+		case i < len(info.SymbolicDimNames) && info.SymbolicDimNames[i] != "":
+			dims[i] = info.SymbolicDimNames[i]
+		default:
+			dims[i] = "?"
+		}
+	}
+	return "[" + strings.Join(dims, " ") + "]"
+}
+
+// onnxTypeName returns a human-readable name for an ONNX value type.
+func onnxTypeName(t ONNXType) string {
+	switch t {
+	case ONNXTypeTensor:
+		return "tensor"
+	case ONNXTypeSequence:
+		return "sequence"
+	case ONNXTypeMap:
+		return "map"
+	case ONNXTypeOpaque:
+		return "opaque"
+	case ONNXTypeSparsetensor:
+		return "sparse tensor"
+	case ONNXTypeOptional:
+		return "optional"
+	default:
+		return fmt.Sprintf("ONNXType(%d)", t)
+	}
+}
+
+// elementTypeName returns the Go-style name of a tensor element type.
+func elementTypeName(t ONNXTensorElementDataType) string {
+	switch t {
+	case ONNXTensorElementDataTypeFloat:
+		return "float32"
+	case ONNXTensorElementDataTypeUint8:
+		return "uint8"
+	case ONNXTensorElementDataTypeInt8:
+		return "int8"
+	case ONNXTensorElementDataTypeUint16:
+		return "uint16"
+	case ONNXTensorElementDataTypeInt16:
+		return "int16"
+	case ONNXTensorElementDataTypeInt32:
+		return "int32"
+	case ONNXTensorElementDataTypeInt64:
+		return "int64"
+	case ONNXTensorElementDataTypeString:
+		return "string"
+	case ONNXTensorElementDataTypeBool:
+		return "bool"
+	case ONNXTensorElementDataTypeFloat16:
+		return "float16"
+	case ONNXTensorElementDataTypeDouble:
+		return "float64"
+	case ONNXTensorElementDataTypeUint32:
+		return "uint32"
+	case ONNXTensorElementDataTypeUint64:
+		return "uint64"
+	case ONNXTensorElementDataTypeComplex64:
+		return "complex64"
+	case ONNXTensorElementDataTypeComplex128:
+		return "complex128"
+	case ONNXTensorElementDataTypeBFloat16:
+		return "bfloat16"
+	default:
+		return fmt.Sprintf("ElementType(%d)", t)
+	}
+}
@@ -0,0 +1,102 @@
+package onnxruntime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateInputs(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	valid, err := NewTensorValue(runtime, make([]float32, 20), []int64{2, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer valid.Close()
+
+	if err := session.ValidateInputs(map[string]*Value{"input": valid}); err != nil {
+		t.Errorf("Expected valid inputs, got: %v", err)
+	}
+}
+
+func TestValidateInputsMismatches(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	wrongType, err := NewTensorValue(runtime, make([]int64, 5), []int64{1, 5})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer wrongType.Close()
+
+	err = session.ValidateInputs(map[string]*Value{"input": wrongType, "extra": wrongType})
+	var verr *InputValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *InputValidationError, got %v", err)
+	}
+	if len(verr.Mismatches) != 2 {
+		t.Fatalf("Expected 2 mismatches, got %d: %v", len(verr.Mismatches), err)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"input 'input': expected float32, got int64; dim 1 expected 10 got 5",
+		"input 'extra': not a model input",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestValidateInputsRankAndMissing(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	wrongRank, err := NewTensorValue(runtime, make([]float32, 10), []int64{10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer wrongRank.Close()
+
+	err = session.ValidateInputs(map[string]*Value{"input": wrongRank})
+	if err == nil || !strings.Contains(err.Error(), "expected rank 2") {
+		t.Errorf("Expected rank mismatch, got %v", err)
+	}
+
+	err = session.ValidateInputs(map[string]*Value{})
+	if err == nil || !strings.Contains(err.Error(), "input 'input': missing") {
+		t.Errorf("Expected missing input, got %v", err)
+	}
+}
+
+func TestValidateInputsClosedSession(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+	session.Close()
+
+	if err := session.ValidateInputs(nil); !errors.Is(err, ErrSessionClosed) {
+		t.Errorf("Expected ErrSessionClosed, got %v", err)
+	}
+}
+
+func TestInputValidationErrorFormat(t *testing.T) {
+	err := &InputValidationError{Mismatches: []InputMismatch{
+		{Name: "attention_mask", Problems: []string{"expected int64, got float32", "dim 1 expected 128 got 256"}},
+		{Name: "input_ids", Problems: []string{"missing"}},
+	}}
+
+	want := "input 'attention_mask': expected int64, got float32; dim 1 expected 128 got 256\ninput 'input_ids': missing"
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestDeclaredShapeString(t *testing.T) {
+	info := &TensorTypeInfo{Shape: []int64{-1, 128, -1}, SymbolicDimNames: []string{"batch", "", ""}}
+	if got := declaredShapeString(info); got != "[batch 128 ?]" {
+		t.Errorf("Expected [batch 128 ?], got %s", got)
+	}
+}
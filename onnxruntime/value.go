@@ -1,7 +1,10 @@
 package onnxruntime
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"math/bits"
 	"runtime"
 	"unsafe"
 
@@ -23,27 +26,47 @@ type TensorData interface {
 //
 // A Value is NOT safe for concurrent use. Do not share across goroutines.
 //
-// While a finalizer is set as a safety net, you should always call Close
+// While a cleanup is set as a safety net, you should always call Close
 // explicitly (typically via defer) to ensure timely release of native memory.
 type Value struct {
 	ptr     api.OrtValue
 	infoPtr api.OrtTensorTypeAndShapeInfo
 	runtime *Runtime
+
+	// goData keeps the buffer wrapped by the tensor reachable until Close.
+	goData any
+
+	// borrowed values are owned by ONNX Runtime, such as custom op kernel
+	// inputs and outputs, and are not released by Close.
+	borrowed bool
+
+	// ID of the value in Runtime.objects, zero unless created in debug mode
+	trackID uint64
+
+	// safety nets releasing ptr and infoPtr if the value is not closed
+	cleanup, infoCleanup runtime.Cleanup
 }
 
 func (r *Runtime) newValueFromPtr(ptr api.OrtValue) *Value {
 	v := &Value{
 		ptr:     ptr,
 		runtime: r,
+		trackID: r.objects.add(kindValue),
 	}
 
 	// Clean up resources when the Value is no longer reachable.
-	runtime.AddCleanup(v, func(_ struct{}) {
-		v.Close()
-	}, struct{}{})
+	v.cleanup = guard(v, leak{runtime: r, kind: kindValue, id: v.trackID, ptr: uintptr(ptr), release: releaseLeakedValue})
 	return v
 }
 
+func releaseLeakedValue(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseValue(api.OrtValue(ptr))
+}
+
+func releaseLeakedTypeAndShapeInfo(funcs api.APIFuncs, ptr uintptr, _ any) {
+	funcs.ReleaseTensorTypeAndShapeInfo(api.OrtTensorTypeAndShapeInfo(ptr))
+}
+
 func (v *Value) initTensorTypeAndShapeInfo() error {
 	if v.infoPtr != 0 {
 		// already initialized
@@ -52,10 +75,13 @@ func (v *Value) initTensorTypeAndShapeInfo() error {
 
 	var infoPtr api.OrtTensorTypeAndShapeInfo
 	status := v.runtime.apiFuncs.GetTensorTypeAndShape(v.ptr, &infoPtr)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetTensorTypeAndShape"); err != nil {
 		return fmt.Errorf("failed to get tensor type and shape: %w", err)
 	}
 	v.infoPtr = infoPtr
+	if !v.borrowed {
+		v.infoCleanup = guard(v, leak{runtime: v.runtime, kind: untracked, ptr: uintptr(infoPtr), release: releaseLeakedTypeAndShapeInfo})
+	}
 	return nil
 }
 
@@ -63,7 +89,7 @@ func (v *Value) initTensorTypeAndShapeInfo() error {
 func (v *Value) getTensorMutableData() (unsafe.Pointer, error) {
 	var dataPtr unsafe.Pointer
 	status := v.runtime.apiFuncs.GetTensorMutableData(v.ptr, &dataPtr)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetTensorMutableData"); err != nil {
 		return nil, fmt.Errorf("failed to get tensor data: %w", err)
 	}
 
@@ -74,7 +100,7 @@ func (v *Value) getTensorMutableData() (unsafe.Pointer, error) {
 func (v *Value) GetValueType() (ONNXType, error) {
 	var valueType ONNXType
 	status := v.runtime.apiFuncs.GetValueType(v.ptr, &valueType)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetValueType"); err != nil {
 		return ONNXTypeUnknown, fmt.Errorf("failed to get value type: %w", err)
 	}
 
@@ -87,7 +113,7 @@ func (v *Value) GetValueType() (ONNXType, error) {
 func (v *Value) HasValue() (bool, error) {
 	var result int32
 	status := v.runtime.apiFuncs.HasValue(v.ptr, &result)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "HasValue"); err != nil {
 		return false, fmt.Errorf("failed to check if value exists: %w", err)
 	}
 	return result != 0, nil
@@ -103,7 +129,7 @@ func (v *Value) GetTensorShape() ([]int64, error) {
 	// Get dimension count
 	var dimCount uintptr
 	status := v.runtime.apiFuncs.GetDimensionsCount(v.infoPtr, &dimCount)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetDimensionsCount"); err != nil {
 		return nil, fmt.Errorf("failed to get dimensions count: %w", err)
 	}
 
@@ -111,7 +137,7 @@ func (v *Value) GetTensorShape() ([]int64, error) {
 	dims := make([]int64, dimCount)
 	if dimCount > 0 {
 		status = v.runtime.apiFuncs.GetDimensions(v.infoPtr, &dims[0], dimCount)
-		if err := v.runtime.statusError(status); err != nil {
+		if err := v.runtime.statusError(status, "GetDimensions"); err != nil {
 			return nil, fmt.Errorf("failed to get dimensions: %w", err)
 		}
 	}
@@ -127,7 +153,7 @@ func (v *Value) GetTensorElementType() (ONNXTensorElementDataType, error) {
 
 	var elemType ONNXTensorElementDataType
 	status := v.runtime.apiFuncs.GetTensorElementType(v.infoPtr, &elemType)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetTensorElementType"); err != nil {
 		return ONNXTensorElementDataTypeUndefined, fmt.Errorf("failed to get element type: %w", err)
 	}
 
@@ -143,7 +169,7 @@ func (v *Value) GetTensorElementCount() (int, error) {
 
 	var count uintptr
 	status := v.runtime.apiFuncs.GetTensorShapeElementCount(v.infoPtr, &count)
-	if err := v.runtime.statusError(status); err != nil {
+	if err := v.runtime.statusError(status, "GetTensorShapeElementCount"); err != nil {
 		return 0, fmt.Errorf("failed to get element count: %w", err)
 	}
 
@@ -160,17 +186,30 @@ func (v *Value) GetTensorElementCount() (int, error) {
 func (v *Value) Close() {
 	v.releaseValue()
 	v.releaseInfo()
+	v.goData = nil
+}
+
+// closeValues closes every value in m.
+func closeValues(m map[string]*Value) {
+	for _, v := range m {
+		v.Close()
+	}
 }
 
 func (v *Value) releaseValue() {
 	if v.ptr != 0 && v.runtime != nil && v.runtime.apiFuncs != nil {
-		v.runtime.apiFuncs.ReleaseValue(v.ptr)
+		if !v.borrowed {
+			v.cleanup.Stop()
+			v.runtime.apiFuncs.ReleaseValue(v.ptr)
+			v.runtime.objects.remove(kindValue, v.trackID)
+		}
 		v.ptr = 0
 	}
 }
 
 func (v *Value) releaseInfo() {
 	if v.infoPtr != 0 && v.runtime != nil && v.runtime.apiFuncs != nil {
+		v.infoCleanup.Stop()
 		v.runtime.apiFuncs.ReleaseTensorTypeAndShapeInfo(v.infoPtr)
 		v.infoPtr = 0
 	}
@@ -239,6 +278,49 @@ func NewTensorValue[T TensorData](r *Runtime, data []T, shape []int64) (*Value,
 	return r.newTensorValue(dataPtr, dataLen, shape, dataType)
 }
 
+// NewTensorValueFromBytes creates a tensor that wraps raw without copying it.
+// raw holds the tensor elements in native byte order and must be exactly the
+// size implied by shape and dataType, aligned to the element size. It may be
+// Go memory or memory mapped from a file.
+//
+// The Value keeps raw reachable until it is closed. The caller must not
+// modify raw while the tensor is in use, and memory that is not managed by Go
+// (such as an mmap'd region) must stay mapped until after Close.
+//
+// Example:
+//
+//	data, _ := syscall.Mmap(fd, 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+//	tensor, err := onnxruntime.NewTensorValueFromBytes(runtime, data, []int64{1024, 768}, onnxruntime.ONNXTensorElementDataTypeFloat)
+//	...
+//	tensor.Close()
+//	syscall.Munmap(data)
+func NewTensorValueFromBytes(r *Runtime, raw []byte, shape []int64, dataType ONNXTensorElementDataType) (*Value, error) {
+	elemSize := tensorElementSize(dataType)
+	if elemSize == 0 {
+		return nil, fmt.Errorf("unsupported element type %d for raw tensor data", dataType)
+	}
+
+	size := uint64(elemSize)
+	for _, d := range shape {
+		if d < 0 {
+			return nil, fmt.Errorf("invalid dimension %d in shape %v", d, shape)
+		}
+		hi, lo := bits.Mul64(size, uint64(d))
+		if hi != 0 {
+			return nil, fmt.Errorf("shape %v is too large", shape)
+		}
+		size = lo
+	}
+	if uint64(len(raw)) != size {
+		return nil, fmt.Errorf("raw data has %d bytes, shape %v requires %d", len(raw), shape, size)
+	}
+	if len(raw) > 0 && uintptr(unsafe.Pointer(&raw[0]))%elemSize != 0 {
+		return nil, fmt.Errorf("raw data is not aligned to the %d-byte element size", elemSize)
+	}
+
+	return r.newTensorValueFromGoBytes(raw, shape, dataType)
+}
+
 // newTensorValue creates a new tensor value from raw data using default CPU memory.
 // The data pointer must point to contiguous memory of size dataLen bytes.
 // The shape defines the tensor dimensions, and dataType specifies the element type.
@@ -254,7 +336,7 @@ func (r *Runtime) newTensorValue(data unsafe.Pointer, dataLen uintptr, shape []i
 	}
 
 	status := r.apiFuncs.CreateTensorWithDataAsOrtValue(r.cpuMemoryInfo.ptr, data, dataLen, shapePtr, uintptr(len(shape)), dataType, &valuePtr)
-	if err := r.statusError(status); err != nil {
+	if err := r.statusError(status, "CreateTensorWithDataAsOrtValue"); err != nil {
 		return nil, fmt.Errorf("failed to create tensor: %w", err)
 	}
 	return r.newValueFromPtr(valuePtr), nil
@@ -398,3 +480,108 @@ func GetTensorData[T TensorData](v *Value) ([]T, []int64, error) {
 
 	return result, shape, nil
 }
+
+// GetTensorDataInto copies tensor data into dst, a buffer owned by the caller
+// that can be reused across calls, and returns the number of elements copied
+// and the tensor's shape. Unlike [GetTensorData] it does not allocate a data
+// slice per call, which matters for servers reading outputs at high QPS.
+//
+// If dst is shorter than the tensor, nothing is copied and the error wraps
+// io.ErrShortBuffer; the returned shape can be used to size a new buffer.
+//
+// Example:
+//
+//	buf := make([]float32, 0, 1024)
+//	n, shape, err := ort.GetTensorDataInto(output, buf[:cap(buf)])
+//	logits := buf[:n]
+func GetTensorDataInto[T TensorData](v *Value, dst []T) (int, []int64, error) {
+	data, shape, err := GetTensorDataUnsafe[T](v)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(dst) < len(data) {
+		return 0, shape, fmt.Errorf("%w: tensor has %d elements, buffer holds %d", io.ErrShortBuffer, len(data), len(dst))
+	}
+	return copy(dst, data), shape, nil
+}
+
+// GetTensorBytes returns a copy of the raw element bytes of a fixed-size
+// tensor, in native byte order, with its shape and element type. It is the
+// inverse of NewTensorValueFromBytes and supports element types, such as
+// float16, that have no TensorData type.
+func GetTensorBytes(v *Value) ([]byte, []int64, ONNXTensorElementDataType, error) {
+	shape, err := v.GetTensorShape()
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get shape: %w", err)
+	}
+	raw, elemType, err := v.tensorBytes()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return bytes.Clone(raw), shape, elemType, nil
+}
+
+// tensorElementSize returns the size in bytes of one element of the given type,
+// or 0 for strings and unsupported types.
+func tensorElementSize(dataType ONNXTensorElementDataType) uintptr {
+	switch dataType {
+	case ONNXTensorElementDataTypeUint8, ONNXTensorElementDataTypeInt8, ONNXTensorElementDataTypeBool:
+		return 1
+	case ONNXTensorElementDataTypeUint16, ONNXTensorElementDataTypeInt16,
+		ONNXTensorElementDataTypeFloat16, ONNXTensorElementDataTypeBFloat16:
+		return 2
+	case ONNXTensorElementDataTypeFloat, ONNXTensorElementDataTypeInt32, ONNXTensorElementDataTypeUint32:
+		return 4
+	case ONNXTensorElementDataTypeInt64, ONNXTensorElementDataTypeUint64,
+		ONNXTensorElementDataTypeDouble, ONNXTensorElementDataTypeComplex64:
+		return 8
+	case ONNXTensorElementDataTypeComplex128:
+		return 16
+	default:
+		return 0
+	}
+}
+
+// tensorBytes returns the raw bytes of a fixed-size tensor without copying.
+// The slice is only valid while the Value is alive.
+func (v *Value) tensorBytes() ([]byte, ONNXTensorElementDataType, error) {
+	elemType, err := v.GetTensorElementType()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get element type: %w", err)
+	}
+	size := tensorElementSize(elemType)
+	if size == 0 {
+		return nil, 0, fmt.Errorf("unsupported element type %d for raw tensor access", elemType)
+	}
+
+	count, err := v.GetTensorElementCount()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get element count: %w", err)
+	}
+	if count == 0 {
+		return []byte{}, elemType, nil
+	}
+
+	dataPtr, err := v.getTensorMutableData()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tensor data: %w", err)
+	}
+	return unsafe.Slice((*byte)(dataPtr), uintptr(count)*size), elemType, nil
+}
+
+// newTensorValueFromGoBytes creates a tensor backed by buf, which the returned
+// Value keeps alive. buf must not be modified by the caller afterwards.
+func (r *Runtime) newTensorValueFromGoBytes(buf []byte, shape []int64, dataType ONNXTensorElementDataType) (*Value, error) {
+	dataLen := uintptr(len(buf))
+	if len(buf) == 0 {
+		// ORT requires a non-null data pointer even for empty tensors.
+		buf = make([]byte, 1)
+	}
+
+	v, err := r.newTensorValue(unsafe.Pointer(&buf[0]), dataLen, shape, dataType)
+	if err != nil {
+		return nil, err
+	}
+	v.goData = buf
+	return v, nil
+}
@@ -1,8 +1,11 @@
 package onnxruntime
 
 import (
+	"errors"
+	"io"
 	"slices"
 	"testing"
+	"unsafe"
 )
 
 // assertTensorData is a helper function to verify tensor data and shape
@@ -615,3 +618,95 @@ func TestGetTensorData(t *testing.T) {
 		assertTensorData(t, tensor, originalData, originalShape)
 	})
 }
+
+func TestGetTensorDataInto(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	tensor, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6}, []int64{2, 3})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+
+	buf := make([]float32, 8)
+	n, shape, err := GetTensorDataInto(tensor, buf)
+	if err != nil {
+		t.Fatalf("GetTensorDataInto failed: %v", err)
+	}
+	if n != 6 || !slices.Equal(buf[:n], []float32{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Expected 6 elements [1 2 3 4 5 6], got %d %v", n, buf[:n])
+	}
+	if !slices.Equal(shape, []int64{2, 3}) {
+		t.Errorf("Expected shape [2 3], got %v", shape)
+	}
+
+	// The buffer is a copy, not a view of the tensor.
+	buf[0] = 100
+	if data, _, _ := GetTensorDataUnsafe[float32](tensor); data[0] != 1 {
+		t.Errorf("Expected the tensor to be unchanged, got %v", data[0])
+	}
+
+	n, shape, err = GetTensorDataInto(tensor, make([]float32, 4))
+	if !errors.Is(err, io.ErrShortBuffer) {
+		t.Errorf("Expected io.ErrShortBuffer, got %v", err)
+	}
+	if n != 0 || !slices.Equal(shape, []int64{2, 3}) {
+		t.Errorf("Expected 0 elements and shape [2 3] for a short buffer, got %d %v", n, shape)
+	}
+
+	if _, _, err := GetTensorDataInto(tensor, make([]int64, 6)); err == nil {
+		t.Error("Expected an element type mismatch error")
+	}
+}
+
+func TestNewTensorValueFromBytesValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      []byte
+		shape    []int64
+		dataType ONNXTensorElementDataType
+	}{
+		{"size mismatch", make([]byte, 12), []int64{2, 2}, ONNXTensorElementDataTypeFloat},
+		{"negative dim", make([]byte, 4), []int64{-1}, ONNXTensorElementDataTypeFloat},
+		{"shape overflow", nil, []int64{1 << 62, 4}, ONNXTensorElementDataTypeFloat},
+		{"string type", make([]byte, 8), []int64{1}, ONNXTensorElementDataTypeString},
+		{"misaligned", make([]byte, 17)[1:], []int64{4}, ONNXTensorElementDataTypeFloat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTensorValueFromBytes(nil, tt.raw, tt.shape, tt.dataType); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestNewTensorValueFromBytesZeroCopy(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	values := []float32{1, 2, 3, 4}
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(&values[0])), len(values)*4)
+
+	tensor, err := NewTensorValueFromBytes(runtime, raw, []int64{2, 2}, ONNXTensorElementDataTypeFloat)
+	if err != nil {
+		t.Fatalf("NewTensorValueFromBytes failed: %v", err)
+	}
+	defer tensor.Close()
+
+	assertTensorData(t, tensor, []float32{1, 2, 3, 4}, []int64{2, 2})
+
+	// Writes to the backing memory are visible through the tensor.
+	values[0] = 42
+	data, _, err := GetTensorDataUnsafe[float32](tensor)
+	if err != nil {
+		t.Fatalf("GetTensorDataUnsafe failed: %v", err)
+	}
+	if data[0] != 42 {
+		t.Errorf("Expected tensor to alias backing memory, got %v", data[0])
+	}
+
+	tensor.Close()
+	if tensor.goData != nil {
+		t.Error("Expected Close to release the backing buffer")
+	}
+}
@@ -44,3 +44,18 @@ func getVersionString(libraryHandle uintptr) (string, error) {
 	// Safe FFI pattern: pointer to static C string
 	return cstrings.CStringToString((*byte)(unsafe.Pointer(versionPtr))), nil
 }
+
+// hasAPIVersion reports whether the library provides the given C API version.
+func hasAPIVersion(libraryHandle uintptr, version uint32) bool {
+	var ortGetAPIBase func() *apiBase
+	purego.RegisterLibFunc(&ortGetAPIBase, libraryHandle, "OrtGetApiBase")
+
+	base := ortGetAPIBase()
+	if base == nil {
+		return false
+	}
+
+	var getAPIFunc func(uint32) unsafe.Pointer
+	purego.RegisterFunc(&getAPIFunc, base.GetAPI)
+	return getAPIFunc(version) != nil
+}
@@ -0,0 +1,153 @@
+package onnxruntime
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WarmupMetadataPrefix prefixes the model custom metadata keys holding
+// sample inputs for warmup. The key for an input is the prefix followed by
+// the input name, and its value is a base64-encoded (standard encoding)
+// ONNX TensorProto, as produced by EncodeWarmupMetadata.
+const WarmupMetadataPrefix = "onnxer.warmup."
+
+// WarmupSidecarSuffix is appended to a model's file path to find its warmup
+// sidecar file, used when the model has no warmup metadata. The sidecar is a
+// JSON object mapping input names to base64-encoded TensorProtos, the same
+// encoding as the metadata values:
+//
+//	{"input": "CAEIChABQgR0ZXN0..."}
+const WarmupSidecarSuffix = ".warmup.json"
+
+// ErrNoWarmupInputs is returned when a model has neither warmup metadata nor
+// a warmup sidecar file.
+var ErrNoWarmupInputs = errors.New("model has no warmup inputs")
+
+// EncodeWarmupMetadata encodes sample inputs as custom metadata entries for
+// WarmupFromMetadata. Add the entries to the model's metadata_props when
+// exporting it, or write them without the prefix to a sidecar file.
+func EncodeWarmupMetadata(inputs map[string]*Value) (map[string]string, error) {
+	entries := make(map[string]string, len(inputs))
+	for name, v := range inputs {
+		data, err := MarshalTensorProto(v, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode warmup input %q: %w", name, err)
+		}
+		entries[WarmupMetadataPrefix+name] = base64.StdEncoding.EncodeToString(data)
+	}
+	return entries, nil
+}
+
+// WarmupInputs returns the sample inputs stored in the model's custom
+// metadata under WarmupMetadataPrefix or, if there are none and the session
+// was created from a file, in the model's warmup sidecar file. It returns
+// ErrNoWarmupInputs if neither exists. The caller must close the returned
+// Values.
+func (s *Session) WarmupInputs() (map[string]*Value, error) {
+	metadata, err := s.GetModelMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make(map[string]string)
+	for key, value := range metadata.CustomMetadata {
+		if name, ok := strings.CutPrefix(key, WarmupMetadataPrefix); ok {
+			encoded[name] = value
+		}
+	}
+	if len(encoded) == 0 && s.modelPath != "" {
+		encoded, err = readWarmupSidecar(s.modelPath + WarmupSidecarSuffix)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(encoded) == 0 {
+		return nil, ErrNoWarmupInputs
+	}
+	return decodeWarmupInputs(s.runtime, encoded)
+}
+
+// readWarmupSidecar reads a warmup sidecar file, returning nil if it does
+// not exist.
+func readWarmupSidecar(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read warmup sidecar: %w", err)
+	}
+	var encoded map[string]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to parse warmup sidecar %s: %w", path, err)
+	}
+	return encoded, nil
+}
+
+// decodeWarmupInputs decodes base64 TensorProtos keyed by input name.
+func decodeWarmupInputs(r *Runtime, encoded map[string]string) (map[string]*Value, error) {
+	inputs := make(map[string]*Value, len(encoded))
+	for name, value := range encoded {
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err == nil {
+			inputs[name], _, err = NewTensorValueFromProto(r, data)
+		}
+		if err != nil {
+			delete(inputs, name)
+			closeValues(inputs)
+			return nil, fmt.Errorf("invalid warmup input %q: %w", name, err)
+		}
+	}
+	return inputs, nil
+}
+
+// WarmupFromMetadata runs the session once on the sample inputs returned by
+// WarmupInputs, discarding the outputs.
+func (s *Session) WarmupFromMetadata(ctx context.Context) error {
+	inputs, err := s.WarmupInputs()
+	if err != nil {
+		return err
+	}
+	defer closeValues(inputs)
+
+	outputs, err := s.Run(ctx, inputs)
+	if err != nil {
+		return fmt.Errorf("warmup run failed: %w", err)
+	}
+	closeValues(outputs)
+	return nil
+}
+
+// WarmupFromMetadata runs Warmup with the sample inputs stored in the
+// model, as returned by Session.WarmupInputs. It returns ErrNoWarmupInputs
+// if the model has none.
+func (p *SessionPool) WarmupFromMetadata(ctx context.Context) error {
+	if p.closed.Load() {
+		return ErrPoolClosed
+	}
+
+	p.inflight.Add(1)
+	session, err := p.borrow(ctx, "")
+	if err != nil {
+		p.inflight.Done()
+		return err
+	}
+	inputs, err := session.WarmupInputs()
+	if !p.closed.Load() {
+		p.release(session)
+	} else {
+		session.Close()
+	}
+	p.inflight.Done()
+	if err != nil {
+		return err
+	}
+	defer closeValues(inputs)
+
+	return p.Warmup(ctx, inputs)
+}
@@ -0,0 +1,121 @@
+package onnxruntime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadWarmupSidecar(t *testing.T) {
+	dir := t.TempDir()
+
+	encoded, err := readWarmupSidecar(filepath.Join(dir, "missing.warmup.json"))
+	if err != nil || encoded != nil {
+		t.Errorf("readWarmupSidecar(missing) = %v, %v, want nil, nil", encoded, err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.warmup.json")
+	if err := os.WriteFile(invalid, []byte("[1, 2]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readWarmupSidecar(invalid); err == nil {
+		t.Error("readWarmupSidecar(invalid) succeeded, want error")
+	}
+}
+
+func TestDecodeWarmupInputsInvalidBase64(t *testing.T) {
+	_, err := decodeWarmupInputs(nil, map[string]string{"input": "not base64!"})
+	if err == nil || !strings.Contains(err.Error(), `"input"`) {
+		t.Errorf("decodeWarmupInputs() error = %v, want invalid input error", err)
+	}
+}
+
+func TestWarmupFromMetadataNoInputs(t *testing.T) {
+	runtime := newTestRuntime(t)
+	session := newTestSession(t, runtime)
+
+	if err := session.WarmupFromMetadata(context.Background()); !errors.Is(err, ErrNoWarmupInputs) {
+		t.Errorf("WarmupFromMetadata() error = %v, want ErrNoWarmupInputs", err)
+	}
+
+	pool := newTestPool(t, 2)
+	if err := pool.WarmupFromMetadata(context.Background()); !errors.Is(err, ErrNoWarmupInputs) {
+		t.Errorf("pool WarmupFromMetadata() error = %v, want ErrNoWarmupInputs", err)
+	}
+}
+
+func TestWarmupFromSidecar(t *testing.T) {
+	runtime := newTestRuntime(t)
+
+	env, err := runtime.NewEnv("test", LoggingLevelWarning)
+	if err != nil {
+		t.Fatalf("Failed to create env: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	modelData, err := os.ReadFile(testModelPath())
+	if err != nil {
+		t.Fatalf("Failed to read model: %v", err)
+	}
+	modelPath := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(modelPath, modelData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tensor, err := NewTensorValue(runtime, []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int64{1, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer tensor.Close()
+	entries, err := EncodeWarmupMetadata(map[string]*Value{"input": tensor})
+	if err != nil {
+		t.Fatalf("EncodeWarmupMetadata() error = %v", err)
+	}
+	sidecar := make(map[string]string)
+	for key, value := range entries {
+		sidecar[strings.TrimPrefix(key, WarmupMetadataPrefix)] = value
+	}
+	data, err := json.Marshal(sidecar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(modelPath+WarmupSidecarSuffix, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := runtime.NewSession(env, modelPath, nil)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	defer session.Close()
+
+	inputs, err := session.WarmupInputs()
+	if err != nil {
+		t.Fatalf("WarmupInputs() error = %v", err)
+	}
+	got, shape, err := GetTensorData[float32](inputs["input"])
+	closeValues(inputs)
+	if err != nil {
+		t.Fatalf("GetTensorData() error = %v", err)
+	}
+	if len(got) != 10 || got[9] != 10 || len(shape) != 2 || shape[1] != 10 {
+		t.Errorf("warmup input = %v %v, want 1..10 [1 10]", got, shape)
+	}
+
+	if err := session.WarmupFromMetadata(context.Background()); err != nil {
+		t.Errorf("WarmupFromMetadata() error = %v", err)
+	}
+
+	pool, err := NewSessionPoolFromFile(runtime, env, modelPath, 2, &PoolConfig{WarmupFromMetadata: true})
+	if err != nil {
+		t.Fatalf("NewSessionPoolFromFile() with warmup error = %v", err)
+	}
+	defer pool.Close()
+	if stats := pool.Stats(); stats.TotalRuns != 2 {
+		t.Errorf("TotalRuns = %d after warmup, want 2", stats.TotalRuns)
+	}
+}
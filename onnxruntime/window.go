@@ -0,0 +1,359 @@
+package onnxruntime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// BlendMode selects how RunWindowed combines output values of overlapping
+// windows.
+type BlendMode int
+
+const (
+	// BlendAverage averages the values of all windows covering a position.
+	BlendAverage BlendMode = iota
+
+	// BlendLinear weights each window's values by their distance from the
+	// window's edge across the overlap, fading from one window into the
+	// next. This avoids visible seams in tiled segmentation.
+	BlendLinear
+
+	// BlendCenter takes each value from the window whose center is closest,
+	// splitting every overlap in half. Models whose predictions degrade near
+	// window edges, such as long-document token classifiers, benefit most.
+	BlendCenter
+)
+
+// WindowConfig configures RunWindowed.
+type WindowConfig struct {
+	// Inputs names the inputs split into windows. Other inputs are passed
+	// unchanged to every window. Default all inputs.
+	Inputs []string
+
+	// Axis is the axis inputs are split along. All split inputs must have
+	// the same length along it.
+	Axis int
+
+	// Size is the length of each window along Axis. Inputs no longer than
+	// Size are run as a single window.
+	Size int
+
+	// Overlap is the number of positions shared by consecutive windows. It
+	// must be less than Size. The last window is moved back to end at the
+	// end of the input, so it may overlap its predecessor by more.
+	Overlap int
+
+	// Blend selects how overlapping outputs are combined. Default
+	// BlendAverage.
+	Blend BlendMode
+
+	// Concurrency is the maximum number of windows run at once. Default the
+	// pool size.
+	Concurrency int
+}
+
+// windowSpan is the range [start, end) of a window along the window axis.
+type windowSpan struct {
+	start, end int
+}
+
+// RunWindowed runs inputs too long for a model in fixed-size windows, such
+// as long documents or large images, through the pool concurrently and
+// stitches the outputs back together.
+//
+// The inputs named in config.Inputs, with element type T, are split into
+// windows of config.Size positions along config.Axis. Outputs with the
+// window's length along the same axis are stitched along it into an output
+// of the input's length, combining overlapping positions as config.Blend
+// selects. Other outputs, such as per-document class scores, are averaged
+// across windows. Outputs must be float32 tensors.
+//
+// The caller owns the returned values and must close them.
+func RunWindowed[T TensorData](ctx context.Context, p *SessionPool, inputs map[string]*Value, config WindowConfig, opts ...RunOption) (map[string]*Value, error) {
+	if config.Size <= 0 {
+		return nil, fmt.Errorf("window size must be positive, got %d", config.Size)
+	}
+	if config.Overlap < 0 || config.Overlap >= config.Size {
+		return nil, fmt.Errorf("window overlap must be in [0, %d), got %d", config.Size, config.Overlap)
+	}
+
+	names := config.Inputs
+	if len(names) == 0 {
+		names = keys(inputs)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no inputs to split into windows")
+	}
+	type splitInput struct {
+		data  []T
+		shape []int64
+	}
+	split := make(map[string]splitInput, len(names))
+	length := -1
+	for _, name := range names {
+		value, ok := inputs[name]
+		if !ok {
+			return nil, fmt.Errorf("windowed input %q not provided", name)
+		}
+		data, shape, err := GetTensorData[T](value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input %q: %w", name, err)
+		}
+		if config.Axis < 0 || config.Axis >= len(shape) {
+			return nil, fmt.Errorf("window axis %d out of range for input %q with shape %v", config.Axis, name, shape)
+		}
+		n := int(shape[config.Axis])
+		if length >= 0 && n != length {
+			return nil, fmt.Errorf("input %q has length %d along the window axis, want %d", name, n, length)
+		}
+		length = n
+		split[name] = splitInput{data: data, shape: shape}
+	}
+
+	spans := windowSpans(length, config.Size, config.Overlap)
+	results := make([]map[string]*Value, len(spans))
+	defer func() {
+		for _, outputs := range results {
+			closeValues(outputs)
+		}
+	}()
+
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = p.Size()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	sem := make(chan struct{}, concurrency)
+	for i, span := range spans {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			windowInputs := make(map[string]*Value, len(inputs))
+			for name, value := range inputs {
+				windowInputs[name] = value
+			}
+			var owned []*Value
+			defer func() {
+				for _, v := range owned {
+					v.Close()
+				}
+			}()
+			for name, in := range split {
+				data, shape := sliceAxis(in.data, in.shape, config.Axis, span.start, span.end)
+				value, err := NewTensorValue(p.runtime, data, shape)
+				if err != nil {
+					fail(fmt.Errorf("failed to create window of input %q: %w", name, err))
+					return
+				}
+				owned = append(owned, value)
+				windowInputs[name] = value
+			}
+
+			outputs, err := p.Run(ctx, windowInputs, opts...)
+			if err != nil {
+				fail(fmt.Errorf("failed to run window %d: %w", i, err))
+				return
+			}
+			results[i] = outputs
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stitched := make(map[string]*Value, len(results[0]))
+	for name := range results[0] {
+		data, shape, err := stitchOutput(results, name, spans, length, config)
+		if err == nil {
+			var value *Value
+			value, err = NewTensorValue(p.runtime, data, shape)
+			stitched[name] = value
+		}
+		if err != nil {
+			closeValues(stitched)
+			return nil, fmt.Errorf("failed to stitch output %q: %w", name, err)
+		}
+	}
+	return stitched, nil
+}
+
+// windowSpans splits [0, length) into windows of size positions that
+// overlap by overlap positions, the last one ending at length.
+func windowSpans(length, size, overlap int) []windowSpan {
+	if length <= size {
+		return []windowSpan{{0, length}}
+	}
+	stride := size - overlap
+	var spans []windowSpan
+	for start := 0; ; start += stride {
+		if start+size >= length {
+			spans = append(spans, windowSpan{length - size, length})
+			return spans
+		}
+		spans = append(spans, windowSpan{start, start + size})
+	}
+}
+
+// sliceAxis copies positions [start, end) along axis of a tensor.
+func sliceAxis[T any](data []T, shape []int64, axis, start, end int) ([]T, []int64) {
+	outer, n, inner := axisLayout(shape, axis)
+	out := make([]T, 0, outer*(end-start)*inner)
+	for o := range outer {
+		base := o * n * inner
+		out = append(out, data[base+start*inner:base+end*inner]...)
+	}
+	sliced := append([]int64(nil), shape...)
+	sliced[axis] = int64(end - start)
+	return out, sliced
+}
+
+// axisLayout returns the number of elements before, along and after axis
+// of a row-major tensor.
+func axisLayout(shape []int64, axis int) (outer, n, inner int) {
+	outer, inner = 1, 1
+	for _, d := range shape[:axis] {
+		outer *= int(d)
+	}
+	for _, d := range shape[axis+1:] {
+		inner *= int(d)
+	}
+	return outer, int(shape[axis]), inner
+}
+
+// stitchOutput combines output name of every window. Outputs matching their
+// window's length along the window axis are stitched along it; others are
+// averaged.
+func stitchOutput(results []map[string]*Value, name string, spans []windowSpan, length int, config WindowConfig) ([]float32, []int64, error) {
+	windows := make([][]float32, len(results))
+	shapes := make([][]int64, len(results))
+	stitch := true
+	for i, outputs := range results {
+		value, ok := outputs[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("missing from window %d", i)
+		}
+		data, shape, err := GetTensorData[float32](value)
+		if err != nil {
+			return nil, nil, err
+		}
+		windows[i], shapes[i] = data, shape
+		if config.Axis >= len(shape) || int(shape[config.Axis]) != spans[i].end-spans[i].start {
+			stitch = false
+		}
+	}
+
+	if !stitch {
+		sum := make([]float32, len(windows[0]))
+		for i, data := range windows {
+			if len(data) != len(sum) {
+				return nil, nil, fmt.Errorf("window %d has shape %v, want %v", i, shapes[i], shapes[0])
+			}
+			for j, v := range data {
+				sum[j] += v
+			}
+		}
+		for j := range sum {
+			sum[j] /= float32(len(windows))
+		}
+		return sum, shapes[0], nil
+	}
+
+	shape := append([]int64(nil), shapes[0]...)
+	shape[config.Axis] = int64(length)
+	outer, _, inner := axisLayout(shape, config.Axis)
+	sum := make([]float32, outer*length*inner)
+	weights := make([]float32, length)
+	for i, data := range windows {
+		span := spans[i]
+		n := span.end - span.start
+		if len(data) != outer*n*inner {
+			return nil, nil, fmt.Errorf("window %d has shape %v, inconsistent with %v", i, shapes[i], shapes[0])
+		}
+		for pos := span.start; pos < span.end; pos++ {
+			w := blendWeight(config.Blend, spans, i, pos)
+			if w == 0 {
+				continue
+			}
+			weights[pos] += w
+			for o := range outer {
+				src := data[(o*n+pos-span.start)*inner:][:inner]
+				dst := sum[(o*length+pos)*inner:][:inner]
+				for j, v := range src {
+					dst[j] += w * v
+				}
+			}
+		}
+	}
+	for pos, w := range weights {
+		for o := range outer {
+			dst := sum[(o*length+pos)*inner:][:inner]
+			for j := range dst {
+				dst[j] /= w
+			}
+		}
+	}
+	return sum, shape, nil
+}
+
+// blendWeight returns the weight of window i's output at position pos.
+func blendWeight(mode BlendMode, spans []windowSpan, i, pos int) float32 {
+	span := spans[i]
+	switch mode {
+	case BlendLinear:
+		w := float32(1)
+		if i > 0 {
+			if overlap := spans[i-1].end - span.start; pos-span.start < overlap {
+				w = min(w, float32(pos-span.start+1)/float32(overlap+1))
+			}
+		}
+		if i < len(spans)-1 {
+			if overlap := span.end - spans[i+1].start; span.end-pos <= overlap {
+				w = min(w, float32(span.end-pos)/float32(overlap+1))
+			}
+		}
+		return w
+	case BlendCenter:
+		// Each window owns the positions between the midpoints of its
+		// overlaps with its neighbors
+		lo, hi := span.start, span.end
+		if i > 0 {
+			lo = (span.start + spans[i-1].end) / 2
+		}
+		if i < len(spans)-1 {
+			hi = (spans[i+1].start + span.end) / 2
+		}
+		if pos >= lo && pos < hi {
+			return 1
+		}
+		return 0
+	default:
+		return 1
+	}
+}
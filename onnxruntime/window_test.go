@@ -0,0 +1,133 @@
+package onnxruntime
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWindowSpans(t *testing.T) {
+	tests := []struct {
+		length, size, overlap int
+		want                  []windowSpan
+	}{
+		{5, 8, 2, []windowSpan{{0, 5}}},
+		{8, 8, 2, []windowSpan{{0, 8}}},
+		{10, 4, 1, []windowSpan{{0, 4}, {3, 7}, {6, 10}}},
+		{11, 4, 1, []windowSpan{{0, 4}, {3, 7}, {6, 10}, {7, 11}}},
+		{6, 3, 0, []windowSpan{{0, 3}, {3, 6}}},
+	}
+	for _, tt := range tests {
+		if got := windowSpans(tt.length, tt.size, tt.overlap); !slices.Equal(got, tt.want) {
+			t.Errorf("windowSpans(%d, %d, %d) = %v, want %v", tt.length, tt.size, tt.overlap, got, tt.want)
+		}
+	}
+}
+
+func TestSliceAxis(t *testing.T) {
+	// 2x4x2 tensor, slicing positions [1, 3) of the middle axis
+	data := make([]int32, 16)
+	for i := range data {
+		data[i] = int32(i)
+	}
+	got, shape := sliceAxis(data, []int64{2, 4, 2}, 1, 1, 3)
+	if want := []int32{2, 3, 4, 5, 10, 11, 12, 13}; !slices.Equal(got, want) {
+		t.Errorf("sliceAxis() data = %v, want %v", got, want)
+	}
+	if want := []int64{2, 2, 2}; !slices.Equal(shape, want) {
+		t.Errorf("sliceAxis() shape = %v, want %v", shape, want)
+	}
+}
+
+func TestBlendWeight(t *testing.T) {
+	spans := windowSpans(10, 4, 2) // {0,4} {2,6} {4,8} {6,10}
+	for _, mode := range []BlendMode{BlendAverage, BlendLinear, BlendCenter} {
+		for pos := range 10 {
+			var total float32
+			for i, span := range spans {
+				if pos >= span.start && pos < span.end {
+					total += blendWeight(mode, spans, i, pos)
+				}
+			}
+			if total <= 0 {
+				t.Errorf("mode %d: position %d has no weight", mode, pos)
+			}
+			if mode == BlendCenter && total != 1 {
+				t.Errorf("BlendCenter: position %d owned by %v windows, want 1", pos, total)
+			}
+		}
+	}
+
+	// Linear blending fades out at the overlap but not at the input's edges
+	if w := blendWeight(BlendLinear, spans, 0, 0); w != 1 {
+		t.Errorf("BlendLinear weight at the input start = %v, want 1", w)
+	}
+	if w := blendWeight(BlendLinear, spans, 0, 3); w >= 1 {
+		t.Errorf("BlendLinear weight in the overlap = %v, want < 1", w)
+	}
+}
+
+func TestRunWindowed(t *testing.T) {
+	pool := newTestPool(t, 2)
+
+	// Rows of the test model are independent, so windowing along the batch
+	// axis must reproduce a single run over all rows
+	const rows = 7
+	data := make([]float32, rows*10)
+	for i := range data {
+		data[i] = float32(i%13) / 13
+	}
+	input, err := NewTensorValue(pool.runtime, data, []int64{rows, 10})
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+	defer input.Close()
+
+	full, err := pool.Run(context.Background(), map[string]*Value{"input": input})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want, _, err := GetTensorData[float32](full["logits"])
+	closeValues(full)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	for _, mode := range []BlendMode{BlendAverage, BlendLinear, BlendCenter} {
+		outputs, err := RunWindowed[float32](context.Background(), pool, map[string]*Value{"input": input}, WindowConfig{
+			Size:    3,
+			Overlap: 1,
+			Blend:   mode,
+		})
+		if err != nil {
+			t.Fatalf("RunWindowed() mode %d error = %v", mode, err)
+		}
+		got, shape, err := GetTensorData[float32](outputs["logits"])
+		closeValues(outputs)
+		if err != nil {
+			t.Fatalf("Failed to read output: %v", err)
+		}
+		if !slices.Equal(shape, []int64{rows, 3}) {
+			t.Errorf("mode %d: output shape = %v, want [%d 3]", mode, shape, rows)
+		}
+		for i := range want {
+			if math.Abs(float64(got[i]-want[i])) > 1e-5 {
+				t.Errorf("mode %d: output[%d] = %v, want %v", mode, i, got[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestRunWindowedInvalidConfig(t *testing.T) {
+	if _, err := RunWindowed[float32](context.Background(), nil, nil, WindowConfig{Size: 0}); err == nil {
+		t.Error("Expected error for zero window size")
+	}
+	if _, err := RunWindowed[float32](context.Background(), nil, nil, WindowConfig{Size: 4, Overlap: 4}); err == nil {
+		t.Error("Expected error for overlap not less than the window size")
+	}
+	if _, err := RunWindowed[float32](context.Background(), nil, nil, WindowConfig{Size: 4}); err == nil {
+		t.Error("Expected error without inputs")
+	}
+}
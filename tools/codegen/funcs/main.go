@@ -0,0 +1,521 @@
+// Command funcs generates the funcs.go of an older API version package from
+// the hand-written funcs.go of the newest one.
+//
+// Functions missing from the target version's API struct, as generated from
+// its header by tools/codegen, are not registered, and their methods return an
+// ORT_NOT_IMPLEMENTED status or do nothing. Methods defined in another file of
+// the target package override the generated ones, for functions that need a
+// different fallback.
+//
+// Usage:
+//
+//	go run ./tools/codegen/funcs -from onnxruntime/internal/api/v24 -out onnxruntime/internal/api/v21
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	from := flag.String("from", "", "Package directory of the newest API version (e.g., onnxruntime/internal/api/v24)")
+	out := flag.String("out", "", "Package directory of the API version to generate (e.g., onnxruntime/internal/api/v21)")
+	flag.Parse()
+
+	if *from == "" || *out == "" {
+		log.Fatal("Source and output directories are required (-from and -out flags)")
+	}
+
+	code, err := generate(*from, *out)
+	if err != nil {
+		log.Fatalf("Failed to generate: %v", err)
+	}
+	path := filepath.Join(*out, "funcs.go")
+	if err := os.WriteFile(path, code, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+	log.Printf("Generated %s", path)
+}
+
+// generate returns the funcs.go of the package in outDir derived from the
+// one in fromDir.
+func generate(fromDir, outDir string) ([]byte, error) {
+	fromDir, err := filepath.Abs(fromDir)
+	if err != nil {
+		return nil, err
+	}
+	outDir, err = filepath.Abs(outDir)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := readAPI(filepath.Join(outDir, "api.go"))
+	if err != nil {
+		return nil, err
+	}
+	added, err := introducedIn(filepath.Dir(fromDir))
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := definedMethods(outDir)
+	if err != nil {
+		return nil, err
+	}
+
+	srcPath := filepath.Join(fromDir, "funcs.go")
+	src, err := os.ReadFile(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, srcPath, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		fset:      fset,
+		src:       src,
+		file:      file,
+		target:    target,
+		added:     added,
+		overrides: overrides,
+		missing:   make(map[string]string),
+		cNames:    make(map[string]string),
+	}
+	if err := g.run(); err != nil {
+		return nil, err
+	}
+
+	code := g.apply()
+	code = bytes.Replace(code, []byte("package "+file.Name.Name), []byte("package "+target.pkg), 1)
+	code = regexp.MustCompile(`\b`+file.Name.Name+`\b`).ReplaceAll(code, []byte(target.pkg))
+	header := fmt.Sprintf("// Code generated by tools/codegen/funcs from %s/funcs.go. DO NOT EDIT.\n\n", file.Name.Name)
+	code = append([]byte(header), code...)
+	return format.Source(collapseBlankLines(code))
+}
+
+// api describes the API struct of a version package.
+type api struct {
+	pkg     string
+	version int
+	funcs   map[string]bool
+}
+
+var apiVersionPattern = regexp.MustCompile(`const APIVersion = (\d+)`)
+
+// readAPI reads the package name, API version and API struct fields of the
+// api.go at path.
+func readAPI(path string) (*api, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := apiVersionPattern.FindSubmatch(src)
+	if m == nil {
+		return nil, fmt.Errorf("%s: no APIVersion constant", path)
+	}
+	version, _ := strconv.Atoi(string(m[1]))
+
+	a := &api{pkg: file.Name.Name, version: version, funcs: make(map[string]bool)}
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != "API" {
+			return true
+		}
+		for _, field := range spec.Type.(*ast.StructType).Fields.List {
+			for _, name := range field.Names {
+				a.funcs[name.Name] = true
+			}
+		}
+		return false
+	})
+	if len(a.funcs) == 0 {
+		return nil, fmt.Errorf("%s: no API struct", path)
+	}
+	return a, nil
+}
+
+// introducedIn maps each API function to the first version of the packages
+// in dir that has it.
+func introducedIn(dir string) (map[string]int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "v*", "api.go"))
+	if err != nil {
+		return nil, err
+	}
+	added := make(map[string]int)
+	for _, path := range paths {
+		a, err := readAPI(path)
+		if err != nil {
+			return nil, err
+		}
+		for name := range a.funcs {
+			if v, ok := added[name]; !ok || a.version < v {
+				added[name] = a.version
+			}
+		}
+	}
+	return added, nil
+}
+
+// definedMethods returns the Funcs methods declared in the files of dir other
+// than api.go and funcs.go.
+func definedMethods(dir string) (map[string]bool, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	methods := make(map[string]bool)
+	for _, path := range paths {
+		switch filepath.Base(path) {
+		case "api.go", "funcs.go":
+			continue
+		}
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+				methods[fn.Name.Name] = true
+			}
+		}
+	}
+	return methods, nil
+}
+
+// edit replaces the source bytes [start, end) with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+type generator struct {
+	fset      *token.FileSet
+	src       []byte
+	file      *ast.File
+	target    *api
+	added     map[string]int
+	overrides map[string]bool
+
+	// missing maps Funcs fields that are not registered to the API function
+	// they need
+	missing map[string]string
+
+	// cNames maps Funcs fields to the C function registered to them
+	cNames map[string]string
+
+	edits          []edit
+	notImplemented bool
+}
+
+func (g *generator) run() error {
+	var funcsType *ast.StructType
+	var initFunc *ast.FuncDecl
+	for _, decl := range g.file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == "Funcs" {
+					funcsType = ts.Type.(*ast.StructType)
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Name.Name == "InitializeFuncs" {
+				initFunc = d
+			}
+		}
+	}
+	if funcsType == nil || initFunc == nil {
+		return fmt.Errorf("source has no Funcs type or InitializeFuncs")
+	}
+
+	ast.Inspect(initFunc.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && isRegisterFunc(call) {
+			dst, ok1 := call.Args[0].(*ast.UnaryExpr).X.(*ast.SelectorExpr)
+			fn, ok2 := call.Args[1].(*ast.SelectorExpr)
+			if ok1 && ok2 {
+				g.cNames[dst.Sel.Name] = fn.Sel.Name
+			}
+		}
+		return true
+	})
+	g.pruneRegistrations(initFunc.Body.List)
+	g.pruneFields(funcsType)
+	for _, decl := range g.file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+			if err := g.stubMethod(fn); err != nil {
+				return err
+			}
+		}
+	}
+	if g.notImplemented && !g.overrides["notImplemented"] {
+		g.edits = append(g.edits, edit{start: len(g.src), end: len(g.src), text: notImplementedSource})
+	}
+	return nil
+}
+
+// pruneRegistrations removes the statements of InitializeFuncs that need an
+// API function the target lacks, along with the statements using the
+// variables they set.
+func (g *generator) pruneRegistrations(stmts []ast.Stmt) {
+	removed := make([]bool, len(stmts))
+	lostVars := make(map[string]string) // local variable -> missing API function
+
+	for changed := true; changed; {
+		changed = false
+		for i, stmt := range stmts {
+			if removed[i] {
+				continue
+			}
+			need := g.missingNeed(stmt, lostVars)
+			if need == "" {
+				continue
+			}
+			removed[i] = true
+			changed = true
+			ast.Inspect(stmt, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || !isRegisterFunc(call) {
+					return true
+				}
+				switch dst := call.Args[0].(*ast.UnaryExpr).X.(type) {
+				case *ast.SelectorExpr:
+					g.missing[dst.Sel.Name] = need
+				case *ast.Ident:
+					lostVars[dst.Name] = need
+				}
+				return true
+			})
+		}
+	}
+
+	for i, stmt := range stmts {
+		if removed[i] {
+			g.removeLines(g.withDoc(stmt.Pos()), stmt.End())
+		}
+	}
+}
+
+// missingNeed returns the API function stmt needs that the target lacks, if
+// any, counting variables set by removed statements.
+func (g *generator) missingNeed(stmt ast.Stmt, lostVars map[string]string) string {
+	var need string
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if need != "" {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && x.Name == "api" && !g.target.funcs[n.Sel.Name] {
+				need = n.Sel.Name
+			}
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				if v, ok := lostVars[name.Name]; ok {
+					need = v
+				}
+			}
+		case *ast.Ident:
+			if v, ok := lostVars[n.Name]; ok {
+				need = v
+			}
+		}
+		return true
+	})
+	return need
+}
+
+func isRegisterFunc(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "RegisterFunc" || len(call.Args) != 2 {
+		return false
+	}
+	_, ok = call.Args[0].(*ast.UnaryExpr)
+	return ok
+}
+
+// pruneFields removes the Funcs fields that are not registered. A comment
+// heading a group of fields goes with the group if every field in it does.
+func (g *generator) pruneFields(st *ast.StructType) {
+	fields := st.Fields.List
+	for start := 0; start < len(fields); {
+		end := start + 1
+		for end < len(fields) && fields[end].Doc == nil {
+			end++
+		}
+		group := fields[start:end]
+		if !slices.ContainsFunc(group, func(f *ast.Field) bool { return !g.isMissing(f) }) {
+			g.removeLines(g.withDoc(group[0].Pos()), group[len(group)-1].End())
+		} else {
+			for _, f := range group {
+				if g.isMissing(f) {
+					g.removeLines(f.Names[0].Pos(), f.End())
+				}
+			}
+		}
+		start = end
+	}
+}
+
+func (g *generator) isMissing(f *ast.Field) bool {
+	_, ok := g.missing[f.Names[0].Name]
+	return len(f.Names) == 1 && ok
+}
+
+// stubMethod replaces the body of a method calling an unregistered function
+// with a fallback, or removes it if the target package overrides it.
+func (g *generator) stubMethod(fn *ast.FuncDecl) error {
+	name := fn.Name.Name
+	var need, cName string
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == "f" {
+			if v, ok := g.missing[sel.Sel.Name]; ok && need == "" {
+				need, cName = v, g.cNames[sel.Sel.Name]
+			}
+		}
+		return true
+	})
+
+	if g.overrides[name] {
+		g.removeLines(g.withDoc(fn.Pos()), fn.End())
+		return nil
+	}
+	if need == "" {
+		return nil
+	}
+
+	var body string
+	switch results := fn.Type.Results; {
+	case results == nil || len(results.List) == 0:
+		body = "{}"
+	case len(results.List) == 1 && exprSource(g.src, g.fset, results.List[0].Type) == "api.OrtStatus":
+		body = fmt.Sprintf("{\n\treturn f.notImplemented(%q)\n}", cName)
+		g.notImplemented = true
+	default:
+		return fmt.Errorf("%s needs %s, missing in API version %d; define %s in another file of package %s",
+			name, need, g.target.version, name, g.target.pkg)
+	}
+
+	doc := fmt.Sprintf("// %s was added in API version %d.\n", name, g.added[need])
+	g.edits = append(g.edits,
+		edit{start: g.offset(g.withDoc(fn.Pos())), end: g.offset(fn.Pos()), text: doc},
+		edit{start: g.offset(fn.Body.Pos()), end: g.offset(fn.Body.End()), text: body},
+	)
+	return nil
+}
+
+// exprSource returns the source of a type expression.
+func exprSource(src []byte, fset *token.FileSet, expr ast.Expr) string {
+	return string(src[fset.Position(expr.Pos()).Offset:fset.Position(expr.End()).Offset])
+}
+
+// withDoc returns the start of the comment lines directly above pos, or pos.
+func (g *generator) withDoc(pos token.Pos) token.Pos {
+	line := g.fset.Position(pos).Line
+	start := pos
+	for i := len(g.file.Comments) - 1; i >= 0; i-- {
+		c := g.file.Comments[i]
+		if c.End() >= pos {
+			continue
+		}
+		if g.fset.Position(c.End()).Line != line-1 {
+			break
+		}
+		start = c.Pos()
+		line = g.fset.Position(c.Pos()).Line
+	}
+	return start
+}
+
+func (g *generator) offset(pos token.Pos) int {
+	return g.fset.Position(pos).Offset
+}
+
+// removeLines removes the whole lines from pos through end.
+func (g *generator) removeLines(pos, end token.Pos) {
+	start := g.offset(pos)
+	for start > 0 && g.src[start-1] != '\n' {
+		start--
+	}
+	stop := g.offset(end)
+	for stop < len(g.src) && g.src[stop] != '\n' {
+		stop++
+	}
+	if stop < len(g.src) {
+		stop++
+	}
+	g.edits = append(g.edits, edit{start: start, end: stop})
+}
+
+// apply returns the source with the edits made. Edits within removed lines
+// are dropped.
+func (g *generator) apply() []byte {
+	sort.SliceStable(g.edits, func(i, j int) bool {
+		if g.edits[i].start != g.edits[j].start {
+			return g.edits[i].start < g.edits[j].start
+		}
+		return g.edits[i].end > g.edits[j].end
+	})
+	var b bytes.Buffer
+	pos := 0
+	for _, e := range g.edits {
+		if e.start < pos {
+			continue
+		}
+		b.Write(g.src[pos:e.start])
+		b.WriteString(e.text)
+		pos = e.end
+	}
+	b.Write(g.src[pos:])
+	return b.Bytes()
+}
+
+var (
+	blankLines       = regexp.MustCompile(`\n{3,}`)
+	blankAfterBrace  = regexp.MustCompile(`\{\n\n`)
+	blankBeforeBrace = regexp.MustCompile(`\n\n(\t*\})`)
+)
+
+// collapseBlankLines removes the blank lines left over by removed lines.
+func collapseBlankLines(code []byte) []byte {
+	code = blankLines.ReplaceAll(code, []byte("\n\n"))
+	code = blankAfterBrace.ReplaceAll(code, []byte("{\n"))
+	return blankBeforeBrace.ReplaceAll(code, []byte("\n$1"))
+}
+
+const notImplementedSource = `
+// errorCodeNotImplemented is ORT_NOT_IMPLEMENTED from onnxruntime_c_api.h.
+const errorCodeNotImplemented api.OrtErrorCode = 9
+
+// notImplemented returns a status reporting that name is not part of this
+// API version. The library copies the message, so it need not outlive the call.
+func (f *Funcs) notImplemented(name string) api.OrtStatus {
+	msg := append([]byte(fmt.Sprintf("%s requires ONNX Runtime API version newer than %d", name, APIVersion)), 0)
+	return f.createStatus(errorCodeNotImplemented, &msg[0])
+}
+`
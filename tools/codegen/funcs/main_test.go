@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const apiDir = "../../../onnxruntime/internal/api"
+
+// TestGeneratedFuncsUpToDate fails if a generated funcs.go differs from what
+// the generator makes of the current source, so that changes to the newest
+// version's funcs.go are carried over with go generate.
+func TestGeneratedFuncsUpToDate(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join(apiDir, "v*", "funcs.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated := 0
+	for _, path := range paths {
+		current, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(current, []byte("// Code generated by tools/codegen/funcs")) {
+			continue
+		}
+		generated++
+		want, err := generate(filepath.Join(apiDir, "v24"), filepath.Dir(path))
+		if err != nil {
+			t.Fatalf("generate(%s) error = %v", filepath.Dir(path), err)
+		}
+		if !bytes.Equal(current, want) {
+			t.Errorf("%s is out of date; run go generate ./onnxruntime/internal/api/...", path)
+		}
+	}
+	if generated == 0 {
+		t.Error("Expected generated funcs.go files")
+	}
+}
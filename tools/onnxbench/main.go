@@ -0,0 +1,116 @@
+// Command onnxbench benchmarks onnxruntime usage patterns against one or more
+// models and prints a JSON report suitable for regression tracking.
+//
+// Usage:
+//
+//	ONNXRUNTIME_LIB_PATH=/path/to/libonnxruntime.so \
+//	    go run ./tools/onnxbench -iterations 200 -o bench.json model1.onnx model2.onnx
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	ort "github.com/benedoc-inc/onnxer/onnxruntime"
+	"github.com/benedoc-inc/onnxer/onnxruntime/bench"
+)
+
+var (
+	iterations  = flag.Int("iterations", 100, "measured operations per benchmark case")
+	warmup      = flag.Int("warmup", 10, "unmeasured warmup runs per model (negative disables)")
+	poolSize    = flag.Int("pool-size", 4, "number of sessions in the pooled case")
+	concurrency = flag.Int("concurrency", 8, "number of goroutines in the concurrent cases")
+	dims        = flag.String("dims", "", "symbolic input dimensions, e.g. batch_size=8,sequence_length=128")
+	apiVersion  = flag.Uint("api-version", 23, "ONNX Runtime C API version (falls back to the highest the library provides)")
+	output      = flag.String("o", "", "write the JSON report to this file instead of stdout")
+)
+
+// parseDims parses "name=value,name=value" into a map.
+func parseDims(s string) (map[string]int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	result := make(map[string]int64)
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid dimension %q, expected name=value", pair)
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid size for dimension %q: %q", name, value)
+		}
+		result[strings.TrimSpace(name)] = n
+	}
+	return result, nil
+}
+
+func run(ctx context.Context, modelPaths []string) error {
+	libraryPath := os.Getenv("ONNXRUNTIME_LIB_PATH")
+	if libraryPath == "" {
+		return errors.New("ONNXRUNTIME_LIB_PATH environment variable not set")
+	}
+
+	symbolicDims, err := parseDims(*dims)
+	if err != nil {
+		return err
+	}
+
+	runtime, err := ort.NewRuntime(libraryPath, uint32(*apiVersion))
+	if err != nil {
+		return fmt.Errorf("failed to create runtime: %w", err)
+	}
+	defer runtime.Close()
+
+	env, err := runtime.NewEnv("onnxbench", ort.LoggingLevelWarning)
+	if err != nil {
+		return fmt.Errorf("failed to create environment: %w", err)
+	}
+	defer env.Close()
+
+	report, err := bench.Run(ctx, runtime, env, modelPaths, bench.Config{
+		Iterations:  *iterations,
+		Warmup:      *warmup,
+		PoolSize:    *poolSize,
+		Concurrency: *concurrency,
+		Dims:        symbolicDims,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	return report.WriteJSON(out)
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <model.onnx>...\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, flag.Args()); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+}